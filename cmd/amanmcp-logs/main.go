@@ -13,6 +13,7 @@
 //	    --no-color       Disable colored output
 //	    --file string    Custom log file path
 //	    --source string  Log source: go, mlx, or all (default: go)
+//	    --category name  Filter by log category (e.g. query)
 package main
 
 import (
@@ -39,13 +40,14 @@ func main() {
 
 func newRootCmd() *cobra.Command {
 	var (
-		follow  bool
-		lines   int
-		level   string
-		filter  string
-		noColor bool
-		logFile string
-		source  string
+		follow   bool
+		lines    int
+		level    string
+		filter   string
+		noColor  bool
+		logFile  string
+		source   string
+		category string
 	)
 
 	cmd := &cobra.Command{
@@ -69,17 +71,19 @@ Examples:
   amanmcp-logs -n 100             # Show last 100 lines
   amanmcp-logs -f                 # Follow logs in real-time
   amanmcp-logs --level error      # Show only error logs
-  amanmcp-logs --filter "search"  # Filter by pattern`,
+  amanmcp-logs --filter "search"  # Filter by pattern
+  amanmcp-logs --category query   # Show search queries (needs --debug)`,
 		Version: version.Version,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runLogs(cmd.Context(), logsOptions{
-				follow:  follow,
-				lines:   lines,
-				level:   level,
-				filter:  filter,
-				noColor: noColor,
-				logFile: logFile,
-				source:  source,
+				follow:   follow,
+				lines:    lines,
+				level:    level,
+				filter:   filter,
+				noColor:  noColor,
+				logFile:  logFile,
+				source:   source,
+				category: category,
 			})
 		},
 	}
@@ -91,18 +95,20 @@ Examples:
 	cmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
 	cmd.Flags().StringVar(&logFile, "file", "", "Path to log file (overrides --source)")
 	cmd.Flags().StringVar(&source, "source", "go", "Log source: go, mlx, or all")
+	cmd.Flags().StringVar(&category, "category", "", "Filter by log category (e.g. query)")
 
 	return cmd
 }
 
 type logsOptions struct {
-	follow  bool
-	lines   int
-	level   string
-	filter  string
-	noColor bool
-	logFile string
-	source  string
+	follow   bool
+	lines    int
+	level    string
+	filter   string
+	noColor  bool
+	logFile  string
+	source   string
+	category string
 }
 
 func runLogs(ctx context.Context, opts logsOptions) error {
@@ -133,6 +139,7 @@ func runLogs(ctx context.Context, opts logsOptions) error {
 		Pattern:    pattern,
 		NoColor:    opts.noColor,
 		ShowSource: showSource,
+		Category:   opts.category,
 	}, os.Stdout)
 
 	// Show log file paths
@@ -81,6 +81,13 @@ func runCompact(ctx context.Context, path string) error {
 		return fmt.Errorf("no vector index found at %s - run 'amanmcp index' first", vectorPath)
 	}
 
+	// Only the HNSW graph accumulates deleted nodes; the flat store frees
+	// them on delete
+	if cfg, err := config.Load(root); err == nil && cfg.Search.VectorBackend == string(store.VectorBackendFlat) {
+		fmt.Println("The flat vector backend has nothing to compact.")
+		return nil
+	}
+
 	fmt.Println("Compacting vector index...")
 	startTime := time.Now()
 
@@ -110,13 +110,16 @@ func runDaemonStart(ctx context.Context, cmd *cobra.Command, foreground bool) er
 	}
 
 	if foreground {
-		// Initialize logging for daemon (BUG-040: daemon wasn't logging to file)
-		logCfg := logging.DefaultConfig()
-		logCfg.Level = "debug"
-		logCfg.WriteToStderr = true // Also write to stderr in foreground mode
-		if logger, cleanup, err := logging.Setup(logCfg); err == nil {
-			slog.SetDefault(logger)
-			defer cleanup()
+		// Initialize logging for daemon (BUG-040: daemon wasn't logging to file).
+		// With --debug the root command has already set up the same log file.
+		if !debugMode {
+			logCfg := logging.DefaultConfig()
+			logCfg.Level = "debug"
+			logCfg.WriteToStderr = true // Also write to stderr in foreground mode
+			if logger, cleanup, err := logging.Setup(logCfg); err == nil {
+				slog.SetDefault(logger)
+				defer cleanup()
+			}
 		}
 
 		// Run in foreground
@@ -130,6 +133,7 @@ func runDaemonStart(ctx context.Context, cmd *cobra.Command, foreground bool) er
 			slog.String("socket", cfg.SocketPath),
 			slog.String("log_file", logging.DefaultLogPath()))
 
+		cfg.QueryLog = newQueryLogger()
 		d, err := daemon.NewDaemon(cfg)
 		if err != nil {
 			slog.Error("Failed to create daemon", slog.String("error", err.Error()))
@@ -149,7 +153,14 @@ func runDaemonStart(ctx context.Context, cmd *cobra.Command, foreground bool) er
 	}
 
 	// Create background process
-	bgCmd := exec.Command(execPath, "daemon", "start", "--foreground")
+	args := []string{"daemon", "start", "--foreground"}
+	if debugMode {
+		args = append(args, "--debug")
+	}
+	if redactQueries {
+		args = append(args, "--redact-queries")
+	}
+	bgCmd := exec.Command(execPath, args...)
 	bgCmd.Stdout = nil
 	bgCmd.Stderr = nil
 	bgCmd.Stdin = nil
@@ -239,16 +239,17 @@ func clearIndexData(dataDir string) error {
 	// Files/directories to remove
 	indexFiles := []string{
 		filepath.Join(dataDir, "metadata.db"),
-		filepath.Join(dataDir, "metadata.db-shm"), // SQLite WAL shared memory
-		filepath.Join(dataDir, "metadata.db-wal"), // SQLite WAL journal
-		filepath.Join(dataDir, "bm25.bleve"),      // BM25 index directory (legacy Bleve)
-		filepath.Join(dataDir, "bm25.db"),         // BM25 index file (SQLite FTS5)
-		filepath.Join(dataDir, "bm25.db-wal"),     // SQLite WAL journal
-		filepath.Join(dataDir, "bm25.db-shm"),     // SQLite shared memory
-		filepath.Join(dataDir, "vectors.hnsw"),    // HNSW vector store
-		filepath.Join(dataDir, "graph.db"),        // AmanGraph overlay
-		filepath.Join(dataDir, "graph.db-wal"),    // SQLite WAL journal
-		filepath.Join(dataDir, "graph.db-shm"),    // SQLite shared memory
+		filepath.Join(dataDir, "metadata.db-shm"),  // SQLite WAL shared memory
+		filepath.Join(dataDir, "metadata.db-wal"),  // SQLite WAL journal
+		filepath.Join(dataDir, "bm25.bleve"),       // BM25 index directory (legacy Bleve)
+		filepath.Join(dataDir, "bm25.db"),          // BM25 index file (SQLite FTS5)
+		filepath.Join(dataDir, "bm25.db-wal"),      // SQLite WAL journal
+		filepath.Join(dataDir, "bm25.db-shm"),      // SQLite shared memory
+		filepath.Join(dataDir, "vectors.hnsw"),     // HNSW vector store
+		filepath.Join(dataDir, "vectors.hnsw.wal"), // HNSW vector log
+		filepath.Join(dataDir, "graph.db"),         // AmanGraph overlay
+		filepath.Join(dataDir, "graph.db-wal"),     // SQLite WAL journal
+		filepath.Join(dataDir, "graph.db-shm"),     // SQLite shared memory
 	}
 
 	for _, path := range indexFiles {
@@ -342,7 +343,9 @@ func runIndexWithOptions(ctx context.Context, cmd *cobra.Command, path string, o
 
 	// Initialize metadata store
 	metadataPath := filepath.Join(dataDir, "metadata.db")
-	metadata, err := store.NewSQLiteStore(metadataPath)
+	storeCfg := store.DefaultStoreConfig()
+	storeCfg.CompressContent = cfg.Performance.CompressContent
+	metadata, err := store.NewSQLiteStoreWithConfig(metadataPath, storeCfg)
 	if err != nil {
 		return fmt.Errorf("failed to create metadata store: %w", err)
 	}
@@ -350,7 +353,8 @@ func runIndexWithOptions(ctx context.Context, cmd *cobra.Command, path string, o
 
 	// Initialize BM25 index using factory (SQLite default for concurrent access)
 	bm25BasePath := filepath.Join(dataDir, "bm25")
-	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, store.DefaultBM25Config(), cfg.Search.BM25Backend)
+	bm25Config := store.BM25ConfigWithTermLength(cfg.Search.BM25MinTermLength, cfg.Search.BM25KeepShortTerms)
+	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, bm25Config, cfg.Search.BM25Backend)
 	if err != nil {
 		return fmt.Errorf("failed to create BM25 index: %w", err)
 	}
@@ -416,7 +420,7 @@ func runIndexWithOptions(ctx context.Context, cmd *cobra.Command, path string, o
 	// Initialize vector store with embedder's dimensions
 	dimensions := embedder.Dimensions()
 	vectorCfg := store.DefaultVectorStoreConfig(dimensions)
-	vector, err := store.NewHNSWStore(vectorCfg)
+	vector, err := store.NewVectorStoreWithBackend(vectorCfg, cfg.Search.VectorBackend)
 	if err != nil {
 		return fmt.Errorf("failed to create vector store: %w", err)
 	}
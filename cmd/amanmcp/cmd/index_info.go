@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -125,6 +127,8 @@ func outputIndexInfoJSON(cmd *cobra.Command, info *store.IndexInfo) error {
 			"index_size_bytes": info.IndexSizeBytes,
 			"bm25_size_bytes":  info.BM25SizeBytes,
 			"vector_size_bytes": info.VectorSizeBytes,
+			"embedding_models":  info.EmbeddingModels,
+			"symbols":           info.SymbolCounts,
 		},
 		"timestamps": map[string]interface{}{
 			"created":     info.CreatedAt,
@@ -172,6 +176,43 @@ func outputIndexInfoHuman(cmd *cobra.Command, info *store.IndexInfo) error {
 	fmt.Fprintf(out, "  Vector Size: %s\n", store.FormatBytes(info.VectorSizeBytes))
 	fmt.Fprintln(out)
 
+	if len(info.EmbeddingModels) > 0 {
+		fmt.Fprintln(out, "Embeddings by Model:")
+		models := make([]string, 0, len(info.EmbeddingModels))
+		for model := range info.EmbeddingModels {
+			models = append(models, model)
+		}
+		sort.Strings(models)
+		for _, model := range models {
+			name := model
+			if name == "" {
+				name = "(unknown)"
+			}
+			line := fmt.Sprintf("  %-24s %d", name, info.EmbeddingModels[model])
+			if info.CurrentModel != "" && model != info.CurrentModel {
+				line += " (stale)"
+			}
+			fmt.Fprintln(out, line)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if info.SymbolCounts != nil {
+		fmt.Fprintln(out, "Symbols by Language (last full index):")
+		languages := make([]string, 0, len(info.SymbolCounts))
+		for lang := range info.SymbolCounts {
+			languages = append(languages, lang)
+		}
+		sort.Strings(languages)
+		for _, lang := range languages {
+			fmt.Fprintf(out, "  %-12s %s\n", lang, formatSymbolTypeCounts(info.SymbolCounts[lang]))
+		}
+		if len(languages) == 0 {
+			fmt.Fprintln(out, "  (no code indexed)")
+		}
+		fmt.Fprintln(out)
+	}
+
 	fmt.Fprintln(out, "Timestamps:")
 	fmt.Fprintf(out, "  Created:     %s\n", store.FormatTime(info.CreatedAt))
 	fmt.Fprintf(out, "  Last Update: %s\n", store.FormatTime(info.UpdatedAt))
@@ -199,3 +240,21 @@ func outputIndexInfoHuman(cmd *cobra.Command, info *store.IndexInfo) error {
 
 	return nil
 }
+
+// formatSymbolTypeCounts renders one language's symbol counts as
+// "function=12, method=3", sorted by symbol type.
+func formatSymbolTypeCounts(counts map[store.SymbolType]int) string {
+	if len(counts) == 0 {
+		return "none extracted"
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = fmt.Sprintf("%s=%d", t, counts[store.SymbolType(t)])
+	}
+	return strings.Join(parts, ", ")
+}
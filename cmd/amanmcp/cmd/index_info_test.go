@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
 )
 
 // ============================================================================
@@ -85,3 +87,35 @@ func TestRunIndexInfo_NonexistentPath(t *testing.T) {
 	// Then: should fail (either path error or no index error)
 	require.Error(t, err)
 }
+
+func TestFormatSymbolTypeCounts(t *testing.T) {
+	// Given/When/Then: types are sorted and languages without symbols are called out
+	assert.Equal(t, "function=12, method=3, type=1", formatSymbolTypeCounts(map[store.SymbolType]int{
+		store.SymbolTypeType:     1,
+		store.SymbolTypeMethod:   3,
+		store.SymbolTypeFunction: 12,
+	}))
+	assert.Equal(t, "none extracted", formatSymbolTypeCounts(nil))
+}
+
+func TestOutputIndexInfoHuman_SymbolCounts(t *testing.T) {
+	// Given: index info with symbols for go and none for python
+	cmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	info := &store.IndexInfo{
+		SymbolCounts: store.SymbolCounts{
+			"go":     {store.SymbolTypeFunction: 4},
+			"python": {},
+		},
+	}
+
+	// When: printing index info
+	require.NoError(t, outputIndexInfoHuman(cmd, info))
+
+	// Then: each language is listed with its counts
+	out := buf.String()
+	assert.Contains(t, out, "Symbols by Language")
+	assert.Regexp(t, `go\s+function=4`, out)
+	assert.Regexp(t, `python\s+none extracted`, out)
+}
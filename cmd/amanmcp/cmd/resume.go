@@ -69,5 +69,5 @@ func runResume(cmd *cobra.Command, name, transport string, port int) error {
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Resuming session '%s' for %s\n", name, sess.ProjectPath)
 
 	// Run serve with session
-	return runServeWithSession(cmd.Context(), name, sess.ProjectPath, transport, port)
+	return runServeWithSession(cmd.Context(), name, sess.ProjectPath, transport, port, "")
 }
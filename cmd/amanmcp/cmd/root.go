@@ -28,9 +28,10 @@ var (
 	traceCleanup func()
 )
 
-// Debug logging flag
+// Debug logging flags
 var (
 	debugMode      bool
+	redactQueries  bool
 	loggingCleanup func()
 )
 
@@ -74,6 +75,7 @@ Just run 'amanmcp' in your project directory to get started.`,
 
 	// Debug logging flag
 	cmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Enable debug logging to ~/.amanmcp/logs/")
+	cmd.PersistentFlags().BoolVar(&redactQueries, "redact-queries", false, "Log hashed query text instead of raw queries in debug logs and the recent query history")
 
 	// Setup profiling and logging hooks
 	cmd.PersistentPreRunE = startProfilingAndLogging
@@ -115,6 +117,19 @@ Just run 'amanmcp' in your project directory to get started.`,
 	return cmd
 }
 
+// newQueryLogger returns the query log sink for --debug runs, or nil when
+// debug logging is off. --redact-queries hashes the logged query text.
+func newQueryLogger() *logging.QueryLogger {
+	if !debugMode {
+		return nil
+	}
+	var redact logging.Redactor
+	if redactQueries {
+		redact = logging.HashQuery
+	}
+	return logging.NewQueryLogger(nil, redact)
+}
+
 // startProfilingAndLogging starts CPU/trace profiling and debug logging if flags are set.
 func startProfilingAndLogging(_ *cobra.Command, _ []string) error {
 	var err error
@@ -250,7 +265,7 @@ func runSmartDefault(ctx context.Context, cmd *cobra.Command, offline, reindex,
 	}
 
 	// Start MCP server directly - NO stdout output before this point
-	return runServe(ctx, "stdio", 0)
+	return runServe(ctx, "stdio", 0, "")
 }
 
 // fileExists checks if a file exists.
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -29,8 +30,10 @@ type searchOptions struct {
 	scopes   []string // path prefixes for filtering
 	profile  string   // retrieval profile
 	bm25Only bool     // FEAT-DIM1: skip semantic search, use BM25 only
+	boolean  bool     // Parse AND/OR/NOT operators for the BM25 stage
 	local    bool     // Force local search (bypass daemon)
 	explain  bool     // FEAT-UNIX3: show search decision process
+	blame    bool     // Show last commit author/date for top results
 }
 
 func newSearchCmd() *cobra.Command {
@@ -50,7 +53,8 @@ Examples:
   amanmcp search "setup instructions" --type docs
   amanmcp search "ADR-039" --profile project-memory
   amanmcp search "review memo" --profile review-corpus
-  amanmcp search "error handling" --format json`,
+  amanmcp search "error handling" --format json
+  amanmcp search "error AND handler NOT test" --boolean --bm25-only`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			query := strings.Join(args, " ")
@@ -65,8 +69,10 @@ Examples:
 	cmd.Flags().StringSliceVarP(&opts.scopes, "scope", "s", nil, "Filter by path scope (repeatable, e.g., --scope services/api)")
 	cmd.Flags().StringVar(&opts.profile, "profile", "", "Retrieval profile: code, project-memory, review-corpus, archive")
 	cmd.Flags().BoolVar(&opts.bm25Only, "bm25-only", false, "Use keyword search only (skip semantic search)")
+	cmd.Flags().BoolVar(&opts.boolean, "boolean", false, "Treat AND, OR, NOT and parentheses as boolean operators for keyword matching")
 	cmd.Flags().BoolVar(&opts.local, "local", false, "Force local search (bypass daemon)")
 	cmd.Flags().BoolVar(&opts.explain, "explain", false, "Show search decision process (BM25/vector results, weights, RRF fusion)")
+	cmd.Flags().BoolVar(&opts.blame, "blame", false, "Show the last commit author and date for top results (searches locally)")
 
 	return cmd
 }
@@ -100,10 +106,10 @@ func runSearch(ctx context.Context, cmd *cobra.Command, query string, opts searc
 	}
 
 	// Try daemon-based search first (fast, keeps embedder loaded)
-	// Skip daemon if --local flag is set
+	// Skip daemon if --local flag is set; --blame also needs the local engine
 	daemonCfg := daemon.DefaultConfig()
 	client := daemon.NewClient(daemonCfg)
-	if !opts.local && client.IsRunning() {
+	if !opts.local && !opts.blame && client.IsRunning() {
 		slog.Info("search_using_daemon")
 		response, err := client.SearchDetailed(ctx, daemon.SearchParams{
 			Query:    query,
@@ -114,6 +120,7 @@ func runSearch(ctx context.Context, cmd *cobra.Command, query string, opts searc
 			Scopes:   opts.scopes,
 			Profile:  opts.profile,
 			BM25Only: opts.bm25Only,
+			Boolean:  opts.boolean,
 			Explain:  opts.explain, // FEAT-UNIX3
 		})
 		if err != nil {
@@ -153,7 +160,7 @@ func runLocalSearch(ctx context.Context, cmd *cobra.Command, root, query string,
 
 	// Use factory for BM25 backend selection (SQLite default for concurrent access)
 	bm25BasePath := filepath.Join(dataDir, "bm25")
-	bm25Config := store.DefaultBM25Config()
+	bm25Config := store.BM25ConfigWithTermLength(cfg.Search.BM25MinTermLength, cfg.Search.BM25KeepShortTerms)
 	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, bm25Config, cfg.Search.BM25Backend)
 	if err != nil {
 		return fmt.Errorf("failed to open BM25 index: %w", err)
@@ -199,7 +206,7 @@ func runLocalSearch(ctx context.Context, cmd *cobra.Command, root, query string,
 	}
 	defer func() { _ = embedder.Close() }()
 	vectorConfig := store.DefaultVectorStoreConfig(dimensions)
-	vector, err := store.NewHNSWStore(vectorConfig)
+	vector, err := store.NewVectorStoreWithBackend(vectorConfig, cfg.Search.VectorBackend)
 	if err != nil {
 		return fmt.Errorf("failed to create vector store: %w", err)
 	}
@@ -212,28 +219,22 @@ func runLocalSearch(ctx context.Context, cmd *cobra.Command, root, query string,
 		}
 	}
 
-	// Create search engine with defaults
-	engineConfig := search.DefaultConfig()
-	if cfg.Search.MaxResults > 0 {
-		engineConfig.DefaultLimit = cfg.Search.MaxResults
-	}
-	engineConfig.MetadataRules = cfg.SearchMetadataRules()
-	engineConfig.ProfileRules = cfg.SearchProfileRules()
-	if cfg.Search.BM25Weight > 0 || cfg.Search.SemanticWeight > 0 {
-		engineConfig.DefaultWeights = search.Weights{
-			BM25:     cfg.Search.BM25Weight,
-			Semantic: cfg.Search.SemanticWeight,
-		}
-	}
-	engineConfig.RerankerPolicy = search.RerankerPolicy(cfg.Search.Reranker.Policy)
+	// Create search engine with the same configuration as serve and the daemon
+	engineConfig := cfg.SearchEngineConfig(embedder.ModelName())
 	// FEAT-QI3: Add multi-query decomposition for generic queries
-	engine := search.New(bm25, vector, embedder, metadata, engineConfig,
-		search.WithMultiQuerySearch(search.NewPatternDecomposer()))
+	engine, err := search.NewEngine(bm25, vector, embedder, metadata, engineConfig,
+		search.WithMultiQuerySearch(search.NewPatternDecomposer()),
+		search.WithBlameProvider(search.NewGitBlameProvider(root)),
+		search.WithQueryLogger(newQueryLogger()))
+	if err != nil {
+		return fmt.Errorf("failed to create search engine: %w", err)
+	}
 
 	// Build search options
 	var profileMismatches []search.ProfileMismatch
 	var queryClassification search.QueryClassification
 	var rerankerStatus search.RerankerStatus
+	var diagnostics search.SearchDiagnostics
 	searchOpts := search.SearchOptions{
 		Limit:               opts.limit,
 		Filter:              opts.filter,
@@ -243,8 +244,11 @@ func runLocalSearch(ctx context.Context, cmd *cobra.Command, root, query string,
 		ProfileMismatches:   &profileMismatches,
 		QueryClassification: &queryClassification,
 		RerankerStatus:      &rerankerStatus,
+		Diagnostics:         &diagnostics,
 		BM25Only:            opts.bm25Only,
+		BooleanQuery:        opts.boolean,
 		Explain:             opts.explain, // FEAT-UNIX3
+		IncludeBlame:        opts.blame,
 	}
 
 	// Execute search
@@ -265,6 +269,9 @@ func runLocalSearch(ctx context.Context, cmd *cobra.Command, root, query string,
 			return nil
 		}
 		out.Status("", fmt.Sprintf("No results found for %q", query))
+		if hint := noResultsHint(diagnostics.NoResultsReason); hint != "" {
+			out.Status("", hint)
+		}
 		return nil
 	}
 
@@ -277,6 +284,20 @@ func runLocalSearch(ctx context.Context, cmd *cobra.Command, root, query string,
 	}
 }
 
+// noResultsHint explains which search stage produced zero results.
+func noResultsHint(reason search.NoResultsReason) string {
+	switch reason {
+	case search.NoResultsFiltered:
+		return "Matches were found but all were removed by --type/--language/--scope/--profile; try widening them."
+	case search.NoResultsDimensionMismatch:
+		return "Semantic search is disabled (embedder dimension mismatch) and keyword search found nothing; run 'amanmcp reindex --force'."
+	case search.NoResultsSemanticUnavailable:
+		return "Semantic search is unavailable (embedder error) and keyword search found nothing."
+	default:
+		return ""
+	}
+}
+
 // formatDaemonResults formats search results from daemon.
 func formatDaemonResults(cmd *cobra.Command, out *output.Writer, query string, response daemon.SearchResponse, format string) error {
 	if len(response.Results) == 0 {
@@ -321,7 +342,7 @@ func formatDaemonResults(cmd *cobra.Command, out *output.Writer, query string, r
 
 			// FEAT-UNIX3: Include BM25/Vector ranks in explain mode
 			if hasExplain {
-				out.Statusf("", "%d. %s (score: %.3f)", i+1, location, r.Score)
+				out.Statusf("", "%d. %s (score: %.3f, raw: %.5f)", i+1, location, r.Score, r.RawScore)
 				out.Status("", fmt.Sprintf("      BM25: rank %d (score: %.3f) | Vector: rank %d (score: %.3f)",
 					r.BM25Rank, r.BM25Score, r.VecRank, r.VecScore))
 			} else {
@@ -413,7 +434,7 @@ func formatText(out *output.Writer, query string, results []*search.SearchResult
 
 		// FEAT-UNIX3: Include BM25/Vector ranks in explain mode
 		if results[0].Explain != nil {
-			out.Statusf("", "%d. %s (score: %.3f)", i+1, location, r.Score)
+			out.Statusf("", "%d. %s (score: %.3f, raw: %.5f)", i+1, location, r.Score, r.RawScore)
 			out.Status("", fmt.Sprintf("      BM25: rank %d (score: %.3f) | Vector: rank %d (score: %.3f)",
 				r.BM25Rank, r.BM25Score, r.VecRank, r.VecScore))
 		} else {
@@ -421,6 +442,14 @@ func formatText(out *output.Writer, query string, results []*search.SearchResult
 		}
 
 		// Show snippet (first 3 lines)
+		if r.Blame.Commit != "" {
+			out.Status("", fmt.Sprintf("   Last change: %s, %s (%s)",
+				r.Blame.Author, r.Blame.Date.Format("2006-01-02"), shortCommit(r.Blame.Commit)))
+		}
+		if r.NearDuplicates > 0 {
+			out.Status("", fmt.Sprintf("   %d near-duplicate(s) not shown", r.NearDuplicates))
+		}
+
 		snippet := getSnippet(r.Chunk.Content, 3)
 		for _, line := range snippet {
 			out.Status("", "   "+line)
@@ -466,19 +495,25 @@ func formatExplainHeader(out *output.Writer, explain *search.ExplainData) {
 
 // formatJSON outputs results in JSON format.
 func formatJSON(cmd *cobra.Command, results []*search.SearchResult, mismatches []search.ProfileMismatch) error {
+	type jsonBlame struct {
+		Author string    `json:"author"`
+		Date   time.Time `json:"date"`
+		Commit string    `json:"commit"`
+	}
 	type jsonResult struct {
-		FilePath    string  `json:"file_path"`
-		StartLine   int     `json:"start_line"`
-		EndLine     int     `json:"end_line"`
-		Score       float64 `json:"score"`
-		Content     string  `json:"content"`
-		Language    string  `json:"language,omitempty"`
-		SourceClass string  `json:"source_class,omitempty"`
-		Authority   string  `json:"authority,omitempty"`
-		Profile     string  `json:"profile,omitempty"`
-		SourcePath  string  `json:"source_path,omitempty"`
-		Generated   bool    `json:"generated"`
-		Stale       bool    `json:"stale"`
+		FilePath    string     `json:"file_path"`
+		StartLine   int        `json:"start_line"`
+		EndLine     int        `json:"end_line"`
+		Score       float64    `json:"score"`
+		Content     string     `json:"content"`
+		Language    string     `json:"language,omitempty"`
+		SourceClass string     `json:"source_class,omitempty"`
+		Authority   string     `json:"authority,omitempty"`
+		Profile     string     `json:"profile,omitempty"`
+		SourcePath  string     `json:"source_path,omitempty"`
+		Generated   bool       `json:"generated"`
+		Stale       bool       `json:"stale"`
+		Blame       *jsonBlame `json:"blame,omitempty"`
 	}
 
 	var output []jsonResult
@@ -486,6 +521,10 @@ func formatJSON(cmd *cobra.Command, results []*search.SearchResult, mismatches [
 		if r.Chunk == nil {
 			continue
 		}
+		var blame *jsonBlame
+		if r.Blame.Commit != "" {
+			blame = &jsonBlame{Author: r.Blame.Author, Date: r.Blame.Date, Commit: r.Blame.Commit}
+		}
 		output = append(output, jsonResult{
 			FilePath:    r.Chunk.FilePath,
 			StartLine:   r.Chunk.StartLine,
@@ -499,6 +538,7 @@ func formatJSON(cmd *cobra.Command, results []*search.SearchResult, mismatches [
 			SourcePath:  r.SourceMetadata.SourcePath,
 			Generated:   r.SourceMetadata.Generated,
 			Stale:       r.SourceMetadata.Stale,
+			Blame:       blame,
 		})
 	}
 
@@ -544,3 +584,11 @@ func getSnippet(content string, n int) []string {
 	}
 	return lines
 }
+
+// shortCommit abbreviates a commit hash for display.
+func shortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime/debug"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -30,6 +34,7 @@ import (
 	"github.com/Aman-CERP/amanmcp/internal/secrets"
 	"github.com/Aman-CERP/amanmcp/internal/session"
 	"github.com/Aman-CERP/amanmcp/internal/store"
+	"github.com/Aman-CERP/amanmcp/internal/telemetry"
 	"github.com/Aman-CERP/amanmcp/internal/watcher"
 	"github.com/Aman-CERP/amanmcp/pkg/version"
 )
@@ -67,6 +72,7 @@ func newServeCmd() *cobra.Command {
 	var port int
 	var sessionName string
 	var debug bool
+	var metricsAddr string
 
 	cmd := &cobra.Command{
 		Use:   "serve",
@@ -90,6 +96,9 @@ Debug mode enables verbose logging to ~/.amanmcp/logs/server.log:
 View logs with the amanmcp-logs command:
   amanmcp-logs -f --level DEBUG
 
+Expose query metrics for Prometheus at http://localhost:9464/metrics:
+  amanmcp serve --metrics-addr localhost:9464
+
 Example configuration (.mcp.json in project root):
   {
     "mcpServers": {
@@ -118,9 +127,9 @@ Note: The cwd field is required for Claude Code to start the server in the corre
 				if err != nil {
 					return fmt.Errorf("failed to find project root: %w", err)
 				}
-				return runServeWithSession(cmd.Context(), sessionName, root, transport, port)
+				return runServeWithSession(cmd.Context(), sessionName, root, transport, port, metricsAddr)
 			}
-			return runServe(cmd.Context(), transport, port)
+			return runServe(cmd.Context(), transport, port, metricsAddr)
 		},
 	}
 
@@ -128,6 +137,7 @@ Note: The cwd field is required for Claude Code to start the server in the corre
 	cmd.Flags().IntVar(&port, "port", 8765, "Port for SSE transport")
 	cmd.Flags().StringVar(&sessionName, "session", "", "Named session to create/load")
 	cmd.Flags().BoolVar(&debug, "debug", false, "Enable debug logging to ~/.amanmcp/logs/server.log")
+	cmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus query metrics at /metrics on this address (e.g. localhost:9464); disabled when empty")
 
 	return cmd
 }
@@ -149,7 +159,7 @@ func setupDebugLogging() (func(), error) {
 	return cleanup, nil
 }
 
-func runServe(ctx context.Context, transport string, port int) (err error) {
+func runServe(ctx context.Context, transport string, port int, metricsAddr string) (err error) {
 	// BUG-034: Initialize MCP-safe logging FIRST, before ANYTHING else.
 	// This ensures all logs go to file, never stdout/stderr.
 	// MCP protocol requires stdout to be used exclusively for JSON-RPC.
@@ -232,7 +242,9 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 
 	// Initialize stores
 	slog.Debug("Opening metadata store", slog.String("path", metadataPath))
-	metadata, err := store.NewSQLiteStore(metadataPath)
+	storeCfg := store.DefaultStoreConfig()
+	storeCfg.CompressContent = cfg.Performance.CompressContent
+	metadata, err := store.NewSQLiteStoreWithConfig(metadataPath, storeCfg)
 	if err != nil {
 		return fmt.Errorf("failed to open metadata store: %w", err)
 	}
@@ -250,7 +262,8 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 	// Use factory for BM25 backend selection (SQLite default for concurrent access)
 	bm25BasePath := filepath.Join(dataDir, "bm25")
 	slog.Debug("Opening BM25 index", slog.String("path", bm25BasePath), slog.String("backend", cfg.Search.BM25Backend))
-	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, store.DefaultBM25Config(), cfg.Search.BM25Backend)
+	bm25Config := store.BM25ConfigWithTermLength(cfg.Search.BM25MinTermLength, cfg.Search.BM25KeepShortTerms)
+	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, bm25Config, cfg.Search.BM25Backend)
 	if err != nil {
 		return fmt.Errorf("failed to open BM25 index: %w", err)
 	}
@@ -300,7 +313,7 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 	skipReconciliation := false
 	storedModel, _ := metadata.GetState(ctx, store.StateKeyIndexModel)
 	currentModel := embedder.ModelName()
-	if storedModel != "" && storedModel != currentModel {
+	if storedModel != "" && storedModel != currentModel && !reembedsModelChange(ctx, cfg, metadata, embedder.Dimensions()) {
 		slog.Warn("embedder_mismatch_skipping_reconciliation",
 			slog.String("stored", storedModel),
 			slog.String("current", currentModel),
@@ -310,22 +323,11 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 
 	// Initialize vector store with embedder's dimensions (fixes BUG-001)
 	dimensions := embedder.Dimensions()
-	vectorCfg := store.DefaultVectorStoreConfig(dimensions)
-	vector, err := store.NewHNSWStore(vectorCfg)
+	vector, closeVector, err := openVectorStore(dimensions, vectorPath, cfg.Search.VectorBackend)
 	if err != nil {
-		return fmt.Errorf("failed to create vector store: %w", err)
-	}
-	// Try to load existing vectors
-	if _, err := os.Stat(vectorPath); err == nil {
-		slog.Debug("Loading existing vectors", slog.String("path", vectorPath))
-		if err := vector.Load(vectorPath); err != nil {
-			// Log warning but continue - vector store will be empty
-			slog.Warn("Failed to load vectors, starting with empty store",
-				slog.String("error", err.Error()),
-				slog.String("path", vectorPath))
-		}
+		return err
 	}
-	defer func() { _ = vector.Close() }()
+	defer closeVector()
 
 	// DEBT-021: Check cross-store consistency on startup
 	// Detects orphaned entries and logs warnings without blocking startup
@@ -359,16 +361,7 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 	}
 
 	// Create search engine with query expander (QI-1 Lite)
-	engineCfg := search.EngineConfig{
-		DefaultLimit:   cfg.Search.MaxResults,
-		MaxLimit:       100,
-		DefaultWeights: search.Weights{BM25: cfg.Search.BM25Weight, Semantic: cfg.Search.SemanticWeight},
-		RRFConstant:    cfg.Search.RRFConstant,
-		SearchTimeout:  search.DefaultConfig().SearchTimeout,
-		MetadataRules:  cfg.SearchMetadataRules(),
-		ProfileRules:   cfg.SearchProfileRules(),
-		RerankerPolicy: search.RerankerPolicy(cfg.Search.Reranker.Policy),
-	}
+	engineCfg := cfg.SearchEngineConfig(embedder.ModelName())
 	// QI-1 Lite: Enable code-aware query expansion to bridge vocabulary gap
 	// Research: https://arxiv.org/html/2408.11058v1 (LLM Agents for Code Search)
 	queryExpander := search.NewQueryExpander()
@@ -376,6 +369,8 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 	// Build engine options
 	engineOpts := []search.EngineOption{
 		search.WithQueryExpander(queryExpander),
+		search.WithBlameProvider(search.NewGitBlameProvider(root)),
+		search.WithQueryLogger(newQueryLogger()),
 	}
 	// FEAT-RR1: Add reranker if available
 	if reranker != nil {
@@ -383,12 +378,19 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 	}
 	// FEAT-QI3: Add multi-query decomposition for generic queries
 	engineOpts = append(engineOpts, search.WithMultiQuerySearch(search.NewPatternDecomposer()))
+	// Query metrics are only collected when they can be scraped
+	metrics := newServeMetrics(metricsAddr)
+	if metrics != nil {
+		defer func() { _ = metrics.Close() }()
+		engineOpts = append(engineOpts, search.WithMetrics(metrics))
+	}
 
 	engine, err := search.NewEngine(bm25, vector, embedder, metadata, engineCfg, engineOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create search engine: %w", err)
 	}
 	defer func() { _ = engine.Close() }()
+	startModelChangeReembed(ctx, engine)
 
 	// Create MCP server with embedder for capability signaling
 	slog.Debug("Creating MCP server")
@@ -399,11 +401,20 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 	defer func() { _ = srv.Close() }()
 	closeGraphRepo := attachGraphRepository(srv, dataDir, cfg)
 	defer closeGraphRepo()
+	if metrics != nil {
+		srv.SetMetrics(metrics)
+	}
 
 	// Handle graceful shutdown (DEBT-015: added SIGHUP for terminal close)
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 	defer cancel()
 
+	if metrics != nil {
+		if err := startMetricsServer(ctx, metricsAddr, metrics); err != nil {
+			return err
+		}
+	}
+
 	// BUG-035: Start file watcher in background to avoid blocking MCP handshake.
 	// MCP protocol requires handshake response within 500ms. File watcher startup
 	// can take 2+ seconds on slow filesystems. Make it non-blocking so the MCP
@@ -413,7 +424,16 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 	excludePatterns := append(cfg.Paths.Exclude, "**/.amanmcp/**")
 	go func() {
 		slog.Debug("Starting file watcher in background", slog.String("root", root))
-		if err := startFileWatcher(ctx, root, dataDir, engine, metadata, skipReconciliation, excludePatterns, cfg.Search.Languages); err != nil {
+		if err := startFileWatcher(ctx, fileWatcherOptions{
+			srv:                srv,
+			root:               root,
+			dataDir:            dataDir,
+			engine:             engine,
+			metadata:           metadata,
+			skipReconciliation: skipReconciliation,
+			excludePatterns:    excludePatterns,
+			cfg:                cfg,
+		}); err != nil {
 			// Log but don't crash - server can still serve search without live updates
 			slog.Error("File watcher failed to start (non-fatal, search still works)",
 				slog.String("error", err.Error()),
@@ -431,37 +451,125 @@ func runServe(ctx context.Context, transport string, port int) (err error) {
 	return srv.Serve(ctx, transport, addr)
 }
 
+// newServeMetrics returns an in-memory query metrics collector when
+// metricsAddr is set, nil otherwise. --redact-queries hashes the query text
+// kept in its recent query history.
+func newServeMetrics(metricsAddr string) *telemetry.QueryMetrics {
+	if metricsAddr == "" {
+		return nil
+	}
+	cfg := telemetry.DefaultQueryMetricsConfig()
+	if redactQueries {
+		cfg.RedactQuery = logging.HashQuery
+	}
+	return telemetry.NewQueryMetricsWithConfig(nil, cfg)
+}
+
+// startMetricsServer serves metrics in the Prometheus text format at
+// /metrics on addr until ctx is done. It fails if addr cannot be bound, so
+// a misconfigured scrape target surfaces at startup.
+func startMetricsServer(ctx context.Context, addr string, metrics *telemetry.QueryMetrics) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for metrics on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", telemetry.PrometheusHandler(metrics))
+	metricsSrv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		_ = metricsSrv.Close()
+	}()
+	go func() {
+		if err := metricsSrv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Warn("metrics_server_failed", slog.String("error", err.Error()))
+		}
+	}()
+
+	slog.Info("metrics_server_started", slog.String("addr", ln.Addr().String()))
+	return nil
+}
+
+// reconcileStatus converts coordinator reconciliation progress for index_status.
+func reconcileStatus(p index.ReconcileProgress) mcp.ReconcileStatus {
+	return mcp.ReconcileStatus{
+		PriorityTotal:    p.PriorityTotal,
+		PriorityDone:     p.PriorityDone,
+		BackgroundTotal:  p.BackgroundTotal,
+		BackgroundDone:   p.BackgroundDone,
+		PriorityComplete: p.PriorityComplete(),
+	}
+}
+
+// fileWatcherOptions configures startFileWatcher.
+type fileWatcherOptions struct {
+	srv      *mcp.Server // reports reconciliation progress in index_status
+	root     string
+	dataDir  string
+	engine   *search.Engine
+	metadata store.MetadataStore
+	// BUG-054: skipReconciliation prevents adding embeddings from mismatched embedder model.
+	skipReconciliation bool
+	// BUG-027: excludePatterns passed to coordinator for consistent reconciliation behavior.
+	excludePatterns []string
+	// cfg supplies the language, path and chunking settings, which must match
+	// the ones used by 'amanmcp index'.
+	cfg *config.Config
+}
+
 // startFileWatcher creates and starts the file watcher for incremental updates.
 // Uses errgroup for proper goroutine coordination (DEBT-002 fix).
 // Returns error if watcher fails to start within startup timeout (BUG-017 fix).
-// BUG-054: skipReconciliation prevents adding embeddings from mismatched embedder model.
-// BUG-027: excludePatterns passed to coordinator for consistent reconciliation behavior.
-func startFileWatcher(ctx context.Context, root, dataDir string, engine *search.Engine, metadata store.MetadataStore, skipReconciliation bool, excludePatterns []string, languageDefs []language.Definition) error {
-	// Create watcher with default options
-	opts := watcher.Options{
-		DebounceWindow:  200 * time.Millisecond,
-		PollInterval:    5 * time.Second,
-		EventBufferSize: 1000,
-	}.WithDefaults()
+func startFileWatcher(ctx context.Context, o fileWatcherOptions) error {
+	root, dataDir, cfg := o.root, o.dataDir, o.cfg
 
-	w, err := watcher.NewHybridWatcher(opts)
+	idScheme, err := index.ParseChunkIDScheme(cfg.Search.ChunkIDScheme)
 	if err != nil {
-		return fmt.Errorf("failed to create watcher: %w", err)
+		return fmt.Errorf("invalid search.chunk_id_scheme: %w", err)
 	}
 
 	// Create chunkers
-	codeChunker, err := chunk.NewCodeChunkerWithLanguageDefinitions(chunk.CodeChunkerOptions{}, languageDefs)
+	codeChunker, err := chunk.NewCodeChunkerWithLanguageDefinitions(codeChunkerOptions(cfg), cfg.Search.Languages)
 	if err != nil {
 		return fmt.Errorf("failed to create code chunker: %w", err)
 	}
-	mdChunker := chunk.NewMarkdownChunker()
+	mdChunker := chunk.NewMarkdownChunkerWithOptions(chunk.MarkdownChunkerOptions{SplitCodeBlocks: cfg.Search.SplitCodeBlocks})
+	var notebookChunker chunk.Chunker
+	if cfg.Paths.IndexNotebooks {
+		notebookChunker = chunk.NewNotebookChunker(codeChunker, mdChunker)
+	}
 
 	// Create scanner for gitignore reconciliation
 	fileScanner, err := scanner.New()
 	if err != nil {
 		return fmt.Errorf("failed to create scanner: %w", err)
 	}
-	languageRegistry, err := language.NewRegistry(languageDefs)
+
+	// Create watcher with default options, watching only the directories
+	// the scanner would index
+	opts := watcher.Options{
+		DebounceWindow:  200 * time.Millisecond,
+		PollInterval:    5 * time.Second,
+		EventBufferSize: 1000,
+		ScanDirs: func(ctx context.Context, root string) (<-chan string, error) {
+			return fileScanner.ScanDirs(ctx, &scanner.ScanOptions{
+				RootDir:          root,
+				DataDir:          dataDir,
+				RespectGitignore: true,
+				ExcludePatterns:  o.excludePatterns,
+				IncludeHidden:    cfg.Paths.IncludeHidden,
+			})
+		},
+	}.WithDefaults()
+
+	w, err := watcher.NewHybridWatcher(opts)
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	languageRegistry, err := language.NewRegistry(cfg.Search.Languages)
 	if err != nil {
 		return fmt.Errorf("failed to create language registry: %w", err)
 	}
@@ -471,23 +579,40 @@ func startFileWatcher(ctx context.Context, root, dataDir string, engine *search.
 	h := sha256.Sum256([]byte(root))
 	projectID := hex.EncodeToString(h[:])[:16]
 	coordinator := index.NewCoordinator(index.CoordinatorConfig{
-		ProjectID:        projectID,
-		RootPath:         root,
-		DataDir:          dataDir,
-		Engine:           engine,
-		Metadata:         metadata,
-		CodeChunker:      codeChunker,
-		MDChunker:        mdChunker,
-		Scanner:          fileScanner,
-		LanguageRegistry: languageRegistry,
-		GraphRepository:  graphRepo,
-		SecretScanner:    secrets.NewScanner(secrets.DefaultPolicy()),
-		ExcludePatterns:  excludePatterns, // BUG-027: passed from caller
+		ProjectID:             projectID,
+		RootPath:              root,
+		DataDir:               dataDir,
+		Engine:                o.engine,
+		Metadata:              o.metadata,
+		CodeChunker:           codeChunker,
+		MDChunker:             mdChunker,
+		NotebookChunker:       notebookChunker,
+		Scanner:               fileScanner,
+		LanguageRegistry:      languageRegistry,
+		GraphRepository:       graphRepo,
+		SecretScanner:         secrets.NewScanner(secrets.DefaultPolicy()),
+		ExcludePatterns:       o.excludePatterns, // BUG-027: passed from caller
+		FollowSymlinkPaths:    cfg.Paths.FollowSymlinks,
+		IncludeHidden:         cfg.Paths.IncludeHidden,
+		GitignoreHashMaxDepth: cfg.Paths.GitignoreMaxDepth,
+		BinaryDetection:       index.BinaryDetection(cfg.Paths),
+		ContentTypeOverrides:  index.ContentTypeOverrides(cfg.Paths.ContentTypeOverrides),
+		LanguageOverrides:     cfg.Paths.LanguageOverrides,
+		ChunkIDScheme:         idScheme,
+		ReconcileInterval:     getReconcileInterval(),
+		PriorityPaths:         cfg.Paths.Priority,
+		NearDuplicateDistance: cfg.Search.NearDuplicateDistance,
+		Watcher:               w,
+		// Edits to large files re-embed only the chunks that changed
+		ReuseUnchangedEmbeddings: true,
+	})
+	o.srv.SetReconcileProgress(func() mcp.ReconcileStatus {
+		return reconcileStatus(coordinator.ReconcileProgress())
 	})
 
 	// BUG-054: Skip reconciliation if embedder model mismatch detected earlier
 	// This prevents adding embeddings from a different model to an existing index
-	if skipReconciliation {
+	if o.skipReconciliation {
 		slog.Info("startup_reconciliation_skipped",
 			slog.String("reason", "embedder model mismatch"),
 			slog.String("note", "run 'amanmcp index --force' to rebuild with current embedder"))
@@ -562,7 +687,11 @@ func startFileWatcher(ctx context.Context, root, dataDir string, engine *search.
 					return nil
 				}
 				if len(events) > 0 {
-					slog.Debug("Processing file events", slog.Int("count", len(events)))
+					backlog := w.Backpressure()
+					slog.Debug("Processing file events",
+						slog.Int("count", len(events)),
+						slog.Int("queued_batches", backlog.QueuedBatches),
+						slog.Int("overflow_events", backlog.OverflowEvents))
 					if err := coordinator.HandleEvents(gctx, events); err != nil {
 						slog.Error("Failed to process file events", slog.String("error", err.Error()))
 					}
@@ -576,7 +705,14 @@ func startFileWatcher(ctx context.Context, root, dataDir string, engine *search.
 		}
 	})
 
-	if graphRepo != nil && !skipReconciliation {
+	// Periodic file reconciliation catches changes the watcher missed
+	if !o.skipReconciliation {
+		g.Go(func() error {
+			return coordinator.RunPeriodicReconciliation(gctx)
+		})
+	}
+
+	if graphRepo != nil && !o.skipReconciliation {
 		g.Go(func() error {
 			interval := getGraphRefreshInterval()
 			if interval <= 0 {
@@ -629,6 +765,105 @@ func startFileWatcher(ctx context.Context, root, dataDir string, engine *search.
 	return nil
 }
 
+// unreadableVectorsSuffix is appended to the files of a vector snapshot that
+// failed to load when they are moved aside.
+const unreadableVectorsSuffix = ".unreadable"
+
+// openVectorStore creates the vector store for backend (search.vector_backend)
+// and loads the snapshot at vectorPath if one exists. Stores that support it
+// log incremental changes so they survive a restart; the returned func folds
+// the log into the snapshot, or saves the whole snapshot for other stores,
+// and closes the store.
+//
+// A snapshot that fails to load, such as a corrupt one, is moved aside with
+// unreadableVectorsSuffix before the store starts empty, so the save on
+// shutdown cannot overwrite it.
+func openVectorStore(dimensions int, vectorPath, backend string) (store.VectorStore, func(), error) {
+	vectorCfg := store.DefaultVectorStoreConfig(dimensions)
+	vector, err := store.NewVectorStoreWithBackend(vectorCfg, backend)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create vector store: %w", err)
+	}
+	if _, err := os.Stat(vectorPath); err == nil {
+		slog.Debug("Loading existing vectors", slog.String("path", vectorPath))
+		if loadErr := vector.Load(vectorPath); loadErr != nil {
+			// Discard the partially loaded store along with the snapshot
+			_ = vector.Close()
+			if err := moveVectorsAside(vectorPath); err != nil {
+				return nil, nil, fmt.Errorf("failed to load vectors (%v) and to move them aside: %w", loadErr, err)
+			}
+			slog.Warn("Failed to load vectors, starting with empty store",
+				slog.String("error", loadErr.Error()),
+				slog.String("path", vectorPath),
+				slog.String("moved_to", vectorPath+unreadableVectorsSuffix))
+			if vector, err = store.NewVectorStoreWithBackend(vectorCfg, backend); err != nil {
+				return nil, nil, fmt.Errorf("failed to create vector store: %w", err)
+			}
+		}
+	}
+
+	logger, logged := vector.(store.VectorLogger)
+	if logged {
+		// Log incremental vector changes so they survive a restart; the
+		// checkpoint on shutdown folds them into the snapshot
+		if err := logger.EnableLog(vectorPath); err != nil {
+			slog.Warn("Failed to enable vector log, incremental changes will not persist",
+				slog.String("error", err.Error()),
+				slog.String("path", vectorPath))
+		}
+	}
+	return vector, func() {
+		if logged {
+			if err := logger.Checkpoint(); err != nil {
+				slog.Debug("vector checkpoint skipped", slog.String("error", err.Error()))
+			}
+		} else if err := vector.Save(vectorPath); err != nil {
+			slog.Warn("Failed to save vectors", slog.String("error", err.Error()), slog.String("path", vectorPath))
+		}
+		_ = vector.Close()
+	}, nil
+}
+
+// moveVectorsAside renames the snapshot at vectorPath, its metadata and its
+// log, where present, by appending unreadableVectorsSuffix.
+func moveVectorsAside(vectorPath string) error {
+	for _, path := range []string{vectorPath, vectorPath + ".meta", store.HNSWLogPath(vectorPath)} {
+		if err := os.Rename(path, path+unreadableVectorsSuffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// codeChunkerOptions returns the code chunker options configured by
+// search.code_chunk_tokens and search.code_chunk_overlap_lines.
+func codeChunkerOptions(cfg *config.Config) chunk.CodeChunkerOptions {
+	return chunk.CodeChunkerOptions{
+		MaxChunkTokens: cfg.Search.CodeChunkTokens,
+		OverlapLines:   cfg.Search.CodeChunkOverlapLines,
+	}
+}
+
+// reembedsModelChange reports whether the engine converts an index built by
+// another model of the current dimension in the background
+// (embeddings.reembed_on_model_change). Reconciliation can then index with
+// the new model, since the old model's vectors are replaced as well.
+func reembedsModelChange(ctx context.Context, cfg *config.Config, metadata store.MetadataStore, dimensions int) bool {
+	if !cfg.Embeddings.ReembedOnModelChange {
+		return false
+	}
+	storedDim, _ := metadata.GetState(ctx, store.StateKeyIndexDimension)
+	return storedDim == "" || storedDim == strconv.Itoa(dimensions)
+}
+
+// startModelChangeReembed starts, or resumes, re-embedding an index built by
+// a previous embedder model without waiting for a file change to trigger it.
+func startModelChangeReembed(ctx context.Context, engine *search.Engine) {
+	if _, err := engine.ReembedIfModelChanged(ctx); err != nil {
+		slog.Warn("reembed_not_started", slog.String("error", err.Error()))
+	}
+}
+
 func attachGraphRepository(srv *mcp.Server, dataDir string, cfg *config.Config) func() {
 	if srv == nil || dataDir == "" {
 		return func() {}
@@ -695,9 +930,26 @@ func getGraphRefreshInterval() time.Duration {
 	return defaultGraphRefreshInterval
 }
 
+// getReconcileInterval returns the periodic file reconciliation interval.
+// It is disabled (0) unless AMANMCP_RECONCILE_INTERVAL is set to a positive
+// duration such as "10m".
+func getReconcileInterval() time.Duration {
+	v := os.Getenv("AMANMCP_RECONCILE_INTERVAL")
+	if v == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		slog.Warn("Invalid AMANMCP_RECONCILE_INTERVAL, periodic reconciliation disabled",
+			slog.String("value", v))
+		return 0
+	}
+	return d
+}
+
 // runServeWithSession runs the server with session management.
 // It creates or loads the named session and uses the session directory for index data.
-func runServeWithSession(ctx context.Context, sessionName, projectPath, transport string, port int) (err error) {
+func runServeWithSession(ctx context.Context, sessionName, projectPath, transport string, port int, metricsAddr string) (err error) {
 	// BUG-035/BUG-034 addendum: Initialize MCP-safe logging FIRST.
 	// This was a gap in BUG-034 - only runServe() had MCP logging.
 	// Without this, session mode would have stdout contamination.
@@ -812,7 +1064,8 @@ func runServeWithSession(ctx context.Context, sessionName, projectPath, transpor
 
 	// Use factory for BM25 backend selection (SQLite default for concurrent access)
 	bm25BasePath := filepath.Join(dataDir, "bm25")
-	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, store.DefaultBM25Config(), projCfg.Search.BM25Backend)
+	bm25Config := store.BM25ConfigWithTermLength(projCfg.Search.BM25MinTermLength, projCfg.Search.BM25KeepShortTerms)
+	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, bm25Config, projCfg.Search.BM25Backend)
 	if err != nil {
 		return fmt.Errorf("failed to open BM25 index: %w", err)
 	}
@@ -861,7 +1114,7 @@ func runServeWithSession(ctx context.Context, sessionName, projectPath, transpor
 	skipReconciliationSession := false
 	storedModelSession, _ := metadata.GetState(ctx, store.StateKeyIndexModel)
 	currentModelSession := embedder.ModelName()
-	if storedModelSession != "" && storedModelSession != currentModelSession {
+	if storedModelSession != "" && storedModelSession != currentModelSession && !reembedsModelChange(ctx, projCfg, metadata, embedder.Dimensions()) {
 		slog.Warn("embedder_mismatch_skipping_reconciliation",
 			slog.String("stored", storedModelSession),
 			slog.String("current", currentModelSession),
@@ -870,20 +1123,11 @@ func runServeWithSession(ctx context.Context, sessionName, projectPath, transpor
 	}
 
 	dimensions := embedder.Dimensions()
-	vectorCfg := store.DefaultVectorStoreConfig(dimensions)
-	vector, err := store.NewHNSWStore(vectorCfg)
+	vector, closeVector, err := openVectorStore(dimensions, vectorPath, projCfg.Search.VectorBackend)
 	if err != nil {
-		return fmt.Errorf("failed to create vector store: %w", err)
-	}
-	if _, err := os.Stat(vectorPath); err == nil {
-		slog.Debug("Loading existing vectors", slog.String("path", vectorPath))
-		if err := vector.Load(vectorPath); err != nil {
-			slog.Warn("Failed to load vectors, starting with empty store",
-				slog.String("error", err.Error()),
-				slog.String("path", vectorPath))
-		}
+		return err
 	}
-	defer func() { _ = vector.Close() }()
+	defer closeVector()
 
 	// DEBT-021: Check cross-store consistency on startup (session mode)
 	sessionChecker := index.NewConsistencyChecker(metadata, bm25, vector)
@@ -918,22 +1162,15 @@ func runServeWithSession(ctx context.Context, sessionName, projectPath, transpor
 	}
 
 	// Create search engine
-	engineCfg := search.EngineConfig{
-		DefaultLimit:   projCfg.Search.MaxResults,
-		MaxLimit:       100,
-		DefaultWeights: search.Weights{BM25: projCfg.Search.BM25Weight, Semantic: projCfg.Search.SemanticWeight},
-		RRFConstant:    projCfg.Search.RRFConstant,
-		SearchTimeout:  search.DefaultConfig().SearchTimeout,
-		MetadataRules:  projCfg.SearchMetadataRules(),
-		ProfileRules:   projCfg.SearchProfileRules(),
-		RerankerPolicy: search.RerankerPolicy(projCfg.Search.Reranker.Policy),
-	}
+	engineCfg := projCfg.SearchEngineConfig(embedder.ModelName())
 	// QI-1 Lite: Enable code-aware query expansion to bridge vocabulary gap
 	queryExpander := search.NewQueryExpander()
 
 	// Build engine options (session mode)
 	engineOptsSession := []search.EngineOption{
 		search.WithQueryExpander(queryExpander),
+		search.WithBlameProvider(search.NewGitBlameProvider(projectPath)),
+		search.WithQueryLogger(newQueryLogger()),
 	}
 	// FEAT-RR1: Add reranker if available
 	if rerankerSession != nil {
@@ -941,12 +1178,18 @@ func runServeWithSession(ctx context.Context, sessionName, projectPath, transpor
 	}
 	// FEAT-QI3: Add multi-query decomposition for generic queries
 	engineOptsSession = append(engineOptsSession, search.WithMultiQuerySearch(search.NewPatternDecomposer()))
+	metrics := newServeMetrics(metricsAddr)
+	if metrics != nil {
+		defer func() { _ = metrics.Close() }()
+		engineOptsSession = append(engineOptsSession, search.WithMetrics(metrics))
+	}
 
 	engine, err := search.NewEngine(bm25, vector, embedder, metadata, engineCfg, engineOptsSession...)
 	if err != nil {
 		return fmt.Errorf("failed to create search engine: %w", err)
 	}
 	defer func() { _ = engine.Close() }()
+	startModelChangeReembed(ctx, engine)
 
 	// Create MCP server
 	srv, err := mcp.NewServer(engine, metadata, embedder, projCfg, projectPath)
@@ -956,11 +1199,20 @@ func runServeWithSession(ctx context.Context, sessionName, projectPath, transpor
 	defer func() { _ = srv.Close() }()
 	closeGraphRepo := attachGraphRepository(srv, dataDir, projCfg)
 	defer closeGraphRepo()
+	if metrics != nil {
+		srv.SetMetrics(metrics)
+	}
 
 	// Handle graceful shutdown with session save (DEBT-015: added SIGHUP for terminal close)
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 	defer cancel()
 
+	if metrics != nil {
+		if err := startMetricsServer(ctx, metricsAddr, metrics); err != nil {
+			return err
+		}
+	}
+
 	// Save session on shutdown if auto_save is enabled
 	if cfg.Sessions.AutoSave {
 		defer func() {
@@ -981,7 +1233,16 @@ func runServeWithSession(ctx context.Context, sessionName, projectPath, transpor
 		slog.Debug("Starting file watcher in background (session mode)",
 			slog.String("root", projectPath),
 			slog.String("session", sessionName))
-		if err := startFileWatcher(ctx, projectPath, dataDir, engine, metadata, skipReconciliationSession, sessionExcludePatterns, projCfg.Search.Languages); err != nil {
+		if err := startFileWatcher(ctx, fileWatcherOptions{
+			srv:                srv,
+			root:               projectPath,
+			dataDir:            dataDir,
+			engine:             engine,
+			metadata:           metadata,
+			skipReconciliation: skipReconciliationSession,
+			excludePatterns:    sessionExcludePatterns,
+			cfg:                projCfg,
+		}); err != nil {
 			slog.Error("File watcher failed to start (non-fatal, search still works)",
 				slog.String("error", err.Error()),
 				slog.String("root", projectPath))
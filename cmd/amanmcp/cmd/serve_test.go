@@ -56,7 +56,7 @@ func TestServe_FileWatcherDoesNotBlockStartup(t *testing.T) {
 		defer func() { _ = os.Chdir(oldDir) }()
 
 		// Run serve (will block on stdin, but we just want to measure startup time)
-		errCh <- runServe(ctx, "stdio", 0)
+		errCh <- runServe(ctx, "stdio", 0, "")
 	}()
 
 	// Give it a moment to start
@@ -214,3 +214,67 @@ func TestServeCmd_HasSessionFlag(t *testing.T) {
 	assert.NotNil(t, flag, "Serve should have --session flag")
 	assert.Equal(t, "", flag.DefValue)
 }
+
+func TestOpenVectorStore_KeepsUnreadableSnapshot(t *testing.T) {
+	// Given: a corrupt vector snapshot on disk
+	vectorPath := filepath.Join(t.TempDir(), "vectors.hnsw")
+	corrupt := []byte("not an hnsw snapshot")
+	require.NoError(t, os.WriteFile(vectorPath, corrupt, 0o644))
+	require.NoError(t, os.WriteFile(vectorPath+".meta", corrupt, 0o644))
+
+	// When: opening the store, adding a vector and shutting down
+	vector, closeVector, err := openVectorStore(4, vectorPath, "")
+	require.NoError(t, err)
+	require.NoError(t, vector.Add(context.Background(), []string{"a"}, [][]float32{{1, 0, 0, 0}}))
+	closeVector()
+
+	// Then: the corrupt snapshot was moved aside intact
+	for _, path := range []string{vectorPath, vectorPath + ".meta"} {
+		content, err := os.ReadFile(path + unreadableVectorsSuffix)
+		require.NoError(t, err)
+		assert.Equal(t, corrupt, content)
+	}
+
+	// And: the new snapshot holds the vectors added since
+	reopened, closeReopened, err := openVectorStore(4, vectorPath, "")
+	require.NoError(t, err)
+	defer closeReopened()
+	assert.Equal(t, 1, reopened.Count())
+}
+
+func TestOpenVectorStore_LoadsExistingSnapshot(t *testing.T) {
+	// Given: a store that was shut down holding a vector
+	vectorPath := filepath.Join(t.TempDir(), "vectors.hnsw")
+	vector, closeVector, err := openVectorStore(4, vectorPath, "")
+	require.NoError(t, err)
+	require.NoError(t, vector.Add(context.Background(), []string{"a"}, [][]float32{{1, 0, 0, 0}}))
+	closeVector()
+
+	// When: opening it again
+	reopened, closeReopened, err := openVectorStore(4, vectorPath, "")
+	require.NoError(t, err)
+	defer closeReopened()
+
+	// Then: the vector is loaded and nothing was moved aside
+	assert.Equal(t, 1, reopened.Count())
+	assert.NoFileExists(t, vectorPath+unreadableVectorsSuffix)
+}
+
+func TestOpenVectorStore_FlatBackendSavesOnShutdown(t *testing.T) {
+	// Given: a flat store that was shut down holding a vector
+	vectorPath := filepath.Join(t.TempDir(), "vectors.hnsw")
+	vector, closeVector, err := openVectorStore(4, vectorPath, "flat")
+	require.NoError(t, err)
+	require.IsType(t, &store.FlatStore{}, vector)
+	require.NoError(t, vector.Add(context.Background(), []string{"a"}, [][]float32{{1, 0, 0, 0}}))
+	closeVector()
+
+	// When: opening it again
+	reopened, closeReopened, err := openVectorStore(4, vectorPath, "flat")
+	require.NoError(t, err)
+	defer closeReopened()
+
+	// Then: the vector was saved without a log
+	assert.Equal(t, 1, reopened.Count())
+	assert.NoFileExists(t, store.HNSWLogPath(vectorPath))
+}
@@ -101,6 +101,17 @@ func collectStatus(ctx context.Context, root, dataDir string) (ui.StatusInfo, er
 		info.TotalFiles = project.FileCount
 		info.TotalChunks = project.ChunkCount
 		info.LastIndexed = project.IndexedAt
+
+		// Language breakdown is best-effort; older indexes may not have it yet
+		if langs, err := metadata.GetProjectLanguages(ctx, projectID); err == nil {
+			for _, lang := range langs {
+				info.Languages = append(info.Languages, ui.LanguageStat{
+					Language: lang.Language,
+					Files:    lang.FileCount,
+					Chunks:   lang.ChunkCount,
+				})
+			}
+		}
 	}
 
 	// Get storage sizes
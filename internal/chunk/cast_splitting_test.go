@@ -46,6 +46,11 @@ func TestCodeChunker_RecursiveCASTSplitting_TypeScriptClassSplitsByMethods(t *te
 	assert.Contains(t, names, "method0")
 	assert.Contains(t, names, "method4")
 
+	// Split chunks keep their class in the enclosing scope
+	for _, c := range chunks {
+		assert.True(t, strings.HasPrefix(c.EnclosingSymbol, "LargeService › method"), c.EnclosingSymbol)
+	}
+
 	for _, c := range chunks {
 		if strings.Contains(c.RawContent, "method0") {
 			assert.NotContains(t, c.RawContent, "method4")
@@ -231,3 +236,69 @@ func collectSymbolNames(chunks []*Chunk) []string {
 	}
 	return names
 }
+
+func TestCodeChunker_OverlapLines_ExtendsSplitChildrenUpwards(t *testing.T) {
+	methods := make([]string, 0, 4)
+	for i := 0; i < 4; i++ {
+		methods = append(methods, fmt.Sprintf(`
+    def step_%d(self):
+        payload = "%s"
+        return len(payload)
+`, i, strings.Repeat("p", 280)))
+	}
+	source := "class Pipeline:\n" + strings.Join(methods, "\n")
+	input := &FileInput{Path: "pipeline.py", Content: []byte(source), Language: "python"}
+
+	// Given: the same oversized class chunked without and with overlap
+	plain := NewCodeChunkerWithOptions(CodeChunkerOptions{MaxChunkTokens: 120})
+	defer plain.Close()
+	overlapping := NewCodeChunkerWithOptions(CodeChunkerOptions{MaxChunkTokens: 120, OverlapLines: 2})
+	defer overlapping.Close()
+
+	// When: chunking
+	base, err := plain.Chunk(context.Background(), input)
+	require.NoError(t, err)
+	chunks, err := overlapping.Chunk(context.Background(), input)
+	require.NoError(t, err)
+
+	// Then: the first chunk is unchanged and later ones start two lines
+	// earlier, carrying the tail of the previous method
+	require.Len(t, chunks, len(base))
+	require.Greater(t, len(chunks), 1)
+	assert.Equal(t, base[0].StartLine, chunks[0].StartLine)
+	assert.NotContains(t, base[0].Metadata, "overlap_lines")
+	sourceLines := strings.Split(source, "\n")
+	for i := 1; i < len(chunks); i++ {
+		assert.Equal(t, base[i].StartLine-2, chunks[i].StartLine)
+		assert.Equal(t, base[i].EndLine, chunks[i].EndLine)
+		assert.Equal(t, "2", chunks[i].Metadata["overlap_lines"])
+		assert.Contains(t, chunks[i].RawContent, "return len(payload)")
+		assert.True(t, strings.HasPrefix(chunks[i].RawContent, sourceLines[chunks[i].StartLine-1]))
+		assert.Equal(t, base[i].Symbols[0].StartLine, chunks[i].Symbols[0].StartLine)
+	}
+}
+
+func TestCodeChunker_OverlapLines_SetsLineWindowStep(t *testing.T) {
+	lines := make([]string, 0, 160)
+	for i := 0; i < 160; i++ {
+		lines = append(lines, fmt.Sprintf("\tfmt.Println(%q)", strings.Repeat("g", 80)))
+	}
+	source := "package main\n\nimport \"fmt\"\n\nfunc LargeFlatFunction() {\n" + strings.Join(lines, "\n") + "\n}\n"
+	chunker := NewCodeChunkerWithOptions(CodeChunkerOptions{MaxChunkTokens: 180, OverlapLines: 5})
+	defer chunker.Close()
+
+	// When: a function without nested symbols is split into line windows
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{
+		Path:     "flat.go",
+		Content:  []byte(source),
+		Language: "go",
+	})
+
+	// Then: consecutive windows share exactly five lines
+	require.NoError(t, err)
+	require.Greater(t, len(chunks), 2)
+	for i := 1; i < len(chunks); i++ {
+		assert.Equal(t, chunks[i-1].EndLine-4, chunks[i].StartLine)
+		assert.NotContains(t, chunks[i].Metadata, "overlap_lines")
+	}
+}
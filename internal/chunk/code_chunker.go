@@ -5,9 +5,11 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Aman-CERP/amanmcp/internal/embed"
 	"github.com/Aman-CERP/amanmcp/internal/language"
 )
 
@@ -15,6 +17,15 @@ import (
 type CodeChunkerOptions struct {
 	MaxChunkTokens int // Maximum tokens per chunk (default: DefaultMaxChunkTokens)
 	OverlapTokens  int // Overlap between chunks when splitting (default: DefaultOverlapTokens)
+
+	// OverlapLines is the number of source lines that consecutive chunks
+	// split from the same symbol share. Line windows step back by exactly
+	// this many lines, and chunks of nested symbols are extended upwards by
+	// up to this many lines, never past the start of the split symbol.
+	// 0 (default) keeps the token-derived line-window overlap and does not
+	// overlap nested symbol chunks. Changes chunk boundaries, so existing
+	// indexes need a reindex.
+	OverlapLines int
 }
 
 // CodeChunker implements AST-aware code chunking using tree-sitter
@@ -286,14 +297,25 @@ func (c *CodeChunker) createChunksFromNode(info *symbolNodeInfo, tree *Tree, fil
 
 	tokens := estimateTokens(rawContentWithDoc)
 
+	var chunks []*Chunk
 	if tokens <= c.options.MaxChunkTokens {
 		// Small enough to be a single chunk
-		chunk := c.createChunk(file, rawContentWithDoc, fileContext, info.symbol, config, now)
-		return []*Chunk{chunk}
+		chunks = []*Chunk{c.createChunk(file, rawContentWithDoc, fileContext, info.symbol, config, now)}
+	} else {
+		// Need to split large symbol
+		chunks = c.splitLargeSymbol(info, tree, file, fileContext, config, now)
+		c.overlapSplitChunks(chunks, tree.Source, int(node.StartPoint.Row)+1)
+	}
+
+	// Go methods are declared at top level; scope them by their receiver type
+	if config.Name == "go" {
+		if receiver := c.extractor.extractGoReceiverType(node, tree.Source); receiver != "" {
+			for _, chunk := range chunks {
+				chunk.EnclosingSymbol = receiver + ScopeSeparator + chunk.EnclosingSymbol
+			}
+		}
 	}
-
-	// Need to split large symbol
-	return c.splitLargeSymbol(info, tree, file, fileContext, config, now)
+	return chunks
 }
 
 // getRawContentWithDocComment gets raw content including doc comment
@@ -338,6 +360,9 @@ func (c *CodeChunker) splitSymbolRecursive(info *symbolNodeInfo, ancestors []*Sy
 		reason = "max_ast_split_depth"
 	}
 	chunks := c.splitByLines(content, info.symbol, file, fileContext, config, now, int(node.StartPoint.Row)+1, reason)
+	for _, chunk := range chunks {
+		chunk.EnclosingSymbol = scopePath(ancestors, info.symbol)
+	}
 	if len(ancestors) > 0 {
 		c.placeParentSymbolsOnce(chunks, ancestors...)
 		for _, chunk := range chunks {
@@ -369,7 +394,9 @@ func (c *CodeChunker) splitBySemanticChildren(parent *symbolNodeInfo, ancestors
 		if estimateTokens(rawContent) > c.options.MaxChunkTokens {
 			produced = c.splitSymbolRecursive(child, parentSymbols, tree, file, fileContext, config, now, depth+1)
 		} else {
-			produced = []*Chunk{c.createSplitChunk(file, rawContent, fileContext, child.symbol, parent.symbol, config, now, false)}
+			chunk := c.createSplitChunk(file, rawContent, fileContext, child.symbol, parent.symbol, config, now, false)
+			chunk.EnclosingSymbol = scopePath(parentSymbols, child.symbol)
+			produced = []*Chunk{chunk}
 		}
 		if !parentPlaced && len(produced) > 0 {
 			c.placeParentSymbolsOnce(produced, parentSymbols...)
@@ -383,6 +410,17 @@ func (c *CodeChunker) splitBySemanticChildren(parent *symbolNodeInfo, ancestors
 	return chunks
 }
 
+// scopePath returns the EnclosingSymbol breadcrumb for symbol nested in
+// ancestors.
+func scopePath(ancestors []*Symbol, symbol *Symbol) string {
+	names := make([]string, 0, len(ancestors)+1)
+	for _, ancestor := range ancestors {
+		names = append(names, ancestor.Name)
+	}
+	names = append(names, symbol.Name)
+	return strings.Join(names, ScopeSeparator)
+}
+
 func appendSymbol(symbols []*Symbol, symbol *Symbol) []*Symbol {
 	out := make([]*Symbol, 0, len(symbols)+1)
 	out = append(out, symbols...)
@@ -427,10 +465,7 @@ func (c *CodeChunker) splitByLines(content string, symbol *Symbol, file *FileInp
 		return []*Chunk{}
 	}
 
-	overlapLines := (c.options.OverlapTokens * TokensPerChar) / 80
-	if overlapLines < 2 {
-		overlapLines = 2
-	}
+	overlapLines := c.lineWindowOverlap()
 
 	var chunks []*Chunk
 	for i := 0; i < len(lines); {
@@ -523,6 +558,47 @@ func (c *CodeChunker) splitByLines(content string, symbol *Symbol, file *FileInp
 	return chunks
 }
 
+// lineWindowOverlap returns how many lines consecutive line windows share.
+func (c *CodeChunker) lineWindowOverlap() int {
+	if c.options.OverlapLines > 0 {
+		return c.options.OverlapLines
+	}
+	overlapLines := (c.options.OverlapTokens * TokensPerChar) / 80
+	if overlapLines < 2 {
+		overlapLines = 2
+	}
+	return overlapLines
+}
+
+// overlapSplitChunks extends each chunk split from a symbol starting at
+// symbolStartLine upwards by up to OverlapLines source lines, so it shares
+// context with the chunk before it. Chunks that already start inside the
+// previous chunk, such as line windows, are left alone. StartLine moves with
+// the added lines; symbols keep their own line ranges.
+func (c *CodeChunker) overlapSplitChunks(chunks []*Chunk, source []byte, symbolStartLine int) {
+	if c.options.OverlapLines <= 0 || len(chunks) < 2 {
+		return
+	}
+
+	lines := strings.Split(string(source), "\n")
+	for i := 1; i < len(chunks); i++ {
+		chunk, prev := chunks[i], chunks[i-1]
+		if chunk.StartLine <= prev.EndLine || chunk.StartLine > len(lines) {
+			continue
+		}
+		start := max(chunk.StartLine-c.options.OverlapLines, symbolStartLine)
+		if start >= chunk.StartLine {
+			continue
+		}
+
+		overlap := strings.Join(lines[start-1:chunk.StartLine-1], "\n")
+		chunk.RawContent = overlap + "\n" + chunk.RawContent
+		chunk.Content = combineContextAndContent(chunk.Context, chunk.RawContent)
+		chunk.Metadata["overlap_lines"] = strconv.Itoa(chunk.StartLine - start)
+		chunk.StartLine = start
+	}
+}
+
 func (c *CodeChunker) splitLongLine(line string, symbol *Symbol, file *FileInput, fileContext string, config *LanguageConfig, now time.Time, lineNumber int, reason string, existingChunks int) []*Chunk {
 	maxChars := c.options.MaxChunkTokens * TokensPerChar
 	if maxChars < 1 {
@@ -594,8 +670,9 @@ func (c *CodeChunker) createChunk(file *FileInput, rawContent, fileContext strin
 			"chunk_provenance":       "ast",
 			"language_config_source": config.ConfigSource,
 		},
-		CreatedAt: now,
-		UpdatedAt: now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		EnclosingSymbol: symbol.Name,
 	}
 }
 
@@ -716,10 +793,7 @@ func (c *CodeChunker) chunkByLines(file *FileInput, configSource string) ([]*Chu
 		return []*Chunk{}, nil
 	}
 
-	overlapLines := (c.options.OverlapTokens * TokensPerChar) / 80
-	if overlapLines < 2 {
-		overlapLines = 2
-	}
+	overlapLines := c.lineWindowOverlap()
 
 	var chunks []*Chunk
 	now := time.Now()
@@ -836,7 +910,7 @@ func generateChunkIDWithDisambiguator(filePath string, content string, disambigu
 
 // estimateTokens estimates the number of tokens in content
 func estimateTokens(content string) int {
-	return len(content) / TokensPerChar
+	return embed.EstimateTokens(content)
 }
 
 // combineContextAndContent combines context and raw content into full content
@@ -394,6 +394,44 @@ func (s *Server) Stop() error {
 	assert.GreaterOrEqual(t, len(methodChunks), 2, "should have 2 method chunks")
 }
 
+func TestCodeChunker_EnclosingSymbol_GoMethodsScopedByReceiver(t *testing.T) {
+	// Given: a Go type with pointer and generic receivers
+	source := `package main
+
+type Server struct{}
+
+func (s *Server) Start() error {
+	return nil
+}
+
+func (c Cache[K]) Get(key K) {}
+
+func Run() {}
+`
+	chunker := NewCodeChunker()
+	defer chunker.Close()
+
+	// When: chunking the file
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{
+		Path:     "server.go",
+		Content:  []byte(source),
+		Language: "go",
+	})
+	require.NoError(t, err)
+
+	// Then: methods are scoped by their receiver type, other symbols by name
+	scopes := make(map[string]string)
+	for _, chunk := range chunks {
+		scopes[chunk.Symbols[0].Name] = chunk.EnclosingSymbol
+	}
+	assert.Equal(t, map[string]string{
+		"Server": "Server",
+		"Start":  "Server › Start",
+		"Get":    "Cache › Get",
+		"Run":    "Run",
+	}, scopes)
+}
+
 func TestCodeChunker_ChunkID_IsUnique(t *testing.T) {
 	source := `package main
 
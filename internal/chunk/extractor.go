@@ -238,6 +238,34 @@ func (e *SymbolExtractor) extractGoName(n *Node, source []byte) string {
 	return ""
 }
 
+// extractGoReceiverType returns the receiver type name of a Go method
+// declaration ("Server" for func (s *Server[T]) Start()), or "" for other
+// nodes.
+func (e *SymbolExtractor) extractGoReceiverType(n *Node, source []byte) string {
+	if n.Type != "method_declaration" {
+		return ""
+	}
+	for _, child := range n.Children {
+		if child.Type == "parameter_list" {
+			return firstTypeIdentifier(child, source)
+		}
+	}
+	return ""
+}
+
+// firstTypeIdentifier returns the first type_identifier under n, depth first.
+func firstTypeIdentifier(n *Node, source []byte) string {
+	for _, child := range n.Children {
+		if child.Type == "type_identifier" {
+			return child.GetContent(source)
+		}
+		if name := firstTypeIdentifier(child, source); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
 func (e *SymbolExtractor) extractTypeScriptName(n *Node, source []byte) string {
 	// Handle lexical_declaration (const/let) and variable_declaration (var)
 	if n.Type == "lexical_declaration" || n.Type == "variable_declaration" {
@@ -16,6 +16,12 @@ import (
 type MarkdownChunkerOptions struct {
 	MaxChunkTokens int // Maximum tokens per chunk (default: DefaultMaxChunkTokens)
 	OverlapTokens  int // Overlap between chunks when splitting (default: DefaultOverlapTokens)
+
+	// SplitCodeBlocks emits fenced code blocks that name a language as
+	// separate code chunks tagged with that language; the surrounding prose
+	// stays markdown. Changes chunk boundaries, so existing indexes need a
+	// reindex. Default: false.
+	SplitCodeBlocks bool
 }
 
 // MarkdownChunker implements header-based Markdown chunking
@@ -101,7 +107,7 @@ func (c *MarkdownChunker) Chunk(ctx context.Context, file *FileInput) ([]*Chunk,
 		// No headers - chunk by paragraphs
 		paragraphChunks := c.chunkByParagraphs(file, remainingContent, "", 1, frontmatterMetadata, now)
 		chunks = append(chunks, paragraphChunks...)
-		return chunks, nil
+		return c.splitAllCodeBlocks(file, chunks), nil
 	}
 
 	// Calculate base line offset (after frontmatter)
@@ -117,7 +123,24 @@ func (c *MarkdownChunker) Chunk(ctx context.Context, file *FileInput) ([]*Chunk,
 		chunks = append(chunks, sectionChunks...)
 	}
 
-	return chunks, nil
+	return c.splitAllCodeBlocks(file, chunks), nil
+}
+
+// splitAllCodeBlocks applies splitCodeBlocks to every content chunk when
+// SplitCodeBlocks is set.
+func (c *MarkdownChunker) splitAllCodeBlocks(file *FileInput, chunks []*Chunk) []*Chunk {
+	if !c.options.SplitCodeBlocks {
+		return chunks
+	}
+	result := make([]*Chunk, 0, len(chunks))
+	for _, ch := range chunks {
+		if ch.Metadata["type"] == "frontmatter" {
+			result = append(result, ch)
+			continue
+		}
+		result = append(result, c.splitCodeBlocks(file, ch)...)
+	}
+	return result
 }
 
 // section represents a markdown section with header info
@@ -125,6 +148,7 @@ type section struct {
 	headerLevel int
 	headerTitle string
 	headerPath  string
+	breadcrumb  string // Heading path joined by ScopeSeparator, for Chunk.Context
 	content     string
 	startLine   int // Line number within the content (0-indexed)
 }
@@ -169,6 +193,7 @@ func (c *MarkdownChunker) parseSections(content string) []*section {
 				headerLevel: level,
 				headerTitle: title,
 				headerPath:  headerPath,
+				breadcrumb:  strings.Join(pathParts, ScopeSeparator),
 				startLine:   lineNum,
 			}
 			contentBuilder.WriteString(line)
@@ -251,6 +276,7 @@ func (c *MarkdownChunker) createSectionChunks(file *FileInput, sec *section, bas
 			FilePath:    file.Path,
 			Content:     content,
 			RawContent:  content,
+			Context:     sec.breadcrumb,
 			ContentType: ContentTypeMarkdown,
 			Language:    "markdown",
 			StartLine:   startLine,
@@ -549,6 +575,7 @@ func (c *MarkdownChunker) createChunkFromContent(file *FileInput, sec *section,
 		FilePath:    file.Path,
 		Content:     content,
 		RawContent:  content,
+		Context:     sec.breadcrumb,
 		ContentType: ContentTypeMarkdown,
 		Language:    "markdown",
 		StartLine:   startLine,
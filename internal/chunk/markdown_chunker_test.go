@@ -797,3 +797,92 @@ func TestMarkdownChunker_Close(t *testing.T) {
 	// Close should be idempotent (safe to call multiple times)
 	chunker.Close()
 }
+
+func TestMarkdownChunker_SplitCodeBlocks(t *testing.T) {
+	chunker := NewMarkdownChunkerWithOptions(MarkdownChunkerOptions{SplitCodeBlocks: true})
+
+	content := "# Usage\n\nCall the client:\n\n```py\nclient = Client()\nclient.run()\n```\n\nOr from a shell:\n\n```bash title=run.sh\nclient run\n```\n\n```\nplain output\n```\n"
+	file := &FileInput{Path: "docs/usage.md", Content: []byte(content), Language: "markdown"}
+
+	chunks, err := chunker.Chunk(context.Background(), file)
+	require.NoError(t, err)
+	require.Len(t, chunks, 5)
+
+	assert.Equal(t, ContentTypeMarkdown, chunks[0].ContentType)
+	assert.Equal(t, "# Usage\n\nCall the client:", chunks[0].Content)
+	assert.Equal(t, 1, chunks[0].StartLine)
+	assert.Equal(t, 3, chunks[0].EndLine)
+
+	assert.Equal(t, ContentTypeCode, chunks[1].ContentType)
+	assert.Equal(t, "python", chunks[1].Language)
+	assert.Equal(t, "client = Client()\nclient.run()", chunks[1].Content)
+	assert.Equal(t, 6, chunks[1].StartLine)
+	assert.Equal(t, 7, chunks[1].EndLine)
+	assert.Equal(t, "Usage", chunks[1].Metadata["header_path"])
+	assert.Equal(t, "code_block", chunks[1].Metadata["type"])
+	assert.Equal(t, "py", chunks[1].Metadata["fence_info"])
+
+	assert.Equal(t, ContentTypeMarkdown, chunks[2].ContentType)
+	assert.Equal(t, "Or from a shell:", chunks[2].Content)
+
+	assert.Equal(t, "shell", chunks[3].Language)
+	assert.Equal(t, "client run", chunks[3].Content)
+	assert.Equal(t, 13, chunks[3].StartLine)
+
+	// Fences without a language stay in the prose
+	assert.Equal(t, ContentTypeMarkdown, chunks[4].ContentType)
+	assert.Equal(t, "```\nplain output\n```", chunks[4].Content)
+
+	ids := make(map[string]bool)
+	for _, ch := range chunks {
+		ids[ch.ID] = true
+	}
+	assert.Len(t, ids, len(chunks), "chunk IDs should be unique")
+}
+
+func TestMarkdownChunker_SplitCodeBlocks_Disabled(t *testing.T) {
+	chunker := NewMarkdownChunker()
+
+	content := "# Usage\n\n```go\nfunc main() {}\n```\n"
+	file := &FileInput{Path: "README.md", Content: []byte(content), Language: "markdown"}
+
+	chunks, err := chunker.Chunk(context.Background(), file)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "markdown", chunks[0].Language)
+	assert.Contains(t, chunks[0].Content, "func main() {}")
+}
+
+func TestMarkdownChunker_Chunk_HeadingBreadcrumbContext(t *testing.T) {
+	// Given: nested sections, one too large for a single chunk and one with
+	// a fenced code block
+	chunker := NewMarkdownChunkerWithOptions(MarkdownChunkerOptions{MaxChunkTokens: 40, SplitCodeBlocks: true})
+	content := "# Installation\n\n## macOS\n\n### Homebrew\n\n" +
+		strings.Repeat("Run the installer and follow the prompts on screen.\n\n", 6) +
+		"## Linux\n\nInstall the binary:\n\n```bash\ncurl -sSL example.com/install | sh\n```\n"
+	file := &FileInput{Path: "docs/install.md", Content: []byte(content), Language: "markdown"}
+
+	// When: chunking
+	chunks, err := chunker.Chunk(context.Background(), file)
+	require.NoError(t, err)
+
+	// Then: every chunk's context is the heading path of its section, including
+	// the parts of a split section and code blocks
+	byContext := make(map[string]int)
+	for _, ch := range chunks {
+		switch {
+		case strings.Contains(ch.Content, "follow the prompts"):
+			assert.Equal(t, "Installation › macOS › Homebrew", ch.Context)
+		case strings.Contains(ch.Content, "curl"), strings.Contains(ch.Content, "Install the binary"):
+			assert.Equal(t, "Installation › Linux", ch.Context)
+		}
+		byContext[ch.Context]++
+	}
+	assert.Greater(t, byContext["Installation › macOS › Homebrew"], 1, "large section should be split")
+	assert.Equal(t, 2, byContext["Installation › Linux"], "prose and code block")
+
+	// And: the context is not part of the indexed content
+	for _, ch := range chunks {
+		assert.Equal(t, ch.RawContent, ch.Content)
+	}
+}
@@ -0,0 +1,164 @@
+package chunk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/language"
+)
+
+// fenceOpenPattern matches the opening line of a fenced code block: up to
+// three spaces of indentation, a run of at least three backticks or tildes,
+// and the info string.
+var fenceOpenPattern = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})(.*)$")
+
+// fencedBlock is a fenced code block within a chunk, by line index.
+type fencedBlock struct {
+	open     int    // Line of the opening fence
+	close    int    // Line of the closing fence, or len(lines) when unclosed
+	info     string // Info string after the opening fence
+	language string // Language derived from the info string
+}
+
+// splitCodeBlocks splits a markdown chunk into its prose and its fenced code
+// blocks that declare a language. Code blocks become code chunks tagged with
+// their language and keep the section metadata; the prose between them stays
+// markdown. Chunks without such code blocks are returned unchanged.
+func (c *MarkdownChunker) splitCodeBlocks(file *FileInput, ch *Chunk) []*Chunk {
+	lines := strings.Split(ch.Content, "\n")
+	var blocks []fencedBlock
+	for _, b := range findFencedBlocks(lines) {
+		if b.language != "" {
+			blocks = append(blocks, b)
+		}
+	}
+	if len(blocks) == 0 {
+		return []*Chunk{ch}
+	}
+
+	headerPath := ch.Metadata["header_path"]
+	var chunks []*Chunk
+	addProse := func(from, to int) {
+		from, to = trimBlankLines(lines, from, to)
+		// A section header alone adds nothing: code chunks keep header_path
+		if from >= to || (to-from == 1 && headerPattern.MatchString(lines[from])) {
+			return
+		}
+		content := strings.Join(lines[from:to], "\n")
+		prose := *ch
+		prose.ID = generateChunkIDWithDisambiguator(file.Path, content, fmt.Sprintf("%s:prose:%d", headerPath, len(chunks)))
+		prose.Content = content
+		prose.RawContent = content
+		prose.StartLine = ch.StartLine + from
+		prose.EndLine = ch.StartLine + to - 1
+		prose.Metadata = make(map[string]string, len(ch.Metadata))
+		copyMetadata(prose.Metadata, ch.Metadata)
+		chunks = append(chunks, &prose)
+	}
+
+	next := 0
+	for _, b := range blocks {
+		addProse(next, b.open)
+		next = min(b.close+1, len(lines))
+
+		from, to := trimBlankLines(lines, b.open+1, b.close)
+		if from >= to {
+			continue
+		}
+		content := strings.Join(lines[from:to], "\n")
+		metadata := make(map[string]string, len(ch.Metadata)+2)
+		copyMetadata(metadata, ch.Metadata)
+		metadata["type"] = "code_block"
+		metadata["fence_info"] = b.info
+		chunks = append(chunks, &Chunk{
+			ID:          generateChunkIDWithDisambiguator(file.Path, content, fmt.Sprintf("%s:code:%d", headerPath, len(chunks))),
+			FilePath:    file.Path,
+			Content:     content,
+			RawContent:  content,
+			Context:     ch.Context,
+			ContentType: ContentTypeCode,
+			Language:    b.language,
+			StartLine:   ch.StartLine + from,
+			EndLine:     ch.StartLine + to - 1,
+			Metadata:    metadata,
+			CreatedAt:   ch.CreatedAt,
+			UpdatedAt:   ch.UpdatedAt,
+		})
+	}
+	addProse(next, len(lines))
+
+	return chunks
+}
+
+// findFencedBlocks finds the fenced code blocks in lines. A block is closed
+// by a fence of the same character at least as long as the opening one; an
+// unclosed block runs to the end.
+func findFencedBlocks(lines []string) []fencedBlock {
+	var blocks []fencedBlock
+	for i := 0; i < len(lines); i++ {
+		match := fenceOpenPattern.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+		fence, info := match[1], strings.TrimSpace(match[2])
+		if fence[0] == '`' && strings.Contains(info, "`") {
+			continue // Inline code, not a fence
+		}
+
+		block := fencedBlock{open: i, close: len(lines), info: info, language: fenceLanguage(info)}
+		for j := i + 1; j < len(lines); j++ {
+			candidate := strings.TrimSpace(lines[j])
+			if strings.HasPrefix(candidate, fence) && strings.Trim(candidate, fence[:1]) == "" {
+				block.close = j
+				break
+			}
+		}
+		blocks = append(blocks, block)
+		i = block.close
+	}
+	return blocks
+}
+
+// fenceLanguage returns the language named by a fence info string such as
+// "python", "{.py}" or "bash title=setup.sh". Names and extensions of
+// registered languages resolve to the language's name ("py" is "python");
+// other names are kept as written, lowercased.
+func fenceLanguage(info string) string {
+	fields := strings.Fields(info)
+	if len(fields) == 0 {
+		return ""
+	}
+	name := strings.ToLower(strings.Trim(fields[0], "{}.,"))
+	if i := strings.IndexAny(name, ",{"); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" {
+		return ""
+	}
+
+	registry := language.DefaultRegistry()
+	def, ok := registry.GetByName(name)
+	if !ok {
+		def, ok = registry.GetByExtension("." + name)
+	}
+	if !ok {
+		return name
+	}
+	if def.ScannerLanguage != "" {
+		return def.ScannerLanguage
+	}
+	return def.Name
+}
+
+// trimBlankLines narrows lines[from:to] to exclude leading and trailing
+// blank lines.
+func trimBlankLines(lines []string, from, to int) (int, int) {
+	for from < to && strings.TrimSpace(lines[from]) == "" {
+		from++
+	}
+	for to > from && strings.TrimSpace(lines[to-1]) == "" {
+		to--
+	}
+	return from, to
+}
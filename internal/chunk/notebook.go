@@ -0,0 +1,260 @@
+package chunk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NotebookExtension is the file extension of Jupyter notebooks.
+const NotebookExtension = ".ipynb"
+
+// defaultNotebookLanguage is used when a notebook declares no kernel language.
+const defaultNotebookLanguage = "python"
+
+// NotebookChunker splits Jupyter notebooks (nbformat 4) into chunks.
+//
+// Code cells are chunked by the code chunker using the kernel language and
+// markdown cells by the markdown chunker. Outputs and raw cells are discarded.
+// Chunk line numbers are relative to their cell; the cell is recorded in
+// metadata ("notebook_cell", 1-indexed) so results can point back to it.
+type NotebookChunker struct {
+	code     Chunker
+	markdown Chunker
+	options  NotebookChunkerOptions
+}
+
+// NotebookChunkerOptions configures notebook chunking behavior.
+type NotebookChunkerOptions struct {
+	MaxChunkTokens int // Maximum tokens per code-cell chunk (default: DefaultMaxChunkTokens)
+}
+
+// NewNotebookChunker creates a notebook chunker that delegates cell content
+// to the given code and markdown chunkers.
+func NewNotebookChunker(code, markdown Chunker) *NotebookChunker {
+	return NewNotebookChunkerWithOptions(code, markdown, NotebookChunkerOptions{})
+}
+
+// NewNotebookChunkerWithOptions creates a notebook chunker with custom options.
+func NewNotebookChunkerWithOptions(code, markdown Chunker, opts NotebookChunkerOptions) *NotebookChunker {
+	if opts.MaxChunkTokens == 0 {
+		opts.MaxChunkTokens = DefaultMaxChunkTokens
+	}
+	return &NotebookChunker{code: code, markdown: markdown, options: opts}
+}
+
+// Close releases chunker resources.
+// The delegate chunkers are owned by the caller, so this is a no-op.
+func (c *NotebookChunker) Close() {
+	// No resources to release - delegates are closed by their owner.
+}
+
+// SupportedExtensions returns file extensions this chunker handles.
+func (c *NotebookChunker) SupportedExtensions() []string {
+	return []string{NotebookExtension}
+}
+
+// notebook is the subset of nbformat 4 that the chunker reads.
+type notebook struct {
+	Cells    []notebookCell `json:"cells"`
+	Metadata struct {
+		KernelSpec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+}
+
+type notebookCell struct {
+	CellType string          `json:"cell_type"`
+	Source   json.RawMessage `json:"source"`
+}
+
+// Chunk parses a notebook and chunks its code and markdown cells.
+// Malformed notebooks return no chunks rather than failing the whole file.
+func (c *NotebookChunker) Chunk(ctx context.Context, file *FileInput) ([]*Chunk, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if file == nil || len(file.Content) == 0 {
+		return nil, nil
+	}
+
+	var nb notebook
+	if err := json.Unmarshal(file.Content, &nb); err != nil {
+		return []*Chunk{}, nil
+	}
+
+	kernelLanguage := notebookLanguage(&nb)
+	now := time.Now()
+	var chunks []*Chunk
+
+	for i, cell := range nb.Cells {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		source := cellSource(cell.Source)
+		if strings.TrimSpace(source) == "" {
+			continue
+		}
+		cellNumber := i + 1
+
+		var cellChunks []*Chunk
+		var err error
+		switch cell.CellType {
+		case "code":
+			cellChunks, err = c.chunkCodeCell(ctx, file, source, kernelLanguage, cellNumber, now)
+		case "markdown":
+			cellChunks, err = c.chunkMarkdownCell(ctx, file, source)
+		default:
+			// Raw cells carry no searchable intent.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to chunk notebook cell %d: %w", cellNumber, err)
+		}
+
+		for _, ch := range cellChunks {
+			annotateNotebookChunk(ch, file.Path, cell.CellType, cellNumber)
+		}
+		chunks = append(chunks, cellChunks...)
+	}
+
+	if len(chunks) == 0 {
+		return []*Chunk{}, nil
+	}
+	return chunks, nil
+}
+
+// chunkCodeCell chunks a code cell. A cell is the natural unit of a notebook
+// and is usually small, so cells within MaxChunkTokens are emitted whole with
+// the symbols the code chunker found. Larger cells use the code chunker's
+// chunks, or line windows when it finds no symbols (top-level statements).
+func (c *NotebookChunker) chunkCodeCell(ctx context.Context, file *FileInput, source, lang string, cellNumber int, now time.Time) ([]*Chunk, error) {
+	var symbolChunks []*Chunk
+	if c.code != nil {
+		var err error
+		symbolChunks, err = c.code.Chunk(ctx, &FileInput{
+			Path:     file.Path,
+			Content:  []byte(source),
+			Language: lang,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if estimateTokens(source) > c.options.MaxChunkTokens && len(symbolChunks) > 0 {
+		return symbolChunks, nil
+	}
+
+	chunks := c.cellChunks(file, source, lang, cellNumber, now)
+	if len(chunks) == 1 {
+		var scopes []string
+		for _, sc := range symbolChunks {
+			chunks[0].Symbols = append(chunks[0].Symbols, sc.Symbols...)
+			if sc.EnclosingSymbol != "" {
+				scopes = append(scopes, sc.EnclosingSymbol)
+			}
+		}
+		chunks[0].EnclosingSymbol = strings.Join(scopes, ", ")
+	}
+	return chunks, nil
+}
+
+func (c *NotebookChunker) chunkMarkdownCell(ctx context.Context, file *FileInput, source string) ([]*Chunk, error) {
+	if c.markdown == nil {
+		return nil, nil
+	}
+	return c.markdown.Chunk(ctx, &FileInput{
+		Path:     file.Path,
+		Content:  []byte(source),
+		Language: "markdown",
+	})
+}
+
+// cellChunks emits a code cell as line-window chunks of at most MaxChunkTokens.
+func (c *NotebookChunker) cellChunks(file *FileInput, source, lang string, cellNumber int, now time.Time) []*Chunk {
+	lines := strings.Split(strings.TrimRight(source, "\n"), "\n")
+	maxChars := c.options.MaxChunkTokens * TokensPerChar
+
+	var chunks []*Chunk
+	start := 0
+	size := 0
+	flush := func(end int) {
+		content := strings.Join(lines[start:end], "\n")
+		if strings.TrimSpace(content) != "" {
+			chunks = append(chunks, &Chunk{
+				ID:          generateChunkIDWithDisambiguator(file.Path, content, fmt.Sprintf("cell%d_line%d", cellNumber, start+1)),
+				FilePath:    file.Path,
+				Content:     content,
+				RawContent:  content,
+				ContentType: ContentTypeCode,
+				Language:    lang,
+				StartLine:   start + 1,
+				EndLine:     end,
+				Metadata:    map[string]string{},
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			})
+		}
+		start = end
+		size = 0
+	}
+
+	for i, line := range lines {
+		if size > 0 && size+len(line)+1 > maxChars {
+			flush(i)
+		}
+		size += len(line) + 1
+	}
+	flush(len(lines))
+	return chunks
+}
+
+// annotateNotebookChunk records the source cell and makes the chunk ID unique
+// per cell, since identical cells are common in notebooks.
+func annotateNotebookChunk(ch *Chunk, path, cellType string, cellNumber int) {
+	if ch.Metadata == nil {
+		ch.Metadata = make(map[string]string)
+	}
+	ch.Metadata["chunker"] = "notebook"
+	ch.Metadata["notebook_cell"] = fmt.Sprint(cellNumber)
+	ch.Metadata["cell_type"] = cellType
+	ch.ID = generateChunkIDWithDisambiguator(path, ch.Content, fmt.Sprintf("cell%d_%s", cellNumber, ch.ID))
+}
+
+// notebookLanguage returns the kernel language, falling back to Python.
+func notebookLanguage(nb *notebook) string {
+	lang := nb.Metadata.KernelSpec.Language
+	if lang == "" {
+		lang = nb.Metadata.LanguageInfo.Name
+	}
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		return defaultNotebookLanguage
+	}
+	return lang
+}
+
+// cellSource decodes a cell source, which nbformat allows to be either a
+// string or a list of lines.
+func cellSource(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var lines []string
+	if err := json.Unmarshal(raw, &lines); err == nil {
+		return strings.Join(lines, "")
+	}
+	return ""
+}
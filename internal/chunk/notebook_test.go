@@ -0,0 +1,165 @@
+package chunk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleNotebook = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "metadata": {},
+   "source": ["# Churn analysis\n", "\n", "Load the customer table and fit a model."]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 1,
+   "metadata": {},
+   "outputs": [{"output_type": "stream", "name": "stdout", "text": ["SECRET_OUTPUT_TEXT\n"]}],
+   "source": ["import pandas as pd\n", "df = pd.read_csv(\"customers.csv\")\n", "df.head()"]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 2,
+   "metadata": {},
+   "outputs": [],
+   "source": "def churn_rate(df):\n    return df[\"churned\"].mean()\n"
+  },
+  {
+   "cell_type": "raw",
+   "metadata": {},
+   "source": "raw cell text"
+  },
+  {
+   "cell_type": "code",
+   "metadata": {},
+   "outputs": [],
+   "source": []
+  }
+ ],
+ "metadata": {
+  "kernelspec": {"display_name": "Python 3", "language": "python", "name": "python3"}
+ },
+ "nbformat": 4,
+ "nbformat_minor": 5
+}`
+
+func TestNotebookChunker_Chunk_ExtractsCodeAndMarkdownCells(t *testing.T) {
+	// Given: a notebook with markdown, code, raw and empty cells
+	codeChunker := NewCodeChunker()
+	defer codeChunker.Close()
+	chunker := NewNotebookChunker(codeChunker, NewMarkdownChunker())
+
+	// When: chunking the notebook
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{
+		Path:    "analysis/churn.ipynb",
+		Content: []byte(sampleNotebook),
+	})
+
+	// Then: markdown and code cells become chunks; outputs and raw cells are dropped
+	require.NoError(t, err)
+	require.NotEmpty(t, chunks)
+
+	cells := map[string][]*Chunk{}
+	for _, ch := range chunks {
+		assert.Equal(t, "notebook", ch.Metadata["chunker"])
+		assert.NotContains(t, ch.Content, "SECRET_OUTPUT_TEXT")
+		assert.NotContains(t, ch.Content, "raw cell text")
+		cells[ch.Metadata["notebook_cell"]] = append(cells[ch.Metadata["notebook_cell"]], ch)
+	}
+
+	require.Contains(t, cells, "1")
+	assert.Equal(t, ContentTypeMarkdown, cells["1"][0].ContentType)
+	assert.Equal(t, "markdown", cells["1"][0].Metadata["cell_type"])
+	assert.Contains(t, cells["1"][0].Content, "Churn analysis")
+
+	// Statement-only cell has no symbols, so it is emitted whole
+	require.Contains(t, cells, "2")
+	require.Len(t, cells["2"], 1)
+	assert.Equal(t, ContentTypeCode, cells["2"][0].ContentType)
+	assert.Equal(t, "python", cells["2"][0].Language)
+	assert.Equal(t, 1, cells["2"][0].StartLine)
+	assert.Equal(t, 3, cells["2"][0].EndLine)
+	assert.Contains(t, cells["2"][0].Content, "pd.read_csv")
+
+	// Function cell keeps the symbols found by the code chunker
+	require.Contains(t, cells, "3")
+	require.Len(t, cells["3"], 1)
+	assert.Contains(t, cells["3"][0].Content, "def churn_rate")
+	assert.Equal(t, 1, cells["3"][0].StartLine)
+	require.NotEmpty(t, cells["3"][0].Symbols)
+	assert.Equal(t, "churn_rate", cells["3"][0].Symbols[0].Name)
+	assert.Equal(t, "churn_rate", cells["3"][0].EnclosingSymbol)
+
+	assert.NotContains(t, cells, "4")
+	assert.NotContains(t, cells, "5")
+}
+
+func TestNotebookChunker_Chunk_UniqueIDsForIdenticalCells(t *testing.T) {
+	// Given: a notebook with two identical code cells
+	nb := `{"cells": [
+		{"cell_type": "code", "source": "df.describe()"},
+		{"cell_type": "code", "source": "df.describe()"}
+	], "metadata": {}}`
+	chunker := NewNotebookChunker(nil, nil)
+
+	// When: chunking
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{Path: "a.ipynb", Content: []byte(nb)})
+
+	// Then: each cell gets a distinct chunk ID
+	require.NoError(t, err)
+	require.Len(t, chunks, 2)
+	assert.NotEqual(t, chunks[0].ID, chunks[1].ID)
+	assert.Equal(t, "python", chunks[0].Language, "default kernel language")
+}
+
+func TestNotebookChunker_Chunk_LanguageFromLanguageInfo(t *testing.T) {
+	// Given: a notebook declaring only language_info
+	nb := `{"cells": [{"cell_type": "code", "source": "x <- c(1, 2, 3)"}],
+		"metadata": {"language_info": {"name": "R"}}}`
+	chunker := NewNotebookChunker(nil, nil)
+
+	// When: chunking
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{Path: "stats.ipynb", Content: []byte(nb)})
+
+	// Then: the kernel language is used for code chunks
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "r", chunks[0].Language)
+}
+
+func TestNotebookChunker_Chunk_SplitsOversizedCells(t *testing.T) {
+	// Given: a long statement-only cell and a small token budget
+	var src strings.Builder
+	for i := 0; i < 40; i++ {
+		src.WriteString("value = compute_something(value, 12345)\\n")
+	}
+	nb := `{"cells": [{"cell_type": "code", "source": "` + src.String() + `"}], "metadata": {}}`
+	chunker := NewNotebookChunkerWithOptions(nil, nil, NotebookChunkerOptions{MaxChunkTokens: 100})
+
+	// When: chunking
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{Path: "long.ipynb", Content: []byte(nb)})
+
+	// Then: the cell is split into contiguous line windows
+	require.NoError(t, err)
+	require.Greater(t, len(chunks), 1)
+	assert.Equal(t, 1, chunks[0].StartLine)
+	for i := 1; i < len(chunks); i++ {
+		assert.Equal(t, chunks[i-1].EndLine+1, chunks[i].StartLine)
+	}
+	assert.Equal(t, 40, chunks[len(chunks)-1].EndLine)
+}
+
+func TestNotebookChunker_Chunk_MalformedNotebook(t *testing.T) {
+	chunker := NewNotebookChunker(nil, nil)
+
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{Path: "bad.ipynb", Content: []byte("{not json")})
+
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+}
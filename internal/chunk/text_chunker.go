@@ -0,0 +1,168 @@
+package chunk
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TextChunker splits plain text and config files (YAML, TOML, JSON, ...) into
+// chunks without parsing them.
+//
+// Blank-line separated blocks are packed into chunks of up to MaxChunkTokens.
+// A block too large for one chunk is cut into fixed windows of whole lines.
+// Chunks keep the original text, including the blank lines between blocks,
+// so line numbers map straight back to the file.
+type TextChunker struct {
+	options TextChunkerOptions
+}
+
+// TextChunkerOptions configures text chunking behavior.
+type TextChunkerOptions struct {
+	MaxChunkTokens int // Maximum tokens per chunk (default: DefaultMaxChunkTokens)
+}
+
+// NewTextChunker creates a text chunker with default options.
+func NewTextChunker() *TextChunker {
+	return NewTextChunkerWithOptions(TextChunkerOptions{})
+}
+
+// NewTextChunkerWithOptions creates a text chunker with custom options.
+func NewTextChunkerWithOptions(opts TextChunkerOptions) *TextChunker {
+	if opts.MaxChunkTokens == 0 {
+		opts.MaxChunkTokens = DefaultMaxChunkTokens
+	}
+	return &TextChunker{options: opts}
+}
+
+// Close releases chunker resources.
+// TextChunker is stateless, so this is a no-op for interface consistency with CodeChunker.
+func (c *TextChunker) Close() {
+	// No resources to release - TextChunker is stateless
+}
+
+// SupportedExtensions returns nil: the text chunker accepts any text file and
+// is selected by content type rather than extension.
+func (c *TextChunker) SupportedExtensions() []string {
+	return nil
+}
+
+// lineSpan is a range of 0-indexed lines, end exclusive.
+type lineSpan struct {
+	start, end int
+}
+
+// Chunk splits a text file into chunks.
+func (c *TextChunker) Chunk(ctx context.Context, file *FileInput) ([]*Chunk, error) {
+	content := string(file.Content)
+	if strings.TrimSpace(content) == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	maxChars := c.options.MaxChunkTokens * TokensPerChar
+
+	// Collect blank-line separated blocks, cutting oversized ones into windows
+	var pieces []lineSpan
+	for _, block := range textBlocks(lines) {
+		pieces = append(pieces, lineWindows(lines, block, maxChars)...)
+	}
+
+	// Pack consecutive pieces into chunks
+	var spans []lineSpan
+	current := pieces[0]
+	for _, piece := range pieces[1:] {
+		if spanChars(lines, lineSpan{current.start, piece.end}) > maxChars {
+			spans = append(spans, current)
+			current = piece
+			continue
+		}
+		current.end = piece.end
+	}
+	spans = append(spans, current)
+
+	now := time.Now()
+	seen := make(map[string]int, len(spans))
+	chunks := make([]*Chunk, 0, len(spans))
+	for _, span := range spans {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		text := strings.Join(lines[span.start:span.end], "\n")
+
+		// Identical blocks in one file need distinct IDs
+		id := generateChunkID(file.Path, text)
+		if n := seen[text]; n > 0 {
+			id = generateChunkIDWithDisambiguator(file.Path, text, strconv.Itoa(n))
+		}
+		seen[text]++
+
+		chunks = append(chunks, &Chunk{
+			ID:          id,
+			FilePath:    file.Path,
+			Content:     text,
+			RawContent:  text,
+			ContentType: ContentTypeText,
+			Language:    file.Language,
+			StartLine:   span.start + 1,
+			EndLine:     span.end,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+
+	return chunks, nil
+}
+
+// textBlocks returns the runs of non-blank lines.
+func textBlocks(lines []string) []lineSpan {
+	var blocks []lineSpan
+	start := -1
+	for i, line := range lines {
+		blank := strings.TrimSpace(line) == ""
+		switch {
+		case !blank && start < 0:
+			start = i
+		case blank && start >= 0:
+			blocks = append(blocks, lineSpan{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		blocks = append(blocks, lineSpan{start, len(lines)})
+	}
+	return blocks
+}
+
+// lineWindows cuts block into windows of whole lines of at most maxChars.
+// A single line longer than maxChars becomes its own window.
+func lineWindows(lines []string, block lineSpan, maxChars int) []lineSpan {
+	if spanChars(lines, block) <= maxChars {
+		return []lineSpan{block}
+	}
+
+	var windows []lineSpan
+	window := lineSpan{block.start, block.start}
+	size := 0
+	for i := block.start; i < block.end; i++ {
+		lineChars := len(lines[i]) + 1
+		if window.end > window.start && size+lineChars > maxChars {
+			windows = append(windows, window)
+			window = lineSpan{i, i}
+			size = 0
+		}
+		window.end = i + 1
+		size += lineChars
+	}
+	return append(windows, window)
+}
+
+// spanChars returns the length of the lines in span joined by newlines.
+func spanChars(lines []string, span lineSpan) int {
+	n := span.end - span.start - 1
+	for _, line := range lines[span.start:span.end] {
+		n += len(line)
+	}
+	return n
+}
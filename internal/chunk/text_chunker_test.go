@@ -0,0 +1,90 @@
+package chunk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextChunker_Chunk_PacksBlocks(t *testing.T) {
+	// Given: a small YAML file with blank-line separated blocks
+	content := "server:\n  port: 8080\n\ndatabase:\n  host: localhost\n"
+	chunker := NewTextChunker()
+
+	// When: chunking the file
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{
+		Path:     "config/app.yaml",
+		Content:  []byte(content),
+		Language: "yaml",
+	})
+
+	// Then: the whole file fits in one chunk covering every line
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "server:\n  port: 8080\n\ndatabase:\n  host: localhost", chunks[0].Content)
+	assert.Equal(t, ContentTypeText, chunks[0].ContentType)
+	assert.Equal(t, "yaml", chunks[0].Language)
+	assert.Equal(t, 1, chunks[0].StartLine)
+	assert.Equal(t, 5, chunks[0].EndLine)
+}
+
+func TestTextChunker_Chunk_SplitsOnBlankLines(t *testing.T) {
+	// Given: blocks that do not fit together in one chunk
+	block := strings.TrimSuffix(strings.Repeat("0123456789abcdef\n", 3), "\n")
+	content := "\n" + block + "\n\n\n" + block + "\n"
+	chunker := NewTextChunkerWithOptions(TextChunkerOptions{MaxChunkTokens: 16})
+
+	// When: chunking the file
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{Path: "notes.txt", Content: []byte(content)})
+
+	// Then: each block is its own chunk with file line numbers
+	require.NoError(t, err)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, block, chunks[0].Content)
+	assert.Equal(t, 2, chunks[0].StartLine)
+	assert.Equal(t, 4, chunks[0].EndLine)
+	assert.Equal(t, block, chunks[1].Content)
+	assert.Equal(t, 7, chunks[1].StartLine)
+	assert.Equal(t, 9, chunks[1].EndLine)
+
+	// And: identical blocks get distinct IDs
+	assert.NotEqual(t, chunks[0].ID, chunks[1].ID)
+}
+
+func TestTextChunker_Chunk_WindowsLargeBlocks(t *testing.T) {
+	// Given: a single block with no blank lines, larger than one chunk
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, strings.Repeat("x", 15))
+	}
+	chunker := NewTextChunkerWithOptions(TextChunkerOptions{MaxChunkTokens: 16})
+
+	// When: chunking the file
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{
+		Path:    "data.json",
+		Content: []byte(strings.Join(lines, "\n")),
+	})
+
+	// Then: it is cut into whole-line windows that cover the block in order
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+	next := 1
+	for _, c := range chunks {
+		assert.Equal(t, next, c.StartLine)
+		assert.LessOrEqual(t, len(c.Content), 16*TokensPerChar)
+		next = c.EndLine + 1
+	}
+	assert.Equal(t, 11, next)
+}
+
+func TestTextChunker_Chunk_EmptyContent(t *testing.T) {
+	chunker := NewTextChunker()
+
+	chunks, err := chunker.Chunk(context.Background(), &FileInput{Path: "empty.txt", Content: []byte("\n  \n")})
+
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+}
@@ -29,7 +29,7 @@ type Chunk struct {
 	FilePath    string            // Relative to project root
 	Content     string            // Full content with context
 	RawContent  string            // Just the symbol, no context (code only)
-	Context     string            // Imports, package decl (code only)
+	Context     string            // Imports, package decl (code); heading breadcrumb (markdown)
 	ContentType ContentType       // code, markdown, text
 	Language    string            // go, typescript, python, etc.
 	StartLine   int               // 1-indexed
@@ -38,8 +38,19 @@ type Chunk struct {
 	Metadata    map[string]string // Custom metadata
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	// EnclosingSymbol is the scope of the chunk's code as a breadcrumb,
+	// outermost first and joined by ScopeSeparator: "AuthService › Login"
+	// for a method, "AuthService" for the class itself. Go methods are
+	// scoped by their receiver type. Chunks holding several top-level
+	// symbols list them separated by ", ". Empty for chunks without
+	// symbols.
+	EnclosingSymbol string
 }
 
+// ScopeSeparator separates the scopes in Chunk.EnclosingSymbol.
+const ScopeSeparator = " › "
+
 // FileInput is input for the Chunker interface
 type FileInput struct {
 	Path     string // Relative path
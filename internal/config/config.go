@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"os"
 	"path/filepath"
@@ -77,6 +78,72 @@ type Config struct {
 type PathsConfig struct {
 	Include []string `yaml:"include" json:"include"`
 	Exclude []string `yaml:"exclude" json:"exclude"`
+
+	// IndexNotebooks indexes Jupyter notebooks (.ipynb): code cells as code,
+	// markdown cells as docs, outputs discarded. Default: false.
+	IndexNotebooks bool `yaml:"index_notebooks" json:"index_notebooks"`
+
+	// IncludeHidden also indexes the hidden cache, dependency and tool
+	// state directories skipped by default (.venv, .cache, .idea, ...).
+	// Other hidden paths are always indexed. Sensitive files, .git and
+	// exclude patterns are still skipped. Default: false.
+	IncludeHidden bool `yaml:"include_hidden" json:"include_hidden"`
+
+	// FollowSymlinks allowlists symlinks to index, by link path (globs
+	// allowed) or target path. All other symlinks are skipped. Default: none.
+	FollowSymlinks []string `yaml:"follow_symlinks" json:"follow_symlinks"`
+
+	// Priority lists project-relative directories or files that are indexed
+	// first, both by 'amanmcp index' and when the server reconciles changes
+	// made while it was stopped (and on periodic reconciliation).
+	// Default: none.
+	Priority []string `yaml:"priority" json:"priority"`
+
+	// BinaryThreshold switches binary detection from "any null byte near
+	// the start" to the fraction of control bytes in that sample: files
+	// above it are skipped as binary. Default: 0 (null-byte check).
+	BinaryThreshold float64 `yaml:"binary_threshold" json:"binary_threshold"`
+
+	// DecodeUTF16 indexes UTF-16 encoded text files, converted to UTF-8,
+	// instead of skipping them as binary. Default: false.
+	DecodeUTF16 bool `yaml:"decode_utf16" json:"decode_utf16"`
+
+	// InvalidUTF8 selects how text files that are not valid UTF-8 are
+	// indexed: "replace" substitutes U+FFFD for invalid bytes, "latin1"
+	// transcodes the file from Windows-1252. Default: "replace".
+	InvalidUTF8 string `yaml:"invalid_utf8" json:"invalid_utf8"`
+
+	// IndexDataFiles indexes large machine-generated data files (minified
+	// JSON, CSV exports, data dumps) instead of skipping them. Default: false.
+	IndexDataFiles bool `yaml:"index_data_files" json:"index_data_files"`
+
+	// DataFileMinSize is the size in bytes from which config and text files
+	// are checked for being machine-generated data. Default: 0 (256KB).
+	DataFileMinSize int64 `yaml:"data_file_min_size" json:"data_file_min_size"`
+
+	// DataFileMaxLineLength is the average line length in bytes above which
+	// a dense checked file is treated as data. Default: 0 (200).
+	DataFileMaxLineLength int `yaml:"data_file_max_line_length" json:"data_file_max_line_length"`
+
+	// DataFileAllow lists patterns of files that are always indexed, even
+	// when they look like machine-generated data.
+	DataFileAllow []string `yaml:"data_file_allow" json:"data_file_allow"`
+
+	// GitignoreMaxDepth limits how many directory levels below the project
+	// root are searched for .gitignore files when checking for changes made
+	// while the server was stopped. Default: 0 (no limit).
+	GitignoreMaxDepth int `yaml:"gitignore_max_depth" json:"gitignore_max_depth"`
+
+	// ContentTypeOverrides reclassifies languages, keyed by language name
+	// (case-insensitive), as "code", "markdown", "text" or "config", e.g.
+	// {sql: code}. Languages without an override keep their built-in
+	// content type. Default: none.
+	ContentTypeOverrides map[string]string `yaml:"content_type_overrides" json:"content_type_overrides"`
+
+	// LanguageOverrides maps file extensions to language names, over the
+	// built-in and search.languages extensions, e.g. {.tmpl: go}. The longest
+	// matching extension wins (".go.tmpl" over ".tmpl"). Default: none.
+	LanguageOverrides map[string]string `yaml:"language_overrides" json:"language_overrides"`
 }
 
 // SearchConfig configures hybrid search parameters.
@@ -98,15 +165,89 @@ type SearchConfig struct {
 	// Higher values reduce the impact of rank differences.
 	RRFConstant int `yaml:"rrf_constant" json:"rrf_constant"`
 
+	// FusionStrategy selects how BM25 and vector results are combined.
+	// Options: "rrf" (default, rank-based) or "weighted" (normalized score sum).
+	// RRF is robust to score scale differences; weighted preserves score gaps
+	// but needs per-corpus weight tuning.
+	FusionStrategy string `yaml:"fusion_strategy" json:"fusion_strategy"`
+
+	// FusionNormalization selects per-list score normalization for the
+	// weighted strategy: "minmax" (default) or "zscore". Ignored by RRF.
+	FusionNormalization string `yaml:"fusion_normalization,omitempty" json:"fusion_normalization,omitempty"`
+
 	// BM25Backend selects the BM25 index backend.
 	// Options: "sqlite" (default, concurrent access) or "bleve" (legacy, single-process)
 	// SQLite FTS5 with WAL mode enables concurrent multi-process access (BUG-064 fix).
 	BM25Backend string `yaml:"bm25_backend" json:"bm25_backend"`
 
+	// VectorBackend selects the vector store backend.
+	// Options: "hnsw" (default, approximate) or "flat" (exact brute-force,
+	// suited to small indexes). Switching requires 'amanmcp index --force'.
+	VectorBackend string `yaml:"vector_backend" json:"vector_backend"`
+
+	// NearDuplicateDistance enables near-duplicate detection at index time:
+	// chunks whose SimHash fingerprint is within this many bits of an already
+	// indexed chunk are skipped, and results for that chunk report how many
+	// near-duplicates it has. 0 disables detection (default); at most 16.
+	// Changing it requires 'amanmcp index --force'.
+	NearDuplicateDistance int `yaml:"near_duplicate_distance,omitempty" json:"near_duplicate_distance,omitempty"`
+
+	// BM25MinTermLength drops terms shorter than this from the BM25 index and
+	// from queries. 0 uses the default (2). Changing it requires a reindex.
+	BM25MinTermLength int `yaml:"bm25_min_term_length,omitempty" json:"bm25_min_term_length,omitempty"`
+
+	// BM25KeepShortTerms lists terms kept regardless of BM25MinTermLength.
+	// Empty uses the built-in list (id, io, os, db, ...).
+	BM25KeepShortTerms []string `yaml:"bm25_keep_short_terms,omitempty" json:"bm25_keep_short_terms,omitempty"`
+
+	// MaxHighlights caps highlight ranges returned per search result.
+	// 0 uses the search engine default (50).
+	MaxHighlights int `yaml:"max_highlights,omitempty" json:"max_highlights,omitempty"`
+
+	// Confidence tunes the per-result high/medium/low confidence labels.
+	Confidence ConfidenceConfig `yaml:"confidence,omitempty" json:"confidence,omitempty"`
+
+	// ChunkIDScheme selects how chunk IDs are derived: "content" (default,
+	// file path + content) or "positional" (file path + start line +
+	// content). Changing it requires a reindex.
+	ChunkIDScheme string `yaml:"chunk_id_scheme,omitempty" json:"chunk_id_scheme,omitempty"`
+
+	// SplitCodeBlocks chunks fenced code blocks in Markdown that name a
+	// language as separate code chunks in that language, instead of as part
+	// of the surrounding markdown. Changing it requires a reindex.
+	SplitCodeBlocks bool `yaml:"split_code_blocks,omitempty" json:"split_code_blocks,omitempty"`
+
+	// CodeChunkTokens is the maximum size in tokens of a code chunk before
+	// a symbol is split. 0 uses the chunker default (512). Changing it
+	// requires a reindex.
+	CodeChunkTokens int `yaml:"code_chunk_tokens,omitempty" json:"code_chunk_tokens,omitempty"`
+
+	// CodeChunkOverlapLines is the number of source lines consecutive code
+	// chunks split from the same symbol share. 0 (default) keeps the
+	// chunker's built-in behavior. Changing it requires a reindex.
+	CodeChunkOverlapLines int `yaml:"code_chunk_overlap_lines,omitempty" json:"code_chunk_overlap_lines,omitempty"`
+
 	ChunkSize    int `yaml:"chunk_size" json:"chunk_size"`
 	ChunkOverlap int `yaml:"chunk_overlap" json:"chunk_overlap"`
 	MaxResults   int `yaml:"max_results" json:"max_results"`
 
+	// MaxLimit is the hard cap on results per query; larger requested
+	// limits are clamped, or rejected with StrictLimits. Default: 100.
+	MaxLimit int `yaml:"max_limit" json:"max_limit"`
+
+	// MaxCandidates caps the candidates BM25 and vector search each fetch
+	// per query, whatever the limit and candidate multiplier. Default: 2000.
+	MaxCandidates int `yaml:"max_candidates" json:"max_candidates"`
+
+	// StrictLimits rejects queries asking for more than MaxLimit results or
+	// MaxCandidates candidates instead of clamping them. Default: false.
+	StrictLimits bool `yaml:"strict_limits" json:"strict_limits"`
+
+	// MaxChunkBytes caps the chunk text sent to the embedder; longer chunks
+	// are truncated, preferably at a line boundary, and stored in full.
+	// 0 uses the embedder's advertised input limit, if any.
+	MaxChunkBytes int `yaml:"max_chunk_bytes,omitempty" json:"max_chunk_bytes,omitempty"`
+
 	// Profiles define retrieval eligibility/classification defaults for F39
 	// authority-safe search. They do not make excluded paths indexable; .gitignore
 	// and paths.exclude remain the source of truth for indexability.
@@ -124,6 +265,23 @@ type SearchConfig struct {
 type RerankerConfig struct {
 	// Policy controls when reranking is attempted: auto, always, or never.
 	Policy string `yaml:"policy" json:"policy"`
+
+	// Threshold skips reranking when the top fused result leads the
+	// runner-up by at least this fraction of its score (0-1). Ignored under
+	// the always policy. Default: 0 (never skip for a clear winner).
+	Threshold float64 `yaml:"threshold" json:"threshold"`
+}
+
+// ConfidenceConfig sets the thresholds for search result confidence labels.
+// Ratios are relative to the mean score of a result set; 0 uses the search
+// engine default.
+type ConfidenceConfig struct {
+	// HighRatio labels results scoring at least this multiple of the mean high (default 1.5).
+	HighRatio float64 `yaml:"high_ratio,omitempty" json:"high_ratio,omitempty"`
+	// LowRatio labels results scoring below this multiple of the mean low (default 0.5).
+	LowRatio float64 `yaml:"low_ratio,omitempty" json:"low_ratio,omitempty"`
+	// MinScore labels results scoring below it low (default 0.05).
+	MinScore float64 `yaml:"min_score,omitempty" json:"min_score,omitempty"`
 }
 
 // SearchProfileConfig defines data-driven source/profile routing rules.
@@ -144,6 +302,20 @@ type EmbeddingsConfig struct {
 	BatchSize            int           `yaml:"batch_size" json:"batch_size"`
 	ModelDownloadTimeout time.Duration `yaml:"model_download_timeout" json:"model_download_timeout"`
 
+	// AutoReindex re-embeds the index in the background when the embedder's
+	// dimension no longer matches the indexed one (default: false).
+	AutoReindex bool `yaml:"auto_reindex" json:"auto_reindex"`
+
+	// ReembedOnModelChange re-embeds, in the background and resumably, the
+	// chunks embedded by another model when the embedder's model changes at
+	// the same dimension (default: false).
+	ReembedOnModelChange bool `yaml:"reembed_on_model_change" json:"reembed_on_model_change"`
+
+	// PipelineIndexing overlaps embedding of the next batch of chunks with
+	// writing the previous one, both in the index command and in large
+	// incremental updates (default: false).
+	PipelineIndexing bool `yaml:"pipeline_indexing" json:"pipeline_indexing"`
+
 	// MLX settings (opt-in on Apple Silicon via --backend=mlx, ~1.7x faster throughput)
 	MLXEndpoint string `yaml:"mlx_endpoint" json:"mlx_endpoint"` // MLX server endpoint (default: http://localhost:9659)
 	MLXModel    string `yaml:"mlx_model" json:"mlx_model"`       // MLX model size: small (0.6B), medium (4B), large (8B)
@@ -167,6 +339,10 @@ type PerformanceConfig struct {
 	MemoryLimit   string `yaml:"memory_limit" json:"memory_limit"`
 	Quantization  string `yaml:"quantization" json:"quantization"`
 	SQLiteCacheMB int    `yaml:"sqlite_cache_mb" json:"sqlite_cache_mb"` // SQLite cache size in MB (default: 64)
+
+	// CompressContent gzips chunk text in the metadata store as it is
+	// written. Existing rows stay as they are until re-indexed.
+	CompressContent bool `yaml:"compress_content,omitempty" json:"compress_content,omitempty"`
 }
 
 // ServerConfig configures the MCP server.
@@ -305,13 +481,17 @@ func NewConfig() *Config {
 			BM25Weight:     0.65,
 			SemanticWeight: 0.35,
 			// RRF constant k=60 is industry standard (Azure AI Search, OpenSearch)
-			RRFConstant: 60,
+			RRFConstant:    60,
+			FusionStrategy: "rrf",
 			// BM25Backend: SQLite FTS5 is default for concurrent multi-process access (BUG-064 fix)
-			BM25Backend:  "sqlite",
-			ChunkSize:    1500,
-			ChunkOverlap: 200,
-			MaxResults:   20,
-			Profiles:     defaultSearchProfiles(),
+			BM25Backend:   "sqlite",
+			VectorBackend: "hnsw",
+			ChunkSize:     1500,
+			ChunkOverlap:  200,
+			MaxResults:    20,
+			MaxLimit:      100,
+			MaxCandidates: 2000,
+			Profiles:      defaultSearchProfiles(),
 			Reranker: RerankerConfig{
 				Policy: "auto",
 			},
@@ -508,6 +688,12 @@ func (c *Config) loadYAML(path string) error {
 		return fmt.Errorf("failed to parse config file %s: %w", path, err)
 	}
 
+	// Shared exclude lists are referenced as "@file:path" relative to this file
+	parsed.Paths.Exclude, err = ExpandPatternIncludes(parsed.Paths.Exclude, filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("invalid paths.exclude in %s: %w", path, err)
+	}
+
 	// Merge parsed values with defaults (only non-zero values)
 	c.mergeWith(&parsed)
 	return nil
@@ -527,6 +713,48 @@ func (c *Config) mergeWith(other *Config) {
 		// Merge with defaults rather than replace
 		c.Paths.Exclude = appendUniqueStrings(c.Paths.Exclude, other.Paths.Exclude...)
 	}
+	if other.Paths.IndexNotebooks {
+		c.Paths.IndexNotebooks = true
+	}
+	if other.Paths.IncludeHidden {
+		c.Paths.IncludeHidden = true
+	}
+	if len(other.Paths.FollowSymlinks) > 0 {
+		c.Paths.FollowSymlinks = appendUniqueStrings(c.Paths.FollowSymlinks, other.Paths.FollowSymlinks...)
+	}
+	if len(other.Paths.Priority) > 0 {
+		c.Paths.Priority = appendUniqueStrings(c.Paths.Priority, other.Paths.Priority...)
+	}
+	if other.Paths.BinaryThreshold != 0 {
+		c.Paths.BinaryThreshold = other.Paths.BinaryThreshold
+	}
+	if other.Paths.DecodeUTF16 {
+		c.Paths.DecodeUTF16 = true
+	}
+	if other.Paths.InvalidUTF8 != "" {
+		c.Paths.InvalidUTF8 = other.Paths.InvalidUTF8
+	}
+	if other.Paths.IndexDataFiles {
+		c.Paths.IndexDataFiles = true
+	}
+	if other.Paths.DataFileMinSize != 0 {
+		c.Paths.DataFileMinSize = other.Paths.DataFileMinSize
+	}
+	if other.Paths.DataFileMaxLineLength != 0 {
+		c.Paths.DataFileMaxLineLength = other.Paths.DataFileMaxLineLength
+	}
+	if len(other.Paths.DataFileAllow) > 0 {
+		c.Paths.DataFileAllow = appendUniqueStrings(c.Paths.DataFileAllow, other.Paths.DataFileAllow...)
+	}
+	if other.Paths.GitignoreMaxDepth != 0 {
+		c.Paths.GitignoreMaxDepth = other.Paths.GitignoreMaxDepth
+	}
+	if len(other.Paths.ContentTypeOverrides) > 0 {
+		c.Paths.ContentTypeOverrides = mergeStringMaps(c.Paths.ContentTypeOverrides, other.Paths.ContentTypeOverrides)
+	}
+	if len(other.Paths.LanguageOverrides) > 0 {
+		c.Paths.LanguageOverrides = mergeStringMaps(c.Paths.LanguageOverrides, other.Paths.LanguageOverrides)
+	}
 
 	// Search weights and RRF constant
 	// Note: 0 is not a practical value for weights, so we only merge non-zero values
@@ -539,9 +767,51 @@ func (c *Config) mergeWith(other *Config) {
 	if other.Search.RRFConstant != 0 {
 		c.Search.RRFConstant = other.Search.RRFConstant
 	}
+	if other.Search.FusionStrategy != "" {
+		c.Search.FusionStrategy = other.Search.FusionStrategy
+	}
+	if other.Search.FusionNormalization != "" {
+		c.Search.FusionNormalization = other.Search.FusionNormalization
+	}
 	if other.Search.BM25Backend != "" {
 		c.Search.BM25Backend = other.Search.BM25Backend
 	}
+	if other.Search.VectorBackend != "" {
+		c.Search.VectorBackend = other.Search.VectorBackend
+	}
+	if other.Search.NearDuplicateDistance != 0 {
+		c.Search.NearDuplicateDistance = other.Search.NearDuplicateDistance
+	}
+	if other.Search.MaxHighlights != 0 {
+		c.Search.MaxHighlights = other.Search.MaxHighlights
+	}
+	if other.Search.Confidence.HighRatio != 0 {
+		c.Search.Confidence.HighRatio = other.Search.Confidence.HighRatio
+	}
+	if other.Search.Confidence.LowRatio != 0 {
+		c.Search.Confidence.LowRatio = other.Search.Confidence.LowRatio
+	}
+	if other.Search.Confidence.MinScore != 0 {
+		c.Search.Confidence.MinScore = other.Search.Confidence.MinScore
+	}
+	if other.Search.BM25MinTermLength != 0 {
+		c.Search.BM25MinTermLength = other.Search.BM25MinTermLength
+	}
+	if len(other.Search.BM25KeepShortTerms) > 0 {
+		c.Search.BM25KeepShortTerms = other.Search.BM25KeepShortTerms
+	}
+	if other.Search.ChunkIDScheme != "" {
+		c.Search.ChunkIDScheme = other.Search.ChunkIDScheme
+	}
+	if other.Search.SplitCodeBlocks {
+		c.Search.SplitCodeBlocks = true
+	}
+	if other.Search.CodeChunkTokens != 0 {
+		c.Search.CodeChunkTokens = other.Search.CodeChunkTokens
+	}
+	if other.Search.CodeChunkOverlapLines != 0 {
+		c.Search.CodeChunkOverlapLines = other.Search.CodeChunkOverlapLines
+	}
 	if other.Search.ChunkSize != 0 {
 		c.Search.ChunkSize = other.Search.ChunkSize
 	}
@@ -551,6 +821,18 @@ func (c *Config) mergeWith(other *Config) {
 	if other.Search.MaxResults != 0 {
 		c.Search.MaxResults = other.Search.MaxResults
 	}
+	if other.Search.MaxLimit != 0 {
+		c.Search.MaxLimit = other.Search.MaxLimit
+	}
+	if other.Search.MaxCandidates != 0 {
+		c.Search.MaxCandidates = other.Search.MaxCandidates
+	}
+	if other.Search.StrictLimits {
+		c.Search.StrictLimits = true
+	}
+	if other.Search.MaxChunkBytes != 0 {
+		c.Search.MaxChunkBytes = other.Search.MaxChunkBytes
+	}
 	if len(other.Search.Profiles) > 0 {
 		c.Search.Profiles = mergeSearchProfiles(c.Search.Profiles, other.Search.Profiles)
 	}
@@ -560,6 +842,9 @@ func (c *Config) mergeWith(other *Config) {
 	if other.Search.Reranker.Policy != "" {
 		c.Search.Reranker.Policy = other.Search.Reranker.Policy
 	}
+	if other.Search.Reranker.Threshold != 0 {
+		c.Search.Reranker.Threshold = other.Search.Reranker.Threshold
+	}
 
 	// Embeddings
 	if other.Embeddings.Provider != "" {
@@ -574,6 +859,15 @@ func (c *Config) mergeWith(other *Config) {
 	if other.Embeddings.BatchSize != 0 {
 		c.Embeddings.BatchSize = other.Embeddings.BatchSize
 	}
+	if other.Embeddings.AutoReindex {
+		c.Embeddings.AutoReindex = true
+	}
+	if other.Embeddings.ReembedOnModelChange {
+		c.Embeddings.ReembedOnModelChange = true
+	}
+	if other.Embeddings.PipelineIndexing {
+		c.Embeddings.PipelineIndexing = true
+	}
 	if other.Embeddings.OllamaHost != "" {
 		c.Embeddings.OllamaHost = other.Embeddings.OllamaHost
 	}
@@ -610,6 +904,9 @@ func (c *Config) mergeWith(other *Config) {
 	if other.Performance.SQLiteCacheMB != 0 {
 		c.Performance.SQLiteCacheMB = other.Performance.SQLiteCacheMB
 	}
+	if other.Performance.CompressContent {
+		c.Performance.CompressContent = true
+	}
 
 	// Server
 	if other.Server.Transport != "" {
@@ -733,6 +1030,15 @@ func appendUniqueStrings(base []string, values ...string) []string {
 	return out
 }
 
+// mergeStringMaps returns base with the entries of override added over it.
+// base itself is never modified.
+func mergeStringMaps(base, override map[string]string) map[string]string {
+	out := make(map[string]string, len(base)+len(override))
+	maps.Copy(out, base)
+	maps.Copy(out, override)
+	return out
+}
+
 func validateRerankerPolicy(policy string) error {
 	switch strings.ToLower(strings.TrimSpace(policy)) {
 	case "", "auto", "always", "never":
@@ -742,6 +1048,38 @@ func validateRerankerPolicy(policy string) error {
 	}
 }
 
+func validateChunkIDScheme(scheme string) error {
+	switch strings.ToLower(strings.TrimSpace(scheme)) {
+	case "", "content", "positional":
+		return nil
+	default:
+		return fmt.Errorf("search.chunk_id_scheme must be one of 'content' or 'positional', got %q", scheme)
+	}
+}
+
+func validateVectorBackend(backend string) error {
+	switch backend {
+	case "", "hnsw", "flat":
+		return nil
+	default:
+		return fmt.Errorf("search.vector_backend must be one of 'hnsw' or 'flat', got %q", backend)
+	}
+}
+
+func validateFusionStrategy(strategy, normalization string) error {
+	switch strings.ToLower(strings.TrimSpace(strategy)) {
+	case "", "rrf", "weighted":
+	default:
+		return fmt.Errorf("search.fusion_strategy must be one of 'rrf' or 'weighted', got %q", strategy)
+	}
+	switch strings.ToLower(strings.TrimSpace(normalization)) {
+	case "", "minmax", "zscore":
+		return nil
+	default:
+		return fmt.Errorf("search.fusion_normalization must be one of 'minmax' or 'zscore', got %q", normalization)
+	}
+}
+
 // validateGraphEvalModeThresholds checks that every populated per-mode relevance
 // floor is in (0, 1]. A zero floor means "not enforced" and is allowed.
 func validateGraphEvalModeThresholds(modes GraphEvalModeThresholds) error {
@@ -789,6 +1127,9 @@ func (c *Config) applyEnvOverrides() {
 			c.Search.RRFConstant = k
 		}
 	}
+	if v := os.Getenv("AMANMCP_FUSION_STRATEGY"); v != "" {
+		c.Search.FusionStrategy = v
+	}
 	if v := os.Getenv("AMANMCP_RERANKER_POLICY"); v != "" {
 		c.Search.Reranker.Policy = v
 	}
@@ -813,6 +1154,13 @@ func (c *Config) applyEnvOverrides() {
 		c.Server.Transport = v
 	}
 
+	if v := os.Getenv("AMANMCP_INDEX_NOTEBOOKS"); v != "" {
+		c.Paths.IndexNotebooks = strings.ToLower(v) == "true" || v == "1"
+	}
+	if v := os.Getenv("AMANMCP_INCLUDE_HIDDEN"); v != "" {
+		c.Paths.IncludeHidden = strings.ToLower(v) == "true" || v == "1"
+	}
+
 	// Compaction env overrides (FEAT-AI3)
 	if v := os.Getenv("AMANMCP_COMPACTION_ENABLED"); v != "" {
 		c.Compaction.Enabled = strings.ToLower(v) == "true" || v == "1"
@@ -1015,13 +1363,67 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("bm25_weight + semantic_weight must equal 1.0, got %.2f", sum)
 	}
 
+	if c.Paths.BinaryThreshold < 0 || c.Paths.BinaryThreshold > 1 {
+		return fmt.Errorf("paths.binary_threshold must be between 0 and 1, got %f", c.Paths.BinaryThreshold)
+	}
+	if c.Paths.GitignoreMaxDepth < 0 {
+		return fmt.Errorf("paths.gitignore_max_depth must be non-negative, got %d", c.Paths.GitignoreMaxDepth)
+	}
+	if c.Paths.DataFileMinSize < 0 {
+		return fmt.Errorf("paths.data_file_min_size must be non-negative, got %d", c.Paths.DataFileMinSize)
+	}
+	if c.Paths.DataFileMaxLineLength < 0 {
+		return fmt.Errorf("paths.data_file_max_line_length must be non-negative, got %d", c.Paths.DataFileMaxLineLength)
+	}
+	for language, contentType := range c.Paths.ContentTypeOverrides {
+		switch contentType {
+		case "code", "markdown", "text", "config":
+		default:
+			return fmt.Errorf("paths.content_type_overrides.%s must be code, markdown, text or config, got %q", language, contentType)
+		}
+	}
+	for ext, language := range c.Paths.LanguageOverrides {
+		if strings.TrimPrefix(ext, ".") == "" || language == "" {
+			return fmt.Errorf("paths.language_overrides must map non-empty extensions to non-empty languages, got %q: %q", ext, language)
+		}
+	}
+	switch c.Paths.InvalidUTF8 {
+	case "", "replace", "latin1":
+	default:
+		return fmt.Errorf("paths.invalid_utf8 must be replace or latin1, got %q", c.Paths.InvalidUTF8)
+	}
+
 	// Validate non-negative values (DEBT-018)
 	if c.Search.MaxResults < 0 {
 		return fmt.Errorf("max_results must be non-negative, got %d", c.Search.MaxResults)
 	}
+	if c.Search.MaxLimit < 0 {
+		return fmt.Errorf("search.max_limit must be non-negative, got %d", c.Search.MaxLimit)
+	}
+	if c.Search.MaxCandidates < 0 {
+		return fmt.Errorf("search.max_candidates must be non-negative, got %d", c.Search.MaxCandidates)
+	}
+	if c.Search.MaxChunkBytes < 0 {
+		return fmt.Errorf("search.max_chunk_bytes must be non-negative, got %d", c.Search.MaxChunkBytes)
+	}
+	if c.Search.MaxLimit > 0 && c.Search.MaxCandidates > 0 && c.Search.MaxCandidates < c.Search.MaxLimit {
+		return fmt.Errorf("search.max_candidates (%d) must be at least search.max_limit (%d)", c.Search.MaxCandidates, c.Search.MaxLimit)
+	}
 	if c.Search.ChunkSize < 0 {
 		return fmt.Errorf("chunk_size must be non-negative, got %d", c.Search.ChunkSize)
 	}
+	if c.Search.CodeChunkTokens < 0 {
+		return fmt.Errorf("search.code_chunk_tokens must be non-negative, got %d", c.Search.CodeChunkTokens)
+	}
+	if c.Search.CodeChunkOverlapLines < 0 {
+		return fmt.Errorf("search.code_chunk_overlap_lines must be non-negative, got %d", c.Search.CodeChunkOverlapLines)
+	}
+	if c.Search.BM25MinTermLength < 0 {
+		return fmt.Errorf("bm25_min_term_length must be non-negative, got %d", c.Search.BM25MinTermLength)
+	}
+	if c.Search.NearDuplicateDistance < 0 || c.Search.NearDuplicateDistance > 16 {
+		return fmt.Errorf("search.near_duplicate_distance must be between 0 and 16, got %d", c.Search.NearDuplicateDistance)
+	}
 	if err := validateSearchProfiles(c.Search.Profiles); err != nil {
 		return err
 	}
@@ -1030,9 +1432,24 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("search.languages: %w", err)
 	}
 	c.Search.Languages = normalizedLanguages
+	if err := validateFusionStrategy(c.Search.FusionStrategy, c.Search.FusionNormalization); err != nil {
+		return err
+	}
+	if err := validateVectorBackend(c.Search.VectorBackend); err != nil {
+		return err
+	}
 	if err := validateRerankerPolicy(c.Search.Reranker.Policy); err != nil {
 		return err
 	}
+	if c.Search.Reranker.Threshold < 0 || c.Search.Reranker.Threshold > 1 {
+		return fmt.Errorf("search.reranker.threshold must be between 0 and 1, got %f", c.Search.Reranker.Threshold)
+	}
+	if err := validateChunkIDScheme(c.Search.ChunkIDScheme); err != nil {
+		return err
+	}
+	if err := c.SearchConfidenceThresholds().Validate(); err != nil {
+		return fmt.Errorf("search.confidence: %w", err)
+	}
 	if c.Eval.Graph.BlockingDegradationThreshold <= 0 || c.Eval.Graph.BlockingDegradationThreshold > 1 {
 		return fmt.Errorf("eval.graph.blocking_degradation_threshold must be greater than 0 and at most 1, got %f",
 			c.Eval.Graph.BlockingDegradationThreshold)
@@ -1109,6 +1526,10 @@ func (c *Config) MergeNewDefaults() []string {
 		c.Search.RRFConstant = defaults.Search.RRFConstant
 		added = append(added, "search.rrf_constant")
 	}
+	if c.Search.FusionStrategy == "" {
+		c.Search.FusionStrategy = defaults.Search.FusionStrategy
+		added = append(added, "search.fusion_strategy")
+	}
 	if len(c.Search.Profiles) == 0 {
 		c.Search.Profiles = cloneSearchProfiles(defaults.Search.Profiles)
 		added = append(added, "search.profiles")
@@ -251,6 +251,212 @@ search:
 	assert.Contains(t, err.Error(), "never")
 }
 
+func TestLoad_FusionStrategy_MergesAndValidates(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `
+version: 1
+search:
+  fusion_strategy: weighted
+  fusion_normalization: zscore
+`
+	err := os.WriteFile(filepath.Join(tmpDir, ".amanmcp.yaml"), []byte(configContent), 0o644)
+	require.NoError(t, err)
+
+	cfg, err := Load(tmpDir)
+
+	require.NoError(t, err)
+	assert.Equal(t, "weighted", cfg.Search.FusionStrategy)
+	assert.Equal(t, "zscore", cfg.Search.FusionNormalization)
+	assert.Equal(t, 60, cfg.Search.RRFConstant, "fusion strategy merge must preserve rrf_constant default")
+}
+
+func TestLoad_BM25TermLength(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `
+version: 1
+search:
+  bm25_min_term_length: 3
+  bm25_keep_short_terms: [id, io]
+`
+	err := os.WriteFile(filepath.Join(tmpDir, ".amanmcp.yaml"), []byte(configContent), 0o644)
+	require.NoError(t, err)
+
+	cfg, err := Load(tmpDir)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, cfg.Search.BM25MinTermLength)
+	assert.Equal(t, []string{"id", "io"}, cfg.Search.BM25KeepShortTerms)
+}
+
+func TestLoad_CompressContent(t *testing.T) {
+	// Given: a config enabling chunk text compression
+	tmpDir := t.TempDir()
+	configContent := `
+version: 1
+performance:
+  compress_content: true
+`
+	err := os.WriteFile(filepath.Join(tmpDir, ".amanmcp.yaml"), []byte(configContent), 0o644)
+	require.NoError(t, err)
+
+	// When: loading configuration
+	cfg, err := Load(tmpDir)
+
+	// Then: compression is enabled and off by default elsewhere
+	require.NoError(t, err)
+	assert.True(t, cfg.Performance.CompressContent)
+	assert.False(t, NewConfig().Performance.CompressContent)
+}
+
+func TestConfig_Validate_NegativeBM25MinTermLength(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Search.BM25MinTermLength = -1
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bm25_min_term_length")
+}
+
+func TestConfig_Validate_UnknownVectorBackend(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Search.VectorBackend = "annoy"
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "search.vector_backend")
+}
+
+func TestConfig_Validate_NearDuplicateDistanceOutOfRange(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Search.NearDuplicateDistance = 17
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "search.near_duplicate_distance")
+}
+
+func TestConfig_Validate_BinaryThresholdOutOfRange(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Paths.BinaryThreshold = 1.5
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "binary_threshold")
+}
+
+func TestLoad_ContentTypeOverrides_MergeOverUserConfig(t *testing.T) {
+	configDir := t.TempDir()
+	projectDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+
+	amanmcpDir := filepath.Join(configDir, "amanmcp")
+	require.NoError(t, os.MkdirAll(amanmcpDir, 0o755))
+	userConfig := `
+version: 1
+paths:
+  content_type_overrides:
+    sql: code
+    rst: text
+`
+	require.NoError(t, os.WriteFile(filepath.Join(amanmcpDir, "config.yaml"), []byte(userConfig), 0o644))
+
+	projectConfig := `
+version: 1
+paths:
+  content_type_overrides:
+    rst: markdown
+`
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".amanmcp.yaml"), []byte(projectConfig), 0o644))
+
+	cfg, err := Load(projectDir)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"sql": "code", "rst": "markdown"}, cfg.Paths.ContentTypeOverrides)
+}
+
+func TestConfig_Validate_UnknownContentTypeOverride(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Paths.ContentTypeOverrides = map[string]string{"sql": "binary"}
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "paths.content_type_overrides.sql")
+}
+
+func TestLoad_LanguageOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `
+version: 1
+paths:
+  language_overrides:
+    .tmpl: go
+    jsonc: json
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".amanmcp.yaml"), []byte(configContent), 0o644))
+
+	cfg, err := Load(tmpDir)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{".tmpl": "go", "jsonc": "json"}, cfg.Paths.LanguageOverrides)
+}
+
+func TestConfig_Validate_EmptyLanguageOverride(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Paths.LanguageOverrides = map[string]string{".": "go"}
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "paths.language_overrides")
+}
+
+func TestLoad_InvalidFusionStrategy_ReturnsError(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `
+version: 1
+search:
+  fusion_strategy: borda
+`
+	err := os.WriteFile(filepath.Join(tmpDir, ".amanmcp.yaml"), []byte(configContent), 0o644)
+	require.NoError(t, err)
+
+	cfg, err := Load(tmpDir)
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Contains(t, err.Error(), "search.fusion_strategy")
+}
+
+func TestLoad_IndexNotebooks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg, err := Load(tmpDir)
+	require.NoError(t, err)
+	assert.False(t, cfg.Paths.IndexNotebooks, "notebooks are opt-in")
+
+	configContent := `
+version: 1
+paths:
+  index_notebooks: true
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".amanmcp.yaml"), []byte(configContent), 0o644))
+
+	cfg, err = Load(tmpDir)
+	require.NoError(t, err)
+	assert.True(t, cfg.Paths.IndexNotebooks)
+	assert.NotEmpty(t, cfg.Paths.Exclude, "notebook flag merge must preserve default excludes")
+
+	t.Setenv("AMANMCP_INDEX_NOTEBOOKS", "false")
+	cfg, err = Load(tmpDir)
+	require.NoError(t, err)
+	assert.False(t, cfg.Paths.IndexNotebooks, "env override wins")
+}
+
 func TestLoad_YmlExtension_IsRecognized(t *testing.T) {
 	// Given: a directory with .amanmcp.yml (alternative extension)
 	tmpDir := t.TempDir()
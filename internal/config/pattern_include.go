@@ -0,0 +1,105 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PatternIncludePrefix marks a path pattern entry that names a file of
+// further patterns, e.g. "@file:../shared/exclude.txt".
+const PatternIncludePrefix = "@file:"
+
+// ErrPatternIncludeCycle is returned when pattern files include each other.
+var ErrPatternIncludeCycle = errors.New("pattern include cycle")
+
+// ExpandPatternIncludes replaces every "@file:path" entry in patterns with the
+// patterns listed in that file, in place, and returns the result. Relative
+// paths resolve against baseDir, or against the including file's directory
+// for includes nested in a pattern file.
+//
+// Pattern files hold one pattern per line; blank lines and lines starting
+// with # are ignored. A missing file is an error, as is a file that
+// (directly or indirectly) includes itself. Patterns without includes are
+// returned unchanged.
+func ExpandPatternIncludes(patterns []string, baseDir string) ([]string, error) {
+	if !hasPatternInclude(patterns) {
+		return patterns, nil
+	}
+	out := make([]string, 0, len(patterns))
+	return expandPatternIncludes(out, patterns, baseDir, nil)
+}
+
+func hasPatternInclude(patterns []string) bool {
+	for _, p := range patterns {
+		if strings.HasPrefix(p, PatternIncludePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandPatternIncludes appends the expansion of patterns to out. stack holds
+// the pattern files currently being expanded, outermost first.
+func expandPatternIncludes(out, patterns []string, baseDir string, stack []string) ([]string, error) {
+	for _, p := range patterns {
+		ref, ok := strings.CutPrefix(p, PatternIncludePrefix)
+		if !ok {
+			out = append(out, p)
+			continue
+		}
+
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			return nil, fmt.Errorf("empty pattern include %q", p)
+		}
+		path := ref
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		path = filepath.Clean(path)
+
+		for i, seen := range stack {
+			if seen == path {
+				chain := append(append([]string(nil), stack[i:]...), path)
+				return nil, fmt.Errorf("%w: %s", ErrPatternIncludeCycle, strings.Join(chain, " -> "))
+			}
+		}
+
+		included, err := readPatternFile(path)
+		if err != nil {
+			return nil, err
+		}
+		out, err = expandPatternIncludes(out, included, filepath.Dir(path), append(stack, path))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// readPatternFile reads the patterns listed in a pattern file.
+func readPatternFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pattern file %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var patterns []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read pattern file %s: %w", path, err)
+	}
+	return patterns, nil
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPatternIncludes_ExpandsInPlace(t *testing.T) {
+	// Given: a shared pattern file with comments and a nested include
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared")
+	require.NoError(t, os.MkdirAll(shared, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "exclude.txt"),
+		[]byte("# build output\n**/dist/**\n\n@file:more.txt\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "more.txt"), []byte("*.log\n"), 0o644))
+
+	// When: expanding a list that references it
+	got, err := ExpandPatternIncludes([]string{"archive/**", "@file:shared/exclude.txt", "tmp/**"}, dir)
+
+	// Then: the include is replaced by its patterns, nested includes resolve
+	// relative to the including file
+	require.NoError(t, err)
+	assert.Equal(t, []string{"archive/**", "**/dist/**", "*.log", "tmp/**"}, got)
+}
+
+func TestExpandPatternIncludes_NoIncludesUnchanged(t *testing.T) {
+	patterns := []string{"a/**", "b/**"}
+
+	got, err := ExpandPatternIncludes(patterns, t.TempDir())
+
+	require.NoError(t, err)
+	assert.Equal(t, patterns, got)
+}
+
+func TestExpandPatternIncludes_MissingFile(t *testing.T) {
+	// When: referencing a file that does not exist
+	_, err := ExpandPatternIncludes([]string{"@file:missing.txt"}, t.TempDir())
+
+	// Then: the error names the file
+	require.Error(t, err)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Contains(t, err.Error(), "missing.txt")
+}
+
+func TestExpandPatternIncludes_Cycle(t *testing.T) {
+	// Given: two pattern files that include each other
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a/**\n@file:b.txt\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b/**\n@file:a.txt\n"), 0o644))
+
+	// When: expanding either
+	_, err := ExpandPatternIncludes([]string{"@file:a.txt"}, dir)
+
+	// Then: the cycle is reported instead of recursing forever
+	assert.ErrorIs(t, err, ErrPatternIncludeCycle)
+}
+
+func TestExpandPatternIncludes_RepeatedIncludeIsNotACycle(t *testing.T) {
+	// Given: the same file included twice side by side
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a/**\n"), 0o644))
+
+	// When: expanding
+	got, err := ExpandPatternIncludes([]string{"@file:a.txt", "@file:a.txt"}, dir)
+
+	// Then: both expand
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a/**", "a/**"}, got)
+}
+
+func TestLoad_ProjectExcludeIncludesResolveAgainstConfigFile(t *testing.T) {
+	// Given: a project config referencing a shared exclude file next to it
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "exclude.txt"), []byte("generated/**\n"), 0o644))
+	projectConfig := `
+version: 1
+paths:
+  exclude:
+    - "@file:exclude.txt"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".amanmcp.yaml"), []byte(projectConfig), 0o644))
+
+	// When: loading the config
+	cfg, err := Load(projectDir)
+
+	// Then: the effective excludes hold the file's patterns, not the directive
+	require.NoError(t, err)
+	assert.Contains(t, cfg.Paths.Exclude, "generated/**")
+	assert.NotContains(t, cfg.Paths.Exclude, "@file:exclude.txt")
+}
+
+func TestLoad_ProjectExcludeIncludeMissingFails(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	projectDir := t.TempDir()
+	projectConfig := `
+version: 1
+paths:
+  exclude:
+    - "@file:nope.txt"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".amanmcp.yaml"), []byte(projectConfig), 0o644))
+
+	_, err := Load(projectDir)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nope.txt")
+}
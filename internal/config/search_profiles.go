@@ -188,6 +188,45 @@ func (c *Config) SearchProfileRules() search.ProfileRules {
 	return out
 }
 
+// SearchConfidenceThresholds converts the confidence settings into runtime
+// thresholds. Unset values fall back to the search engine defaults.
+func (c *Config) SearchConfidenceThresholds() search.ConfidenceThresholds {
+	return search.ConfidenceThresholds{
+		HighRatio: c.Search.Confidence.HighRatio,
+		LowRatio:  c.Search.Confidence.LowRatio,
+		MinScore:  c.Search.Confidence.MinScore,
+	}
+}
+
+// SearchEngineConfig converts the search and embedding settings into the
+// engine configuration, with the query instruction for embedderModel. Every
+// entry point builds its engine from it so they rank and limit results alike.
+func (c *Config) SearchEngineConfig(embedderModel string) search.EngineConfig {
+	return search.EngineConfig{
+		DefaultLimit:                 c.Search.MaxResults,
+		MaxLimit:                     c.Search.MaxLimit,
+		MaxCandidates:                c.Search.MaxCandidates,
+		StrictLimits:                 c.Search.StrictLimits,
+		MaxChunkBytes:                c.Search.MaxChunkBytes,
+		DefaultWeights:               search.Weights{BM25: c.Search.BM25Weight, Semantic: c.Search.SemanticWeight},
+		RRFConstant:                  c.Search.RRFConstant,
+		FusionStrategy:               search.FusionStrategyName(c.Search.FusionStrategy),
+		FusionNormalization:          search.ScoreNormalization(c.Search.FusionNormalization),
+		SearchTimeout:                search.DefaultConfig().SearchTimeout,
+		MetadataRules:                c.SearchMetadataRules(),
+		ProfileRules:                 c.SearchProfileRules(),
+		RerankerPolicy:               search.RerankerPolicy(c.Search.Reranker.Policy),
+		RerankThreshold:              c.Search.Reranker.Threshold,
+		AutoReindexOnDimensionChange: c.Embeddings.AutoReindex,
+		ReembedOnModelChange:         c.Embeddings.ReembedOnModelChange,
+		PipelineIndexing:             c.Embeddings.PipelineIndexing,
+		EmbedRetry:                   search.DefaultEmbedRetryPolicy(),
+		MaxHighlights:                c.Search.MaxHighlights,
+		Confidence:                   c.SearchConfidenceThresholds(),
+		QueryInstruction:             search.QueryInstructionForModel(embedderModel),
+	}
+}
+
 // SearchMetadataRules adds profile include extensions to the built-in metadata
 // classifier so user profile additions affect runtime source classification.
 func (c *Config) SearchMetadataRules() search.MetadataRules {
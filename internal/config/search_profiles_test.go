@@ -78,3 +78,33 @@ search:
 	assert.Equal(t, search.ProfileReviewCorpus, meta.Profile)
 	assert.True(t, eligibility.Eligible)
 }
+
+func TestSearchEngineConfig_AppliesSearchSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `
+version: 1
+search:
+  fusion_strategy: weighted
+  fusion_normalization: zscore
+  max_limit: 50
+  strict_limits: true
+  max_highlights: 2
+  max_chunk_bytes: 4096
+  confidence:
+    min_score: 0.2
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".amanmcp.yaml"), []byte(configContent), 0o644))
+	cfg, err := Load(tmpDir)
+	require.NoError(t, err)
+
+	engineCfg := cfg.SearchEngineConfig("nomic-embed-text")
+
+	assert.Equal(t, search.FusionStrategyName("weighted"), engineCfg.FusionStrategy)
+	assert.Equal(t, search.ScoreNormalization("zscore"), engineCfg.FusionNormalization)
+	assert.Equal(t, 50, engineCfg.MaxLimit)
+	assert.True(t, engineCfg.StrictLimits)
+	assert.Equal(t, 2, engineCfg.MaxHighlights)
+	assert.Equal(t, 4096, engineCfg.MaxChunkBytes)
+	assert.Equal(t, 0.2, engineCfg.Confidence.MinScore)
+	assert.Equal(t, search.QueryInstructionForModel("nomic-embed-text"), engineCfg.QueryInstruction)
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/Aman-CERP/amanmcp/internal/logging"
 )
 
 // Config holds configuration for the daemon service.
@@ -37,6 +39,10 @@ type Config struct {
 	// AutoStart enables auto-starting daemon from CLI if not running.
 	// Default: false
 	AutoStart bool
+
+	// QueryLog logs every search query to the daemon log (optional).
+	// Nil disables query logging.
+	QueryLog *logging.QueryLogger
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -327,6 +327,7 @@ func (d *Daemon) HandleSearch(ctx context.Context, params SearchParams) (SearchR
 		Profile:           search.Profile(params.Profile),
 		ProfileMismatches: &profileMismatches,
 		BM25Only:          params.BM25Only,
+		BooleanQuery:      params.Boolean,
 		Explain:           params.Explain, // FEAT-UNIX3
 	}
 
@@ -365,6 +366,7 @@ func (d *Daemon) HandleSearch(ctx context.Context, params SearchParams) (SearchR
 
 		// FEAT-UNIX3: Include explain data when requested
 		if params.Explain {
+			result.RawScore = r.RawScore
 			result.BM25Score = r.BM25Score
 			result.VecScore = r.VecScore
 			result.BM25Rank = r.BM25Rank
@@ -511,14 +513,17 @@ func (d *Daemon) loadProject(ctx context.Context, rootPath string) (*projectStat
 	}
 
 	// Open metadata store
-	metadata, err := store.NewSQLiteStore(metadataPath)
+	storeCfg := store.DefaultStoreConfig()
+	storeCfg.CompressContent = cfg.Performance.CompressContent
+	metadata, err := store.NewSQLiteStoreWithConfig(metadataPath, storeCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open metadata: %w", err)
 	}
 
 	// Open BM25 index using factory (SQLite default for concurrent access)
 	bm25BasePath := filepath.Join(dataDir, "bm25")
-	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, store.DefaultBM25Config(), cfg.Search.BM25Backend)
+	bm25Config := store.BM25ConfigWithTermLength(cfg.Search.BM25MinTermLength, cfg.Search.BM25KeepShortTerms)
+	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, bm25Config, cfg.Search.BM25Backend)
 	if err != nil {
 		_ = metadata.Close()
 		return nil, fmt.Errorf("failed to open BM25 index: %w", err)
@@ -527,7 +532,7 @@ func (d *Daemon) loadProject(ctx context.Context, rootPath string) (*projectStat
 	// Open vector store with embedder dimensions
 	dimensions := d.embedder.Dimensions()
 	vectorCfg := store.DefaultVectorStoreConfig(dimensions)
-	vector, err := store.NewHNSWStore(vectorCfg)
+	vector, err := store.NewVectorStoreWithBackend(vectorCfg, cfg.Search.VectorBackend)
 	if err != nil {
 		_ = bm25.Close()
 		_ = metadata.Close()
@@ -545,23 +550,13 @@ func (d *Daemon) loadProject(ctx context.Context, rootPath string) (*projectStat
 	}
 
 	// Create search engine with shared embedder and expander
-	engineCfg := search.EngineConfig{
-		DefaultLimit: cfg.Search.MaxResults,
-		MaxLimit:     100,
-		DefaultWeights: search.Weights{
-			BM25:     cfg.Search.BM25Weight,
-			Semantic: cfg.Search.SemanticWeight,
-		},
-		RRFConstant:    cfg.Search.RRFConstant,
-		SearchTimeout:  search.DefaultConfig().SearchTimeout,
-		MetadataRules:  cfg.SearchMetadataRules(),
-		ProfileRules:   cfg.SearchProfileRules(),
-		RerankerPolicy: search.RerankerPolicy(cfg.Search.Reranker.Policy),
-	}
+	engineCfg := cfg.SearchEngineConfig(d.embedder.ModelName())
 
 	// Build engine options
 	engineOpts := []search.EngineOption{
 		search.WithQueryExpander(d.expander),
+		search.WithBlameProvider(search.NewGitBlameProvider(rootPath)),
+		search.WithQueryLogger(d.config.QueryLog),
 	}
 	// FEAT-RR1: Add reranker if available
 	if d.reranker != nil {
@@ -577,6 +572,11 @@ func (d *Daemon) loadProject(ctx context.Context, rootPath string) (*projectStat
 		_ = metadata.Close()
 		return nil, fmt.Errorf("failed to create search engine: %w", err)
 	}
+	// Start, or resume, converting an index built by a previous embedder
+	// model (embeddings.reembed_on_model_change)
+	if _, err := engine.ReembedIfModelChanged(ctx); err != nil {
+		slog.Warn("reembed_not_started", slog.String("error", err.Error()))
+	}
 
 	return &projectState{
 		rootPath: rootPath,
@@ -99,6 +99,10 @@ type SearchParams struct {
 	// FEAT-DIM1: Useful when embedder is unavailable or for exact keyword matching.
 	BM25Only bool `json:"bm25_only,omitempty"`
 
+	// Boolean parses AND, OR, NOT and parentheses in the query as boolean
+	// operators for keyword matching (see search.SearchOptions.BooleanQuery).
+	Boolean bool `json:"boolean,omitempty"`
+
 	// Explain enables detailed search explanation mode.
 	// FEAT-UNIX3: When true, returns ExplainData with search decision details.
 	Explain bool `json:"explain,omitempty"`
@@ -138,6 +142,7 @@ type SearchResult struct {
 	Stale       bool    `json:"stale"`
 
 	// FEAT-UNIX3: Explain mode fields (only populated when Explain=true)
+	RawScore  float64      `json:"raw_score,omitempty"` // Fusion score before per-query normalization
 	BM25Score float64      `json:"bm25_score,omitempty"`
 	VecScore  float64      `json:"vec_score,omitempty"`
 	BM25Rank  int          `json:"bm25_rank,omitempty"`
@@ -26,6 +26,10 @@ const (
 	DefaultMLXBaseTimeout = 60 * time.Second        // Base timeout for progressive scaling
 	DefaultMLXMaxRetries  = 2                       // Retry attempts for transient failures
 	DefaultMLXBatchSize   = 32                      // Assumed batch size for timeout calculation
+
+	// DefaultMLXMaxTextLength is the MLX server's default input limit in
+	// tokens (MAX_TEXT_LENGTH); longer inputs are truncated by the server.
+	DefaultMLXMaxTextLength = 8192
 )
 
 // MLXConfig holds configuration for MLX embedder
@@ -38,6 +42,10 @@ type MLXConfig struct {
 
 	// SkipHealthCheck skips health check during creation (for testing)
 	SkipHealthCheck bool
+
+	// MaxTextLength is the server's input limit in tokens (0 = ask the
+	// server during the health check, else DefaultMLXMaxTextLength)
+	MaxTextLength int
 }
 
 // DefaultMLXConfig returns default MLX configuration
@@ -55,6 +63,7 @@ type MLXEmbedder struct {
 	config       MLXConfig
 	dims         int
 	model        string
+	maxTokens    int // Input limit in tokens
 	mu           sync.RWMutex
 	closed       bool
 	batchIndex   int  // Track batch progress for thermal timeout scaling
@@ -62,7 +71,10 @@ type MLXEmbedder struct {
 }
 
 // Verify interface implementation at compile time
-var _ Embedder = (*MLXEmbedder)(nil)
+var (
+	_ Embedder     = (*MLXEmbedder)(nil)
+	_ InputLimiter = (*MLXEmbedder)(nil)
+)
 
 // NewMLXEmbedder creates a new MLX embedder
 func NewMLXEmbedder(ctx context.Context, cfg MLXConfig) (*MLXEmbedder, error) {
@@ -86,9 +98,13 @@ func NewMLXEmbedder(ctx context.Context, cfg MLXConfig) (*MLXEmbedder, error) {
 	}
 
 	e := &MLXEmbedder{
-		client: client,
-		config: cfg,
-		model:  cfg.Model,
+		client:    client,
+		config:    cfg,
+		model:     cfg.Model,
+		maxTokens: cfg.MaxTextLength,
+	}
+	if e.maxTokens <= 0 {
+		e.maxTokens = DefaultMLXMaxTextLength
 	}
 
 	// Set dimensions based on model
@@ -112,10 +128,15 @@ func NewMLXEmbedder(ctx context.Context, cfg MLXConfig) (*MLXEmbedder, error) {
 			return nil, fmt.Errorf("MLX health check failed: %w", err)
 		}
 
-		// Try to get actual dimensions from server
+		// Try to get actual dimensions and input limit from server
 		if dims, err := e.getDimensionsFromServer(checkCtx); err == nil {
 			e.dims = dims
 		}
+		if cfg.MaxTextLength <= 0 {
+			if maxTokens, err := e.getMaxTextLengthFromServer(checkCtx); err == nil {
+				e.maxTokens = maxTokens
+			}
+		}
 	}
 
 	slog.Debug("mlx_embedder_created",
@@ -189,6 +210,39 @@ func (e *MLXEmbedder) getDimensionsFromServer(ctx context.Context) (int, error)
 	return 0, fmt.Errorf("model %s not found", e.config.Model)
 }
 
+// getMaxTextLengthFromServer gets the server's input limit in tokens
+func (e *MLXEmbedder) getMaxTextLengthFromServer(ctx context.Context) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.config.Endpoint+"/models", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to get models: status %d", resp.StatusCode)
+	}
+
+	var result mlxServerModelsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, err
+	}
+	if result.Embeddings.MaxTextLength <= 0 {
+		return 0, fmt.Errorf("server reports no max text length")
+	}
+	return result.Embeddings.MaxTextLength, nil
+}
+
+// MaxInputBytes returns the server's input limit in bytes, estimated at
+// BytesPerToken. It implements InputLimiter.
+func (e *MLXEmbedder) MaxInputBytes() int {
+	return e.maxTokens * BytesPerToken
+}
+
 // Embed generates embedding for a single text
 func (e *MLXEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
 	e.mu.RLock()
@@ -450,6 +504,14 @@ type mlxModelsResponse struct {
 	Models map[string]mlxModelInfo `json:"models"`
 }
 
+// mlxServerModelsResponse is the part of the /models response describing
+// the embedding server's limits
+type mlxServerModelsResponse struct {
+	Embeddings struct {
+		MaxTextLength int `json:"max_text_length"`
+	} `json:"embeddings"`
+}
+
 type mlxModelInfo struct {
 	Dimensions int `json:"dimensions"`
 }
@@ -65,6 +65,44 @@ func TestNewMLXEmbedder(t *testing.T) {
 	}
 }
 
+func TestMLXEmbedder_MaxInputBytes(t *testing.T) {
+	// Given: an MLX server reporting its max text length
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/health":
+			_ = json.NewEncoder(w).Encode(map[string]any{"status": "healthy"})
+		case "/models":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"embeddings": map[string]any{"max_text_length": 4096},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// When: creating embedders with and without the health check
+	checked, err := NewMLXEmbedder(context.Background(), MLXConfig{Endpoint: server.URL, Model: "small"})
+	if err != nil {
+		t.Fatalf("failed to create MLXEmbedder: %v", err)
+	}
+	defer checked.Close()
+	unchecked, err := NewMLXEmbedder(context.Background(), MLXConfig{Endpoint: server.URL, Model: "small", SkipHealthCheck: true})
+	if err != nil {
+		t.Fatalf("failed to create MLXEmbedder: %v", err)
+	}
+	defer unchecked.Close()
+
+	// Then: the server's limit is used when asked, the server default otherwise
+	if got := checked.MaxInputBytes(); got != 4096*BytesPerToken {
+		t.Errorf("expected %d max input bytes from the server, got %d", 4096*BytesPerToken, got)
+	}
+	if got := unchecked.MaxInputBytes(); got != DefaultMLXMaxTextLength*BytesPerToken {
+		t.Errorf("expected %d default max input bytes, got %d", DefaultMLXMaxTextLength*BytesPerToken, got)
+	}
+}
+
 // TestMLXEmbedder_Embed tests single text embedding
 func TestMLXEmbedder_Embed(t *testing.T) {
 	// Create mock server that returns embeddings
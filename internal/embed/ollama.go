@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,6 +22,7 @@ type OllamaEmbedder struct {
 	config    OllamaConfig
 	modelName string
 	dims      int
+	ctxLen    int // Input limit in tokens (0 = unknown)
 
 	mu           sync.RWMutex
 	closed       bool
@@ -30,7 +32,10 @@ type OllamaEmbedder struct {
 }
 
 // Verify interface implementation at compile time
-var _ Embedder = (*OllamaEmbedder)(nil)
+var (
+	_ Embedder     = (*OllamaEmbedder)(nil)
+	_ InputLimiter = (*OllamaEmbedder)(nil)
+)
 
 // NewOllamaEmbedder creates a new Ollama embedder
 func NewOllamaEmbedder(ctx context.Context, cfg OllamaConfig) (*OllamaEmbedder, error) {
@@ -86,6 +91,7 @@ func NewOllamaEmbedder(ctx context.Context, cfg OllamaConfig) (*OllamaEmbedder,
 		config:    cfg,
 		modelName: cfg.Model,
 		dims:      cfg.Dimensions,
+		ctxLen:    cfg.ContextLength,
 	}
 
 	// Health check and model discovery (unless skipped for testing)
@@ -111,6 +117,17 @@ func NewOllamaEmbedder(ctx context.Context, cfg OllamaConfig) (*OllamaEmbedder,
 			}
 			e.dims = dims
 		}
+
+		// Input limit is best effort: without it chunks are not truncated
+		if cfg.ContextLength == 0 {
+			ctxLen, err := e.detectContextLength(checkCtx)
+			if err != nil {
+				slog.Debug("ollama_context_length_unknown",
+					slog.String("model", e.modelName),
+					slog.String("error", err.Error()))
+			}
+			e.ctxLen = ctxLen
+		}
 	}
 
 	// Fallback to default dimensions if still not set
@@ -237,6 +254,61 @@ func (e *OllamaEmbedder) detectDimensions(ctx context.Context) (int, error) {
 	return len(result.Embeddings[0]), nil
 }
 
+// detectContextLength returns the number of tokens the model embeds without
+// truncation: its trained context length from /api/show, capped at the
+// num_ctx it runs with (OllamaDefaultNumCtx unless its Modelfile sets one).
+func (e *OllamaEmbedder) detectContextLength(ctx context.Context) (int, error) {
+	body, err := json.Marshal(OllamaShowRequest{Model: e.modelName})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.config.Host+"/api/show", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("show failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result OllamaShowResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	numCtx := OllamaDefaultNumCtx
+	for _, line := range strings.Split(result.Parameters, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "num_ctx" {
+			if n, err := strconv.Atoi(fields[1]); err == nil && n > 0 {
+				numCtx = n
+			}
+		}
+	}
+	for key, value := range result.ModelInfo {
+		if n, ok := value.(float64); ok && strings.HasSuffix(key, ".context_length") && n > 0 {
+			return min(int(n), numCtx), nil
+		}
+	}
+	return 0, fmt.Errorf("model %s reports no context length", e.modelName)
+}
+
+// MaxInputBytes returns the model's input limit in bytes, estimated from
+// its context length at BytesPerToken, or 0 when it is unknown. It
+// implements InputLimiter.
+func (e *OllamaEmbedder) MaxInputBytes() int {
+	return e.ctxLen * BytesPerToken
+}
+
 // Embed generates embedding for a single text
 func (e *OllamaEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
 	e.mu.RLock()
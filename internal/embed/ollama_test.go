@@ -784,6 +784,89 @@ func TestOllamaEmbedder_ProgressiveTimeout_UsedInsteadOfFixedTimeout(t *testing.
 		"Progressive timeout should be at least base timeout")
 }
 
+// ============================================================================
+// TS15: Input Limit From Model Context Length
+// ============================================================================
+
+func TestOllamaEmbedder_MaxInputBytes_FromModelContextLength(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters string
+		modelInfo  map[string]any
+		want       int
+	}{
+		{
+			name:       "num_ctx set in Modelfile caps the trained context",
+			parameters: "num_ctx                        8192\nstop \"<eos>\"",
+			modelInfo:  map[string]any{"general.architecture": "qwen3", "qwen3.context_length": 32768},
+			want:       8192 * BytesPerToken,
+		},
+		{
+			name:      "default num_ctx caps the trained context",
+			modelInfo: map[string]any{"bert.context_length": 8192},
+			want:      OllamaDefaultNumCtx * BytesPerToken,
+		},
+		{
+			name:       "trained context below num_ctx",
+			parameters: "num_ctx 8192",
+			modelInfo:  map[string]any{"bert.context_length": 512},
+			want:       512 * BytesPerToken,
+		},
+		{
+			name: "no context length reported",
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: a server whose /api/show describes the model
+			var base *httptest.Server
+			server := mockOllamaServer(t, 768, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/show" {
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"parameters": tt.parameters,
+						"model_info": tt.modelInfo,
+					})
+					return
+				}
+				base.Config.Handler.ServeHTTP(w, r)
+			})
+			defer server.Close()
+			base = mockOllamaServer(t, 768, nil)
+			defer base.Close()
+
+			cfg := DefaultOllamaConfig()
+			cfg.Host = server.URL
+			cfg.Model = "qwen3-embedding:8b"
+
+			// When: creating the embedder with its health check
+			embedder, err := NewOllamaEmbedder(context.Background(), cfg)
+			require.NoError(t, err)
+			defer func() { _ = embedder.Close() }()
+
+			// Then: the input limit follows the context the model runs with
+			assert.Equal(t, tt.want, embedder.MaxInputBytes())
+			assert.Equal(t, tt.want, MaxInputBytesFor(embedder))
+		})
+	}
+}
+
+func TestOllamaEmbedder_MaxInputBytes_ConfiguredContextLength(t *testing.T) {
+	// Given: a configured context length and no health check
+	cfg := DefaultOllamaConfig()
+	cfg.SkipHealthCheck = true
+	cfg.ContextLength = 1024
+
+	// When: creating the embedder
+	embedder, err := NewOllamaEmbedder(context.Background(), cfg)
+	require.NoError(t, err)
+	defer func() { _ = embedder.Close() }()
+
+	// Then: the configured context length sets the limit
+	assert.Equal(t, 1024*BytesPerToken, embedder.MaxInputBytes())
+}
+
 // ============================================================================
 // Test Helpers
 // ============================================================================
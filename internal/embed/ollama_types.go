@@ -17,6 +17,11 @@ const (
 
 	// OllamaPoolSize for connection pool
 	OllamaPoolSize = 4
+
+	// OllamaDefaultNumCtx is the context length in tokens Ollama runs a
+	// model with when its Modelfile does not set num_ctx. Newer Ollama
+	// versions default higher; this is the conservative floor.
+	OllamaDefaultNumCtx = 2048
 )
 
 // FallbackOllamaModels are tried in order if primary model unavailable.
@@ -56,6 +61,10 @@ type OllamaConfig struct {
 	// PoolSize for HTTP connection pool (default: 4)
 	PoolSize int
 
+	// ContextLength is the model's input limit in tokens (0 = detect from
+	// /api/show during the health check; unknown when it is skipped)
+	ContextLength int
+
 	// SkipHealthCheck skips initial Ollama availability check (for testing)
 	SkipHealthCheck bool
 
@@ -107,6 +116,18 @@ type OllamaEmbedResponse struct {
 	Embeddings [][]float64 `json:"embeddings"`
 }
 
+// OllamaShowRequest is the Ollama /api/show request
+type OllamaShowRequest struct {
+	Model string `json:"model"`
+}
+
+// OllamaShowResponse is the part of the Ollama /api/show response used to
+// find a model's context length
+type OllamaShowResponse struct {
+	Parameters string         `json:"parameters"` // Modelfile parameters, one "name value" per line
+	ModelInfo  map[string]any `json:"model_info"` // Includes "<architecture>.context_length"
+}
+
 // OllamaModelListResponse is the Ollama /api/tags response
 type OllamaModelListResponse struct {
 	Models []OllamaModelInfo `json:"models"`
@@ -0,0 +1,257 @@
+package embed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RouteInput is a text to embed together with where it comes from, for
+// RoutingPolicy to pick an embedder by.
+type RouteInput struct {
+	Text     string
+	Path     string // File path relative to the project root
+	Language string // go, typescript, python, etc.
+}
+
+// RoutingPolicy returns the index, in the order passed to
+// NewRoutingEmbedder, of the embedder that embeds in. Indexes out of range
+// select the first embedder.
+type RoutingPolicy func(in RouteInput) int
+
+// InputRouter is implemented by embedders that embed each input with a
+// model chosen for it, such as RoutingEmbedder. Indexing uses it, when
+// available, instead of EmbedBatch.
+type InputRouter interface {
+	// EmbedRouted returns the embeddings of inputs in order, together with
+	// the model name that produced each of them.
+	EmbedRouted(ctx context.Context, inputs []RouteInput) (embeddings [][]float32, models []string, err error)
+
+	// ModelFor returns the name of the model EmbedRouted embeds in with.
+	ModelFor(in RouteInput) string
+
+	// ModelNames returns the names of all models EmbedRouted may report.
+	ModelNames() []string
+}
+
+// EmbedInputs embeds inputs with e, routed when e is or wraps an
+// InputRouter and in one EmbedBatch call otherwise, and returns the
+// embeddings in order together with the model that produced each.
+func EmbedInputs(ctx context.Context, e Embedder, inputs []RouteInput) ([][]float32, []string, error) {
+	if router := RouterFor(e); router != nil {
+		return router.EmbedRouted(ctx, inputs)
+	}
+
+	texts := make([]string, len(inputs))
+	for i, in := range inputs {
+		texts[i] = in.Text
+	}
+	embeddings, err := e.EmbedBatch(ctx, texts)
+	if err != nil {
+		return nil, nil, err
+	}
+	models := make([]string, len(embeddings))
+	for i := range models {
+		models[i] = e.ModelName()
+	}
+	return embeddings, models, nil
+}
+
+// ModelNamesFor returns the names of the models whose embeddings e
+// produces: all routed models for an InputRouter, else e's model.
+func ModelNamesFor(e Embedder) []string {
+	if router := RouterFor(e); router != nil {
+		return router.ModelNames()
+	}
+	return []string{e.ModelName()}
+}
+
+// ModelNameFor returns the name of the model e embeds in with.
+func ModelNameFor(e Embedder, in RouteInput) string {
+	if router := RouterFor(e); router != nil {
+		return router.ModelFor(in)
+	}
+	return e.ModelName()
+}
+
+// RouterFor returns e as an InputRouter if it (or the embedder it wraps,
+// for CachedEmbedder) implements one, and nil otherwise.
+func RouterFor(e Embedder) InputRouter {
+	for e != nil {
+		if r, ok := e.(InputRouter); ok {
+			return r
+		}
+		wrapper, ok := e.(interface{ Inner() Embedder })
+		if !ok {
+			break
+		}
+		e = wrapper.Inner()
+	}
+	return nil
+}
+
+// RoutingEmbedder embeds each chunk with one of several embedders of the
+// same dimension, chosen by a RoutingPolicy, e.g. a cheap local model for
+// large low-priority files and an accurate remote one for hot paths.
+//
+// Only indexing routes, through EmbedRouted; Embed and EmbedBatch, which
+// also embed queries, always use the first embedder. Vectors of the other
+// embedders are therefore only comparable with queries when the models
+// share an embedding space, such as a model and its quantized variant.
+type RoutingEmbedder struct {
+	embedders []Embedder
+	policy    RoutingPolicy
+	models    []string
+	name      string
+}
+
+// NewRoutingEmbedder returns a RoutingEmbedder over embedders, the first of
+// which is the default. It fails when no embedder or policy is given or
+// when the embedders' dimensions differ.
+func NewRoutingEmbedder(policy RoutingPolicy, embedders ...Embedder) (*RoutingEmbedder, error) {
+	if policy == nil {
+		return nil, errors.New("routing policy is required")
+	}
+	if len(embedders) == 0 {
+		return nil, errors.New("at least one embedder is required")
+	}
+
+	dims := embedders[0].Dimensions()
+	names := make([]string, len(embedders))
+	var models []string
+	for i, e := range embedders {
+		if e.Dimensions() != dims {
+			return nil, fmt.Errorf("embedder %s has %d dimensions, %s has %d",
+				e.ModelName(), e.Dimensions(), embedders[0].ModelName(), dims)
+		}
+		names[i] = e.ModelName()
+		if !slices.Contains(models, names[i]) {
+			models = append(models, names[i])
+		}
+	}
+
+	return &RoutingEmbedder{
+		embedders: embedders,
+		policy:    policy,
+		models:    models,
+		name:      "routed(" + strings.Join(names, ",") + ")",
+	}, nil
+}
+
+// Route returns the embedder the policy selects for in.
+func (r *RoutingEmbedder) Route(in RouteInput) Embedder {
+	i := r.policy(in)
+	if i < 0 || i >= len(r.embedders) {
+		i = 0
+	}
+	return r.embedders[i]
+}
+
+// ModelFor returns the name of the model the policy selects for in.
+func (r *RoutingEmbedder) ModelFor(in RouteInput) string {
+	return r.Route(in).ModelName()
+}
+
+// ModelNames returns the names of the routed models, without duplicates.
+func (r *RoutingEmbedder) ModelNames() []string {
+	return slices.Clone(r.models)
+}
+
+// EmbedRouted embeds each input with the embedder the policy selects for it,
+// in one batch per embedder. See InputRouter.
+func (r *RoutingEmbedder) EmbedRouted(ctx context.Context, inputs []RouteInput) ([][]float32, []string, error) {
+	groups := make(map[Embedder][]int)
+	var order []Embedder
+	for i, in := range inputs {
+		e := r.Route(in)
+		if _, ok := groups[e]; !ok {
+			order = append(order, e)
+		}
+		groups[e] = append(groups[e], i)
+	}
+
+	embeddings := make([][]float32, len(inputs))
+	models := make([]string, len(inputs))
+	for _, e := range order {
+		indexes := groups[e]
+		texts := make([]string, len(indexes))
+		for j, i := range indexes {
+			texts[j] = inputs[i].Text
+		}
+		generated, err := e.EmbedBatch(ctx, texts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to embed with %s: %w", e.ModelName(), err)
+		}
+		if len(generated) != len(texts) {
+			return nil, nil, fmt.Errorf("%s returned %d embeddings for %d texts", e.ModelName(), len(generated), len(texts))
+		}
+		for j, i := range indexes {
+			embeddings[i] = generated[j]
+			models[i] = e.ModelName()
+		}
+	}
+	return embeddings, models, nil
+}
+
+// Embed embeds text with the first embedder.
+func (r *RoutingEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return r.embedders[0].Embed(ctx, text)
+}
+
+// EmbedBatch embeds texts with the first embedder.
+func (r *RoutingEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	return r.embedders[0].EmbedBatch(ctx, texts)
+}
+
+// Dimensions returns the dimension shared by the routed embedders.
+func (r *RoutingEmbedder) Dimensions() int {
+	return r.embedders[0].Dimensions()
+}
+
+// ModelName returns a composite name listing the routed models, e.g.
+// "routed(nomic-embed-text,text-embedding-3-small)".
+func (r *RoutingEmbedder) ModelName() string {
+	return r.name
+}
+
+// Available reports whether every routed embedder is ready, since any of
+// them may be selected.
+func (r *RoutingEmbedder) Available(ctx context.Context) bool {
+	for _, e := range r.embedders {
+		if !e.Available(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// Close closes all routed embedders.
+func (r *RoutingEmbedder) Close() error {
+	var errs []error
+	for _, e := range r.embedders {
+		if err := e.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetBatchIndex passes through to all routed embedders.
+func (r *RoutingEmbedder) SetBatchIndex(idx int) {
+	for _, e := range r.embedders {
+		e.SetBatchIndex(idx)
+	}
+}
+
+// SetFinalBatch passes through to all routed embedders.
+func (r *RoutingEmbedder) SetFinalBatch(isFinal bool) {
+	for _, e := range r.embedders {
+		e.SetFinalBatch(isFinal)
+	}
+}
+
+// Verify RoutingEmbedder implements Embedder and InputRouter.
+var _ Embedder = (*RoutingEmbedder)(nil)
+var _ InputRouter = (*RoutingEmbedder)(nil)
@@ -0,0 +1,118 @@
+package embed
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNamedMockEmbedder returns a mock embedder whose vectors start with marker.
+func newNamedMockEmbedder(name string, dims int, marker float32) *mockEmbedder {
+	m := newMockEmbedder(dims)
+	m.modelName = name
+	m.returnedVector = append([]float32{marker}, m.returnedVector[1:]...)
+	return m
+}
+
+// routeGenerated sends generated files to the second embedder.
+func routeGenerated(in RouteInput) int {
+	if strings.HasPrefix(in.Path, "gen/") {
+		return 1
+	}
+	return 0
+}
+
+func TestNewRoutingEmbedder_Validates(t *testing.T) {
+	accurate := newNamedMockEmbedder("accurate", 768, 1)
+
+	// When: the embedders' dimensions differ
+	_, err := NewRoutingEmbedder(routeGenerated, accurate, newNamedMockEmbedder("small", 384, 2))
+
+	// Then: construction fails naming both models
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "small")
+	assert.Contains(t, err.Error(), "accurate")
+
+	// And: a policy and at least one embedder are required
+	_, err = NewRoutingEmbedder(nil, accurate)
+	assert.Error(t, err)
+	_, err = NewRoutingEmbedder(routeGenerated)
+	assert.Error(t, err)
+}
+
+func TestRoutingEmbedder_EmbedRouted(t *testing.T) {
+	// Given: an accurate default embedder and a cheap one for generated files
+	accurate := newNamedMockEmbedder("accurate", 768, 1)
+	cheap := newNamedMockEmbedder("cheap", 768, 2)
+	router, err := NewRoutingEmbedder(routeGenerated, accurate, cheap)
+	require.NoError(t, err)
+
+	// When: embedding inputs from both kinds of paths
+	embeddings, models, err := router.EmbedRouted(context.Background(), []RouteInput{
+		{Text: "a", Path: "internal/core.go"},
+		{Text: "b", Path: "gen/api.pb.go"},
+		{Text: "c", Path: "cmd/main.go"},
+		{Text: "d", Path: "gen/types.go"},
+	})
+
+	// Then: each input is embedded by its routed model, one batch per model,
+	// and results keep input order
+	require.NoError(t, err)
+	assert.Equal(t, []string{"accurate", "cheap", "accurate", "cheap"}, models)
+	require.Len(t, embeddings, 4)
+	assert.Equal(t, []float32{1, 2, 1, 2}, []float32{embeddings[0][0], embeddings[1][0], embeddings[2][0], embeddings[3][0]})
+	assert.Equal(t, int64(1), accurate.batchCalls.Load())
+	assert.Equal(t, int64(1), cheap.batchCalls.Load())
+}
+
+func TestRoutingEmbedder_Embedder(t *testing.T) {
+	// Given: a routing embedder whose policy selects out of range
+	accurate := newNamedMockEmbedder("accurate", 768, 1)
+	cheap := newNamedMockEmbedder("cheap", 768, 2)
+	router, err := NewRoutingEmbedder(func(RouteInput) int { return 5 }, accurate, cheap)
+	require.NoError(t, err)
+
+	// Then: it reports a composite name, the shared dimension, and routes
+	// queries and unknown indexes to the first embedder
+	assert.Equal(t, "routed(accurate,cheap)", router.ModelName())
+	assert.Equal(t, 768, router.Dimensions())
+	assert.Same(t, Embedder(accurate), router.Route(RouteInput{Path: "x.go"}))
+	vec, err := router.Embed(context.Background(), "query")
+	require.NoError(t, err)
+	assert.Equal(t, float32(1), vec[0])
+
+	// And: it reports the routed models, each once
+	router, err = NewRoutingEmbedder(routeGenerated, accurate, cheap, newNamedMockEmbedder("accurate", 768, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"accurate", "cheap"}, router.ModelNames())
+	assert.Equal(t, "cheap", router.ModelFor(RouteInput{Path: "gen/api.pb.go"}))
+	assert.Equal(t, []string{"accurate", "cheap"}, ModelNamesFor(NewCachedEmbedder(router, 10)))
+	assert.Equal(t, []string{"accurate"}, ModelNamesFor(accurate))
+	assert.Equal(t, "accurate", ModelNameFor(accurate, RouteInput{Path: "gen/api.pb.go"}))
+
+	// And: it is found behind a cache wrapper
+	assert.Equal(t, InputRouter(router), RouterFor(NewCachedEmbedder(router, 10)))
+	assert.Nil(t, RouterFor(accurate))
+}
+
+func TestEmbedInputs(t *testing.T) {
+	accurate := newNamedMockEmbedder("accurate", 768, 1)
+	cheap := newNamedMockEmbedder("cheap", 768, 2)
+	router, err := NewRoutingEmbedder(routeGenerated, accurate, cheap)
+	require.NoError(t, err)
+	inputs := []RouteInput{{Text: "a", Path: "main.go"}, {Text: "b", Path: "gen/api.go"}}
+
+	// When: embedding through a router and through a plain embedder
+	_, routedModels, err := EmbedInputs(context.Background(), router, inputs)
+	require.NoError(t, err)
+	embeddings, plainModels, err := EmbedInputs(context.Background(), accurate, inputs)
+	require.NoError(t, err)
+
+	// Then: the router picks a model per input, the plain embedder embeds all
+	assert.Equal(t, []string{"accurate", "cheap"}, routedModels)
+	assert.Equal(t, []string{"accurate", "accurate"}, plainModels)
+	assert.Len(t, embeddings, 2)
+}
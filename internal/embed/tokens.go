@@ -0,0 +1,64 @@
+package embed
+
+// BytesPerToken is the average number of bytes per token assumed by
+// HeuristicTokenizer. Source code and English prose both average close to
+// four bytes per token across common BPE vocabularies.
+const BytesPerToken = 4
+
+// Tokenizer counts the tokens a model sees in text. Counts are used for
+// estimates such as token budgets and cost reports, not for truncation, so
+// approximate implementations are fine.
+//
+// Embedders whose model has a known tokenizer can implement Tokenizer to
+// replace the heuristic; TokenizerFor picks it up.
+type Tokenizer interface {
+	CountTokens(text string) int
+}
+
+// TokenizerFunc adapts a function to Tokenizer.
+type TokenizerFunc func(text string) int
+
+// CountTokens calls f(text).
+func (f TokenizerFunc) CountTokens(text string) int {
+	return f(text)
+}
+
+// HeuristicTokenizer estimates tokens as BytesPerToken bytes each, rounded
+// up, so any non-empty text counts as at least one token.
+type HeuristicTokenizer struct{}
+
+// CountTokens returns the estimated token count of text.
+func (HeuristicTokenizer) CountTokens(text string) int {
+	return EstimateTokensForBytes(len(text))
+}
+
+// EstimateTokens estimates the token count of text with HeuristicTokenizer.
+// Use TokenizerFor when the embedder is known.
+func EstimateTokens(text string) int {
+	return HeuristicTokenizer{}.CountTokens(text)
+}
+
+// EstimateTokensForBytes estimates the token count of n bytes of text like
+// HeuristicTokenizer, for when only the size of the text is at hand.
+func EstimateTokensForBytes(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return (n + BytesPerToken - 1) / BytesPerToken
+}
+
+// TokenizerFor returns the tokenizer of e if it (or the embedder it wraps,
+// for CachedEmbedder) implements Tokenizer, and HeuristicTokenizer otherwise.
+func TokenizerFor(e Embedder) Tokenizer {
+	for e != nil {
+		if t, ok := e.(Tokenizer); ok {
+			return t
+		}
+		wrapper, ok := e.(interface{ Inner() Embedder })
+		if !ok {
+			break
+		}
+		e = wrapper.Inner()
+	}
+	return HeuristicTokenizer{}
+}
@@ -0,0 +1,54 @@
+package embed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"a", 1},
+		{"abcd", 1},
+		{"abcde", 2},
+		{"func main() {}", 4},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, EstimateTokens(tt.text), tt.text)
+	}
+}
+
+func TestEstimateTokensForBytes(t *testing.T) {
+	assert.Equal(t, 0, EstimateTokensForBytes(-1))
+	assert.Equal(t, 0, EstimateTokensForBytes(0))
+	assert.Equal(t, 1, EstimateTokensForBytes(4))
+	assert.Equal(t, EstimateTokens("func main() {}"), EstimateTokensForBytes(len("func main() {}")))
+}
+
+// countingEmbedder is a StaticEmbedder with its own tokenizer.
+type countingEmbedder struct {
+	*StaticEmbedder
+}
+
+func (countingEmbedder) CountTokens(text string) int { return len(text) }
+
+func TestTokenizerFor(t *testing.T) {
+	// Given: embedders with and without their own tokenizer
+	plain := NewStaticEmbedder()
+	counting := countingEmbedder{StaticEmbedder: NewStaticEmbedder()}
+
+	// Then: the embedder's tokenizer is used when present, also through a cache
+	assert.Equal(t, HeuristicTokenizer{}, TokenizerFor(plain))
+	assert.Equal(t, 5, TokenizerFor(counting).CountTokens("hello"))
+	assert.Equal(t, 5, TokenizerFor(NewCachedEmbedder(counting, 8)).CountTokens("hello"))
+	assert.Equal(t, HeuristicTokenizer{}, TokenizerFor(nil))
+}
+
+func TestTokenizerFunc(t *testing.T) {
+	words := TokenizerFunc(func(text string) int { return len(text) / 2 })
+	assert.Equal(t, 3, words.CountTokens("abcdef"))
+}
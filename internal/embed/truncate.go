@@ -0,0 +1,50 @@
+package embed
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// InputLimiter is implemented by embedders whose model accepts inputs up to
+// a known size. Callers truncate longer texts before embedding rather than
+// relying on each backend to fail or cut them in its own way.
+type InputLimiter interface {
+	// MaxInputBytes returns the largest input in bytes the model embeds
+	// without truncation; 0 means unknown.
+	MaxInputBytes() int
+}
+
+// MaxInputBytesFor returns the input limit of e if it (or the embedder it
+// wraps, for CachedEmbedder) implements InputLimiter, and 0 otherwise.
+func MaxInputBytesFor(e Embedder) int {
+	for e != nil {
+		if l, ok := e.(InputLimiter); ok {
+			return l.MaxInputBytes()
+		}
+		wrapper, ok := e.(interface{ Inner() Embedder })
+		if !ok {
+			break
+		}
+		e = wrapper.Inner()
+	}
+	return 0
+}
+
+// TruncateInput shortens text to at most maxBytes bytes and reports whether
+// it did. It cuts after the last complete line when that keeps at least half
+// of the allowed bytes, and otherwise at the last whole UTF-8 character.
+// maxBytes <= 0 disables truncation.
+func TruncateInput(text string, maxBytes int) (string, bool) {
+	if maxBytes <= 0 || len(text) <= maxBytes {
+		return text, false
+	}
+
+	if nl := strings.LastIndexByte(text[:maxBytes], '\n'); nl >= maxBytes/2 {
+		return text[:nl], true
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut], true
+}
@@ -0,0 +1,56 @@
+package embed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxBytes  int
+		want      string
+		truncated bool
+	}{
+		{name: "disabled", text: "abcdef", maxBytes: 0, want: "abcdef"},
+		{name: "within limit", text: "abc", maxBytes: 3, want: "abc"},
+		{name: "cuts at last line", text: "line one\nline two\nline three", maxBytes: 20, want: "line one\nline two", truncated: true},
+		{name: "line too early, cuts bytes", text: "a\nbcdefghijkl", maxBytes: 8, want: "a\nbcdefg", truncated: true},
+		{name: "keeps runes whole", text: "héllo", maxBytes: 2, want: "h", truncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: truncating
+			got, truncated := TruncateInput(tt.text, tt.maxBytes)
+
+			// Then: the text is cut where expected and never exceeds the limit
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.truncated, truncated)
+			if tt.maxBytes > 0 {
+				assert.LessOrEqual(t, len(got), tt.maxBytes)
+			}
+		})
+	}
+}
+
+// limitedEmbedder is a StaticEmbedder768 that advertises an input limit.
+type limitedEmbedder struct {
+	*StaticEmbedder768
+}
+
+func (limitedEmbedder) MaxInputBytes() int { return 4096 }
+
+func TestMaxInputBytesFor(t *testing.T) {
+	// Given: embedders with and without an advertised limit
+	plain := NewStaticEmbedder768()
+	limited := limitedEmbedder{plain}
+
+	// Then: the limit is found, also through a cache wrapper
+	assert.Equal(t, 0, MaxInputBytesFor(plain))
+	assert.Equal(t, 4096, MaxInputBytesFor(limited))
+	cached := NewCachedEmbedder(limited, 10)
+	assert.Equal(t, 4096, MaxInputBytesFor(cached))
+}
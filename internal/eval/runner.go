@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Aman-CERP/amanmcp/internal/embed"
 	"gopkg.in/yaml.v3"
 )
 
@@ -567,12 +568,12 @@ func failureReason(query Query, results []SearchResult) string {
 }
 
 func estimateTokens(query Query, response SearchResponse) TokenEstimate {
-	queryTokens := estimateUTF8Tokens(query.Query)
+	queryTokens := embed.EstimateTokens(query.Query)
 	responseBytes := response.ResponseBytes
 	if responseBytes == 0 {
 		responseBytes = compactJSONLen(response.Results)
 	}
-	resultTokens := estimateTokensFromBytes(responseBytes)
+	resultTokens := embed.EstimateTokensForBytes(responseBytes)
 	perResult := 0.0
 	if len(response.Results) > 0 {
 		perResult = float64(resultTokens) / float64(len(response.Results))
@@ -587,10 +588,6 @@ func estimateTokens(query Query, response SearchResponse) TokenEstimate {
 	}
 }
 
-func estimateUTF8Tokens(s string) int {
-	return estimateTokensFromBytes(len([]byte(s)))
-}
-
 func compactJSONLen(v any) int {
 	data, err := json.Marshal(v)
 	if err != nil {
@@ -599,13 +596,6 @@ func compactJSONLen(v any) int {
 	return len(data)
 }
 
-func estimateTokensFromBytes(n int) int {
-	if n <= 0 {
-		return 0
-	}
-	return int(math.Ceil(float64(n) / 4.0))
-}
-
 func buildReport(opts Options, results []QueryResult) *Report {
 	report := &Report{
 		Run: RunMetadata{
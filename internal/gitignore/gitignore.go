@@ -21,12 +21,13 @@ type Matcher struct {
 
 // rule represents a single compiled gitignore pattern.
 type rule struct {
-	pattern  string         // original pattern
-	regex    *regexp.Regexp // compiled regex
-	negation bool           // starts with !
-	dirOnly  bool           // ends with /
-	anchored bool           // contains / or starts with /
-	base     string         // base directory (for nested .gitignore)
+	pattern  string            // original pattern
+	regex    *regexp.Regexp    // compiled regex
+	match    func(string) bool // precompiled matcher, equivalent to regex.MatchString
+	negation bool              // starts with !
+	dirOnly  bool              // ends with /
+	anchored bool              // contains / or starts with /
+	base     string            // base directory (for nested .gitignore)
 }
 
 // New creates a new empty Matcher.
@@ -101,9 +102,10 @@ func (m *Matcher) AddPatternWithBase(pattern, base string) {
 		r.anchored = true
 	}
 
-	// Compile pattern to regex
+	// Compile pattern to regex, and to a cheaper matcher where one exists
 	regex := patternToRegex(pattern)
 	r.regex = regexp.MustCompile("^" + regex + "$")
+	r.match = compileMatch(pattern, r.regex)
 
 	m.mu.Lock()
 	m.rules = append(m.rules, r)
@@ -141,8 +143,28 @@ func (m *Matcher) Match(path string, isDir bool) bool {
 
 	ignored := false
 
-	for _, r := range m.rules {
-		if m.matchRule(path, isDir, r) {
+	// Split the path once, and once per base for nested .gitignore rules.
+	// Rules from one file are contiguous, so remembering the last base is
+	// enough.
+	root := newTarget(path)
+	var based target
+	var lastBase string
+	underBase := false
+
+	for i := range m.rules {
+		r := &m.rules[i]
+		t := root
+		if r.base != "" {
+			if r.base != lastBase {
+				lastBase = r.base
+				based, underBase = root.relativeTo(r.base)
+			}
+			if !underBase {
+				continue
+			}
+			t = based
+		}
+		if r.matches(t, isDir) {
 			ignored = !r.negation
 		}
 	}
@@ -150,31 +172,40 @@ func (m *Matcher) Match(path string, isDir bool) bool {
 	return ignored
 }
 
-// matchRule checks if a path matches a single rule.
-// Note: Directory-only patterns (ending with /) can match files inside that directory.
-// For pattern "temp/", path "temp/file.go" should match.
-func (m *Matcher) matchRule(path string, isDir bool, r rule) bool {
-	// If rule has a base, only match paths under that base
-	if r.base != "" {
-		if !strings.HasPrefix(path, r.base+"/") && path != r.base {
-			return false
-		}
-		// Remove base from path for matching
-		if path == r.base {
-			path = filepath.Base(path)
-		} else {
-			path = strings.TrimPrefix(path, r.base+"/")
-		}
+// target is a slash-separated path split into components for matching.
+type target struct {
+	path  string
+	parts []string
+}
+
+func newTarget(path string) target {
+	return target{path: path, parts: strings.Split(path, "/")}
+}
+
+// relativeTo returns the target relative to base, or false if it is not
+// under base. The base directory itself matches as its own name.
+func (t target) relativeTo(base string) (target, bool) {
+	if t.path == base {
+		return newTarget(filepath.Base(t.path)), true
+	}
+	if !strings.HasPrefix(t.path, base+"/") {
+		return target{}, false
 	}
+	return newTarget(strings.TrimPrefix(t.path, base+"/")), true
+}
 
-	// Get path components
-	parts := strings.Split(path, "/")
+// matches checks if a path (already made relative to the rule's base)
+// matches the rule.
+// Note: Directory-only patterns (ending with /) can match files inside that directory.
+// For pattern "temp/", path "temp/file.go" should match.
+func (r *rule) matches(t target, isDir bool) bool {
+	path, parts := t.path, t.parts
 	basename := parts[len(parts)-1]
 
 	// For anchored patterns, the pattern must match from the start
 	if r.anchored {
 		// Anchored pattern: must match the full path or path prefix
-		if r.regex.MatchString(path) {
+		if r.match(path) {
 			if r.dirOnly {
 				return isDir
 			}
@@ -183,11 +214,13 @@ func (m *Matcher) matchRule(path string, isDir bool, r rule) bool {
 		// Also check if pattern matches as a prefix (for files inside matched dir)
 		if r.dirOnly {
 			// Check if path starts with the matched directory
-			for i := range parts[:len(parts)-1] {
-				checkPath := strings.Join(parts[:i+1], "/")
-				if r.regex.MatchString(checkPath) {
+			end := 0
+			for _, part := range parts[:len(parts)-1] {
+				end += len(part)
+				if r.match(path[:end]) {
 					return true
 				}
+				end++ // the separating slash
 			}
 		}
 		return false
@@ -198,7 +231,7 @@ func (m *Matcher) matchRule(path string, isDir bool, r rule) bool {
 	if r.dirOnly {
 		// Check if any directory component matches
 		for i, part := range parts {
-			if r.regex.MatchString(part) {
+			if r.match(part) {
 				// If it's the last component, it must be a directory
 				if i == len(parts)-1 {
 					return isDir
@@ -212,18 +245,18 @@ func (m *Matcher) matchRule(path string, isDir bool, r rule) bool {
 
 	// Non-anchored, non-dir-only pattern:
 	// Check if pattern matches basename
-	if r.regex.MatchString(basename) {
+	if r.match(basename) {
 		return true
 	}
 
 	// Also check full path (for patterns with **)
-	if r.regex.MatchString(path) {
+	if r.match(path) {
 		return true
 	}
 
 	// Check each path component
 	for _, part := range parts {
-		if r.regex.MatchString(part) {
+		if r.match(part) {
 			return true
 		}
 	}
@@ -231,6 +264,34 @@ func (m *Matcher) matchRule(path string, isDir bool, r rule) bool {
 	return false
 }
 
+// compileMatch returns a matcher equivalent to re.MatchString, where re is
+// the compiled form of pattern. Literal names and single-star prefix/suffix
+// globs ("*.log", "debug*"), which make up most real .gitignore files, are
+// matched with plain string operations; everything else uses the regex.
+func compileMatch(pattern string, re *regexp.Regexp) func(string) bool {
+	const special = `*?[\`
+
+	if !strings.ContainsAny(pattern, special) {
+		return func(s string) bool { return s == pattern }
+	}
+
+	// "*suffix": * matches anything except /
+	if rest, ok := strings.CutPrefix(pattern, "*"); ok && !strings.ContainsAny(rest, special+"/") {
+		return func(s string) bool {
+			return strings.HasSuffix(s, rest) && !strings.Contains(s[:len(s)-len(rest)], "/")
+		}
+	}
+
+	// "prefix*"
+	if rest, ok := strings.CutSuffix(pattern, "*"); ok && !strings.ContainsAny(rest, special+"/") {
+		return func(s string) bool {
+			return strings.HasPrefix(s, rest) && !strings.Contains(s[len(rest):], "/")
+		}
+	}
+
+	return re.MatchString
+}
+
 // patternToRegex converts a gitignore pattern to a regex string.
 func patternToRegex(pattern string) string {
 	var result strings.Builder
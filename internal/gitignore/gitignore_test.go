@@ -1,8 +1,10 @@
 package gitignore
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sync"
 	"testing"
 
@@ -853,3 +855,82 @@ func TestMatchesAnyPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestCompileMatch_EquivalentToRegex(t *testing.T) {
+	patterns := []string{
+		"foo.txt", "build", "*.log", "*", "*.", "debug*", "log*", "*~", "a*b",
+		"**/tmp", "doc/**", "src/*.go", "file?.txt", "[abc].go", `\#notes`, "file ",
+		"foo.bar+baz", "(x)", "node_modules",
+	}
+	inputs := []string{
+		"", "foo.txt", "foo.txtx", "a/foo.txt", "build", "builder", "app.log", "a/app.log",
+		".log", "log", "debug", "debug.out", "debug/out", "logs", "x~", "~", "ab", "axxb",
+		"a/b", "tmp", "x/tmp", "doc/a/b", "src/main.go", "src/a/main.go", "file1.txt",
+		"a.go", "d.go", "#notes", "file ", "foo.bar+baz", "(x)", "node_modules",
+	}
+
+	for _, pattern := range patterns {
+		re := regexp.MustCompile("^" + patternToRegex(pattern) + "$")
+		match := compileMatch(pattern, re)
+		for _, in := range inputs {
+			assert.Equal(t, re.MatchString(in), match(in), "pattern %q, input %q", pattern, in)
+		}
+	}
+}
+
+// =============================================================================
+// Benchmarks
+// =============================================================================
+
+// benchmarkGitignore returns a 200-line .gitignore in the style of large
+// real-world projects: literal names, extension globs, anchored paths,
+// directory-only rules, ** patterns and negations.
+func benchmarkGitignore() []string {
+	lines := []string{
+		"# Build output", "/bin/", "/dist/", "build/", "out/", "*.o", "*.a", "*.so", "*.exe",
+		"*.test", "*.out", "coverage.txt", "*.prof", "vendor/", "node_modules/", ".env",
+		".env.*", "!.env.example", "*.log", "logs/", "**/tmp/", "**/*.bak", "docs/_build/",
+		"/.idea/", ".vscode/", "*.swp", "*~", ".DS_Store", "Thumbs.db", "*.pyc", "__pycache__/",
+		"target/", "*.class", "*.jar", "!gradle-wrapper.jar", "/coverage/", "*.lcov", ".cache/",
+	}
+	for i := len(lines); i < 200; i++ {
+		switch i % 5 {
+		case 0:
+			lines = append(lines, fmt.Sprintf("*.gen%d", i))
+		case 1:
+			lines = append(lines, fmt.Sprintf("generated_%d/", i))
+		case 2:
+			lines = append(lines, fmt.Sprintf("/fixtures/case%d/**", i))
+		case 3:
+			lines = append(lines, fmt.Sprintf("scratch%d.txt", i))
+		default:
+			lines = append(lines, fmt.Sprintf("pkg/**/mock_%d_*.go", i))
+		}
+	}
+	return lines
+}
+
+func BenchmarkMatcher_Match_LargeGitignore(b *testing.B) {
+	m := New()
+	for _, line := range benchmarkGitignore() {
+		m.AddPattern(line)
+	}
+	paths := []string{
+		"internal/search/engine.go",
+		"cmd/amanmcp/cmd/serve.go",
+		"pkg/indexer/hybrid_test.go",
+		"docs/concepts/hybrid-search/advanced.md",
+		"node_modules/react/index.js",
+		"internal/store/metadata.go.bak",
+		"build/output.o",
+		".env.example",
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range paths {
+			m.Match(p, false)
+		}
+	}
+}
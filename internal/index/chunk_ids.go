@@ -0,0 +1,67 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/chunk"
+)
+
+// ChunkIDScheme selects how indexed chunks get their IDs.
+type ChunkIDScheme string
+
+const (
+	// ChunkIDSchemeContent keeps the IDs assigned by the chunkers, which
+	// hash the file path and chunk content (plus a chunker-specific
+	// disambiguator such as a symbol name). IDs survive line shifts.
+	ChunkIDSchemeContent ChunkIDScheme = "content"
+
+	// ChunkIDSchemePositional derives IDs from the file path, start line and
+	// content hash, so a chunk keeps its ID, and with it its stored
+	// embedding, exactly when it keeps both its content and its position.
+	// Two chunks of a file with the same start line and content get a
+	// numeric disambiguator in file order (":1", ":2", ...).
+	ChunkIDSchemePositional ChunkIDScheme = "positional"
+)
+
+// ParseChunkIDScheme parses a chunk ID scheme name. Empty selects
+// ChunkIDSchemeContent.
+func ParseChunkIDScheme(name string) (ChunkIDScheme, error) {
+	switch scheme := ChunkIDScheme(strings.ToLower(strings.TrimSpace(name))); scheme {
+	case "", ChunkIDSchemeContent:
+		return ChunkIDSchemeContent, nil
+	case ChunkIDSchemePositional:
+		return scheme, nil
+	default:
+		return "", fmt.Errorf("unknown chunk ID scheme %q (want %q or %q)", name, ChunkIDSchemeContent, ChunkIDSchemePositional)
+	}
+}
+
+// assignChunkIDs rewrites the IDs of one file's chunks according to scheme.
+// It must run after any step that changes chunk content, and before chunk
+// IDs are used as keys. The file is identified by its project-relative path
+// rather than a file ID, so the full indexer and the watcher, which derive
+// file IDs differently, agree on chunk IDs.
+//
+// Switching schemes changes every chunk ID, so an existing index has to be
+// rebuilt (amanmcp index --force); until then incremental updates replace a
+// file's chunks wholesale as usual and reuse embeddings by content.
+func assignChunkIDs(scheme ChunkIDScheme, filePath string, chunks []*chunk.Chunk) {
+	if scheme != ChunkIDSchemePositional {
+		return
+	}
+
+	seen := make(map[string]int, len(chunks))
+	for _, c := range chunks {
+		if c == nil {
+			continue
+		}
+		base := fmt.Sprintf("%s:%d:%s", filePath, c.StartLine, hashString(c.Content))
+		input := base
+		if n := seen[base]; n > 0 {
+			input = fmt.Sprintf("%s:%d", base, n)
+		}
+		seen[base]++
+		c.ID = hashString(input)
+	}
+}
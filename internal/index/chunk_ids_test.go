@@ -0,0 +1,77 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/chunk"
+)
+
+func TestParseChunkIDScheme(t *testing.T) {
+	scheme, err := ParseChunkIDScheme("")
+	require.NoError(t, err)
+	assert.Equal(t, ChunkIDSchemeContent, scheme)
+
+	scheme, err = ParseChunkIDScheme(" Positional ")
+	require.NoError(t, err)
+	assert.Equal(t, ChunkIDSchemePositional, scheme)
+
+	_, err = ParseChunkIDScheme("random")
+	assert.Error(t, err)
+}
+
+func TestAssignChunkIDs_ContentSchemeKeepsChunkerIDs(t *testing.T) {
+	chunks := []*chunk.Chunk{{ID: "from-chunker", StartLine: 1, Content: "a"}}
+
+	assignChunkIDs(ChunkIDSchemeContent, "main.go", chunks)
+
+	assert.Equal(t, "from-chunker", chunks[0].ID)
+}
+
+func TestAssignChunkIDs_Positional(t *testing.T) {
+	newChunks := func(start int) []*chunk.Chunk {
+		return []*chunk.Chunk{
+			{ID: "x", StartLine: start, Content: "func A() {}"},
+			{ID: "y", StartLine: start + 5, Content: "func B() {}"},
+		}
+	}
+
+	// Given: the same file chunked twice, and once with every chunk moved down
+	first, again, shifted := newChunks(1), newChunks(1), newChunks(3)
+
+	// When: assigning positional IDs
+	assignChunkIDs(ChunkIDSchemePositional, "main.go", first)
+	assignChunkIDs(ChunkIDSchemePositional, "main.go", again)
+	assignChunkIDs(ChunkIDSchemePositional, "main.go", shifted)
+
+	// Then: IDs are reproducible, distinct, and change with position
+	assert.Equal(t, first[0].ID, again[0].ID)
+	assert.Equal(t, first[1].ID, again[1].ID)
+	assert.NotEqual(t, first[0].ID, first[1].ID)
+	assert.NotEqual(t, first[0].ID, shifted[0].ID)
+	assert.Len(t, first[0].ID, 16)
+
+	// And: the same chunk in another file gets another ID
+	other := newChunks(1)
+	assignChunkIDs(ChunkIDSchemePositional, "other.go", other)
+	assert.NotEqual(t, first[0].ID, other[0].ID)
+}
+
+func TestAssignChunkIDs_PositionalDisambiguatesCollisions(t *testing.T) {
+	// Given: two chunks with the same start line and content (e.g. a symbol
+	// and a split part of it)
+	chunks := []*chunk.Chunk{
+		{StartLine: 10, Content: "same"},
+		{StartLine: 10, Content: "same"},
+		{StartLine: 10, Content: "same"},
+	}
+
+	// When: assigning positional IDs
+	assignChunkIDs(ChunkIDSchemePositional, "main.go", chunks)
+
+	// Then: every chunk gets a unique ID, the first the undisambiguated one
+	assert.Len(t, map[string]bool{chunks[0].ID: true, chunks[1].ID: true, chunks[2].ID: true}, 3)
+	assert.Equal(t, hashString("main.go:10:"+hashString("same")), chunks[0].ID)
+}
@@ -0,0 +1,87 @@
+package index
+
+import (
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/chunk"
+	"github.com/Aman-CERP/amanmcp/internal/scanner"
+)
+
+// ChunkerRegistry routes files to chunkers by language and content type.
+//
+// A chunker registered for a file's language wins over the one registered
+// for its content type, so a language can get a dedicated chunker (say, one
+// tuned for Python) while every other code file keeps the generic code
+// chunker. The zero value is an empty registry ready to use.
+type ChunkerRegistry struct {
+	byContentType map[scanner.ContentType]chunk.Chunker
+	byLanguage    map[string]chunk.Chunker
+}
+
+// NewChunkerRegistry creates an empty chunker registry.
+func NewChunkerRegistry() *ChunkerRegistry {
+	return &ChunkerRegistry{}
+}
+
+// Register routes files of contentType to c, replacing any chunker already
+// registered for it. A nil chunker removes the registration.
+func (r *ChunkerRegistry) Register(contentType scanner.ContentType, c chunk.Chunker) {
+	if c == nil {
+		delete(r.byContentType, contentType)
+		return
+	}
+	if r.byContentType == nil {
+		r.byContentType = make(map[scanner.ContentType]chunk.Chunker)
+	}
+	r.byContentType[contentType] = c
+}
+
+// RegisterLanguage routes files of language (as detected by the scanner,
+// matched case-insensitively) to c, ahead of the content type chunker. A nil
+// chunker removes the registration.
+func (r *ChunkerRegistry) RegisterLanguage(language string, c chunk.Chunker) {
+	key := strings.ToLower(language)
+	if c == nil {
+		delete(r.byLanguage, key)
+		return
+	}
+	if r.byLanguage == nil {
+		r.byLanguage = make(map[string]chunk.Chunker)
+	}
+	r.byLanguage[key] = c
+}
+
+// Lookup returns the chunker for a file of the given language and content
+// type, or nil if none is registered.
+func (r *ChunkerRegistry) Lookup(language string, contentType scanner.ContentType) chunk.Chunker {
+	if c, ok := r.byLanguage[strings.ToLower(language)]; ok && language != "" {
+		return c
+	}
+	return r.byContentType[contentType]
+}
+
+// Has reports whether Lookup finds a chunker for language and contentType.
+func (r *ChunkerRegistry) Has(language string, contentType scanner.ContentType) bool {
+	return r.Lookup(language, contentType) != nil
+}
+
+// defaultChunkerRegistry builds the coordinator's registry: the dedicated
+// chunker fields of config first, then config.Chunkers and
+// config.LanguageChunkers on top, so an empty map keeps the defaults.
+func defaultChunkerRegistry(config CoordinatorConfig) *ChunkerRegistry {
+	r := NewChunkerRegistry()
+	r.Register(scanner.ContentTypeCode, config.CodeChunker)
+	r.Register(scanner.ContentTypeMarkdown, config.MDChunker)
+	r.Register(scanner.ContentTypePDF, config.PDFChunker)
+	r.Register(scanner.ContentTypeNotebook, config.NotebookChunker)
+	r.Register(scanner.ContentTypeText, config.TextChunker)
+	r.Register(scanner.ContentTypeConfig, config.TextChunker)
+
+	for contentType, c := range config.Chunkers {
+		r.Register(contentType, c)
+	}
+	for language, c := range config.LanguageChunkers {
+		r.RegisterLanguage(language, c)
+	}
+	return r
+}
@@ -0,0 +1,91 @@
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/chunk"
+	"github.com/Aman-CERP/amanmcp/internal/scanner"
+	"github.com/Aman-CERP/amanmcp/internal/watcher"
+)
+
+func TestChunkerRegistry_LanguageTakesPrecedence(t *testing.T) {
+	// Given: a generic code chunker and a Python-specific one
+	generic, python := &MockChunker{}, &MockChunker{}
+	r := NewChunkerRegistry()
+	r.Register(scanner.ContentTypeCode, generic)
+	r.RegisterLanguage("Python", python)
+
+	// When / Then: Python files use the Python chunker, other code the generic one
+	assert.Same(t, python, r.Lookup("python", scanner.ContentTypeCode))
+	assert.Same(t, generic, r.Lookup("go", scanner.ContentTypeCode))
+	assert.Same(t, generic, r.Lookup("", scanner.ContentTypeCode))
+	assert.Nil(t, r.Lookup("go", scanner.ContentTypeMarkdown))
+}
+
+func TestChunkerRegistry_NilRemovesRegistration(t *testing.T) {
+	// Given: a registry with a text chunker
+	r := NewChunkerRegistry()
+	r.Register(scanner.ContentTypeText, &MockChunker{})
+
+	// When: registering nil for it
+	r.Register(scanner.ContentTypeText, nil)
+
+	// Then: text has no chunker
+	assert.False(t, r.Has("", scanner.ContentTypeText))
+}
+
+func TestDefaultChunkerRegistry_MapsOverrideFields(t *testing.T) {
+	// Given: config with chunker fields and map overrides
+	code, md, text, config := &MockChunker{}, &MockChunker{}, &MockChunker{}, &MockChunker{}
+	r := defaultChunkerRegistry(CoordinatorConfig{
+		CodeChunker: code,
+		MDChunker:   md,
+		TextChunker: text,
+		Chunkers: map[scanner.ContentType]chunk.Chunker{
+			scanner.ContentTypeConfig:   config,
+			scanner.ContentTypeMarkdown: nil,
+		},
+	})
+
+	// Then: fields are the defaults, map entries replace or disable them
+	assert.Same(t, code, r.Lookup("go", scanner.ContentTypeCode))
+	assert.Same(t, text, r.Lookup("", scanner.ContentTypeText))
+	assert.Same(t, config, r.Lookup("yaml", scanner.ContentTypeConfig))
+	assert.False(t, r.Has("markdown", scanner.ContentTypeMarkdown))
+	assert.False(t, r.Has("jupyter", scanner.ContentTypeNotebook))
+}
+
+func TestCoordinator_LanguageChunkers_RouteByLanguage(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+
+	// Given: a coordinator with a Python-specific chunker
+	python := &MockChunker{}
+	cfg := coord.config
+	cfg.LanguageChunkers = map[string]chunk.Chunker{"python": python}
+	coord = NewCoordinator(cfg)
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "app.py"), []byte("def main():\n    pass\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\nfunc main() {}\n"), 0o644))
+
+	// When: both files are created
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "app.py", Operation: watcher.OpCreate, Timestamp: time.Now()},
+		{Path: "main.go", Operation: watcher.OpCreate, Timestamp: time.Now()},
+	}))
+
+	// Then: only the Python file went through the Python chunker, and both are indexed
+	require.Len(t, python.Inputs, 1)
+	assert.Equal(t, "app.py", python.Inputs[0].Path)
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"app.py", "main.go"}, paths)
+}
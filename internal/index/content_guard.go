@@ -0,0 +1,133 @@
+package index
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/Aman-CERP/amanmcp/internal/chunk"
+	"github.com/Aman-CERP/amanmcp/internal/scanner"
+	"github.com/Aman-CERP/amanmcp/internal/secrets"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// ContentGuard holds the checks a scanned file's content passes before it
+// is chunked: the size limit, text decoding and binary detection, UTF-8
+// sanitizing and the secrets guard. The Runner and pkg/indexer chunk files
+// through it so every indexing path skips and redacts the same content.
+type ContentGuard struct {
+	// MaxFileSize skips files larger than this many bytes (0 = no limit).
+	MaxFileSize int64
+
+	// BinaryDetection controls decoding, binary detection and UTF-8
+	// sanitizing.
+	BinaryDetection scanner.BinaryDetection
+
+	// Secrets guards content before it is chunked (nil = not guarded).
+	Secrets *secrets.Scanner
+}
+
+// DefaultContentGuard returns a ContentGuard with the scanner's default size
+// limit and binary detection and the default secrets policy.
+func DefaultContentGuard() ContentGuard {
+	return ContentGuard{
+		MaxFileSize: scanner.DefaultMaxFileSize,
+		Secrets:     secrets.NewScanner(secrets.DefaultPolicy()),
+	}
+}
+
+// Read reads file and returns its content ready for chunking, with the
+// secrets guard's result for Annotate. ok is false when the file must be
+// skipped: it is too large, binary, or blocked by the secrets guard. The
+// result's warnings are set either way.
+//
+// PDFs are returned as read; their text is guarded after extraction by
+// Annotate.
+func (g ContentGuard) Read(file *scanner.FileInfo) (content []byte, result secrets.Result, ok bool, err error) {
+	if g.MaxFileSize > 0 && file.Size > g.MaxFileSize {
+		slog.Warn("skipping oversized file",
+			slog.String("path", file.Path),
+			slog.Int64("size", file.Size),
+			slog.Int64("max", g.MaxFileSize))
+		return nil, result, false, nil
+	}
+
+	content, err = os.ReadFile(file.AbsPath)
+	if err != nil {
+		return nil, result, false, fmt.Errorf("failed to read: %w", err)
+	}
+	if file.ContentType == scanner.ContentTypePDF {
+		return content, result, true, nil
+	}
+
+	content = scanner.DecodeText(content, g.BinaryDetection)
+	if scanner.IsBinaryContent(content, g.BinaryDetection) {
+		return nil, result, false, nil
+	}
+	content = sanitizeUTF8(file.Path, content, g.BinaryDetection)
+
+	if g.Secrets == nil {
+		return content, result, true, nil
+	}
+	result = g.Secrets.GuardContent(secrets.ContentInput{
+		Path:    file.Path,
+		Content: content,
+		Source:  secrets.SourceIndex,
+	})
+	if result.Blocked {
+		return nil, result, false, nil
+	}
+	return result.Content, result, true, nil
+}
+
+// Annotate applies the secrets guard's result to the chunks of a file read
+// with Read: PDF chunks are guarded one by one now that their text is
+// extracted, other chunks are tagged with the warnings Read found. It
+// returns the chunks to index and any new warnings.
+func (g ContentGuard) Annotate(file *scanner.FileInfo, chunks []*chunk.Chunk, result secrets.Result) ([]*chunk.Chunk, []secrets.Warning) {
+	if file.ContentType == scanner.ContentTypePDF {
+		return guardExtractedPDFChunks(chunks, g.Secrets, file.Path)
+	}
+	annotateSecretScan(chunks, result)
+	return chunks, nil
+}
+
+// FileID returns the ID a newly indexed file at relPath is stored under.
+func FileID(relPath string) string {
+	return hashString(relPath)
+}
+
+// ConvertChunk converts a chunker chunk to the store model, stamped with
+// fileID and now.
+func ConvertChunk(c *chunk.Chunk, fileID string, now time.Time) *store.Chunk {
+	var symbols []*store.Symbol
+	for _, s := range c.Symbols {
+		symbols = append(symbols, &store.Symbol{
+			Name:       s.Name,
+			Type:       store.SymbolType(s.Type),
+			StartLine:  s.StartLine,
+			EndLine:    s.EndLine,
+			Signature:  s.Signature,
+			DocComment: s.DocComment,
+		})
+	}
+
+	return &store.Chunk{
+		ID:              c.ID,
+		FileID:          fileID,
+		FilePath:        c.FilePath,
+		Content:         c.Content,
+		RawContent:      c.RawContent,
+		Context:         c.Context,
+		ContentType:     store.ContentType(c.ContentType),
+		Language:        c.Language,
+		StartLine:       c.StartLine,
+		EndLine:         c.EndLine,
+		Symbols:         symbols,
+		Metadata:        c.Metadata,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		EnclosingSymbol: c.EnclosingSymbol,
+	}
+}
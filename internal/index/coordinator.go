@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,6 +30,10 @@ import (
 // Files larger than this are skipped to prevent memory exhaustion (BUG-002).
 const DefaultMaxFileSize int64 = 100 * 1024 * 1024
 
+// DefaultBM25StatsRecomputeEvery is the default number of file mutations
+// between BM25 corpus statistics resyncs.
+const DefaultBM25StatsRecomputeEvery = 500
+
 // CoordinatorConfig contains configuration for the Coordinator.
 type CoordinatorConfig struct {
 	// ProjectID is the unique identifier for this project.
@@ -37,7 +42,8 @@ type CoordinatorConfig struct {
 	// RootPath is the absolute path to the project root.
 	RootPath string
 
-	// DataDir is the path to the .amanmcp directory.
+	// DataDir is the path to the .amanmcp directory. When it lies inside
+	// RootPath it is never indexed.
 	DataDir string
 
 	// Engine is the search engine for indexing and deletion.
@@ -59,6 +65,25 @@ type CoordinatorConfig struct {
 	// PDFChunker handles PDF document files.
 	PDFChunker chunk.Chunker
 
+	// NotebookChunker handles Jupyter notebooks (optional).
+	// Nil leaves .ipynb files unindexed.
+	NotebookChunker chunk.Chunker
+
+	// TextChunker handles plain text and config files (optional).
+	// Nil skips plain text and records config files for the graph only.
+	TextChunker chunk.Chunker
+
+	// Chunkers routes content types to chunkers, on top of the chunker
+	// fields above: an entry replaces the field for its content type, a new
+	// content type becomes indexable, and a nil entry stops indexing that
+	// content type. Nil or empty keeps the defaults.
+	Chunkers map[scanner.ContentType]chunk.Chunker
+
+	// LanguageChunkers routes languages (as detected by the scanner) to
+	// chunkers that take precedence over the content type chunker, e.g. a
+	// Python-specific chunker alongside the generic CodeChunker.
+	LanguageChunkers map[string]chunk.Chunker
+
 	// Scanner is used for gitignore reconciliation (optional).
 	// When set, enables automatic index updates on .gitignore changes.
 	Scanner *scanner.Scanner
@@ -75,6 +100,36 @@ type CoordinatorConfig struct {
 	// These are used during reconciliation to match initial indexing behavior.
 	ExcludePatterns []string
 
+	// GitignoreHashMaxDepth limits how many directory levels below RootPath
+	// are searched for .gitignore files when checking for gitignore changes
+	// made while the server was stopped (see GitignoreHashOptions.MaxDepth).
+	// Zero means no limit.
+	GitignoreHashMaxDepth int
+
+	// FollowSymlinkPaths allowlists symlinks to index (see
+	// scanner.ScanOptions.FollowSymlinkPaths). All other symlinks are skipped.
+	FollowSymlinkPaths []string
+
+	// IncludeHidden indexes hidden tool directories (see
+	// scanner.ScanOptions.IncludeHidden). When false, events for paths in
+	// them are ignored.
+	IncludeHidden bool
+
+	// BinaryDetection decides which files are skipped as binary and whether
+	// UTF-16 text is decoded (see scanner.BinaryDetection). The zero value
+	// skips files with a null byte near the start.
+	BinaryDetection scanner.BinaryDetection
+
+	// ContentTypeOverrides reclassifies languages for changed and reconciled
+	// files (see scanner.ScanOptions.ContentTypeOverrides). It must match
+	// the overrides used by 'amanmcp index'.
+	ContentTypeOverrides map[string]scanner.ContentType
+
+	// LanguageOverrides maps file extensions to languages for changed and
+	// reconciled files (see scanner.ScanOptions.LanguageOverrides). It must
+	// match the overrides used by 'amanmcp index'.
+	LanguageOverrides map[string]string
+
 	// MaxFileSize is the maximum file size to index in bytes (optional).
 	// Files larger than this are skipped with a warning.
 	// Defaults to DefaultMaxFileSize (100MB) if zero.
@@ -83,6 +138,56 @@ type CoordinatorConfig struct {
 	// GraphStalePurgeAfter controls stale-edge retention for refresh
 	// maintenance. Defaults to graph.DefaultStalePurgeAfter when zero.
 	GraphStalePurgeAfter time.Duration
+
+	// ReuseUnchangedEmbeddings makes re-indexing an already indexed file
+	// diff-aware: chunks whose content is unchanged keep their stored
+	// embeddings and only changed chunks are re-embedded. When fewer than
+	// MinEmbeddingReuseRatio of the new chunks are unchanged (chunk boundaries
+	// shifted), the whole file is re-embedded.
+	ReuseUnchangedEmbeddings bool
+
+	// ChunkIDScheme selects how chunk IDs are assigned. It must match the
+	// scheme the index was built with. Empty keeps the chunkers' IDs
+	// (ChunkIDSchemeContent).
+	ChunkIDScheme ChunkIDScheme
+
+	// BM25StatsRecomputeEvery is the number of processed file mutations after
+	// which BM25 corpus statistics are recomputed from the stored postings.
+	// Defaults to DefaultBM25StatsRecomputeEvery when zero; negative disables.
+	BM25StatsRecomputeEvery int
+
+	// ReconcileInterval is how often RunPeriodicReconciliation re-runs the
+	// startup file reconciliation to catch changes the watcher missed, e.g.
+	// on network mounts where fsnotify is unreliable. Zero disables it.
+	// Requires Scanner.
+	ReconcileInterval time.Duration
+
+	// PriorityPaths are project-relative directories or files whose changes
+	// file reconciliation applies before all others, so search over the code
+	// being worked on catches up first on large repositories. Progress for
+	// both groups is reported by ReconcileProgress. Full index runs (Runner)
+	// order files by paths.priority too.
+	PriorityPaths []string
+
+	// NearDuplicateDistance skips chunks whose SimHash fingerprint is within
+	// this many bits of an indexed chunk, as full index runs do for
+	// search.near_duplicate_distance. It must match the setting the index
+	// was built with. Zero disables near-duplicate detection. Requires a
+	// Metadata store implementing store.NearDuplicateStore.
+	NearDuplicateDistance int
+
+	// Watcher is the watcher delivering events to HandleEvents (optional).
+	// When set, it is paused while an OpResync reconciliation runs, so
+	// changes made meanwhile are collapsed into one more resync instead of
+	// being replayed file by file.
+	Watcher EventPauser
+}
+
+// EventPauser pauses and resumes the delivery of file events, as
+// watcher.HybridWatcher does.
+type EventPauser interface {
+	Pause()
+	Resume(resync bool) int
 }
 
 // Coordinator handles incremental index updates based on file events.
@@ -90,8 +195,43 @@ type Coordinator struct {
 	config CoordinatorConfig
 	mu     sync.Mutex
 
+	// mutationsSinceStatsRecompute counts file mutations since the last
+	// BM25 stats resync.
+	mutationsSinceStatsRecompute int
+
 	graphKnownSourcesLoaded bool
 	graphKnownSourcesCache  []graph.SourceFile
+
+	// nearDuplicates holds the canonical chunk fingerprints when
+	// NearDuplicateDistance is set. It is loaded from the metadata store on
+	// first use.
+	nearDuplicates *SimHashIndex
+
+	// releasedFiles queues files whose near-duplicate chunks lost their
+	// canonical chunk. They are re-indexed even though unchanged.
+	releasedFiles      []string
+	releasedPending    map[string]bool
+	reindexingReleased bool
+
+	// progress is guarded by progressMu rather than mu, so it can be read
+	// while a reconciliation holds mu.
+	progressMu sync.Mutex
+	progress   ReconcileProgress
+}
+
+// ReconcileProgress reports the latest file reconciliation, split into the
+// files under CoordinatorConfig.PriorityPaths and the rest, which are
+// processed after them.
+type ReconcileProgress struct {
+	PriorityTotal   int
+	PriorityDone    int
+	BackgroundTotal int
+	BackgroundDone  int
+}
+
+// PriorityComplete reports whether every priority change has been applied.
+func (p ReconcileProgress) PriorityComplete() bool {
+	return p.PriorityDone >= p.PriorityTotal
 }
 
 // NewCoordinator creates a new index coordinator.
@@ -105,11 +245,18 @@ func NewCoordinator(config CoordinatorConfig) *Coordinator {
 	if config.PDFChunker == nil {
 		config.PDFChunker = chunk.NewPDFChunker()
 	}
+	config.PriorityPaths = normalizePriorityPaths(config.RootPath, config.PriorityPaths)
 	return &Coordinator{
 		config: config,
 	}
 }
 
+// chunkers returns the chunker registry for the current config. It is built
+// per call so chunkers set on the config after NewCoordinator take effect.
+func (c *Coordinator) chunkers() *ChunkerRegistry {
+	return defaultChunkerRegistry(c.config)
+}
+
 // maxFileSize returns the effective maximum file size (uses default if not configured).
 func (c *Coordinator) maxFileSize() int64 {
 	if c.config.MaxFileSize > 0 {
@@ -141,11 +288,40 @@ func (c *Coordinator) HandleEvents(ctx context.Context, events []watcher.FileEve
 		if err := c.config.Metadata.RefreshProjectStats(ctx, c.config.ProjectID); err != nil {
 			slog.Warn("failed to refresh project stats", slog.String("error", err.Error()))
 		}
+		c.maybeRecomputeBM25Stats(ctx, processed)
 	}
 
 	return nil
 }
 
+// maybeRecomputeBM25Stats resyncs BM25 corpus statistics once enough file
+// mutations have accumulated. Failures are logged; the running statistics
+// remain usable and the next threshold crossing retries.
+func (c *Coordinator) maybeRecomputeBM25Stats(ctx context.Context, mutations int) {
+	every := c.config.BM25StatsRecomputeEvery
+	if every == 0 {
+		every = DefaultBM25StatsRecomputeEvery
+	}
+	if every < 0 || c.config.Engine == nil {
+		return
+	}
+
+	c.mutationsSinceStatsRecompute += mutations
+	if c.mutationsSinceStatsRecompute < every {
+		return
+	}
+	c.mutationsSinceStatsRecompute = 0
+
+	stats, err := c.config.Engine.RecomputeBM25Stats(ctx)
+	if err != nil {
+		slog.Warn("failed to recompute BM25 stats", slog.String("error", err.Error()))
+		return
+	}
+	slog.Debug("bm25 stats recomputed",
+		slog.Int("documents", stats.DocumentCount),
+		slog.Float64("avg_doc_length", stats.AvgDocLength))
+}
+
 // handleEvent processes a single file event.
 func (c *Coordinator) handleEvent(ctx context.Context, event watcher.FileEvent) error {
 	slog.Debug("processing file event",
@@ -153,8 +329,8 @@ func (c *Coordinator) handleEvent(ctx context.Context, event watcher.FileEvent)
 		slog.String("operation", event.Operation.String()),
 		slog.Bool("is_dir", event.IsDir))
 
-	// Skip directories
-	if event.IsDir {
+	// Skip directories, except deletions which remove their indexed files
+	if event.IsDir && event.Operation != watcher.OpDelete {
 		return nil
 	}
 
@@ -162,6 +338,11 @@ func (c *Coordinator) handleEvent(ctx context.Context, event watcher.FileEvent)
 	case watcher.OpCreate, watcher.OpModify:
 		return c.indexFile(ctx, event.Path)
 	case watcher.OpDelete:
+		// fsnotify cannot stat a deleted path, so a removed directory may
+		// arrive without IsDir
+		if event.IsDir || c.hasIndexedFilesUnder(ctx, event.Path) {
+			return c.removeDirectory(ctx, event.Path)
+		}
 		return c.removeFile(ctx, event.Path)
 	case watcher.OpRename:
 		if event.OldPath != "" {
@@ -187,13 +368,43 @@ func (c *Coordinator) handleEvent(ctx context.Context, event watcher.FileEvent)
 		return c.handleGitignoreChange(ctx, event.Path)
 	case watcher.OpConfigChange:
 		return c.handleConfigChange(ctx)
+	case watcher.OpResync:
+		// Events were suppressed while the watcher was paused; reconcile
+		// against the filesystem instead of replaying them
+		if c.config.Watcher != nil {
+			c.config.Watcher.Pause()
+			defer c.config.Watcher.Resume(true)
+		}
+		return c.reconcileFiles(ctx)
 	default:
 		return nil
 	}
 }
 
+// inDataDir reports whether relPath lies in the data directory, which is
+// never indexed.
+func (c *Coordinator) inDataDir(relPath string) bool {
+	dataDir := scanner.RelDataDir(c.config.RootPath, c.config.DataDir)
+	if dataDir == "" {
+		return false
+	}
+	relPath = filepath.Clean(relPath)
+	return relPath == dataDir || strings.HasPrefix(relPath, dataDir+string(filepath.Separator))
+}
+
 // indexFile indexes or re-indexes a file.
 func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
+	defer c.reindexReleasedFiles(ctx)
+
+	if !c.config.IncludeHidden && scanner.IsHiddenToolPath(relPath) {
+		slog.Debug("skipping hidden tool file", slog.String("path", relPath))
+		return nil
+	}
+	if c.inDataDir(relPath) {
+		slog.Debug("skipping data dir file", slog.String("path", relPath))
+		return nil
+	}
+
 	absPath := filepath.Join(c.config.RootPath, relPath)
 
 	// Use Lstat to detect symlinks without following them (BUG-005)
@@ -202,10 +413,22 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 		return fmt.Errorf("failed to stat file: %w", err)
 	}
 
-	// Skip symlinks to prevent security issues and infinite loops (BUG-005)
+	// Skip symlinks to prevent security issues and infinite loops (BUG-005),
+	// unless allowlisted
 	if info.Mode()&os.ModeSymlink != 0 {
-		slog.Debug("skipping symlink", slog.String("path", relPath))
-		return nil
+		target, ok := scanner.ResolveAllowedSymlink(c.config.RootPath, relPath, c.config.FollowSymlinkPaths)
+		if !ok {
+			slog.Debug("skipping symlink", slog.String("path", relPath))
+			return nil
+		}
+		info, err = os.Stat(target)
+		if err != nil {
+			return fmt.Errorf("failed to stat symlink target: %w", err)
+		}
+		if info.IsDir() {
+			// Linked directories are indexed by the scanner, not per event
+			return nil
+		}
 	}
 
 	// Check file size before reading to prevent memory exhaustion (BUG-002)
@@ -218,24 +441,42 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 		return nil // Skip gracefully, don't error
 	}
 
+	// Detect language and content type
+	detectedLanguage, contentType := scanner.DetectFileType(relPath, &scanner.ScanOptions{
+		LanguageRegistry:     c.config.LanguageRegistry,
+		IncludeNotebooks:     c.chunkers().Has(scanner.NotebookLanguage, scanner.ContentTypeNotebook),
+		ContentTypeOverrides: c.config.ContentTypeOverrides,
+		LanguageOverrides:    c.config.LanguageOverrides,
+	})
+
+	// Skip machine-generated data files before reading them whole, as the
+	// scanner does
+	if scanner.IsDataFile(absPath, relPath, contentType, info.Size(), c.config.BinaryDetection.DataFiles) {
+		slog.Debug("skipping data file",
+			slog.String("path", relPath),
+			slog.Int64("size", info.Size()))
+		return nil
+	}
+
 	// Read file content
 	content, err := os.ReadFile(absPath)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Detect language and content type
-	detectedLanguage := scanner.DetectLanguageWithRegistry(relPath, c.config.LanguageRegistry)
-	contentType := scanner.DetectContentTypeWithRegistry(detectedLanguage, c.config.LanguageRegistry)
-
 	// Skip binary files except first-class binary document types with chunkers.
-	if contentType != scanner.ContentTypePDF && isBinaryContent(content) {
-		return nil
+	if contentType != scanner.ContentTypePDF {
+		content = scanner.DecodeText(content, c.config.BinaryDetection)
+		if scanner.IsBinaryContent(content, c.config.BinaryDetection) {
+			return nil
+		}
+		content = sanitizeUTF8(relPath, content, c.config.BinaryDetection)
 	}
 
-	// Skip plain text. Config files are recorded as graph-only metadata below;
-	// they do not produce BM25/vector chunks.
-	if !isIndexableContentType(contentType) {
+	// Skip plain text unless a text chunker is configured. Without one, config
+	// files are recorded as graph-only metadata below and produce no BM25/vector
+	// chunks.
+	if !c.isIndexable(detectedLanguage, contentType) {
 		return nil
 	}
 
@@ -253,20 +494,18 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 		content = secretResult.Content
 	}
 
-	if contentType == scanner.ContentTypeConfig {
-		return c.indexConfigFile(ctx, relPath, info, detectedLanguage, contentType, content)
+	// Touched but byte-identical files (git checkout, editor saves) keep
+	// their chunks and embeddings
+	contentHash := hashContent(content)
+	if c.skipUnchangedFile(ctx, relPath, info, contentHash, detectedLanguage, contentType) {
+		return nil
 	}
 
-	// Select the appropriate chunker
-	var chunker chunk.Chunker
-	switch contentType {
-	case scanner.ContentTypeCode:
-		chunker = c.config.CodeChunker
-	case scanner.ContentTypeMarkdown:
-		chunker = c.config.MDChunker
-	case scanner.ContentTypePDF:
-		chunker = c.config.PDFChunker
-	default:
+	chunker := c.chunkers().Lookup(detectedLanguage, contentType)
+	if contentType == scanner.ContentTypeConfig && chunker == nil {
+		return c.indexConfigFile(ctx, relPath, info, detectedLanguage, contentType, content)
+	}
+	if chunker == nil {
 		// Skip files without a chunker
 		return nil
 	}
@@ -289,6 +528,7 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 				slog.String("file", relPath),
 				slog.String("reason", "ocr_scanned_encrypted_or_malformed_not_supported"))
 		}
+		c.releaseNearDuplicates(ctx, relPath, nil)
 		if err := c.removeIndexedFile(ctx, relPath); err != nil {
 			return err
 		}
@@ -303,6 +543,7 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 		chunks, warnings = guardExtractedPDFChunks(chunks, c.config.SecretScanner, relPath)
 		logSecretWarnings(warnings)
 		if len(chunks) == 0 {
+			c.releaseNearDuplicates(ctx, relPath, nil)
 			if err := c.removeIndexedFile(ctx, relPath); err != nil {
 				return err
 			}
@@ -315,8 +556,9 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 	} else {
 		annotateSecretScan(chunks, secretResult)
 	}
+	assignChunkIDs(c.config.ChunkIDScheme, relPath, chunks)
 
-	fileID := generateFileID(c.config.ProjectID, relPath)
+	fileID := c.fileID(ctx, relPath)
 
 	// Save file record FIRST (chunks have foreign key to files)
 	// Note: reusing 'info' from the size check above
@@ -326,11 +568,23 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 		Path:        relPath,
 		Size:        info.Size(),
 		ModTime:     info.ModTime(),
-		ContentHash: hashContent(content),
+		ContentHash: contentHash,
 		Language:    detectedLanguage,
 		ContentType: string(contentType),
 	}
 
+	// Look up reusable embeddings before the existing chunks are removed
+	reuse := c.reusableEmbeddings(ctx, relPath, fileID, chunks)
+
+	// Re-check the file's chunks for near-duplicates against the rest of the
+	// index; canonical chunks that are still present stay canonical
+	chunkIDs := make([]string, len(chunks))
+	for i, ch := range chunks {
+		chunkIDs[i] = ch.ID
+	}
+	c.releaseNearDuplicates(ctx, relPath, chunkIDs)
+	indexed, fingerprints := c.filterNearDuplicates(ctx, chunks)
+
 	// Remove existing chunks only after the replacement content has successfully
 	// chunked. This preserves the last good graph/search state on chunker failure.
 	if err := c.removeIndexedFile(ctx, relPath); err != nil {
@@ -342,8 +596,8 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 	}
 
 	// Convert to store.Chunk format
-	storeChunks := make([]*store.Chunk, len(chunks))
-	for i, ch := range chunks {
+	storeChunks := make([]*store.Chunk, len(indexed))
+	for i, ch := range indexed {
 		symbols := make([]*store.Symbol, 0, len(ch.Symbols))
 		for _, sym := range ch.Symbols {
 			symbols = append(symbols, &store.Symbol{
@@ -356,25 +610,27 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 			})
 		}
 		storeChunks[i] = &store.Chunk{
-			ID:          ch.ID,
-			FileID:      fileID,
-			FilePath:    relPath,
-			Content:     ch.Content,
-			RawContent:  ch.RawContent,
-			Context:     ch.Context,
-			ContentType: store.ContentType(ch.ContentType),
-			Language:    ch.Language,
-			StartLine:   ch.StartLine,
-			EndLine:     ch.EndLine,
-			Symbols:     symbols,
-			Metadata:    ch.Metadata,
+			ID:              ch.ID,
+			FileID:          fileID,
+			FilePath:        relPath,
+			Content:         ch.Content,
+			RawContent:      ch.RawContent,
+			Context:         ch.Context,
+			ContentType:     store.ContentType(ch.ContentType),
+			Language:        ch.Language,
+			StartLine:       ch.StartLine,
+			EndLine:         ch.EndLine,
+			Symbols:         symbols,
+			Metadata:        ch.Metadata,
+			EnclosingSymbol: ch.EnclosingSymbol,
 		}
 	}
 
 	// Index the chunks (engine handles embeddings and saves to metadata)
-	if err := c.config.Engine.Index(ctx, storeChunks); err != nil {
+	if err := c.config.Engine.IndexWithEmbeddings(ctx, storeChunks, reuse); err != nil {
 		return fmt.Errorf("failed to index chunks: %w", err)
 	}
+	c.saveChunkFingerprints(ctx, relPath, fingerprints)
 	if err := c.updateGraphSource(ctx, relPath, detectedLanguage, contentType, content, chunks); err != nil {
 		c.recordGraphUpdateFailure(ctx, "graph_incremental_update_failed", relPath, err)
 	}
@@ -382,8 +638,114 @@ func (c *Coordinator) indexFile(ctx context.Context, relPath string) error {
 	return nil
 }
 
+// skipUnchangedFile reports whether relPath is already indexed with content
+// of this hash, language and content type, so re-indexing would reproduce the
+// same chunks. A skipped file still gets its size and mtime refreshed so that
+// reconciliation stops flagging it. Lookup failures re-index the file, as do
+// files whose near-duplicate chunks were released.
+func (c *Coordinator) skipUnchangedFile(ctx context.Context, relPath string, info fs.FileInfo, contentHash, language string, contentType scanner.ContentType) bool {
+	if c.releasedPending[relPath] {
+		return false
+	}
+
+	existing, err := c.config.Metadata.GetFileByPath(ctx, c.config.ProjectID, relPath)
+	if err != nil || existing == nil {
+		return false
+	}
+	if existing.ContentHash != contentHash || existing.Language != language || existing.ContentType != string(contentType) {
+		return false
+	}
+
+	// A file record saved before its chunks failed to index has no chunks;
+	// graph-only config files never have any
+	if contentType != scanner.ContentTypeConfig || c.chunkers().Has(language, contentType) {
+		chunks, err := c.config.Metadata.GetChunksByFile(ctx, existing.ID)
+		if err != nil || len(chunks) == 0 {
+			return false
+		}
+	}
+
+	if existing.Size != info.Size() || !existing.ModTime.Equal(info.ModTime()) {
+		existing.Size = info.Size()
+		existing.ModTime = info.ModTime()
+		if err := c.config.Metadata.SaveFiles(ctx, []*store.File{existing}); err != nil {
+			slog.Warn("failed to refresh unchanged file record",
+				slog.String("path", relPath),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	slog.Debug("skipping unchanged file", slog.String("path", relPath))
+	return true
+}
+
+// MinEmbeddingReuseRatio is the share of a re-indexed file's chunks that must
+// be unchanged for CoordinatorConfig.ReuseUnchangedEmbeddings to reuse their
+// embeddings. Below it, chunk boundaries have shifted enough that a full
+// re-embed is simpler and the savings small.
+const MinEmbeddingReuseRatio = 0.5
+
+// reusableEmbeddings maps the IDs of new chunks whose content matches an
+// existing chunk of the file to that chunk's stored embedding. It returns nil,
+// meaning embed everything, when reuse is disabled, the file is not indexed
+// yet, or too few chunks are unchanged. Lookup failures only cost the reuse.
+func (c *Coordinator) reusableEmbeddings(ctx context.Context, relPath, fileID string, chunks []*chunk.Chunk) map[string][]float32 {
+	if !c.config.ReuseUnchangedEmbeddings || len(chunks) == 0 {
+		return nil
+	}
+
+	existing, err := c.config.Metadata.GetChunksByFile(ctx, fileID)
+	if err != nil || len(existing) == 0 {
+		return nil
+	}
+	byContent := make(map[string]string, len(existing))
+	for _, ch := range existing {
+		byContent[ch.Content] = ch.ID
+	}
+
+	// Match on the embedded text rather than the chunk ID: code chunk IDs
+	// hash only the raw symbol, so they survive edits to shared context
+	oldIDs := make(map[string]string, len(chunks))
+	for _, ch := range chunks {
+		if oldID, ok := byContent[ch.Content]; ok {
+			oldIDs[ch.ID] = oldID
+		}
+	}
+	if float64(len(oldIDs)) < MinEmbeddingReuseRatio*float64(len(chunks)) {
+		slog.Debug("re-embedding whole file, too few unchanged chunks",
+			slog.String("path", relPath),
+			slog.Int("unchanged", len(oldIDs)),
+			slog.Int("chunks", len(chunks)))
+		return nil
+	}
+
+	ids := make([]string, 0, len(oldIDs))
+	for _, oldID := range oldIDs {
+		ids = append(ids, oldID)
+	}
+	stored, err := c.config.Engine.StoredEmbeddings(ctx, ids)
+	if err != nil {
+		slog.Warn("failed to load stored embeddings, re-embedding whole file",
+			slog.String("path", relPath),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	reuse := make(map[string][]float32, len(oldIDs))
+	for newID, oldID := range oldIDs {
+		if emb, ok := stored[oldID]; ok {
+			reuse[newID] = emb
+		}
+	}
+	slog.Debug("reusing embeddings for unchanged chunks",
+		slog.String("path", relPath),
+		slog.Int("reused", len(reuse)),
+		slog.Int("chunks", len(chunks)))
+	return reuse
+}
+
 func (c *Coordinator) indexConfigFile(ctx context.Context, relPath string, info fs.FileInfo, language string, contentType scanner.ContentType, content []byte) error {
-	fileID := generateFileID(c.config.ProjectID, relPath)
+	fileID := c.fileID(ctx, relPath)
 	file := &store.File{
 		ID:          fileID,
 		ProjectID:   c.config.ProjectID,
@@ -395,6 +757,7 @@ func (c *Coordinator) indexConfigFile(ctx context.Context, relPath string, info
 		ContentType: string(contentType),
 	}
 
+	c.releaseNearDuplicates(ctx, relPath, nil)
 	if err := c.removeIndexedFile(ctx, relPath); err != nil {
 		return err
 	}
@@ -409,6 +772,9 @@ func (c *Coordinator) indexConfigFile(ctx context.Context, relPath string, info
 
 // removeFile removes a file's chunks from the index.
 func (c *Coordinator) removeFile(ctx context.Context, relPath string) error {
+	defer c.reindexReleasedFiles(ctx)
+
+	c.releaseNearDuplicates(ctx, relPath, nil)
 	if err := c.removeIndexedFile(ctx, relPath); err != nil {
 		return err
 	}
@@ -419,8 +785,152 @@ func (c *Coordinator) removeFile(ctx context.Context, relPath string) error {
 	return nil
 }
 
+// removeDirectory removes all indexed files under relPath in one batch.
+func (c *Coordinator) removeDirectory(ctx context.Context, relPath string) error {
+	defer c.reindexReleasedFiles(ctx)
+
+	deletion, err := c.config.Engine.DeleteByPathPrefix(ctx, c.config.ProjectID, filepath.ToSlash(relPath))
+	if err != nil {
+		return fmt.Errorf("failed to remove directory %s: %w", relPath, err)
+	}
+	for _, path := range deletion.Paths {
+		c.releaseNearDuplicates(ctx, path, nil)
+		c.removeGraphKnownSource(path)
+		if err := c.replaceGraphSourceWithEmptyEdges(ctx, path, true); err != nil {
+			c.recordGraphUpdateFailure(ctx, "graph_incremental_delete_failed", path, err)
+		}
+	}
+	if deletion.Files > 0 {
+		slog.Info("directory removed from index",
+			slog.String("path", relPath),
+			slog.Int("files", deletion.Files),
+			slog.Int("chunks", deletion.Chunks))
+	}
+	return nil
+}
+
+// nearDuplicateIndex returns the store of chunk fingerprints and the index of
+// canonical ones, loading it on first use. It returns false when
+// near-duplicate detection is disabled or the metadata store does not
+// support it.
+func (c *Coordinator) nearDuplicateIndex(ctx context.Context) (store.NearDuplicateStore, *SimHashIndex, bool) {
+	if c.config.NearDuplicateDistance <= 0 {
+		return nil, nil, false
+	}
+	nds, ok := c.config.Metadata.(store.NearDuplicateStore)
+	if !ok {
+		return nil, nil, false
+	}
+	if c.nearDuplicates == nil {
+		idx, err := loadSimHashIndex(ctx, nds, c.config.ProjectID, c.config.NearDuplicateDistance)
+		if err != nil {
+			slog.Warn("near_duplicate_index_load_failed", slog.String("error", err.Error()))
+			return nil, nil, false
+		}
+		c.nearDuplicates = idx
+	}
+	return nds, c.nearDuplicates, true
+}
+
+// releaseNearDuplicates forgets the chunk fingerprints of relPath, except
+// canonical chunks in keep, and queues the files holding near-duplicates of
+// the forgotten canonical chunks for re-indexing: those near-duplicates are
+// not indexed anywhere else.
+func (c *Coordinator) releaseNearDuplicates(ctx context.Context, relPath string, keep []string) {
+	nds, idx, ok := c.nearDuplicateIndex(ctx)
+	if !ok {
+		return
+	}
+	canonicalIDs, released, err := nds.DeleteChunkFingerprintsByFile(ctx, c.config.ProjectID, relPath, keep)
+	if err != nil {
+		slog.Warn("near_duplicate_release_failed",
+			slog.String("path", relPath),
+			slog.String("error", err.Error()))
+		return
+	}
+	for _, id := range canonicalIDs {
+		idx.Remove(id)
+	}
+	for _, path := range released {
+		if c.releasedPending == nil {
+			c.releasedPending = make(map[string]bool)
+		}
+		if !c.releasedPending[path] {
+			c.releasedPending[path] = true
+			c.releasedFiles = append(c.releasedFiles, path)
+		}
+	}
+}
+
+// filterNearDuplicates returns the chunks to index, without near-duplicates
+// of indexed chunks, and the fingerprints to save once they are indexed.
+// It returns chunks unchanged when near-duplicate detection is off.
+func (c *Coordinator) filterNearDuplicates(ctx context.Context, chunks []*chunk.Chunk) ([]*chunk.Chunk, []store.ChunkFingerprint) {
+	_, idx, ok := c.nearDuplicateIndex(ctx)
+	if !ok {
+		return chunks, nil
+	}
+	return filterNearDuplicates(idx, chunks)
+}
+
+// saveChunkFingerprints persists the fingerprints returned by
+// filterNearDuplicates. A failure only costs near-duplicate counts and
+// releases for the file until it is indexed again.
+func (c *Coordinator) saveChunkFingerprints(ctx context.Context, relPath string, fingerprints []store.ChunkFingerprint) {
+	nds, _, ok := c.nearDuplicateIndex(ctx)
+	if !ok || len(fingerprints) == 0 {
+		return
+	}
+	if err := nds.SaveChunkFingerprints(ctx, c.config.ProjectID, fingerprints); err != nil {
+		slog.Warn("near_duplicate_save_failed",
+			slog.String("path", relPath),
+			slog.String("error", err.Error()))
+	}
+}
+
+// reindexReleasedFiles re-indexes the files queued by releaseNearDuplicates,
+// including files queued while doing so. Re-indexing an unchanged file keeps
+// its canonical chunks, so it releases nothing further.
+func (c *Coordinator) reindexReleasedFiles(ctx context.Context) {
+	if c.reindexingReleased {
+		return
+	}
+	c.reindexingReleased = true
+	defer func() { c.reindexingReleased = false }()
+
+	for len(c.releasedFiles) > 0 {
+		path := c.releasedFiles[0]
+		c.releasedFiles = c.releasedFiles[1:]
+		err := c.indexFile(ctx, path)
+		delete(c.releasedPending, path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("near_duplicate_reindex_failed",
+				slog.String("path", path),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// hasIndexedFilesUnder reports whether relPath is a directory containing
+// indexed files, as opposed to an indexed file itself.
+func (c *Coordinator) hasIndexedFilesUnder(ctx context.Context, relPath string) bool {
+	if relPath == "" || relPath == "." {
+		return false
+	}
+	paths, err := c.config.Metadata.ListFilePathsUnder(ctx, c.config.ProjectID, filepath.ToSlash(relPath))
+	if err != nil {
+		return false
+	}
+	for _, p := range paths {
+		if p != relPath {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Coordinator) removeIndexedFile(ctx context.Context, relPath string) error {
-	fileID := generateFileID(c.config.ProjectID, relPath)
+	fileID := c.fileID(ctx, relPath)
 
 	// Get existing chunks for this file
 	chunks, err := c.config.Metadata.GetChunksByFile(ctx, fileID)
@@ -698,12 +1208,46 @@ const (
 	reconcilePatternDiff
 )
 
+// String returns the name of the strategy, as used in ReconciliationPlan.
+func (t reconcileType) String() string {
+	switch t {
+	case reconcileSubtree:
+		return "subtree"
+	case reconcilePatternDiff:
+		return "pattern_diff"
+	default:
+		return "full"
+	}
+}
+
 // reconcileStrategy contains the determined reconciliation approach.
 type reconcileStrategy struct {
 	Type            reconcileType
 	Scope           string   // for subtree (directory path)
 	AddedPatterns   []string // for pattern diff
 	RemovedPatterns []string // for pattern diff (triggers full scan)
+
+	// cacheContent is set when the root .gitignore content to diff against
+	// next time changes to gitignoreContent.
+	cacheContent     bool
+	gitignoreContent string
+}
+
+// ReconciliationPlan lists the index changes a gitignore reconciliation
+// makes, without making them (see Coordinator.PreviewReconciliation).
+type ReconciliationPlan struct {
+	// Strategy is "full" (whole project scanned), "subtree" (only Scope
+	// scanned) or "pattern_diff" (indexed files filtered by added patterns).
+	Strategy string
+
+	// Scope is the directory reconciled by a "subtree" strategy.
+	Scope string
+
+	// ToAdd are the files that would be indexed, sorted.
+	ToAdd []string
+
+	// ToRemove are the files that would be removed from the index, sorted.
+	ToRemove []string
 }
 
 // stateGitignoreContent is the state key for storing root .gitignore content.
@@ -754,21 +1298,26 @@ func (c *Coordinator) handleGitignoreChange(ctx context.Context, gitignorePath s
 	}
 
 	// Update the cached hash after successful reconciliation
-	newHash, hashErr := ComputeGitignoreHash(c.config.RootPath)
-	if hashErr != nil {
-		slog.Warn("failed to compute new gitignore hash", slog.String("error", hashErr.Error()))
-		return nil
-	}
-
-	if setErr := c.config.Metadata.SetState(ctx, GitignoreHashKey, newHash); setErr != nil {
-		slog.Warn("failed to save gitignore hash", slog.String("error", setErr.Error()))
+	if err := saveGitignoreHash(ctx, c.config.Metadata, c.config.RootPath, c.gitignoreHashOptions()); err != nil {
+		slog.Warn("failed to update gitignore hash", slog.String("error", err.Error()))
 	}
 
 	return nil
 }
 
 // determineReconciliationStrategy analyzes the gitignore change and returns the optimal strategy.
+// It caches the root .gitignore content for the next diff.
 func (c *Coordinator) determineReconciliationStrategy(ctx context.Context, gitignorePath string) reconcileStrategy {
+	strategy := c.planReconciliationStrategy(ctx, gitignorePath)
+	if strategy.cacheContent {
+		_ = c.config.Metadata.SetState(ctx, stateGitignoreContent, strategy.gitignoreContent)
+	}
+	return strategy
+}
+
+// planReconciliationStrategy is determineReconciliationStrategy without
+// updating the cached root .gitignore content.
+func (c *Coordinator) planReconciliationStrategy(ctx context.Context, gitignorePath string) reconcileStrategy {
 	// Get relative path from project root
 	relPath, err := filepath.Rel(c.config.RootPath, gitignorePath)
 	if err != nil {
@@ -789,33 +1338,32 @@ func (c *Coordinator) determineReconciliationStrategy(ctx context.Context, gitig
 		// No previous content cached, must do full scan
 		// But save current content for next time
 		newContent, _ := os.ReadFile(gitignorePath)
-		if len(newContent) > 0 {
-			_ = c.config.Metadata.SetState(ctx, stateGitignoreContent, string(newContent))
+		return reconcileStrategy{
+			Type:             reconcileFull,
+			cacheContent:     len(newContent) > 0,
+			gitignoreContent: string(newContent),
 		}
-		return reconcileStrategy{Type: reconcileFull}
 	}
 
 	newContent, err := os.ReadFile(gitignorePath)
 	if err != nil {
 		// File deleted or unreadable, must do full scan
 		// Clear cached content
-		_ = c.config.Metadata.SetState(ctx, stateGitignoreContent, "")
-		return reconcileStrategy{Type: reconcileFull}
+		return reconcileStrategy{Type: reconcileFull, cacheContent: true}
 	}
 
 	added, removed := gitignore.DiffPatterns(oldContent, string(newContent))
 
 	// Update cached content for next diff
-	_ = c.config.Metadata.SetState(ctx, stateGitignoreContent, string(newContent))
+	strategy := reconcileStrategy{cacheContent: true, gitignoreContent: string(newContent)}
 
 	// Case 2a: Only added patterns - no scan needed!
 	if len(added) > 0 && len(removed) == 0 {
 		slog.Debug("root gitignore: only patterns added, using pattern diff",
 			slog.Int("added_count", len(added)))
-		return reconcileStrategy{
-			Type:          reconcilePatternDiff,
-			AddedPatterns: added,
-		}
+		strategy.Type = reconcilePatternDiff
+		strategy.AddedPatterns = added
+		return strategy
 	}
 
 	// Case 2b: Patterns removed - need full scan to find newly-unignored files
@@ -823,16 +1371,40 @@ func (c *Coordinator) determineReconciliationStrategy(ctx context.Context, gitig
 		slog.Debug("root gitignore: patterns removed, requiring full scan",
 			slog.Int("removed_count", len(removed)),
 			slog.Int("added_count", len(added)))
-		return reconcileStrategy{
-			Type:            reconcileFull,
-			AddedPatterns:   added,
-			RemovedPatterns: removed,
-		}
+		strategy.Type = reconcileFull
+		strategy.AddedPatterns = added
+		strategy.RemovedPatterns = removed
+		return strategy
 	}
 
 	// Case 2c: No actual pattern change (only comments/whitespace)
 	slog.Debug("root gitignore: no pattern changes detected")
-	return reconcileStrategy{Type: reconcilePatternDiff, AddedPatterns: nil}
+	strategy.Type = reconcilePatternDiff
+	return strategy
+}
+
+// PreviewReconciliation returns the index changes the reconciliation for a
+// change of the .gitignore at gitignorePath would make, without making them
+// or updating any reconciliation state. Callers can use it to confirm a
+// change that removes many files from the index before applying it.
+func (c *Coordinator) PreviewReconciliation(ctx context.Context, gitignorePath string) (*ReconciliationPlan, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.config.Scanner == nil {
+		return nil, fmt.Errorf("scanner not configured")
+	}
+	c.config.Scanner.InvalidateGitignoreCache()
+
+	strategy := c.planReconciliationStrategy(ctx, gitignorePath)
+	switch strategy.Type {
+	case reconcileSubtree:
+		return c.planGitignoreSubtree(ctx, strategy.Scope)
+	case reconcilePatternDiff:
+		return c.planGitignorePatternDiff(ctx, strategy.AddedPatterns)
+	default:
+		return c.planGitignoreFull(ctx)
+	}
 }
 
 // reconcileGitignorePatternDiff handles root .gitignore with only ADDED patterns.
@@ -843,117 +1415,99 @@ func (c *Coordinator) reconcileGitignorePatternDiff(ctx context.Context, addedPa
 		return nil
 	}
 
+	plan, err := c.planGitignorePatternDiff(ctx, addedPatterns)
+	if err != nil {
+		return err
+	}
+	if err := c.applyReconciliationPlan(ctx, plan); err != nil {
+		return err
+	}
+
+	slog.Info("pattern diff reconciliation complete",
+		slog.Int("patterns_added", len(addedPatterns)),
+		slog.Int("files_removed", len(plan.ToRemove)))
+
+	return nil
+}
+
+// planGitignorePatternDiff computes the changes of
+// reconcileGitignorePatternDiff: indexed files matching an added pattern
+// are removed.
+func (c *Coordinator) planGitignorePatternDiff(ctx context.Context, addedPatterns []string) (*ReconciliationPlan, error) {
+	plan := &ReconciliationPlan{Strategy: reconcilePatternDiff.String()}
+	if len(addedPatterns) == 0 {
+		return plan, nil
+	}
+
 	// Get all indexed files
 	indexedPaths, err := c.config.Metadata.GetFilePathsByProject(ctx, c.config.ProjectID)
 	if err != nil {
-		return fmt.Errorf("failed to list indexed files: %w", err)
+		return nil, fmt.Errorf("failed to list indexed files: %w", err)
 	}
 
-	var toRemove []string
 	for _, path := range indexedPaths {
 		if gitignore.MatchesAnyPattern(path, addedPatterns) {
-			toRemove = append(toRemove, path)
+			plan.ToRemove = append(plan.ToRemove, path)
 		}
 	}
+	sort.Strings(plan.ToRemove)
+	return plan, nil
+}
 
-	// Remove files matching new ignore patterns
-	for _, path := range toRemove {
-		if err := c.removeFile(ctx, path); err != nil {
-			slog.Warn("failed to remove newly-ignored file",
-				slog.String("path", path),
-				slog.String("error", err.Error()))
-		}
+// reconcileGitignoreSubtree reconciles only files under a specific subtree.
+// Used when a nested .gitignore changes - no need to scan entire project.
+func (c *Coordinator) reconcileGitignoreSubtree(ctx context.Context, subtreePath string) error {
+	plan, err := c.planGitignoreSubtree(ctx, subtreePath)
+	if err != nil {
+		return err
+	}
+	if err := c.applyReconciliationPlan(ctx, plan); err != nil {
+		return err
 	}
 
-	slog.Info("pattern diff reconciliation complete",
-		slog.Int("patterns_added", len(addedPatterns)),
-		slog.Int("files_removed", len(toRemove)))
+	slog.Info("subtree reconciliation complete",
+		slog.String("subtree", subtreePath),
+		slog.Int("removed", len(plan.ToRemove)),
+		slog.Int("added", len(plan.ToAdd)))
 
 	return nil
 }
 
-// reconcileGitignoreSubtree reconciles only files under a specific subtree.
-// Used when a nested .gitignore changes - no need to scan entire project.
-func (c *Coordinator) reconcileGitignoreSubtree(ctx context.Context, subtreePath string) error {
+// planGitignoreSubtree computes the changes of reconcileGitignoreSubtree by
+// comparing the indexed files under subtreePath with a fresh scan of it.
+func (c *Coordinator) planGitignoreSubtree(ctx context.Context, subtreePath string) (*ReconciliationPlan, error) {
 	// Step 1: Get indexed files under subtree
 	indexedPaths, err := c.config.Metadata.ListFilePathsUnder(ctx, c.config.ProjectID, subtreePath)
 	if err != nil {
-		return fmt.Errorf("failed to list indexed files under %s: %w", subtreePath, err)
-	}
-	indexedSet := make(map[string]bool, len(indexedPaths))
-	for _, p := range indexedPaths {
-		indexedSet[p] = true
+		return nil, fmt.Errorf("failed to list indexed files under %s: %w", subtreePath, err)
 	}
 	slog.Debug("indexed files in subtree", slog.Int("count", len(indexedPaths)), slog.String("subtree", subtreePath))
 
 	// Step 2: Scan only the subtree with fresh gitignore rules
 	resultChan, err := c.config.Scanner.ScanSubtree(ctx, &scanner.ScanOptions{
-		RootDir:          c.config.RootPath,
-		RespectGitignore: true,
-		LanguageRegistry: c.config.LanguageRegistry,
+		RootDir:              c.config.RootPath,
+		DataDir:              c.config.DataDir,
+		RespectGitignore:     true,
+		LanguageRegistry:     c.config.LanguageRegistry,
+		IncludeHidden:        c.config.IncludeHidden,
+		FollowSymlinkPaths:   c.config.FollowSymlinkPaths,
+		Binary:               c.config.BinaryDetection,
+		ContentTypeOverrides: c.config.ContentTypeOverrides,
+		LanguageOverrides:    c.config.LanguageOverrides,
 	}, subtreePath)
 	if err != nil {
-		return fmt.Errorf("failed to scan subtree %s: %w", subtreePath, err)
+		return nil, fmt.Errorf("failed to scan subtree %s: %w", subtreePath, err)
 	}
 
 	// Step 3: Build "should be indexed" set
-	shouldBeIndexed := make(map[string]bool)
-	for result := range resultChan {
-		if result.Error != nil {
-			slog.Warn("scan error in subtree",
-				slog.String("path", result.File.Path),
-				slog.String("error", result.Error.Error()))
-			continue
-		}
-		if result.File == nil {
-			continue
-		}
-		contentType := scanner.DetectContentTypeWithRegistry(result.File.Language, c.config.LanguageRegistry)
-		if isIndexableContentType(contentType) {
-			shouldBeIndexed[result.File.Path] = true
-		}
-	}
+	shouldBeIndexed := c.indexableScanResults(ctx, resultChan, "scan error in subtree", slog.LevelWarn)
 	slog.Debug("current files in subtree", slog.Int("count", len(shouldBeIndexed)), slog.String("subtree", subtreePath))
 
-	// Step 4: Find files to remove (indexed but now ignored)
-	var toRemove []string
-	for path := range indexedSet {
-		if !shouldBeIndexed[path] {
-			toRemove = append(toRemove, path)
-		}
-	}
-
-	// Step 5: Find files to add (should be indexed but not yet)
-	var toAdd []string
-	for path := range shouldBeIndexed {
-		if !indexedSet[path] {
-			toAdd = append(toAdd, path)
-		}
-	}
-
-	// Step 6: Process changes
-	for _, path := range toRemove {
-		if err := c.removeFile(ctx, path); err != nil {
-			slog.Warn("failed to remove file during subtree reconciliation",
-				slog.String("path", path),
-				slog.String("error", err.Error()))
-		}
-	}
-
-	for _, path := range toAdd {
-		if err := c.indexFile(ctx, path); err != nil {
-			slog.Warn("failed to index file during subtree reconciliation",
-				slog.String("path", path),
-				slog.String("error", err.Error()))
-		}
-	}
-
-	slog.Info("subtree reconciliation complete",
-		slog.String("subtree", subtreePath),
-		slog.Int("removed", len(toRemove)),
-		slog.Int("added", len(toAdd)))
-
-	return nil
+	// Steps 4-5: Find files to remove (indexed but now ignored) and to add
+	plan := diffIndexedFiles(indexedPaths, shouldBeIndexed)
+	plan.Strategy = reconcileSubtree.String()
+	plan.Scope = subtreePath
+	return plan, nil
 }
 
 // handleConfigChange handles .amanmcp.yaml configuration file changes.
@@ -979,6 +1533,16 @@ func (c *Coordinator) handleConfigChange(ctx context.Context) error {
 	return c.reconcileGitignoreInternal(ctx)
 }
 
+// fileID returns the ID of the indexed file at relPath, looked up by project
+// and path, so files keep their ID after store.SQLiteStore.RenameProject and
+// whichever indexer created them. New files get generateFileID.
+func (c *Coordinator) fileID(ctx context.Context, relPath string) string {
+	if f, err := c.config.Metadata.GetFileByPath(ctx, c.config.ProjectID, relPath); err == nil && f != nil {
+		return f.ID
+	}
+	return generateFileID(c.config.ProjectID, relPath)
+}
+
 // generateFileID creates a deterministic file ID.
 func generateFileID(projectID, path string) string {
 	input := fmt.Sprintf("%s:%s", projectID, path)
@@ -992,81 +1556,45 @@ func hashContent(content []byte) string {
 	return hex.EncodeToString(hash[:])
 }
 
-// isBinaryContent checks if content appears to be binary.
-func isBinaryContent(content []byte) bool {
-	// Check first 512 bytes for null bytes
-	checkLen := 512
-	if len(content) < checkLen {
-		checkLen = len(content)
-	}
-
-	for i := 0; i < checkLen; i++ {
-		if content[i] == 0 {
-			return true
+// sanitizeUTF8 makes content valid UTF-8 per d.InvalidUTF8, logging files
+// that needed it.
+func sanitizeUTF8(relPath string, content []byte, d scanner.BinaryDetection) []byte {
+	content, changed := scanner.SanitizeUTF8(content, d)
+	if changed {
+		mode := d.InvalidUTF8
+		if mode == "" {
+			mode = scanner.InvalidUTF8Replace
 		}
+		slog.Debug("invalid_utf8_sanitized",
+			slog.String("path", relPath),
+			slog.String("mode", string(mode)))
 	}
+	return content
+}
 
-	return false
+// isIndexable reports whether files of language and contentType are
+// indexed: the built-in indexable content types, plus any content type or
+// language with a registered chunker (such as plain text with a text
+// chunker).
+func (c *Coordinator) isIndexable(language string, contentType scanner.ContentType) bool {
+	return isIndexableContentType(contentType) || c.chunkers().Has(language, contentType)
 }
 
 func isIndexableContentType(contentType scanner.ContentType) bool {
 	return contentType == scanner.ContentTypeCode ||
 		contentType == scanner.ContentTypeMarkdown ||
 		contentType == scanner.ContentTypePDF ||
+		contentType == scanner.ContentTypeNotebook ||
 		contentType == scanner.ContentTypeConfig
 }
 
-// GitignoreHashKey is the state key for storing the gitignore hash.
-// Exported for use by index command to save hash after completion.
-const GitignoreHashKey = "gitignore_hash"
-
-// ComputeGitignoreHash computes a SHA256 hash of all .gitignore files in the project.
-// The hash is deterministic: files are sorted by path and each contributes "path:content".
-// Exported for use by index command to save hash after completion.
-func ComputeGitignoreHash(rootPath string) (string, error) {
-	var gitignorePaths []string
-
-	// Walk the directory tree to find all .gitignore files
-	err := filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return nil // Skip files we can't access
-		}
-		if d.IsDir() {
-			// Skip hidden directories (except root) and common large directories
-			name := d.Name()
-			if name != "." && (name[0] == '.' || name == "node_modules" || name == "vendor") {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-		if d.Name() == ".gitignore" {
-			gitignorePaths = append(gitignorePaths, path)
-		}
-		return nil
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to walk directory: %w", err)
-	}
-
-	// Sort for deterministic ordering
-	sort.Strings(gitignorePaths)
-
-	// Build hash input from all gitignore files
-	h := sha256.New()
-	for _, path := range gitignorePaths {
-		content, err := os.ReadFile(path)
-		if err != nil {
-			continue // Skip unreadable files
-		}
-		relPath, _ := filepath.Rel(rootPath, path)
-		// Write "path:content" for each file
-		h.Write([]byte(relPath))
-		h.Write([]byte(":"))
-		h.Write(content)
-		h.Write([]byte("\n"))
+// gitignoreHashOptions returns the scope of the gitignore hash: the
+// configured exclude patterns and depth limit.
+func (c *Coordinator) gitignoreHashOptions() GitignoreHashOptions {
+	return GitignoreHashOptions{
+		ExcludePatterns: c.config.ExcludePatterns,
+		MaxDepth:        c.config.GitignoreHashMaxDepth,
 	}
-
-	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 // ReconcileOnStartup checks if .gitignore files have changed since last run
@@ -1087,16 +1615,30 @@ func (c *Coordinator) ReconcileOnStartup(ctx context.Context) error {
 		// Continue anyway - treat as first run
 	}
 
-	// Compute current hash
-	currentHash, err := ComputeGitignoreHash(c.config.RootPath)
+	files, err := findGitignoreFiles(c.config.RootPath, c.gitignoreHashOptions())
 	if err != nil {
 		slog.Warn("failed to compute gitignore hash", slog.String("error", err.Error()))
 		return nil // Non-fatal, skip reconciliation
 	}
 
+	// Fast path: no .gitignore was added, removed or modified, so their
+	// content is not read at all
+	currentStamp := gitignoreStamp(files)
+	if cachedHash != "" {
+		cachedStamp, _ := c.config.Metadata.GetState(ctx, GitignoreStampKey)
+		if cachedStamp == currentStamp {
+			slog.Debug("gitignore files untouched since last run, skipping startup reconciliation")
+			return nil
+		}
+	}
+
 	// Compare hashes
+	currentHash := gitignoreContentHash(c.config.RootPath, files)
 	if cachedHash == currentHash && cachedHash != "" {
 		slog.Debug("gitignore unchanged since last run, skipping startup reconciliation")
+		if err := c.config.Metadata.SetState(ctx, GitignoreStampKey, currentStamp); err != nil {
+			slog.Warn("failed to save gitignore stamp", slog.String("error", err.Error()))
+		}
 		return nil
 	}
 
@@ -1113,10 +1655,31 @@ func (c *Coordinator) ReconcileOnStartup(ctx context.Context) error {
 		slog.Warn("failed to save gitignore hash", slog.String("error", err.Error()))
 		// Non-fatal
 	}
+	if err := c.config.Metadata.SetState(ctx, GitignoreStampKey, currentStamp); err != nil {
+		slog.Warn("failed to save gitignore stamp", slog.String("error", err.Error()))
+	}
 
 	return nil
 }
 
+// reconcileInterrupted returns ctx.Err() if the context is done, logging how far
+// the reconciliation phase got. Checked before each file operation so shutdown
+// during a large gitignore reconciliation stops before hitting a closing DB
+// (same race as BUG-037). Unlike applyFileChanges, the error is returned so
+// callers skip updating the cached gitignore hash for a partial reconciliation.
+func reconcileInterrupted(ctx context.Context, phase string, processed, total int) error {
+	select {
+	case <-ctx.Done():
+		slog.Debug("gitignore reconciliation interrupted by shutdown",
+			slog.String("phase", phase),
+			slog.Int("processed", processed),
+			slog.Int("remaining", total-processed))
+		return ctx.Err()
+	default:
+		return nil
+	}
+}
+
 // reconcileGitignoreInternal is the internal reconciliation logic without locking.
 // It's called by both handleGitignoreChange (via runtime events) and ReconcileOnStartup.
 func (c *Coordinator) reconcileGitignoreInternal(ctx context.Context) error {
@@ -1126,90 +1689,137 @@ func (c *Coordinator) reconcileGitignoreInternal(ctx context.Context) error {
 
 	slog.Debug("reconciling index after gitignore change")
 
-	// Get all indexed file paths
-	indexedPaths, err := c.config.Metadata.GetFilePathsByProject(ctx, c.config.ProjectID)
+	plan, err := c.planGitignoreFull(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get indexed files: %w", err)
+		return err
+	}
+	if err := c.applyReconciliationPlan(ctx, plan); err != nil {
+		return err
 	}
 
-	// Build a set of indexed paths for quick lookup
-	indexedSet := make(map[string]bool, len(indexedPaths))
-	for _, p := range indexedPaths {
-		indexedSet[p] = true
+	// Log summary
+	if len(plan.ToRemove) > 0 || len(plan.ToAdd) > 0 {
+		slog.Info("gitignore sync completed",
+			slog.Int("removed", len(plan.ToRemove)),
+			slog.Int("added", len(plan.ToAdd)))
+	} else {
+		slog.Debug("gitignore sync: no changes needed")
+	}
+
+	return nil
+}
+
+// planGitignoreFull computes the changes of reconcileGitignoreInternal by
+// comparing all indexed files with a scan of the project under the current
+// gitignore rules and exclude patterns.
+func (c *Coordinator) planGitignoreFull(ctx context.Context) (*ReconciliationPlan, error) {
+	// Get all indexed file paths
+	indexedPaths, err := c.config.Metadata.GetFilePathsByProject(ctx, c.config.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indexed files: %w", err)
 	}
 
 	// Scan filesystem with current gitignore rules and exclude patterns
 	resultChan, err := c.config.Scanner.Scan(ctx, &scanner.ScanOptions{
-		RootDir:          c.config.RootPath,
-		RespectGitignore: true,
-		ExcludePatterns:  c.config.ExcludePatterns,
-		LanguageRegistry: c.config.LanguageRegistry,
+		RootDir:              c.config.RootPath,
+		DataDir:              c.config.DataDir,
+		RespectGitignore:     true,
+		ExcludePatterns:      c.config.ExcludePatterns,
+		LanguageRegistry:     c.config.LanguageRegistry,
+		IncludeHidden:        c.config.IncludeHidden,
+		FollowSymlinkPaths:   c.config.FollowSymlinkPaths,
+		Binary:               c.config.BinaryDetection,
+		ContentTypeOverrides: c.config.ContentTypeOverrides,
+		LanguageOverrides:    c.config.LanguageOverrides,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to scan for gitignore reconciliation: %w", err)
+		return nil, fmt.Errorf("failed to scan for gitignore reconciliation: %w", err)
 	}
 
 	// Build a set of files that should be indexed from the channel
+	shouldBeIndexed := c.indexableScanResults(ctx, resultChan, "scan error during gitignore reconciliation", slog.LevelDebug)
+
+	plan := diffIndexedFiles(indexedPaths, shouldBeIndexed)
+	plan.Strategy = reconcileFull.String()
+	return plan, nil
+}
+
+// indexableScanResults drains a scan and returns the set of scanned files
+// that would be indexed (matching indexFile logic). Scan errors are logged
+// with msg at level.
+func (c *Coordinator) indexableScanResults(ctx context.Context, resultChan <-chan scanner.ScanResult, msg string, level slog.Level) map[string]bool {
 	shouldBeIndexed := make(map[string]bool)
 	for result := range resultChan {
 		if result.Error != nil {
-			slog.Debug("scan error during gitignore reconciliation",
+			slog.Log(ctx, level, msg,
+				slog.String("path", result.File.Path),
 				slog.String("error", result.Error.Error()))
 			continue
 		}
 		if result.File == nil {
 			continue
 		}
-		// Only consider code and markdown files (matching indexFile logic)
-		contentType := scanner.DetectContentTypeWithRegistry(result.File.Language, c.config.LanguageRegistry)
-		if isIndexableContentType(contentType) {
+		if c.isIndexable(result.File.Language, result.File.ContentType) {
 			shouldBeIndexed[result.File.Path] = true
 		}
 	}
+	return shouldBeIndexed
+}
 
-	// Find files to remove (indexed but now ignored)
-	var toRemove []string
-	for path := range indexedSet {
+// diffIndexedFiles returns a plan removing the indexed files that should no
+// longer be indexed and adding the files that should be but are not.
+func diffIndexedFiles(indexedPaths []string, shouldBeIndexed map[string]bool) *ReconciliationPlan {
+	plan := &ReconciliationPlan{}
+	indexedSet := make(map[string]bool, len(indexedPaths))
+	for _, path := range indexedPaths {
+		indexedSet[path] = true
 		if !shouldBeIndexed[path] {
-			toRemove = append(toRemove, path)
+			plan.ToRemove = append(plan.ToRemove, path)
 		}
 	}
-
-	// Find files to add (should be indexed but aren't)
-	var toAdd []string
 	for path := range shouldBeIndexed {
 		if !indexedSet[path] {
-			toAdd = append(toAdd, path)
+			plan.ToAdd = append(plan.ToAdd, path)
 		}
 	}
+	sort.Strings(plan.ToRemove)
+	sort.Strings(plan.ToAdd)
+	return plan
+}
+
+// applyReconciliationPlan removes and then indexes the files of plan.
+// Failures on single files are logged and skipped; a cancelled ctx stops it
+// with ctx.Err().
+func (c *Coordinator) applyReconciliationPlan(ctx context.Context, plan *ReconciliationPlan) error {
+	phase := plan.Strategy
+	if phase == reconcileFull.String() {
+		phase = "gitignore"
+	}
 
-	// Remove newly-ignored files
-	for _, path := range toRemove {
+	for i, path := range plan.ToRemove {
+		if err := reconcileInterrupted(ctx, phase+"_remove", i, len(plan.ToRemove)); err != nil {
+			return err
+		}
 		if err := c.removeFile(ctx, path); err != nil {
-			slog.Warn("failed to remove file during gitignore sync",
+			slog.Warn("failed to remove file during gitignore reconciliation",
+				slog.String("strategy", plan.Strategy),
 				slog.String("path", path),
 				slog.String("error", err.Error()))
 		}
 	}
 
-	// Add newly-unignored files
-	for _, path := range toAdd {
+	for i, path := range plan.ToAdd {
+		if err := reconcileInterrupted(ctx, phase+"_add", i, len(plan.ToAdd)); err != nil {
+			return err
+		}
 		if err := c.indexFile(ctx, path); err != nil {
-			slog.Warn("failed to index file during gitignore sync",
+			slog.Warn("failed to index file during gitignore reconciliation",
+				slog.String("strategy", plan.Strategy),
 				slog.String("path", path),
 				slog.String("error", err.Error()))
 		}
 	}
 
-	// Log summary
-	if len(toRemove) > 0 || len(toAdd) > 0 {
-		slog.Info("gitignore sync completed",
-			slog.Int("removed", len(toRemove)),
-			slog.Int("added", len(toAdd)))
-	} else {
-		slog.Debug("gitignore sync: no changes needed")
-	}
-
 	return nil
 }
 
@@ -1242,6 +1852,12 @@ func (c *Coordinator) ReconcileFilesOnStartup(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	return c.reconcileFiles(ctx)
+}
+
+// reconcileFiles is the file reconciliation logic without locking. It's
+// called by ReconcileFilesOnStartup and for watcher resync events.
+func (c *Coordinator) reconcileFiles(ctx context.Context) error {
 	if c.config.Scanner == nil {
 		slog.Debug("file reconciliation skipped: scanner not configured")
 		return nil
@@ -1261,18 +1877,26 @@ func (c *Coordinator) ReconcileFilesOnStartup(ctx context.Context) error {
 	}
 
 	// Step 2: Scan current filesystem
-	currentFiles, err := c.scanCurrentFiles(ctx)
+	currentFiles, unreadable, err := c.scanCurrentFiles(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to scan filesystem: %w", err)
 	}
 
-	// Step 3: Detect changes
+	// Step 3: Detect changes. Files under paths the scan could not read are
+	// unknown, not deleted, so they stay indexed until the next pass.
 	changes := c.detectFileChanges(indexedFiles, currentFiles)
+	if len(unreadable) > 0 {
+		slog.Warn("file reconciliation skipped unreadable paths",
+			slog.Int("count", len(unreadable)),
+			slog.String("first", unreadable[0]))
+		changes = dropDeletionsUnder(changes, unreadable)
+	}
 
 	if len(changes) == 0 {
 		slog.Debug("no file changes detected since last index")
 		return nil
 	}
+	priority := prioritizeChanges(changes, c.config.PriorityPaths)
 
 	// Count changes by type
 	var added, modified, deleted int
@@ -1290,10 +1914,11 @@ func (c *Coordinator) ReconcileFilesOnStartup(ctx context.Context) error {
 	slog.Info("file changes detected, reconciling",
 		slog.Int("added", added),
 		slog.Int("modified", modified),
-		slog.Int("deleted", deleted))
+		slog.Int("deleted", deleted),
+		slog.Int("priority", priority))
 
 	// Step 4: Apply changes
-	if err := c.applyFileChanges(ctx, changes); err != nil {
+	if err := c.applyFileChanges(ctx, changes, priority); err != nil {
 		return fmt.Errorf("failed to apply file changes: %w", err)
 	}
 
@@ -1303,6 +1928,34 @@ func (c *Coordinator) ReconcileFilesOnStartup(ctx context.Context) error {
 	return nil
 }
 
+// RunPeriodicReconciliation runs file reconciliation (see
+// ReconcileFilesOnStartup) every ReconcileInterval until ctx is cancelled,
+// then returns ctx.Err(). Each pass holds the coordinator lock, so it never
+// overlaps with event handling. Failed passes are logged and retried on the
+// next tick. Returns nil immediately when ReconcileInterval is not positive
+// or no Scanner is configured.
+func (c *Coordinator) RunPeriodicReconciliation(ctx context.Context) error {
+	interval := c.config.ReconcileInterval
+	if interval <= 0 || c.config.Scanner == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	slog.Debug("periodic_reconciliation_started", slog.Duration("interval", interval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := c.ReconcileFilesOnStartup(ctx); err != nil && ctx.Err() == nil {
+				slog.Warn("periodic_reconciliation_failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
 // ReconcileGraphOnStartup verifies the graph overlay and rebuilds it from the
 // committed metadata index when it is empty, stale, partial, failed, or missing
 // build metadata. It shares the coordinator lock with watcher events so rebuilds
@@ -1444,35 +2097,131 @@ func (c *Coordinator) rebuildGraphFromMetadataLocked(ctx context.Context, event,
 	return nil
 }
 
-// scanCurrentFiles performs a filesystem scan and returns map[path] -> FileInfo.
-func (c *Coordinator) scanCurrentFiles(ctx context.Context) (map[string]*scanner.FileInfo, error) {
+// scanCurrentFiles performs a filesystem scan and returns map[path] -> FileInfo,
+// along with the paths that could not be read (other than vanished ones).
+func (c *Coordinator) scanCurrentFiles(ctx context.Context) (map[string]*scanner.FileInfo, []string, error) {
 	resultChan, err := c.config.Scanner.Scan(ctx, &scanner.ScanOptions{
-		RootDir:          c.config.RootPath,
-		RespectGitignore: true,
-		ExcludePatterns:  c.config.ExcludePatterns,
-		LanguageRegistry: c.config.LanguageRegistry,
+		RootDir:              c.config.RootPath,
+		DataDir:              c.config.DataDir,
+		RespectGitignore:     true,
+		ExcludePatterns:      c.config.ExcludePatterns,
+		LanguageRegistry:     c.config.LanguageRegistry,
+		IncludeHidden:        c.config.IncludeHidden,
+		FollowSymlinkPaths:   c.config.FollowSymlinkPaths,
+		Binary:               c.config.BinaryDetection,
+		ContentTypeOverrides: c.config.ContentTypeOverrides,
+		LanguageOverrides:    c.config.LanguageOverrides,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to start scan: %w", err)
+		return nil, nil, fmt.Errorf("failed to start scan: %w", err)
 	}
 
 	current := make(map[string]*scanner.FileInfo)
+	var unreadable []string
 	for result := range resultChan {
 		if result.Error != nil {
 			slog.Debug("scan error during file reconciliation",
+				slog.String("path", result.File.Path),
 				slog.String("error", result.Error.Error()))
+			var scanErr *scanner.ScanError
+			if errors.As(result.Error, &scanErr) && scanErr.Kind != scanner.ScanErrorNotFound {
+				unreadable = append(unreadable, scanErr.Path)
+			}
 			continue
 		}
 		if result.File == nil {
 			continue
 		}
 		// Only consider indexable content types (matching indexFile logic)
-		contentType := scanner.DetectContentTypeWithRegistry(result.File.Language, c.config.LanguageRegistry)
-		if isIndexableContentType(contentType) {
+		if c.isIndexable(result.File.Language, result.File.ContentType) {
 			current[result.File.Path] = result.File
 		}
 	}
-	return current, nil
+	return current, unreadable, nil
+}
+
+// dropDeletionsUnder removes deletions of files at or under any of paths.
+// A path of "." covers the whole project.
+func dropDeletionsUnder(changes []FileChange, paths []string) []FileChange {
+	kept := changes[:0]
+	for _, ch := range changes {
+		if ch.Type == ChangeTypeDeleted && underAnyPath(ch.Path, paths) {
+			continue
+		}
+		kept = append(kept, ch)
+	}
+	return kept
+}
+
+// prioritizeChanges stably moves the changes under any of paths to the front
+// and returns how many there are.
+func prioritizeChanges(changes []FileChange, paths []string) int {
+	if len(paths) == 0 {
+		return 0
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return underAnyPath(changes[i].Path, paths) && !underAnyPath(changes[j].Path, paths)
+	})
+	n := 0
+	for n < len(changes) && underAnyPath(changes[n].Path, paths) {
+		n++
+	}
+	return n
+}
+
+// normalizePriorityPaths cleans priority paths into the project-relative,
+// OS-separated form used for change paths. Paths outside root are dropped.
+func normalizePriorityPaths(root string, paths []string) []string {
+	var normalized []string
+	for _, p := range paths {
+		p = filepath.Clean(filepath.FromSlash(p))
+		if filepath.IsAbs(p) {
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				continue
+			}
+			p = rel
+		}
+		if p == ".." || strings.HasPrefix(p, ".."+string(filepath.Separator)) {
+			slog.Warn("priority path outside project ignored", slog.String("path", p))
+			continue
+		}
+		normalized = append(normalized, p)
+	}
+	return normalized
+}
+
+// ReconcileProgress returns the progress of the current or most recent file
+// reconciliation that found changes.
+func (c *Coordinator) ReconcileProgress() ReconcileProgress {
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	return c.progress
+}
+
+func (c *Coordinator) setReconcileProgress(p ReconcileProgress) {
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	c.progress = p
+}
+
+func (c *Coordinator) advanceReconcileProgress(priority bool) {
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	if priority {
+		c.progress.PriorityDone++
+	} else {
+		c.progress.BackgroundDone++
+	}
+}
+
+func underAnyPath(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if dir == "." || path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
 }
 
 // detectFileChanges compares indexed vs current files and returns changes.
@@ -1489,12 +2238,7 @@ func (c *Coordinator) detectFileChanges(indexed map[string]*store.File, current
 				Type: ChangeTypeDeleted,
 			})
 		} else {
-			// Check if modified (mtime or size changed)
-			// Note: We truncate both to second precision since filesystem mtime
-			// resolution varies and SQLite stores with second precision
-			indexedMtime := indexedFile.ModTime.Truncate(1e9)
-			currentMtime := currentFile.ModTime.Truncate(1e9)
-			if !currentMtime.Equal(indexedMtime) || currentFile.Size != indexedFile.Size {
+			if fileChanged(indexedFile, currentFile.ModTime, currentFile.Size) {
 				changes = append(changes, FileChange{
 					Path: path,
 					Type: ChangeTypeModified,
@@ -1524,12 +2268,36 @@ func (c *Coordinator) detectFileChanges(indexed map[string]*store.File, current
 	return changes
 }
 
-// applyFileChanges processes the detected changes.
+// fileChanged reports whether a file with the given modification time and
+// size differs from its indexed version.
+func fileChanged(indexed *store.File, modTime time.Time, size int64) bool {
+	// Note: We truncate both to second precision since filesystem mtime
+	// resolution varies and SQLite stores with second precision
+	indexedMtime := indexed.ModTime.Truncate(time.Second)
+	currentMtime := modTime.Truncate(time.Second)
+	return !currentMtime.Equal(indexedMtime) || size != indexed.Size
+}
+
+// applyFileChanges processes the detected changes. The first priority
+// changes are the ones under PriorityPaths; progress is tracked for them and
+// the rest separately.
 // BUG-037: Checks context before each operation to handle graceful shutdown.
-func (c *Coordinator) applyFileChanges(ctx context.Context, changes []FileChange) error {
+func (c *Coordinator) applyFileChanges(ctx context.Context, changes []FileChange, priority int) error {
 	var deleted, modified, added int
+	started := time.Now()
+	c.setReconcileProgress(ReconcileProgress{
+		PriorityTotal:   priority,
+		BackgroundTotal: len(changes) - priority,
+	})
 
 	for i, change := range changes {
+		if i == priority && priority > 0 {
+			slog.Info("priority_reconciliation_complete",
+				slog.Int("files", priority),
+				slog.Int("remaining", len(changes)-priority),
+				slog.Duration("elapsed", time.Since(started)))
+		}
+
 		// BUG-037: Check for shutdown before each file operation.
 		// This prevents "database is closed" errors when server shuts down
 		// while reconciliation is still running in a background goroutine.
@@ -1568,6 +2336,7 @@ func (c *Coordinator) applyFileChanges(ctx context.Context, changes []FileChange
 				added++
 			}
 		}
+		c.advanceReconcileProgress(i < priority)
 	}
 
 	slog.Debug("file reconciliation applied",
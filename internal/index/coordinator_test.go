@@ -191,6 +191,64 @@ func hello() {
 	assert.NotEmpty(t, results, "expected search results for indexed file")
 }
 
+func TestCoordinator_HandleEvents_ReindexesReleasedNearDuplicates(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+	coord.config.NearDuplicateDistance = 4
+	metadata := coord.config.Metadata.(*store.SQLiteStore)
+	ctx := context.Background()
+
+	// Given: a file and a slightly edited copy of it, indexed in that order
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "a.go"), []byte(vendoredGoFile("wrap")), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "b.go"), []byte(vendoredGoFile("encode")), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "a.go", Operation: watcher.OpCreate},
+		{Path: "b.go", Operation: watcher.OpCreate},
+	}))
+
+	// Then: the copy's chunk is skipped and recorded as a near-duplicate
+	chunks, err := metadata.GetChunksByFile(ctx, coord.fileID(ctx, "b.go"))
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+	fingerprints, err := metadata.GetChunkFingerprints(ctx, "test-project")
+	require.NoError(t, err)
+	require.Len(t, fingerprints, 2)
+
+	// When: the original is rewritten so the copy no longer duplicates it
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "a.go"), []byte("package vendor\n\nfunc other() {}\n"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{{Path: "a.go", Operation: watcher.OpModify}}))
+
+	// Then: the copy is re-indexed in its place
+	chunks, err = metadata.GetChunksByFile(ctx, coord.fileID(ctx, "b.go"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, chunks)
+	fingerprints, err = metadata.GetChunkFingerprints(ctx, "test-project")
+	require.NoError(t, err)
+	for _, fp := range fingerprints {
+		assert.Empty(t, fp.CanonicalID, "no near-duplicates remain")
+	}
+}
+
+func TestCoordinator_HandleEvents_RecomputesBM25StatsAfterThreshold(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+	coord.config.BM25StatsRecomputeEvery = 2
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "a.go"), []byte("package a\n\nfunc alpha() {}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "b.go"), []byte("package b\n\nfunc beta() {}\n"), 0o644))
+
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{{Path: "a.go", Operation: watcher.OpCreate}}))
+	assert.Equal(t, 1, coord.mutationsSinceStatsRecompute)
+
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{{Path: "b.go", Operation: watcher.OpCreate}}))
+	assert.Equal(t, 0, coord.mutationsSinceStatsRecompute, "counter resets after recompute")
+
+	stats := coord.config.Engine.Stats().BM25Stats
+	require.NotNil(t, stats)
+	assert.Greater(t, stats.AvgDocLength, 0.0)
+}
+
 func TestCoordinator_HandleEvents_CreateUpdatesGraph(t *testing.T) {
 	coord, tempDir, repo, cleanup := setupTestCoordinatorWithGraph(t)
 	defer cleanup()
@@ -466,6 +524,206 @@ func TestCoordinator_HandleEvents_ConfigFileUpdatesGraphWithoutSearchChunks(t *t
 	}
 }
 
+func TestCoordinator_HandleEvents_TextChunkerIndexesConfigAndText(t *testing.T) {
+	// Given: a coordinator with a text chunker
+	coord, tempDir, repo, cleanup := setupTestCoordinatorWithGraph(t)
+	defer cleanup()
+	coord.config.TextChunker = chunk.NewTextChunker()
+
+	// When: a config file and a plain text file are created
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.yaml"), []byte("server:\n  port: 8080\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "NOTES.txt"), []byte("Rotate the staging certificates quarterly.\n"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "config.yaml", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+		{Path: "NOTES.txt", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+	}))
+
+	// Then: both files are chunked and searchable
+	for _, path := range []string{"config.yaml", "NOTES.txt"} {
+		chunks, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, path))
+		require.NoError(t, err)
+		assert.Len(t, chunks, 1, path)
+	}
+	results, err := coord.config.Engine.Search(ctx, "certificates quarterly", search.SearchOptions{Limit: 10, BM25Only: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "NOTES.txt", results[0].Chunk.FilePath)
+
+	// And: config files still feed the graph
+	edges, err := repo.ListEdges(ctx, graph.EdgeQuery{ProjectID: "test-project", SourcePath: "config.yaml"})
+	require.NoError(t, err)
+	requireGraphEdgeToFile(t, edges, graph.EdgeKindFileDefinesConfigKey, "config.yaml#server.port")
+}
+
+func TestCoordinator_HandleEvents_SkipsTextWithoutTextChunker(t *testing.T) {
+	// Given: a coordinator without a text chunker
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+
+	// When: a plain text file is created
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "NOTES.txt"), []byte("Rotate the staging certificates quarterly.\n"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "NOTES.txt", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+	}))
+
+	// Then: it is not indexed
+	file, err := coord.config.Metadata.GetFileByPath(ctx, coord.config.ProjectID, "NOTES.txt")
+	require.NoError(t, err)
+	assert.Nil(t, file)
+}
+
+func TestCoordinator_HandleEvents_AppliesContentTypeOverrides(t *testing.T) {
+	// Given: a coordinator without a text chunker that reclassifies text as markdown
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+	coord.config.ContentTypeOverrides = map[string]scanner.ContentType{"text": scanner.ContentTypeMarkdown}
+
+	// When: a plain text file is created
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "NOTES.txt"), []byte("# Certificates\n\nRotate the staging certificates quarterly.\n"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "NOTES.txt", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+	}))
+
+	// Then: it is indexed by the markdown chunker
+	chunks, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, "NOTES.txt"))
+	require.NoError(t, err)
+	require.NotEmpty(t, chunks)
+	assert.Equal(t, store.ContentTypeMarkdown, chunks[0].ContentType)
+}
+
+func TestCoordinator_HandleEvents_AppliesLanguageOverrides(t *testing.T) {
+	// Given: a coordinator that maps an unknown extension to markdown
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+	coord.config.LanguageOverrides = map[string]string{".draft": "markdown"}
+
+	// When: a file with that extension is created
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "NOTES.draft"), []byte("# Certificates\n\nRotate the staging certificates quarterly.\n"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "NOTES.draft", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+	}))
+
+	// Then: it is indexed as markdown
+	chunks, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, "NOTES.draft"))
+	require.NoError(t, err)
+	require.NotEmpty(t, chunks)
+	assert.Equal(t, store.ContentTypeMarkdown, chunks[0].ContentType)
+	assert.Equal(t, "markdown", chunks[0].Language)
+}
+
+func TestCoordinator_HandleEvents_SkipsUnchangedContent(t *testing.T) {
+	// Given: an indexed file
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+	embedder := &recordingEmbedder{Embedder: embed.NewStaticEmbedder()}
+	bm25, err := store.NewBM25IndexWithBackend(filepath.Join(tempDir, ".amanmcp", "bm25-skip"), store.DefaultBM25Config(), "")
+	require.NoError(t, err)
+	defer func() { _ = bm25.Close() }()
+	vector, err := store.NewHNSWStore(store.DefaultVectorStoreConfig(256))
+	require.NoError(t, err)
+	defer func() { _ = vector.Close() }()
+	coord.config.Engine = search.New(bm25, vector, embedder, coord.config.Metadata, search.DefaultConfig())
+
+	ctx := context.Background()
+	testFile := filepath.Join(tempDir, "main.go")
+	content := []byte("package main\n\nfunc stable() {}\n")
+	require.NoError(t, os.WriteFile(testFile, content, 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "main.go", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+	}))
+	require.NotEmpty(t, embedder.texts)
+	chunksBefore, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, "main.go"))
+	require.NoError(t, err)
+
+	// When: the file is touched without changing its content
+	embedder.texts = nil
+	touched := time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(testFile, touched, touched))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "main.go", Operation: watcher.OpModify, IsDir: false, Timestamp: time.Now()},
+	}))
+
+	// Then: nothing is re-embedded, the chunks stay, and the mtime is refreshed
+	assert.Empty(t, embedder.texts)
+	chunksAfter, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, "main.go"))
+	require.NoError(t, err)
+	assert.Len(t, chunksAfter, len(chunksBefore))
+	file, err := coord.config.Metadata.GetFileByPath(ctx, coord.config.ProjectID, "main.go")
+	require.NoError(t, err)
+	require.NotNil(t, file)
+	assert.True(t, file.ModTime.Equal(touched), "mtime %v, want %v", file.ModTime, touched)
+
+	// When: the content changes
+	require.NoError(t, os.WriteFile(testFile, []byte("package main\n\nfunc changed() {}\n"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "main.go", Operation: watcher.OpModify, IsDir: false, Timestamp: time.Now()},
+	}))
+
+	// Then: the file is re-indexed
+	assert.NotEmpty(t, embedder.texts)
+}
+
+// recordingEmbedder records the texts sent to EmbedBatch.
+type recordingEmbedder struct {
+	embed.Embedder
+	texts []string
+}
+
+func (e *recordingEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	e.texts = append(e.texts, texts...)
+	return e.Embedder.EmbedBatch(ctx, texts)
+}
+
+func TestCoordinator_HandleEvents_ReuseUnchangedEmbeddings(t *testing.T) {
+	// Given: a coordinator reusing embeddings, with a file of three blocks that
+	// each become one chunk
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+	embedder := &recordingEmbedder{Embedder: embed.NewStaticEmbedder()}
+	bm25, err := store.NewBM25IndexWithBackend(filepath.Join(tempDir, ".amanmcp", "bm25-reuse"), store.DefaultBM25Config(), "")
+	require.NoError(t, err)
+	defer func() { _ = bm25.Close() }()
+	vector, err := store.NewHNSWStore(store.DefaultVectorStoreConfig(256))
+	require.NoError(t, err)
+	defer func() { _ = vector.Close() }()
+	coord.config.Engine = search.New(bm25, vector, embedder, coord.config.Metadata, search.DefaultConfig())
+	coord.config.TextChunker = chunk.NewTextChunkerWithOptions(chunk.TextChunkerOptions{MaxChunkTokens: 8})
+	coord.config.ReuseUnchangedEmbeddings = true
+
+	ctx := context.Background()
+	write := func(content string, op watcher.Operation) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "notes.txt"), []byte(content), 0o644))
+		require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+			{Path: "notes.txt", Operation: op, IsDir: false, Timestamp: time.Now()},
+		}))
+	}
+	write("alpha alpha alpha\n\nbravo bravo bravo\n\ncharlie charlie\n", watcher.OpCreate)
+	require.Len(t, embedder.texts, 3)
+
+	// When: one block changes
+	embedder.texts = nil
+	write("alpha alpha alpha\n\nbravo bravo bravo\n\ndelta delta delta\n", watcher.OpModify)
+
+	// Then: only the changed chunk is embedded and every chunk keeps an embedding
+	assert.Equal(t, []string{"delta delta delta"}, embedder.texts)
+	withEmbedding, withoutEmbedding, err := coord.config.Metadata.GetEmbeddingStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, withEmbedding)
+	assert.Zero(t, withoutEmbedding)
+
+	// When: most blocks change
+	embedder.texts = nil
+	write("alpha alpha alpha\n\necho echo echo\n\nfoxtrot foxtrot foxtrot\n", watcher.OpModify)
+
+	// Then: the whole file is re-embedded
+	assert.Len(t, embedder.texts, 3)
+}
+
 func TestCoordinator_RefreshGraph_PurgesStaleEdgesWithoutRebuildWhenGraphIsFresh(t *testing.T) {
 	coord, _, cleanup := setupTestCoordinator(t)
 	defer cleanup()
@@ -615,6 +873,55 @@ func TestCoordinator_HandleEvents_IndexesModifiedPDF(t *testing.T) {
 	assert.NotEmpty(t, results)
 }
 
+func TestCoordinator_HandleEvents_Notebooks(t *testing.T) {
+	nb := `{"cells": [
+		{"cell_type": "markdown", "source": ["# Churn notebook marker\n", "\n", "Predicts churn from usage."]},
+		{"cell_type": "code", "source": ["import pandas as pd\n", "df = pd.read_csv(\"churn.csv\")"], "outputs": []}
+	], "metadata": {"kernelspec": {"language": "python"}}}`
+
+	t.Run("disabled by default", func(t *testing.T) {
+		coord, tempDir, cleanup := setupTestCoordinator(t)
+		defer cleanup()
+		ctx := context.Background()
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "churn.ipynb"), []byte(nb), 0o644))
+
+		err := coord.HandleEvents(ctx, []watcher.FileEvent{
+			{Path: "churn.ipynb", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+		})
+		require.NoError(t, err)
+
+		chunks, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, "churn.ipynb"))
+		require.NoError(t, err)
+		assert.Empty(t, chunks)
+	})
+
+	t.Run("indexes cells when notebook chunker is set", func(t *testing.T) {
+		coord, tempDir, cleanup := setupTestCoordinator(t)
+		defer cleanup()
+		coord.config.NotebookChunker = chunk.NewNotebookChunker(coord.config.CodeChunker, coord.config.MDChunker)
+		ctx := context.Background()
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "churn.ipynb"), []byte(nb), 0o644))
+
+		err := coord.HandleEvents(ctx, []watcher.FileEvent{
+			{Path: "churn.ipynb", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+		})
+		require.NoError(t, err)
+
+		chunks, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, "churn.ipynb"))
+		require.NoError(t, err)
+		require.Len(t, chunks, 2)
+		byCell := map[string]*store.Chunk{}
+		for _, c := range chunks {
+			byCell[c.Metadata["notebook_cell"]] = c
+		}
+		require.Contains(t, byCell, "1")
+		require.Contains(t, byCell, "2")
+		assert.Equal(t, store.ContentTypeMarkdown, byCell["1"].ContentType)
+		assert.Equal(t, store.ContentTypeCode, byCell["2"].ContentType)
+		assert.Equal(t, "python", byCell["2"].Language)
+	})
+}
+
 func TestCoordinator_HandleEvents_DoesNotRedactPDFBytesBeforeChunking(t *testing.T) {
 	coord, tempDir, cleanup := setupTestCoordinator(t)
 	defer cleanup()
@@ -703,6 +1010,49 @@ func deleteMe() {
 	assert.Empty(t, results, "expected file to be removed from index")
 }
 
+func TestCoordinator_HandleEvents_DeleteDirectory(t *testing.T) {
+	for _, isDir := range []bool{true, false} {
+		t.Run(fmt.Sprintf("is_dir=%v", isDir), func(t *testing.T) {
+			coord, tempDir, cleanup := setupTestCoordinator(t)
+			defer cleanup()
+			ctx := context.Background()
+
+			// Given: indexed files inside and beside a directory
+			require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "old", "sub"), 0o755))
+			files := map[string]string{
+				"old/a.go":     "package old\nfunc oldAlpha() {}\n",
+				"old/sub/b.go": "package sub\nfunc oldBeta() {}\n",
+				"older.go":     "package main\nfunc olderGamma() {}\n",
+			}
+			var events []watcher.FileEvent
+			for path, content := range files {
+				require.NoError(t, os.WriteFile(filepath.Join(tempDir, filepath.FromSlash(path)), []byte(content), 0o644))
+				events = append(events, watcher.FileEvent{Path: filepath.FromSlash(path), Operation: watcher.OpCreate, Timestamp: time.Now()})
+			}
+			require.NoError(t, coord.HandleEvents(ctx, events))
+
+			// When: the directory is removed and only its own delete event arrives
+			// (fsnotify reports it without IsDir since it can no longer stat it)
+			require.NoError(t, os.RemoveAll(filepath.Join(tempDir, "old")))
+			require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+				{Path: "old", Operation: watcher.OpDelete, IsDir: isDir, Timestamp: time.Now()},
+			}))
+
+			// Then: every file under it is gone and the sibling remains
+			for path, want := range map[string]bool{"old/a.go": false, "old/sub/b.go": false, "older.go": true} {
+				file, err := coord.config.Metadata.GetFileByPath(ctx, coord.config.ProjectID, filepath.FromSlash(path))
+				require.NoError(t, err)
+				assert.Equal(t, want, file != nil, path)
+			}
+			results, err := coord.config.Engine.Search(ctx, "oldBeta", search.SearchOptions{Limit: 10})
+			require.NoError(t, err)
+			for _, r := range results {
+				assert.NotContains(t, r.Chunk.FilePath, "sub", "chunks under the directory should be removed")
+			}
+		})
+	}
+}
+
 func TestCoordinator_HandleEvents_DeleteRemovesSourceEdgesAndMarksInboundEdgesStale(t *testing.T) {
 	coord, tempDir, repo, cleanup := setupTestCoordinatorWithGraph(t)
 	defer cleanup()
@@ -1498,6 +1848,36 @@ func TestCoordinator_HandleEvents_SkipsCircularSymlinks(t *testing.T) {
 	assert.NoError(t, err, "circular symlink should not cause error or hang")
 }
 
+func TestCoordinator_HandleEvents_FollowsAllowlistedSymlinks(t *testing.T) {
+	// Given: a coordinator allowlisting one of two symlinked files
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	external := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(external, "shared.go"), []byte("package shared\n\nfunc sharedFunc() {}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(external, "private.go"), []byte("package private\n\nfunc privateFunc() {}\n"), 0o644))
+	require.NoError(t, os.Symlink(filepath.Join(external, "shared.go"), filepath.Join(tempDir, "shared.go")))
+	require.NoError(t, os.Symlink(filepath.Join(external, "private.go"), filepath.Join(tempDir, "private.go")))
+	coord.config.FollowSymlinkPaths = []string{"shared.go"}
+
+	// When: both links are created
+	err := coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "shared.go", Operation: watcher.OpCreate, Timestamp: time.Now()},
+		{Path: "private.go", Operation: watcher.OpCreate, Timestamp: time.Now()},
+	})
+	require.NoError(t, err)
+
+	// Then: only the allowlisted link is indexed
+	shared, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, "shared.go"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, shared, "allowlisted symlink should be indexed")
+
+	private, err := coord.config.Metadata.GetChunksByFile(ctx, generateFileID(coord.config.ProjectID, "private.go"))
+	require.NoError(t, err)
+	assert.Empty(t, private, "other symlinks should still be skipped")
+}
+
 // =============================================================================
 // BUG-036: Startup File Reconciliation
 // =============================================================================
@@ -1535,6 +1915,206 @@ func TestCoordinator_ReconcileFilesOnStartup_DetectsNewFiles(t *testing.T) {
 	assert.Contains(t, paths, "newfile.go", "new file should be indexed")
 }
 
+func TestCoordinator_RunPeriodicReconciliation(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+
+	// Given: one indexed file and a periodic reconciliation interval
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "existing.go"), []byte("package main\nfunc existing() {}"), 0o644))
+	events := []watcher.FileEvent{{Path: "existing.go", Operation: watcher.OpCreate, Timestamp: time.Now()}}
+	require.NoError(t, coord.HandleEvents(ctx, events))
+	coord.config.ReconcileInterval = 20 * time.Millisecond
+
+	done := make(chan error, 1)
+	go func() { done <- coord.RunPeriodicReconciliation(ctx) }()
+
+	// When: a file is written without a watcher event
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "missed.go"), []byte("package main\nfunc missed() {}"), 0o644))
+
+	// Then: a later pass indexes it
+	require.Eventually(t, func() bool {
+		paths, err := coord.config.Metadata.GetFilePathsByProject(context.Background(), "test-project")
+		return err == nil && len(paths) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// And: the loop stops with the context
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("periodic reconciliation did not stop on cancellation")
+	}
+}
+
+func TestCoordinator_RunPeriodicReconciliation_Disabled(t *testing.T) {
+	coord, _, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+
+	// Given: no reconciliation interval
+	coord.config.ReconcileInterval = 0
+
+	// When / Then: the loop returns immediately
+	assert.NoError(t, coord.RunPeriodicReconciliation(context.Background()))
+}
+
+// TestCoordinator_HandleEvents_ResyncReconcilesFiles tests that a watcher
+// resync event indexes changes made while the watcher was paused.
+func TestCoordinator_HandleEvents_ResyncReconcilesFiles(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Given: one indexed file and two files written without events
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "existing.go"), []byte("package main\nfunc existing() {}"), 0o644))
+	events := []watcher.FileEvent{{Path: "existing.go", Operation: watcher.OpCreate, Timestamp: time.Now()}}
+	require.NoError(t, coord.HandleEvents(ctx, events))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "bulk1.go"), []byte("package main\nfunc bulkOne() {}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "bulk2.go"), []byte("package main\nfunc bulkTwo() {}"), 0o644))
+
+	// When: the watcher signals a resync
+	events = []watcher.FileEvent{{Operation: watcher.OpResync, Timestamp: time.Now()}}
+	require.NoError(t, coord.HandleEvents(ctx, events))
+
+	// Then: the files are indexed
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"existing.go", "bulk1.go", "bulk2.go"}, paths)
+}
+
+// recordingPauser records Pause and Resume calls.
+type recordingPauser struct {
+	calls []string
+}
+
+func (p *recordingPauser) Pause() { p.calls = append(p.calls, "pause") }
+
+func (p *recordingPauser) Resume(resync bool) int {
+	p.calls = append(p.calls, fmt.Sprintf("resume(%t)", resync))
+	return 0
+}
+
+// TestCoordinator_HandleEvents_ResyncPausesWatcher tests that the watcher is
+// paused while a resync reconciles files.
+func TestCoordinator_HandleEvents_ResyncPausesWatcher(t *testing.T) {
+	coord, _, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+
+	// Given: a coordinator fed by a pausable watcher
+	pauser := &recordingPauser{}
+	coord.config.Watcher = pauser
+
+	// When: the watcher signals a resync
+	events := []watcher.FileEvent{{Operation: watcher.OpResync, Timestamp: time.Now()}}
+	require.NoError(t, coord.HandleEvents(context.Background(), events))
+
+	// Then: the watcher is paused for the reconciliation and resumed with a
+	// resync for changes made meanwhile
+	assert.Equal(t, []string{"pause", "resume(true)"}, pauser.calls)
+}
+
+func TestCoordinator_HandleEvents_HiddenFiles(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for _, dir := range []string{".venv", ".github"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, dir), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".venv", "tool.go"), []byte("package tool\nfunc Tool() {}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".github", "ci.go"), []byte("package ci\nfunc CI() {}"), 0o644))
+	events := []watcher.FileEvent{
+		{Path: ".venv/tool.go", Operation: watcher.OpCreate, Timestamp: time.Now()},
+		{Path: ".github/ci.go", Operation: watcher.OpCreate, Timestamp: time.Now()},
+	}
+
+	// When: hidden files are created with IncludeHidden off (the default)
+	require.NoError(t, coord.HandleEvents(ctx, events))
+
+	// Then: only the one in a hidden tool directory is not indexed
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/ci.go"}, paths)
+
+	// When: the same events arrive with IncludeHidden on
+	coord.config.IncludeHidden = true
+	require.NoError(t, coord.HandleEvents(ctx, events))
+
+	// Then: both are indexed
+	paths, err = coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/ci.go", ".venv/tool.go"}, paths)
+}
+
+func TestCoordinator_HandleEvents_ReusesFileIDAfterProjectRename(t *testing.T) {
+	// Given: a file indexed under another project ID, then renamed to ours
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	metadata := coord.config.Metadata.(*store.SQLiteStore)
+	require.NoError(t, metadata.DeleteProject(ctx, "test-project"))
+	require.NoError(t, metadata.SaveProject(ctx, &store.Project{ID: "old-project", Name: "Test Project", RootPath: tempDir}))
+	coord.config.ProjectID = "old-project"
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\nfunc oldName() {}"), 0o644))
+	event := []watcher.FileEvent{{Path: "main.go", Operation: watcher.OpCreate, Timestamp: time.Now()}}
+	require.NoError(t, coord.HandleEvents(ctx, event))
+	require.NoError(t, metadata.RenameProject(ctx, "old-project", "test-project"))
+	coord.config.ProjectID = "test-project"
+
+	// When: the file is modified
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\nfunc newName() {}"), 0o644))
+	event[0].Operation = watcher.OpModify
+	require.NoError(t, coord.HandleEvents(ctx, event))
+
+	// Then: its record and chunks are replaced, not duplicated
+	paths, err := metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.go"}, paths)
+	file, err := metadata.GetFileByPath(ctx, "test-project", "main.go")
+	require.NoError(t, err)
+	chunks, err := metadata.GetChunksByFile(ctx, file.ID)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Contains(t, chunks[0].Content, "newName")
+
+	// When: the file is deleted
+	require.NoError(t, os.Remove(filepath.Join(tempDir, "main.go")))
+	event[0].Operation = watcher.OpDelete
+	require.NoError(t, coord.HandleEvents(ctx, event))
+
+	// Then: its chunks go with it
+	chunks, err = metadata.GetChunksByFile(ctx, file.ID)
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+}
+
+func TestCoordinator_HandleEvents_SkipsDataDir(t *testing.T) {
+	// Given: a coordinator whose data directory lies inside the project
+	coord, tempDir, cleanup := setupTestCoordinator(t)
+	defer cleanup()
+	coord.config.DataDir = filepath.Join(tempDir, "index-data")
+
+	ctx := context.Background()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "index-data"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "index-data", "state.go"), []byte("package state\nfunc State() {}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\nfunc main() {}"), 0o644))
+
+	// When: files are created in it and next to it
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "index-data/state.go", Operation: watcher.OpCreate, Timestamp: time.Now()},
+		{Path: "main.go", Operation: watcher.OpCreate, Timestamp: time.Now()},
+	}))
+
+	// Then: only the file outside the data directory is indexed
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.go"}, paths)
+}
+
 // TestCoordinator_ReconcileFilesOnStartup_DetectsModifiedFiles tests that modified files
 // are re-indexed on startup.
 func TestCoordinator_ReconcileFilesOnStartup_DetectsModifiedFiles(t *testing.T) {
@@ -1596,8 +2176,129 @@ func TestCoordinator_ReconcileFilesOnStartup_DetectsDeletedFiles(t *testing.T) {
 	assert.NotContains(t, paths, "tobedeleted.go", "deleted file should be removed from index")
 }
 
+func TestDropDeletionsUnder(t *testing.T) {
+	// Given: changes including deletions inside and outside an unreadable directory
+	changes := []FileChange{
+		{Path: filepath.Join("locked", "a.go"), Type: ChangeTypeDeleted},
+		{Path: filepath.Join("locked", "b.go"), Type: ChangeTypeModified},
+		{Path: "lockedout.go", Type: ChangeTypeDeleted},
+		{Path: "main.go", Type: ChangeTypeAdded},
+	}
+
+	// When: dropping deletions under the unreadable directory
+	kept := dropDeletionsUnder(changes, []string{"locked"})
+
+	// Then: only the deletion inside it is dropped
+	assert.Equal(t, []FileChange{
+		{Path: filepath.Join("locked", "b.go"), Type: ChangeTypeModified},
+		{Path: "lockedout.go", Type: ChangeTypeDeleted},
+		{Path: "main.go", Type: ChangeTypeAdded},
+	}, kept)
+
+	// An unreadable root keeps every indexed file
+	assert.Empty(t, dropDeletionsUnder([]FileChange{{Path: "x.go", Type: ChangeTypeDeleted}}, []string{"."}))
+}
+
+func TestPrioritizeChanges(t *testing.T) {
+	// Given: sorted changes, some under a priority directory
+	changes := []FileChange{
+		{Path: "old.go", Type: ChangeTypeDeleted},
+		{Path: filepath.Join("svc", "gone.go"), Type: ChangeTypeDeleted},
+		{Path: "main.go", Type: ChangeTypeModified},
+		{Path: filepath.Join("svc", "api.go"), Type: ChangeTypeAdded},
+		{Path: "svcx.go", Type: ChangeTypeAdded},
+	}
+
+	// When: prioritizing the directory
+	n := prioritizeChanges(changes, []string{"svc"})
+
+	// Then: its changes come first, each group keeping its order
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []FileChange{
+		{Path: filepath.Join("svc", "gone.go"), Type: ChangeTypeDeleted},
+		{Path: filepath.Join("svc", "api.go"), Type: ChangeTypeAdded},
+		{Path: "old.go", Type: ChangeTypeDeleted},
+		{Path: "main.go", Type: ChangeTypeModified},
+		{Path: "svcx.go", Type: ChangeTypeAdded},
+	}, changes)
+}
+
+func TestNormalizePriorityPaths(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "repo")
+	got := normalizePriorityPaths(root, []string{
+		"services/api/",
+		"./cmd",
+		filepath.Join(root, "internal", "core"),
+		"../elsewhere",
+		filepath.Join(string(filepath.Separator), "other"),
+	})
+	assert.Equal(t, []string{
+		filepath.Join("services", "api"),
+		"cmd",
+		filepath.Join("internal", "core"),
+	}, got)
+}
+
+func TestCoordinator_ReconcileFilesOnStartup_PriorityPathsFirst(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Given: an indexed project, then new files in and outside a priority directory
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\nfunc main() {}"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{{Path: "main.go", Operation: watcher.OpCreate, Timestamp: time.Now()}}))
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "hot"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "hot", "a.go"), []byte("package hot\nfunc A() {}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "cold.go"), []byte("package main\nfunc cold() {}"), 0o644))
+	coord.config.PriorityPaths = normalizePriorityPaths(tempDir, []string{"hot/"})
+
+	// When: reconciling
+	require.NoError(t, coord.ReconcileFilesOnStartup(ctx))
+
+	// Then: progress is reported per group and both files are indexed
+	assert.Equal(t, ReconcileProgress{PriorityTotal: 1, PriorityDone: 1, BackgroundTotal: 1, BackgroundDone: 1}, coord.ReconcileProgress())
+	assert.True(t, coord.ReconcileProgress().PriorityComplete())
+	for _, path := range []string{filepath.Join("hot", "a.go"), "cold.go"} {
+		file, err := coord.config.Metadata.GetFileByPath(ctx, coord.config.ProjectID, path)
+		require.NoError(t, err)
+		assert.NotNil(t, file, path)
+	}
+}
+
 // TestCoordinator_ReconcileFilesOnStartup_NoChanges tests that reconciliation
 // is fast when no changes occurred.
+func TestCoordinator_CountStaleFiles(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Given: three indexed files
+	for _, name := range []string{"stable.go", "edited.go", "removed.go"} {
+		content := fmt.Sprintf("package main\nfunc %s() {}", strings.TrimSuffix(name, ".go"))
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0o644))
+		events := []watcher.FileEvent{{Path: name, Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()}}
+		require.NoError(t, coord.HandleEvents(ctx, events))
+	}
+	stale, err := coord.CountStaleFiles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, stale)
+
+	// When: one is edited and one deleted offline, and a new file appears
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(tempDir, "edited.go"), later, later))
+	require.NoError(t, os.Remove(filepath.Join(tempDir, "removed.go")))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "added.go"), []byte("package main"), 0o644))
+	stale, err = coord.CountStaleFiles(ctx)
+
+	// Then: the edited and deleted files are stale, and the index is unchanged
+	require.NoError(t, err)
+	assert.Equal(t, 2, stale)
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"stable.go", "edited.go", "removed.go"}, paths)
+}
+
 func TestCoordinator_ReconcileFilesOnStartup_NoChanges(t *testing.T) {
 	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
 	defer cleanup()
@@ -2288,6 +2989,42 @@ func TestReconcileGitignorePatternDiff_RemovesMatchingFiles(t *testing.T) {
 	assert.NotContains(t, paths, "remove_me.generated.go", "generated file should be removed")
 }
 
+// TestReconcileGitignorePatternDiff_ContextCancelled tests that a cancelled
+// context stops pattern diff reconciliation with ctx.Err() before touching files.
+func TestReconcileGitignorePatternDiff_ContextCancelled(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Given: an indexed file that matches a newly-added ignore pattern
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "a.generated.go"), []byte("package main\nfunc a() {}"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "a.generated.go", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+	}))
+
+	// When: reconciling with an already-cancelled context
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err := coord.reconcileGitignorePatternDiff(cancelled, []string{"*.generated.go"})
+
+	// Then: a context error is returned and the file is left in the index
+	require.ErrorIs(t, err, context.Canceled)
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.Contains(t, paths, "a.generated.go")
+}
+
+func TestReconcileInterrupted(t *testing.T) {
+	// Given: a live and a cancelled context
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Then: only the cancelled context interrupts reconciliation
+	assert.NoError(t, reconcileInterrupted(context.Background(), "gitignore_add", 0, 10))
+	assert.ErrorIs(t, reconcileInterrupted(cancelled, "gitignore_add", 3, 10), context.Canceled)
+}
+
 // =============================================================================
 // reconcileGitignoreSubtree Tests (DEBT-028: Coverage improvement)
 // =============================================================================
@@ -2389,6 +3126,74 @@ func TestReconcileGitignoreSubtree_RemovesIgnoredFiles(t *testing.T) {
 	assert.NotContains(t, paths, "pkg/ignore.go", "ignored file should be removed")
 }
 
+// TestPreviewReconciliation_DoesNotApply tests that a preview lists the
+// files a gitignore change would remove without removing them.
+func TestPreviewReconciliation_DoesNotApply(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Given: two indexed files and a cached root .gitignore
+	gitignorePath := filepath.Join(tempDir, ".gitignore")
+	require.NoError(t, os.WriteFile(gitignorePath, []byte("*.log\n"), 0o644))
+	require.NoError(t, coord.config.Metadata.SetState(ctx, stateGitignoreContent, "*.log\n"))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "keep.go"), []byte("package main\nfunc keep() {}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "gen.go"), []byte("package main\nfunc gen() {}"), 0o644))
+	events := []watcher.FileEvent{
+		{Path: "keep.go", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+		{Path: "gen.go", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+	}
+	require.NoError(t, coord.HandleEvents(ctx, events))
+
+	// When: a pattern ignoring gen.go is added and the change is previewed
+	require.NoError(t, os.WriteFile(gitignorePath, []byte("*.log\ngen.go\n"), 0o644))
+	plan, err := coord.PreviewReconciliation(ctx, gitignorePath)
+	require.NoError(t, err)
+
+	// Then: the plan removes gen.go, but nothing is applied
+	assert.Equal(t, "pattern_diff", plan.Strategy)
+	assert.Equal(t, []string{"gen.go"}, plan.ToRemove)
+	assert.Empty(t, plan.ToAdd)
+
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.Len(t, paths, 2, "preview should not remove files")
+	cached, err := coord.config.Metadata.GetState(ctx, stateGitignoreContent)
+	require.NoError(t, err)
+	assert.Equal(t, "*.log\n", cached, "preview should not update the cached gitignore")
+}
+
+// TestPreviewReconciliation_Full tests that a full preview lists both
+// removals and additions.
+func TestPreviewReconciliation_Full(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Given: an indexed file, and an unindexed one the .gitignore stops ignoring
+	gitignorePath := filepath.Join(tempDir, ".gitignore")
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "a.go"), []byte("package main\nfunc a() {}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "b.go"), []byte("package main\nfunc b() {}"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{
+		{Path: "a.go", Operation: watcher.OpCreate, IsDir: false, Timestamp: time.Now()},
+	}))
+	require.NoError(t, os.WriteFile(gitignorePath, []byte("a.go\n"), 0o644))
+
+	// When: previewed without cached content (full strategy)
+	plan, err := coord.PreviewReconciliation(ctx, gitignorePath)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, "full", plan.Strategy)
+	assert.Equal(t, []string{"a.go"}, plan.ToRemove)
+	assert.Equal(t, []string{"b.go"}, plan.ToAdd)
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go"}, paths)
+}
+
 // =============================================================================
 // Additional Coordinator Edge Case Tests (DEBT-028)
 // =============================================================================
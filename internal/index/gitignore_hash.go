@@ -0,0 +1,166 @@
+package index
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/scanner"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// GitignoreHashKey is the state key for storing the gitignore hash.
+// Exported for use by index command to save hash after completion.
+const GitignoreHashKey = "gitignore_hash"
+
+// GitignoreStampKey is the state key for the stamp (path, size and
+// modification time of every .gitignore) the stored gitignore hash was
+// computed from. An unchanged stamp lets startup skip reading the files.
+const GitignoreStampKey = "gitignore_stamp"
+
+// GitignoreHashOptions limits which .gitignore files contribute to the
+// gitignore hash.
+type GitignoreHashOptions struct {
+	// ExcludePatterns skips directories the scanner excludes (see
+	// scanner.ScanOptions.ExcludePatterns).
+	ExcludePatterns []string
+
+	// MaxDepth is the number of directory levels below the root searched
+	// for .gitignore files: 1 covers the root and its direct
+	// subdirectories. Zero means no limit.
+	MaxDepth int
+}
+
+// gitignoreFile is a .gitignore found by findGitignoreFiles.
+type gitignoreFile struct {
+	relPath string
+	size    int64
+	modTime int64
+}
+
+// ComputeGitignoreHash computes a SHA256 hash of all .gitignore files in the project.
+// The hash is deterministic: files are sorted by path and each contributes "path:content".
+// Exported for use by index command to save hash after completion.
+func ComputeGitignoreHash(rootPath string) (string, error) {
+	return ComputeGitignoreHashWithOptions(rootPath, GitignoreHashOptions{})
+}
+
+// ComputeGitignoreHashWithOptions is ComputeGitignoreHash over the
+// .gitignore files selected by opts.
+func ComputeGitignoreHashWithOptions(rootPath string, opts GitignoreHashOptions) (string, error) {
+	files, err := findGitignoreFiles(rootPath, opts)
+	if err != nil {
+		return "", err
+	}
+	return gitignoreContentHash(rootPath, files), nil
+}
+
+// findGitignoreFiles walks rootPath for .gitignore files, sorted by path.
+// Hidden directories (except the root) and common large directories are
+// skipped, as are directories matching opts.ExcludePatterns or deeper than
+// opts.MaxDepth.
+func findGitignoreFiles(rootPath string, opts GitignoreHashOptions) ([]gitignoreFile, error) {
+	var files []gitignoreFile
+
+	err := filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip files we can't access
+		}
+		relPath, relErr := filepath.Rel(rootPath, path)
+		if relErr != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if relPath == "." {
+				return nil
+			}
+			name := d.Name()
+			if name[0] == '.' || name == "node_modules" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			if opts.MaxDepth > 0 && strings.Count(relPath, string(filepath.Separator))+1 > opts.MaxDepth {
+				return filepath.SkipDir
+			}
+			if scanner.MatchesExcludeDir(relPath, opts.ExcludePatterns) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != ".gitignore" {
+			return nil
+		}
+		info, infoErr := d.Info()
+		if infoErr != nil {
+			return nil
+		}
+		files = append(files, gitignoreFile{
+			relPath: relPath,
+			size:    info.Size(),
+			modTime: info.ModTime().UnixNano(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	// Sort for deterministic ordering
+	sort.Slice(files, func(i, j int) bool { return files[i].relPath < files[j].relPath })
+	return files, nil
+}
+
+// gitignoreStamp hashes the path, size and modification time of files. It
+// changes whenever a .gitignore is added, removed or rewritten, without
+// reading any of them.
+func gitignoreStamp(files []gitignoreFile) string {
+	h := sha256.New()
+	for _, f := range files {
+		h.Write([]byte(f.relPath))
+		h.Write([]byte(":"))
+		h.Write([]byte(strconv.FormatInt(f.size, 10)))
+		h.Write([]byte(":"))
+		h.Write([]byte(strconv.FormatInt(f.modTime, 10)))
+		h.Write([]byte("\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// gitignoreContentHash builds the gitignore hash from the content of files.
+func gitignoreContentHash(rootPath string, files []gitignoreFile) string {
+	h := sha256.New()
+	for _, f := range files {
+		content, err := os.ReadFile(filepath.Join(rootPath, f.relPath))
+		if err != nil {
+			continue // Skip unreadable files
+		}
+		// Write "path:content" for each file
+		h.Write([]byte(f.relPath))
+		h.Write([]byte(":"))
+		h.Write(content)
+		h.Write([]byte("\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// saveGitignoreHash computes the gitignore hash and stamp of rootPath and
+// stores them for the next startup reconciliation check.
+func saveGitignoreHash(ctx context.Context, metadata store.MetadataStore, rootPath string, opts GitignoreHashOptions) error {
+	files, err := findGitignoreFiles(rootPath, opts)
+	if err != nil {
+		return fmt.Errorf("failed to compute gitignore hash: %w", err)
+	}
+	if err := metadata.SetState(ctx, GitignoreHashKey, gitignoreContentHash(rootPath, files)); err != nil {
+		return fmt.Errorf("failed to save gitignore hash: %w", err)
+	}
+	if err := metadata.SetState(ctx, GitignoreStampKey, gitignoreStamp(files)); err != nil {
+		return fmt.Errorf("failed to save gitignore stamp: %w", err)
+	}
+	return nil
+}
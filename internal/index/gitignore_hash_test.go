@@ -0,0 +1,96 @@
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/watcher"
+)
+
+func TestComputeGitignoreHashWithOptions_Scope(t *testing.T) {
+	// Given: .gitignore files at the root, in an excluded directory and deep
+	// in the tree
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".gitignore"), []byte("*.log\n"), 0o644))
+	buildDir := filepath.Join(tempDir, "build")
+	deepDir := filepath.Join(tempDir, "a", "b", "c")
+	require.NoError(t, os.MkdirAll(buildDir, 0o755))
+	require.NoError(t, os.MkdirAll(deepDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(buildDir, ".gitignore"), []byte("*.o\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(deepDir, ".gitignore"), []byte("*.tmp\n"), 0o644))
+
+	opts := GitignoreHashOptions{ExcludePatterns: []string{"build/**"}, MaxDepth: 2}
+	scoped1, err := ComputeGitignoreHashWithOptions(tempDir, opts)
+	require.NoError(t, err)
+	full1, err := ComputeGitignoreHash(tempDir)
+	require.NoError(t, err)
+
+	// When: the out-of-scope files change
+	require.NoError(t, os.WriteFile(filepath.Join(buildDir, ".gitignore"), []byte("*.a\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(deepDir, ".gitignore"), []byte("*.bak\n"), 0o644))
+
+	// Then: only the unscoped hash changes
+	scoped2, err := ComputeGitignoreHashWithOptions(tempDir, opts)
+	require.NoError(t, err)
+	full2, err := ComputeGitignoreHash(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, scoped1, scoped2, "excluded and too deep .gitignore files should not affect the hash")
+	assert.NotEqual(t, full1, full2)
+
+	// When: an in-scope file changes
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".gitignore"), []byte("*.log\n*.out\n"), 0o644))
+
+	// Then: the scoped hash changes too
+	scoped3, err := ComputeGitignoreHashWithOptions(tempDir, opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, scoped2, scoped3)
+}
+
+func TestReconcileOnStartup_GitignoreStampFastPath(t *testing.T) {
+	coord, tempDir, cleanup := setupTestCoordinatorWithScanner(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Given: an indexed file and a saved gitignore hash and stamp
+	gitignorePath := filepath.Join(tempDir, ".gitignore")
+	require.NoError(t, os.WriteFile(gitignorePath, []byte("*.log\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "test.go"), []byte("package main\nfunc test() {}"), 0o644))
+	require.NoError(t, coord.HandleEvents(ctx, []watcher.FileEvent{{Path: "test.go", Operation: watcher.OpCreate, Timestamp: time.Now()}}))
+	require.NoError(t, saveGitignoreHash(ctx, coord.config.Metadata, tempDir, coord.gitignoreHashOptions()))
+	hash, err := coord.config.Metadata.GetState(ctx, GitignoreHashKey)
+	require.NoError(t, err)
+	stamp, err := coord.config.Metadata.GetState(ctx, GitignoreStampKey)
+	require.NoError(t, err)
+	require.NotEmpty(t, stamp)
+
+	// When: the .gitignore is touched without changing its content
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(gitignorePath, later, later))
+	require.NoError(t, coord.ReconcileOnStartup(ctx))
+
+	// Then: the content hash matches, so only the stamp is refreshed
+	newHash, err := coord.config.Metadata.GetState(ctx, GitignoreHashKey)
+	require.NoError(t, err)
+	newStamp, err := coord.config.Metadata.GetState(ctx, GitignoreStampKey)
+	require.NoError(t, err)
+	assert.Equal(t, hash, newHash)
+	assert.NotEqual(t, stamp, newStamp, "stamp should follow the new modification time")
+
+	// When: the stored hash is stale but the stamp still matches
+	require.NoError(t, coord.config.Metadata.SetState(ctx, GitignoreHashKey, "stale"))
+	require.NoError(t, coord.ReconcileOnStartup(ctx))
+
+	// Then: the files are not read again and the stale hash is kept
+	cached, err := coord.config.Metadata.GetState(ctx, GitignoreHashKey)
+	require.NoError(t, err)
+	assert.Equal(t, "stale", cached)
+	paths, err := coord.config.Metadata.GetFilePathsByProject(ctx, "test-project")
+	require.NoError(t, err)
+	assert.Len(t, paths, 1)
+}
@@ -0,0 +1,227 @@
+package index
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"math/bits"
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/chunk"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// MaxNearDuplicateDistance is the largest supported SimHash Hamming distance
+// for near-duplicate detection.
+const MaxNearDuplicateDistance = 16
+
+// DefaultNearDuplicateMinTokens is the minimum chunk size (in
+// whitespace-separated tokens) for near-duplicate detection. Smaller chunks
+// (closing braces, one-line getters) collide too easily to be deduplicated
+// safely.
+const DefaultNearDuplicateMinTokens = 20
+
+// simhashShingleSize is the number of consecutive tokens hashed per feature.
+const simhashShingleSize = 3
+
+// SimHash returns the 64-bit SimHash of content over token shingles. It
+// returns false for content with fewer than minTokens whitespace-separated
+// tokens, which is too short to fingerprint.
+func SimHash(content string, minTokens int) (uint64, bool) {
+	tokens := strings.Fields(content)
+	if len(tokens) == 0 || len(tokens) < minTokens {
+		return 0, false
+	}
+
+	var weights [64]int
+	h := fnv.New64a()
+	n := len(tokens) - simhashShingleSize + 1
+	if n < 1 {
+		n = 1
+	}
+	for i := 0; i < n; i++ {
+		h.Reset()
+		end := i + simhashShingleSize
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		for _, tok := range tokens[i:end] {
+			_, _ = h.Write([]byte(tok))
+			_, _ = h.Write([]byte{0})
+		}
+		sum := h.Sum64()
+		for b := 0; b < 64; b++ {
+			if sum&(1<<uint(b)) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	var fp uint64
+	for b := 0; b < 64; b++ {
+		if weights[b] > 0 {
+			fp |= 1 << uint(b)
+		}
+	}
+	return fp, true
+}
+
+// SimHashIndex finds the nearest of a set of SimHash fingerprints within a
+// maximum Hamming distance.
+//
+// Lookups use the pigeonhole principle: fingerprints are split into
+// maxDistance+1 bands, and any fingerprint within maxDistance bits must match
+// at least one band exactly, so only band collisions are compared.
+//
+// SimHashIndex is not safe for concurrent use.
+type SimHashIndex struct {
+	maxDistance  int
+	bandWidth    int
+	fingerprints map[string]uint64     // ID -> fingerprint
+	bands        []map[uint64][]string // band value -> IDs
+}
+
+// NewSimHashIndex creates an empty index matching fingerprints within
+// maxDistance bits, clamped to 1..MaxNearDuplicateDistance.
+func NewSimHashIndex(maxDistance int) *SimHashIndex {
+	maxDistance = max(1, min(maxDistance, MaxNearDuplicateDistance))
+	numBands := maxDistance + 1
+	x := &SimHashIndex{
+		maxDistance: maxDistance,
+		bandWidth:   64 / numBands,
+		bands:       make([]map[uint64][]string, numBands),
+	}
+	x.Reset()
+	return x
+}
+
+// Add records fp under id, replacing any fingerprint id already had.
+func (x *SimHashIndex) Add(id string, fp uint64) {
+	x.Remove(id)
+	x.fingerprints[id] = fp
+	for i, table := range x.bands {
+		key := x.band(fp, i)
+		table[key] = append(table[key], id)
+	}
+}
+
+// Has reports whether id is in the index.
+func (x *SimHashIndex) Has(id string) bool {
+	_, ok := x.fingerprints[id]
+	return ok
+}
+
+// Remove drops id from the index and reports whether it was present.
+func (x *SimHashIndex) Remove(id string) bool {
+	fp, ok := x.fingerprints[id]
+	if !ok {
+		return false
+	}
+	delete(x.fingerprints, id)
+	for i, table := range x.bands {
+		key := x.band(fp, i)
+		ids := table[key]
+		for j, other := range ids {
+			if other == id {
+				ids = append(ids[:j], ids[j+1:]...)
+				break
+			}
+		}
+		if len(ids) == 0 {
+			delete(table, key)
+		} else {
+			table[key] = ids
+		}
+	}
+	return true
+}
+
+// Nearest returns the ID whose fingerprint is nearest to fp within the
+// maximum distance. Ties are broken by ID for determinism.
+func (x *SimHashIndex) Nearest(fp uint64) (string, bool) {
+	best, bestDist := "", x.maxDistance+1
+	for i, table := range x.bands {
+		for _, id := range table[x.band(fp, i)] {
+			dist := bits.OnesCount64(fp ^ x.fingerprints[id])
+			if dist < bestDist || (dist == bestDist && id < best) {
+				best, bestDist = id, dist
+			}
+		}
+	}
+	return best, best != ""
+}
+
+// Reset removes all fingerprints.
+func (x *SimHashIndex) Reset() {
+	x.fingerprints = make(map[string]uint64)
+	for i := range x.bands {
+		x.bands[i] = make(map[uint64][]string)
+	}
+}
+
+// band extracts the i-th band of a fingerprint. The last band takes any
+// remaining high bits.
+func (x *SimHashIndex) band(fp uint64, i int) uint64 {
+	v := fp >> (uint(i) * uint(x.bandWidth))
+	if i == len(x.bands)-1 {
+		return v
+	}
+	return v & (1<<uint(x.bandWidth) - 1)
+}
+
+// filterNearDuplicates drops chunks that are near-duplicates of a canonical
+// chunk in idx, including earlier chunks of the same slice, and adds the
+// chunks it keeps to idx as canonical. A chunk already in idx stays
+// canonical. It returns the kept chunks and a fingerprint for every chunk
+// long enough to have one: kept chunks without a CanonicalID, dropped ones
+// with the chunk they duplicate.
+func filterNearDuplicates(idx *SimHashIndex, chunks []*chunk.Chunk) ([]*chunk.Chunk, []store.ChunkFingerprint) {
+	kept := make([]*chunk.Chunk, 0, len(chunks))
+	var fingerprints []store.ChunkFingerprint
+	for _, ch := range chunks {
+		// Code chunk content starts with a header naming the file, so copies
+		// in different files are compared without their context
+		text := ch.RawContent
+		if text == "" {
+			text = ch.Content
+		}
+		fp, ok := SimHash(text, DefaultNearDuplicateMinTokens)
+		if !ok {
+			kept = append(kept, ch)
+			continue
+		}
+		record := store.ChunkFingerprint{ChunkID: ch.ID, FilePath: ch.FilePath, Fingerprint: fp}
+		if !idx.Has(ch.ID) {
+			if canonicalID, found := idx.Nearest(fp); found {
+				record.CanonicalID = canonicalID
+				fingerprints = append(fingerprints, record)
+				continue
+			}
+		}
+		idx.Add(ch.ID, fp)
+		kept = append(kept, ch)
+		fingerprints = append(fingerprints, record)
+	}
+	return kept, fingerprints
+}
+
+// loadSimHashIndex builds an index of the canonical chunk fingerprints stored
+// for projectID.
+func loadSimHashIndex(ctx context.Context, nds store.NearDuplicateStore, projectID string, maxDistance int) (*SimHashIndex, error) {
+	fingerprints, err := nds.GetChunkFingerprints(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	idx := NewSimHashIndex(maxDistance)
+	for _, fp := range fingerprints {
+		if fp.CanonicalID == "" {
+			idx.Add(fp.ChunkID, fp.Fingerprint)
+		}
+	}
+	slog.Debug("near_duplicate_index_loaded",
+		slog.Int("canonical_chunks", len(idx.fingerprints)),
+		slog.Int("fingerprints", len(fingerprints)))
+	return idx, nil
+}
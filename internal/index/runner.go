@@ -5,11 +5,12 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -64,6 +65,10 @@ type RunnerResult struct {
 
 	// Resumed indicates if this was a resumed operation.
 	Resumed bool
+
+	// SymbolCounts is the number of extracted symbols per language and
+	// symbol type. It is also saved for `amanmcp index info`.
+	SymbolCounts store.SymbolCounts
 }
 
 const pdfUnindexableWarning = "PDF content unindexable: OCR, scanned, encrypted, or malformed PDFs are not supported"
@@ -97,6 +102,14 @@ type RunnerDependencies struct {
 	// PDFChunker for chunking PDF document files.
 	PDFChunker chunk.Chunker
 
+	// NotebookChunker for chunking Jupyter notebooks. Used only when
+	// Config.Paths.IndexNotebooks is set; nil creates a default one.
+	NotebookChunker chunk.Chunker
+
+	// TextChunker for chunking plain text and config files (optional).
+	// Nil skips plain text and records config files for the graph only.
+	TextChunker chunk.Chunker
+
 	// SecretScanner gates content before chunking, embedding, BM25, and vector indexing.
 	SecretScanner *secrets.Scanner
 
@@ -116,9 +129,12 @@ type Runner struct {
 	codeChunker      chunk.Chunker
 	markdownChunker  chunk.Chunker
 	pdfChunker       chunk.Chunker
+	notebookChunker  chunk.Chunker
+	textChunker      chunk.Chunker
 	languageRegistry *language.Registry
 	secretScanner    *secrets.Scanner
 	graphRepository  graph.Repository
+	chunkIDScheme    ChunkIDScheme
 }
 
 // NewRunner creates a Runner with injected dependencies.
@@ -146,7 +162,10 @@ func NewRunner(deps RunnerDependencies) (*Runner, error) {
 	codeChunker := deps.CodeChunker
 	if codeChunker == nil {
 		var chunkerErr error
-		codeChunker, chunkerErr = chunk.NewCodeChunkerWithLanguageDefinitions(chunk.CodeChunkerOptions{}, deps.Config.Search.Languages)
+		codeChunker, chunkerErr = chunk.NewCodeChunkerWithLanguageDefinitions(chunk.CodeChunkerOptions{
+			MaxChunkTokens: deps.Config.Search.CodeChunkTokens,
+			OverlapLines:   deps.Config.Search.CodeChunkOverlapLines,
+		}, deps.Config.Search.Languages)
 		if chunkerErr != nil {
 			return nil, fmt.Errorf("failed to create code chunker: %w", chunkerErr)
 		}
@@ -154,7 +173,9 @@ func NewRunner(deps RunnerDependencies) (*Runner, error) {
 
 	markdownChunker := deps.MarkdownChunker
 	if markdownChunker == nil {
-		markdownChunker = chunk.NewMarkdownChunker()
+		markdownChunker = chunk.NewMarkdownChunkerWithOptions(chunk.MarkdownChunkerOptions{
+			SplitCodeBlocks: deps.Config.Search.SplitCodeBlocks,
+		})
 	}
 
 	pdfChunker := deps.PDFChunker
@@ -162,6 +183,14 @@ func NewRunner(deps RunnerDependencies) (*Runner, error) {
 		pdfChunker = chunk.NewPDFChunker()
 	}
 
+	var notebookChunker chunk.Chunker
+	if deps.Config.Paths.IndexNotebooks {
+		notebookChunker = deps.NotebookChunker
+		if notebookChunker == nil {
+			notebookChunker = chunk.NewNotebookChunker(codeChunker, markdownChunker)
+		}
+	}
+
 	secretScanner := deps.SecretScanner
 	if secretScanner == nil {
 		secretScanner = secrets.NewScanner(secrets.DefaultPolicy())
@@ -170,6 +199,10 @@ func NewRunner(deps RunnerDependencies) (*Runner, error) {
 	if registryErr != nil {
 		return nil, fmt.Errorf("failed to create language registry: %w", registryErr)
 	}
+	chunkIDScheme, err := ParseChunkIDScheme(deps.Config.Search.ChunkIDScheme)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search.chunk_id_scheme: %w", err)
+	}
 
 	return &Runner{
 		renderer:         deps.Renderer,
@@ -181,9 +214,12 @@ func NewRunner(deps RunnerDependencies) (*Runner, error) {
 		codeChunker:      codeChunker,
 		markdownChunker:  markdownChunker,
 		pdfChunker:       pdfChunker,
+		notebookChunker:  notebookChunker,
+		textChunker:      deps.TextChunker,
 		languageRegistry: languageRegistry,
 		secretScanner:    secretScanner,
 		graphRepository:  deps.GraphRepository,
+		chunkIDScheme:    chunkIDScheme,
 	}, nil
 }
 
@@ -204,6 +240,9 @@ func (r *Runner) Close() error {
 	if c, ok := r.pdfChunker.(Closer); ok {
 		c.Close()
 	}
+	if c, ok := r.textChunker.(Closer); ok {
+		c.Close()
+	}
 	return nil
 }
 
@@ -250,12 +289,15 @@ func (r *Runner) Run(ctx context.Context, cfg RunnerConfig) (*RunnerResult, erro
 
 	// Stage 1: Scan files
 	scanStart := time.Now()
-	files, err := r.scanFiles(ctx, root)
+	files, err := r.scanFiles(ctx, root, dataDir)
 	if err != nil {
 		return nil, err
 	}
 	timing.scan = time.Since(scanStart)
 	warnCount += r.getWarningCount(files)
+	// Chunk and embed files under paths.priority first, so search over them
+	// is ready soonest on large repositories
+	prioritizeFiles(files, normalizePriorityPaths(root, r.config.Paths.Priority))
 
 	if len(files) == 0 {
 		return &RunnerResult{
@@ -298,6 +340,11 @@ func (r *Runner) Run(ctx context.Context, cfg RunnerConfig) (*RunnerResult, erro
 		}
 	}
 
+	allChunks, err = r.dedupNearDuplicates(ctx, projectID, allChunks)
+	if err != nil {
+		return nil, err
+	}
+
 	storeChunks := make([]*store.Chunk, len(allChunks))
 	for i, c := range allChunks {
 		storeChunks[i] = convertChunkToStore(c, storeFiles, now)
@@ -305,6 +352,8 @@ func (r *Runner) Run(ctx context.Context, cfg RunnerConfig) (*RunnerResult, erro
 	if err := r.metadata.SaveChunks(ctx, storeChunks); err != nil {
 		return nil, fmt.Errorf("failed to save chunks: %w", err)
 	}
+	symbolCounts := make(store.SymbolCounts)
+	symbolCounts.Add(storeChunks)
 
 	// Stage 3: Contextual enrichment (CR-1)
 	if r.config.Contextual.Enabled && cfg.ResumeFromCheckpoint == 0 {
@@ -364,14 +413,17 @@ func (r *Runner) Run(ctx context.Context, cfg RunnerConfig) (*RunnerResult, erro
 		slog.Warn("failed to store index embedding info", slog.String("error", err.Error()))
 	}
 
+	if err := r.storeSymbolCounts(ctx, symbolCounts); err != nil {
+		slog.Warn("failed to store symbol counts", slog.String("error", err.Error()))
+	}
+
 	// Save gitignore hash for startup reconciliation (BUG-053)
-	gitignoreHash, err := ComputeGitignoreHash(root)
-	if err != nil {
-		slog.Warn("failed to compute gitignore hash", slog.String("error", err.Error()))
-	} else {
-		if err := r.metadata.SetState(ctx, GitignoreHashKey, gitignoreHash); err != nil {
-			slog.Warn("failed to save gitignore hash", slog.String("error", err.Error()))
-		}
+	gitignoreOpts := GitignoreHashOptions{
+		ExcludePatterns: r.config.Paths.Exclude,
+		MaxDepth:        r.config.Paths.GitignoreMaxDepth,
+	}
+	if err := saveGitignoreHash(ctx, r.metadata, root, gitignoreOpts); err != nil {
+		slog.Warn("failed to save gitignore hash", slog.String("error", err.Error()))
 	}
 
 	duration := time.Since(startTime)
@@ -422,6 +474,7 @@ func (r *Runner) Run(ctx context.Context, cfg RunnerConfig) (*RunnerResult, erro
 		slog.String("embedder_model", embedderInfo.Model),
 		slog.Int("embedder_dimensions", embedderInfo.Dimensions),
 		slog.Float64("chunks_per_sec", chunksPerSec),
+		slog.Int("symbols", symbolCounts.Total()),
 		slog.String("path", root))
 
 	return &RunnerResult{
@@ -431,11 +484,42 @@ func (r *Runner) Run(ctx context.Context, cfg RunnerConfig) (*RunnerResult, erro
 		Errors:   errorCount,
 		Warnings: warnCount,
 		Resumed:  cfg.ResumeFromCheckpoint > 0,
+
+		SymbolCounts: symbolCounts,
 	}, nil
 }
 
+// ContentTypeOverrides converts paths.content_type_overrides to the form
+// scanner.ScanOptions takes. Values are validated by config.Validate.
+func ContentTypeOverrides(overrides map[string]string) map[string]scanner.ContentType {
+	if len(overrides) == 0 {
+		return nil
+	}
+	out := make(map[string]scanner.ContentType, len(overrides))
+	for language, contentType := range overrides {
+		out[language] = scanner.ContentType(contentType)
+	}
+	return out
+}
+
+// BinaryDetection converts the binary and data file settings in paths to the
+// form scanner.ScanOptions takes.
+func BinaryDetection(paths config.PathsConfig) scanner.BinaryDetection {
+	return scanner.BinaryDetection{
+		MaxNonPrintableRatio: paths.BinaryThreshold,
+		DecodeUTF16:          paths.DecodeUTF16,
+		InvalidUTF8:          scanner.InvalidUTF8Mode(paths.InvalidUTF8),
+		DataFiles: scanner.DataFileDetection{
+			Disabled:      paths.IndexDataFiles,
+			MinSize:       paths.DataFileMinSize,
+			MaxLineLength: paths.DataFileMaxLineLength,
+			Allow:         paths.DataFileAllow,
+		},
+	}
+}
+
 // scanFiles scans the project directory for indexable files.
-func (r *Runner) scanFiles(ctx context.Context, root string) ([]*scanner.FileInfo, error) {
+func (r *Runner) scanFiles(ctx context.Context, root, dataDir string) ([]*scanner.FileInfo, error) {
 	r.renderer.UpdateProgress(ui.ProgressEvent{
 		Stage:   ui.StageScanning,
 		Message: fmt.Sprintf("Scanning %s...", root),
@@ -448,13 +532,22 @@ func (r *Runner) scanFiles(ctx context.Context, root string) ([]*scanner.FileInf
 		return nil, fmt.Errorf("failed to create scanner: %w", err)
 	}
 
+	stats := &scanner.ScanStats{}
 	results, err := s.Scan(ctx, &scanner.ScanOptions{
-		RootDir:          root,
-		IncludePatterns:  r.config.Paths.Include,
-		ExcludePatterns:  excludePatterns,
-		RespectGitignore: true,
-		Workers:          runtime.NumCPU(),
-		LanguageRegistry: r.languageRegistry,
+		RootDir:              root,
+		DataDir:              dataDir,
+		IncludePatterns:      r.config.Paths.Include,
+		ExcludePatterns:      excludePatterns,
+		RespectGitignore:     true,
+		Workers:              runtime.NumCPU(),
+		LanguageRegistry:     r.languageRegistry,
+		IncludeNotebooks:     r.notebookChunker != nil,
+		IncludeHidden:        r.config.Paths.IncludeHidden,
+		FollowSymlinkPaths:   r.config.Paths.FollowSymlinks,
+		Binary:               BinaryDetection(r.config.Paths),
+		ContentTypeOverrides: ContentTypeOverrides(r.config.Paths.ContentTypeOverrides),
+		LanguageOverrides:    r.config.Paths.LanguageOverrides,
+		Stats:                stats,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to start scanning: %w", err)
@@ -473,11 +566,24 @@ func (r *Runner) scanFiles(ctx context.Context, root string) ([]*scanner.FileInf
 		files = append(files, result.File)
 	}
 
+	skipped := stats.Skipped()
 	slog.Info("index_scan_complete",
-		slog.Int("files", len(files)))
+		slog.Int("files", len(files)),
+		slog.Int("skipped_data_files", skipped[scanner.SkipReasonDataFile]),
+		slog.Int("skipped_max_depth_dirs", skipped[scanner.SkipReasonMaxDepth]))
 	return files, nil
 }
 
+// prioritizeFiles stably moves the files under any of paths to the front.
+func prioritizeFiles(files []*scanner.FileInfo, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return underAnyPath(files[i].Path, paths) && !underAnyPath(files[j].Path, paths)
+	})
+}
+
 // getWarningCount returns the number of warnings from scan results (currently 0 since we don't track).
 func (r *Runner) getWarningCount(files []*scanner.FileInfo) int {
 	return 0 // Warnings are tracked via renderer.AddError
@@ -496,6 +602,7 @@ func (r *Runner) chunkFiles(ctx context.Context, files []*scanner.FileInfo, proj
 		Total: totalFiles,
 	})
 
+	guard := r.contentGuard()
 	for i, file := range files {
 		r.renderer.UpdateProgress(ui.ProgressEvent{
 			Stage:       ui.StageChunking,
@@ -504,35 +611,25 @@ func (r *Runner) chunkFiles(ctx context.Context, files []*scanner.FileInfo, proj
 			CurrentFile: file.Path,
 		})
 
-		// Read file content
-		content, err := os.ReadFile(file.AbsPath)
+		// Read and guard file content
+		content, secretResult, ok, err := guard.Read(file)
 		if err != nil {
 			r.renderer.AddError(ui.ErrorEvent{
 				File:   file.Path,
-				Err:    fmt.Errorf("failed to read: %w", err),
+				Err:    err,
 				IsWarn: true,
 			})
 			warnCount++
 			continue
 		}
-
-		var secretResult secrets.Result
-		if file.ContentType != scanner.ContentTypePDF {
-			secretResult = r.secretScanner.GuardContent(secrets.ContentInput{
-				Path:    file.Path,
-				Content: content,
-				Source:  secrets.SourceIndex,
-			})
-			warnCount += r.reportSecretWarnings(secretResult.Warnings)
-			if secretResult.Blocked {
-				continue
-			}
-			content = secretResult.Content
+		warnCount += r.reportSecretWarnings(secretResult.Warnings)
+		if !ok {
+			continue
 		}
 
 		// Create store file record
 		storeFile := &store.File{
-			ID:          hashString(file.Path),
+			ID:          FileID(file.Path),
 			ProjectID:   projectID,
 			Path:        file.Path,
 			Size:        file.Size,
@@ -559,11 +656,24 @@ func (r *Runner) chunkFiles(ctx context.Context, files []*scanner.FileInfo, proj
 			chunks, err = r.markdownChunker.Chunk(ctx, input)
 		case scanner.ContentTypePDF:
 			chunks, err = r.pdfChunker.Chunk(ctx, input)
+		case scanner.ContentTypeNotebook:
+			if r.notebookChunker == nil {
+				continue
+			}
+			chunks, err = r.notebookChunker.Chunk(ctx, input)
 		case scanner.ContentTypeConfig:
-			if source, ok := graphSourceFromChunkedFile(file, content, nil); ok {
-				graphSources = append(graphSources, source)
+			if r.textChunker == nil {
+				if source, ok := graphSourceFromChunkedFile(file, content, nil); ok {
+					graphSources = append(graphSources, source)
+				}
+				continue
 			}
-			continue
+			chunks, err = r.textChunker.Chunk(ctx, input)
+		case scanner.ContentTypeText:
+			if r.textChunker == nil {
+				continue
+			}
+			chunks, err = r.textChunker.Chunk(ctx, input)
 		default:
 			continue
 		}
@@ -591,16 +701,13 @@ func (r *Runner) chunkFiles(ctx context.Context, files []*scanner.FileInfo, proj
 			continue
 		}
 
-		if file.ContentType == scanner.ContentTypePDF {
-			var secretWarnings []secrets.Warning
-			chunks, secretWarnings = guardExtractedPDFChunks(chunks, r.secretScanner, file.Path)
-			warnCount += r.reportSecretWarnings(secretWarnings)
-			if len(chunks) == 0 {
-				continue
-			}
-		} else {
-			annotateSecretScan(chunks, secretResult)
+		var secretWarnings []secrets.Warning
+		chunks, secretWarnings = guard.Annotate(file, chunks, secretResult)
+		warnCount += r.reportSecretWarnings(secretWarnings)
+		if len(chunks) == 0 {
+			continue
 		}
+		assignChunkIDs(r.chunkIDScheme, file.Path, chunks)
 		allChunks = append(allChunks, chunks...)
 		if source, ok := graphSourceFromChunkedFile(file, content, chunks); ok {
 			graphSources = append(graphSources, source)
@@ -611,6 +718,15 @@ func (r *Runner) chunkFiles(ctx context.Context, files []*scanner.FileInfo, proj
 	return allChunks, storeFiles, graphSources, warnCount
 }
 
+// contentGuard returns the checks chunkFiles applies to file content. The
+// scanner already enforced the size limit.
+func (r *Runner) contentGuard() ContentGuard {
+	return ContentGuard{
+		BinaryDetection: BinaryDetection(r.config.Paths),
+		Secrets:         r.secretScanner,
+	}
+}
+
 func (r *Runner) reportSecretWarnings(warnings []secrets.Warning) int {
 	for _, warning := range warnings {
 		r.renderer.AddError(ui.ErrorEvent{
@@ -882,58 +998,73 @@ func (r *Runner) generateEmbeddings(ctx context.Context, chunks []*chunk.Chunk,
 		Total:   len(chunks),
 	})
 
-	modelName := r.embedder.ModelName()
 	embeddedCount := startFromChunk
-
-	for batchStart := startFromChunk; batchStart < len(chunks); batchStart += embeddingBatchSize {
-		select {
-		case <-ctx.Done():
-			slog.Info("index_interrupted",
-				slog.Int("embedded", embeddedCount),
-				slog.Int("total", len(chunks)))
-			return fmt.Errorf("indexing interrupted at %d/%d chunks: %w", embeddedCount, len(chunks), ctx.Err())
-		default:
+	save := func(b embeddedBatch) error {
+		if err := store.SaveChunkEmbeddingsByModel(ctx, r.metadata, b.ids, b.embeddings, b.models); err != nil {
+			return fmt.Errorf("failed to save embeddings: %w", err)
 		}
 
-		batchEnd := batchStart + embeddingBatchSize
-		if batchEnd > len(chunks) {
-			batchEnd = len(chunks)
+		embeddedCount += len(b.ids)
+
+		if err := r.metadata.SaveIndexCheckpoint(ctx, "embedding", len(chunks), embeddedCount, currentModel); err != nil {
+			slog.Warn("failed to save checkpoint", slog.String("error", err.Error()))
 		}
-		batchChunks := chunks[batchStart:batchEnd]
 
-		batchContents := make([]string, len(batchChunks))
-		batchIDs := make([]string, len(batchChunks))
-		for i, c := range batchChunks {
-			batchContents[i] = c.Content
-			batchIDs[i] = c.ID
+		r.renderer.UpdateProgress(ui.ProgressEvent{
+			Stage:   ui.StageEmbedding,
+			Current: embeddedCount,
+			Total:   len(chunks),
+		})
+		return nil
+	}
+
+	var err error
+	if r.config.Embeddings.PipelineIndexing {
+		err = r.embedPipelined(ctx, chunks, startFromChunk, embeddingBatchSize, cfg, save)
+	} else {
+		err = r.embedBatches(ctx, chunks, startFromChunk, embeddingBatchSize, cfg, save)
+	}
+	if err != nil && ctx.Err() != nil {
+		slog.Info("index_interrupted",
+			slog.Int("embedded", embeddedCount),
+			slog.Int("total", len(chunks)))
+		return fmt.Errorf("indexing interrupted at %d/%d chunks: %w", embeddedCount, len(chunks), err)
+	}
+	return err
+}
+
+// embeddedBatch is one batch of chunk IDs with their embeddings and the
+// model that produced each embedding.
+type embeddedBatch struct {
+	ids        []string
+	embeddings [][]float32
+	models     []string
+}
+
+// embedBatches embeds chunks[startFromChunk:] in batches of batchSize and
+// passes each batch to emit before embedding the next one.
+func (r *Runner) embedBatches(ctx context.Context, chunks []*chunk.Chunk, startFromChunk, batchSize int, cfg RunnerConfig, emit func(embeddedBatch) error) error {
+	for batchStart := startFromChunk; batchStart < len(chunks); batchStart += batchSize {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
+		batchEnd := min(batchStart+batchSize, len(chunks))
+
 		// Mark final batch for timeout boost (BUG-050)
 		if batchEnd >= len(chunks) {
 			r.embedder.SetFinalBatch(true)
 		}
 
-		batchEmbeddings, err := r.embedder.EmbedBatch(ctx, batchContents)
+		ids, embeddings, models, err := r.embedChunks(ctx, chunks[batchStart:batchEnd])
 		if err != nil {
 			return fmt.Errorf("failed to generate embeddings for batch %d-%d: %w", batchStart, batchEnd, err)
 		}
 
-		if err := r.metadata.SaveChunkEmbeddings(ctx, batchIDs, batchEmbeddings, modelName); err != nil {
-			return fmt.Errorf("failed to save embeddings: %w", err)
-		}
-
-		embeddedCount += len(batchChunks)
-
-		if err := r.metadata.SaveIndexCheckpoint(ctx, "embedding", len(chunks), embeddedCount, currentModel); err != nil {
-			slog.Warn("failed to save checkpoint", slog.String("error", err.Error()))
+		if err := emit(embeddedBatch{ids: ids, embeddings: embeddings, models: models}); err != nil {
+			return err
 		}
 
-		r.renderer.UpdateProgress(ui.ProgressEvent{
-			Stage:   ui.StageEmbedding,
-			Current: embeddedCount,
-			Total:   len(chunks),
-		})
-
 		// Inter-batch cooling delay (thermal management)
 		if cfg.InterBatchDelay > 0 {
 			select {
@@ -943,10 +1074,83 @@ func (r *Runner) generateEmbeddings(ctx context.Context, chunks []*chunk.Chunk,
 			}
 		}
 	}
-
 	return nil
 }
 
+// embedPipelined is embedBatches with embedding and saving overlapped: the
+// next batch is embedded while save writes the previous one. At most one
+// embedded batch waits to be saved. An embed or save error stops both
+// sides; batches saved before it stay saved and checkpointed.
+func (r *Runner) embedPipelined(ctx context.Context, chunks []*chunk.Chunk, startFromChunk, batchSize int, cfg RunnerConfig, save func(embeddedBatch) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan embeddedBatch, 1)
+
+	// embedErr is written before batches is closed and read after
+	var embedErr error
+	go func() {
+		defer close(batches)
+		embedErr = r.embedBatches(ctx, chunks, startFromChunk, batchSize, cfg, func(b embeddedBatch) error {
+			select {
+			case batches <- b:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	for b := range batches {
+		if err := save(b); err != nil {
+			cancel()
+			for range batches {
+				// Wait for the embedder goroutine to stop
+			}
+			return err
+		}
+	}
+	return embedErr
+}
+
+// embedChunks embeds chunks in one batch, each with the model the embedder
+// routes it to (see embed.InputRouter), and returns their IDs, embeddings
+// and the model that produced each embedding, in order.
+func (r *Runner) embedChunks(ctx context.Context, chunks []*chunk.Chunk) ([]string, [][]float32, []string, error) {
+	ids := make([]string, len(chunks))
+	inputs := make([]embed.RouteInput, len(chunks))
+	for i, c := range chunks {
+		ids[i] = c.ID
+		inputs[i] = embed.RouteInput{Text: r.embedInput(c), Path: c.FilePath, Language: c.Language}
+	}
+	embeddings, models, err := embed.EmbedInputs(ctx, r.embedder, inputs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(embeddings) != len(chunks) || len(models) != len(chunks) {
+		return nil, nil, nil, fmt.Errorf("got %d embeddings for %d chunks", len(embeddings), len(chunks))
+	}
+	return ids, embeddings, models, nil
+}
+
+// embedInput returns the text embedded for c, truncated to
+// search.max_chunk_bytes like search.Engine does for incremental updates.
+func (r *Runner) embedInput(c *chunk.Chunk) string {
+	limit := r.config.Search.MaxChunkBytes
+	if limit <= 0 {
+		limit = embed.MaxInputBytesFor(r.embedder)
+	}
+	text, truncated := embed.TruncateInput(c.Content, limit)
+	if truncated {
+		slog.Info("embed_text_truncated",
+			slog.String("chunk_id", c.ID),
+			slog.String("file", c.FilePath),
+			slog.Int("bytes", len(c.Content)),
+			slog.Int("limit", limit))
+	}
+	return text
+}
+
 // buildIndices creates BM25 and vector indices from chunks.
 func (r *Runner) buildIndices(ctx context.Context, chunks []*chunk.Chunk, dataDir string, currentModel string) error {
 	r.renderer.UpdateProgress(ui.ProgressEvent{
@@ -999,20 +1203,12 @@ func (r *Runner) buildIndices(ctx context.Context, chunks []*chunk.Chunk, dataDi
 			slog.Int("count", len(missingChunks)),
 			slog.String("first_chunk", missingChunks[0].ID))
 
-		missingContents := make([]string, len(missingChunks))
-		missingIDs := make([]string, len(missingChunks))
-		for i, c := range missingChunks {
-			missingContents[i] = c.Content
-			missingIDs[i] = c.ID
-		}
-
-		regenerated, err := r.embedder.EmbedBatch(ctx, missingContents)
+		missingIDs, regenerated, missingModels, err := r.embedChunks(ctx, missingChunks)
 		if err != nil {
 			return fmt.Errorf("failed to regenerate %d missing embeddings: %w", len(missingChunks), err)
 		}
 
-		modelName := r.embedder.ModelName()
-		if err := r.metadata.SaveChunkEmbeddings(ctx, missingIDs, regenerated, modelName); err != nil {
+		if err := store.SaveChunkEmbeddingsByModel(ctx, r.metadata, missingIDs, regenerated, missingModels); err != nil {
 			slog.Warn("failed to save regenerated embeddings", slog.String("error", err.Error()))
 		}
 
@@ -1063,12 +1259,56 @@ func (r *Runner) storeIndexEmbeddingInfo(ctx context.Context) error {
 	return nil
 }
 
+// storeSymbolCounts saves the run's symbol counts for `amanmcp index info`,
+// replacing those of the previous run. Incremental updates do not adjust
+// them, so they describe the last full index run.
+func (r *Runner) storeSymbolCounts(ctx context.Context, counts store.SymbolCounts) error {
+	data, err := json.Marshal(counts)
+	if err != nil {
+		return fmt.Errorf("failed to encode symbol counts: %w", err)
+	}
+	if err := r.metadata.SetState(ctx, store.StateKeyIndexSymbolCounts, string(data)); err != nil {
+		return fmt.Errorf("failed to store symbol counts: %w", err)
+	}
+	return nil
+}
+
 // hashString returns SHA256 hash of a string (first 16 chars).
 func hashString(s string) string {
 	h := sha256.Sum256([]byte(s))
 	return hex.EncodeToString(h[:])[:16]
 }
 
+// dedupNearDuplicates drops chunks that are near-duplicates of an earlier
+// chunk when search.near_duplicate_distance is set, and records the
+// fingerprints of all chunks so that incremental updates and search results
+// know which chunk each skipped one duplicates. A full run re-chunks every
+// file, so the project's previous fingerprints are cleared first. The result
+// is deterministic for a given set of files, which keeps resume offsets valid.
+func (r *Runner) dedupNearDuplicates(ctx context.Context, projectID string, chunks []*chunk.Chunk) ([]*chunk.Chunk, error) {
+	nds, ok := r.metadata.(store.NearDuplicateStore)
+	if !ok {
+		return chunks, nil
+	}
+	if err := nds.ClearChunkFingerprints(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("failed to clear chunk fingerprints: %w", err)
+	}
+	if r.config.Search.NearDuplicateDistance <= 0 {
+		return chunks, nil
+	}
+
+	kept, fingerprints := filterNearDuplicates(NewSimHashIndex(r.config.Search.NearDuplicateDistance), chunks)
+	if err := nds.SaveChunkFingerprints(ctx, projectID, fingerprints); err != nil {
+		return nil, fmt.Errorf("failed to save chunk fingerprints: %w", err)
+	}
+	if skipped := len(chunks) - len(kept); skipped > 0 {
+		slog.Info("index_near_duplicates_skipped",
+			slog.Int("skipped", skipped),
+			slog.Int("chunks", len(kept)))
+	}
+	return kept, nil
+}
+
 // replaceIndexedFileChunks removes the prior chunk generation for each file about
 // to be re-indexed, so the stores stay a single source of truth for the current
 // content (DEBT-042). For each file it deletes the existing chunks from the search
@@ -1112,32 +1352,5 @@ func convertChunkToStore(c *chunk.Chunk, files []*store.File, now time.Time) *st
 		}
 	}
 
-	var symbols []*store.Symbol
-	for _, s := range c.Symbols {
-		symbols = append(symbols, &store.Symbol{
-			Name:       s.Name,
-			Type:       store.SymbolType(s.Type),
-			StartLine:  s.StartLine,
-			EndLine:    s.EndLine,
-			Signature:  s.Signature,
-			DocComment: s.DocComment,
-		})
-	}
-
-	return &store.Chunk{
-		ID:          c.ID,
-		FileID:      fileID,
-		FilePath:    c.FilePath,
-		Content:     c.Content,
-		RawContent:  c.RawContent,
-		Context:     c.Context,
-		ContentType: store.ContentType(c.ContentType),
-		Language:    c.Language,
-		StartLine:   c.StartLine,
-		EndLine:     c.EndLine,
-		Symbols:     symbols,
-		Metadata:    c.Metadata,
-		CreatedAt:   now,
-		UpdatedAt:   now,
-	}
+	return ConvertChunk(c, fileID, now)
 }
@@ -2,13 +2,19 @@ package index
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Aman-CERP/amanmcp/internal/chunk"
 	"github.com/Aman-CERP/amanmcp/internal/config"
+	"github.com/Aman-CERP/amanmcp/internal/embed"
 	"github.com/Aman-CERP/amanmcp/internal/graph"
 	"github.com/Aman-CERP/amanmcp/internal/store"
 	"github.com/Aman-CERP/amanmcp/internal/ui"
@@ -68,6 +74,7 @@ type MockMetadataStore struct {
 	FilesSaved      []*store.File
 	ChunksSaved     []*store.Chunk
 	EmbeddingsSaved map[string][]float32
+	EmbeddingModels map[string]string // Model each saved embedding was saved under
 
 	AllEmbeddings    map[string][]float32
 	CheckpointToLoad *store.IndexCheckpoint
@@ -195,9 +202,11 @@ func (m *MockMetadataStore) SaveChunkEmbeddings(ctx context.Context, chunkIDs []
 	m.SaveEmbeddingsCalled = true
 	if m.EmbeddingsSaved == nil {
 		m.EmbeddingsSaved = make(map[string][]float32)
+		m.EmbeddingModels = make(map[string]string)
 	}
 	for i, id := range chunkIDs {
 		m.EmbeddingsSaved[id] = embeddings[i]
+		m.EmbeddingModels[id] = model
 	}
 	return m.SaveEmbeddingsError
 }
@@ -793,6 +802,114 @@ func TestRunner_StoresEmbeddingMetadata(t *testing.T) {
 	}
 }
 
+func TestRunner_Run_RoutesEmbeddings(t *testing.T) {
+	// Given: a runner whose embedder routes generated files to a cheap model
+	metadata := &MockMetadataStore{}
+	accurate := &MockEmbedder{DimensionsValue: 8, ModelNameValue: "accurate"}
+	cheap := &MockEmbedder{DimensionsValue: 8, ModelNameValue: "cheap"}
+	router, err := embed.NewRoutingEmbedder(func(in embed.RouteInput) int {
+		if strings.HasPrefix(in.Path, "gen/") {
+			return 1
+		}
+		return 0
+	}, accurate, cheap)
+	require.NoError(t, err)
+
+	runner, err := NewRunner(RunnerDependencies{
+		Renderer:        &MockRenderer{},
+		Config:          config.NewConfig(),
+		Metadata:        metadata,
+		BM25:            &MockBM25Index{},
+		Vector:          &MockVectorStore{},
+		Embedder:        router,
+		CodeChunker:     &MockChunker{},
+		MarkdownChunker: &MockChunker{},
+	})
+	require.NoError(t, err)
+	defer runner.Close()
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "gen"), 0o755))
+	require.NoError(t, writeTestFile(filepath.Join(tmpDir, "main.go"), "package main\nfunc main() {}"))
+	require.NoError(t, writeTestFile(filepath.Join(tmpDir, "gen", "api.go"), "package gen\nfunc API() {}"))
+
+	// When: indexing the project
+	_, err = runner.Run(context.Background(), RunnerConfig{RootDir: tmpDir, DataDir: filepath.Join(tmpDir, ".amanmcp")})
+
+	// Then: each file is embedded by its routed model and recorded under it
+	require.NoError(t, err)
+	assert.Equal(t, []string{"package main\nfunc main() {}"}, accurate.BatchTexts)
+	assert.Equal(t, []string{"package gen\nfunc API() {}"}, cheap.BatchTexts)
+	assert.ElementsMatch(t, []string{"accurate", "cheap"}, slices.Collect(maps.Values(metadata.EmbeddingModels)))
+}
+
+func TestRunner_Run_PipelineIndexing(t *testing.T) {
+	// Given: a runner with pipelined indexing and more files than one embedding batch
+	cfg := config.NewConfig()
+	cfg.Embeddings.PipelineIndexing = true
+	metadata := &MockMetadataStore{}
+	embedder := &MockEmbedder{DimensionsValue: 8}
+
+	runner, err := NewRunner(RunnerDependencies{
+		Renderer:        &MockRenderer{},
+		Config:          cfg,
+		Metadata:        metadata,
+		BM25:            &MockBM25Index{},
+		Vector:          &MockVectorStore{},
+		Embedder:        embedder,
+		CodeChunker:     &MockChunker{},
+		MarkdownChunker: &MockChunker{},
+	})
+	require.NoError(t, err)
+	defer runner.Close()
+
+	tmpDir := t.TempDir()
+	for i := range 70 {
+		require.NoError(t, writeTestFile(filepath.Join(tmpDir, fmt.Sprintf("f%d.go", i)), fmt.Sprintf("package main\n// %d", i)))
+	}
+
+	// When: indexing the project
+	result, err := runner.Run(context.Background(), RunnerConfig{RootDir: tmpDir, DataDir: filepath.Join(tmpDir, ".amanmcp")})
+
+	// Then: every chunk is embedded and saved across the batches
+	require.NoError(t, err)
+	assert.Equal(t, 70, result.Chunks)
+	assert.Len(t, embedder.BatchTexts, 70)
+	assert.Len(t, metadata.EmbeddingsSaved, 70)
+}
+
+func TestRunner_Run_PipelineIndexing_SaveErrorAborts(t *testing.T) {
+	// Given: a pipelined runner whose embedding saves fail
+	cfg := config.NewConfig()
+	cfg.Embeddings.PipelineIndexing = true
+	metadata := &MockMetadataStore{SaveEmbeddingsError: errors.New("disk full")}
+
+	runner, err := NewRunner(RunnerDependencies{
+		Renderer:        &MockRenderer{},
+		Config:          cfg,
+		Metadata:        metadata,
+		BM25:            &MockBM25Index{},
+		Vector:          &MockVectorStore{},
+		Embedder:        &MockEmbedder{DimensionsValue: 8},
+		CodeChunker:     &MockChunker{},
+		MarkdownChunker: &MockChunker{},
+	})
+	require.NoError(t, err)
+	defer runner.Close()
+
+	tmpDir := t.TempDir()
+	for i := range 70 {
+		require.NoError(t, writeTestFile(filepath.Join(tmpDir, fmt.Sprintf("f%d.go", i)), fmt.Sprintf("package main\n// %d", i)))
+	}
+
+	// When: indexing the project
+	_, err = runner.Run(context.Background(), RunnerConfig{RootDir: tmpDir, DataDir: filepath.Join(tmpDir, ".amanmcp")})
+
+	// Then: the save error aborts indexing
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+}
+
 func TestRunner_Run_GraphBuildFailureIsWarning(t *testing.T) {
 	// Given: a runner whose graph repository fails during edge replacement
 	renderer := &MockRenderer{}
@@ -998,6 +1115,94 @@ func TestRunner_Run_ReplacesFileChunksOnReindex(t *testing.T) {
 	assert.NotContains(t, bm25.DeletedIDs, "genB-1", "the current chunk must not be deleted")
 }
 
+func TestRunner_Run_SkipsNearDuplicatesAndRecordsThem(t *testing.T) {
+	// Given: a file, a slightly edited copy of it and an unrelated file,
+	// with near-duplicate detection on
+	tmpDir := t.TempDir()
+	dataDir := tmpDir + "/.amanmcp"
+	require.NoError(t, os.MkdirAll(dataDir, 0o755))
+	require.NoError(t, os.WriteFile(tmpDir+"/a.go", []byte(vendoredGoFile("wrap")), 0o644))
+	require.NoError(t, os.WriteFile(tmpDir+"/b.go", []byte(vendoredGoFile("encode")), 0o644))
+	require.NoError(t, os.WriteFile(tmpDir+"/c.go", []byte("package c\n\nfunc c() {}\n"), 0o644))
+
+	metadata, err := store.NewSQLiteStore(dataDir + "/metadata.db")
+	require.NoError(t, err)
+	defer func() { _ = metadata.Close() }()
+
+	cfg := config.NewConfig()
+	cfg.Search.NearDuplicateDistance = 4
+	embedder := &MockEmbedder{}
+	runner, err := NewRunner(RunnerDependencies{
+		Renderer:        &MockRenderer{},
+		Config:          cfg,
+		Metadata:        metadata,
+		BM25:            &MockBM25Index{},
+		Vector:          &MockVectorStore{},
+		Embedder:        embedder,
+		CodeChunker:     &MockChunker{},
+		MarkdownChunker: &MockChunker{},
+		GraphRepository: &MockGraphRepository{},
+	})
+	require.NoError(t, err)
+	defer runner.Close()
+
+	// When: indexing the project
+	ctx := context.Background()
+	result, err := runner.Run(ctx, RunnerConfig{RootDir: tmpDir, DataDir: dataDir})
+	require.NoError(t, err)
+
+	// Then: the copy is neither stored nor embedded
+	assert.Equal(t, 2, result.Chunks)
+	assert.NotContains(t, embedder.BatchTexts, vendoredGoFile("encode"))
+	chunks, err := metadata.GetChunksByFile(ctx, hashString("b.go"))
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	// And: the store records it as a near-duplicate of the original
+	counts, err := metadata.CountNearDuplicates(ctx, []string{hashString("a.go0")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{hashString("a.go0"): 1}, counts)
+}
+
+func TestRunner_Run_AppliesContentTypeOverrides(t *testing.T) {
+	// Given: a config that reclassifies SQL as markdown
+	tmpDir := t.TempDir()
+	dataDir := tmpDir + "/.amanmcp"
+	require.NoError(t, os.MkdirAll(dataDir, 0o755))
+	require.NoError(t, os.WriteFile(tmpDir+"/schema.sql", []byte("CREATE TABLE users (id INT);\n"), 0o644))
+
+	metadata, err := store.NewSQLiteStore(dataDir + "/metadata.db")
+	require.NoError(t, err)
+	defer func() { _ = metadata.Close() }()
+
+	cfg := config.NewConfig()
+	cfg.Paths.ContentTypeOverrides = map[string]string{"sql": "markdown"}
+	codeChunker := &MockChunker{}
+	mdChunker := &MockChunker{}
+	runner, err := NewRunner(RunnerDependencies{
+		Renderer:        &MockRenderer{},
+		Config:          cfg,
+		Metadata:        metadata,
+		BM25:            &MockBM25Index{},
+		Vector:          &MockVectorStore{},
+		Embedder:        &MockEmbedder{},
+		CodeChunker:     codeChunker,
+		MarkdownChunker: mdChunker,
+		GraphRepository: &MockGraphRepository{},
+	})
+	require.NoError(t, err)
+	defer runner.Close()
+
+	// When: indexing the project
+	_, err = runner.Run(context.Background(), RunnerConfig{RootDir: tmpDir, DataDir: dataDir})
+	require.NoError(t, err)
+
+	// Then: the SQL file goes to the markdown chunker
+	require.Len(t, mdChunker.Inputs, 1)
+	assert.Equal(t, "schema.sql", mdChunker.Inputs[0].Path)
+	assert.Empty(t, codeChunker.Inputs)
+}
+
 func TestRunner_Run_DispatchesPDFsToPDFChunker(t *testing.T) {
 	metadata := &MockMetadataStore{AllEmbeddings: make(map[string][]float32)}
 	pdfChunker := &MockChunker{
@@ -1152,3 +1357,47 @@ func TestRunner_Run_DoesNotRedactPDFBytesBeforeChunking(t *testing.T) {
 func writeTestFile(path, content string) error {
 	return os.WriteFile(path, []byte(content), 0644)
 }
+
+func TestRunner_Run_IndexesPriorityPathsFirst(t *testing.T) {
+	// Given: a project with more files than one embedding batch, a few of
+	// them under a priority path that sorts last
+	cfg := config.NewConfig()
+	cfg.Paths.Priority = []string{"zz/hot"}
+	embedder := &MockEmbedder{DimensionsValue: 8}
+
+	runner, err := NewRunner(RunnerDependencies{
+		Renderer:        &MockRenderer{},
+		Config:          cfg,
+		Metadata:        &MockMetadataStore{},
+		BM25:            &MockBM25Index{},
+		Vector:          &MockVectorStore{},
+		Embedder:        embedder,
+		CodeChunker:     &MockChunker{},
+		MarkdownChunker: &MockChunker{},
+	})
+	require.NoError(t, err)
+	defer runner.Close()
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "aa"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "zz", "hot"), 0o755))
+	for i := range 40 {
+		require.NoError(t, writeTestFile(filepath.Join(tmpDir, "aa", fmt.Sprintf("f%d.go", i)), fmt.Sprintf("package main\n// cold %d", i)))
+	}
+	for i := range 3 {
+		require.NoError(t, writeTestFile(filepath.Join(tmpDir, "zz", "hot", fmt.Sprintf("f%d.go", i)), fmt.Sprintf("package main\n// hot %d", i)))
+	}
+
+	// When: indexing the project
+	_, err = runner.Run(context.Background(), RunnerConfig{RootDir: tmpDir, DataDir: filepath.Join(tmpDir, ".amanmcp")})
+
+	// Then: the first embedding batch starts with the priority files
+	require.NoError(t, err)
+	require.Len(t, embedder.BatchTexts, 43)
+	for _, text := range embedder.BatchTexts[:3] {
+		assert.Contains(t, text, "// hot ")
+	}
+	for _, text := range embedder.BatchTexts[3:] {
+		assert.Contains(t, text, "// cold ")
+	}
+}
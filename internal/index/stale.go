@@ -0,0 +1,61 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// staleCheckWorkers bounds the concurrent stat calls of CountStaleFiles.
+const staleCheckWorkers = 16
+
+// CountStaleFiles returns how many indexed files changed on disk since they
+// were indexed: files whose modification time or size differs from the
+// indexed one, compared as file reconciliation does, and files that no
+// longer exist. Files added since are not counted, as finding them needs a
+// full scan; files that cannot be stat'ed for other reasons are not counted
+// either.
+//
+// It only reads indexed file metadata and stats the files, so it is cheap
+// enough for status reports. Unlike the reconciliation methods it does not
+// change the index or take the coordinator lock, so a count taken while
+// changes are applied may already be out of date.
+func (c *Coordinator) CountStaleFiles(ctx context.Context) (int, error) {
+	indexedFiles, err := c.config.Metadata.GetFilesForReconciliation(ctx, c.config.ProjectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get indexed files: %w", err)
+	}
+
+	var stale atomic.Int64
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(staleCheckWorkers)
+	for path, indexed := range indexedFiles {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			info, err := os.Stat(filepath.Join(c.config.RootPath, path))
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				stale.Add(1)
+			case err != nil:
+				// Unreadable files are unknown, not stale
+			case fileChanged(indexed, info.ModTime(), info.Size()):
+				stale.Add(1)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return int(stale.Load()), nil
+}
@@ -523,6 +523,35 @@ func TestViewer_MatchesFilter_PatternFilter(t *testing.T) {
 	}
 }
 
+func TestViewer_MatchesFilter_CategoryFilter(t *testing.T) {
+	var buf strings.Builder
+	v := NewViewer(ViewerConfig{Category: CategoryQuery}, &buf)
+
+	tests := []struct {
+		name        string
+		attrs       map[string]interface{}
+		shouldMatch bool
+	}{
+		{"query category", map[string]interface{}{"category": "query"}, true},
+		{"other category", map[string]interface{}{"category": "index"}, false},
+		{"no category", map[string]interface{}{}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := LogEntry{
+				IsValid: true,
+				Attrs:   tc.attrs,
+			}
+
+			result := v.matchesFilter(entry)
+			if result != tc.shouldMatch {
+				t.Errorf("matchesFilter() = %v, want %v", result, tc.shouldMatch)
+			}
+		})
+	}
+}
+
 func TestViewer_FormatEntry_ValidEntry(t *testing.T) {
 	var buf strings.Builder
 	v := NewViewer(ViewerConfig{NoColor: true}, &buf)
@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+)
+
+// CategoryQuery is the category attribute of query log entries.
+// Filter with: amanmcp-logs --category query
+const CategoryQuery = "query"
+
+// QueryLogMessage is the message of query log entries.
+const QueryLogMessage = "search_query"
+
+// QueryRecord is one search query as written to the query log.
+type QueryRecord struct {
+	Query          string
+	BM25Weight     float64
+	SemanticWeight float64
+	ResultCount    int
+	Latency        time.Duration
+}
+
+// Redactor rewrites query text before it is logged.
+type Redactor func(query string) string
+
+// HashQuery redacts a query to a short SHA-256 digest. Repeated queries keep
+// the same digest, so they can still be counted and correlated.
+func HashQuery(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// TruncateQuery returns a Redactor that keeps the first n runes of a query.
+func TruncateQuery(n int) Redactor {
+	return func(query string) string {
+		if utf8.RuneCountInString(query) <= n {
+			return query
+		}
+		runes := []rune(query)
+		return string(runes[:n]) + "…"
+	}
+}
+
+// QueryLogger writes search queries to the log under CategoryQuery. It
+// complements telemetry.QueryMetrics, which only keeps aggregates in memory,
+// by persisting each query to the log file.
+//
+// A nil *QueryLogger is valid and logs nothing.
+type QueryLogger struct {
+	logger *slog.Logger
+	redact Redactor
+}
+
+// NewQueryLogger creates a query logger. A nil logger writes to
+// slog.Default() at log time; a nil redact logs query text verbatim.
+func NewQueryLogger(logger *slog.Logger, redact Redactor) *QueryLogger {
+	return &QueryLogger{logger: logger, redact: redact}
+}
+
+// Log writes one query record at info level.
+func (l *QueryLogger) Log(ctx context.Context, rec QueryRecord) {
+	if l == nil {
+		return
+	}
+	logger := l.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	query := rec.Query
+	if l.redact != nil {
+		query = l.redact(query)
+	}
+
+	logger.LogAttrs(ctx, slog.LevelInfo, QueryLogMessage,
+		slog.String("category", CategoryQuery),
+		slog.String("query", query),
+		slog.Bool("redacted", l.redact != nil),
+		slog.Float64("bm25_weight", rec.BM25Weight),
+		slog.Float64("semantic_weight", rec.SemanticWeight),
+		slog.Int("results", rec.ResultCount),
+		slog.Int64("latency_ms", rec.Latency.Milliseconds()),
+	)
+}
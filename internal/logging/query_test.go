@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func logQuery(t *testing.T, redact Redactor, rec QueryRecord) map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	ql := NewQueryLogger(slog.New(slog.NewJSONHandler(&buf, nil)), redact)
+	ql.Log(context.Background(), rec)
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+		t.Fatalf("query log line is not JSON: %v (%q)", err, buf.String())
+	}
+	return data
+}
+
+func TestQueryLogger_Log(t *testing.T) {
+	data := logQuery(t, nil, QueryRecord{
+		Query:          "find user by id",
+		BM25Weight:     0.35,
+		SemanticWeight: 0.65,
+		ResultCount:    7,
+		Latency:        42 * time.Millisecond,
+	})
+
+	if data["msg"] != QueryLogMessage {
+		t.Errorf("expected msg %q, got %v", QueryLogMessage, data["msg"])
+	}
+	if data["category"] != CategoryQuery {
+		t.Errorf("expected category %q, got %v", CategoryQuery, data["category"])
+	}
+	if data["query"] != "find user by id" {
+		t.Errorf("expected verbatim query, got %v", data["query"])
+	}
+	if data["redacted"] != false {
+		t.Errorf("expected redacted=false, got %v", data["redacted"])
+	}
+	if data["bm25_weight"] != 0.35 || data["semantic_weight"] != 0.65 {
+		t.Errorf("unexpected weights: %v / %v", data["bm25_weight"], data["semantic_weight"])
+	}
+	if data["results"] != float64(7) {
+		t.Errorf("expected results 7, got %v", data["results"])
+	}
+	if data["latency_ms"] != float64(42) {
+		t.Errorf("expected latency_ms 42, got %v", data["latency_ms"])
+	}
+}
+
+func TestQueryLogger_Redaction(t *testing.T) {
+	data := logQuery(t, HashQuery, QueryRecord{Query: "customer 4711 invoices"})
+
+	query, _ := data["query"].(string)
+	if strings.Contains(query, "4711") {
+		t.Errorf("redacted query leaks text: %q", query)
+	}
+	if query != HashQuery("customer 4711 invoices") {
+		t.Errorf("expected stable hash, got %q", query)
+	}
+	if data["redacted"] != true {
+		t.Errorf("expected redacted=true, got %v", data["redacted"])
+	}
+}
+
+func TestTruncateQuery(t *testing.T) {
+	redact := TruncateQuery(5)
+
+	if got := redact("short"); got != "short" {
+		t.Errorf("expected short query unchanged, got %q", got)
+	}
+	if got := redact("héllo world"); got != "héllo…" {
+		t.Errorf("expected rune-safe truncation, got %q", got)
+	}
+}
+
+func TestQueryLogger_Nil(t *testing.T) {
+	var ql *QueryLogger
+	// Should not panic
+	ql.Log(context.Background(), QueryRecord{Query: "anything"})
+}
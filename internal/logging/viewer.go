@@ -32,6 +32,7 @@ type ViewerConfig struct {
 	Pattern    *regexp.Regexp // Filter by pattern
 	NoColor    bool           // Disable colors
 	ShowSource bool           // Show source label in output
+	Category   string         // Filter by category attribute (e.g. CategoryQuery)
 }
 
 // Viewer provides log viewing and filtering capabilities.
@@ -412,6 +413,13 @@ func (v *Viewer) matchesFilter(entry LogEntry) bool {
 		}
 	}
 
+	// Category filter
+	if v.config.Category != "" {
+		if category, _ := entry.Attrs["category"].(string); category != v.config.Category {
+			return false
+		}
+	}
+
 	// Pattern filter
 	if v.config.Pattern != nil {
 		if !v.config.Pattern.MatchString(entry.Raw) {
@@ -138,6 +138,21 @@ func formatResult(sb *strings.Builder, num int, r *search.SearchResult) {
 		r.Score,
 	)
 
+	// Scope breadcrumb, e.g. "AuthService › Login"
+	if r.Chunk.EnclosingSymbol != "" {
+		fmt.Fprintf(sb, "**Scope:** `%s`\n", r.Chunk.EnclosingSymbol)
+	}
+
+	// Heading breadcrumb of markdown results, e.g. "Installation › macOS"
+	if section := r.Section(); section != "" {
+		fmt.Fprintf(sb, "**Section:** %s\n", section)
+	}
+
+	// Copies skipped at index time, e.g. vendored code
+	if r.NearDuplicates > 0 {
+		fmt.Fprintf(sb, "**Note:** %s\n", nearDuplicatesNote(r.NearDuplicates))
+	}
+
 	// Symbol names if available
 	if len(r.Chunk.Symbols) > 0 {
 		names := make([]string, len(r.Chunk.Symbols))
@@ -162,6 +177,15 @@ func formatResult(sb *strings.Builder, num int, r *search.SearchResult) {
 	fmt.Fprintf(sb, "```%s\n%s\n```\n\n", lang, content)
 }
 
+// nearDuplicatesNote describes how many near-duplicates of a result were
+// skipped at index time.
+func nearDuplicatesNote(n int) string {
+	if n == 1 {
+		return "1 near-duplicate not shown"
+	}
+	return fmt.Sprintf("%d near-duplicates not shown", n)
+}
+
 // formatDocsResult formats a documentation result preserving structure.
 func formatDocsResult(sb *strings.Builder, num int, r *search.SearchResult) {
 	if r.Chunk == nil {
@@ -173,6 +197,9 @@ func formatDocsResult(sb *strings.Builder, num int, r *search.SearchResult) {
 		r.Chunk.FilePath,
 		r.Score,
 	)
+	if section := r.Section(); section != "" {
+		fmt.Fprintf(sb, "**Section:** %s\n\n", section)
+	}
 
 	// For markdown, preserve the content as-is (no code block wrapping)
 	if r.Chunk.Language == "markdown" || r.Chunk.Language == "md" {
@@ -213,6 +240,10 @@ func ToSearchResultOutput(r *search.SearchResult) SearchResultOutput {
 		ContentType:         string(r.Chunk.ContentType),
 		MatchedTerms:        r.MatchedTerms,
 		InBothLists:         r.InBothLists,
+		Confidence:          string(r.Confidence),
+		LineCount:           r.LineCount,
+		ByteCount:           r.ByteCount,
+		NearDuplicates:      r.NearDuplicates,
 	}
 	if r.Chunk.Metadata != nil {
 		output.Chunker = r.Chunk.Metadata["chunker"]
@@ -254,6 +285,8 @@ func ToSearchResultOutput(r *search.SearchResult) SearchResultOutput {
 		output.SymbolType = string(sym.Type)
 		output.Signature = sym.Signature
 	}
+	output.EnclosingSymbol = r.Chunk.EnclosingSymbol
+	output.Section = r.Section()
 
 	// Generate human-readable match reason
 	output.MatchReason = generateMatchReason(r)
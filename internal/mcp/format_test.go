@@ -41,6 +41,84 @@ func TestFormatSearchResults_Basic(t *testing.T) {
 	assert.Contains(t, markdown, "`AuthMiddleware`")
 }
 
+func TestFormatSearchResults_EnclosingSymbol(t *testing.T) {
+	// Given: a method result with its scope breadcrumb
+	r := &search.SearchResult{
+		Chunk: &store.Chunk{
+			FilePath:        "internal/auth/service.go",
+			StartLine:       10,
+			EndLine:         20,
+			Content:         "func (s *AuthService) Login() {}",
+			Language:        "go",
+			Symbols:         []*store.Symbol{{Name: "Login", Type: store.SymbolTypeMethod}},
+			EnclosingSymbol: "AuthService › Login",
+		},
+		Score: 0.9,
+	}
+
+	// When: formatting as markdown and as structured output
+	markdown := FormatSearchResults("login", []*search.SearchResult{r})
+	output := ToSearchResultOutput(r)
+
+	// Then: both carry the breadcrumb
+	assert.Contains(t, markdown, "**Scope:** `AuthService › Login`")
+	assert.Equal(t, "AuthService › Login", output.EnclosingSymbol)
+}
+
+func TestFormatSearchResults_NearDuplicates(t *testing.T) {
+	// Given: a result with near-duplicates skipped at index time
+	r := &search.SearchResult{
+		Chunk: &store.Chunk{
+			FilePath:  "internal/auth/service.go",
+			StartLine: 10,
+			EndLine:   20,
+			Content:   "func Login() {}",
+			Language:  "go",
+		},
+		Score:          0.9,
+		NearDuplicates: 3,
+	}
+
+	// When: formatting as markdown and as structured output
+	markdown := FormatSearchResults("login", []*search.SearchResult{r})
+	output := ToSearchResultOutput(r)
+
+	// Then: both report them
+	assert.Contains(t, markdown, "**Note:** 3 near-duplicates not shown")
+	assert.Equal(t, 3, output.NearDuplicates)
+}
+
+func TestFormatSearchResults_MarkdownSection(t *testing.T) {
+	// Given: a markdown result and a Go result, both with a chunk context
+	doc := &search.SearchResult{
+		Chunk: &store.Chunk{
+			FilePath: "docs/install.md",
+			Content:  "### Homebrew\n\nbrew install amanmcp",
+			Context:  "Installation › macOS › Homebrew",
+			Language: "markdown",
+		},
+		Score: 0.9,
+	}
+	code := &search.SearchResult{
+		Chunk: &store.Chunk{
+			FilePath: "main.go",
+			Content:  "func main() {}",
+			Context:  "package main",
+			Language: "go",
+		},
+		Score: 0.8,
+	}
+
+	// When: formatting as markdown and as structured output
+	markdown := FormatSearchResults("install", []*search.SearchResult{doc, code})
+
+	// Then: only the markdown result carries its heading breadcrumb
+	assert.Contains(t, markdown, "**Section:** Installation › macOS › Homebrew")
+	assert.Equal(t, 1, strings.Count(markdown, "**Section:**"))
+	assert.Equal(t, "Installation › macOS › Homebrew", ToSearchResultOutput(doc).Section)
+	assert.Empty(t, ToSearchResultOutput(code).Section)
+}
+
 func TestFormatSearchResults_MultipleResults(t *testing.T) {
 	// Given: multiple search results
 	results := []*search.SearchResult{
@@ -358,6 +436,7 @@ func TestToSearchResultOutput_BasicFields(t *testing.T) {
 		Score:        0.95,
 		MatchedTerms: []string{"auth", "middleware"},
 		InBothLists:  true,
+		Confidence:   search.ConfidenceHigh,
 	}
 
 	// When: converting to output format
@@ -370,6 +449,7 @@ func TestToSearchResultOutput_BasicFields(t *testing.T) {
 	assert.Equal(t, "go", output.Language)
 	assert.Equal(t, []string{"auth", "middleware"}, output.MatchedTerms)
 	assert.True(t, output.InBothLists)
+	assert.Equal(t, "high", output.Confidence)
 }
 
 func TestToSearchResultOutput_WithSymbol(t *testing.T) {
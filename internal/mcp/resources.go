@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -274,3 +275,70 @@ func (s *Server) makeQueryMetricsHandler() mcp.ResourceHandler {
 		}, nil
 	}
 }
+
+// RecentQueriesOutput is the JSON structure for the recent_queries resource.
+type RecentQueriesOutput struct {
+	Queries []RecentQueryOutput `json:"queries"`
+}
+
+// RecentQueryOutput is one query handled by the server.
+type RecentQueryOutput struct {
+	Query       string    `json:"query"`
+	QueryType   string    `json:"query_type"`
+	ResultCount int       `json:"result_count"`
+	LatencyMS   int64     `json:"latency_ms"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// registerRecentQueriesResource registers the recent_queries resource.
+func (s *Server) registerRecentQueriesResource() {
+	s.mcp.AddResource(
+		&mcp.Resource{
+			Name:        "recent_queries",
+			URI:         "amanmcp://recent_queries",
+			Description: "Most recent search queries and their outcomes, newest first",
+			MIMEType:    "application/json",
+		},
+		s.makeRecentQueriesHandler(),
+	)
+}
+
+// makeRecentQueriesHandler creates a handler for the recent_queries resource.
+func (s *Server) makeRecentQueriesHandler() mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		s.mu.RLock()
+		metrics := s.metrics
+		s.mu.RUnlock()
+
+		if metrics == nil {
+			return nil, NewInvalidParamsError("query metrics not available")
+		}
+
+		events := metrics.RecentQueries(0)
+		output := RecentQueriesOutput{Queries: make([]RecentQueryOutput, 0, len(events))}
+		for _, e := range events {
+			output.Queries = append(output.Queries, RecentQueryOutput{
+				Query:       e.Query,
+				QueryType:   string(e.QueryType),
+				ResultCount: e.ResultCount,
+				LatencyMS:   e.Latency.Milliseconds(),
+				Timestamp:   e.Timestamp,
+			})
+		}
+
+		content, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return nil, MapError(err)
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      "amanmcp://recent_queries",
+					MIMEType: "application/json",
+					Text:     string(content),
+				},
+			},
+		}, nil
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
 	"path"
 	"strconv"
 	"strings"
@@ -85,6 +86,7 @@ type SearchExplainOutput struct {
 	DimensionMismatch    bool                  `json:"dimension_mismatch,omitempty"`
 	MultiQueryDecomposed bool                  `json:"multi_query_decomposed,omitempty"`
 	SubQueries           []string              `json:"sub_queries,omitempty"`
+	SubQueryResultCounts map[string]int        `json:"sub_query_result_counts,omitempty"`
 	Reranker             SearchRerankerOutput  `json:"reranker"`
 	Warnings             []SearchWarningOutput `json:"warnings,omitempty"`
 }
@@ -95,7 +97,11 @@ type SearchResultExplainOutput struct {
 	VectorRank  int     `json:"vector_rank,omitempty"`
 	VectorScore float64 `json:"vector_score,omitempty"`
 	RRFScore    float64 `json:"rrf_score,omitempty"`
+	RawRRFScore float64 `json:"raw_rrf_score,omitempty"`
 	InBothLists bool    `json:"in_both_lists,omitempty"`
+
+	MatchedSubQueries []string `json:"matched_sub_queries,omitempty"`
+	PathMatchBoost    float64  `json:"path_match_boost,omitempty"`
 }
 
 type searchOutputBuildContext struct {
@@ -235,6 +241,7 @@ func buildSearchExplain(ctx searchOutputBuildContext, results []*search.SearchRe
 		output.DimensionMismatch = explain.DimensionMismatch
 		output.MultiQueryDecomposed = explain.MultiQueryDecomposed
 		output.SubQueries = append([]string(nil), explain.SubQueries...)
+		output.SubQueryResultCounts = maps.Clone(explain.SubQueryResultCounts)
 	}
 
 	return output
@@ -270,7 +277,11 @@ func toSearchResultOutput(toolName, query string, r *search.SearchResult, explai
 			VectorRank:  r.VecRank,
 			VectorScore: r.VecScore,
 			RRFScore:    r.Score,
+			RawRRFScore: r.RawScore,
 			InBothLists: r.InBothLists,
+
+			MatchedSubQueries: r.MatchedSubQueries,
+			PathMatchBoost:    r.PathMatchBoost,
 		}
 	}
 	return output
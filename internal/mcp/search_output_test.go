@@ -280,6 +280,24 @@ func TestSDKSearchDocsHandler_DecisionsModeSetsProjectMemoryDefaults(t *testing.
 	assert.Contains(t, capturedOpts.Scopes, "docs/reference/decisions")
 }
 
+func TestSDKSearchCodeHandler_SortBy(t *testing.T) {
+	var capturedOpts search.SearchOptions
+	engine := &MockSearchEngine{
+		SearchFn: func(ctx context.Context, query string, opts search.SearchOptions) ([]*search.SearchResult, error) {
+			capturedOpts = opts
+			return []*search.SearchResult{}, nil
+		},
+	}
+	srv := newTestServerWithEngine(t, engine)
+
+	_, _, err := srv.mcpSearchCodeHandler(context.Background(), nil, SearchCodeInput{Query: "handlers", SortBy: "line"})
+	require.NoError(t, err)
+	assert.Equal(t, search.SortByLine, capturedOpts.SortBy)
+
+	_, _, err = srv.mcpSearchCodeHandler(context.Background(), nil, SearchCodeInput{Query: "handlers", SortBy: "size"})
+	require.Error(t, err)
+}
+
 func TestSearchResultID_IsStableAndIndependentOfRank(t *testing.T) {
 	first := searchQualityResult("chunk-auth", "internal/auth/handler.go", 10, 40)
 	second := searchQualityResult("chunk-cache", "internal/cache/cache.go", 50, 70)
@@ -41,6 +41,9 @@ type Server struct {
 	// Background indexing progress (nil if not indexing)
 	indexProgress *async.IndexProgress
 
+	// File reconciliation progress (optional, set via SetReconcileProgress)
+	reconcileProgress func() ReconcileStatus
+
 	// Query telemetry (optional, set via SetMetrics)
 	metrics *telemetry.QueryMetrics
 
@@ -76,13 +79,16 @@ type ResourceContent struct {
 
 // SearchInput defines the input schema for the search tool.
 type SearchInput struct {
-	Query    string   `json:"query" jsonschema:"the search query to execute"`
-	Limit    int      `json:"limit,omitempty" jsonschema:"maximum number of results, default 10"`
-	Filter   string   `json:"filter,omitempty" jsonschema:"filter by content type: all, code, docs"`
-	Language string   `json:"language,omitempty" jsonschema:"filter by programming language, e.g. go, typescript"`
-	Scope    []string `json:"scope,omitempty" jsonschema:"filter by path prefixes (OR logic)"`
-	Profile  string   `json:"profile,omitempty" jsonschema:"retrieval profile: code, project-memory, review-corpus, archive"`
-	Explain  bool     `json:"explain,omitempty" jsonschema:"include verbose search explainability metadata"`
+	Query          string   `json:"query" jsonschema:"the search query to execute"`
+	Limit          int      `json:"limit,omitempty" jsonschema:"maximum number of results, default 10"`
+	Filter         string   `json:"filter,omitempty" jsonschema:"filter by content type: all, code, docs"`
+	Language       string   `json:"language,omitempty" jsonschema:"filter by programming language, e.g. go, typescript"`
+	Scope          []string `json:"scope,omitempty" jsonschema:"filter by path prefixes (OR logic)"`
+	Profile        string   `json:"profile,omitempty" jsonschema:"retrieval profile: code, project-memory, review-corpus, archive"`
+	Explain        bool     `json:"explain,omitempty" jsonschema:"include verbose search explainability metadata"`
+	SortBy         string   `json:"sort_by,omitempty" jsonschema:"result order: score (default), path, recency, or line"`
+	IncludeTests   string   `json:"include_tests,omitempty" jsonschema:"test file results: demote (default), exclude, or only"`
+	BoostPathMatch bool     `json:"boost_path_match,omitempty" jsonschema:"rank results from files whose path matches the query higher, e.g. for a query naming a file"`
 }
 
 // SearchOutput defines the output schema for the search tool.
@@ -111,8 +117,14 @@ type SearchResultOutput struct {
 	Symbol              string                     `json:"symbol,omitempty" jsonschema:"primary symbol name (function, class, type)"`
 	SymbolType          string                     `json:"symbol_type,omitempty" jsonschema:"type of symbol: function, class, interface, type, method"`
 	Signature           string                     `json:"signature,omitempty" jsonschema:"full function/method signature"`
+	EnclosingSymbol     string                     `json:"enclosing_symbol,omitempty" jsonschema:"scope breadcrumb of the result, outermost first, e.g. AuthService › Login"`
+	Section             string                     `json:"section,omitempty" jsonschema:"heading breadcrumb of a markdown result, outermost first, e.g. Installation › macOS › Homebrew"`
 	MatchedTerms        []string                   `json:"matched_terms,omitempty" jsonschema:"query terms that matched this result"`
 	InBothLists         bool                       `json:"in_both_lists,omitempty" jsonschema:"true if result appeared in both keyword and semantic search"`
+	Confidence          string                     `json:"confidence,omitempty" jsonschema:"coarse relevance label relative to the other results: high, medium, or low"`
+	LineCount           int                        `json:"line_count,omitempty" jsonschema:"number of lines the result spans"`
+	ByteCount           int                        `json:"byte_count,omitempty" jsonschema:"length of the result content in bytes"`
+	NearDuplicates      int                        `json:"near_duplicates,omitempty" jsonschema:"number of near-duplicate chunks of this result skipped at index time"`
 	Explain             *SearchResultExplainOutput `json:"explain,omitempty" jsonschema:"per-result stage diagnostics; present only when explain is true"`
 
 	SourceClass     string   `json:"source_class" jsonschema:"source artifact class, e.g. source_code, docs, adr, review_corpus"`
@@ -181,8 +193,17 @@ func (s *Server) SetIndexProgress(progress *async.IndexProgress) {
 	s.indexProgress = progress
 }
 
+// SetReconcileProgress sets the source of file reconciliation progress,
+// reported by index_status while the server catches up with changes made
+// while it was stopped.
+func (s *Server) SetReconcileProgress(progress func() ReconcileStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reconcileProgress = progress
+}
+
 // SetMetrics sets the query metrics collector for telemetry.
-// When set, a query_metrics resource is registered.
+// When set, the query_metrics and recent_queries resources are registered.
 func (s *Server) SetMetrics(m *telemetry.QueryMetrics) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -191,6 +212,7 @@ func (s *Server) SetMetrics(m *telemetry.QueryMetrics) {
 	// Register query_metrics resource if metrics is provided
 	if m != nil {
 		s.registerQueryMetricsResource()
+		s.registerRecentQueriesResource()
 	}
 }
 
@@ -324,6 +346,23 @@ func (s *Server) handleSearchTool(ctx context.Context, args map[string]any) (str
 	if lang, ok := args["language"].(string); ok {
 		opts.Language = lang
 	}
+	if sortValue, ok := args["sort_by"].(string); ok {
+		sortBy, err := search.ParseSortBy(sortValue)
+		if err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
+		opts.SortBy = sortBy
+	}
+	if testsValue, ok := args["include_tests"].(string); ok {
+		includeTests, err := search.ParseTestFileMode(testsValue)
+		if err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
+		opts.IncludeTests = includeTests
+	}
+	if boostPath, ok := args["boost_path_match"].(bool); ok {
+		opts.BoostPathMatch = boostPath
+	}
 	if scope, ok := args["scope"].([]interface{}); ok {
 		for _, s := range scope {
 			if str, ok := s.(string); ok {
@@ -389,6 +428,23 @@ func (s *Server) handleSearchCodeTool(ctx context.Context, args map[string]any)
 		}
 		opts.Profile = profile
 	}
+	if sortValue, ok := args["sort_by"].(string); ok {
+		sortBy, err := search.ParseSortBy(sortValue)
+		if err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
+		opts.SortBy = sortBy
+	}
+	if testsValue, ok := args["include_tests"].(string); ok {
+		includeTests, err := search.ParseTestFileMode(testsValue)
+		if err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
+		opts.IncludeTests = includeTests
+	}
+	if boostPath, ok := args["boost_path_match"].(bool); ok {
+		opts.BoostPathMatch = boostPath
+	}
 
 	// Language filter
 	var langFilter string
@@ -470,6 +526,13 @@ func (s *Server) handleSearchDocsTool(ctx context.Context, args map[string]any)
 		}
 		opts.Profile = profile
 	}
+	if sortValue, ok := args["sort_by"].(string); ok {
+		sortBy, err := search.ParseSortBy(sortValue)
+		if err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
+		opts.SortBy = sortBy
+	}
 
 	if modeValue, ok := args["mode"].(string); ok && modeValue != "" {
 		mode, err := search.ParseMode(modeValue)
@@ -612,8 +675,15 @@ func (s *Server) handleIndexStatusTool(ctx context.Context, _ map[string]any) (*
 	// Add indexing progress if available
 	s.mu.RLock()
 	progress := s.indexProgress
+	reconcileProgress := s.reconcileProgress
 	s.mu.RUnlock()
 
+	if reconcileProgress != nil {
+		if rs := reconcileProgress(); rs.PriorityTotal+rs.BackgroundTotal > 0 {
+			output.Reconcile = &rs
+		}
+	}
+
 	if progress != nil {
 		snap := progress.Snapshot()
 		output.Indexing = &IndexingProgress{
@@ -681,6 +751,14 @@ func (s *Server) mcpSearchHandler(ctx context.Context, req *mcp.CallToolRequest,
 	if err != nil {
 		return nil, SearchOutput{}, MapError(err)
 	}
+	sortBy, err := search.ParseSortBy(input.SortBy)
+	if err != nil {
+		return nil, SearchOutput{}, NewInvalidParamsError(err.Error())
+	}
+	includeTests, err := search.ParseTestFileMode(input.IncludeTests)
+	if err != nil {
+		return nil, SearchOutput{}, NewInvalidParamsError(err.Error())
+	}
 	var profileMismatches []search.ProfileMismatch
 	var queryClassification search.QueryClassification
 	var rerankerStatus search.RerankerStatus
@@ -696,6 +774,9 @@ func (s *Server) mcpSearchHandler(ctx context.Context, req *mcp.CallToolRequest,
 		QueryClassification: &queryClassification,
 		RerankerStatus:      &rerankerStatus,
 		Explain:             input.Explain,
+		SortBy:              sortBy,
+		IncludeTests:        includeTests,
+		BoostPathMatch:      input.BoostPathMatch,
 	}
 	if input.Limit > 0 {
 		opts.Limit = input.Limit
@@ -728,6 +809,14 @@ func (s *Server) mcpSearchCodeHandler(ctx context.Context, _ *mcp.CallToolReques
 	if profile == "" {
 		profile = search.ProfileCode
 	}
+	sortBy, err := search.ParseSortBy(input.SortBy)
+	if err != nil {
+		return nil, SearchOutput{}, NewInvalidParamsError(err.Error())
+	}
+	includeTests, err := search.ParseTestFileMode(input.IncludeTests)
+	if err != nil {
+		return nil, SearchOutput{}, NewInvalidParamsError(err.Error())
+	}
 	var profileMismatches []search.ProfileMismatch
 	var queryClassification search.QueryClassification
 	var rerankerStatus search.RerankerStatus
@@ -743,6 +832,9 @@ func (s *Server) mcpSearchCodeHandler(ctx context.Context, _ *mcp.CallToolReques
 		QueryClassification: &queryClassification,
 		RerankerStatus:      &rerankerStatus,
 		Explain:             input.Explain,
+		SortBy:              sortBy,
+		IncludeTests:        includeTests,
+		BoostPathMatch:      input.BoostPathMatch,
 	}
 	if input.Limit > 0 {
 		opts.Limit = input.Limit
@@ -782,6 +874,10 @@ func (s *Server) mcpSearchDocsHandler(ctx context.Context, _ *mcp.CallToolReques
 	if err != nil {
 		return nil, SearchOutput{}, NewInvalidParamsError(err.Error())
 	}
+	sortBy, err := search.ParseSortBy(input.SortBy)
+	if err != nil {
+		return nil, SearchOutput{}, NewInvalidParamsError(err.Error())
+	}
 	var profileMismatches []search.ProfileMismatch
 	var queryClassification search.QueryClassification
 	var rerankerStatus search.RerankerStatus
@@ -797,6 +893,7 @@ func (s *Server) mcpSearchDocsHandler(ctx context.Context, _ *mcp.CallToolReques
 		QueryClassification: &queryClassification,
 		RerankerStatus:      &rerankerStatus,
 		Explain:             input.Explain,
+		SortBy:              sortBy,
 	}
 	if input.Limit > 0 {
 		opts.Limit = input.Limit
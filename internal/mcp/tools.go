@@ -2,13 +2,16 @@ package mcp
 
 // SearchCodeInput defines the input schema for the search_code tool.
 type SearchCodeInput struct {
-	Query      string   `json:"query" jsonschema:"the code search query to execute"`
-	Language   string   `json:"language,omitempty" jsonschema:"filter by programming language (go, typescript, python)"`
-	SymbolType string   `json:"symbol_type,omitempty" jsonschema:"filter by symbol type: function, class, interface, type, method, or any"`
-	Limit      int      `json:"limit,omitempty" jsonschema:"maximum number of results, default 10"`
-	Scope      []string `json:"scope,omitempty" jsonschema:"filter by path prefixes (OR logic)"`
-	Profile    string   `json:"profile,omitempty" jsonschema:"retrieval profile: code, project-memory, review-corpus, archive"`
-	Explain    bool     `json:"explain,omitempty" jsonschema:"include verbose search explainability metadata"`
+	Query          string   `json:"query" jsonschema:"the code search query to execute"`
+	Language       string   `json:"language,omitempty" jsonschema:"filter by programming language (go, typescript, python)"`
+	SymbolType     string   `json:"symbol_type,omitempty" jsonschema:"filter by symbol type: function, class, interface, type, method, or any"`
+	Limit          int      `json:"limit,omitempty" jsonschema:"maximum number of results, default 10"`
+	Scope          []string `json:"scope,omitempty" jsonschema:"filter by path prefixes (OR logic)"`
+	Profile        string   `json:"profile,omitempty" jsonschema:"retrieval profile: code, project-memory, review-corpus, archive"`
+	Explain        bool     `json:"explain,omitempty" jsonschema:"include verbose search explainability metadata"`
+	SortBy         string   `json:"sort_by,omitempty" jsonschema:"result order: score (default), path, recency, or line"`
+	IncludeTests   string   `json:"include_tests,omitempty" jsonschema:"test file results: demote (default), exclude, or only"`
+	BoostPathMatch bool     `json:"boost_path_match,omitempty" jsonschema:"rank results from files whose path matches the query higher, e.g. for a query naming a file"`
 }
 
 // SearchDocsInput defines the input schema for the search_docs tool.
@@ -19,6 +22,7 @@ type SearchDocsInput struct {
 	Profile string   `json:"profile,omitempty" jsonschema:"retrieval profile: code, project-memory, review-corpus, archive"`
 	Mode    string   `json:"mode,omitempty" jsonschema:"docs search mode: decisions for current ADRs, decision-history for current plus superseded ADRs"`
 	Explain bool     `json:"explain,omitempty" jsonschema:"include verbose search explainability metadata"`
+	SortBy  string   `json:"sort_by,omitempty" jsonschema:"result order: score (default), path, recency, or line"`
 }
 
 // IndexStatusInput defines the input schema for the index_status tool (no parameters).
@@ -29,7 +33,18 @@ type IndexStatusOutput struct {
 	Project    ProjectInfo       `json:"project"`
 	Stats      IndexStats        `json:"stats"`
 	Embeddings EmbeddingInfo     `json:"embeddings"`
-	Indexing   *IndexingProgress `json:"indexing,omitempty"` // Present during background indexing
+	Indexing   *IndexingProgress `json:"indexing,omitempty"`  // Present during background indexing
+	Reconcile  *ReconcileStatus  `json:"reconcile,omitempty"` // Present once reconciliation found changes
+}
+
+// ReconcileStatus reports the latest file reconciliation. Changes under
+// paths.priority are applied first, then the rest in the background.
+type ReconcileStatus struct {
+	PriorityTotal    int  `json:"priority_total"`    // Changes under paths.priority
+	PriorityDone     int  `json:"priority_done"`     // Priority changes applied so far
+	BackgroundTotal  int  `json:"background_total"`  // Other changes
+	BackgroundDone   int  `json:"background_done"`   // Other changes applied so far
+	PriorityComplete bool `json:"priority_complete"` // Search over priority paths is up to date
 }
 
 // IndexingProgress contains information about ongoing background indexing.
@@ -203,6 +203,47 @@ func TestSearchDocsTool_RejectsUnknownMode(t *testing.T) {
 	assert.Contains(t, err.Error(), "unknown search mode")
 }
 
+func TestSearchTools_SortBySetsResultOrder(t *testing.T) {
+	for _, tool := range []string{"search", "search_code", "search_docs"} {
+		t.Run(tool, func(t *testing.T) {
+			var capturedOpts search.SearchOptions
+			engine := &MockSearchEngine{
+				SearchFn: func(ctx context.Context, query string, opts search.SearchOptions) ([]*search.SearchResult, error) {
+					capturedOpts = opts
+					return []*search.SearchResult{}, nil
+				},
+			}
+			srv := newTestServerWithEngine(t, engine)
+
+			_, err := srv.CallTool(context.Background(), tool, map[string]any{
+				"query":   "handlers",
+				"sort_by": "path",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, search.SortByPath, capturedOpts.SortBy)
+		})
+	}
+}
+
+func TestSearchTool_RejectsUnknownSortBy(t *testing.T) {
+	engine := &MockSearchEngine{
+		SearchFn: func(ctx context.Context, query string, opts search.SearchOptions) ([]*search.SearchResult, error) {
+			t.Fatal("search must not execute for an invalid sort order")
+			return nil, nil
+		},
+	}
+	srv := newTestServerWithEngine(t, engine)
+
+	_, err := srv.CallTool(context.Background(), "search", map[string]any{
+		"query":   "handlers",
+		"sort_by": "size",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown sort order")
+}
+
 // ============================================================================
 // TS04: Search Code with Symbol Type
 // ============================================================================
@@ -430,6 +471,28 @@ func TestIndexStatusTool_NilEmbedder_Unavailable(t *testing.T) {
 	assert.Equal(t, "unavailable", output.Embeddings.Status)
 }
 
+func TestIndexStatusTool_ReportsReconcileProgress(t *testing.T) {
+	// Given: a server whose reconciliation has applied all priority changes
+	srv := newTestServerWithEngine(t, &MockSearchEngine{})
+	progress := ReconcileStatus{}
+	srv.SetReconcileProgress(func() ReconcileStatus { return progress })
+
+	// When: calling index_status before and after reconciliation finds changes
+	result, err := srv.CallTool(context.Background(), "index_status", map[string]any{})
+	require.NoError(t, err)
+	idle := result.(*IndexStatusOutput)
+
+	progress = ReconcileStatus{PriorityTotal: 2, PriorityDone: 2, BackgroundTotal: 10, BackgroundDone: 3, PriorityComplete: true}
+	result, err = srv.CallTool(context.Background(), "index_status", map[string]any{})
+	require.NoError(t, err)
+	busy := result.(*IndexStatusOutput)
+
+	// Then: progress is reported only once there are changes
+	assert.Nil(t, idle.Reconcile)
+	require.NotNil(t, busy.Reconcile)
+	assert.Equal(t, progress, *busy.Reconcile)
+}
+
 // ============================================================================
 // TS07: Empty Results Handling
 // ============================================================================
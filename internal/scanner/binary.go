@@ -0,0 +1,176 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// BinarySniffLen is the number of leading bytes inspected to classify a
+// file as binary.
+const BinarySniffLen = 512
+
+// BinaryDetection configures how files are classified as binary. The zero
+// value treats a file as binary when its first BinarySniffLen bytes contain
+// a null byte.
+type BinaryDetection struct {
+	// MaxNonPrintableRatio switches to ratio-based detection: a file is
+	// binary when more than this fraction of the sampled bytes are control
+	// bytes other than whitespace. 0 keeps the null-byte check.
+	MaxNonPrintableRatio float64
+
+	// DecodeUTF16 indexes UTF-16 text as UTF-8 (see DecodeText) instead of
+	// rejecting it for the null bytes it contains.
+	DecodeUTF16 bool
+
+	// InvalidUTF8 selects how text that is not valid UTF-8 is made valid
+	// before indexing (see SanitizeUTF8). Empty means InvalidUTF8Replace.
+	InvalidUTF8 InvalidUTF8Mode
+
+	// DataFiles configures skipping of large machine-generated data files
+	// (see IsDataFile).
+	DataFiles DataFileDetection
+}
+
+// InvalidUTF8Mode selects how SanitizeUTF8 handles text that is not valid
+// UTF-8.
+type InvalidUTF8Mode string
+
+const (
+	// InvalidUTF8Replace replaces each invalid byte sequence with U+FFFD
+	// and keeps valid text as is (the default).
+	InvalidUTF8Replace InvalidUTF8Mode = "replace"
+
+	// InvalidUTF8Latin1 transcodes the whole file from Windows-1252, the
+	// superset of ISO-8859-1 most legacy text files use, since such files
+	// are rarely valid UTF-8 and replacing their accented letters loses text.
+	InvalidUTF8Latin1 InvalidUTF8Mode = "latin1"
+)
+
+// IsBinaryContent reports whether content looks binary under d. Only the
+// first BinarySniffLen bytes are inspected. Content is not decoded; call
+// DecodeText first when d.DecodeUTF16 is set.
+func IsBinaryContent(content []byte, d BinaryDetection) bool {
+	sample := content[:min(len(content), BinarySniffLen)]
+	if d.MaxNonPrintableRatio <= 0 {
+		return bytes.IndexByte(sample, 0) >= 0
+	}
+	if len(sample) == 0 {
+		return false
+	}
+
+	nonPrintable := 0
+	for _, b := range sample {
+		if isNonPrintableByte(b) {
+			nonPrintable++
+		}
+	}
+	return float64(nonPrintable)/float64(len(sample)) > d.MaxNonPrintableRatio
+}
+
+// DecodeText returns content converted to UTF-8 when d.DecodeUTF16 is set
+// and content is UTF-16, recognized by its byte order mark or, without one,
+// by the alternating null bytes of mostly-ASCII text. Other content is
+// returned unchanged.
+func DecodeText(content []byte, d BinaryDetection) []byte {
+	if !d.DecodeUTF16 {
+		return content
+	}
+	order, bomLen := detectUTF16(content)
+	if order == nil {
+		return content
+	}
+
+	units := make([]uint16, (len(content)-bomLen)/2)
+	for i := range units {
+		units[i] = order.Uint16(content[bomLen+2*i:])
+	}
+	runes := utf16.Decode(units)
+	decoded := make([]byte, 0, len(runes))
+	for _, r := range runes {
+		decoded = utf8.AppendRune(decoded, r)
+	}
+	return decoded
+}
+
+// SanitizeUTF8 returns content as valid UTF-8 and reports whether it had to
+// change it. Valid UTF-8 is returned unchanged; otherwise d.InvalidUTF8
+// decides between replacing invalid bytes and transcoding from Latin-1.
+// Downstream code relies on valid UTF-8: highlight byte offsets must fall on
+// rune boundaries, and JSON output would silently replace invalid bytes.
+// Call it after DecodeText and the binary check.
+func SanitizeUTF8(content []byte, d BinaryDetection) ([]byte, bool) {
+	if utf8.Valid(content) {
+		return content, false
+	}
+	if d.InvalidUTF8 == InvalidUTF8Latin1 {
+		decoded := make([]byte, 0, len(content)+len(content)/4)
+		for _, b := range content {
+			decoded = utf8.AppendRune(decoded, windows1252Rune(b))
+		}
+		return decoded, true
+	}
+	return bytes.ToValidUTF8(content, []byte(string(utf8.RuneError))), true
+}
+
+// windows1252High maps the bytes 0x80-0x9F, which Windows-1252 assigns to
+// printable characters, to their runes. Undefined bytes map to U+FFFD.
+var windows1252High = [32]rune{
+	'€', utf8.RuneError, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', utf8.RuneError, 'Ž', utf8.RuneError,
+	utf8.RuneError, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', utf8.RuneError, 'ž', 'Ÿ',
+}
+
+// windows1252Rune decodes a single Windows-1252 byte.
+func windows1252Rune(b byte) rune {
+	if b >= 0x80 && b < 0xA0 {
+		return windows1252High[b-0x80]
+	}
+	return rune(b) // ASCII and ISO-8859-1 share their code points with Unicode
+}
+
+// detectUTF16 returns the byte order of UTF-16 content and the length of
+// its byte order mark, or a nil order if content does not look like UTF-16.
+func detectUTF16(content []byte) (binary.ByteOrder, int) {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}):
+		return binary.LittleEndian, 2
+	case bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		return binary.BigEndian, 2
+	}
+
+	// Without a BOM, ASCII text in UTF-16 has a null in every other byte
+	// and none in between. Require most units to follow that pattern.
+	sample := content[:min(len(content), BinarySniffLen)&^1]
+	if len(sample) < 4 {
+		return nil, 0
+	}
+	var evenNulls, oddNulls int
+	for i := 0; i < len(sample); i += 2 {
+		if sample[i] == 0 {
+			evenNulls++
+		}
+		if sample[i+1] == 0 {
+			oddNulls++
+		}
+	}
+	units := len(sample) / 2
+	switch {
+	case evenNulls == 0 && oddNulls*2 >= units:
+		return binary.LittleEndian, 0
+	case oddNulls == 0 && evenNulls*2 >= units:
+		return binary.BigEndian, 0
+	}
+	return nil, 0
+}
+
+// isNonPrintableByte reports whether b is a control byte other than the
+// whitespace and form feeds found in text files. Bytes of multi-byte UTF-8
+// sequences count as printable.
+func isNonPrintableByte(b byte) bool {
+	switch b {
+	case '\t', '\n', '\r', '\f', '\v':
+		return false
+	}
+	return b < 0x20 || b == 0x7F
+}
@@ -0,0 +1,133 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeUTF16 encodes s as UTF-16 in the given byte order, with an optional BOM.
+func encodeUTF16(s string, bigEndian, bom bool) []byte {
+	var out []byte
+	put := func(u uint16) {
+		if bigEndian {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+	if bom {
+		put(0xFEFF)
+	}
+	for _, r := range s {
+		put(uint16(r))
+	}
+	return out
+}
+
+func TestIsBinaryContent(t *testing.T) {
+	ratio := BinaryDetection{MaxNonPrintableRatio: 0.3}
+	tests := []struct {
+		name    string
+		content []byte
+		d       BinaryDetection
+		want    bool
+	}{
+		{name: "text", content: []byte("package main\n\tfunc main() {}\r\n"), want: false},
+		{name: "empty", content: nil, want: false},
+		{name: "single null", content: []byte("text\x00text"), want: true},
+		{name: "null past sniff window", content: append([]byte(strings.Repeat("a", BinarySniffLen)), 0), want: false},
+		{name: "ratio tolerates a stray null", content: []byte("some text with one\x00 null byte"), d: ratio, want: false},
+		{name: "ratio flags control bytes", content: []byte("\x00\x01\x02\x03\x04ab\x05\x06\x07"), d: ratio, want: true},
+		{name: "ratio counts UTF-8 as printable", content: []byte("héllo wörld ✓"), d: ratio, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsBinaryContent(tt.content, tt.d))
+		})
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	const text = "func Größe() {}\n"
+	utf16 := BinaryDetection{DecodeUTF16: true}
+	tests := []struct {
+		name    string
+		content []byte
+		d       BinaryDetection
+		want    string
+	}{
+		{name: "little endian BOM", content: encodeUTF16(text, false, true), d: utf16, want: text},
+		{name: "big endian BOM", content: encodeUTF16(text, true, true), d: utf16, want: text},
+		{name: "little endian without BOM", content: encodeUTF16(text, false, false), d: utf16, want: text},
+		{name: "big endian without BOM", content: encodeUTF16(text, true, false), d: utf16, want: text},
+		{name: "UTF-8 unchanged", content: []byte(text), d: utf16, want: text},
+		{name: "disabled", content: encodeUTF16("ab", false, true), want: "\xff\xfea\x00b\x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DecodeText(tt.content, tt.d)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestSanitizeUTF8(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		d           BinaryDetection
+		want        string
+		wantChanged bool
+	}{
+		{name: "valid UTF-8 unchanged", content: "Größe €", want: "Größe €"},
+		{name: "invalid bytes replaced", content: "a\xffb\xc3", want: "a\uFFFDb\uFFFD", wantChanged: true},
+		{
+			name:        "latin1 transcoded",
+			content:     "Gr\xf6\xdfe \x80 \x93ok\x94",
+			d:           BinaryDetection{InvalidUTF8: InvalidUTF8Latin1},
+			want:        "Größe € “ok”",
+			wantChanged: true,
+		},
+		{name: "latin1 leaves valid UTF-8 alone", content: "Größe", d: BinaryDetection{InvalidUTF8: InvalidUTF8Latin1}, want: "Größe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := SanitizeUTF8([]byte(tt.content), tt.d)
+			assert.Equal(t, tt.want, string(got))
+			assert.Equal(t, tt.wantChanged, changed)
+		})
+	}
+}
+
+func TestScanner_Scan_UTF16Files(t *testing.T) {
+	// Given: a UTF-16 source file next to a UTF-8 one
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "legacy.cs"), encodeUTF16("class Legacy {}\n", false, true), 0o644))
+	s, err := New()
+	require.NoError(t, err)
+
+	scan := func(d BinaryDetection) []string {
+		results, err := s.Scan(context.Background(), &ScanOptions{RootDir: tmpDir, Binary: d})
+		require.NoError(t, err)
+		var paths []string
+		for r := range results {
+			require.NoError(t, r.Error)
+			paths = append(paths, r.File.Path)
+		}
+		return paths
+	}
+
+	// When/Then: the default check skips it, UTF-16 decoding keeps it
+	assert.ElementsMatch(t, []string{"main.go"}, scan(BinaryDetection{}))
+	assert.ElementsMatch(t, []string{"main.go", "legacy.cs"}, scan(BinaryDetection{DecodeUTF16: true}))
+}
@@ -0,0 +1,179 @@
+package scanner
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Defaults for DataFileDetection.
+const (
+	// DefaultDataFileMinSize is the size from which files are checked for
+	// being machine-generated data (256KB).
+	DefaultDataFileMinSize = 256 * 1024
+
+	// DefaultDataFileMaxLineLength is the average line length in bytes
+	// above which a sample is treated as data.
+	DefaultDataFileMaxLineLength = 200
+)
+
+const (
+	// dataFileSniffLen is the number of leading bytes sampled.
+	dataFileSniffLen = 16 * 1024
+
+	// dataFileMinWhitespaceRatio is the fraction of whitespace bytes below
+	// which a sample is dense (minified JSON, base64 blobs).
+	dataFileMinWhitespaceRatio = 0.05
+
+	// dataFileMinUniformLines is the number of lines a sample needs before
+	// uniform indentation counts as evidence of data (CSV, NDJSON, dumps).
+	dataFileMinUniformLines = 50
+
+	// dataFileMinDelimitedRatio is the fraction of non-blank lines that must
+	// share one nonzero count of a field delimiter for a sample to be
+	// delimited.
+	dataFileMinDelimitedRatio = 0.9
+)
+
+// dataFileDelimiters are the field delimiters checked for delimited rows.
+var dataFileDelimiters = []byte{',', '\t', ';', '|'}
+
+// DataFileDetection configures how large machine-generated data files
+// (minified JSON, CSV exports, fixtures, lockfile-like dumps) are recognized
+// and skipped. Only config and text files are checked; code, markdown,
+// notebooks, and PDFs are never treated as data. The zero value enables
+// detection with the defaults.
+type DataFileDetection struct {
+	// Disabled indexes data files like any other file.
+	Disabled bool
+
+	// MinSize is the size in bytes from which files are checked; smaller
+	// files are always indexed. 0 means DefaultDataFileMinSize.
+	MinSize int64
+
+	// MaxLineLength is the average line length in bytes above which a dense
+	// file is data. 0 means DefaultDataFileMaxLineLength.
+	MaxLineLength int
+
+	// Allow lists patterns (same syntax as ScanOptions.IncludePatterns) of
+	// files that are never treated as data, e.g. a large fixture the
+	// project wants searchable.
+	Allow []string
+}
+
+// IsDataFile reports whether the file at absPath, of the given size and
+// content type, looks like machine-generated data under d. Only the first
+// 16KB are read (see IsDataContent).
+func IsDataFile(absPath, relPath string, contentType ContentType, size int64, d DataFileDetection) bool {
+	if d.Disabled || (contentType != ContentTypeConfig && contentType != ContentTypeText) {
+		return false
+	}
+	minSize := d.MinSize
+	if minSize <= 0 {
+		minSize = DefaultDataFileMinSize
+	}
+	if size < minSize {
+		return false
+	}
+	baseName := filepath.Base(relPath)
+	for _, pattern := range d.Allow {
+		if matchFilePattern(baseName, relPath, pattern) {
+			return false
+		}
+	}
+
+	f, err := os.Open(absPath)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, dataFileSniffLen)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return false
+	}
+	return IsDataContent(buf[:n], n < int(size), d.MaxLineLength)
+}
+
+// IsDataContent reports whether sample looks like machine-generated data
+// rather than text written by people. No single signal is enough, since
+// prose, changelogs and SQL also have long or unindented lines; a sample is
+// data when less than 5% of it is whitespace and either its average line is
+// longer than maxLineLength (0 means DefaultDataFileMaxLineLength) or all of
+// its many lines share one indentation width, or when its many lines share
+// one indentation width and one count of a field delimiter (CSV rows).
+// truncated says sample is the start of a longer file, so its last,
+// possibly partial, line is ignored.
+func IsDataContent(sample []byte, truncated bool, maxLineLength int) bool {
+	if maxLineLength <= 0 {
+		maxLineLength = DefaultDataFileMaxLineLength
+	}
+	if truncated {
+		if nl := bytes.LastIndexByte(sample, '\n'); nl >= 0 {
+			sample = sample[:nl+1]
+		}
+	}
+	if len(sample) == 0 {
+		return false
+	}
+
+	lines := bytes.Count(sample, []byte{'\n'})
+	if sample[len(sample)-1] != '\n' {
+		lines++
+	}
+	longLines := len(sample)/lines > maxLineLength
+
+	whitespace := 0
+	for _, b := range sample {
+		if b == ' ' || b == '\t' || b == '\n' || b == '\r' {
+			whitespace++
+		}
+	}
+	dense := float64(whitespace)/float64(len(sample)) < dataFileMinWhitespaceRatio
+
+	uniform := lines >= dataFileMinUniformLines && indentationWidths(sample) <= 1
+
+	return (dense && (longLines || uniform)) || (uniform && delimitedRows(sample))
+}
+
+// delimitedRows reports whether most non-blank lines of sample contain the
+// same nonzero number of one of dataFileDelimiters. A delimiter ending the
+// line is not counted, so statement terminators such as SQL's ';' are not
+// taken for fields.
+func delimitedRows(sample []byte) bool {
+	for _, delim := range dataFileDelimiters {
+		counts := make(map[int]int)
+		total := 0
+		for line := range bytes.Lines(sample) {
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+			line = bytes.TrimSuffix(line, []byte{delim})
+			counts[bytes.Count(line, []byte{delim})]++
+			total++
+		}
+		for n, lines := range counts {
+			if n > 0 && float64(lines) >= dataFileMinDelimitedRatio*float64(total) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// indentationWidths returns the number of distinct leading-whitespace
+// widths among the non-blank lines of sample.
+func indentationWidths(sample []byte) int {
+	widths := make(map[int]struct{})
+	for line := range bytes.Lines(sample) {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if len(bytes.TrimSpace(trimmed)) == 0 {
+			continue
+		}
+		widths[len(line)-len(trimmed)] = struct{}{}
+	}
+	return len(widths)
+}
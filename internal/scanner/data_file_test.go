@@ -0,0 +1,187 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// yamlSample returns a nested YAML document of roughly size bytes.
+func yamlSample(size int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < size; i++ {
+		fmt.Fprintf(&b, "service%d:\n  image: app:%d\n  ports:\n    - \"80%02d:80\"\n", i, i, i%100)
+	}
+	return b.String()
+}
+
+// csvSample returns a CSV export of roughly size bytes.
+func csvSample(size int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < size; i++ {
+		fmt.Fprintf(&b, "%d,user%d@example.com,2024-01-%02d,%d.50\n", i, i, i%28+1, i*7)
+	}
+	return b.String()
+}
+
+// changelogSample returns unindented changelog entries of roughly size bytes.
+func changelogSample(size int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < size; i++ {
+		fmt.Fprintf(&b, "- Fixed issue #%d where the watcher%s missed renames\n", i, strings.Repeat(", again,", i%3))
+	}
+	return b.String()
+}
+
+// sqlSample returns unindented SQL statements of roughly size bytes.
+func sqlSample(size int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < size; i++ {
+		fmt.Fprintf(&b, "ALTER TABLE t%d ADD COLUMN c%d TEXT;\n", i, i)
+		fmt.Fprintf(&b, "UPDATE t%d SET c%d = 'x', n = n + 1 WHERE id > %d;\n", i, i, i)
+	}
+	return b.String()
+}
+
+func TestIsDataContent(t *testing.T) {
+	tests := []struct {
+		name      string
+		sample    string
+		truncated bool
+		want      bool
+	}{
+		{
+			name:   "minified JSON on one line",
+			sample: strings.Repeat(`{"id":1,"name":"a","tags":["x","y"]},`, 100),
+			want:   true,
+		},
+		{
+			name:   "long lines with whitespace",
+			sample: strings.Repeat(strings.Repeat("word ", 60)+"\n", 20),
+			want:   false,
+		},
+		{
+			name:   "unindented changelog lines",
+			sample: changelogSample(4096),
+			want:   false,
+		},
+		{
+			name:   "SQL statements without indentation",
+			sample: sqlSample(4096),
+			want:   false,
+		},
+		{
+			name:   "base64 blob wrapped at 76 columns",
+			sample: strings.Repeat(strings.Repeat("QUJD", 19)+"\n", 60),
+			want:   true,
+		},
+		{
+			name:   "CSV rows without indentation",
+			sample: csvSample(4096),
+			want:   true,
+		},
+		{
+			name:   "nested YAML",
+			sample: yamlSample(4096),
+			want:   false,
+		},
+		{
+			name:   "few flat lines",
+			sample: "a: 1\nb: 2\nc: 3\n",
+			want:   false,
+		},
+		{
+			name:      "partial last line of a truncated sample is ignored",
+			sample:    yamlSample(1024) + strings.Repeat("x", 4096),
+			truncated: true,
+			want:      false,
+		},
+		{
+			name:   "empty",
+			sample: "",
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: classifying the sample with the default line length
+			got := IsDataContent([]byte(tt.sample), tt.truncated, 0)
+
+			// Then: only machine-generated data is reported
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsDataContent_MaxLineLength(t *testing.T) {
+	// Given: dense lines averaging about 100 bytes with varied indentation
+	sample := strings.Repeat("  "+strings.Repeat("a", 150)+"\n    "+strings.Repeat("b", 50)+"\n", 10)
+
+	// Then: a tighter limit classifies it as data, the default does not
+	assert.False(t, IsDataContent([]byte(sample), false, 0))
+	assert.True(t, IsDataContent([]byte(sample), false, 40))
+}
+
+func TestScanner_Scan_SkipsDataFiles(t *testing.T) {
+	// Given: large data files next to a large hand-written config and code
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"data/users.csv":        csvSample(DefaultDataFileMinSize + 1024),
+		"data/dump.json":        strings.Repeat(`{"id":1,"v":"abc"},`, DefaultDataFileMinSize/18),
+		"testdata/golden.json":  strings.Repeat(`{"id":1,"v":"abc"},`, DefaultDataFileMinSize/18),
+		"deploy/compose.yaml":   yamlSample(DefaultDataFileMinSize + 1024),
+		"small.csv":             csvSample(4096),
+		"internal/generated.go": "package internal\n\nvar Table = []int{" + strings.Repeat("1,", DefaultDataFileMinSize) + "}\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+
+	s, err := New()
+	require.NoError(t, err)
+	scan := func(d DataFileDetection) ([]string, *ScanStats) {
+		stats := &ScanStats{}
+		results, err := s.Scan(context.Background(), &ScanOptions{
+			RootDir: tmpDir,
+			Binary:  BinaryDetection{DataFiles: d},
+			Stats:   stats,
+		})
+		require.NoError(t, err)
+		var paths []string
+		for result := range results {
+			require.NoError(t, result.Error)
+			paths = append(paths, filepath.ToSlash(result.File.Path))
+		}
+		slices.Sort(paths)
+		return paths, stats
+	}
+
+	// When: scanning with default detection and an allowlisted fixture
+	paths, stats := scan(DataFileDetection{Allow: []string{"testdata/**"}})
+
+	// Then: the data files are skipped and counted; code is never checked
+	assert.Equal(t, []string{
+		"deploy/compose.yaml",
+		"internal/generated.go",
+		"small.csv",
+		"testdata/golden.json",
+	}, paths)
+	assert.Equal(t, map[SkipReason]int{SkipReasonDataFile: 2}, stats.Skipped())
+
+	// When: detection is disabled
+	paths, stats = scan(DataFileDetection{Disabled: true})
+
+	// Then: every file is scanned and nothing is recorded as skipped
+	assert.Len(t, paths, len(files))
+	assert.Empty(t, stats.Skipped())
+}
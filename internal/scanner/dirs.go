@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// ScanDirs streams the absolute paths of the directories Scan would descend
+// into, starting with the root itself. It applies the same default and custom
+// exclusions as Scan, including the data directory, and, with
+// RespectGitignore, skips gitignored directories and everything below them.
+// Symlinked directories are not followed.
+//
+// Watchers use it to register exactly the directories whose files get
+// indexed. The channel is closed when the walk completes or ctx is done.
+func (s *Scanner) ScanDirs(ctx context.Context, opts *ScanOptions) (<-chan string, error) {
+	if opts == nil {
+		opts = &ScanOptions{}
+	}
+
+	rootDir := opts.RootDir
+	if rootDir == "" {
+		rootDir = "."
+	}
+
+	absRoot, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	info, err := os.Stat(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat root directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("root path is not a directory: %s", absRoot)
+	}
+
+	opts, err = withExpandedExcludes(opts, absRoot)
+	if err != nil {
+		return nil, err
+	}
+	opts = withDataDirExcluded(opts, absRoot)
+
+	dirs := make(chan string, 64)
+	go func() {
+		defer close(dirs)
+		s.scanDirs(ctx, absRoot, opts, dirs)
+	}()
+
+	return dirs, nil
+}
+
+// scanDirs walks absRoot and sends every directory that is not excluded.
+func (s *Scanner) scanDirs(ctx context.Context, absRoot string, opts *ScanOptions, dirs chan<- string) {
+	err := filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil // Skip entries we can't access and non-directories
+		}
+
+		relPath, err := filepath.Rel(absRoot, path)
+		if err != nil {
+			return nil
+		}
+
+		if relPath != "." {
+			if s.shouldExcludeDir(relPath, opts) {
+				return filepath.SkipDir
+			}
+			if opts.RespectGitignore && s.isGitignored(relPath, absRoot, true) {
+				return filepath.SkipDir
+			}
+		}
+
+		select {
+		case dirs <- path:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	if err != nil && err != context.Canceled {
+		slog.Warn("directory scan failed",
+			slog.String("root", absRoot),
+			slog.String("error", err.Error()))
+	}
+}
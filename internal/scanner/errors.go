@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"syscall"
+)
+
+// ScanErrorKind classifies why a path could not be scanned, so callers can
+// decide whether to skip the path or abort.
+type ScanErrorKind string
+
+const (
+	// ScanErrorPermission means the path exists but cannot be read.
+	ScanErrorPermission ScanErrorKind = "permission"
+
+	// ScanErrorNotFound means the path disappeared during the scan.
+	ScanErrorNotFound ScanErrorKind = "not_found"
+
+	// ScanErrorTooDeep means the path nests too deeply to resolve: too many
+	// levels of symbolic links or a name longer than the OS allows.
+	ScanErrorTooDeep ScanErrorKind = "too_deep"
+
+	// ScanErrorOther covers any other failure.
+	ScanErrorOther ScanErrorKind = "other"
+)
+
+// ScanError is the error carried by a ScanResult. Path is relative to the
+// scanned root, like FileInfo.Path.
+type ScanError struct {
+	Path string
+	Kind ScanErrorKind
+	Err  error
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("failed to read %s: %v", e.Path, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// newScanError wraps err for relPath, classifying it. Errors that already
+// are a *ScanError are returned unchanged.
+func newScanError(relPath string, err error) *ScanError {
+	var scanErr *ScanError
+	if errors.As(err, &scanErr) {
+		return scanErr
+	}
+	return &ScanError{Path: relPath, Kind: classifyScanError(err), Err: err}
+}
+
+func classifyScanError(err error) ScanErrorKind {
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		return ScanErrorPermission
+	case errors.Is(err, fs.ErrNotExist):
+		return ScanErrorNotFound
+	case errors.Is(err, syscall.ELOOP), errors.Is(err, syscall.ENAMETOOLONG):
+		return ScanErrorTooDeep
+	default:
+		return ScanErrorOther
+	}
+}
+
+// sendError reports err for the path at absPath on results. The result's
+// File carries only the path, so callers can log which path failed.
+func sendError(ctx context.Context, results chan<- ScanResult, relPath, absPath string, err error) error {
+	result := ScanResult{
+		File:  &FileInfo{Path: relPath, AbsPath: absPath},
+		Error: newScanError(relPath, err),
+	}
+	select {
+	case results <- result:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// relPathOrAbs returns path relative to base, or path itself when it has no
+// relative form.
+func relPathOrAbs(base, path string) string {
+	if rel, err := filepath.Rel(base, path); err == nil {
+		return rel
+	}
+	return path
+}
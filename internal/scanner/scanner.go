@@ -1,7 +1,6 @@
 package scanner
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io/fs"
@@ -9,11 +8,13 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 
+	"github.com/Aman-CERP/amanmcp/internal/config"
 	"github.com/Aman-CERP/amanmcp/internal/gitignore"
 )
 
@@ -69,6 +70,12 @@ func (s *Scanner) Scan(ctx context.Context, opts *ScanOptions) (<-chan ScanResul
 		return nil, fmt.Errorf("root path is not a directory: %s", absRoot)
 	}
 
+	opts, err = withExpandedExcludes(opts, absRoot)
+	if err != nil {
+		return nil, err
+	}
+	opts = withDataDirExcluded(opts, absRoot)
+
 	// Set defaults
 	maxFileSize := opts.MaxFileSize
 	if maxFileSize <= 0 {
@@ -169,6 +176,12 @@ func (s *Scanner) ScanSubtree(ctx context.Context, opts *ScanOptions, subtreePat
 		return nil, fmt.Errorf("subtree path is not a directory: %s", absSubtree)
 	}
 
+	opts, err = withExpandedExcludes(opts, absRoot)
+	if err != nil {
+		return nil, err
+	}
+	opts = withDataDirExcluded(opts, absRoot)
+
 	// Set defaults
 	maxFileSize := opts.MaxFileSize
 	if maxFileSize <= 0 {
@@ -195,6 +208,7 @@ func (s *Scanner) ScanSubtree(ctx context.Context, opts *ScanOptions, subtreePat
 // scanSubtreeInternal performs directory traversal starting from a subtree.
 // Paths in results are relative to absRoot, not absSubtree.
 func (s *Scanner) scanSubtreeInternal(ctx context.Context, absRoot, absSubtree string, opts *ScanOptions, maxFileSize int64, results chan<- ScanResult) {
+	visits := newSymlinkVisits(absRoot)
 	err := filepath.WalkDir(absSubtree, func(path string, d fs.DirEntry, err error) error {
 		// Check context cancellation
 		select {
@@ -204,7 +218,8 @@ func (s *Scanner) scanSubtreeInternal(ctx context.Context, absRoot, absSubtree s
 		}
 
 		if err != nil {
-			return nil // Skip files we can't access
+			// Report paths we can't access and keep walking
+			return sendError(ctx, results, relPathOrAbs(absRoot, path), path, err)
 		}
 
 		// Get relative path from PROJECT ROOT (not subtree root)
@@ -221,9 +236,10 @@ func (s *Scanner) scanSubtreeInternal(ctx context.Context, absRoot, absSubtree s
 			return nil
 		}
 
-		// Handle symlinks
+		// Handle symlinks: only allowlisted ones are followed unless
+		// FollowSymlinks is set
 		if d.Type()&fs.ModeSymlink != 0 && !opts.FollowSymlinks {
-			return nil
+			return s.followSymlink(ctx, absRoot, relPath, path, opts, maxFileSize, visits, results)
 		}
 
 		// Check if file should be excluded
@@ -243,19 +259,23 @@ func (s *Scanner) scanSubtreeInternal(ctx context.Context, absRoot, absSubtree s
 		}
 
 		// Skip binary files
-		if s.isBinaryFile(path) {
+		if s.isBinaryFile(path, opts.Binary) {
 			return nil
 		}
 
 		// Detect language and content type
-		language := DetectLanguageWithRegistry(relPath, opts.LanguageRegistry)
-		contentType := DetectContentTypeWithRegistry(language, opts.LanguageRegistry)
+		language, contentType := detectFileType(relPath, opts)
 
 		// Check if file matches include patterns
 		if len(opts.IncludePatterns) > 0 && !s.matchesAnyPattern(relPath, opts.IncludePatterns) {
 			return nil
 		}
 
+		// Skip machine-generated data files
+		if s.isDataFile(path, relPath, contentType, info.Size(), opts) {
+			return nil
+		}
+
 		// Check for generated file
 		isGenerated := s.isGeneratedFile(path)
 
@@ -279,15 +299,13 @@ func (s *Scanner) scanSubtreeInternal(ctx context.Context, absRoot, absSubtree s
 	})
 
 	if err != nil && err != context.Canceled {
-		select {
-		case results <- ScanResult{Error: err}:
-		case <-ctx.Done():
-		}
+		_ = sendError(ctx, results, relPathOrAbs(absRoot, absSubtree), absSubtree, err)
 	}
 }
 
 // scan performs the actual directory traversal.
 func (s *Scanner) scan(ctx context.Context, absRoot string, opts *ScanOptions, maxFileSize int64, results chan<- ScanResult) {
+	visits := newSymlinkVisits(absRoot)
 	err := filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
 		// Check context cancellation
 		select {
@@ -297,7 +315,8 @@ func (s *Scanner) scan(ctx context.Context, absRoot string, opts *ScanOptions, m
 		}
 
 		if err != nil {
-			return nil // Skip files we can't access
+			// Report paths we can't access and keep walking
+			return sendError(ctx, results, relPathOrAbs(absRoot, path), path, err)
 		}
 
 		// Get relative path
@@ -319,9 +338,10 @@ func (s *Scanner) scan(ctx context.Context, absRoot string, opts *ScanOptions, m
 			return nil
 		}
 
-		// Handle symlinks
+		// Handle symlinks: only allowlisted ones are followed unless
+		// FollowSymlinks is set
 		if d.Type()&fs.ModeSymlink != 0 && !opts.FollowSymlinks {
-			return nil
+			return s.followSymlink(ctx, absRoot, relPath, path, opts, maxFileSize, visits, results)
 		}
 
 		// Check if file should be excluded
@@ -341,19 +361,23 @@ func (s *Scanner) scan(ctx context.Context, absRoot string, opts *ScanOptions, m
 		}
 
 		// Skip binary files
-		if s.isBinaryFile(path) {
+		if s.isBinaryFile(path, opts.Binary) {
 			return nil
 		}
 
 		// Detect language and content type
-		language := DetectLanguageWithRegistry(relPath, opts.LanguageRegistry)
-		contentType := DetectContentTypeWithRegistry(language, opts.LanguageRegistry)
+		language, contentType := detectFileType(relPath, opts)
 
 		// Check if file matches include patterns
 		if len(opts.IncludePatterns) > 0 && !s.matchesAnyPattern(relPath, opts.IncludePatterns) {
 			return nil
 		}
 
+		// Skip machine-generated data files
+		if s.isDataFile(path, relPath, contentType, info.Size(), opts) {
+			return nil
+		}
+
 		// Check for generated file
 		isGenerated := s.isGeneratedFile(path)
 
@@ -377,10 +401,7 @@ func (s *Scanner) scan(ctx context.Context, absRoot string, opts *ScanOptions, m
 	})
 
 	if err != nil && err != context.Canceled {
-		select {
-		case results <- ScanResult{Error: err}:
-		case <-ctx.Done():
-		}
+		_ = sendError(ctx, results, ".", absRoot, err)
 	}
 }
 
@@ -388,6 +409,9 @@ func (s *Scanner) scan(ctx context.Context, absRoot string, opts *ScanOptions, m
 // Files are indexed with their full path relative to the root (e.g., "libs/utils/file.go").
 func (s *Scanner) scanSubmodule(ctx context.Context, absRoot, submodulePath string, opts *ScanOptions, maxFileSize int64, results chan<- ScanResult) {
 	submoduleAbsPath := filepath.Join(absRoot, submodulePath)
+	if exceedsMaxDepth(submodulePath, opts) {
+		return
+	}
 
 	err := filepath.WalkDir(submoduleAbsPath, func(path string, d fs.DirEntry, walkErr error) error {
 		// Check context cancellation
@@ -398,7 +422,8 @@ func (s *Scanner) scanSubmodule(ctx context.Context, absRoot, submodulePath stri
 		}
 
 		if walkErr != nil {
-			return nil // Skip files we can't access
+			// Report paths we can't access and keep walking
+			return sendError(ctx, results, relPathOrAbs(absRoot, path), path, walkErr)
 		}
 
 		// Get relative path from submodule root
@@ -421,7 +446,7 @@ func (s *Scanner) scanSubmodule(ctx context.Context, absRoot, submodulePath stri
 			if d.Name() == ".git" {
 				return filepath.SkipDir
 			}
-			if s.shouldExcludeDir(relFromSubmodule, opts) {
+			if s.shouldExcludeDir(relFromSubmodule, opts) || exceedsMaxDepth(relPath, opts) {
 				return filepath.SkipDir
 			}
 			return nil
@@ -449,19 +474,23 @@ func (s *Scanner) scanSubmodule(ctx context.Context, absRoot, submodulePath stri
 		}
 
 		// Skip binary files
-		if s.isBinaryFile(path) {
+		if s.isBinaryFile(path, opts.Binary) {
 			return nil
 		}
 
 		// Detect language and content type
-		language := DetectLanguageWithRegistry(relFromSubmodule, opts.LanguageRegistry)
-		contentType := DetectContentTypeWithRegistry(language, opts.LanguageRegistry)
+		language, contentType := detectFileType(relFromSubmodule, opts)
 
 		// Check if file matches include patterns (using submodule-relative path)
 		if len(opts.IncludePatterns) > 0 && !s.matchesAnyPattern(relFromSubmodule, opts.IncludePatterns) {
 			return nil
 		}
 
+		// Skip machine-generated data files
+		if s.isDataFile(path, relPath, contentType, info.Size(), opts) {
+			return nil
+		}
+
 		// Check for generated file
 		isGenerated := s.isGeneratedFile(path)
 
@@ -491,8 +520,74 @@ func (s *Scanner) scanSubmodule(ctx context.Context, absRoot, submodulePath stri
 	}
 }
 
+// withExpandedExcludes returns opts with "@file:" entries in ExcludePatterns
+// expanded relative to absRoot. Config loading already expands includes in
+// .amanmcp.yaml, so this only matters for callers that build ScanOptions by
+// hand. opts itself is never modified.
+func withExpandedExcludes(opts *ScanOptions, absRoot string) (*ScanOptions, error) {
+	patterns, err := config.ExpandPatternIncludes(opts.ExcludePatterns, absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand exclude patterns: %w", err)
+	}
+	if slices.Equal(patterns, opts.ExcludePatterns) {
+		return opts, nil
+	}
+	expanded := *opts
+	expanded.ExcludePatterns = patterns
+	return &expanded, nil
+}
+
+// withDataDirExcluded returns opts with opts.DataDir added to
+// ExcludePatterns when it lies inside absRoot. opts itself is never modified.
+func withDataDirExcluded(opts *ScanOptions, absRoot string) *ScanOptions {
+	rel := RelDataDir(absRoot, opts.DataDir)
+	if rel == "" {
+		return opts
+	}
+	excluded := *opts
+	excluded.ExcludePatterns = append(slices.Clip(opts.ExcludePatterns), rel+"/**")
+	return &excluded
+}
+
+// RelDataDir returns the path of dataDir relative to root, or "" when
+// dataDir is empty, is root itself, or lies outside root.
+func RelDataDir(root, dataDir string) string {
+	if dataDir == "" {
+		return ""
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return ""
+	}
+	absData, err := filepath.Abs(dataDir)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(absRoot, absData)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return rel
+}
+
+// IsHiddenToolPath reports whether relPath lies in one of the hidden cache,
+// dependency or tool state directories skipped unless
+// ScanOptions.IncludeHidden is set, such as .venv or .cache.
+func IsHiddenToolPath(relPath string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if slices.Contains(hiddenToolDirs, part) {
+			return true
+		}
+	}
+	return false
+}
+
 // shouldExcludeDir checks if a directory should be excluded.
 func (s *Scanner) shouldExcludeDir(relPath string, opts *ScanOptions) bool {
+	if !opts.IncludeHidden && IsHiddenToolPath(relPath) {
+		return true
+	}
+
 	// Check default exclusions
 	for _, pattern := range defaultExcludeDirs {
 		if matchDirPattern(relPath, pattern) {
@@ -507,11 +602,34 @@ func (s *Scanner) shouldExcludeDir(relPath string, opts *ScanOptions) bool {
 		}
 	}
 
-	return false
+	// Checked last so excluded directories are not counted as depth-pruned
+	return exceedsMaxDepth(relPath, opts)
+}
+
+// exceedsMaxDepth reports whether the directory at relPath lies more than
+// opts.MaxDepth levels below the root, counting it in opts.Stats as pruned
+// when it does. MaxDepth <= 0 means unlimited.
+func exceedsMaxDepth(relPath string, opts *ScanOptions) bool {
+	if opts.MaxDepth <= 0 || relPath == "." || relPath == "" {
+		return false
+	}
+	depth := strings.Count(filepath.ToSlash(filepath.Clean(relPath)), "/") + 1
+	if depth <= opts.MaxDepth {
+		return false
+	}
+	slog.Debug("scan_depth_limited",
+		slog.String("path", relPath),
+		slog.Int("max_depth", opts.MaxDepth))
+	opts.Stats.recordSkip(SkipReasonMaxDepth)
+	return true
 }
 
 // shouldExcludeFile checks if a file should be excluded.
 func (s *Scanner) shouldExcludeFile(relPath, absRoot string, opts *ScanOptions) bool {
+	if !opts.IncludeHidden && IsHiddenToolPath(relPath) {
+		return true
+	}
+
 	baseName := filepath.Base(relPath)
 
 	// Check sensitive file patterns
@@ -537,7 +655,7 @@ func (s *Scanner) shouldExcludeFile(relPath, absRoot string, opts *ScanOptions)
 
 	// Check gitignore
 	if opts.RespectGitignore {
-		if s.isGitignored(relPath, absRoot) {
+		if s.isGitignored(relPath, absRoot, false) {
 			return true
 		}
 	}
@@ -545,6 +663,18 @@ func (s *Scanner) shouldExcludeFile(relPath, absRoot string, opts *ScanOptions)
 	return false
 }
 
+// MatchesExcludeDir reports whether the directory at relPath (relative to
+// the scan root) matches one of the exclude patterns, as the scanner would
+// skip it.
+func MatchesExcludeDir(relPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matchDirPattern(relPath, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // matchDirPattern checks if a directory path matches a pattern.
 func matchDirPattern(relPath, pattern string) bool {
 	// Handle **/ prefix patterns (e.g., **/node_modules/**)
@@ -664,8 +794,8 @@ func (s *Scanner) matchesAnyPattern(relPath string, patterns []string) bool {
 	return false
 }
 
-// isBinaryFile checks if a file is binary by looking for null bytes.
-func (s *Scanner) isBinaryFile(path string) bool {
+// isBinaryFile checks if a file is binary under d (see IsBinaryContent).
+func (s *Scanner) isBinaryFile(path string, d BinaryDetection) bool {
 	if strings.EqualFold(filepath.Ext(path), ".pdf") {
 		return false
 	}
@@ -676,15 +806,26 @@ func (s *Scanner) isBinaryFile(path string) bool {
 	}
 	defer func() { _ = f.Close() }()
 
-	// Read first 512 bytes
-	buf := make([]byte, 512)
+	buf := make([]byte, BinarySniffLen)
 	n, err := f.Read(buf)
 	if err != nil {
 		return false
 	}
 
-	// Check for null bytes
-	return bytes.Contains(buf[:n], []byte{0})
+	return IsBinaryContent(DecodeText(buf[:n], d), d)
+}
+
+// isDataFile checks if a file is machine-generated data under
+// opts.Binary.DataFiles (see IsDataFile) and records the skip in opts.Stats.
+func (s *Scanner) isDataFile(path, relPath string, contentType ContentType, size int64, opts *ScanOptions) bool {
+	if !IsDataFile(path, relPath, contentType, size, opts.Binary.DataFiles) {
+		return false
+	}
+	slog.Debug("scan_data_file_skipped",
+		slog.String("path", relPath),
+		slog.Int64("size", size))
+	opts.Stats.recordSkip(SkipReasonDataFile)
+	return true
 }
 
 // isGeneratedFile checks if a file is auto-generated.
@@ -724,12 +865,12 @@ func (s *Scanner) isGeneratedFile(path string) bool {
 	return false
 }
 
-// isGitignored checks if a file is ignored by gitignore.
-func (s *Scanner) isGitignored(relPath, absRoot string) bool {
+// isGitignored checks if a file or directory is ignored by gitignore.
+func (s *Scanner) isGitignored(relPath, absRoot string, isDir bool) bool {
 	// Build a composite matcher that includes all relevant .gitignore files
 	// First check root .gitignore
 	rootMatcher := s.getGitignoreMatcher(absRoot, "")
-	if rootMatcher != nil && rootMatcher.Match(relPath, false) {
+	if rootMatcher != nil && rootMatcher.Match(relPath, isDir) {
 		return true
 	}
 
@@ -750,7 +891,7 @@ func (s *Scanner) isGitignored(relPath, absRoot string) bool {
 		}
 
 		matcher := s.getGitignoreMatcher(currentDir, currentBase)
-		if matcher != nil && matcher.Match(relPath, false) {
+		if matcher != nil && matcher.Match(relPath, isDir) {
 			return true
 		}
 	}
@@ -808,6 +949,31 @@ var defaultExcludeDirs = []string{
 	"**/.ssh/**",
 }
 
+// Hidden directories of caches, dependencies and tool state, skipped unless
+// ScanOptions.IncludeHidden is set. Other hidden directories, such as
+// .github, are indexed.
+var hiddenToolDirs = []string{
+	".cache",
+	".venv",
+	".tox",
+	".nox",
+	".mypy_cache",
+	".pytest_cache",
+	".ruff_cache",
+	".gradle",
+	".next",
+	".nuxt",
+	".turbo",
+	".parcel-cache",
+	".terraform",
+	".yarn",
+	".pnpm-store",
+	".npm",
+	".idea",
+	".svn",
+	".hg",
+}
+
 // Default files to exclude.
 var defaultExcludeFiles = []string{
 	"**/*.min.js",
@@ -2,11 +2,14 @@ package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -133,7 +136,7 @@ func TestScanner_IsBinaryFile_AllowsPDFsThroughTextExtractionPath(t *testing.T)
 
 	scanner := &Scanner{}
 
-	assert.False(t, scanner.isBinaryFile(path), "PDFs may contain null bytes but must reach the PDF chunker")
+	assert.False(t, scanner.isBinaryFile(path, BinaryDetection{}), "PDFs may contain null bytes but must reach the PDF chunker")
 }
 
 func TestScanner_Scan_BasicFiles(t *testing.T) {
@@ -199,6 +202,79 @@ func TestScanner_Scan_BasicFiles(t *testing.T) {
 	assert.Equal(t, ContentTypeConfig, config.ContentType)
 }
 
+func TestScanner_Scan_Notebooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "analysis.ipynb"), []byte(`{"cells": []}`), 0o644))
+
+	scan := func(includeNotebooks bool) *FileInfo {
+		s, err := New()
+		require.NoError(t, err)
+		results, err := s.Scan(context.Background(), &ScanOptions{
+			RootDir:          tmpDir,
+			IncludeNotebooks: includeNotebooks,
+		})
+		require.NoError(t, err)
+		var found *FileInfo
+		for result := range results {
+			require.NoError(t, result.Error)
+			found = result.File
+		}
+		require.NotNil(t, found)
+		return found
+	}
+
+	// Notebooks are plain text unless explicitly enabled
+	assert.Equal(t, ContentTypeText, scan(false).ContentType)
+
+	nb := scan(true)
+	assert.Equal(t, ContentTypeNotebook, nb.ContentType)
+	assert.Equal(t, NotebookLanguage, nb.Language)
+}
+
+func TestScanner_Scan_ContentTypeOverrides(t *testing.T) {
+	// Given: a proto file, an rst file, a generated Go file and a plain Go file
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"api.proto":  "syntax = \"proto3\";\n",
+		"guide.rst":  "Guide\n=====\n",
+		"gen.pb.go":  "// Code generated by protoc-gen-go. DO NOT EDIT.\npackage api\n",
+		"main.go":    "package main\n",
+		"schema.sql": "CREATE TABLE t (id INT);\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o644))
+	}
+
+	// When: scanning with overrides, keyed in mixed case
+	s, err := New()
+	require.NoError(t, err)
+	results, err := s.Scan(context.Background(), &ScanOptions{
+		RootDir: tmpDir,
+		ContentTypeOverrides: map[string]ContentType{
+			"Protobuf": ContentTypeConfig,
+			"rst":      ContentTypeText,
+			"go":       ContentTypeText,
+		},
+	})
+	require.NoError(t, err)
+	filesByPath := make(map[string]*FileInfo)
+	for result := range results {
+		require.NoError(t, result.Error)
+		filesByPath[result.File.Path] = result.File
+	}
+
+	// Then: overridden languages are reclassified, others keep the default
+	require.Len(t, filesByPath, len(files))
+	assert.Equal(t, ContentTypeConfig, filesByPath["api.proto"].ContentType)
+	assert.Equal(t, ContentTypeText, filesByPath["guide.rst"].ContentType)
+	assert.Equal(t, ContentTypeText, filesByPath["main.go"].ContentType)
+	assert.Equal(t, DetectContentType("sql"), filesByPath["schema.sql"].ContentType)
+
+	// And: generated-file detection is unaffected by the override
+	assert.Equal(t, ContentTypeText, filesByPath["gen.pb.go"].ContentType)
+	assert.True(t, filesByPath["gen.pb.go"].IsGenerated)
+}
+
 func TestScanner_Scan_ExcludesNodeModules(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -266,6 +342,62 @@ func TestScanner_Scan_ExcludesGitDir(t *testing.T) {
 	assert.Equal(t, "main.go", fileInfos[0].Path)
 }
 
+func TestScanner_Scan_ExcludesDataDir(t *testing.T) {
+	// Given: a data directory with a non-hidden name inside the project
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"main.go":             "package main\n",
+		"index-data/index.db": "SQLite format 3\n",
+		"index-data/run.log":  "indexing started\n",
+		"sub/index-data/a.go": "package sub\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+
+	scanner, err := New()
+	require.NoError(t, err)
+	opts := &ScanOptions{
+		RootDir:       tmpDir,
+		DataDir:       filepath.Join(tmpDir, "index-data"),
+		IncludeHidden: true,
+	}
+
+	// When: scanning the whole tree and the data directory's subtree
+	var paths []string
+	for _, scan := range []func() (<-chan ScanResult, error){
+		func() (<-chan ScanResult, error) { return scanner.Scan(context.Background(), opts) },
+		func() (<-chan ScanResult, error) {
+			return scanner.ScanSubtree(context.Background(), opts, "index-data")
+		},
+	} {
+		results, err := scan()
+		require.NoError(t, err)
+		for result := range results {
+			require.NoError(t, result.Error)
+			paths = append(paths, filepath.ToSlash(result.File.Path))
+		}
+	}
+
+	// Then: only the data directory itself is skipped, not same-named
+	// directories elsewhere, and options are not modified
+	assert.ElementsMatch(t, []string{"main.go", "sub/index-data/a.go"}, paths)
+	assert.Empty(t, opts.ExcludePatterns)
+}
+
+func TestRelDataDir(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "project")
+
+	assert.Equal(t, ".amanmcp", RelDataDir(root, filepath.Join(root, ".amanmcp")))
+	assert.Equal(t, filepath.Join("var", "index"), RelDataDir(root, filepath.Join(root, "var", "index")))
+	assert.Empty(t, RelDataDir(root, ""))
+	assert.Empty(t, RelDataDir(root, root))
+	assert.Empty(t, RelDataDir(root, filepath.Join(filepath.Dir(root), "data")))
+	assert.Empty(t, RelDataDir(root, filepath.Join(filepath.Dir(root), "project-data")))
+}
+
 func TestScanner_Scan_ExcludesVendor(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -560,6 +692,66 @@ func TestScanner_Scan_SkipsSymlinks(t *testing.T) {
 	assert.Len(t, fileInfos, 2)
 }
 
+func TestScanner_Scan_FollowSymlinkPaths(t *testing.T) {
+	// Given: a project with two symlinked directories outside the root
+	tmpDir := t.TempDir()
+	shared := t.TempDir()
+	other := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "util.go"), []byte("package shared\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(other, "other.go"), []byte("package other\n"), 0o644))
+	if err := os.Symlink(shared, filepath.Join(tmpDir, "shared")); err != nil {
+		t.Skip("symlinks not supported on this platform")
+	}
+	require.NoError(t, os.Symlink(other, filepath.Join(tmpDir, "other")))
+
+	// And: loops back into the followed directory and into the project root
+	require.NoError(t, os.Symlink(shared, filepath.Join(shared, "self")))
+	require.NoError(t, os.Symlink(tmpDir, filepath.Join(shared, "project")))
+
+	scan := func(allow ...string) []string {
+		t.Helper()
+		s, err := New()
+		require.NoError(t, err)
+		results, err := s.Scan(context.Background(), &ScanOptions{
+			RootDir:            tmpDir,
+			FollowSymlinkPaths: allow,
+		})
+		require.NoError(t, err)
+
+		var paths []string
+		for result := range results {
+			require.NoError(t, result.Error)
+			paths = append(paths, result.File.Path)
+		}
+		return paths
+	}
+
+	t.Run("by link path", func(t *testing.T) {
+		// When: allowlisting the shared link, including the loop links
+		paths := scan("shared", "shared/*")
+
+		// Then: the linked directory is indexed once under the link path
+		assert.ElementsMatch(t, []string{"main.go", filepath.Join("shared", "util.go")}, paths)
+	})
+
+	t.Run("by target path", func(t *testing.T) {
+		// When: allowlisting the target directory
+		paths := scan(shared)
+
+		// Then: only links resolving into it are followed
+		assert.ElementsMatch(t, []string{"main.go", filepath.Join("shared", "util.go")}, paths)
+	})
+
+	t.Run("empty allowlist", func(t *testing.T) {
+		// When: nothing is allowlisted
+		paths := scan()
+
+		// Then: all symlinks are skipped
+		assert.Equal(t, []string{"main.go"}, paths)
+	})
+}
+
 func TestScanner_Scan_SkipsBinaryFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -656,6 +848,40 @@ func TestScanner_Scan_CustomExcludePatterns(t *testing.T) {
 	assert.Equal(t, "main.go", fileInfos[0].Path)
 }
 
+func TestScanner_Scan_ExcludePatternFileInclude(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"main.go":          "package main\n",
+		"fixtures/data.go": "package fixtures\n",
+		"excludes.txt":     "# shared\n**/fixtures/**\nexcludes.txt\n",
+	}
+
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+
+	scanner, err := New()
+	require.NoError(t, err)
+	opts := &ScanOptions{
+		RootDir:         tmpDir,
+		ExcludePatterns: []string{"@file:excludes.txt"},
+	}
+	results, err := scanner.Scan(context.Background(), opts)
+	require.NoError(t, err)
+
+	var paths []string
+	for result := range results {
+		require.NoError(t, result.Error)
+		paths = append(paths, result.File.Path)
+	}
+
+	assert.Equal(t, []string{"main.go"}, paths)
+	assert.Equal(t, []string{"@file:excludes.txt"}, opts.ExcludePatterns, "caller options are not modified")
+}
+
 func TestScanner_Scan_IncludePatterns(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -965,6 +1191,150 @@ func TestScanner_Scan_ExcludesAmanPMDir(t *testing.T) {
 	assert.Equal(t, "main.go", fileInfos[0].Path)
 }
 
+func TestScanner_Scan_HiddenFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"main.go":              "package main\n",
+		".config/tool/tool.go": "package tool\n",
+		".golangci.yml":        "run:\n  timeout: 5m\n",
+		".git/config":          "[core]\n",
+		".env":                 "SECRET=1\n",
+		".cache/blob.txt":      "cached\n",
+	}
+
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+
+	scan := func(opts *ScanOptions) []string {
+		scanner, err := New()
+		require.NoError(t, err)
+		opts.RootDir = tmpDir
+		results, err := scanner.Scan(context.Background(), opts)
+		require.NoError(t, err)
+
+		var paths []string
+		for result := range results {
+			require.NoError(t, result.Error)
+			paths = append(paths, filepath.ToSlash(result.File.Path))
+		}
+		return paths
+	}
+
+	// Hidden tool directories are skipped by default, other hidden paths
+	// are scanned
+	assert.ElementsMatch(t,
+		[]string{"main.go", ".config/tool/tool.go", ".golangci.yml"},
+		scan(&ScanOptions{}))
+
+	// IncludeHidden scans tool directories too, but .git, sensitive files
+	// and exclude patterns still apply
+	assert.ElementsMatch(t,
+		[]string{"main.go", ".config/tool/tool.go", ".golangci.yml", ".cache/blob.txt"},
+		scan(&ScanOptions{IncludeHidden: true}))
+	assert.ElementsMatch(t,
+		[]string{"main.go", ".config/tool/tool.go", ".golangci.yml"},
+		scan(&ScanOptions{IncludeHidden: true, ExcludePatterns: []string{"**/.cache/**"}}))
+}
+
+func TestScanner_Scan_MaxDepth(t *testing.T) {
+	// Given: a nested tree with a gitignored and an excluded file
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		".gitignore":            "cmd/ignored.go\n",
+		"main.go":               "package main\n",
+		"cmd/root.go":           "package cmd\n",
+		"cmd/ignored.go":        "package cmd\n",
+		"cmd/app/main.go":       "package main\n",
+		"cmd/app/deep/deep.go":  "package deep\n",
+		"internal/skip/skip.go": "package skip\n",
+		"docs/guide.md":         "# Guide\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+
+	scanner, err := New()
+	require.NoError(t, err)
+	collect := func(results <-chan ScanResult) []string {
+		var paths []string
+		for result := range results {
+			require.NoError(t, result.Error)
+			paths = append(paths, filepath.ToSlash(result.File.Path))
+		}
+		return paths
+	}
+	opts := func(maxDepth int) *ScanOptions {
+		return &ScanOptions{
+			RootDir:          tmpDir,
+			MaxDepth:         maxDepth,
+			ExcludePatterns:  []string{"internal/**"},
+			RespectGitignore: true,
+		}
+	}
+
+	// When: scanning with depth limits
+	depthOneOpts := opts(1)
+	depthOneOpts.Stats = &ScanStats{}
+	results, err := scanner.Scan(context.Background(), depthOneOpts)
+	require.NoError(t, err)
+	depthOne := collect(results)
+	results, err = scanner.Scan(context.Background(), opts(0))
+	require.NoError(t, err)
+	unlimited := collect(results)
+	results, err = scanner.ScanSubtree(context.Background(), opts(2), "cmd")
+	require.NoError(t, err)
+	subtree := collect(results)
+
+	// Then: depth counts from the root, and gitignore and excludes still apply
+	assert.ElementsMatch(t, []string{".gitignore", "main.go", "cmd/root.go", "docs/guide.md"}, depthOne)
+	assert.Equal(t, map[SkipReason]int{SkipReasonMaxDepth: 1}, depthOneOpts.Stats.Skipped(),
+		"only cmd/app is pruned; the excluded internal/ is not counted")
+	assert.ElementsMatch(t, []string{
+		".gitignore", "main.go", "cmd/root.go", "cmd/app/main.go", "cmd/app/deep/deep.go", "docs/guide.md",
+	}, unlimited)
+	assert.ElementsMatch(t, []string{"cmd/root.go", "cmd/app/main.go"}, subtree)
+
+	// And: directory discovery stops at the same depth
+	dirs, err := scanner.ScanDirs(context.Background(), opts(1))
+	require.NoError(t, err)
+	var dirPaths []string
+	for dir := range dirs {
+		rel, err := filepath.Rel(tmpDir, dir)
+		require.NoError(t, err)
+		dirPaths = append(dirPaths, filepath.ToSlash(rel))
+	}
+	assert.ElementsMatch(t, []string{".", "cmd", "docs"}, dirPaths)
+}
+
+func TestIsHiddenToolPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"main.go", false},
+		{"internal/scanner/scanner.go", false},
+		{".github/workflows/ci.yml", false},
+		{".config/tool/tool.go", false},
+		{".venv/lib/site.py", true},
+		{"web/.next/server.js", true},
+		{".cache", true},
+		{"src/cache/file.go", false},
+		{".", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsHiddenToolPath(tt.path))
+		})
+	}
+}
+
 // =============================================================================
 // F04: Gitignore Parser Bug Fixes from F03 Validation
 // =============================================================================
@@ -1218,6 +1588,67 @@ func TestScanner_Scan_GitignoreDoubleStarPatterns(t *testing.T) {
 	assert.NotContains(t, paths, "src/logs/debug.log", "**/logs/*.log should exclude src/logs/debug.log")
 }
 
+func TestScanner_ScanDirs_MatchesScanExclusions(t *testing.T) {
+	// Given: a tree with gitignored, nested-gitignored, default and custom excluded dirs
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		".gitignore":             "build/\n",
+		"main.go":                "package main\n",
+		"src/app.go":             "package src\n",
+		"src/.gitignore":         "temp/\n",
+		"src/temp/cache.txt":     "cache\n",
+		"src/deep/nested/x.go":   "package nested\n",
+		"build/out/output.js":    "compiled\n",
+		"node_modules/pkg/i.js":  "module\n",
+		"generated/code/gen.go":  "package code\n",
+		"docs/guide/overview.md": "# Overview\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+	absRoot, err := filepath.Abs(tmpDir)
+	require.NoError(t, err)
+
+	// When: scanning directories
+	scanner, err := New()
+	require.NoError(t, err)
+	dirs, err := scanner.ScanDirs(context.Background(), &ScanOptions{
+		RootDir:          tmpDir,
+		ExcludePatterns:  []string{"generated/**"},
+		RespectGitignore: true,
+	})
+	require.NoError(t, err)
+
+	var paths []string
+	for dir := range dirs {
+		rel, err := filepath.Rel(absRoot, dir)
+		require.NoError(t, err)
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+
+	// Then: the root and indexable directories are streamed, excluded ones are not
+	assert.ElementsMatch(t, []string{
+		".", "src", "src/deep", "src/deep/nested", "docs", "docs/guide",
+	}, paths)
+}
+
+func TestScanner_ScanDirs_RootMustBeDirectory(t *testing.T) {
+	// Given: a regular file as root
+	file := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(file, []byte("package main\n"), 0o644))
+
+	// When: scanning directories
+	scanner, err := New()
+	require.NoError(t, err)
+	_, err = scanner.ScanDirs(context.Background(), &ScanOptions{RootDir: file})
+
+	// Then: an error is returned
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a directory")
+}
+
 // =============================================================================
 // DEBT-003: Scanner Channel Abandonment Edge Cases
 // =============================================================================
@@ -1813,3 +2244,145 @@ func TestScanner_Scan_DirGlobExcludePatterns(t *testing.T) {
 	assert.NotContains(t, paths, "docs/bugs/BUG-001.md", "BUG-0[0-2]*.md should exclude BUG-001.md")
 	assert.NotContains(t, paths, "docs/tech-debt/DEBT-001.md", "DEBT-*.md should exclude DEBT-001.md")
 }
+
+func TestScanner_Scan_ReportsUnreadableDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced for this user")
+	}
+
+	// Given: a project with an unreadable subdirectory
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n"), 0o644))
+	locked := filepath.Join(tmpDir, "locked")
+	require.NoError(t, os.MkdirAll(locked, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(locked, "secret.go"), []byte("package locked\n"), 0o644))
+	require.NoError(t, os.Chmod(locked, 0o000))
+	t.Cleanup(func() { _ = os.Chmod(locked, 0o755) })
+
+	s, err := New()
+	require.NoError(t, err)
+
+	// When: scanning the project
+	results, err := s.Scan(context.Background(), &ScanOptions{RootDir: tmpDir})
+	require.NoError(t, err)
+
+	var files []string
+	var scanErrs []*ScanError
+	for result := range results {
+		if result.Error != nil {
+			var scanErr *ScanError
+			require.ErrorAs(t, result.Error, &scanErr)
+			require.NotNil(t, result.File)
+			assert.Equal(t, scanErr.Path, result.File.Path)
+			scanErrs = append(scanErrs, scanErr)
+			continue
+		}
+		files = append(files, result.File.Path)
+	}
+
+	// Then: the directory is reported as a permission error and the scan continues
+	assert.Equal(t, []string{"main.go"}, files)
+	require.Len(t, scanErrs, 1)
+	assert.Equal(t, "locked", scanErrs[0].Path)
+	assert.Equal(t, ScanErrorPermission, scanErrs[0].Kind)
+	assert.ErrorIs(t, scanErrs[0], fs.ErrPermission)
+	assert.Contains(t, scanErrs[0].Error(), "failed to read locked")
+}
+
+func TestNewScanError_ClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ScanErrorKind
+	}{
+		{&fs.PathError{Op: "open", Path: "x", Err: fs.ErrPermission}, ScanErrorPermission},
+		{&fs.PathError{Op: "lstat", Path: "x", Err: fs.ErrNotExist}, ScanErrorNotFound},
+		{&fs.PathError{Op: "open", Path: "x", Err: syscall.ELOOP}, ScanErrorTooDeep},
+		{&fs.PathError{Op: "open", Path: "x", Err: syscall.ENAMETOOLONG}, ScanErrorTooDeep},
+		{errors.New("disk on fire"), ScanErrorOther},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.want), func(t *testing.T) {
+			// When: wrapping the error for a path
+			scanErr := newScanError("pkg/dir", tt.err)
+
+			// Then: it carries the path, kind and cause
+			assert.Equal(t, "pkg/dir", scanErr.Path)
+			assert.Equal(t, tt.want, scanErr.Kind)
+			assert.ErrorIs(t, scanErr, tt.err)
+		})
+	}
+
+	// Already-wrapped errors keep their original path
+	inner := newScanError("a", fs.ErrNotExist)
+	assert.Same(t, inner, newScanError("b", fmt.Errorf("walk: %w", inner)))
+}
+
+func TestLookupLanguageOverride_LongestMatchWins(t *testing.T) {
+	overrides := map[string]string{
+		".tmpl":    "text",
+		".go.tmpl": "go",
+		"TMPL":     "html",
+	}
+
+	tests := []struct {
+		path     string
+		want     string
+		wantFind bool
+	}{
+		{path: "views/page.go.tmpl", want: "go", wantFind: true},
+		{path: "views/PAGE.GO.TMPL", want: "go", wantFind: true},
+		{path: "views/page.tmpl", want: "text", wantFind: true},
+		{path: "views/page.gotmpl", wantFind: false},
+		{path: "views/page", wantFind: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			// When: looking up the override for the path
+			got, ok := lookupLanguageOverride(overrides, tt.path)
+
+			// Then: the longest matching key wins; equal keys resolve in sorted order
+			assert.Equal(t, tt.wantFind, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScanner_Scan_LanguageOverrides(t *testing.T) {
+	// Given: files with extensions the built-in table does not know, or maps differently
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"page.tmpl":      "{{ define \"page\" }}{{ end }}\n",
+		"settings.JSONC": "{ // comment\n}\n",
+		"main.go":        "package main\n",
+		"notes.txt":      "notes\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o644))
+	}
+
+	// When: scanning with extension overrides, keyed with and without a dot
+	s, err := New()
+	require.NoError(t, err)
+	results, err := s.Scan(context.Background(), &ScanOptions{
+		RootDir: tmpDir,
+		LanguageOverrides: map[string]string{
+			".tmpl": "go",
+			"jsonc": "json",
+		},
+	})
+	require.NoError(t, err)
+	filesByPath := make(map[string]*FileInfo)
+	for result := range results {
+		require.NoError(t, result.Error)
+		filesByPath[result.File.Path] = result.File
+	}
+
+	// Then: overridden files take the language and its content type, others keep theirs
+	require.Len(t, filesByPath, len(files))
+	assert.Equal(t, "go", filesByPath["page.tmpl"].Language)
+	assert.Equal(t, ContentTypeCode, filesByPath["page.tmpl"].ContentType)
+	assert.Equal(t, "json", filesByPath["settings.JSONC"].Language)
+	assert.Equal(t, DetectContentType("json"), filesByPath["settings.JSONC"].ContentType)
+	assert.Equal(t, DetectLanguage("notes.txt"), filesByPath["notes.txt"].Language)
+}
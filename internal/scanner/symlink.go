@@ -0,0 +1,198 @@
+package scanner
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveAllowedSymlink reports whether the symlink at relPath (relative to
+// absRoot) may be followed under the allowlist, and returns its fully
+// resolved target.
+//
+// An allowlist entry matches either the link itself (a root-relative path,
+// optionally a filepath.Match glob) or its target: an absolute or
+// root-relative path that the resolved target equals or lies under. Dangling
+// links never match.
+func ResolveAllowedSymlink(absRoot, relPath string, allow []string) (string, bool) {
+	if len(allow) == 0 {
+		return "", false
+	}
+
+	target, err := filepath.EvalSymlinks(filepath.Join(absRoot, relPath))
+	if err != nil {
+		return "", false
+	}
+
+	for _, entry := range allow {
+		if entry == "" {
+			continue
+		}
+		if matched, err := filepath.Match(filepath.Clean(entry), relPath); err == nil && matched {
+			return target, true
+		}
+
+		allowed := entry
+		if !filepath.IsAbs(allowed) {
+			allowed = filepath.Join(absRoot, allowed)
+		}
+		if resolved, err := filepath.EvalSymlinks(allowed); err == nil {
+			allowed = resolved
+		}
+		if pathWithin(target, filepath.Clean(allowed)) {
+			return target, true
+		}
+	}
+	return "", false
+}
+
+// pathWithin reports whether path equals dir or lies under it.
+func pathWithin(path, dir string) bool {
+	if path == dir {
+		return true
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
+
+// symlinkVisits tracks the real directories a scan has walked, so that
+// followed symlinks cannot loop back into them or index them twice.
+type symlinkVisits struct {
+	dirs []string
+}
+
+func newSymlinkVisits(absRoot string) *symlinkVisits {
+	root := absRoot
+	if resolved, err := filepath.EvalSymlinks(absRoot); err == nil {
+		root = resolved
+	}
+	return &symlinkVisits{dirs: []string{root}}
+}
+
+// claim records dir as walked. It returns false if dir overlaps a directory
+// already walked: following it would either revisit files or, when dir
+// contains a walked directory, reach the same symlink again.
+func (v *symlinkVisits) claim(dir string) bool {
+	for _, seen := range v.dirs {
+		if pathWithin(dir, seen) || pathWithin(seen, dir) {
+			return false
+		}
+	}
+	v.dirs = append(v.dirs, dir)
+	return true
+}
+
+// followSymlink handles a symlink found at relPath during a walk. Only links
+// matched by opts.FollowSymlinkPaths are followed: file targets are reported
+// under the link's path, directory targets are walked with their files
+// reported under relPath.
+func (s *Scanner) followSymlink(ctx context.Context, absRoot, relPath, linkPath string, opts *ScanOptions, maxFileSize int64, visits *symlinkVisits, results chan<- ScanResult) error {
+	target, ok := ResolveAllowedSymlink(absRoot, relPath, opts.FollowSymlinkPaths)
+	if !ok {
+		return nil
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		return s.emitFile(ctx, absRoot, relPath, linkPath, info, opts, maxFileSize, results)
+	}
+
+	if s.shouldExcludeDir(relPath, opts) {
+		return nil
+	}
+	if !visits.claim(target) {
+		slog.Debug("skipping symlink loop",
+			slog.String("path", relPath),
+			slog.String("target", target))
+		return nil
+	}
+
+	return filepath.WalkDir(target, func(path string, d fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		relFromTarget, relErr := filepath.Rel(target, path)
+		if relErr != nil {
+			return nil
+		}
+		logicalPath := filepath.Join(relPath, relFromTarget)
+
+		if err != nil {
+			// Report paths we can't access and keep walking
+			return sendError(ctx, results, logicalPath, path, err)
+		}
+		if relFromTarget == "." {
+			return nil
+		}
+
+		if d.IsDir() {
+			if s.shouldExcludeDir(logicalPath, opts) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			if opts.FollowSymlinks {
+				if info, statErr := os.Stat(path); statErr == nil && !info.IsDir() {
+					return s.emitFile(ctx, absRoot, logicalPath, path, info, opts, maxFileSize, results)
+				}
+				return nil
+			}
+			return s.followSymlink(ctx, absRoot, logicalPath, path, opts, maxFileSize, visits, results)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		return s.emitFile(ctx, absRoot, logicalPath, path, info, opts, maxFileSize, results)
+	})
+}
+
+// emitFile applies the file filters to a file reached through a followed
+// symlink and sends it on results if it passes.
+func (s *Scanner) emitFile(ctx context.Context, absRoot, relPath, path string, info fs.FileInfo, opts *ScanOptions, maxFileSize int64, results chan<- ScanResult) error {
+	if s.shouldExcludeFile(relPath, absRoot, opts) {
+		return nil
+	}
+	if info.Size() > maxFileSize {
+		return nil
+	}
+	if s.isBinaryFile(path, opts.Binary) {
+		return nil
+	}
+
+	language, contentType := detectFileType(relPath, opts)
+	if len(opts.IncludePatterns) > 0 && !s.matchesAnyPattern(relPath, opts.IncludePatterns) {
+		return nil
+	}
+	if s.isDataFile(path, relPath, contentType, info.Size(), opts) {
+		return nil
+	}
+
+	fileInfo := &FileInfo{
+		Path:        relPath,
+		AbsPath:     path,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		ContentType: contentType,
+		Language:    language,
+		IsGenerated: s.isGeneratedFile(path),
+	}
+
+	select {
+	case results <- ScanResult{File: fileInfo}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
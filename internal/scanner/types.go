@@ -4,6 +4,11 @@
 package scanner
 
 import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Aman-CERP/amanmcp/internal/config"
@@ -24,8 +29,14 @@ const (
 	ContentTypeText ContentType = "text"
 	// ContentTypeConfig represents configuration files.
 	ContentTypeConfig ContentType = "config"
+	// ContentTypeNotebook represents Jupyter notebooks (only when
+	// ScanOptions.IncludeNotebooks is set).
+	ContentTypeNotebook ContentType = "notebook"
 )
 
+// NotebookLanguage is the language reported for Jupyter notebooks.
+const NotebookLanguage = "jupyter"
+
 // FileInfo contains metadata about a discovered file.
 type FileInfo struct {
 	Path        string      // Relative path to project root
@@ -54,12 +65,49 @@ type ScanOptions struct {
 	// Workers is the number of concurrent workers (0 = NumCPU).
 	Workers int
 
+	// MaxDepth limits how many directory levels below the root are
+	// descended into (0 = unlimited). With MaxDepth 1, files in the root and
+	// in its direct subdirectories are scanned, e.g. cmd/main.go but not
+	// cmd/app/main.go. Depth counts from the project root, also for
+	// ScanSubtree, submodules, and followed symlinks; deeper directories are
+	// pruned like excluded ones, so their contents are never read.
+	MaxDepth int
+
+	// DataDir is the directory amanmcp keeps its index in. When it lies
+	// inside RootDir it is never scanned, whatever its name and regardless
+	// of IncludeHidden and IncludePatterns, so the index never indexes
+	// itself. Relative paths are resolved against the working directory.
+	DataDir string
+
 	// MaxFileSize is the maximum file size to include in bytes (0 = 10MB default).
 	MaxFileSize int64
 
+	// IncludeHidden also descends into the hidden cache, dependency and tool
+	// state directories skipped by default, such as .venv, .cache and .idea
+	// (see IsHiddenToolPath). Other hidden files and directories, such as
+	// .github, are always scanned. Default: false. Sensitive file patterns,
+	// default exclusions such as .git, ExcludePatterns and .gitignore still
+	// apply.
+	IncludeHidden bool
+
 	// FollowSymlinks enables following symbolic links (default: false).
 	FollowSymlinks bool
 
+	// FollowSymlinkPaths allowlists symlinks to follow while all others are
+	// skipped. Entries match the link's root-relative path (globs allowed) or
+	// its target, as an absolute or root-relative path the target equals or
+	// lies under. Linked directories are walked with their files reported
+	// under the link's path; directories already walked are never re-entered.
+	FollowSymlinkPaths []string
+
+	// Binary configures binary file detection. The zero value skips files
+	// with a null byte near the start.
+	Binary BinaryDetection
+
+	// Stats, when set, collects counts of the files and directories the
+	// scan skipped and why. Read it after the result channel is closed.
+	Stats *ScanStats
+
 	// ProgressFunc is called with progress updates during scanning.
 	ProgressFunc func(scanned, total int)
 
@@ -70,14 +118,81 @@ type ScanOptions struct {
 	// LanguageRegistry resolves language detection and content type.
 	// Nil uses the built-in default registry.
 	LanguageRegistry *language.Registry
+
+	// IncludeNotebooks reports Jupyter notebooks (.ipynb) as
+	// ContentTypeNotebook instead of plain text (default: false).
+	IncludeNotebooks bool
+
+	// ContentTypeOverrides reclassifies languages, keyed by the language
+	// name reported in FileInfo.Language (case-insensitive), e.g.
+	// {"sql": ContentTypeText, "rst": ContentTypeText}. Overrides
+	// take precedence over LanguageRegistry; languages without an override
+	// use the registry's content type. Notebook detection (IncludeNotebooks)
+	// runs first and is not overridable. Generated-file detection is
+	// independent of the content type: overridden files are still reported
+	// with IsGenerated when they carry a generated-code marker.
+	ContentTypeOverrides map[string]ContentType
+
+	// LanguageOverrides maps file extensions to languages, over the
+	// extensions known to LanguageRegistry, e.g. {".tmpl": "go", ".jsonc":
+	// "json"}. Extensions match case-insensitively, with or without the
+	// leading dot, and may have several parts (".go.tmpl"); when several
+	// keys match a file, the longest wins. The overriding language is reported in FileInfo.Language
+	// and drives everything keyed by it: its content type (from the registry,
+	// or ContentTypeOverrides) and the chunker that indexes the file.
+	LanguageOverrides map[string]string
 }
 
 // ScanResult is returned from the scanner channel.
+//
+// Paths that cannot be read are reported with Error set to a *ScanError and
+// File holding only Path and AbsPath. The scan continues past them.
 type ScanResult struct {
 	File  *FileInfo
 	Error error
 }
 
+// SkipReason says why the scanner skipped a file.
+type SkipReason string
+
+const (
+	// SkipReasonDataFile marks large machine-generated data files (see
+	// IsDataFile).
+	SkipReasonDataFile SkipReason = "data_file"
+
+	// SkipReasonMaxDepth marks directories pruned below ScanOptions.MaxDepth.
+	// Each pruned directory counts once, whatever it contains.
+	SkipReasonMaxDepth SkipReason = "max_depth"
+)
+
+// ScanStats counts the files and directories a scan skipped, by reason. It
+// is safe for concurrent use, so one ScanStats can be shared by several
+// scans.
+type ScanStats struct {
+	mu      sync.Mutex
+	skipped map[SkipReason]int
+}
+
+// Skipped returns a copy of the skip counts by reason.
+func (s *ScanStats) Skipped() map[SkipReason]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.skipped)
+}
+
+// recordSkip counts one file or directory skipped for reason. It is a no-op on nil.
+func (s *ScanStats) recordSkip(reason SkipReason) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.skipped == nil {
+		s.skipped = make(map[SkipReason]int)
+	}
+	s.skipped[reason]++
+}
+
 // DefaultMaxFileSize is the default maximum file size (10MB).
 const DefaultMaxFileSize = 10 * 1024 * 1024
 
@@ -94,6 +209,77 @@ func DetectLanguageWithRegistry(path string, registry *language.Registry) string
 	return registry.Detect(path)
 }
 
+// IsNotebookPath reports whether path is a Jupyter notebook (.ipynb).
+func IsNotebookPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".ipynb")
+}
+
+// DetectFileType returns the language and content type Scan reports for the
+// file at relPath under opts' LanguageRegistry, IncludeNotebooks and
+// overrides. Nil opts uses the defaults.
+func DetectFileType(relPath string, opts *ScanOptions) (string, ContentType) {
+	if opts == nil {
+		opts = &ScanOptions{}
+	}
+	return detectFileType(relPath, opts)
+}
+
+// detectFileType detects the language and content type of a scanned file.
+func detectFileType(relPath string, opts *ScanOptions) (string, ContentType) {
+	if opts.IncludeNotebooks && IsNotebookPath(relPath) {
+		return NotebookLanguage, ContentTypeNotebook
+	}
+	language, ok := lookupLanguageOverride(opts.LanguageOverrides, relPath)
+	if !ok {
+		language = DetectLanguageWithRegistry(relPath, opts.LanguageRegistry)
+	}
+	if contentType, ok := lookupContentTypeOverride(opts.ContentTypeOverrides, language); ok {
+		return language, contentType
+	}
+	return language, DetectContentTypeWithRegistry(language, opts.LanguageRegistry)
+}
+
+// lookupLanguageOverride returns the language that overrides maps relPath's
+// extension to. Keys match the end of the file name case-insensitively, with
+// or without the leading dot, so multi-part keys such as ".go.tmpl" work; the
+// longest matching key wins, and keys that differ only in case or the dot are
+// resolved in sorted order.
+func lookupLanguageOverride(overrides map[string]string, relPath string) (string, bool) {
+	if len(overrides) == 0 {
+		return "", false
+	}
+	name := strings.ToLower(filepath.Base(relPath))
+	keys := slices.Sorted(maps.Keys(overrides))
+	best, bestLen := "", 0
+	for _, key := range keys {
+		suffix := "." + strings.ToLower(strings.TrimPrefix(key, "."))
+		if len(suffix) > bestLen && strings.HasSuffix(name, suffix) {
+			best, bestLen = key, len(suffix)
+		}
+	}
+	if bestLen == 0 {
+		return "", false
+	}
+	return overrides[best], true
+}
+
+// lookupContentTypeOverride returns the override for languageName, matching
+// keys case-insensitively.
+func lookupContentTypeOverride(overrides map[string]ContentType, languageName string) (ContentType, bool) {
+	if len(overrides) == 0 {
+		return "", false
+	}
+	if contentType, ok := overrides[languageName]; ok {
+		return contentType, true
+	}
+	for name, contentType := range overrides {
+		if strings.EqualFold(name, languageName) {
+			return contentType, true
+		}
+	}
+	return "", false
+}
+
 // DetectContentType detects the content type from a language.
 func DetectContentType(languageName string) ContentType {
 	return DetectContentTypeWithRegistry(languageName, nil)
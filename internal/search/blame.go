@@ -0,0 +1,160 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBlameLimit is the number of top results enriched with blame when
+// SearchOptions.BlameLimit is zero. git blame is expensive, so enrichment is
+// always bounded.
+const DefaultBlameLimit = 5
+
+// uncommittedSHA is the commit git blame reports for uncommitted lines.
+const uncommittedSHA = "0000000000000000000000000000000000000000"
+
+// Blame identifies the most recent commit touching a result's line range.
+// The zero value means blame was not requested or is unavailable.
+type Blame struct {
+	Author string
+	Date   time.Time
+	Commit string
+}
+
+// BlameLine is the blame of a single source line.
+type BlameLine struct {
+	Commit string
+	Author string
+	Date   time.Time
+}
+
+// BlameProvider returns per-line blame for a project-relative file path.
+// Line i of the file is at index i-1.
+type BlameProvider interface {
+	BlameFile(ctx context.Context, path string) ([]BlameLine, error)
+}
+
+// GitBlameProvider runs git blame in a repository.
+type GitBlameProvider struct {
+	root string
+}
+
+// NewGitBlameProvider creates a blame provider for the repository at root.
+func NewGitBlameProvider(root string) *GitBlameProvider {
+	return &GitBlameProvider{root: root}
+}
+
+// BlameFile runs git blame on path. Outside a git repository, or for untracked
+// files, it returns an error and callers fall back to empty blame.
+func (p *GitBlameProvider) BlameFile(ctx context.Context, path string) ([]BlameLine, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", p.root, "blame", "--line-porcelain", "--", path)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git blame for %s: %w", path, err)
+	}
+	return parseBlamePorcelain(out), nil
+}
+
+// parseBlamePorcelain parses `git blame --line-porcelain` output.
+func parseBlamePorcelain(out []byte) []BlameLine {
+	var lines []BlameLine
+	var current BlameLine
+	inEntry := false
+
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			// Source line terminates the entry.
+			lines = append(lines, current)
+			current = BlameLine{}
+			inEntry = false
+		case !inEntry:
+			fields := strings.Fields(line)
+			if len(fields) >= 3 && len(fields[0]) == 40 {
+				current.Commit = fields[0]
+				inEntry = true
+			}
+		case strings.HasPrefix(line, "author "):
+			current.Author = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-time "):
+			if secs, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64); err == nil {
+				current.Date = time.Unix(secs, 0).UTC()
+			}
+		}
+	}
+	return lines
+}
+
+// blameForRange returns the most recently authored committed line in
+// [startLine, endLine] (1-indexed, inclusive).
+func blameForRange(lines []BlameLine, startLine, endLine int) Blame {
+	if startLine < 1 {
+		startLine = 1
+	}
+	if endLine < startLine || endLine > len(lines) {
+		endLine = len(lines)
+	}
+	if startLine > endLine {
+		return Blame{}
+	}
+
+	var best BlameLine
+	for _, l := range lines[startLine-1 : endLine] {
+		if l.Commit == "" || l.Commit == uncommittedSHA {
+			continue
+		}
+		if best.Commit == "" || l.Date.After(best.Date) {
+			best = l
+		}
+	}
+	if best.Commit == "" {
+		return Blame{}
+	}
+	return Blame{Author: best.Author, Date: best.Date, Commit: best.Commit}
+}
+
+// attachBlame populates Blame on the top results when opts.IncludeBlame is
+// set. Each file is blamed at most once per query.
+func (e *Engine) attachBlame(ctx context.Context, results []*SearchResult, opts SearchOptions) {
+	if !opts.IncludeBlame || e.blame == nil {
+		return
+	}
+	limit := opts.BlameLimit
+	if limit <= 0 {
+		limit = DefaultBlameLimit
+	}
+
+	cache := make(map[string][]BlameLine)
+	for i, r := range results {
+		if i >= limit || ctx.Err() != nil {
+			return
+		}
+		if r.Chunk == nil || r.Chunk.FilePath == "" {
+			continue
+		}
+		lines, ok := cache[r.Chunk.FilePath]
+		if !ok {
+			var err error
+			lines, err = e.blame.BlameFile(ctx, r.Chunk.FilePath)
+			if err != nil {
+				slog.Debug("blame_unavailable",
+					slog.String("file", r.Chunk.FilePath),
+					slog.String("error", err.Error()))
+			}
+			cache[r.Chunk.FilePath] = lines
+		}
+		if len(lines) > 0 {
+			r.Blame = blameForRange(lines, r.Chunk.StartLine, r.Chunk.EndLine)
+		}
+	}
+}
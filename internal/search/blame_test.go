@@ -0,0 +1,180 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// mockBlameProvider returns canned blame per file and counts calls.
+type mockBlameProvider struct {
+	files map[string][]BlameLine
+	err   error
+	calls map[string]int
+}
+
+func (m *mockBlameProvider) BlameFile(ctx context.Context, path string) ([]BlameLine, error) {
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[path]++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.files[path], nil
+}
+
+func blameLines(n int, line BlameLine) []BlameLine {
+	lines := make([]BlameLine, n)
+	for i := range lines {
+		lines[i] = line
+	}
+	return lines
+}
+
+func TestParseBlamePorcelain(t *testing.T) {
+	// Given: --line-porcelain output for two lines from different commits
+	out := strings.Join([]string{
+		"1111111111111111111111111111111111111111 1 1 1",
+		"author Alice",
+		"author-mail <alice@example.com>",
+		"author-time 1700000000",
+		"author-tz +0000",
+		"summary first",
+		"filename main.go",
+		"\tpackage main",
+		"2222222222222222222222222222222222222222 2 2 1",
+		"author Bob",
+		"author-time 1710000000",
+		"filename main.go",
+		"\tfunc main() {}",
+	}, "\n")
+
+	// When: parsing
+	lines := parseBlamePorcelain([]byte(out))
+
+	// Then: one entry per source line
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Alice", lines[0].Author)
+	assert.Equal(t, "1111111111111111111111111111111111111111", lines[0].Commit)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), lines[0].Date)
+	assert.Equal(t, "Bob", lines[1].Author)
+}
+
+func TestBlameForRange_PicksMostRecentCommittedLine(t *testing.T) {
+	old := BlameLine{Commit: "aaa", Author: "Alice", Date: time.Unix(100, 0)}
+	recent := BlameLine{Commit: "bbb", Author: "Bob", Date: time.Unix(200, 0)}
+	uncommitted := BlameLine{Commit: uncommittedSHA, Author: "Not Committed Yet", Date: time.Unix(300, 0)}
+	lines := []BlameLine{old, recent, old, uncommitted, old}
+
+	assert.Equal(t, "Bob", blameForRange(lines, 1, 5).Author, "most recent committed line wins")
+	assert.Equal(t, "Alice", blameForRange(lines, 3, 5).Author, "uncommitted lines are ignored")
+	assert.Equal(t, Blame{}, blameForRange(lines, 4, 4), "only uncommitted lines yields empty blame")
+	assert.Equal(t, Blame{}, blameForRange(lines, 10, 12), "range past EOF yields empty blame")
+	assert.Equal(t, "Bob", blameForRange(lines, 2, 100).Author, "end past EOF is clamped")
+}
+
+func TestEngine_Search_IncludeBlame(t *testing.T) {
+	// Given: an engine with a blame provider and BM25 results from two files
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{
+			{DocID: "chunk1", Score: 0.9},
+			{DocID: "chunk2", Score: 0.7},
+		}, nil
+	}
+	when := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	provider := &mockBlameProvider{files: map[string][]BlameLine{
+		"auth/login.go": blameLines(30, BlameLine{Commit: "abc123", Author: "Alice", Date: when}),
+	}}
+	engine.blame = provider
+
+	// When: searching with IncludeBlame
+	results, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true, IncludeBlame: true})
+
+	// Then: the result from a blamed file is enriched, others stay empty
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		switch r.Chunk.FilePath {
+		case "auth/login.go":
+			assert.Equal(t, Blame{Author: "Alice", Date: when, Commit: "abc123"}, r.Blame)
+		default:
+			assert.Equal(t, Blame{}, r.Blame)
+		}
+	}
+	assert.Equal(t, 1, provider.calls["auth/login.go"])
+}
+
+func TestEngine_Search_IncludeBlame_Bounded(t *testing.T) {
+	// Given: three results, two from the same file, and BlameLimit 2
+	engine, bm25, _, _, metadata := setupTestEngine(t)
+	metadata.chunks["chunk1b"] = &store.Chunk{
+		ID: "chunk1b", FilePath: "auth/login.go", Content: "func validate() {}",
+		ContentType: store.ContentTypeCode, Language: "go", StartLine: 26, EndLine: 30,
+	}
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{
+			{DocID: "chunk1", Score: 0.9},
+			{DocID: "chunk1b", Score: 0.8},
+			{DocID: "chunk2", Score: 0.7},
+		}, nil
+	}
+	provider := &mockBlameProvider{files: map[string][]BlameLine{
+		"auth/login.go":  blameLines(30, BlameLine{Commit: "abc123", Author: "Alice", Date: time.Unix(100, 0)}),
+		"auth/logout.go": blameLines(30, BlameLine{Commit: "def456", Author: "Bob", Date: time.Unix(100, 0)}),
+	}}
+	engine.blame = provider
+
+	// When: searching with a blame limit of 2
+	results, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true, IncludeBlame: true, BlameLimit: 2})
+
+	// Then: the shared file is blamed once and the third result is not blamed
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.NotEmpty(t, results[0].Blame.Commit)
+	assert.NotEmpty(t, results[1].Blame.Commit)
+	assert.Empty(t, results[2].Blame.Commit)
+	assert.Equal(t, 1, provider.calls[results[0].Chunk.FilePath])
+	assert.Len(t, provider.calls, 1)
+}
+
+func TestEngine_Search_IncludeBlame_Degrades(t *testing.T) {
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{{DocID: "chunk1", Score: 0.9}}, nil
+	}
+
+	t.Run("provider error", func(t *testing.T) {
+		// Given: a provider failing as git does outside a repository
+		engine.blame = &mockBlameProvider{err: errors.New("not a git repository")}
+
+		// When: searching with IncludeBlame
+		results, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true, IncludeBlame: true})
+
+		// Then: the search succeeds with empty blame
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, Blame{}, results[0].Blame)
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		// Given: a working provider
+		provider := &mockBlameProvider{}
+		engine.blame = provider
+
+		// When: searching without IncludeBlame
+		_, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true})
+
+		// Then: git blame is never run
+		require.NoError(t, err)
+		assert.Empty(t, provider.calls)
+	})
+}
@@ -0,0 +1,43 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// parseBooleanQuery parses query for SearchOptions.BooleanQuery, failing
+// early when the BM25 index cannot evaluate boolean queries.
+func (e *Engine) parseBooleanQuery(query string) (*store.BooleanQuery, error) {
+	if _, ok := e.bm25.(store.BooleanSearcher); !ok {
+		return nil, fmt.Errorf("BM25 index %T does not support boolean queries", e.bm25)
+	}
+	return store.ParseBooleanQuery(query)
+}
+
+// searchBM25 runs a BM25 search, evaluating boolQuery instead of query when
+// it is non-nil. Otherwise prefix words ("auth*", or every word with
+// prefixMatch) are expanded when the index supports it; indexes that do not
+// fall back to a plain search.
+func (e *Engine) searchBM25(ctx context.Context, query string, boolQuery *store.BooleanQuery, prefixMatch bool, limit int) ([]*store.BM25Result, error) {
+	if boolQuery == nil {
+		if usesPrefixSearch(query, boolQuery, prefixMatch) {
+			if ps, ok := e.bm25.(store.PrefixSearcher); ok {
+				return ps.SearchPrefix(ctx, query, prefixMatch, limit)
+			}
+		}
+		return e.bm25.Search(ctx, query, limit)
+	}
+	bs, ok := e.bm25.(store.BooleanSearcher)
+	if !ok {
+		return nil, fmt.Errorf("BM25 index %T does not support boolean queries", e.bm25)
+	}
+	return bs.SearchBoolean(ctx, boolQuery, limit)
+}
+
+// usesPrefixSearch reports whether the BM25 query is a prefix query.
+// Boolean queries take precedence.
+func usesPrefixSearch(query string, boolQuery *store.BooleanQuery, prefixMatch bool) bool {
+	return boolQuery == nil && (prefixMatch || store.HasPrefixMarker(query))
+}
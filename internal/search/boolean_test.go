@@ -0,0 +1,98 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// mockBooleanBM25Index is a MockBM25Index that also evaluates boolean queries.
+type mockBooleanBM25Index struct {
+	MockBM25Index
+	results []*store.BM25Result
+	queries []string
+}
+
+func (m *mockBooleanBM25Index) SearchBoolean(_ context.Context, query *store.BooleanQuery, _ int) ([]*store.BM25Result, error) {
+	m.queries = append(m.queries, query.String())
+	return m.results, nil
+}
+
+func TestEngine_Search_BooleanQuery_BM25Only(t *testing.T) {
+	// Given: an engine whose BM25 index supports boolean queries
+	_, _, vector, embedder, metadata := setupTestEngine(t)
+	bm25 := &mockBooleanBM25Index{results: []*store.BM25Result{{DocID: "chunk1", Score: 2}}}
+	engine := New(bm25, vector, embedder, metadata, DefaultConfig())
+
+	// When: searching with a boolean query
+	results, err := engine.Search(context.Background(), "login AND user NOT test", SearchOptions{
+		BooleanQuery: true,
+		BM25Only:     true,
+	})
+
+	// Then: the BM25 index evaluates the parsed expression instead of plain terms
+	require.NoError(t, err)
+	assert.Equal(t, []string{"login AND user AND NOT test"}, bm25.queries)
+	assert.Zero(t, bm25.searchCalled.Load())
+	require.Len(t, results, 1)
+	assert.Equal(t, "chunk1", results[0].Chunk.ID)
+}
+
+func TestEngine_Search_BooleanQuery_EmbedsOnlyPositiveTerms(t *testing.T) {
+	// Given: a hybrid engine whose BM25 index supports boolean queries
+	_, _, vector, embedder, metadata := setupTestEngine(t)
+	bm25 := &mockBooleanBM25Index{}
+	engine := New(bm25, vector, embedder, metadata, DefaultConfig())
+
+	var mu sync.Mutex
+	var embedded []string
+	embedder.EmbedFn = func(_ context.Context, text string) ([]float32, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		embedded = append(embedded, text)
+		return make([]float32, 768), nil
+	}
+
+	// When: searching with a boolean query
+	_, err := engine.Search(context.Background(), "(login OR session) NOT mock", SearchOptions{BooleanQuery: true})
+
+	// Then: semantic search sees the terms without operators or negated terms
+	require.NoError(t, err)
+	assert.Equal(t, []string{"(login OR session) AND NOT mock"}, bm25.queries)
+	require.NotEmpty(t, embedded)
+	for _, text := range embedded {
+		assert.Contains(t, text, "login session")
+		assert.False(t, strings.Contains(text, "mock") || strings.Contains(text, "NOT"), text)
+	}
+}
+
+func TestEngine_Search_BooleanQuery_Errors(t *testing.T) {
+	t.Run("unsupported index", func(t *testing.T) {
+		// Given: a BM25 index without boolean support
+		engine, _, _, _, _ := setupTestEngine(t)
+
+		// When: searching with a boolean query
+		_, err := engine.Search(context.Background(), "a AND b", SearchOptions{BooleanQuery: true})
+
+		// Then: the search fails instead of ignoring the operators
+		assert.ErrorContains(t, err, "does not support boolean queries")
+	})
+
+	t.Run("malformed query", func(t *testing.T) {
+		// Given: an engine with boolean support
+		_, _, vector, embedder, metadata := setupTestEngine(t)
+		engine := New(&mockBooleanBM25Index{}, vector, embedder, metadata, DefaultConfig())
+
+		// When: searching with a query that only negates
+		_, err := engine.Search(context.Background(), "NOT test", SearchOptions{BooleanQuery: true})
+
+		// Then: the parse error is returned
+		assert.ErrorIs(t, err, store.ErrInvalidBooleanQuery)
+	})
+}
@@ -0,0 +1,136 @@
+package search
+
+import (
+	"context"
+	"fmt"
+)
+
+// RankMove describes how a result's rank changed from ranking A to ranking B.
+type RankMove string
+
+const (
+	RankMoveUp   RankMove = "up"   // ranked higher under B
+	RankMoveDown RankMove = "down" // ranked lower under B
+	RankMoveSame RankMove = "same" // same rank under both
+	RankMoveIn   RankMove = "in"   // only in B's top k
+	RankMoveOut  RankMove = "out"  // only in A's top k
+)
+
+// RankChange is one result's position under both weight sets.
+type RankChange struct {
+	ChunkID string
+
+	// RankA and RankB are 1-based ranks, 0 when absent from that ranking.
+	RankA int
+	RankB int
+
+	Move RankMove
+}
+
+// WeightedRanking is the top k results of a query under one weight set.
+type WeightedRanking struct {
+	Weights Weights
+	Results []*SearchResult
+}
+
+// WeightDiffReport compares the rankings of one query under two weight sets.
+type WeightDiffReport struct {
+	Query string
+	K     int
+	A     WeightedRanking
+	B     WeightedRanking
+
+	// Changes lists every result of either ranking: B's results in rank
+	// order, then those that dropped out, in A's rank order.
+	Changes []RankChange
+}
+
+// CompareWeights runs query with weights a and then b and reports how the
+// top k results move between them. It is a tuning aid: both searches go
+// through the normal Search path with explicit weights, so the query
+// classifier is bypassed, and nothing is written to the index.
+//
+// k <= 0 uses DefaultEvalK; k is still capped by EngineConfig.MaxLimit.
+// Weights are validated as in SearchOptions.Validate.
+func (e *Engine) CompareWeights(ctx context.Context, query string, a, b Weights, k int) (*WeightDiffReport, error) {
+	if k <= 0 {
+		k = DefaultEvalK
+	}
+
+	report := &WeightDiffReport{Query: query, K: k}
+	for _, run := range []struct {
+		name    string
+		weights Weights
+		out     *WeightedRanking
+	}{
+		{"a", a, &report.A},
+		{"b", b, &report.B},
+	} {
+		opts := SearchOptions{Limit: k, Weights: &run.weights}
+		if err := opts.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid weights %s: %w", run.name, err)
+		}
+		results, err := e.Search(ctx, query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search with weights %s: %w", run.name, err)
+		}
+		if len(results) > k {
+			results = results[:k]
+		}
+		*run.out = WeightedRanking{Weights: run.weights, Results: results}
+	}
+
+	report.Changes = diffRankings(resultChunkIDs(report.A.Results), resultChunkIDs(report.B.Results))
+	return report, nil
+}
+
+// resultChunkIDs returns the chunk IDs of results in rank order.
+func resultChunkIDs(results []*SearchResult) []string {
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		if r.Chunk != nil {
+			ids = append(ids, r.Chunk.ID)
+		}
+	}
+	return ids
+}
+
+// diffRankings compares two rankings of IDs. Duplicate IDs keep their first
+// rank.
+func diffRankings(a, b []string) []RankChange {
+	rankA := make(map[string]int, len(a))
+	for i, id := range a {
+		if _, ok := rankA[id]; !ok {
+			rankA[id] = i + 1
+		}
+	}
+
+	changes := make([]RankChange, 0, len(b))
+	inB := make(map[string]bool, len(b))
+	for i, id := range b {
+		if inB[id] {
+			continue
+		}
+		inB[id] = true
+		change := RankChange{ChunkID: id, RankA: rankA[id], RankB: i + 1}
+		switch {
+		case change.RankA == 0:
+			change.Move = RankMoveIn
+		case change.RankB < change.RankA:
+			change.Move = RankMoveUp
+		case change.RankB > change.RankA:
+			change.Move = RankMoveDown
+		default:
+			change.Move = RankMoveSame
+		}
+		changes = append(changes, change)
+	}
+
+	for i, id := range a {
+		if inB[id] || rankA[id] != i+1 {
+			continue
+		}
+		changes = append(changes, RankChange{ChunkID: id, RankA: i + 1, Move: RankMoveOut})
+	}
+	return changes
+}
@@ -0,0 +1,65 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRankings(t *testing.T) {
+	// Given: two rankings sharing some IDs
+	a := []string{"x", "y", "z", "gone"}
+	b := []string{"y", "x", "z", "new"}
+
+	// When: diffing them
+	changes := diffRankings(a, b)
+
+	// Then: B's results come first in rank order, then the dropped ones
+	assert.Equal(t, []RankChange{
+		{ChunkID: "y", RankA: 2, RankB: 1, Move: RankMoveUp},
+		{ChunkID: "x", RankA: 1, RankB: 2, Move: RankMoveDown},
+		{ChunkID: "z", RankA: 3, RankB: 3, Move: RankMoveSame},
+		{ChunkID: "new", RankB: 4, Move: RankMoveIn},
+		{ChunkID: "gone", RankA: 4, Move: RankMoveOut},
+	}, changes)
+}
+
+func TestEngine_CompareWeights(t *testing.T) {
+	// Given: an indexed engine
+	engine := newSimilarFilesTestEngine(t)
+	keyword := Weights{BM25: 1, Semantic: 0}
+	semantic := Weights{BM25: 0.1, Semantic: 0.9}
+
+	// When: comparing keyword-heavy and semantic-heavy weights
+	report, err := engine.CompareWeights(context.Background(), "AuthenticateUser", keyword, semantic, 3)
+
+	// Then: both rankings are returned with their weights, capped at k
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.K)
+	assert.Equal(t, keyword, report.A.Weights)
+	assert.Equal(t, semantic, report.B.Weights)
+	require.NotEmpty(t, report.A.Results)
+	assert.LessOrEqual(t, len(report.B.Results), 3)
+
+	// And: every result of either ranking appears in the diff
+	seen := map[string]bool{}
+	for _, c := range report.Changes {
+		seen[c.ChunkID] = true
+	}
+	for _, r := range append(report.A.Results, report.B.Results...) {
+		assert.True(t, seen[r.Chunk.ID], r.Chunk.ID)
+	}
+}
+
+func TestEngine_CompareWeights_InvalidWeights(t *testing.T) {
+	// Given: an engine
+	engine, _, _, _, _ := setupTestEngine(t)
+
+	// When: comparing against all-zero weights
+	_, err := engine.CompareWeights(context.Background(), "login", DefaultWeights(), Weights{}, 5)
+
+	// Then: the weights are rejected
+	assert.ErrorIs(t, err, ErrInvalidSearchOptions)
+}
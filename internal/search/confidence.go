@@ -0,0 +1,123 @@
+package search
+
+import (
+	"fmt"
+	"math"
+)
+
+// Confidence is a coarse relevance label for a search result, meant for
+// clients that should not interpret raw scores.
+type Confidence string
+
+const (
+	// ConfidenceHigh marks a result that stands well above the rest of the
+	// result set.
+	ConfidenceHigh Confidence = "high"
+
+	// ConfidenceMedium marks a result in the bulk of the result set.
+	ConfidenceMedium Confidence = "medium"
+
+	// ConfidenceLow marks a result well below the rest of the result set, or
+	// one whose score is too small to mean much.
+	ConfidenceLow Confidence = "low"
+)
+
+// ConfidenceThresholds controls how ClassifyConfidence labels results.
+// Ratios are relative to the mean score of the result set being classified.
+// Zero fields select the DefaultConfidenceThresholds value.
+type ConfidenceThresholds struct {
+	// HighRatio labels a result High when its score is at least HighRatio
+	// times the mean score. Default: 1.5.
+	HighRatio float64
+
+	// LowRatio labels a result Low when its score is below LowRatio times
+	// the mean score. Default: 0.5.
+	LowRatio float64
+
+	// MinScore labels every result scoring below it Low, so a result set
+	// bunched near zero is Low throughout. Default: 0.05.
+	MinScore float64
+}
+
+// DefaultConfidenceThresholds returns the default confidence thresholds.
+func DefaultConfidenceThresholds() ConfidenceThresholds {
+	return ConfidenceThresholds{
+		HighRatio: 1.5,
+		LowRatio:  0.5,
+		MinScore:  0.05,
+	}
+}
+
+// Validate checks the thresholds.
+func (t ConfidenceThresholds) Validate() error {
+	for _, f := range []struct {
+		name  string
+		value float64
+	}{
+		{"high ratio", t.HighRatio},
+		{"low ratio", t.LowRatio},
+		{"min score", t.MinScore},
+	} {
+		if f.value < 0 || math.IsNaN(f.value) || math.IsInf(f.value, 0) {
+			return fmt.Errorf("confidence %s must be a finite value >= 0, got %v", f.name, f.value)
+		}
+	}
+	t = t.withDefaults()
+	if t.LowRatio > t.HighRatio {
+		return fmt.Errorf("confidence low ratio %v must not exceed high ratio %v", t.LowRatio, t.HighRatio)
+	}
+	return nil
+}
+
+// withDefaults fills zero fields from DefaultConfidenceThresholds.
+func (t ConfidenceThresholds) withDefaults() ConfidenceThresholds {
+	def := DefaultConfidenceThresholds()
+	if t.HighRatio == 0 {
+		t.HighRatio = def.HighRatio
+	}
+	if t.LowRatio == 0 {
+		t.LowRatio = def.LowRatio
+	}
+	if t.MinScore == 0 {
+		t.MinScore = def.MinScore
+	}
+	return t
+}
+
+// ClassifyConfidence sets Confidence on each result from where its score
+// falls in the distribution of the result set: well above the mean is High,
+// well below it (or below MinScore) is Low, everything else Medium. It runs
+// on the final results, after boosts, reranking and the limit, so labels
+// describe what the caller actually receives.
+func ClassifyConfidence(results []*SearchResult, thresholds ConfidenceThresholds) {
+	thresholds = thresholds.withDefaults()
+
+	var sum float64
+	var n int
+	for _, r := range results {
+		if r != nil {
+			sum += r.Score
+			n++
+		}
+	}
+	if n == 0 {
+		return
+	}
+	mean := sum / float64(n)
+
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		switch {
+		case r.Score < thresholds.MinScore:
+			r.Confidence = ConfidenceLow
+		case r.Score >= thresholds.HighRatio*mean:
+			r.Confidence = ConfidenceHigh
+		case r.Score < thresholds.LowRatio*mean:
+			r.Confidence = ConfidenceLow
+		default:
+			r.Confidence = ConfidenceMedium
+		}
+	}
+}
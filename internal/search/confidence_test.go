@@ -0,0 +1,97 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestClassifyConfidence(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []float64
+		want   []Confidence
+	}{
+		{
+			name:   "standout top result",
+			scores: []float64{1.0, 0.2, 0.15, 0.1},
+			want:   []Confidence{ConfidenceHigh, ConfidenceMedium, ConfidenceLow, ConfidenceLow},
+		},
+		{
+			name:   "bunched high scores",
+			scores: []float64{0.9, 0.88, 0.85},
+			want:   []Confidence{ConfidenceMedium, ConfidenceMedium, ConfidenceMedium},
+		},
+		{
+			name:   "bunched near zero",
+			scores: []float64{0.04, 0.01, 0.01},
+			want:   []Confidence{ConfidenceLow, ConfidenceLow, ConfidenceLow},
+		},
+		{
+			name:   "single result",
+			scores: []float64{0.7},
+			want:   []Confidence{ConfidenceMedium},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := make([]*SearchResult, len(tt.scores))
+			for i, s := range tt.scores {
+				results[i] = &SearchResult{Score: s}
+			}
+
+			ClassifyConfidence(results, ConfidenceThresholds{})
+
+			for i, r := range results {
+				assert.Equal(t, tt.want[i], r.Confidence, "result %d (score %v)", i, r.Score)
+			}
+		})
+	}
+}
+
+func TestClassifyConfidence_CustomThresholds(t *testing.T) {
+	// Given: results where the top score is 1.4x the mean
+	results := []*SearchResult{{Score: 0.7}, {Score: 0.3}}
+
+	// When: classifying with the defaults and with a lower high ratio
+	ClassifyConfidence(results, ConfidenceThresholds{})
+	defaultLabel := results[0].Confidence
+	ClassifyConfidence(results, ConfidenceThresholds{HighRatio: 1.2})
+
+	// Then: only the lower ratio labels it High
+	assert.Equal(t, ConfidenceMedium, defaultLabel)
+	assert.Equal(t, ConfidenceHigh, results[0].Confidence)
+}
+
+func TestConfidenceThresholds_Validate(t *testing.T) {
+	assert.NoError(t, ConfidenceThresholds{}.Validate())
+	assert.NoError(t, DefaultConfidenceThresholds().Validate())
+	assert.Error(t, ConfidenceThresholds{HighRatio: -1}.Validate())
+	assert.Error(t, ConfidenceThresholds{HighRatio: 1.2, LowRatio: 1.5}.Validate())
+}
+
+func TestEngine_Search_SetsConfidence(t *testing.T) {
+	// Given: an engine with BM25 results
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{
+			{DocID: "chunk1", Score: 0.9},
+			{DocID: "chunk2", Score: 0.7},
+		}, nil
+	}
+
+	// When: searching
+	results, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true})
+
+	// Then: every result carries a confidence label
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, r := range results {
+		assert.Contains(t, []Confidence{ConfidenceHigh, ConfidenceMedium, ConfidenceLow}, r.Confidence)
+	}
+}
@@ -0,0 +1,98 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ErrEmptyPathPrefix is returned by DeleteByPathPrefix for a prefix that
+// would match the whole project; use DeleteProject for that.
+var ErrEmptyPathPrefix = errors.New("empty path prefix")
+
+// PathPrefixDeletion reports what DeleteByPathPrefix removed.
+type PathPrefixDeletion struct {
+	Prefix string
+
+	// Paths are the removed files, sorted.
+	Paths []string
+
+	Files  int
+	Chunks int
+}
+
+// DeleteByPathPrefix removes every indexed file at or under the directory
+// prefix, e.g. after the directory was deleted. Its chunks are removed from
+// all stores in one batch instead of file by file.
+//
+// Like Delete it is best-effort for the BM25 index and vector store, whose
+// leftovers are filtered from results, while the metadata deletion must
+// succeed. Metadata is deleted last, so a failed run can be retried.
+func (e *Engine) DeleteByPathPrefix(ctx context.Context, projectID, prefix string) (*PathPrefixDeletion, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" || prefix == "." {
+		return nil, ErrEmptyPathPrefix
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.queryCache.invalidate()
+
+	paths, err := e.metadata.ListFilePathsUnder(ctx, projectID, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files under %s: %w", prefix, err)
+	}
+
+	deletion := &PathPrefixDeletion{Prefix: prefix}
+	var fileIDs, chunkIDs []string
+	for _, path := range paths {
+		file, err := e.metadata.GetFileByPath(ctx, projectID, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file %s: %w", path, err)
+		}
+		if file == nil {
+			continue
+		}
+		chunks, err := e.metadata.GetChunksByFile(ctx, file.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chunks for %s: %w", path, err)
+		}
+		for _, c := range chunks {
+			chunkIDs = append(chunkIDs, c.ID)
+		}
+		fileIDs = append(fileIDs, file.ID)
+		deletion.Paths = append(deletion.Paths, path)
+	}
+
+	if len(chunkIDs) > 0 {
+		if err := e.bm25.Delete(ctx, chunkIDs); err != nil {
+			slog.Warn("BM25 delete failed, orphans will remain until compaction",
+				slog.String("error", err.Error()),
+				slog.Int("count", len(chunkIDs)))
+		}
+		if err := e.vector.Delete(ctx, chunkIDs); err != nil {
+			slog.Warn("vector delete failed, orphans will remain until compaction",
+				slog.String("error", err.Error()),
+				slog.Int("count", len(chunkIDs)))
+		}
+		if err := e.metadata.DeleteChunks(ctx, chunkIDs); err != nil {
+			return nil, fmt.Errorf("delete chunks metadata: %w", err)
+		}
+	}
+
+	for i, fileID := range fileIDs {
+		if err := e.metadata.DeleteFile(ctx, fileID); err != nil {
+			return nil, fmt.Errorf("failed to delete file %s: %w", deletion.Paths[i], err)
+		}
+	}
+
+	deletion.Files = len(fileIDs)
+	deletion.Chunks = len(chunkIDs)
+	slog.Debug("path_prefix_deleted",
+		slog.String("prefix", prefix),
+		slog.Int("files", deletion.Files),
+		slog.Int("chunks", deletion.Chunks))
+	return deletion, nil
+}
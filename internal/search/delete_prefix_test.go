@@ -0,0 +1,68 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEngine_DeleteByPathPrefix(t *testing.T) {
+	// Given: an index with files inside, below and beside internal/old
+	ctx := context.Background()
+	embedder := embed.NewStaticEmbedder768()
+	vector, err := store.NewFlatStore(store.DefaultVectorStoreConfig(embedder.Dimensions()))
+	require.NoError(t, err)
+	engine := newSQLiteTestEngine(t, vector, embedder, []*store.File{
+		{ID: "f1", ProjectID: "proj", Path: "internal/old/a.go", Language: "go"},
+		{ID: "f2", ProjectID: "proj", Path: "internal/old/deep/b.go", Language: "go"},
+		{ID: "f3", ProjectID: "proj", Path: "internal/older/c.go", Language: "go"},
+	}, []*store.Chunk{
+		{ID: "a-1", FileID: "f1", FilePath: "internal/old/a.go", Content: "func LegacyAlpha() {}"},
+		{ID: "a-2", FileID: "f1", FilePath: "internal/old/a.go", Content: "func LegacyAlphaHelper() {}"},
+		{ID: "b-1", FileID: "f2", FilePath: "internal/old/deep/b.go", Content: "func LegacyBeta() {}"},
+		{ID: "c-1", FileID: "f3", FilePath: "internal/older/c.go", Content: "func LegacyGamma() {}"},
+	})
+	metadata, bm25 := engine.metadata, engine.bm25
+
+	// When: deleting the directory
+	deletion, err := engine.DeleteByPathPrefix(ctx, "proj", "internal/old/")
+
+	// Then: its files and chunks are gone from every store, its sibling is not
+	require.NoError(t, err)
+	assert.Equal(t, &PathPrefixDeletion{
+		Prefix: "internal/old",
+		Paths:  []string{"internal/old/a.go", "internal/old/deep/b.go"},
+		Files:  2,
+		Chunks: 3,
+	}, deletion)
+	for _, id := range []string{"a-1", "a-2", "b-1"} {
+		assert.False(t, vector.Contains(id), id)
+	}
+	assert.True(t, vector.Contains("c-1"))
+	file, err := metadata.GetFileByPath(ctx, "proj", "internal/old/a.go")
+	require.NoError(t, err)
+	assert.Nil(t, file)
+	bm25Results, err := bm25.Search(ctx, "Legacy", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, bm25Results)
+	for _, r := range bm25Results {
+		assert.Equal(t, "c-1", r.DocID)
+	}
+	remaining, err := metadata.GetChunksByFile(ctx, "f3")
+	require.NoError(t, err)
+	assert.Len(t, remaining, 1)
+}
+
+func TestEngine_DeleteByPathPrefix_RejectsEmptyPrefix(t *testing.T) {
+	engine, _, _, _, _ := setupTestEngine(t)
+
+	for _, prefix := range []string{"", "/", "."} {
+		_, err := engine.DeleteByPathPrefix(context.Background(), "proj", prefix)
+		assert.ErrorIs(t, err, ErrEmptyPathPrefix, prefix)
+	}
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// ProjectDeletion reports what DeleteProject removed.
+type ProjectDeletion struct {
+	ProjectID string
+	Files     int
+	Chunks    int
+}
+
+// DeleteProject removes a project for good: its chunks from the BM25 index
+// and vector store, then its files, chunks and project record from metadata.
+// Use it when a repository is removed or its project ID changes.
+//
+// Vectors are purged rather than tombstoned when the vector store implements
+// store.VectorPurger. Metadata is deleted last, so a failed run can simply be
+// retried. The metadata store must implement store.ProjectDeleter.
+func (e *Engine) DeleteProject(ctx context.Context, projectID string) (*ProjectDeletion, error) {
+	deleter, ok := e.metadata.(store.ProjectDeleter)
+	if !ok {
+		return nil, fmt.Errorf("metadata store %T does not support project deletion", e.metadata)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.queryCache.invalidate()
+
+	files, err := e.metadata.GetFilesForReconciliation(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project files: %w", err)
+	}
+
+	var chunkIDs []string
+	for _, f := range files {
+		chunks, err := e.metadata.GetChunksByFile(ctx, f.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chunks for %s: %w", f.Path, err)
+		}
+		for _, c := range chunks {
+			chunkIDs = append(chunkIDs, c.ID)
+		}
+	}
+
+	if len(chunkIDs) > 0 {
+		if err := e.bm25.Delete(ctx, chunkIDs); err != nil {
+			return nil, fmt.Errorf("failed to delete BM25 entries: %w", err)
+		}
+		if purger, ok := e.vector.(store.VectorPurger); ok {
+			if err := purger.Purge(ctx, chunkIDs); err != nil {
+				return nil, fmt.Errorf("failed to purge vectors: %w", err)
+			}
+		} else {
+			if err := e.vector.Delete(ctx, chunkIDs); err != nil {
+				return nil, fmt.Errorf("failed to delete vectors: %w", err)
+			}
+			slog.Debug("vector store cannot purge, deleted vectors remain until compaction",
+				slog.String("project_id", projectID),
+				slog.Int("chunks", len(chunkIDs)))
+		}
+	}
+
+	if err := deleter.DeleteProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	slog.Info("project_deleted",
+		slog.String("project_id", projectID),
+		slog.Int("files", len(files)),
+		slog.Int("chunks", len(chunkIDs)))
+
+	return &ProjectDeletion{
+		ProjectID: projectID,
+		Files:     len(files),
+		Chunks:    len(chunkIDs),
+	}, nil
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEngine_DeleteProject(t *testing.T) {
+	// Given: an engine with two indexed projects
+	engine := newExportTestEngine(t)
+	ctx := context.Background()
+	require.NoError(t, engine.metadata.SaveProject(ctx, &store.Project{ID: "other", Name: "other", RootPath: "/other"}))
+	require.NoError(t, engine.metadata.SaveFiles(ctx, []*store.File{{ID: "file-other", ProjectID: "other", Path: "db.go", Language: "go"}}))
+	require.NoError(t, engine.Index(ctx, []*store.Chunk{
+		{ID: "chunk-other", FileID: "file-other", FilePath: "db.go", Content: "func Connect(dsn string) error", ContentType: store.ContentTypeCode, Language: "go"},
+	}))
+
+	// When: deleting the first project
+	deleted, err := engine.DeleteProject(ctx, "proj")
+
+	// Then: its file and chunks are reported
+	require.NoError(t, err)
+	assert.Equal(t, &ProjectDeletion{ProjectID: "proj", Files: 1, Chunks: 2}, deleted)
+
+	// And: the project record is gone
+	project, err := engine.metadata.GetProject(ctx, "proj")
+	require.NoError(t, err)
+	assert.Nil(t, project)
+
+	// And: BM25 entries are deleted and vectors purged, not tombstoned
+	hits, err := engine.bm25.Search(ctx, "Logout", 10)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+	hnswStore := engine.vector.(*store.HNSWStore)
+	assert.Equal(t, store.HNSWStats{ValidIDs: 1, GraphNodes: 1}, hnswStore.Stats())
+
+	// And: the other project still searches
+	results, err := engine.Search(ctx, "Connect", SearchOptions{BM25Only: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "chunk-other", results[0].Chunk.ID)
+}
+
+func TestEngine_DeleteProject_UnsupportedStore(t *testing.T) {
+	// Given: an engine over a mock metadata store
+	engine, _, _, _, _ := setupTestEngine(t)
+
+	// When: deleting a project
+	_, err := engine.DeleteProject(context.Background(), "proj")
+
+	// Then: a clear error is returned
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support project deletion")
+}
@@ -0,0 +1,139 @@
+package search
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// TextPreprocessor returns the text to embed for a chunk.
+//
+// It only changes what the embedder sees: the chunk's stored Content, its
+// BM25 document and search output are left untouched. Embeddings are not
+// recomputed when the preprocessor changes, so switching or removing one
+// requires a reindex for existing vectors to match new ones.
+type TextPreprocessor func(chunk *store.Chunk) string
+
+// WithEmbedPreprocessor sets the preprocessor applied to chunk text before
+// it is embedded by Index and IndexWithEmbeddings. nil embeds Content as is.
+func WithEmbedPreprocessor(p TextPreprocessor) EngineOption {
+	return func(e *Engine) {
+		e.preprocess = p
+	}
+}
+
+// embedText returns the text the embedder sees for c, truncated to
+// EngineConfig.MaxChunkBytes.
+func (e *Engine) embedText(c *store.Chunk) string {
+	text := c.Content
+	if e.preprocess != nil {
+		// Never embed an empty string; fall back to the raw content
+		if processed := e.preprocess(c); strings.TrimSpace(processed) != "" {
+			text = processed
+		}
+	}
+
+	limit := e.config.MaxChunkBytes
+	if limit <= 0 {
+		limit = embed.MaxInputBytesFor(e.embedder)
+	}
+	truncated, ok := embed.TruncateInput(text, limit)
+	if ok {
+		slog.Info("embed_text_truncated",
+			slog.String("chunk_id", c.ID),
+			slog.String("file", c.FilePath),
+			slog.Int("bytes", len(text)),
+			slog.Int("limit", limit))
+	}
+	return truncated
+}
+
+// licenseKeywords mark a leading comment block as a license header.
+var licenseKeywords = regexp.MustCompile(`(?i)copyright|spdx-license-identifier|all rights reserved|licen[cs]ed under|(mit|apache|bsd|mozilla|gnu[a-z ]*) (public )?licen[cs]e`)
+
+// blankLineRuns matches runs of two or more blank lines.
+var blankLineRuns = regexp.MustCompile(`\n{3,}`)
+
+// StripLicenseHeader is a TextPreprocessor that drops a license header from
+// the first chunk of a file and normalizes whitespace.
+//
+// A header is the leading comment, either a /* ... */ block or consecutive
+// lines starting with //, #, -- or ;, when it mentions a copyright or
+// license. Only chunks starting at the top of a file are checked, so license
+// comments further down are kept. Trailing whitespace is trimmed from every
+// line and runs of blank lines are collapsed into one.
+func StripLicenseHeader(chunk *store.Chunk) string {
+	content := chunk.Content
+	if chunk.StartLine <= 1 {
+		content = stripLeadingLicense(content)
+	}
+	return normalizeWhitespace(content)
+}
+
+// stripLeadingLicense removes the leading comment of content if it is a
+// license header.
+func stripLeadingLicense(content string) string {
+	body := strings.TrimLeft(content, " \t\r\n")
+
+	// Keep a shebang line in front of the header
+	var shebang string
+	if strings.HasPrefix(body, "#!") {
+		end := strings.IndexByte(body, '\n')
+		if end == -1 {
+			return content
+		}
+		shebang, body = body[:end+1], strings.TrimLeft(body[end+1:], " \t\r\n")
+	}
+
+	var header, rest string
+	if strings.HasPrefix(body, "/*") {
+		end := strings.Index(body, "*/")
+		if end == -1 {
+			return content
+		}
+		header, rest = body[:end+2], body[end+2:]
+	} else {
+		n := 0
+		for n < len(body) {
+			end := strings.IndexByte(body[n:], '\n')
+			line := body[n:]
+			if end != -1 {
+				line = body[n : n+end+1]
+			}
+			if !isLineComment(strings.TrimSpace(line)) {
+				break
+			}
+			n += len(line)
+		}
+		header, rest = body[:n], body[n:]
+	}
+
+	if header == "" || !licenseKeywords.MatchString(header) {
+		return content
+	}
+	return shebang + strings.TrimLeft(rest, " \t\r\n")
+}
+
+// isLineComment reports whether a trimmed line is a line comment.
+func isLineComment(line string) bool {
+	for _, prefix := range []string{"//", "#", "--", ";"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeWhitespace trims trailing whitespace from each line and
+// collapses runs of blank lines.
+func normalizeWhitespace(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	content = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLineRuns.ReplaceAllString(content, "\n\n"))
+}
@@ -0,0 +1,146 @@
+package search
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestStripLicenseHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		startLine int
+		want      string
+	}{
+		{
+			name:      "go line comment license",
+			content:   "// Copyright 2024 Acme Inc.\n// Licensed under the Apache License, Version 2.0\n\npackage main\n",
+			startLine: 1,
+			want:      "package main",
+		},
+		{
+			name:      "block comment license",
+			content:   "/*\n * SPDX-License-Identifier: MIT\n */\n\nfunction main() {}\n",
+			startLine: 1,
+			want:      "function main() {}",
+		},
+		{
+			name:      "shebang kept before hash license",
+			content:   "#!/usr/bin/env python\n# Copyright (c) 2023 Example\n# All rights reserved.\nimport os\n",
+			startLine: 1,
+			want:      "#!/usr/bin/env python\nimport os",
+		},
+		{
+			name:      "named license only",
+			content:   "# Released under the MIT License.\n\nimport os\n",
+			startLine: 1,
+			want:      "import os",
+		},
+		{
+			name:      "package doc mentioning license kept",
+			content:   "// Package license checks license files.\npackage license\n",
+			startLine: 1,
+			want:      "// Package license checks license files.\npackage license",
+		},
+		{
+			name:      "leading doc comment without license kept",
+			content:   "// Package main runs the server.\npackage main\n",
+			startLine: 1,
+			want:      "// Package main runs the server.\npackage main",
+		},
+		{
+			name:      "license comment in later chunk kept",
+			content:   "// Copyright notice for vendored code\nfunc x() {}\n",
+			startLine: 40,
+			want:      "// Copyright notice for vendored code\nfunc x() {}",
+		},
+		{
+			name:      "whitespace normalized",
+			content:   "func a() {}   \n\n\n\nfunc b() {}\t\n",
+			startLine: 1,
+			want:      "func a() {}\n\nfunc b() {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StripLicenseHeader(&store.Chunk{Content: tt.content, StartLine: tt.startLine})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEngine_Index_EmbedPreprocessor(t *testing.T) {
+	// Given: an engine with the license preprocessor and an embedder that
+	// records the texts it receives
+	var mu sync.Mutex
+	var embedded []string
+	embedder := &MockEmbedder{
+		EmbedFn: func(_ context.Context, text string) ([]float32, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			embedded = append(embedded, text)
+			return make([]float32, 768), nil
+		},
+	}
+	metadata := NewMockMetadataStore()
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, embedder, metadata, DefaultConfig(),
+		WithEmbedPreprocessor(StripLicenseHeader))
+	content := "// Copyright 2024 Acme Inc.\n// Licensed under the MIT License\n\npackage main\n\nfunc main() {}\n"
+
+	// When: indexing a chunk that starts with a license header
+	err := engine.Index(context.Background(), []*store.Chunk{
+		{ID: "c1", FilePath: "main.go", Content: content, StartLine: 1},
+	})
+
+	// Then: the embedder sees the code only, while the stored content is unchanged
+	require.NoError(t, err)
+	require.Equal(t, []string{"package main\n\nfunc main() {}"}, embedded)
+	assert.Equal(t, content, metadata.chunks["c1"].Content)
+}
+
+func TestEngine_embedText_FallsBackOnEmptyOutput(t *testing.T) {
+	// Given: a preprocessor that strips everything
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, &MockEmbedder{}, NewMockMetadataStore(), DefaultConfig(),
+		WithEmbedPreprocessor(func(*store.Chunk) string { return "  \n" }))
+
+	// When / Then: the raw content is embedded instead of an empty string
+	assert.Equal(t, "// Copyright only", engine.embedText(&store.Chunk{Content: "// Copyright only"}))
+}
+
+func TestEngine_Index_TruncatesEmbedTextToMaxChunkBytes(t *testing.T) {
+	// Given: an engine capping embed text at 20 bytes and an embedder that
+	// records the texts it receives
+	var mu sync.Mutex
+	var embedded []string
+	embedder := &MockEmbedder{
+		EmbedFn: func(_ context.Context, text string) ([]float32, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			embedded = append(embedded, text)
+			return make([]float32, 768), nil
+		},
+	}
+	cfg := DefaultConfig()
+	cfg.MaxChunkBytes = 20
+	metadata := NewMockMetadataStore()
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, embedder, metadata, cfg)
+	content := "switch x {\ncase 1:\ncase 2:\ncase 3:\n}\n"
+
+	// When: indexing a chunk longer than the cap
+	err := engine.Index(context.Background(), []*store.Chunk{
+		{ID: "c1", FilePath: "gen.go", Content: content, StartLine: 1},
+	})
+
+	// Then: the embedder sees whole lines within the cap, while the stored
+	// content is unchanged
+	require.NoError(t, err)
+	require.Equal(t, []string{"switch x {\ncase 1:"}, embedded)
+	assert.Equal(t, content, metadata.chunks["c1"].Content)
+}
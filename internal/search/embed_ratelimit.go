@@ -0,0 +1,103 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// EmbedRateLimit throttles embedder calls for API-based backends that enforce
+// request quotas. Each Embed or EmbedBatch call, including retries, consumes
+// one token from a token bucket.
+//
+// The zero value disables rate limiting, which is what local embedders want.
+type EmbedRateLimit struct {
+	// RequestsPerSecond is the sustained call rate. Values <= 0 disable the
+	// limiter. Use fractions for per-minute quotas (e.g. 60 RPM = 1.0).
+	RequestsPerSecond float64
+
+	// Burst is the bucket size: how many calls may run back to back after an
+	// idle period. Default: 1.
+	Burst int
+}
+
+// Validate checks the rate limit values.
+func (l EmbedRateLimit) Validate() error {
+	if l.RequestsPerSecond < 0 || math.IsNaN(l.RequestsPerSecond) || math.IsInf(l.RequestsPerSecond, 0) {
+		return fmt.Errorf("requests per second must be a finite value >= 0, got %v", l.RequestsPerSecond)
+	}
+	if l.Burst < 0 {
+		return fmt.Errorf("burst must be >= 0, got %d", l.Burst)
+	}
+	return nil
+}
+
+// embedLimiter is a token bucket. A nil limiter never blocks.
+type embedLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// newEmbedLimiter returns a limiter for cfg, or nil when cfg disables limiting.
+// The bucket starts full.
+func newEmbedLimiter(cfg EmbedRateLimit) *embedLimiter {
+	if cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := cfg.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return &embedLimiter{
+		rate:   cfg.RequestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *embedLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		wait := l.take()
+		if wait == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take consumes a token and returns 0, or returns how long until one is
+// available.
+func (l *embedLimiter) take() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - l.tokens) / l.rate * float64(time.Second)))
+}
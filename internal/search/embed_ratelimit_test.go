@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEmbedLimiter_TokenBucket(t *testing.T) {
+	// Given: a 2 rps limiter with burst 2 on a fake clock
+	clock := time.Unix(0, 0)
+	l := newEmbedLimiter(EmbedRateLimit{RequestsPerSecond: 2, Burst: 2})
+	l.now = func() time.Time { return clock }
+
+	// Then: the burst is available immediately
+	assert.Zero(t, l.take())
+	assert.Zero(t, l.take())
+
+	// And: the next token is 500ms away
+	assert.Equal(t, 500*time.Millisecond, l.take())
+
+	// And: tokens refill with time but never exceed the burst
+	clock = clock.Add(10 * time.Second)
+	assert.Zero(t, l.take())
+	assert.Zero(t, l.take())
+	assert.Positive(t, l.take())
+}
+
+func TestEmbedLimiter_Disabled(t *testing.T) {
+	assert.Nil(t, newEmbedLimiter(EmbedRateLimit{}))
+	assert.Nil(t, newEmbedLimiter(EmbedRateLimit{RequestsPerSecond: -1}))
+
+	var l *embedLimiter
+	assert.NoError(t, l.Wait(context.Background()))
+}
+
+func TestEmbedLimiter_WaitRespectsCancellation(t *testing.T) {
+	// Given: a drained limiter refilling once a minute
+	l := newEmbedLimiter(EmbedRateLimit{RequestsPerSecond: 1.0 / 60})
+	require.NoError(t, l.Wait(context.Background()))
+
+	// When: waiting with a short deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := l.Wait(ctx)
+
+	// Then: the wait is abandoned at the deadline
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestEngine_EmbedRateLimit(t *testing.T) {
+	// Given: an engine limited to 20 embedder calls/sec with no burst
+	cfg := DefaultConfig()
+	cfg.EmbedRateLimit = EmbedRateLimit{RequestsPerSecond: 20, Burst: 1}
+	embedder := &MockEmbedder{}
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, embedder, NewMockMetadataStore(), cfg)
+
+	// When: indexing three batches back to back
+	start := time.Now()
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, engine.Index(context.Background(), []*store.Chunk{{ID: id, Content: "content"}}))
+	}
+
+	// Then: the second and third calls waited for tokens (~50ms each)
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	assert.Equal(t, int32(3), embedder.embedCalled.Load())
+}
+
+func TestNewEngine_InvalidEmbedRateLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EmbedRateLimit = EmbedRateLimit{RequestsPerSecond: 1, Burst: -1}
+
+	_, err := NewEngine(&MockBM25Index{}, &MockVectorStore{}, &MockEmbedder{}, NewMockMetadataStore(), cfg)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid embed rate limit")
+}
@@ -0,0 +1,144 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	amerrors "github.com/Aman-CERP/amanmcp/internal/errors"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// EmbedRetryPolicy controls how the engine retries transient embedder
+// failures (connection resets, 503s from a remote or MLX server).
+//
+// The zero value disables retries: each call is attempted once.
+type EmbedRetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values <= 1 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the wait before the first retry. It doubles on each
+	// subsequent retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the wait between retries. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Jitter randomizes each wait to 50-100% of the computed delay so
+	// concurrent indexers don't retry in lockstep.
+	Jitter bool
+}
+
+// DefaultEmbedRetryPolicy returns the default embedder retry policy.
+func DefaultEmbedRetryPolicy() EmbedRetryPolicy {
+	return EmbedRetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    4 * time.Second,
+		Jitter:      true,
+	}
+}
+
+// delay returns the wait before retry number retry (1-based).
+func (p EmbedRetryPolicy) delay(retry int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter && d > 0 {
+		d = time.Duration(float64(d) * (0.5 + rand.Float64()*0.5))
+	}
+	return d
+}
+
+// isRetryableEmbedError reports whether an embedder error is worth retrying.
+// Cancellation, dimension mismatches, open circuit breakers and structured
+// errors marked non-retryable are permanent; anything else is assumed to be
+// a transient transport failure.
+func isRetryableEmbedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrDimensionMismatch) || errors.Is(err, amerrors.ErrCircuitOpen) {
+		return false
+	}
+	var dimErr store.ErrDimensionMismatch
+	if errors.As(err, &dimErr) {
+		return false
+	}
+	var amanErr *amerrors.AmanError
+	if errors.As(err, &amanErr) {
+		return amanErr.Retryable
+	}
+	return true
+}
+
+// withEmbedRetry runs fn under the engine's embed retry policy.
+func withEmbedRetry[T any](ctx context.Context, p EmbedRetryPolicy, op string, fn func() (T, error)) (T, error) {
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var zero T
+	for attempt := 1; ; attempt++ {
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+
+		if attempt == attempts || !isRetryableEmbedError(err) || ctx.Err() != nil {
+			if attempt > 1 {
+				return zero, fmt.Errorf("%s failed after %d attempts: %w", op, attempt, err)
+			}
+			return zero, err
+		}
+
+		wait := p.delay(attempt)
+		slog.Warn("embedder call failed, retrying",
+			slog.String("op", op),
+			slog.Int("attempt", attempt),
+			slog.Int("max_attempts", attempts),
+			slog.Duration("backoff", wait),
+			slog.String("error", err.Error()))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, fmt.Errorf("%s interrupted after %d attempt(s): %w", op, attempt, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// embedBatch calls EmbedBatch with the configured retry policy and rate limit.
+func (e *Engine) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	return withEmbedRetry(ctx, e.config.EmbedRetry, "embed batch", func() ([][]float32, error) {
+		if err := e.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		return e.embedder.EmbedBatch(ctx, texts)
+	})
+}
+
+// embed calls emb.Embed with the configured retry policy and rate limit.
+func (e *Engine) embed(ctx context.Context, emb embed.Embedder, text string) ([]float32, error) {
+	return withEmbedRetry(ctx, e.config.EmbedRetry, "embed", func() ([]float32, error) {
+		if err := e.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		return emb.Embed(ctx, text)
+	})
+}
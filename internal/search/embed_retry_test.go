@@ -0,0 +1,150 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amerrors "github.com/Aman-CERP/amanmcp/internal/errors"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func fastEmbedRetryConfig(attempts int) EngineConfig {
+	cfg := DefaultConfig()
+	cfg.EmbedRetry = EmbedRetryPolicy{MaxAttempts: attempts, BaseDelay: time.Millisecond, MaxDelay: 4 * time.Millisecond}
+	return cfg
+}
+
+func TestEngine_Index_RetriesTransientEmbedderError(t *testing.T) {
+	// Given: an embedder that fails twice with a transient error
+	embedder := &MockEmbedder{}
+	embedder.EmbedFn = func(ctx context.Context, text string) ([]float32, error) {
+		if embedder.embedCalled.Load() <= 2 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return make([]float32, 768), nil
+	}
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, embedder, NewMockMetadataStore(), fastEmbedRetryConfig(3))
+
+	// When: indexing a chunk
+	err := engine.Index(context.Background(), []*store.Chunk{{ID: "chunk1", Content: "test content"}})
+
+	// Then: indexing succeeds on the third attempt
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), embedder.embedCalled.Load())
+}
+
+func TestEngine_Index_RetryExhausted(t *testing.T) {
+	// Given: an embedder that always fails with a transient error
+	embedder := &MockEmbedder{
+		EmbedFn: func(ctx context.Context, text string) ([]float32, error) {
+			return nil, errors.New("503 service unavailable")
+		},
+	}
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, embedder, NewMockMetadataStore(), fastEmbedRetryConfig(3))
+
+	// When: indexing a chunk
+	err := engine.Index(context.Background(), []*store.Chunk{{ID: "chunk1", Content: "test content"}})
+
+	// Then: the error reports all attempts
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed after 3 attempts")
+	assert.Contains(t, err.Error(), "503 service unavailable")
+	assert.Equal(t, int32(3), embedder.embedCalled.Load())
+}
+
+func TestEngine_Index_DoesNotRetryPermanentErrors(t *testing.T) {
+	permanent := []error{
+		store.ErrDimensionMismatch{Expected: 768, Got: 256},
+		fmt.Errorf("wrapped: %w", ErrDimensionMismatch),
+		amerrors.ErrCircuitOpen,
+		context.Canceled,
+		amerrors.ValidationError("bad input", nil),
+	}
+
+	for _, permErr := range permanent {
+		t.Run(permErr.Error(), func(t *testing.T) {
+			// Given: an embedder that fails with a permanent error
+			embedder := &MockEmbedder{
+				EmbedFn: func(ctx context.Context, text string) ([]float32, error) {
+					return nil, permErr
+				},
+			}
+			engine := New(&MockBM25Index{}, &MockVectorStore{}, embedder, NewMockMetadataStore(), fastEmbedRetryConfig(5))
+
+			// When: indexing a chunk
+			err := engine.Index(context.Background(), []*store.Chunk{{ID: "chunk1", Content: "test content"}})
+
+			// Then: the error is returned after a single attempt
+			require.Error(t, err)
+			assert.ErrorIs(t, err, permErr)
+			assert.Equal(t, int32(1), embedder.embedCalled.Load())
+		})
+	}
+}
+
+func TestEngine_Index_RetryRespectsCancellation(t *testing.T) {
+	// Given: a failing embedder and a long backoff
+	ctx, cancel := context.WithCancel(context.Background())
+	embedder := &MockEmbedder{
+		EmbedFn: func(_ context.Context, text string) ([]float32, error) {
+			cancel()
+			return nil, errors.New("connection reset by peer")
+		},
+	}
+	cfg := DefaultConfig()
+	cfg.EmbedRetry = EmbedRetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour}
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, embedder, NewMockMetadataStore(), cfg)
+
+	// When: the context is cancelled during the first attempt
+	err := engine.Index(ctx, []*store.Chunk{{ID: "chunk1", Content: "test content"}})
+
+	// Then: no retry is made
+	require.Error(t, err)
+	assert.Equal(t, int32(1), embedder.embedCalled.Load())
+}
+
+func TestEmbedRetryPolicy_Delay(t *testing.T) {
+	// Given: a policy without jitter
+	p := EmbedRetryPolicy{MaxAttempts: 6, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	// Then: delays double and are capped at MaxDelay
+	assert.Equal(t, 100*time.Millisecond, p.delay(1))
+	assert.Equal(t, 200*time.Millisecond, p.delay(2))
+	assert.Equal(t, 400*time.Millisecond, p.delay(3))
+	assert.Equal(t, 800*time.Millisecond, p.delay(4))
+	assert.Equal(t, time.Second, p.delay(5))
+
+	// Given: jitter enabled
+	p.Jitter = true
+
+	// Then: delays stay within 50-100% of the computed backoff
+	for i := 0; i < 100; i++ {
+		d := p.delay(2)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.LessOrEqual(t, d, 200*time.Millisecond)
+	}
+}
+
+func TestEngineConfig_ZeroEmbedRetryDisablesRetries(t *testing.T) {
+	// Given: an engine config without a retry policy
+	embedder := &MockEmbedder{
+		EmbedFn: func(ctx context.Context, text string) ([]float32, error) {
+			return nil, errors.New("connection reset by peer")
+		},
+	}
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, embedder, NewMockMetadataStore(), EngineConfig{})
+
+	// When: indexing a chunk
+	err := engine.Index(context.Background(), []*store.Chunk{{ID: "chunk1", Content: "test content"}})
+
+	// Then: the embedder is called once and the error is unwrapped
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "attempts")
+	assert.Equal(t, int32(1), embedder.embedCalled.Load())
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// routedEmbeddings is the result of one embed.InputRouter call.
+type routedEmbeddings struct {
+	embeddings [][]float32
+	models     []string
+}
+
+// embedChunkTexts embeds the texts of chunks in one batch and returns the
+// embeddings in order, together with the model that produced each. When the
+// embedder routes inputs (embed.InputRouter, e.g. embed.RoutingEmbedder),
+// each chunk is embedded by the model its path and language select;
+// otherwise all are embedded by the engine's embedder.
+func (e *Engine) embedChunkTexts(ctx context.Context, chunks []*store.Chunk) ([][]float32, []string, error) {
+	var generated [][]float32
+	var models []string
+	if router := embed.RouterFor(e.embedder); router != nil {
+		inputs := make([]embed.RouteInput, len(chunks))
+		for i, c := range chunks {
+			inputs[i] = e.routeInput(c)
+		}
+		routed, err := withEmbedRetry(ctx, e.config.EmbedRetry, "embed batch", func() (routedEmbeddings, error) {
+			if err := e.limiter.Wait(ctx); err != nil {
+				return routedEmbeddings{}, err
+			}
+			embeddings, models, err := router.EmbedRouted(ctx, inputs)
+			return routedEmbeddings{embeddings: embeddings, models: models}, err
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		generated, models = routed.embeddings, routed.models
+	} else {
+		texts := make([]string, len(chunks))
+		for i, c := range chunks {
+			texts[i] = e.embedText(c)
+		}
+		var err error
+		if generated, err = e.embedBatch(ctx, texts); err != nil {
+			return nil, nil, err
+		}
+		models = make([]string, len(generated))
+		for i := range models {
+			models[i] = e.embedder.ModelName()
+		}
+	}
+
+	if len(generated) != len(chunks) || len(models) != len(chunks) {
+		return nil, nil, fmt.Errorf("got %d embeddings for %d texts", len(generated), len(chunks))
+	}
+	return generated, models, nil
+}
+
+// saveChunkEmbeddings persists embeddings with the model that produced each.
+func (e *Engine) saveChunkEmbeddings(ctx context.Context, ids []string, embeddings [][]float32, models []string) error {
+	return store.SaveChunkEmbeddingsByModel(ctx, e.metadata, ids, embeddings, models)
+}
+
+// routeInput returns the embed.RouteInput of c.
+func (e *Engine) routeInput(c *store.Chunk) embed.RouteInput {
+	return embed.RouteInput{Text: e.embedText(c), Path: c.FilePath, Language: c.Language}
+}
+
+// reusedModel returns the model recorded for a stored embedding of c that is
+// reused: the model the embedder routes c to. Routing policies are expected
+// to pick the same model for unchanged chunks.
+func (e *Engine) reusedModel(c *store.Chunk) string {
+	return embed.ModelNameFor(e.embedder, e.routeInput(c))
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEngine_Index_RoutingEmbedderRecordsModelPerChunk(t *testing.T) {
+	// Given: an engine whose embedder routes generated files to a cheap model
+	accurate := &namedEmbedder{MockEmbedder: &MockEmbedder{}, name: "accurate"}
+	cheap := &namedEmbedder{MockEmbedder: &MockEmbedder{}, name: "cheap"}
+	router, err := embed.NewRoutingEmbedder(func(in embed.RouteInput) int {
+		if strings.HasPrefix(in.Path, "gen/") {
+			return 1
+		}
+		return 0
+	}, accurate, cheap)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	metadata := store.NewMemoryStore()
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, embed.NewCachedEmbedder(router, 10), metadata, DefaultConfig())
+
+	// When: indexing chunks from both kinds of paths
+	err = engine.Index(ctx, []*store.Chunk{
+		{ID: "core", FilePath: "internal/core.go", Content: "func Core() {}"},
+		{ID: "api", FilePath: "gen/api.pb.go", Content: "func API() {}"},
+		{ID: "main", FilePath: "cmd/main.go", Content: "func main() {}"},
+	})
+
+	// Then: each chunk's embedding is recorded under the model that made it,
+	// and the index under the composite name
+	require.NoError(t, err)
+	counts, err := metadata.GetEmbeddingModelCounts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"accurate": 2, "cheap": 1}, counts)
+	assert.Equal(t, int32(2), accurate.embedCalled.Load())
+	assert.Equal(t, int32(1), cheap.embedCalled.Load())
+	model, err := metadata.GetState(ctx, store.StateKeyIndexModel)
+	require.NoError(t, err)
+	assert.Equal(t, "routed(accurate,cheap)", model)
+}
+
+func TestEngine_IndexWithEmbeddings_RoutingEmbedderReusesStoredEmbeddings(t *testing.T) {
+	// Given: chunks indexed through a routing embedder
+	accurate := &namedEmbedder{MockEmbedder: &MockEmbedder{}, name: "accurate"}
+	cheap := &namedEmbedder{MockEmbedder: &MockEmbedder{}, name: "cheap"}
+	router, err := embed.NewRoutingEmbedder(func(in embed.RouteInput) int {
+		if strings.HasPrefix(in.Path, "gen/") {
+			return 1
+		}
+		return 0
+	}, accurate, cheap)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	metadata := store.NewMemoryStore()
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, router, metadata, DefaultConfig())
+	chunks := []*store.Chunk{
+		{ID: "core", FilePath: "internal/core.go", Content: "func Core() {}"},
+		{ID: "api", FilePath: "gen/api.pb.go", Content: "func API() {}"},
+	}
+	require.NoError(t, engine.Index(ctx, chunks))
+
+	// When: looking up their stored embeddings and re-indexing with them
+	stored, err := engine.StoredEmbeddings(ctx, []string{"core", "api"})
+	require.NoError(t, err)
+	require.NoError(t, engine.IndexWithEmbeddings(ctx, chunks, stored))
+
+	// Then: embeddings of both routed models are found and reused, and stay
+	// recorded under the model that made them
+	assert.Len(t, stored, 2)
+	assert.Equal(t, int32(1), accurate.embedCalled.Load())
+	assert.Equal(t, int32(1), cheap.embedCalled.Load())
+	counts, err := metadata.GetEmbeddingModelCounts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"accurate": 1, "cheap": 1}, counts)
+}
@@ -5,14 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math"
 	"sort"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/logging"
 	"github.com/Aman-CERP/amanmcp/internal/store"
 	"github.com/Aman-CERP/amanmcp/internal/telemetry"
 )
@@ -24,12 +29,19 @@ type Engine struct {
 	embedder   embed.Embedder
 	metadata   store.MetadataStore
 	config     EngineConfig
-	fusion     *RRFFusion
+	fusion     FusionStrategy
 	classifier Classifier              // Optional query classifier for dynamic weights
 	metrics    *telemetry.QueryMetrics // Optional query telemetry collector
 	expander   *QueryExpander          // QI-1 Lite: Code-aware query expansion for BM25
 	reranker   Reranker                // FEAT-RR1: Optional cross-encoder reranker
 	multiQuery *MultiQuerySearcher     // FEAT-QI3: Optional multi-query decomposition
+	blame      BlameProvider           // Optional git blame source for opts.IncludeBlame
+	limiter    *embedLimiter           // Embedder rate limiter (nil = unlimited)
+	queryLog   *logging.QueryLogger    // Optional per-query log sink
+	preprocess TextPreprocessor        // Optional text rewrite before embedding
+	queryCache *queryCache             // Optional search result cache (nil = disabled)
+	searches   *searchGate             // Bounds concurrent searches (EngineConfig.MaxConcurrentSearches)
+	reembed    *reembedJob             // Running re-embedding after a dimension or model change (nil = none)
 	mu         sync.RWMutex
 }
 
@@ -49,10 +61,26 @@ var ErrDimensionMismatch = errors.New("embedding dimension mismatch")
 // See: https://huggingface.co/Qwen/Qwen3-Embedding-0.6B
 const Qwen3QueryInstruction = "Instruct: Given a code search query, retrieve relevant code snippets that answer the query\nQuery:"
 
-// formatQueryForEmbedding formats a query with Qwen3 instruction prefix.
-// This improves retrieval by 1-5% according to Qwen3 documentation.
-func formatQueryForEmbedding(query string) string {
-	return Qwen3QueryInstruction + query
+// QueryInstructionForModel returns the query instruction prefix suited to
+// an embedding model, for EngineConfig.QueryInstruction: Qwen3QueryInstruction
+// for Qwen3 models, none for others.
+func QueryInstructionForModel(model string) string {
+	if strings.Contains(strings.ToLower(model), "qwen3") {
+		return Qwen3QueryInstruction
+	}
+	return ""
+}
+
+// formatQueryForEmbedding prefixes a query with the instruction for the
+// embedder that embeds it: EngineConfig.QueryInstruction for the engine's
+// own, the one QueryInstructionForModel picks for a per-query override
+// (opts.Embedder). For Qwen3 this improves retrieval by 1-5% according to
+// its documentation.
+func (e *Engine) formatQueryForEmbedding(query string, opts SearchOptions) string {
+	if opts.Embedder != nil {
+		return QueryInstructionForModel(opts.Embedder.ModelName()) + query
+	}
+	return e.config.QueryInstruction + query
 }
 
 // EngineOption configures the search engine.
@@ -75,6 +103,14 @@ func WithMetrics(m *telemetry.QueryMetrics) EngineOption {
 	}
 }
 
+// WithQueryLogger sets an optional sink that logs every query with its
+// weights, result count and latency under the "query" log category.
+func WithQueryLogger(l *logging.QueryLogger) EngineOption {
+	return func(e *Engine) {
+		e.queryLog = l
+	}
+}
+
 // WithQueryExpander sets an optional query expander for BM25 search.
 // QI-1 Lite: Expands queries with code-aware synonyms to bridge vocabulary gap.
 // When set, BM25 search uses expanded query while vector search uses original.
@@ -93,6 +129,24 @@ func WithReranker(r Reranker) EngineOption {
 	}
 }
 
+// WithFusionStrategy overrides the fusion strategy selected by EngineConfig.
+// Use this to plug in a custom FusionStrategy; nil is ignored.
+func WithFusionStrategy(f FusionStrategy) EngineOption {
+	return func(e *Engine) {
+		if f != nil {
+			e.fusion = f
+		}
+	}
+}
+
+// WithBlameProvider sets the blame source used when SearchOptions.IncludeBlame
+// is set. Without one, blame enrichment is a no-op.
+func WithBlameProvider(p BlameProvider) EngineOption {
+	return func(e *Engine) {
+		e.blame = p
+	}
+}
+
 // WithMultiQuerySearch enables multi-query decomposition for generic queries.
 // FEAT-QI3: Decomposes generic queries like "Search function" into multiple
 // specific sub-queries, runs them in parallel, and fuses results.
@@ -136,14 +190,33 @@ func NewEngine(
 	if err := config.RerankerPolicy.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid reranker policy: %w", err)
 	}
+	fusion, err := NewFusionStrategy(config.FusionStrategy, config.RRFConstant, config.FusionNormalization)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fusion strategy: %w", err)
+	}
+	if err := config.EmbedRateLimit.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid embed rate limit: %w", err)
+	}
+	if err := config.Confidence.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid confidence thresholds: %w", err)
+	}
+	if config.RerankThreshold < 0 || config.RerankThreshold > 1 {
+		return nil, fmt.Errorf("invalid rerank threshold: must be within [0, 1], got %v", config.RerankThreshold)
+	}
+	if config.MaxLimit <= 0 {
+		config.MaxLimit = DefaultConfig().MaxLimit
+	}
 	e := &Engine{
 		bm25:     bm25,
 		vector:   vector,
 		embedder: embedder,
 		metadata: metadata,
 		config:   config,
-		fusion:   NewRRFFusionWithK(config.RRFConstant),
+		fusion:   fusion,
+		limiter:  newEmbedLimiter(config.EmbedRateLimit),
+		searches: newSearchGate(config.MaxConcurrentSearches),
 	}
+	e.queryCache = newQueryCache(config.QueryCacheSize, config.QueryCacheTTL)
 	for _, opt := range opts {
 		opt(e)
 	}
@@ -167,23 +240,52 @@ func New(
 	return e
 }
 
-// Search executes a hybrid search combining BM25 and semantic search.
-// It runs both searches in parallel and fuses results using Reciprocal Rank Fusion (RRF).
-//
-// FEAT-QI3: If multi-query search is enabled and the query benefits from
-// decomposition, this method delegates to MultiQuerySearcher which runs
-// multiple sub-queries in parallel and fuses results with consensus boosting.
-func (e *Engine) Search(ctx context.Context, query string, opts SearchOptions) ([]*SearchResult, error) {
+// search runs the search pipeline behind Search, without the query cache.
+func (e *Engine) search(ctx context.Context, query string, opts SearchOptions) ([]*SearchResult, error) {
+	if err := e.searches.acquire(ctx); err != nil {
+		return nil, fmt.Errorf("failed to acquire search slot: %w", err)
+	}
+	defer e.searches.release()
+
+	return e.searchHoldingSlot(ctx, query, opts)
+}
+
+// searchHoldingSlot is search for callers that already hold a search slot.
+func (e *Engine) searchHoldingSlot(ctx context.Context, query string, opts SearchOptions) ([]*SearchResult, error) {
 	start := time.Now()
 
 	// Normalize query
 	query = strings.TrimSpace(query)
 	if query == "" {
+		recordSearchDiagnostics(opts, SearchDiagnostics{NoResultsReason: NoResultsEmptyQuery})
 		return nil, nil
 	}
 
+	if e.config.StrictOptions {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if e.config.StrictLimits {
+		if err := e.checkLimits(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	// Boolean mode: BM25 evaluates the expression, later stages see its terms
+	var boolQuery *store.BooleanQuery
+	if opts.BooleanQuery {
+		parsed, err := e.parseBooleanQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		boolQuery = parsed
+		query = strings.Join(parsed.Terms(), " ")
+	}
+
 	// FEAT-QI3: Check if multi-query decomposition should be used
-	if e.multiQuery != nil && e.multiQuery.decomposer.ShouldDecompose(query) {
+	if boolQuery == nil && !opts.PathsOnly && !usesPrefixSearch(query, nil, opts.PrefixMatch) &&
+		e.multiQuery != nil && e.multiQuery.decomposer.ShouldDecompose(query) {
 		return e.multiQuerySearch(ctx, query, opts, start)
 	}
 
@@ -204,11 +306,15 @@ func (e *Engine) Search(ctx context.Context, query string, opts SearchOptions) (
 	// Apply defaults
 	opts = e.applyDefaults(opts)
 
+	if opts.PathsOnly {
+		return e.searchPaths(ctx, query, boolQuery, opts, start)
+	}
+
 	// FEAT-DIM1: Explicit BM25-only mode (user requested via --bm25-only flag)
 	if opts.BM25Only {
 		slog.Info("bm25_only mode enabled (user requested)")
-		candidateLimit := candidateLimitForOptions(query, opts)
-		bm25Results, bm25Err := e.bm25.Search(ctx, query, candidateLimit)
+		candidateLimit := e.candidateLimit(query, opts)
+		bm25Results, bm25Err := e.searchBM25(ctx, query, boolQuery, opts.PrefixMatch, candidateLimit)
 		if bm25Err != nil {
 			return nil, fmt.Errorf("BM25 search failed: %w", bm25Err)
 		}
@@ -233,37 +339,58 @@ func (e *Engine) Search(ctx context.Context, query string, opts SearchOptions) (
 			return nil, err
 		}
 		// FEAT-QI5: Enrich with adjacent context if requested
-		e.enrichResultsWithAdjacent(ctx, enriched, opts.AdjacentChunks, 5)
+		e.enrichAdjacent(ctx, enriched, opts)
 		// TASK-SYN42: Exact lexical lookups should rank definitions above references.
 		enriched = ApplyExactMatchBoost(enriched, query)
 		enriched = ApplyPDFContentBoost(enriched, query)
-		// FEAT-QI4: Apply test file penalty to prioritize real implementations
-		enriched = ApplyTestFilePenalty(enriched)
+		// FEAT-QI4: Demote test files to prioritize real implementations,
+		// or drop them or keep only them as opts.IncludeTests asks
+		enriched = ApplyTestFileMode(enriched, opts.IncludeTests)
 		// BUG-066: Apply path boost to prioritize internal/ over cmd/
 		enriched = ApplyPathBoost(enriched)
+		if opts.BoostPathMatch {
+			enriched = ApplyPathMatchBoost(enriched, query)
+		}
 		// F39: Apply authority/freshness boost after path boosts.
 		enriched = ApplyAuthorityBoost(enriched)
-		filtered := ApplyFilters(enriched, opts)
+		filtered := e.mergeContiguous(ApplyFilters(enriched, opts), opts)
 		if len(filtered) > opts.Limit {
 			filtered = filtered[:opts.Limit]
 		}
+		filtered = SortResults(filtered, opts.SortBy)
 		// FEAT-UNIX3: Attach explain data for debugging
 		e.attachExplainData(filtered, query, opts, len(bm25Results), 0, false, nil)
+		e.attachBlame(ctx, filtered, opts)
+		e.attachSalientTerms(ctx, filtered, opts)
+		setResultSizes(filtered)
+		ClassifyConfidence(filtered, e.config.Confidence)
+		recordSearchDiagnostics(opts, SearchDiagnostics{
+			BM25ResultCount: len(bm25Results),
+			CandidateCount:  len(enriched),
+			ResultCount:     len(filtered),
+		})
 		e.recordMetrics(query, QueryTypeLexical, len(filtered), time.Since(start))
+		e.logQuery(ctx, query, Weights{BM25: 1.0}, len(filtered), time.Since(start))
 		return filtered, nil
 	}
 
 	// QW-5: Validate embedder dimensions match indexed dimensions
-	if err := e.validateDimensions(ctx); err != nil {
-		// FEAT-DIM1: Enhanced warning with recovery options
-		slog.Warn("dimension mismatch detected, semantic search disabled",
-			slog.String("error", err.Error()),
-			slog.String("recovery_1", "amanmcp reindex --force"),
-			slog.String("recovery_2", "amanmcp search --bm25-only"),
-			slog.String("info", "amanmcp index info"))
+	if dimErr := e.validateQueryDimensions(ctx, opts); dimErr != nil {
+		if opts.Embedder != nil {
+			// Per-query override: the index is fine, only this query degrades
+			slog.Info("query embedder dimension mismatch, semantic search disabled",
+				slog.String("error", dimErr.Error()))
+		} else {
+			// FEAT-DIM1: Enhanced warning with recovery options
+			slog.Warn("dimension mismatch detected, semantic search disabled",
+				slog.String("error", dimErr.Error()),
+				slog.String("recovery_1", "amanmcp reindex --force"),
+				slog.String("recovery_2", "amanmcp search --bm25-only"),
+				slog.String("info", "amanmcp index info"))
+		}
 		// Skip vector search entirely - return BM25 results only
-		candidateLimit := candidateLimitForOptions(query, opts)
-		bm25Results, bm25Err := e.bm25.Search(ctx, query, candidateLimit)
+		candidateLimit := e.candidateLimit(query, opts)
+		bm25Results, bm25Err := e.searchBM25(ctx, query, boolQuery, opts.PrefixMatch, candidateLimit)
 		if bm25Err != nil {
 			return nil, fmt.Errorf("BM25 search failed (semantic disabled due to dimension mismatch): %w", bm25Err)
 		}
@@ -288,29 +415,48 @@ func (e *Engine) Search(ctx context.Context, query string, opts SearchOptions) (
 			return nil, err
 		}
 		// FEAT-QI5: Enrich with adjacent context if requested
-		e.enrichResultsWithAdjacent(ctx, enriched, opts.AdjacentChunks, 5)
+		e.enrichAdjacent(ctx, enriched, opts)
 		// TASK-SYN42: Exact lexical lookups should rank definitions above references.
 		enriched = ApplyExactMatchBoost(enriched, query)
 		enriched = ApplyPDFContentBoost(enriched, query)
-		// FEAT-QI4: Apply test file penalty to prioritize real implementations
-		enriched = ApplyTestFilePenalty(enriched)
+		// FEAT-QI4: Demote test files to prioritize real implementations,
+		// or drop them or keep only them as opts.IncludeTests asks
+		enriched = ApplyTestFileMode(enriched, opts.IncludeTests)
 		// BUG-066: Apply path boost to prioritize internal/ over cmd/
 		enriched = ApplyPathBoost(enriched)
+		if opts.BoostPathMatch {
+			enriched = ApplyPathMatchBoost(enriched, query)
+		}
 		// F39: Apply authority/freshness boost after path boosts.
 		enriched = ApplyAuthorityBoost(enriched)
-		filtered := ApplyFilters(enriched, opts)
+		filtered := e.mergeContiguous(ApplyFilters(enriched, opts), opts)
 		if len(filtered) > opts.Limit {
 			filtered = filtered[:opts.Limit]
 		}
+		filtered = SortResults(filtered, opts.SortBy)
 		// FEAT-UNIX3: Attach explain data with dimension mismatch flag
 		e.attachExplainData(filtered, query, opts, len(bm25Results), 0, true, nil)
+		if len(filtered) > 0 && filtered[0].Explain != nil {
+			filtered[0].Explain.Note = "semantic search skipped: " + dimErr.Error()
+		}
+		e.attachBlame(ctx, filtered, opts)
+		e.attachSalientTerms(ctx, filtered, opts)
+		setResultSizes(filtered)
+		ClassifyConfidence(filtered, e.config.Confidence)
+		recordSearchDiagnostics(opts, SearchDiagnostics{
+			BM25ResultCount:   len(bm25Results),
+			CandidateCount:    len(enriched),
+			ResultCount:       len(filtered),
+			DimensionMismatch: true,
+		})
 		e.recordMetrics(query, QueryTypeLexical, len(filtered), time.Since(start))
+		e.logQuery(ctx, query, Weights{BM25: 1.0}, len(filtered), time.Since(start))
 		return filtered, nil
 	}
 
 	// Run searches in parallel
-	candidateLimit := candidateLimitForOptions(query, opts)
-	bm25Results, vecResults, searchErr := e.parallelSearch(ctx, query, candidateLimit)
+	candidateLimit := e.candidateLimit(query, opts)
+	bm25Results, vecResults, searchErr := e.parallelSearch(ctx, query, boolQuery, candidateLimit, opts)
 
 	// Handle graceful degradation
 	if searchErr != nil {
@@ -347,31 +493,49 @@ func (e *Engine) Search(ctx context.Context, query string, opts SearchOptions) (
 	}
 
 	// FEAT-QI5: Enrich with adjacent context if requested
-	e.enrichResultsWithAdjacent(ctx, enriched, opts.AdjacentChunks, 5)
+	e.enrichAdjacent(ctx, enriched, opts)
 
 	// TASK-SYN42: Exact lexical lookups should rank definitions above references.
 	enriched = ApplyExactMatchBoost(enriched, query)
 	enriched = ApplyPDFContentBoost(enriched, query)
-	// FEAT-QI4: Apply test file penalty to prioritize real implementations
-	enriched = ApplyTestFilePenalty(enriched)
+	// FEAT-QI4: Demote test files to prioritize real implementations,
+	// or drop them or keep only them as opts.IncludeTests asks
+	enriched = ApplyTestFileMode(enriched, opts.IncludeTests)
 	// BUG-066: Apply path boost to prioritize internal/ over cmd/
 	enriched = ApplyPathBoost(enriched)
+	if opts.BoostPathMatch {
+		enriched = ApplyPathMatchBoost(enriched, query)
+	}
 	// F39: Apply authority/freshness boost after path boosts.
 	enriched = ApplyAuthorityBoost(enriched)
 
 	// Apply filters after enrichment (need chunk metadata)
 	filtered := ApplyFilters(enriched, opts)
+	filtered = e.mergeContiguous(filtered, opts)
 
 	// Apply limit
 	if len(filtered) > opts.Limit {
 		filtered = filtered[:opts.Limit]
 	}
+	filtered = SortResults(filtered, opts.SortBy)
 
 	// FEAT-UNIX3: Attach explain data for debugging
 	e.attachExplainData(filtered, query, opts, len(bm25Results), len(vecResults), false, nil)
+	e.attachBlame(ctx, filtered, opts)
+	e.attachSalientTerms(ctx, filtered, opts)
+	setResultSizes(filtered)
+	ClassifyConfidence(filtered, e.config.Confidence)
+	recordSearchDiagnostics(opts, SearchDiagnostics{
+		BM25ResultCount:     len(bm25Results),
+		VectorResultCount:   len(vecResults),
+		CandidateCount:      len(enriched),
+		ResultCount:         len(filtered),
+		SemanticUnavailable: searchErr != nil && vecResults == nil,
+	})
 
 	// Record telemetry
 	e.recordMetrics(query, e.classifyQueryType(ctx, query, opts), len(filtered), time.Since(start))
+	e.logQuery(ctx, query, *opts.Weights, len(filtered), time.Since(start))
 
 	return filtered, nil
 }
@@ -385,7 +549,7 @@ func candidateLimitForOptions(query string, opts SearchOptions) int {
 	if resultLimit <= 0 {
 		resultLimit = DefaultConfig().DefaultLimit
 	}
-	baseLimit := resultLimit * 2
+	baseLimit := resultLimit * candidateMultiplier(opts)
 	if !shouldBroadenCandidatePool(query, opts) {
 		return baseLimit
 	}
@@ -393,11 +557,58 @@ func candidateLimitForOptions(query string, opts SearchOptions) int {
 		return baseLimit
 	}
 
-	exactLimit := resultLimit * 10
-	if exactLimit < 50 {
-		return 50
+	exactLimit := max(resultLimit*10, 50)
+	return max(exactLimit, baseLimit)
+}
+
+// candidateLimit returns the candidate pool size for query, capped at
+// EngineConfig.MaxCandidates.
+func (e *Engine) candidateLimit(query string, opts SearchOptions) int {
+	limit := candidateLimitForOptions(query, opts)
+	if maxCandidates := e.maxCandidates(); limit > maxCandidates {
+		slog.Debug("candidate_limit_clamped",
+			slog.Int("requested", limit),
+			slog.Int("max_candidates", maxCandidates))
+		return maxCandidates
+	}
+	return limit
+}
+
+// maxCandidates returns EngineConfig.MaxCandidates, defaulted.
+func (e *Engine) maxCandidates() int {
+	if e.config.MaxCandidates <= 0 {
+		return DefaultMaxCandidates
+	}
+	return e.config.MaxCandidates
+}
+
+// checkLimits reports, for EngineConfig.StrictLimits, a Limit above MaxLimit
+// or a candidate pool (Limit times the candidate multiplier) above
+// MaxCandidates.
+func (e *Engine) checkLimits(opts SearchOptions) error {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = e.config.DefaultLimit
+	}
+	if limit > e.config.MaxLimit {
+		return fmt.Errorf("%w: limit %d is above the maximum of %d", ErrLimitExceeded, limit, e.config.MaxLimit)
 	}
-	return exactLimit
+	multiplier := candidateMultiplier(opts)
+	if pool := limit * multiplier; pool > e.maxCandidates() {
+		return fmt.Errorf("%w: limit %d with candidate multiplier %d needs %d candidates, above the maximum of %d",
+			ErrLimitExceeded, limit, multiplier, pool, e.maxCandidates())
+	}
+	return nil
+}
+
+// candidateMultiplier returns opts.CandidateMultiplier, defaulted and
+// clamped to [1, MaxCandidateMultiplier].
+func candidateMultiplier(opts SearchOptions) int {
+	m := opts.CandidateMultiplier
+	if m <= 0 {
+		return DefaultCandidateMultiplier
+	}
+	return min(m, MaxCandidateMultiplier)
 }
 
 func shouldUseBaseLimitForExpandedContentFilter(query string, opts SearchOptions, resultLimit int) bool {
@@ -456,6 +667,33 @@ func (e *Engine) attachExplainData(results []*SearchResult, query string, opts S
 		MultiQueryDecomposed: len(subQueries) > 0,
 		SubQueries:           subQueries,
 	}
+	if len(subQueries) > 0 {
+		counts := make(map[string]int, len(subQueries))
+		for _, sq := range subQueries {
+			counts[sq] = 0
+		}
+		for _, r := range results {
+			for _, sq := range r.MatchedSubQueries {
+				counts[sq]++
+			}
+		}
+		results[0].Explain.SubQueryResultCounts = counts
+	}
+	if opts.Embedder != nil {
+		results[0].Explain.QueryEmbedder = opts.Embedder.ModelName()
+	}
+	if opts.RerankerStatus != nil {
+		results[0].Explain.Reranker = *opts.RerankerStatus
+	}
+}
+
+// queryEmbedder returns the embedder for the query vector: the per-query
+// override if set, otherwise the engine's embedder.
+func (e *Engine) queryEmbedder(opts SearchOptions) embed.Embedder {
+	if opts.Embedder != nil {
+		return opts.Embedder
+	}
+	return e.embedder
 }
 
 // recordMetrics records query telemetry if metrics collector is configured.
@@ -472,6 +710,17 @@ func (e *Engine) recordMetrics(query string, queryType QueryType, resultCount in
 	})
 }
 
+// logQuery writes the query to the query log if one is configured.
+func (e *Engine) logQuery(ctx context.Context, query string, weights Weights, resultCount int, latency time.Duration) {
+	e.queryLog.Log(ctx, logging.QueryRecord{
+		Query:          query,
+		BM25Weight:     weights.BM25,
+		SemanticWeight: weights.Semantic,
+		ResultCount:    resultCount,
+		Latency:        latency,
+	})
+}
+
 // classifyQueryType determines the query type based on classifier or weights.
 func (e *Engine) classifyQueryType(ctx context.Context, query string, opts SearchOptions) QueryType {
 	// If weights are explicitly set, determine type from them
@@ -497,36 +746,167 @@ func (e *Engine) classifyQueryType(ctx context.Context, query string, opts Searc
 	return QueryTypeMixed
 }
 
+// indexPipelineBatchSize is the number of chunks embedded per batch when
+// EngineConfig.PipelineIndexing is set.
+const indexPipelineBatchSize = 64
+
 // Index adds chunks to both BM25 and vector indices.
 func (e *Engine) Index(ctx context.Context, chunks []*store.Chunk) error {
+	return e.IndexWithEmbeddings(ctx, chunks, nil)
+}
+
+// IndexWithEmbeddings indexes chunks like Index, but uses the embedding in
+// reuse for any chunk ID it contains instead of embedding the chunk again.
+// Only the remaining chunks are sent to the embedder. Callers must only pass
+// embeddings produced by the current embedder for identical content (see
+// StoredEmbeddings).
+func (e *Engine) IndexWithEmbeddings(ctx context.Context, chunks []*store.Chunk, reuse map[string][]float32) error {
 	if len(chunks) == 0 {
 		return nil
 	}
 
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	defer e.queryCache.invalidate()
 
-	// Prepare documents for BM25
-	docs := make([]*store.Document, len(chunks))
-	for i, c := range chunks {
-		docs[i] = &store.Document{
-			ID:      c.ID,
-			Content: store.BM25DocumentContent(c.FilePath, c.Content),
+	// The vector store is rebuilt at the new dimension in the background;
+	// these chunks go straight into it
+	reembedding, err := e.reembedIfDimensionChanged(ctx)
+	if err != nil {
+		return err
+	}
+	if !reembedding {
+		if reembedding, err = e.reembedIfModelChanged(ctx); err != nil {
+			return err
 		}
 	}
 
-	// Generate embeddings
-	texts := make([]string, len(chunks))
-	for i, c := range chunks {
-		texts[i] = c.Content
+	if e.config.PipelineIndexing && len(chunks) > indexPipelineBatchSize {
+		err = e.indexPipelined(ctx, chunks, reuse)
+	} else {
+		err = e.indexChunks(ctx, chunks, reuse)
+	}
+	if err != nil {
+		return err
+	}
+
+	// QW-5: Store embedding dimension and model for mismatch detection.
+	// A running re-embedding stores them once the whole index is converted.
+	if !reembedding {
+		if err := e.storeIndexEmbeddingInfo(ctx); err != nil {
+			slog.Warn("failed to store index embedding info",
+				slog.String("error", err.Error()))
+		}
 	}
 
-	embeddings, err := e.embedder.EmbedBatch(ctx, texts)
+	return nil
+}
+
+// indexChunks embeds chunks, except those with an embedding in reuse, and
+// stores them.
+func (e *Engine) indexChunks(ctx context.Context, chunks []*store.Chunk, reuse map[string][]float32) error {
+	embeddings, models, err := e.embedChunks(ctx, chunks, reuse)
 	if err != nil {
-		return fmt.Errorf("generate embeddings: %w", err)
+		return err
+	}
+	return e.storeChunks(ctx, chunks, embeddings, models)
+}
+
+// indexPipelined indexes chunks like indexChunks, in batches of
+// indexPipelineBatchSize: one goroutine embeds the next batch while the
+// caller stores the previous one, overlapping network-bound embedding with
+// disk-bound writes. At most one embedded batch waits to be stored. The
+// first embed or store error stops both sides and is returned; batches
+// stored before it stay indexed.
+func (e *Engine) indexPipelined(ctx context.Context, chunks []*store.Chunk, reuse map[string][]float32) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type embeddedBatch struct {
+		chunks     []*store.Chunk
+		embeddings [][]float32
+		models     []string
+	}
+	batches := make(chan embeddedBatch, 1)
+
+	// embedErr is written before batches is closed and read after
+	var embedErr error
+	go func() {
+		defer close(batches)
+		for lo := 0; lo < len(chunks); lo += indexPipelineBatchSize {
+			if err := ctx.Err(); err != nil {
+				embedErr = err
+				return
+			}
+			batch := chunks[lo:min(lo+indexPipelineBatchSize, len(chunks))]
+			embeddings, models, err := e.embedChunks(ctx, batch, reuse)
+			if err != nil {
+				embedErr = err
+				return
+			}
+			select {
+			case batches <- embeddedBatch{chunks: batch, embeddings: embeddings, models: models}:
+			case <-ctx.Done():
+				embedErr = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	for b := range batches {
+		if err := e.storeChunks(ctx, b.chunks, b.embeddings, b.models); err != nil {
+			cancel()
+			for range batches {
+				// Wait for the embedder goroutine to stop
+			}
+			return err
+		}
 	}
+	return embedErr
+}
 
+// embedChunks returns the embeddings of chunks in order, taking them from
+// reuse where present and embedding the rest in one batch, together with
+// the model that produced each embedding.
+func (e *Engine) embedChunks(ctx context.Context, chunks []*store.Chunk, reuse map[string][]float32) ([][]float32, []string, error) {
+	embeddings := make([][]float32, len(chunks))
+	models := make([]string, len(chunks))
+	var pending []*store.Chunk
+	var pendingIdx []int
+	for i, c := range chunks {
+		if emb, ok := reuse[c.ID]; ok {
+			embeddings[i] = emb
+			models[i] = e.reusedModel(c)
+			continue
+		}
+		pending = append(pending, c)
+		pendingIdx = append(pendingIdx, i)
+	}
+
+	if len(pending) > 0 {
+		generated, generatedModels, err := e.embedChunkTexts(ctx, pending)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generate embeddings: %w", err)
+		}
+		for j, i := range pendingIdx {
+			embeddings[i] = generated[j]
+			models[i] = generatedModels[j]
+		}
+	}
+	return embeddings, models, nil
+}
+
+// storeChunks adds embedded chunks to the BM25 index, the vector store and
+// the metadata store. models holds the model that produced each embedding.
+func (e *Engine) storeChunks(ctx context.Context, chunks []*store.Chunk, embeddings [][]float32, models []string) error {
 	// Index in BM25
+	docs := make([]*store.Document, len(chunks))
+	for i, c := range chunks {
+		docs[i] = &store.Document{
+			ID:      c.ID,
+			Content: store.BM25DocumentContent(c.FilePath, c.Content),
+		}
+	}
 	if err := e.bm25.Index(ctx, docs); err != nil {
 		return fmt.Errorf("index in BM25: %w", err)
 	}
@@ -547,20 +927,59 @@ func (e *Engine) Index(ctx context.Context, chunks []*store.Chunk) error {
 	}
 
 	// Persist embeddings in SQLite for future compaction (BUG-024 fix)
-	if err := e.metadata.SaveChunkEmbeddings(ctx, ids, embeddings, e.embedder.ModelName()); err != nil {
+	if err := e.saveChunkEmbeddings(ctx, ids, embeddings, models); err != nil {
 		// Log warning but don't fail - embeddings can be regenerated
 		slog.Warn("failed to persist embeddings, compaction will require re-embedding",
 			slog.String("error", err.Error()),
 			slog.Int("count", len(ids)))
 	}
+	return nil
+}
+
+// StoredEmbeddings returns the persisted embeddings of the given chunks that
+// were produced by the current embedder, keyed by chunk ID; for an
+// embed.InputRouter, by any of its routed models. Chunks without one are
+// omitted. Metadata stores without store.ChunkEmbeddingGetter fall back to
+// GetAllEmbeddings, keeping only vectors of the current dimension.
+func (e *Engine) StoredEmbeddings(ctx context.Context, ids []string) (map[string][]float32, error) {
+	if getter, ok := e.metadata.(store.ChunkEmbeddingGetter); ok {
+		models := embed.ModelNamesFor(e.embedder)
+		if len(models) == 1 {
+			return getter.GetChunkEmbeddings(ctx, ids, models[0])
+		}
+		result := make(map[string][]float32, len(ids))
+		for _, model := range models {
+			stored, err := getter.GetChunkEmbeddings(ctx, ids, model)
+			if err != nil {
+				return nil, err
+			}
+			maps.Copy(result, stored)
+		}
+		return result, nil
+	}
 
-	// QW-5: Store embedding dimension and model for mismatch detection
-	if err := e.storeIndexEmbeddingInfo(ctx); err != nil {
-		slog.Warn("failed to store index embedding info",
-			slog.String("error", err.Error()))
+	// Without per-chunk model information, require the index to have been
+	// built by the current embedder
+	model, err := e.metadata.GetState(ctx, store.StateKeyIndexModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index model: %w", err)
+	}
+	if model != e.embedder.ModelName() {
+		return map[string][]float32{}, nil
 	}
 
-	return nil
+	all, err := e.metadata.GetAllEmbeddings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embeddings: %w", err)
+	}
+	dims := e.embedder.Dimensions()
+	result := make(map[string][]float32, len(ids))
+	for _, id := range ids {
+		if emb, ok := all[id]; ok && len(emb) == dims {
+			result[id] = emb
+		}
+	}
+	return result, nil
 }
 
 // storeIndexEmbeddingInfo saves the current embedder's dimension and model to metadata.
@@ -582,6 +1001,13 @@ func (e *Engine) storeIndexEmbeddingInfo(ctx context.Context) error {
 // QW-5: Returns ErrDimensionMismatch if embedder changed (e.g., Ollama → Static768 fallback).
 // Returns nil if no index dimension stored (first-time indexing) or dimensions match.
 func (e *Engine) validateDimensions(ctx context.Context) error {
+	return e.validateQueryDimensions(ctx, SearchOptions{})
+}
+
+// validateQueryDimensions checks the query embedder's dimension against the
+// indexed dimension. A per-query override (opts.Embedder) gets a message
+// without reindex advice, since the index itself is fine.
+func (e *Engine) validateQueryDimensions(ctx context.Context, opts SearchOptions) error {
 	storedDim, err := e.metadata.GetState(ctx, store.StateKeyIndexDimension)
 	if err != nil || storedDim == "" {
 		// No stored dimension - first time or legacy index, allow search
@@ -595,10 +1021,15 @@ func (e *Engine) validateDimensions(ctx context.Context) error {
 		return nil
 	}
 
-	currentDim := e.embedder.Dimensions()
+	emb := e.queryEmbedder(opts)
+	currentDim := emb.Dimensions()
 	if indexDim != currentDim {
 		storedModel, _ := e.metadata.GetState(ctx, store.StateKeyIndexModel)
-		currentModel := e.embedder.ModelName()
+		currentModel := emb.ModelName()
+		if opts.Embedder != nil {
+			return fmt.Errorf("%w: index has %d dimensions (%s), but query embedder has %d dimensions (%s)",
+				ErrDimensionMismatch, indexDim, storedModel, currentDim, currentModel)
+		}
 		return fmt.Errorf("%w: index has %d dimensions (%s), but current embedder has %d dimensions (%s). Run 'amanmcp reindex --force' to rebuild with current embedder",
 			ErrDimensionMismatch, indexDim, storedModel, currentDim, currentModel)
 	}
@@ -614,6 +1045,7 @@ func (e *Engine) Delete(ctx context.Context, chunkIDs []string) error {
 
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	defer e.queryCache.invalidate()
 
 	// BUG-023 fix: Use best-effort delete pattern.
 	// Metadata is the source of truth - orphans in BM25/Vector are
@@ -658,11 +1090,34 @@ func (e *Engine) Stats() *EngineStats {
 	return &EngineStats{
 		BM25Stats:   e.bm25.Stats(),
 		VectorCount: e.vector.Count(),
+		Concurrency: e.SearchConcurrency(),
+	}
+}
+
+// RecomputeBM25Stats resyncs BM25 corpus statistics (document count, total
+// tokens, average length) from the stored postings. Indexes that do not
+// support recomputation return their current Stats unchanged.
+func (e *Engine) RecomputeBM25Stats(ctx context.Context) (*store.IndexStats, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.queryCache.invalidate()
+
+	recomputer, ok := e.bm25.(store.BM25StatsRecomputer)
+	if !ok {
+		return e.bm25.Stats(), nil
+	}
+	stats, err := recomputer.RecomputeStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recompute BM25 stats: %w", err)
 	}
+	return stats, nil
 }
 
-// Close releases all resources.
+// Close releases all resources. A running re-embedding is stopped first and
+// resumes from its checkpoint on the next Index.
 func (e *Engine) Close() error {
+	e.stopReembed()
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
@@ -710,6 +1165,13 @@ func (e *Engine) applyDefaults(opts SearchOptions) SearchOptions {
 	if len(opts.ProfileRules.Profiles) == 0 {
 		opts.ProfileRules = DefaultProfileRules()
 	}
+	if opts.RerankThreshold == 0 {
+		opts.RerankThreshold = e.config.RerankThreshold
+	}
+	if opts.Explain && opts.RerankerStatus == nil {
+		// Collected for ExplainData.Reranker
+		opts.RerankerStatus = &RerankerStatus{}
+	}
 
 	return opts
 }
@@ -741,6 +1203,27 @@ func recordQueryClassification(opts SearchOptions, classification QueryClassific
 	*opts.QueryClassification = classification
 }
 
+// recordSearchDiagnostics fills opts.Diagnostics, deriving NoResultsReason
+// from the stage counts unless the caller already set one.
+func recordSearchDiagnostics(opts SearchOptions, diag SearchDiagnostics) {
+	if opts.Diagnostics == nil {
+		return
+	}
+	if diag.NoResultsReason == "" {
+		diag.NoResultsReason = diag.reason()
+	}
+	*opts.Diagnostics = diag
+}
+
+// isClearWinner reports whether the top fused result leads the runner-up
+// by at least threshold of its own score. A zero threshold never matches.
+func isClearWinner(fused []*fusedResult, threshold float64) bool {
+	if threshold <= 0 || len(fused) < 2 || fused[0].rrfScore <= 0 {
+		return false
+	}
+	return (fused[0].rrfScore-fused[1].rrfScore)/fused[0].rrfScore >= threshold
+}
+
 func recordRerankerStatus(opts SearchOptions, status RerankerStatus) {
 	if opts.RerankerStatus == nil {
 		return
@@ -755,7 +1238,11 @@ func recordRerankerStatus(opts SearchOptions, status RerankerStatus) {
 // uses original query. Embedding models handle semantic similarity natively,
 // so expansion can hurt precision by adding noise. BM25 benefits from expansion
 // because it matches exact keywords.
-func (e *Engine) parallelSearch(ctx context.Context, query string, limit int) (
+//
+// A non-nil boolQuery replaces the BM25 query and is not expanded; query then
+// holds its terms for vector search. Prefix queries are not expanded either, since
+// synonyms would be matched as prefixes too.
+func (e *Engine) parallelSearch(ctx context.Context, query string, boolQuery *store.BooleanQuery, limit int, opts SearchOptions) (
 	bm25Results []*store.BM25Result,
 	vecResults []*store.VectorResult,
 	err error,
@@ -768,7 +1255,7 @@ func (e *Engine) parallelSearch(ctx context.Context, query string, limit int) (
 	// BM25 matches exact keywords, so synonyms help (e.g., "function" → "func method")
 	// Vector search uses original query - embedding model handles semantic similarity
 	bm25Query := query
-	if e.expander != nil {
+	if e.expander != nil && boolQuery == nil && !usesPrefixSearch(query, nil, opts.PrefixMatch) {
 		bm25Query = e.expander.Expand(query)
 		if bm25Query != query {
 			slog.Debug("query expanded for BM25",
@@ -780,7 +1267,7 @@ func (e *Engine) parallelSearch(ctx context.Context, query string, limit int) (
 	// BM25 search (with expanded query)
 	g.Go(func() error {
 		var searchErr error
-		bm25Results, searchErr = e.bm25.Search(gctx, bm25Query, limit)
+		bm25Results, searchErr = e.searchBM25(gctx, bm25Query, boolQuery, opts.PrefixMatch, limit)
 		if searchErr != nil {
 			bm25Err = searchErr
 			// Don't return error - allow vector search to continue
@@ -788,12 +1275,12 @@ func (e *Engine) parallelSearch(ctx context.Context, query string, limit int) (
 		return nil
 	})
 
-	// Vector search with Qwen3 query instruction format
-	// Per Qwen3 docs: queries need instruction prefix, documents don't
+	// Vector search with the configured query instruction
+	// Instruction-tuned embedders (e.g. Qwen3) want it on queries, not documents
 	var queryEmbedding []float32 // Captured for telemetry (SPIKE-004)
 	g.Go(func() error {
-		formattedQuery := formatQueryForEmbedding(query)
-		embedding, embedErr := e.embedder.Embed(gctx, formattedQuery)
+		formattedQuery := e.formatQueryForEmbedding(query, opts)
+		embedding, embedErr := e.embed(gctx, e.queryEmbedder(opts), formattedQuery)
 		if embedErr != nil {
 			vecErr = embedErr
 			return nil // Don't fail the group
@@ -801,7 +1288,7 @@ func (e *Engine) parallelSearch(ctx context.Context, query string, limit int) (
 		queryEmbedding = embedding // Capture for semantic similarity tracking
 
 		var searchErr error
-		vecResults, searchErr = e.vector.Search(gctx, embedding, limit)
+		vecResults, searchErr = e.vectorSearch(gctx, embedding, limit, opts.VectorEf)
 		if searchErr != nil {
 			vecErr = searchErr
 		}
@@ -814,8 +1301,9 @@ func (e *Engine) parallelSearch(ctx context.Context, query string, limit int) (
 		return nil, nil, waitErr
 	}
 
-	// Record embedding for semantic similarity sampling (SPIKE-004)
-	if e.metrics != nil && len(queryEmbedding) > 0 {
+	// Record embedding for semantic similarity sampling (SPIKE-004).
+	// Override embeddings live in a different space and are not sampled.
+	if e.metrics != nil && len(queryEmbedding) > 0 && opts.Embedder == nil {
 		e.metrics.RecordQueryEmbedding(queryEmbedding)
 	}
 
@@ -834,25 +1322,39 @@ func (e *Engine) parallelSearch(ctx context.Context, query string, limit int) (
 	return bm25Results, vecResults, err
 }
 
+// vectorSearch runs a vector search, passing a per-query HNSW ef to stores
+// that support it. ef <= 0 uses the store's configured search width.
+func (e *Engine) vectorSearch(ctx context.Context, query []float32, k, ef int) ([]*store.VectorResult, error) {
+	if ef > 0 {
+		if es, ok := e.vector.(store.EfSearcher); ok {
+			return es.SearchWithEf(ctx, query, k, ef)
+		}
+	}
+	return e.vector.Search(ctx, query, k)
+}
+
 // fusedResult holds intermediate fusion state.
 type fusedResult struct {
 	chunkID      string
 	rrfScore     float64 // Normalized RRF score (0-1)
+	rawScore     float64 // Fusion score before normalization
 	bm25Score    float64
 	vecScore     float64
 	bm25Rank     int
 	vecRank      int
 	inBothLists  bool
 	matchedTerms []string
+
+	matchedSubQueries []string // Multi-query provenance (see MultiFusedResult)
 }
 
-// fuseResults combines BM25 and vector results using Reciprocal Rank Fusion (RRF).
+// fuseResults combines BM25 and vector results using the configured fusion
+// strategy (Reciprocal Rank Fusion by default).
 func (e *Engine) fuseResults(
 	bm25Results []*store.BM25Result,
 	vecResults []*store.VectorResult,
 	weights *Weights,
 ) []*fusedResult {
-	// Use RRF fusion
 	rrfResults := e.fusion.Fuse(bm25Results, vecResults, *weights)
 
 	// Convert to internal fusedResult type
@@ -861,6 +1363,7 @@ func (e *Engine) fuseResults(
 		results[i] = &fusedResult{
 			chunkID:      r.ChunkID,
 			rrfScore:     r.RRFScore,
+			rawScore:     r.RawScore,
 			bm25Score:    r.BM25Score,
 			vecScore:     r.VecScore,
 			bm25Rank:     r.BM25Rank,
@@ -905,6 +1408,7 @@ func (e *Engine) enrichResults(ctx context.Context, fused []*fusedResult) ([]*Se
 		result := &SearchResult{
 			Chunk:          chunk,
 			Score:          f.rrfScore, // Use pre-calculated RRF score (already normalized 0-1)
+			RawScore:       f.rawScore,
 			BM25Score:      f.bm25Score,
 			VecScore:       f.vecScore,
 			BM25Rank:       f.bm25Rank, // FEAT-UNIX3: Expose for explain mode
@@ -913,14 +1417,39 @@ func (e *Engine) enrichResults(ctx context.Context, fused []*fusedResult) ([]*Se
 			Highlights:     e.calculateHighlights(chunk.Content, f.matchedTerms),
 			MatchedTerms:   f.matchedTerms, // UX-1: Expose matched terms for context display
 			SourceMetadata: SourceMetadataFromChunkWithRules(chunk, e.config.MetadataRules),
+
+			MatchedSubQueries: f.matchedSubQueries,
 		}
+		setResultSize(result)
 
 		results = append(results, result)
 	}
+	e.setNearDuplicateCounts(ctx, results)
 
 	return results, nil
 }
 
+// setNearDuplicateCounts fills SearchResult.NearDuplicates when the metadata
+// store records near-duplicates. Lookup failures leave the counts at zero.
+func (e *Engine) setNearDuplicateCounts(ctx context.Context, results []*SearchResult) {
+	nds, ok := e.metadata.(store.NearDuplicateStore)
+	if !ok || len(results) == 0 {
+		return
+	}
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.Chunk.ID
+	}
+	counts, err := nds.CountNearDuplicates(ctx, ids)
+	if err != nil {
+		slog.Debug("failed to count near-duplicates", slog.String("error", err.Error()))
+		return
+	}
+	for _, r := range results {
+		r.NearDuplicates = counts[r.Chunk.ID]
+	}
+}
+
 // addExactSymbolCandidates supplements exact identifier searches with chunks
 // from the symbol table. BM25 can rank dense references above a long definition
 // chunk after code-aware tokenization splits identifiers, so the symbol table is
@@ -964,21 +1493,29 @@ func (e *Engine) addExactSymbolCandidates(ctx context.Context, results []*Search
 		if _, ok := seen[chunk.ID]; ok {
 			continue
 		}
-		results = append(results, &SearchResult{
-			Chunk: chunk,
-			// Tie with the current top lexical result; ApplyExactMatchBoost
-			// then boosts all exact matches uniformly in the shared pipeline.
-			Score:          maxScore,
-			Highlights:     e.calculateHighlights(chunk.Content, terms),
-			MatchedTerms:   terms,
-			SourceMetadata: SourceMetadataFromChunkWithRules(chunk, e.config.MetadataRules),
-		})
+		// Tie with the current top lexical result; ApplyExactMatchBoost
+		// then boosts all exact matches uniformly in the shared pipeline.
+		results = append(results, e.candidateResult(chunk, maxScore, terms))
 		seen[chunk.ID] = struct{}{}
 	}
 
 	return results, nil
 }
 
+// candidateResult returns the result for a chunk added outside fusion, such
+// as an exact symbol or path match, scored score and matching terms.
+func (e *Engine) candidateResult(chunk *store.Chunk, score float64, terms []string) *SearchResult {
+	result := &SearchResult{
+		Chunk:          chunk,
+		Score:          score,
+		Highlights:     e.calculateHighlights(chunk.Content, terms),
+		MatchedTerms:   terms,
+		SourceMetadata: SourceMetadataFromChunkWithRules(chunk, e.config.MetadataRules),
+	}
+	setResultSize(result)
+	return result
+}
+
 const (
 	adrReferenceDocCandidateLimit = 20
 	adrReferencePathLimit         = 12
@@ -1043,13 +1580,7 @@ func (e *Engine) addADRReferenceCandidates(ctx context.Context, results []*Searc
 			if _, ok := seen[chunk.ID]; ok {
 				continue
 			}
-			results = append(results, &SearchResult{
-				Chunk:          chunk,
-				Score:          maxScore,
-				Highlights:     e.calculateHighlights(chunk.Content, terms),
-				MatchedTerms:   terms,
-				SourceMetadata: SourceMetadataFromChunkWithRules(chunk, e.config.MetadataRules),
-			})
+			results = append(results, e.candidateResult(chunk, maxScore, terms))
 			seen[chunk.ID] = struct{}{}
 			addedForPath = true
 		}
@@ -1209,13 +1740,7 @@ func (e *Engine) addPDFContentCandidates(ctx context.Context, results []*SearchR
 
 	terms := store.TokenizeCode(query)
 	for _, candidate := range scored {
-		results = append(results, &SearchResult{
-			Chunk:          candidate.chunk,
-			Score:          maxScore * (1 + 0.05*float64(candidate.score)),
-			Highlights:     e.calculateHighlights(candidate.chunk.Content, terms),
-			MatchedTerms:   terms,
-			SourceMetadata: SourceMetadataFromChunkWithRules(candidate.chunk, e.config.MetadataRules),
-		})
+		results = append(results, e.candidateResult(candidate.chunk, maxScore*(1+0.05*float64(candidate.score)), terms))
 		seen[candidate.chunk.ID] = struct{}{}
 	}
 
@@ -1359,6 +1884,72 @@ func normalizeIndexedPathReference(value string) string {
 	return ""
 }
 
+// adjacentTopN is how many top results get adjacent context.
+const adjacentTopN = 5
+
+// enrichAdjacent adds adjacent context to the top results as configured by
+// opts.AdjacentChunks and opts.AdjacentTokenBudget.
+func (e *Engine) enrichAdjacent(ctx context.Context, results []*SearchResult, opts SearchOptions) {
+	count := opts.AdjacentChunks
+	if count <= 0 && opts.AdjacentTokenBudget > 0 {
+		count = math.MaxInt // budget only: the budget is the limit
+	}
+	e.enrichResultsWithAdjacent(ctx, results, count, adjacentTopN)
+	trimAdjacentToBudget(results, opts.AdjacentTokenBudget, adjacentTopN, embed.TokenizerFor(e.embedder))
+}
+
+// trimAdjacentToBudget drops adjacent chunks beyond budget tokens, as counted
+// by tokenizer.
+// Chunks are admitted nearest-first, one distance step at a time across the
+// top results in rank order, so every result gets its closest context before
+// any result gets more distant context. Admission stops at the first chunk
+// that does not fit, keeping each result's context contiguous.
+func trimAdjacentToBudget(results []*SearchResult, budget int, topN int, tokenizer embed.Tokenizer) {
+	if budget <= 0 {
+		return
+	}
+	if topN > 0 && len(results) > topN {
+		results = results[:topN]
+	}
+
+	keepBefore := make([]int, len(results))
+	keepAfter := make([]int, len(results))
+	used := 0
+	exhausted := false
+	for dist := 0; !exhausted; dist++ {
+		progressed := false
+		for i, r := range results {
+			for _, side := range []struct {
+				chunks []*store.Chunk
+				keep   *int
+			}{
+				{r.AdjacentContext.Before, &keepBefore[i]},
+				{r.AdjacentContext.After, &keepAfter[i]},
+			} {
+				if exhausted || dist >= len(side.chunks) {
+					continue
+				}
+				tokens := tokenizer.CountTokens(side.chunks[dist].Content)
+				if used+tokens > budget {
+					exhausted = true
+					continue
+				}
+				used += tokens
+				*side.keep = dist + 1
+				progressed = true
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	for i, r := range results {
+		r.AdjacentContext.Before = r.AdjacentContext.Before[:keepBefore[i]]
+		r.AdjacentContext.After = r.AdjacentContext.After[:keepAfter[i]]
+	}
+}
+
 // enrichResultsWithAdjacent fetches adjacent chunks for context continuity.
 // FEAT-QI5: For each top-N result, retrieves chunks before/after from the same file.
 // This improves "How does X work" queries by providing surrounding context.
@@ -1477,6 +2068,17 @@ func (e *Engine) rerankResults(ctx context.Context, query string, fused []*fused
 		return fused
 	}
 
+	// Skip if fusion already produced a clear winner; "always" still reranks
+	if decision.Policy != RerankerPolicyAlways && isClearWinner(fused, opts.RerankThreshold) {
+		slog.Debug("clear winner, skipping reranking",
+			slog.String("chunk_id", fused[0].chunkID),
+			slog.Float64("threshold", opts.RerankThreshold))
+		status.State = RerankerStateSkipped
+		status.SkipReason = RerankerSkipClearWinner
+		recordRerankerStatus(opts, status)
+		return fused
+	}
+
 	// DEBT-024: Measure availability check
 	availStart := time.Now()
 	if !e.reranker.Available(ctx) {
@@ -1608,19 +2210,40 @@ func (e *Engine) rerankResults(ctx context.Context, query string, fused []*fused
 
 // calculateHighlights finds text ranges for matched terms.
 // Optimized: pre-allocates capacity, limits matches per term.
+//
+// The total is capped at EngineConfig.MaxHighlights so synonym-heavy queries
+// cannot produce unbounded payloads. Terms are scanned in the order given,
+// so earlier (original query) terms keep their matches when the cap is hit.
 func (e *Engine) calculateHighlights(content string, matchedTerms []string) []Range {
 	// Early return for empty inputs - return empty slice, not nil (DEBT-012)
 	if len(matchedTerms) == 0 || len(content) == 0 {
 		return []Range{}
 	}
 
+	maxHighlights := e.config.MaxHighlights
+	if maxHighlights <= 0 {
+		maxHighlights = DefaultMaxHighlights
+	}
+
 	// Pre-allocate with estimated capacity (avg 3 matches per term)
 	const maxMatchesPerTerm = 10
-	highlights := make([]Range, 0, len(matchedTerms)*3)
+	highlights := make([]Range, 0, min(len(matchedTerms)*3, maxHighlights))
 
-	lowerContent := strings.ToLower(content)
+	// Offsets are found in the lowercased content but reported for content.
+	// Lowercasing can change byte lengths (invalid UTF-8, some non-ASCII
+	// letters), so offsets are mapped back unless content is ASCII.
+	lowerContent, offsets := lowerWithOffsets(content)
+	original := func(i int) int {
+		if offsets == nil {
+			return i
+		}
+		return offsets[i]
+	}
 
 	for _, term := range matchedTerms {
+		if len(highlights) >= maxHighlights {
+			break
+		}
 		if len(term) == 0 {
 			continue
 		}
@@ -1629,7 +2252,7 @@ func (e *Engine) calculateHighlights(content string, matchedTerms []string) []Ra
 		start := 0
 		matchCount := 0
 
-		for matchCount < maxMatchesPerTerm {
+		for matchCount < maxMatchesPerTerm && len(highlights) < maxHighlights {
 			idx := strings.Index(lowerContent[start:], lowerTerm)
 			if idx == -1 {
 				break
@@ -1637,11 +2260,11 @@ func (e *Engine) calculateHighlights(content string, matchedTerms []string) []Ra
 
 			absStart := start + idx
 			highlights = append(highlights, Range{
-				Start: absStart,
-				End:   absStart + len(term),
+				Start: original(absStart),
+				End:   original(absStart + len(lowerTerm)),
 			})
 
-			start = absStart + len(term)
+			start = absStart + len(lowerTerm)
 			matchCount++
 		}
 	}
@@ -1656,6 +2279,43 @@ func (e *Engine) calculateHighlights(content string, matchedTerms []string) []Ra
 	return highlights
 }
 
+// lowerWithOffsets lowercases content like strings.ToLower. For non-ASCII
+// content it also returns, for each byte offset of the result (and its end),
+// the offset in content of the rune that byte came from, so matches in the
+// lowercased text map to whole runes of content. Invalid UTF-8 bytes are
+// kept as is. offsets is nil when content is ASCII and offsets are equal.
+func lowerWithOffsets(content string) (string, []int) {
+	ascii := true
+	for i := 0; i < len(content); i++ {
+		if content[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return strings.ToLower(content), nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(content))
+	offsets := make([]int, 0, len(content)+1)
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		before := b.Len()
+		if r == utf8.RuneError && size == 1 {
+			b.WriteByte(content[i])
+		} else {
+			b.WriteRune(unicode.ToLower(r))
+		}
+		for range b.Len() - before {
+			offsets = append(offsets, i)
+		}
+		i += size
+	}
+	offsets = append(offsets, len(content))
+	return b.String(), offsets
+}
+
 // multiQuerySearch handles FEAT-QI3 multi-query decomposition search.
 // It decomposes the query, runs sub-queries in parallel, and fuses results.
 func (e *Engine) multiQuerySearch(ctx context.Context, query string, opts SearchOptions, start time.Time) ([]*SearchResult, error) {
@@ -1667,8 +2327,13 @@ func (e *Engine) multiQuerySearch(ctx context.Context, query string, opts Search
 	// FEAT-UNIX3: Get sub-queries for explain output
 	var subQueryStrings []string
 	if opts.Explain {
-		subQueryStrings = make([]string, len(subQueries))
-		for i, sq := range subQueries {
+		// Only the sub-queries MultiQuerySearcher actually runs
+		executed := subQueries
+		if len(executed) > e.multiQuery.maxSubQueries {
+			executed = executed[:e.multiQuery.maxSubQueries]
+		}
+		subQueryStrings = make([]string, len(executed))
+		for i, sq := range executed {
 			subQueryStrings[i] = sq.Query
 		}
 	}
@@ -1685,12 +2350,15 @@ func (e *Engine) multiQuerySearch(ctx context.Context, query string, opts Search
 		fused[i] = &fusedResult{
 			chunkID:      mf.ChunkID,
 			rrfScore:     mf.RRFScore,
+			rawScore:     mf.RawScore,
 			bm25Score:    mf.BM25Score,
 			vecScore:     mf.VecScore,
 			bm25Rank:     mf.BM25Rank,
 			vecRank:      mf.VecRank,
 			inBothLists:  mf.InBothLists,
 			matchedTerms: mf.MatchedTerms,
+
+			matchedSubQueries: mf.MatchedSubQueries,
 		}
 	}
 
@@ -1717,27 +2385,42 @@ func (e *Engine) multiQuerySearch(ctx context.Context, query string, opts Search
 	}
 
 	// FEAT-QI5: Enrich with adjacent context if requested
-	e.enrichResultsWithAdjacent(ctx, enriched, opts.AdjacentChunks, 5)
+	e.enrichAdjacent(ctx, enriched, opts)
 
 	// TASK-SYN42: Exact lexical lookups should rank definitions above references.
 	enriched = ApplyExactMatchBoost(enriched, query)
 	enriched = ApplyPDFContentBoost(enriched, query)
-	// FEAT-QI4: Apply test file penalty to prioritize real implementations
-	enriched = ApplyTestFilePenalty(enriched)
+	// FEAT-QI4: Demote test files to prioritize real implementations,
+	// or drop them or keep only them as opts.IncludeTests asks
+	enriched = ApplyTestFileMode(enriched, opts.IncludeTests)
 	// BUG-066: Apply path boost to prioritize internal/ over cmd/
 	enriched = ApplyPathBoost(enriched)
+	if opts.BoostPathMatch {
+		enriched = ApplyPathMatchBoost(enriched, query)
+	}
 	// F39: Apply authority/freshness boost after path boosts.
 	enriched = ApplyAuthorityBoost(enriched)
 
 	// Apply filters after enrichment (need chunk metadata)
 	filtered := ApplyFilters(enriched, opts)
+	filtered = e.mergeContiguous(filtered, opts)
+	filtered = SortResults(filtered, opts.SortBy)
 
 	// FEAT-UNIX3: Attach explain data for multi-query search
 	// Note: BM25/vector counts are aggregated across sub-queries, so we use result count
 	e.attachExplainData(filtered, query, opts, len(filtered), len(filtered), false, subQueryStrings)
+	e.attachBlame(ctx, filtered, opts)
+	e.attachSalientTerms(ctx, filtered, opts)
+	setResultSizes(filtered)
+	ClassifyConfidence(filtered, e.config.Confidence)
+	recordSearchDiagnostics(opts, SearchDiagnostics{
+		CandidateCount: len(enriched),
+		ResultCount:    len(filtered),
+	})
 
 	// Record telemetry
 	e.recordMetrics(query, QueryTypeMixed, len(filtered), time.Since(start))
+	e.logQuery(ctx, query, *opts.Weights, len(filtered), time.Since(start))
 
 	slog.Debug("multi_query_search_complete",
 		slog.String("query", query),
@@ -1803,13 +2486,7 @@ func (e *Engine) addSubQueryPathCandidates(ctx context.Context, results []*Searc
 			if _, ok := seenChunks[chunk.ID]; ok {
 				continue
 			}
-			results = append(results, &SearchResult{
-				Chunk:          chunk,
-				Score:          maxScore,
-				Highlights:     e.calculateHighlights(chunk.Content, terms),
-				MatchedTerms:   terms,
-				SourceMetadata: SourceMetadataFromChunkWithRules(chunk, e.config.MetadataRules),
-			})
+			results = append(results, e.candidateResult(chunk, maxScore, terms))
 			seenChunks[chunk.ID] = struct{}{}
 			addedForPath = true
 		}
@@ -1859,7 +2536,7 @@ func (e *Engine) singleSearch(ctx context.Context, query string, opts SearchOpti
 
 	// Handle BM25-only mode
 	if opts.BM25Only {
-		candidateLimit := candidateLimitForOptions(query, opts)
+		candidateLimit := e.candidateLimit(query, opts)
 		bm25Results, err := e.bm25.Search(ctx, query, candidateLimit)
 		if err != nil {
 			return nil, fmt.Errorf("BM25 search failed: %w", err)
@@ -1869,9 +2546,9 @@ func (e *Engine) singleSearch(ctx context.Context, query string, opts SearchOpti
 	}
 
 	// Validate dimensions
-	if err := e.validateDimensions(ctx); err != nil {
+	if err := e.validateQueryDimensions(ctx, opts); err != nil {
 		// Fall back to BM25-only
-		candidateLimit := candidateLimitForOptions(query, opts)
+		candidateLimit := e.candidateLimit(query, opts)
 		bm25Results, bm25Err := e.bm25.Search(ctx, query, candidateLimit)
 		if bm25Err != nil {
 			return nil, fmt.Errorf("BM25 search failed: %w", bm25Err)
@@ -1881,8 +2558,8 @@ func (e *Engine) singleSearch(ctx context.Context, query string, opts SearchOpti
 	}
 
 	// Run parallel search
-	candidateLimit := candidateLimitForOptions(query, opts)
-	bm25Results, vecResults, _ := e.parallelSearch(ctx, query, candidateLimit)
+	candidateLimit := e.candidateLimit(query, opts)
+	bm25Results, vecResults, _ := e.parallelSearch(ctx, query, nil, candidateLimit, opts)
 
 	// Fuse results
 	fused := e.fuseResults(bm25Results, vecResults, opts.Weights)
@@ -1896,8 +2573,11 @@ func (e *Engine) singleSearch(ctx context.Context, query string, opts SearchOpti
 		}
 		enriched = ApplyExactMatchBoost(enriched, query)
 		enriched = ApplyPDFContentBoost(enriched, query)
-		enriched = ApplyTestFilePenalty(enriched)
+		enriched = ApplyTestFileMode(enriched, opts.IncludeTests)
 		enriched = ApplyPathBoost(enriched)
+		if opts.BoostPathMatch {
+			enriched = ApplyPathMatchBoost(enriched, query)
+		}
 		enriched = ApplyAuthorityBoost(enriched)
 		// Apply filter
 		filtered := ApplyFilters(enriched, opts)
@@ -1907,6 +2587,7 @@ func (e *Engine) singleSearch(ctx context.Context, query string, opts SearchOpti
 			fusedFiltered[i] = &FusedResult{
 				ChunkID:      r.Chunk.ID,
 				RRFScore:     r.Score,
+				RawScore:     r.RawScore,
 				BM25Score:    r.BM25Score,
 				BM25Rank:     0, // Not tracked after enrichment
 				VecScore:     r.VecScore,
@@ -1928,6 +2609,7 @@ func (e *Engine) convertToFusedResult(internal []*fusedResult) []*FusedResult {
 		results[i] = &FusedResult{
 			ChunkID:      f.chunkID,
 			RRFScore:     f.rrfScore,
+			RawScore:     f.rawScore,
 			BM25Score:    f.bm25Score,
 			BM25Rank:     f.bm25Rank,
 			VecScore:     f.vecScore,
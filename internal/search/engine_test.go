@@ -1,14 +1,20 @@
 package search
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/logging"
 	"github.com/Aman-CERP/amanmcp/internal/store"
 	"github.com/Aman-CERP/amanmcp/internal/telemetry"
 	"github.com/stretchr/testify/assert"
@@ -1972,6 +1978,158 @@ func TestEngine_Search_DimensionMismatch_GracefulDegradation(t *testing.T) {
 	}
 }
 
+func TestEngine_IndexWithEmbeddings_ReusesProvidedEmbeddings(t *testing.T) {
+	// Given: an engine and one of two chunks with a reusable embedding
+	engine, _, vector, embedder, _ := setupTestEngine(t)
+
+	var indexedVectors [][]float32
+	vector.AddFn = func(ctx context.Context, ids []string, vectors [][]float32) error {
+		indexedVectors = vectors
+		return nil
+	}
+
+	chunks := createTestChunks()[:2]
+	reused := make([]float32, 768)
+	reused[0] = 1
+	reuse := map[string][]float32{chunks[0].ID: reused}
+
+	// When: indexing with the reusable embedding
+	err := engine.IndexWithEmbeddings(context.Background(), chunks, reuse)
+
+	// Then: only the other chunk is embedded and vectors keep chunk order
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), embedder.embedCalled.Load())
+	require.Len(t, indexedVectors, 2)
+	assert.Equal(t, reused, indexedVectors[0])
+	assert.Equal(t, float32(0), indexedVectors[1][0])
+}
+
+// pipelineTestChunks returns n chunks with distinct IDs and content.
+func pipelineTestChunks(n int) []*store.Chunk {
+	chunks := make([]*store.Chunk, n)
+	for i := range chunks {
+		chunks[i] = &store.Chunk{
+			ID:       fmt.Sprintf("chunk-%03d", i),
+			FilePath: "big.go",
+			Content:  fmt.Sprintf("func F%d() {}", i),
+		}
+	}
+	return chunks
+}
+
+func TestEngine_Index_Pipelined(t *testing.T) {
+	// Given: a pipelined engine and more chunks than fit in one batch, one
+	// with a reusable embedding
+	bm25 := &MockBM25Index{}
+	vector := &MockVectorStore{}
+	embedder := &MockEmbedder{}
+	metadata := NewMockMetadataStore()
+	cfg := DefaultConfig()
+	cfg.PipelineIndexing = true
+	engine := New(bm25, vector, embedder, metadata, cfg)
+
+	var batchSizes []int
+	var indexedIDs []string
+	var indexedVectors [][]float32
+	vector.AddFn = func(ctx context.Context, ids []string, vectors [][]float32) error {
+		batchSizes = append(batchSizes, len(ids))
+		indexedIDs = append(indexedIDs, ids...)
+		indexedVectors = append(indexedVectors, vectors...)
+		return nil
+	}
+
+	chunks := pipelineTestChunks(2*indexPipelineBatchSize + 10)
+	reused := make([]float32, 768)
+	reused[0] = 1
+	reuse := map[string][]float32{chunks[70].ID: reused}
+
+	// When: indexing
+	err := engine.IndexWithEmbeddings(context.Background(), chunks, reuse)
+
+	// Then: every chunk is stored once, batch by batch and in order, and
+	// only the chunks without a reusable embedding are embedded
+	require.NoError(t, err)
+	assert.Equal(t, []int{indexPipelineBatchSize, indexPipelineBatchSize, 10}, batchSizes)
+	require.Len(t, indexedIDs, len(chunks))
+	for i, c := range chunks {
+		assert.Equal(t, c.ID, indexedIDs[i])
+	}
+	assert.Equal(t, reused, indexedVectors[70])
+	assert.Equal(t, int32(len(chunks)-1), embedder.embedCalled.Load())
+	assert.Len(t, metadata.chunks, len(chunks))
+	assert.Equal(t, "768", metadata.state[store.StateKeyIndexDimension])
+}
+
+func TestEngine_Index_PipelinedStoreErrorStopsEmbedding(t *testing.T) {
+	// Given: a pipelined engine whose vector store fails on the second batch
+	vector := &MockVectorStore{}
+	embedder := &MockEmbedder{}
+	metadata := NewMockMetadataStore()
+	cfg := DefaultConfig()
+	cfg.PipelineIndexing = true
+	engine := New(&MockBM25Index{}, vector, embedder, metadata, cfg)
+
+	adds := 0
+	vector.AddFn = func(ctx context.Context, ids []string, vectors [][]float32) error {
+		adds++
+		if adds == 2 {
+			return errors.New("disk full")
+		}
+		return nil
+	}
+	chunks := pipelineTestChunks(10 * indexPipelineBatchSize)
+
+	// When: indexing
+	err := engine.Index(context.Background(), chunks)
+
+	// Then: the write error is returned, the first batch stays stored, and
+	// embedding stops within the one batch the pipeline buffers ahead
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+	assert.Equal(t, 2, adds)
+	assert.Len(t, metadata.chunks, indexPipelineBatchSize)
+	assert.LessOrEqual(t, embedder.embedCalled.Load(), int32(4*indexPipelineBatchSize))
+}
+
+func TestEngine_Index_PipelinedEmbedError(t *testing.T) {
+	// Given: a pipelined engine whose embedder fails in the second batch
+	vector := &MockVectorStore{}
+	embedder := &MockEmbedder{}
+	embedder.EmbedFn = func(ctx context.Context, text string) ([]float32, error) {
+		if text == "func F100() {}" {
+			return nil, errors.New("connection reset")
+		}
+		return make([]float32, 768), nil
+	}
+	metadata := NewMockMetadataStore()
+	cfg := DefaultConfig()
+	cfg.PipelineIndexing = true
+	cfg.EmbedRetry = EmbedRetryPolicy{}
+	engine := New(&MockBM25Index{}, vector, embedder, metadata, cfg)
+
+	// When: indexing
+	err := engine.Index(context.Background(), pipelineTestChunks(3*indexPipelineBatchSize))
+
+	// Then: the embed error is returned after the first batch was stored
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "generate embeddings")
+	assert.Contains(t, err.Error(), "connection reset")
+	assert.Len(t, metadata.chunks, indexPipelineBatchSize)
+}
+
+func TestEngine_StoredEmbeddings_RequiresCurrentModel(t *testing.T) {
+	// Given: an index built by a different embedder
+	engine, _, _, _, metadata := setupTestEngine(t)
+	metadata.state[store.StateKeyIndexModel] = "other-model"
+
+	// When: loading stored embeddings
+	embeddings, err := engine.StoredEmbeddings(context.Background(), []string{"chunk1"})
+
+	// Then: nothing is returned for reuse
+	require.NoError(t, err)
+	assert.Empty(t, embeddings)
+}
+
 func TestEngine_Index_StoresDimensionInfo(t *testing.T) {
 	// Given: engine with embedder
 	engine, bm25, vector, embedder, metadata := setupTestEngine(t)
@@ -2129,6 +2287,76 @@ func TestEngine_enrichResultsWithAdjacent_AdjacentCountLimit(t *testing.T) {
 	assert.Equal(t, "chunk7", results[0].AdjacentContext.After[1].ID, "should have second closest after")
 }
 
+func TestTrimAdjacentToBudget_NearestFirstAcrossResults(t *testing.T) {
+	// Given: two results whose adjacent chunks are 10 tokens each
+	chunk := func(id string) *store.Chunk {
+		return &store.Chunk{ID: id, Content: strings.Repeat("x", 40)}
+	}
+	results := []*SearchResult{
+		{AdjacentContext: AdjacentContext{
+			Before: []*store.Chunk{chunk("r0-b1"), chunk("r0-b2")},
+			After:  []*store.Chunk{chunk("r0-a1")},
+		}},
+		{AdjacentContext: AdjacentContext{
+			Before: []*store.Chunk{chunk("r1-b1")},
+			After:  []*store.Chunk{chunk("r1-a1")},
+		}},
+	}
+
+	// When: trimming to a 35-token budget
+	trimAdjacentToBudget(results, 35, 5, embed.HeuristicTokenizer{})
+
+	// Then: the closest chunks are kept in rank order until the budget is hit
+	assert.Len(t, results[0].AdjacentContext.Before, 1, "distant chunk dropped before closer ones of later results")
+	assert.Len(t, results[0].AdjacentContext.After, 1)
+	assert.Len(t, results[1].AdjacentContext.Before, 1)
+	assert.Empty(t, results[1].AdjacentContext.After, "chunk past the budget is dropped")
+}
+
+func TestEngine_enrichAdjacent_TokenBudget(t *testing.T) {
+	// Given: a file of ten 10-token chunks and a result in the middle
+	engine, _, _, _, metadata := setupTestEngine(t)
+	chunks := make([]*store.Chunk, 10)
+	for i := range chunks {
+		chunks[i] = &store.Chunk{
+			ID:        fmt.Sprintf("chunk%d", i),
+			FileID:    "test-file",
+			StartLine: i*10 + 1,
+			EndLine:   (i + 1) * 10,
+			Content:   strings.Repeat("x", 40),
+		}
+		metadata.chunks[chunks[i].ID] = chunks[i]
+	}
+
+	tests := []struct {
+		name       string
+		opts       SearchOptions
+		wantBefore int
+		wantAfter  int
+	}{
+		{"budget stricter than count", SearchOptions{AdjacentChunks: 3, AdjacentTokenBudget: 25}, 1, 1},
+		{"count stricter than budget", SearchOptions{AdjacentChunks: 1, AdjacentTokenBudget: 1000}, 1, 1},
+		{"budget only", SearchOptions{AdjacentTokenBudget: 1000}, 5, 4},
+		{"neither", SearchOptions{}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := []*SearchResult{{Chunk: chunks[5]}}
+
+			// When: enriching with adjacent context
+			engine.enrichAdjacent(context.Background(), results, tt.opts)
+
+			// Then: the stricter limit applies
+			assert.Len(t, results[0].AdjacentContext.Before, tt.wantBefore)
+			assert.Len(t, results[0].AdjacentContext.After, tt.wantAfter)
+			if tt.wantBefore > 0 {
+				assert.Equal(t, "chunk4", results[0].AdjacentContext.Before[0].ID, "closest chunk kept")
+			}
+		})
+	}
+}
+
 // =============================================================================
 // QI-1: Query Expansion Tests
 // =============================================================================
@@ -2155,8 +2383,9 @@ func TestEngine_Search_BM25UsesExpandedQuery(t *testing.T) {
 		return []*store.VectorResult{{ID: "chunk1", Score: 0.8}}, nil
 	}
 
-	// Set up expander on the engine
+	// Set up expander and Qwen3 query instruction on the engine
 	engine.expander = NewQueryExpander()
+	engine.config.QueryInstruction = Qwen3QueryInstruction
 
 	// When: searching with a query that will be expanded
 	originalQuery := "Search function"
@@ -2171,11 +2400,70 @@ func TestEngine_Search_BM25UsesExpandedQuery(t *testing.T) {
 
 	// Vector search should receive the FORMATTED query with Qwen3 instruction prefix
 	// Per Qwen3 docs: queries need instruction prefix for optimal retrieval
-	expectedFormattedQuery := formatQueryForEmbedding(originalQuery)
+	expectedFormattedQuery := Qwen3QueryInstruction + originalQuery
 	assert.Equal(t, expectedFormattedQuery, embeddedQuery,
 		"vector search should use Qwen3 formatted query with instruction prefix")
 }
 
+func TestEngine_Search_QueryInstruction(t *testing.T) {
+	tests := []struct {
+		name        string
+		instruction string
+		want        string
+	}{
+		{name: "no instruction", instruction: "", want: "parse config"},
+		{name: "custom instruction", instruction: "query: ", want: "query: parse config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: an engine configured with a query instruction
+			_, bm25, vector, embedder, metadata := setupTestEngine(t)
+			cfg := DefaultConfig()
+			cfg.QueryInstruction = tt.instruction
+			engine := New(bm25, vector, embedder, metadata, cfg)
+			var embedded string
+			embedder.EmbedFn = func(ctx context.Context, text string) ([]float32, error) {
+				embedded = text
+				return make([]float32, 768), nil
+			}
+
+			// When: searching
+			_, err := engine.Search(context.Background(), "parse config", SearchOptions{})
+
+			// Then: the query is embedded with exactly that prefix
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, embedded)
+		})
+	}
+}
+
+func TestEngine_Search_QueryInstructionFollowsEmbedderOverride(t *testing.T) {
+	// Given: an engine with no query instruction and a Qwen3 per-query embedder
+	_, bm25, vector, embedder, metadata := setupTestEngine(t)
+	engine := New(bm25, vector, embedder, metadata, DefaultConfig())
+	var embedded string
+	override := &namedEmbedder{MockEmbedder: &MockEmbedder{
+		EmbedFn: func(ctx context.Context, text string) ([]float32, error) {
+			embedded = text
+			return make([]float32, 768), nil
+		},
+	}, name: "qwen3-embedding:0.6b"}
+
+	// When: searching with the override
+	_, err := engine.Search(context.Background(), "parse config", SearchOptions{Embedder: override})
+
+	// Then: the query gets the instruction for the override's model
+	require.NoError(t, err)
+	assert.Equal(t, Qwen3QueryInstruction+"parse config", embedded)
+}
+
+func TestQueryInstructionForModel(t *testing.T) {
+	assert.Equal(t, Qwen3QueryInstruction, QueryInstructionForModel("qwen3-embedding:0.6b"))
+	assert.Equal(t, Qwen3QueryInstruction, QueryInstructionForModel("Qwen/Qwen3-Embedding-8B"))
+	assert.Empty(t, QueryInstructionForModel("nomic-embed-text"))
+	assert.Empty(t, QueryInstructionForModel(""))
+}
+
 func TestEngine_Search_BM25QueryExpansion(t *testing.T) {
 	// These tests verify BM25 query expansion for the 3 dogfood queries
 	// RCA-010: Vocabulary mismatch between natural language and code
@@ -2267,6 +2555,27 @@ func TestCandidateLimitForQuery_BroadensExactLexicalPool(t *testing.T) {
 	}))
 }
 
+func TestCandidateLimitForOptions_CandidateMultiplier(t *testing.T) {
+	// Given: a plain semantic query with Limit 10
+	opts := SearchOptions{Limit: 10}
+
+	// When/Then: the multiplier scales the per-searcher pool
+	assert.Equal(t, 20, candidateLimitForOptions("hybrid search", opts), "default multiplier is 2")
+
+	opts.CandidateMultiplier = 10
+	assert.Equal(t, 100, candidateLimitForOptions("hybrid search", opts))
+
+	opts.CandidateMultiplier = 1000
+	assert.Equal(t, 10*MaxCandidateMultiplier, candidateLimitForOptions("hybrid search", opts),
+		"multiplier is clamped")
+
+	// And: a broadened pool never shrinks below the requested multiplier
+	opts.CandidateMultiplier = 15
+	assert.Equal(t, 150, candidateLimitForOptions("OllamaEmbedder", opts))
+	opts.CandidateMultiplier = 3
+	assert.Equal(t, 100, candidateLimitForOptions("OllamaEmbedder", opts))
+}
+
 func TestAddADRReferenceCandidates_UsesIndexedImplementationPaths(t *testing.T) {
 	ctx := context.Background()
 	metadata := NewMockMetadataStore()
@@ -3850,6 +4159,30 @@ func TestEngine_RecordMetrics_WithMetrics(t *testing.T) {
 	// Note: We can't easily verify without exposing internals, but this exercises the code path
 }
 
+func TestEngine_Search_QueryLogger(t *testing.T) {
+	// Given: an engine with a redacting query logger
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{{DocID: "chunk1", Score: 0.9}}, nil
+	}
+	var buf bytes.Buffer
+	engine.queryLog = logging.NewQueryLogger(slog.New(slog.NewJSONHandler(&buf, nil)), logging.HashQuery)
+
+	// When: searching BM25-only
+	results, err := engine.Search(context.Background(), "secret-customer-id", SearchOptions{BM25Only: true})
+	require.NoError(t, err)
+
+	// Then: one query entry is logged with redacted text and BM25-only weights
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, logging.CategoryQuery, entry["category"])
+	assert.Equal(t, logging.HashQuery("secret-customer-id"), entry["query"])
+	assert.Equal(t, 1.0, entry["bm25_weight"])
+	assert.Equal(t, 0.0, entry["semantic_weight"])
+	assert.Equal(t, float64(len(results)), entry["results"])
+	assert.NotContains(t, buf.String(), "secret-customer-id")
+}
+
 // =============================================================================
 // DEBT-028: multiQuerySearch Tests
 // =============================================================================
@@ -3960,6 +4293,60 @@ func TestEngine_MultiQuerySearch_WithExplain(t *testing.T) {
 	}
 }
 
+func TestEngine_MultiQuerySearch_MatchedSubQueries(t *testing.T) {
+	// Given: sub-queries where "auth" matches both chunks, "session" only one
+	// and "token" none
+	bm25 := &MockBM25Index{
+		SearchFn: func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+			switch query {
+			case "session":
+				return []*store.BM25Result{{DocID: "chunk2", Score: 5.0}}, nil
+			case "token":
+				return nil, nil
+			}
+			return []*store.BM25Result{
+				{DocID: "chunk1", Score: 10.0},
+				{DocID: "chunk2", Score: 8.0},
+			}, nil
+		},
+	}
+	metadata := NewMockMetadataStore()
+	metadata.chunks["chunk1"] = &store.Chunk{ID: "chunk1", FilePath: "internal/auth/login.go", Content: "func Login()"}
+	metadata.chunks["chunk2"] = &store.Chunk{ID: "chunk2", FilePath: "internal/auth/session.go", Content: "func NewSession()"}
+
+	decomposer := &MockDecomposer{
+		ShouldDecomposeFn: func(query string) bool { return true },
+		DecomposeFn: func(query string) []SubQuery {
+			return []SubQuery{
+				{Query: "auth", Weight: 1.0},
+				{Query: "session", Weight: 1.0},
+				{Query: "token", Weight: 1.0},
+			}
+		},
+	}
+	engine := New(bm25, &MockVectorStore{}, &MockEmbedder{}, metadata, DefaultConfig(), WithMultiQuerySearch(decomposer))
+
+	// When: searching with Explain enabled
+	results, err := engine.Search(context.Background(), "how does auth work", SearchOptions{
+		Limit:   10,
+		Explain: true,
+	})
+
+	// Then: each result lists the sub-queries that found it
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	byID := make(map[string][]string)
+	for _, r := range results {
+		byID[r.Chunk.ID] = r.MatchedSubQueries
+	}
+	assert.Equal(t, []string{"auth"}, byID["chunk1"])
+	assert.Equal(t, []string{"auth", "session"}, byID["chunk2"])
+
+	// And: explain data counts results per sub-query, including unproductive ones
+	require.NotNil(t, results[0].Explain)
+	assert.Equal(t, map[string]int{"auth": 2, "session": 1, "token": 0}, results[0].Explain.SubQueryResultCounts)
+}
+
 func TestEngine_MultiQuerySearch_WithAdjacentChunks(t *testing.T) {
 	// Given: engine with multi-query and adjacent chunks option
 	bm25 := &MockBM25Index{
@@ -4388,3 +4775,313 @@ func TestEngine_Index_WithContextPrefix(t *testing.T) {
 	// Then: no error
 	require.NoError(t, err)
 }
+
+// =============================================================================
+// Zero-result diagnostics
+// =============================================================================
+
+func TestEngine_Search_Diagnostics_NoResultsReason(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		opts        SearchOptions
+		bm25        []*store.BM25Result
+		embedErr    error
+		indexDim    string
+		wantReason  NoResultsReason
+		wantResults bool
+	}{
+		{
+			name:       "empty query",
+			query:      "   ",
+			wantReason: NoResultsEmptyQuery,
+		},
+		{
+			name:       "genuinely no matches",
+			query:      "nothing matches this",
+			wantReason: NoResultsNoMatches,
+		},
+		{
+			name:       "all candidates filtered",
+			query:      "login",
+			opts:       SearchOptions{Filter: "docs"},
+			bm25:       []*store.BM25Result{{DocID: "chunk1", Score: 0.9}},
+			wantReason: NoResultsFiltered,
+		},
+		{
+			name:       "dimension mismatch with empty BM25",
+			query:      "frobnicate quux",
+			indexDim:   "384",
+			wantReason: NoResultsDimensionMismatch,
+		},
+		{
+			name:       "embedder offline with empty BM25",
+			query:      "frobnicate quux",
+			bm25:       []*store.BM25Result{}, // BM25 ran and matched nothing
+			embedErr:   errors.New("connection refused"),
+			wantReason: NoResultsSemanticUnavailable,
+		},
+		{
+			name:        "results returned",
+			query:       "login",
+			bm25:        []*store.BM25Result{{DocID: "chunk1", Score: 0.9}},
+			wantReason:  "",
+			wantResults: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: an engine whose retrievers return the configured results
+			engine, bm25, vector, embedder, metadata := setupTestEngine(t)
+			engine.config.EmbedRetry = EmbedRetryPolicy{}
+			bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+				return tt.bm25, nil
+			}
+			vector.SearchFn = func(ctx context.Context, query []float32, k int) ([]*store.VectorResult, error) {
+				return nil, nil
+			}
+			embedder.EmbedFn = func(ctx context.Context, text string) ([]float32, error) {
+				if tt.embedErr != nil {
+					return nil, tt.embedErr
+				}
+				return make([]float32, 768), nil
+			}
+			if tt.indexDim != "" {
+				metadata.state[store.StateKeyIndexDimension] = tt.indexDim
+			}
+
+			// When: searching with diagnostics requested (Explain off)
+			var diag SearchDiagnostics
+			opts := tt.opts
+			opts.Diagnostics = &diag
+			results, err := engine.Search(context.Background(), tt.query, opts)
+
+			// Then: the zeroing stage is reported
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResults, len(results) > 0)
+			assert.Equal(t, tt.wantReason, diag.NoResultsReason)
+			assert.Equal(t, len(results), diag.ResultCount)
+		})
+	}
+}
+
+func TestEngine_Search_Diagnostics_StageCounts(t *testing.T) {
+	// Given: BM25 finds two candidates and a docs filter keeps one
+	engine, bm25, vector, _, _ := setupTestEngine(t)
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{
+			{DocID: "chunk1", Score: 0.9},
+			{DocID: "chunk3", Score: 0.8},
+		}, nil
+	}
+	vector.SearchFn = func(ctx context.Context, query []float32, k int) ([]*store.VectorResult, error) {
+		return []*store.VectorResult{{ID: "chunk1", Distance: 0.1, Score: 0.9}}, nil
+	}
+
+	// When: searching with diagnostics
+	var diag SearchDiagnostics
+	results, err := engine.Search(context.Background(), "login", SearchOptions{Filter: "docs", Diagnostics: &diag})
+
+	// Then: per-stage counts are recorded
+	require.NoError(t, err)
+	assert.Equal(t, 2, diag.BM25ResultCount)
+	assert.Equal(t, 1, diag.VectorResultCount)
+	assert.GreaterOrEqual(t, diag.CandidateCount, 2)
+	assert.Equal(t, len(results), diag.ResultCount)
+	assert.Empty(t, diag.NoResultsReason)
+}
+
+// efMockVectorStore is a MockVectorStore that supports per-query ef.
+type efMockVectorStore struct {
+	*MockVectorStore
+	gotEf atomic.Int32
+}
+
+func (m *efMockVectorStore) SearchWithEf(ctx context.Context, query []float32, k, ef int) ([]*store.VectorResult, error) {
+	m.gotEf.Store(int32(ef))
+	return m.SearchFn(ctx, query, k)
+}
+
+func TestEngine_Search_VectorEf(t *testing.T) {
+	newEngine := func() (*Engine, *efMockVectorStore) {
+		vector := &efMockVectorStore{MockVectorStore: &MockVectorStore{
+			SearchFn: func(ctx context.Context, query []float32, k int) ([]*store.VectorResult, error) {
+				return []*store.VectorResult{{ID: "chunk1", Distance: 0.1, Score: 0.9}}, nil
+			},
+		}}
+		metadata := NewMockMetadataStore()
+		for _, c := range createTestChunks() {
+			metadata.chunks[c.ID] = c
+		}
+		return New(&MockBM25Index{}, vector, &MockEmbedder{}, metadata, DefaultConfig()), vector
+	}
+
+	t.Run("override is passed to the store", func(t *testing.T) {
+		// Given: a store supporting per-query ef
+		engine, vector := newEngine()
+
+		// When: searching with VectorEf
+		results, err := engine.Search(context.Background(), "login", SearchOptions{VectorEf: 200})
+
+		// Then: the store receives the ef and the default Search is bypassed
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, int32(200), vector.gotEf.Load())
+		assert.Equal(t, int32(0), vector.searchCalled.Load())
+	})
+
+	t.Run("zero uses the store default", func(t *testing.T) {
+		// Given: a store supporting per-query ef
+		engine, vector := newEngine()
+
+		// When: searching without VectorEf
+		_, err := engine.Search(context.Background(), "login", SearchOptions{})
+
+		// Then: the plain Search path is used
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), vector.gotEf.Load())
+		assert.Equal(t, int32(1), vector.searchCalled.Load())
+	})
+}
+
+func TestEngine_Search_EmbedderOverride(t *testing.T) {
+	t.Run("matching dimensions embed the query with the override", func(t *testing.T) {
+		// Given: an index built at 768 dimensions and a 768-dim override
+		engine, bm25, vector, embedder, metadata := setupTestEngine(t)
+		metadata.state[store.StateKeyIndexDimension] = "768"
+		bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+			return []*store.BM25Result{{DocID: "chunk1", Score: 0.9}}, nil
+		}
+		vector.SearchFn = func(ctx context.Context, query []float32, k int) ([]*store.VectorResult, error) {
+			return []*store.VectorResult{{ID: "chunk3", Distance: 0.1, Score: 0.9}}, nil
+		}
+		override := &MockEmbedder{}
+
+		// When: searching with the override
+		results, err := engine.Search(context.Background(), "login", SearchOptions{Embedder: override, Explain: true})
+
+		// Then: only the override embeds the query and vector results are used
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, int32(1), override.embedCalled.Load())
+		assert.Equal(t, int32(0), embedder.embedCalled.Load())
+		assert.Equal(t, int32(1), vector.searchCalled.Load())
+		assert.Equal(t, "mock-embedder", results[0].Explain.QueryEmbedder)
+		assert.Empty(t, results[0].Explain.Note)
+	})
+
+	t.Run("mismatched dimensions fall back to BM25 with a note", func(t *testing.T) {
+		// Given: an index built at 768 dimensions and a 384-dim override
+		engine, bm25, vector, embedder, metadata := setupTestEngine(t)
+		metadata.state[store.StateKeyIndexDimension] = "768"
+		bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+			return []*store.BM25Result{{DocID: "chunk1", Score: 0.9}}, nil
+		}
+		override := &MockEmbedder{DimensionsFn: func() int { return 384 }}
+
+		// When: searching with the override
+		var diag SearchDiagnostics
+		results, err := engine.Search(context.Background(), "login", SearchOptions{Embedder: override, Explain: true, Diagnostics: &diag})
+
+		// Then: vector search is skipped and the explain note says why
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, int32(0), override.embedCalled.Load())
+		assert.Equal(t, int32(0), embedder.embedCalled.Load())
+		assert.Equal(t, int32(0), vector.searchCalled.Load())
+		assert.True(t, diag.DimensionMismatch)
+		require.NotNil(t, results[0].Explain)
+		assert.True(t, results[0].Explain.DimensionMismatch)
+		assert.Contains(t, results[0].Explain.Note, "query embedder has 384 dimensions")
+		assert.NotContains(t, results[0].Explain.Note, "reindex")
+	})
+}
+
+func TestEngine_calculateHighlights_TotalCap(t *testing.T) {
+	// Given: an engine capped at 5 highlights and content where each of
+	// three terms matches 4 times
+	cfg := DefaultConfig()
+	cfg.MaxHighlights = 5
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, &MockEmbedder{}, NewMockMetadataStore(), cfg)
+	content := strings.Repeat("alpha beta gamma ", 4)
+
+	// When: highlighting all three terms
+	result := engine.calculateHighlights(content, []string{"gamma", "alpha", "beta"})
+
+	// Then: the total is capped, earlier terms keep their matches,
+	// and the ranges are sorted by position
+	require.Len(t, result, 5)
+	var gamma, alpha int
+	for _, r := range result {
+		switch content[r.Start:r.End] {
+		case "gamma":
+			gamma++
+		case "alpha":
+			alpha++
+		default:
+			t.Errorf("unexpected highlight %q", content[r.Start:r.End])
+		}
+	}
+	assert.Equal(t, 4, gamma)
+	assert.Equal(t, 1, alpha)
+	assert.True(t, sort.SliceIsSorted(result, func(i, j int) bool { return result[i].Start < result[j].Start }))
+}
+
+func TestEngine_calculateHighlights_NonASCIIOffsets(t *testing.T) {
+	// Given: content where lowercasing changes byte lengths before the
+	// match: an invalid UTF-8 byte and letters whose lowercase is longer
+	// or shorter
+	engine, _, _, _, _ := setupTestEngine(t)
+	content := "\xff \u023a \u0130 Size := Größe"
+
+	// When: highlighting terms after them
+	result := engine.calculateHighlights(content, []string{"size", "größe"})
+
+	// Then: ranges cover the original text of each match
+	require.Len(t, result, 2)
+	assert.Equal(t, "Size", content[result[0].Start:result[0].End])
+	assert.Equal(t, "Größe", content[result[1].Start:result[1].End])
+}
+
+func TestEngine_calculateHighlights_DefaultCap(t *testing.T) {
+	// Given: an engine with the default config and 10 terms matching 10 times each
+	engine, _, _, _, _ := setupTestEngine(t)
+	terms := make([]string, 10)
+	var b strings.Builder
+	for i := range terms {
+		terms[i] = fmt.Sprintf("term%d", i)
+	}
+	for range 10 {
+		b.WriteString(strings.Join(terms, " ") + " ")
+	}
+
+	// When: highlighting all terms
+	result := engine.calculateHighlights(b.String(), terms)
+
+	// Then: the result is bounded by DefaultMaxHighlights
+	assert.Len(t, result, DefaultMaxHighlights)
+}
+
+func TestEngine_Search_ReportsNearDuplicates(t *testing.T) {
+	// Given: an index where two skipped chunks were near-duplicates of chunk-1
+	engine := newExportTestEngine(t)
+	ctx := context.Background()
+	nds := engine.metadata.(store.NearDuplicateStore)
+	require.NoError(t, nds.SaveChunkFingerprints(ctx, "proj", []store.ChunkFingerprint{
+		{ChunkID: "chunk-1", FilePath: "auth.go", Fingerprint: 1},
+		{ChunkID: "copy-1", FilePath: "vendor/auth.go", Fingerprint: 1, CanonicalID: "chunk-1"},
+		{ChunkID: "copy-2", FilePath: "third_party/auth.go", Fingerprint: 1, CanonicalID: "chunk-1"},
+	}))
+
+	// When: searching
+	results, err := engine.Search(ctx, "Login Logout", SearchOptions{Limit: 10})
+	require.NoError(t, err)
+
+	// Then: each result reports its near-duplicates
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Chunk.ID] = r.NearDuplicates
+	}
+	assert.Equal(t, map[string]int{"chunk-1": 2, "chunk-2": 0}, counts)
+}
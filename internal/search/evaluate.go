@@ -0,0 +1,151 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DefaultEvalK is the cutoff Evaluate uses when k is not positive.
+const DefaultEvalK = 10
+
+// ErrNoEvalCases is returned by Evaluate when given no cases.
+var ErrNoEvalCases = errors.New("no evaluation cases")
+
+// EvalCase is one query with its ground-truth relevant chunks.
+type EvalCase struct {
+	// Query is passed to Search as is.
+	Query string
+
+	// RelevantIDs are the IDs of the chunks that answer Query. Order and
+	// duplicates do not matter; relevance is binary.
+	RelevantIDs []string
+
+	// Options are the search options for this query. Limit is overridden
+	// by the evaluation cutoff k.
+	Options SearchOptions
+}
+
+// EvalCaseResult holds the metrics of one EvalCase.
+type EvalCaseResult struct {
+	Query string
+
+	// Recall is the fraction of relevant chunks in the top k.
+	Recall float64
+
+	// ReciprocalRank is 1/rank of the first relevant chunk in the top k,
+	// or 0 if there is none.
+	ReciprocalRank float64
+
+	// NDCG is the normalized discounted cumulative gain at k.
+	NDCG float64
+
+	// FirstRelevantRank is the 1-based rank of the first relevant chunk,
+	// or 0 if none is in the top k.
+	FirstRelevantRank int
+
+	// ResultIDs are the returned chunk IDs in rank order.
+	ResultIDs []string
+}
+
+// EvalReport aggregates Evaluate metrics across cases. The aggregate
+// metrics are means over cases.
+type EvalReport struct {
+	K         int
+	RecallAtK float64
+	MRR       float64
+	NDCGAtK   float64
+	Cases     []EvalCaseResult
+}
+
+// Evaluate runs each case through Search and scores the top k results
+// against its ground truth, reporting recall@k, MRR and NDCG@k. It is meant
+// for tuning and for CI gates on ranking regressions: it goes through the
+// normal Search path, so it measures what users get, and writes nothing.
+//
+// k <= 0 uses DefaultEvalK; k is still capped by EngineConfig.MaxLimit. A
+// case with no relevant IDs scores zero. The first search error aborts the
+// evaluation.
+func (e *Engine) Evaluate(ctx context.Context, cases []EvalCase, k int) (*EvalReport, error) {
+	if len(cases) == 0 {
+		return nil, ErrNoEvalCases
+	}
+	if k <= 0 {
+		k = DefaultEvalK
+	}
+
+	report := &EvalReport{K: k, Cases: make([]EvalCaseResult, 0, len(cases))}
+	for i, c := range cases {
+		opts := c.Options
+		opts.Limit = k
+		results, err := e.Search(ctx, c.Query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate case %d (%q): %w", i, c.Query, err)
+		}
+
+		ids := make([]string, 0, min(len(results), k))
+		for _, r := range results {
+			if len(ids) == k {
+				break
+			}
+			if r.Chunk != nil {
+				ids = append(ids, r.Chunk.ID)
+			}
+		}
+
+		result := scoreEvalCase(ids, c.RelevantIDs, k)
+		result.Query = c.Query
+		report.Cases = append(report.Cases, result)
+		report.RecallAtK += result.Recall
+		report.MRR += result.ReciprocalRank
+		report.NDCGAtK += result.NDCG
+	}
+
+	n := float64(len(cases))
+	report.RecallAtK /= n
+	report.MRR /= n
+	report.NDCGAtK /= n
+	return report, nil
+}
+
+// scoreEvalCase computes binary-relevance metrics for the top k ranked ids.
+func scoreEvalCase(ids, relevantIDs []string, k int) EvalCaseResult {
+	result := EvalCaseResult{ResultIDs: ids}
+
+	relevant := make(map[string]bool, len(relevantIDs))
+	for _, id := range relevantIDs {
+		relevant[id] = true
+	}
+	if len(relevant) == 0 {
+		return result
+	}
+
+	var hits int
+	var dcg float64
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		if !relevant[id] || seen[id] {
+			continue
+		}
+		seen[id] = true
+		hits++
+		dcg += 1 / math.Log2(float64(i+2))
+		if result.FirstRelevantRank == 0 {
+			result.FirstRelevantRank = i + 1
+			result.ReciprocalRank = 1 / float64(i+1)
+		}
+	}
+
+	// The ideal ranking puts every relevant chunk that fits in the cutoff first
+	var idcg float64
+	for i := range min(len(relevant), k) {
+		idcg += 1 / math.Log2(float64(i+2))
+	}
+
+	result.Recall = float64(hits) / float64(len(relevant))
+	if idcg > 0 {
+		result.NDCG = dcg / idcg
+	}
+	return result
+}
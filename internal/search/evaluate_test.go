@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreEvalCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []string
+		relevant []string
+		k        int
+		recall   float64
+		rr       float64
+		ndcg     float64
+		first    int
+	}{
+		{
+			name: "relevant first", ids: []string{"a", "b", "c"}, relevant: []string{"a"}, k: 3,
+			recall: 1, rr: 1, ndcg: 1, first: 1,
+		},
+		{
+			name: "relevant second", ids: []string{"x", "a", "c"}, relevant: []string{"a"}, k: 3,
+			recall: 1, rr: 0.5, ndcg: 1 / math.Log2(3), first: 2,
+		},
+		{
+			name: "half of relevant found", ids: []string{"a", "x"}, relevant: []string{"a", "b"}, k: 2,
+			recall: 0.5, rr: 1, ndcg: 1 / (1 + 1/math.Log2(3)), first: 1,
+		},
+		{
+			name: "nothing relevant returned", ids: []string{"x", "y"}, relevant: []string{"a"}, k: 2,
+		},
+		{
+			name: "no ground truth", ids: []string{"a"}, k: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreEvalCase(tt.ids, tt.relevant, tt.k)
+			assert.InDelta(t, tt.recall, got.Recall, 1e-9)
+			assert.InDelta(t, tt.rr, got.ReciprocalRank, 1e-9)
+			assert.InDelta(t, tt.ndcg, got.NDCG, 1e-9)
+			assert.Equal(t, tt.first, got.FirstRelevantRank)
+		})
+	}
+}
+
+func TestEngine_Evaluate_RunsCasesThroughSearch(t *testing.T) {
+	// Given: an indexed engine and cases with one answerable and one
+	// unanswerable query
+	engine := newSimilarFilesTestEngine(t)
+	cases := []EvalCase{
+		{Query: "MultiplyMatrix", RelevantIDs: []string{"matrix-1"}},
+		{Query: "MultiplyMatrix", RelevantIDs: []string{"does-not-exist"}},
+	}
+
+	// When: evaluating at k=3
+	report, err := engine.Evaluate(context.Background(), cases, 3)
+
+	// Then: per-case and mean metrics reflect the ground truth
+	require.NoError(t, err)
+	require.Len(t, report.Cases, 2)
+	assert.Equal(t, 3, report.K)
+	assert.Equal(t, 1, report.Cases[0].FirstRelevantRank)
+	assert.LessOrEqual(t, len(report.Cases[0].ResultIDs), 3)
+	assert.Zero(t, report.Cases[1].Recall)
+	assert.InDelta(t, 0.5, report.RecallAtK, 1e-9)
+	assert.InDelta(t, 0.5, report.MRR, 1e-9)
+	assert.InDelta(t, 0.5, report.NDCGAtK, 1e-9)
+}
+
+func TestEngine_Evaluate_NoCases(t *testing.T) {
+	// Given: an engine
+	engine, _, _, _, _ := setupTestEngine(t)
+
+	// When: evaluating nothing
+	_, err := engine.Evaluate(context.Background(), nil, 10)
+
+	// Then: ErrNoEvalCases is returned
+	assert.ErrorIs(t, err, ErrNoEvalCases)
+}
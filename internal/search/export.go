@@ -0,0 +1,339 @@
+package search
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// IndexArchiveFormatVersion is the layout version of index archives written
+// by Export. ImportIndex refuses archives with a different version.
+const IndexArchiveFormatVersion = 1
+
+// IndexManifestName is the archive entry holding the IndexManifest.
+// It is always the first entry.
+const IndexManifestName = "manifest.json"
+
+// Archive entry names. They match the file names in a project's data
+// directory, so an imported archive opens like a locally built index.
+const (
+	archiveMetadataName = "metadata.db"
+	archiveBM25Name     = "bm25.db"
+	archiveVectorName   = "vectors.hnsw"
+	archiveVectorMeta   = "vectors.hnsw.meta"
+)
+
+// archiveOptionalFiles are written by some vector backends only (the flat
+// store keeps everything in one file) and are archived when present.
+var archiveOptionalFiles = []string{archiveVectorMeta}
+
+// ErrIndexArchiveIncompatible is returned by ImportIndex when an archive's
+// format or schema version does not match this binary.
+var ErrIndexArchiveIncompatible = errors.New("incompatible index archive")
+
+// IndexManifest describes an exported index.
+type IndexManifest struct {
+	FormatVersion int                 `json:"format_version"`
+	SchemaVersion int                 `json:"schema_version"`
+	EmbedderModel string              `json:"embedder_model"`
+	Dimensions    int                 `json:"dimensions"`
+	VectorCount   int                 `json:"vector_count"`
+	BM25Documents int                 `json:"bm25_documents"`
+	CreatedAt     time.Time           `json:"created_at"`
+	Files         []IndexManifestFile `json:"files"`
+}
+
+// IndexManifestFile is a file in an index archive.
+type IndexManifestFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Export writes a portable tar archive of the index to w: the metadata
+// database, the BM25 index, the vector store and a manifest recording the
+// embedder model, dimensions and schema version. Writes are blocked while the
+// stores are snapshotted; searches continue.
+//
+// The metadata store and BM25 index must implement store.Snapshotter.
+func (e *Engine) Export(ctx context.Context, w io.Writer) error {
+	tmpDir, err := os.MkdirTemp("", "amanmcp-export-*")
+	if err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	manifest, err := e.snapshotIndex(ctx, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	for i := range manifest.Files {
+		f := &manifest.Files[i]
+		size, sum, err := fileDigest(filepath.Join(tmpDir, f.Name))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		f.Size, f.SHA256 = size, sum
+	}
+
+	tw := tar.NewWriter(w)
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    IndexManifestName,
+		Mode:    0644,
+		Size:    int64(len(manifestJSON)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if _, err := tw.Write(manifestJSON); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	for _, f := range manifest.Files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := addArchiveFile(tw, filepath.Join(tmpDir, f.Name), f, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// snapshotIndex writes point-in-time copies of all stores into dir.
+func (e *Engine) snapshotIndex(ctx context.Context, dir string) (*IndexManifest, error) {
+	metaSnap, ok := e.metadata.(store.Snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("metadata store %T does not support export", e.metadata)
+	}
+	bm25Snap, ok := e.bm25.(store.Snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("BM25 index %T does not support export (use the sqlite backend)", e.bm25)
+	}
+
+	// Exclusive lock: Index and Delete must not interleave with the snapshots,
+	// or the three stores would disagree.
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := metaSnap.Snapshot(ctx, filepath.Join(dir, archiveMetadataName)); err != nil {
+		return nil, err
+	}
+	if err := bm25Snap.Snapshot(ctx, filepath.Join(dir, archiveBM25Name)); err != nil {
+		return nil, err
+	}
+	if err := e.vector.Save(filepath.Join(dir, archiveVectorName)); err != nil {
+		return nil, fmt.Errorf("failed to save vectors: %w", err)
+	}
+
+	manifest := &IndexManifest{
+		FormatVersion: IndexArchiveFormatVersion,
+		SchemaVersion: store.MetadataSchemaVersion,
+		EmbedderModel: e.embedder.ModelName(),
+		Dimensions:    e.embedder.Dimensions(),
+		VectorCount:   e.vector.Count(),
+		CreatedAt:     time.Now().UTC(),
+		Files: []IndexManifestFile{
+			{Name: archiveMetadataName},
+			{Name: archiveBM25Name},
+			{Name: archiveVectorName},
+		},
+	}
+	for _, name := range archiveOptionalFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			manifest.Files = append(manifest.Files, IndexManifestFile{Name: name})
+		}
+	}
+	// Prefer the model the index was built with over the live embedder.
+	if model, err := e.metadata.GetState(ctx, store.StateKeyIndexModel); err == nil && model != "" {
+		manifest.EmbedderModel = model
+	}
+	if dim, err := e.metadata.GetState(ctx, store.StateKeyIndexDimension); err == nil {
+		if n, convErr := strconv.Atoi(dim); convErr == nil && n > 0 {
+			manifest.Dimensions = n
+		}
+	}
+	if stats := e.bm25.Stats(); stats != nil {
+		manifest.BM25Documents = stats.DocumentCount
+	}
+	return manifest, nil
+}
+
+func addArchiveFile(tw *tar.Writer, path string, f IndexManifestFile, modTime time.Time) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    f.Name,
+		Mode:    0644,
+		Size:    f.Size,
+		ModTime: modTime,
+	}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.Name, err)
+	}
+	if _, err := io.Copy(tw, file); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// ImportIndex extracts an archive written by Engine.Export into destDir
+// (a project's .amanmcp directory) and returns its manifest.
+//
+// The manifest is validated first: archives with a different format or
+// metadata schema version are refused with ErrIndexArchiveIncompatible, and
+// every file is checked against its recorded size and SHA-256. Files are
+// staged and only moved into destDir once the whole archive validates;
+// existing index files are never overwritten.
+func ImportIndex(r io.Reader, destDir string) (*IndexManifest, error) {
+	tr := tar.NewReader(r)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index archive: %w", err)
+	}
+	if hdr.Name != IndexManifestName {
+		return nil, fmt.Errorf("invalid index archive: first entry is %q, expected %s", hdr.Name, IndexManifestName)
+	}
+	var manifest IndexManifest
+	if err := json.NewDecoder(io.LimitReader(tr, 1<<20)).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid index archive manifest: %w", err)
+	}
+	if err := validateIndexManifest(&manifest); err != nil {
+		return nil, err
+	}
+
+	expected := make(map[string]IndexManifestFile, len(manifest.Files))
+	for _, f := range manifest.Files {
+		if f.Name != filepath.Base(f.Name) || f.Name == "." || f.Name == ".." || f.Name == IndexManifestName {
+			return nil, fmt.Errorf("invalid index archive: bad file name %q", f.Name)
+		}
+		if _, err := os.Stat(filepath.Join(destDir, f.Name)); err == nil {
+			return nil, fmt.Errorf("destination %s already contains %s; remove the existing index first", destDir, f.Name)
+		}
+		expected[f.Name] = f
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", destDir, err)
+	}
+	staging, err := os.MkdirTemp(destDir, ".import-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index archive: %w", err)
+		}
+		f, ok := expected[hdr.Name]
+		if !ok {
+			return nil, fmt.Errorf("invalid index archive: unexpected entry %q", hdr.Name)
+		}
+		if err := extractArchiveFile(tr, filepath.Join(staging, f.Name), f); err != nil {
+			return nil, err
+		}
+		delete(expected, hdr.Name)
+	}
+	if len(expected) > 0 {
+		missing := make([]string, 0, len(expected))
+		for name := range expected {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("invalid index archive: missing %s", strings.Join(missing, ", "))
+	}
+
+	for _, f := range manifest.Files {
+		if err := os.Rename(filepath.Join(staging, f.Name), filepath.Join(destDir, f.Name)); err != nil {
+			return nil, fmt.Errorf("failed to install %s: %w", f.Name, err)
+		}
+	}
+	return &manifest, nil
+}
+
+func validateIndexManifest(m *IndexManifest) error {
+	if m.FormatVersion != IndexArchiveFormatVersion {
+		return fmt.Errorf("%w: archive format version %d, this binary supports version %d",
+			ErrIndexArchiveIncompatible, m.FormatVersion, IndexArchiveFormatVersion)
+	}
+	if m.SchemaVersion != store.MetadataSchemaVersion {
+		return fmt.Errorf("%w: archive metadata schema version %d, this binary uses version %d; export and import with the same amanmcp version",
+			ErrIndexArchiveIncompatible, m.SchemaVersion, store.MetadataSchemaVersion)
+	}
+	if m.Dimensions <= 0 {
+		return fmt.Errorf("invalid index archive manifest: dimensions %d", m.Dimensions)
+	}
+	if len(m.Files) == 0 {
+		return fmt.Errorf("invalid index archive manifest: no files")
+	}
+	return nil
+}
+
+func extractArchiveFile(r io.Reader, path string, f IndexManifestFile) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", f.Name, err)
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(out, h), io.LimitReader(r, f.Size+1))
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", f.Name, err)
+	}
+	if n != f.Size {
+		return fmt.Errorf("invalid index archive: %s is %d bytes, manifest says %d", f.Name, n, f.Size)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != f.SHA256 {
+		return fmt.Errorf("invalid index archive: %s checksum mismatch", f.Name)
+	}
+	return nil
+}
+
+// fileDigest returns the size and hex SHA-256 of a file.
+func fileDigest(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
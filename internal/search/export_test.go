@@ -0,0 +1,256 @@
+package search
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// newExportTestEngine builds an engine over real on-disk stores with two
+// indexed chunks.
+func newExportTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	vector, err := store.NewHNSWStore(store.DefaultVectorStoreConfig(768))
+	require.NoError(t, err)
+	return newExportTestEngineWithVector(t, vector)
+}
+
+// newExportTestEngineWithVector is newExportTestEngine over the given vector
+// store.
+func newExportTestEngineWithVector(t *testing.T, vector store.VectorStore) *Engine {
+	t.Helper()
+	return newSQLiteTestEngine(t, vector, embed.NewStaticEmbedder768(),
+		[]*store.File{{ID: "file-1", ProjectID: "proj", Path: "auth.go", Language: "go"}},
+		[]*store.Chunk{
+			{ID: "chunk-1", FileID: "file-1", FilePath: "auth.go", Content: "func Login(user string) error", ContentType: store.ContentTypeCode, Language: "go"},
+			{ID: "chunk-2", FileID: "file-1", FilePath: "auth.go", Content: "func Logout(session string) error", ContentType: store.ContentTypeCode, Language: "go"},
+		})
+}
+
+func TestEngine_ExportImport_RoundTrip(t *testing.T) {
+	// Given: an engine with an indexed project
+	engine := newExportTestEngine(t)
+	ctx := context.Background()
+
+	// When: exporting and importing into an empty directory
+	var archive bytes.Buffer
+	require.NoError(t, engine.Export(ctx, &archive))
+	dest := filepath.Join(t.TempDir(), ".amanmcp")
+	manifest, err := ImportIndex(&archive, dest)
+
+	// Then: the manifest describes the index
+	require.NoError(t, err)
+	assert.Equal(t, IndexArchiveFormatVersion, manifest.FormatVersion)
+	assert.Equal(t, store.MetadataSchemaVersion, manifest.SchemaVersion)
+	assert.Equal(t, engine.embedder.ModelName(), manifest.EmbedderModel)
+	assert.Equal(t, 768, manifest.Dimensions)
+	assert.Equal(t, 2, manifest.VectorCount)
+
+	// And: the imported stores open and contain the index
+	metadata, err := store.NewSQLiteStore(filepath.Join(dest, "metadata.db"))
+	require.NoError(t, err)
+	defer func() { _ = metadata.Close() }()
+	chunk, err := metadata.GetChunk(ctx, "chunk-1")
+	require.NoError(t, err)
+	assert.Equal(t, "func Login(user string) error", chunk.Content)
+
+	bm25, err := store.NewSQLiteBM25Index(filepath.Join(dest, "bm25.db"), store.DefaultBM25Config())
+	require.NoError(t, err)
+	defer func() { _ = bm25.Close() }()
+	hits, err := bm25.Search(ctx, "Logout", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, hits)
+	assert.Equal(t, "chunk-2", hits[0].DocID)
+
+	vector, err := store.NewHNSWStore(store.DefaultVectorStoreConfig(768))
+	require.NoError(t, err)
+	defer func() { _ = vector.Close() }()
+	require.NoError(t, vector.Load(filepath.Join(dest, "vectors.hnsw")))
+	assert.Equal(t, 2, vector.Count())
+
+	// And: no staging directories are left behind
+	entries, err := os.ReadDir(dest)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.False(t, entry.IsDir(), entry.Name())
+	}
+}
+
+func TestEngine_ExportImport_FlatVectorStore(t *testing.T) {
+	// Given: an engine over the flat vector backend, which writes no .meta file
+	vector, err := store.NewFlatStore(store.DefaultVectorStoreConfig(768))
+	require.NoError(t, err)
+	engine := newExportTestEngineWithVector(t, vector)
+	ctx := context.Background()
+
+	// When: exporting and importing
+	var archive bytes.Buffer
+	require.NoError(t, engine.Export(ctx, &archive))
+	dest := filepath.Join(t.TempDir(), ".amanmcp")
+	manifest, err := ImportIndex(&archive, dest)
+
+	// Then: the archive holds only the files the backend wrote
+	require.NoError(t, err)
+	names := make([]string, 0, len(manifest.Files))
+	for _, f := range manifest.Files {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{"metadata.db", "bm25.db", "vectors.hnsw"}, names)
+
+	// And: the imported vectors load into a flat store
+	imported, err := store.NewFlatStore(store.DefaultVectorStoreConfig(768))
+	require.NoError(t, err)
+	defer func() { _ = imported.Close() }()
+	require.NoError(t, imported.Load(filepath.Join(dest, "vectors.hnsw")))
+	assert.Equal(t, 2, imported.Count())
+}
+
+func TestEngine_Export_UnsupportedStore(t *testing.T) {
+	// Given: an engine over mock stores that cannot snapshot
+	engine, _, _, _, _ := setupTestEngine(t)
+
+	// When: exporting
+	err := engine.Export(context.Background(), &bytes.Buffer{})
+
+	// Then: a clear error is returned
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support export")
+}
+
+// rewriteArchive re-encodes an exported archive, letting edit change the
+// manifest and file contents.
+func rewriteArchive(t *testing.T, archive []byte, edit func(m *IndexManifest, files map[string][]byte)) *bytes.Buffer {
+	t.Helper()
+	tr := tar.NewReader(bytes.NewReader(archive))
+	var manifest IndexManifest
+	files := make(map[string][]byte)
+	var order []string
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(tr)
+		require.NoError(t, err)
+		if hdr.Name == IndexManifestName {
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &manifest))
+			continue
+		}
+		files[hdr.Name] = buf.Bytes()
+		order = append(order, hdr.Name)
+	}
+
+	edit(&manifest, files)
+
+	var out bytes.Buffer
+	tw := tar.NewWriter(&out)
+	manifestJSON, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: IndexManifestName, Mode: 0644, Size: int64(len(manifestJSON))}))
+	_, err = tw.Write(manifestJSON)
+	require.NoError(t, err)
+	for _, name := range order {
+		data, ok := files[name]
+		if !ok {
+			continue
+		}
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}))
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return &out
+}
+
+func TestImportIndex_Rejects(t *testing.T) {
+	engine := newExportTestEngine(t)
+	var archive bytes.Buffer
+	require.NoError(t, engine.Export(context.Background(), &archive))
+
+	tests := []struct {
+		name    string
+		edit    func(m *IndexManifest, files map[string][]byte)
+		wantErr string
+		compat  bool
+	}{
+		{
+			name:    "schema version mismatch",
+			edit:    func(m *IndexManifest, _ map[string][]byte) { m.SchemaVersion = store.MetadataSchemaVersion + 1 },
+			wantErr: "metadata schema version",
+			compat:  true,
+		},
+		{
+			name:    "format version mismatch",
+			edit:    func(m *IndexManifest, _ map[string][]byte) { m.FormatVersion = 99 },
+			wantErr: "archive format version 99",
+			compat:  true,
+		},
+		{
+			name:    "corrupted file",
+			edit:    func(_ *IndexManifest, files map[string][]byte) { files["vectors.hnsw"][0] ^= 0xff },
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:    "missing file",
+			edit:    func(_ *IndexManifest, files map[string][]byte) { delete(files, "bm25.db") },
+			wantErr: "missing bm25.db",
+		},
+		{
+			name: "path traversal",
+			edit: func(m *IndexManifest, _ map[string][]byte) {
+				m.Files = append(m.Files, IndexManifestFile{Name: "../escape"})
+			},
+			wantErr: "bad file name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: a tampered archive
+			tampered := rewriteArchive(t, archive.Bytes(), tt.edit)
+			dest := filepath.Join(t.TempDir(), ".amanmcp")
+
+			// When: importing
+			_, err := ImportIndex(tampered, dest)
+
+			// Then: the import is refused and nothing is installed
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			if tt.compat {
+				assert.ErrorIs(t, err, ErrIndexArchiveIncompatible)
+			}
+			_, statErr := os.Stat(filepath.Join(dest, "metadata.db"))
+			assert.True(t, os.IsNotExist(statErr))
+		})
+	}
+}
+
+func TestImportIndex_RefusesToOverwrite(t *testing.T) {
+	// Given: a destination that already has an index
+	engine := newExportTestEngine(t)
+	var archive bytes.Buffer
+	require.NoError(t, engine.Export(context.Background(), &archive))
+	dest := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "metadata.db"), []byte("existing"), 0644))
+
+	// When: importing
+	_, err := ImportIndex(&archive, dest)
+
+	// Then: the existing index is left alone
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already contains metadata.db")
+	data, readErr := os.ReadFile(filepath.Join(dest, "metadata.db"))
+	require.NoError(t, readErr)
+	assert.Equal(t, "existing", string(data))
+}
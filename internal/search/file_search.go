@@ -0,0 +1,233 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// In-file BM25 parameters (Okapi defaults, as used by the BM25 index).
+const (
+	fileBM25K1 = 1.2
+	fileBM25B  = 0.75
+)
+
+// SearchInFile ranks the chunks of the file at path by relevance to query,
+// for "explain this file" workflows. Only that file's chunks are scored, so
+// other files never crowd out the results and the cost is proportional to
+// the file, not the index.
+//
+// Chunks are scored with BM25 computed over the file's chunks alone and by
+// cosine similarity between the query embedding and each chunk's stored
+// embedding; the two rankings are combined with the engine's fusion
+// strategy. Without a vector index or stored embeddings, or when embedding
+// the query fails, ranking falls back to BM25 only.
+//
+// opts.Limit and opts.Weights apply as in Search; other options are ignored,
+// including opts.Embedder, because stored embeddings come from the engine's
+// embedder. Chunks that match neither ranking are omitted. Returns
+// ErrFileNotIndexed if path has no indexed chunks.
+func (e *Engine) SearchInFile(ctx context.Context, projectID, path, query string, opts SearchOptions) ([]*SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+	opts = e.applyDefaults(opts)
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	file, err := e.metadata.GetFileByPath(ctx, projectID, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %s: %w", path, err)
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotIndexed, path)
+	}
+	chunks, err := e.metadata.GetChunksByFile(ctx, file.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunks for %s: %w", path, err)
+	}
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotIndexed, path)
+	}
+
+	bm25Results := fileBM25(query, chunks)
+	vecResults, err := e.fileVectorResults(ctx, query, chunks)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		slog.Debug("search_in_file_vector_skipped",
+			slog.String("path", path),
+			slog.String("error", err.Error()))
+	}
+
+	byID := make(map[string]*store.Chunk, len(chunks))
+	for _, c := range chunks {
+		byID[c.ID] = c
+	}
+
+	fused := e.fusion.Fuse(bm25Results, vecResults, *opts.Weights)
+	results := make([]*SearchResult, 0, min(len(fused), opts.Limit))
+	for _, f := range fused {
+		if len(results) == opts.Limit {
+			break
+		}
+		c, ok := byID[f.ChunkID]
+		if !ok {
+			continue
+		}
+		results = append(results, &SearchResult{
+			Chunk:        c,
+			Score:        f.RRFScore,
+			RawScore:     f.RawScore,
+			BM25Score:    f.BM25Score,
+			VecScore:     f.VecScore,
+			BM25Rank:     f.BM25Rank,
+			VecRank:      f.VecRank,
+			InBothLists:  f.InBothLists,
+			MatchedTerms: f.MatchedTerms,
+			Highlights:   e.calculateHighlights(c.Content, f.MatchedTerms),
+		})
+	}
+	setResultSizes(results)
+
+	return results, nil
+}
+
+// fileVectorResults scores chunks by cosine similarity between the query
+// embedding and their stored embeddings, best first. It returns no results
+// and no error when there are no vectors to compare.
+func (e *Engine) fileVectorResults(ctx context.Context, query string, chunks []*store.Chunk) ([]*store.VectorResult, error) {
+	if e.vector.Count() == 0 {
+		return nil, nil
+	}
+	if err := e.validateDimensions(ctx); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(chunks))
+	for i, c := range chunks {
+		ids[i] = c.ID
+	}
+	embeddings, err := e.StoredEmbeddings(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embeddings: %w", err)
+	}
+	if len(embeddings) == 0 {
+		return nil, nil
+	}
+
+	queryVec, err := e.embed(ctx, e.embedder, e.formatQueryForEmbedding(query, SearchOptions{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	results := make([]*store.VectorResult, 0, len(embeddings))
+	for _, id := range ids {
+		v, ok := embeddings[id]
+		if !ok || len(v) != len(queryVec) {
+			continue
+		}
+		sim, ok := cosine(queryVec, v)
+		if !ok {
+			continue
+		}
+		// Same distance/score mapping as the cosine HNSW index
+		distance := float32(1 - sim)
+		results = append(results, &store.VectorResult{
+			ID:       id,
+			Distance: distance,
+			Score:    1 - distance/2,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results, nil
+}
+
+// cosine returns the cosine similarity of a and b. ok is false if either
+// vector has zero length.
+func cosine(a, b []float32) (sim float64, ok bool) {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0, false
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB)), true
+}
+
+// fileBM25 scores chunks against query with BM25, using the chunks
+// themselves as the corpus. Chunks matching no query term are omitted;
+// results are sorted best first.
+func fileBM25(query string, chunks []*store.Chunk) []*store.BM25Result {
+	queryTerms := store.TokenizeCode(query)
+	slices.Sort(queryTerms)
+	queryTerms = slices.Compact(queryTerms)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	termFreqs := make([]map[string]int, len(chunks))
+	lengths := make([]int, len(chunks))
+	docFreq := make(map[string]int, len(queryTerms))
+	var totalLength int
+	for i, c := range chunks {
+		tokens := store.TokenizeCode(store.BM25DocumentContent(c.FilePath, c.Content))
+		tf := make(map[string]int)
+		for _, t := range tokens {
+			tf[t]++
+		}
+		for _, q := range queryTerms {
+			if tf[q] > 0 {
+				docFreq[q]++
+			}
+		}
+		termFreqs[i] = tf
+		lengths[i] = len(tokens)
+		totalLength += len(tokens)
+	}
+	avgLength := float64(totalLength) / float64(len(chunks))
+	if avgLength == 0 {
+		return nil
+	}
+
+	n := float64(len(chunks))
+	var results []*store.BM25Result
+	for i, c := range chunks {
+		var score float64
+		var matched []string
+		for _, q := range queryTerms {
+			tf := float64(termFreqs[i][q])
+			if tf == 0 {
+				continue
+			}
+			df := float64(docFreq[q])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			norm := fileBM25K1 * (1 - fileBM25B + fileBM25B*float64(lengths[i])/avgLength)
+			score += idf * tf * (fileBM25K1 + 1) / (tf + norm)
+			matched = append(matched, q)
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		results = append(results, &store.BM25Result{DocID: c.ID, Score: score, MatchedTerms: matched})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEngine_SearchInFile_RanksOnlyTargetFileChunks(t *testing.T) {
+	// Given: an index where login.go also mentions session tokens
+	engine := newSimilarFilesTestEngine(t)
+
+	// When: searching auth.go for session token handling
+	results, err := engine.SearchInFile(context.Background(), "proj", "auth.go", "session token", SearchOptions{})
+
+	// Then: only auth.go chunks are returned, the session chunk first
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "auth-2", results[0].Chunk.ID)
+	assert.Positive(t, results[0].BM25Score)
+	assert.Positive(t, results[0].VecScore)
+	assert.NotEmpty(t, results[0].Highlights)
+	for i, r := range results {
+		assert.Equal(t, "auth.go", r.Chunk.FilePath)
+		if i > 0 {
+			assert.GreaterOrEqual(t, results[i-1].Score, r.Score)
+		}
+	}
+}
+
+func TestEngine_SearchInFile_RespectsLimit(t *testing.T) {
+	// Given: an indexed file with two chunks
+	engine := newSimilarFilesTestEngine(t)
+
+	// When: searching it with a limit of one
+	results, err := engine.SearchInFile(context.Background(), "proj", "auth.go", "user", SearchOptions{Limit: 1})
+
+	// Then: a single chunk is returned
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
+func TestEngine_SearchInFile_UnknownFile(t *testing.T) {
+	// Given: an indexed engine
+	engine := newSimilarFilesTestEngine(t)
+
+	// When: searching a file that is not indexed
+	_, err := engine.SearchInFile(context.Background(), "proj", "missing.go", "user", SearchOptions{})
+
+	// Then: ErrFileNotIndexed is returned
+	assert.ErrorIs(t, err, ErrFileNotIndexed)
+}
+
+func TestFileBM25_ScoresWithinChunks(t *testing.T) {
+	// Given: three chunks of one file, one mentioning the query term twice
+	chunks := []*store.Chunk{
+		{ID: "a", FilePath: "f.go", Content: "func parseConfig() { parseConfig() }"},
+		{ID: "b", FilePath: "f.go", Content: "func parseConfigFile() {}"},
+		{ID: "c", FilePath: "f.go", Content: "func render() {}"},
+	}
+
+	// When: scoring them for "parseConfig"
+	results := fileBM25("parseConfig", chunks)
+
+	// Then: the unrelated chunk is omitted and the denser match ranks first
+	require.Len(t, results, 2)
+	assert.Equal(t, "a", results[0].DocID)
+	assert.Equal(t, "b", results[1].DocID)
+	assert.ElementsMatch(t, []string{"parse", "config"}, results[0].MatchedTerms)
+}
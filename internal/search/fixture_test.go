@@ -0,0 +1,36 @@
+package search
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// newSQLiteTestEngine builds an engine over on-disk SQLite metadata and BM25
+// stores in a temporary directory and the given vector store, saves files
+// under project "proj" and indexes chunks. The engine is closed when the
+// test ends.
+func newSQLiteTestEngine(t *testing.T, vector store.VectorStore, embedder embed.Embedder, files []*store.File, chunks []*store.Chunk) *Engine {
+	t.Helper()
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	metadata, err := store.NewSQLiteStore(filepath.Join(dir, "metadata.db"))
+	require.NoError(t, err)
+	bm25, err := store.NewSQLiteBM25Index(filepath.Join(dir, "bm25.db"), store.DefaultBM25Config())
+	require.NoError(t, err)
+
+	require.NoError(t, metadata.SaveProject(ctx, &store.Project{ID: "proj", Name: "proj", RootPath: dir}))
+	require.NoError(t, metadata.SaveFiles(ctx, files))
+
+	engine := New(bm25, vector, embedder, metadata, DefaultConfig())
+	t.Cleanup(func() { _ = engine.Close() })
+
+	require.NoError(t, engine.Index(ctx, chunks))
+	return engine
+}
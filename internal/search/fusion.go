@@ -3,7 +3,9 @@
 package search
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/Aman-CERP/amanmcp/internal/store"
 )
@@ -16,6 +18,7 @@ const DefaultRRFConstant = 60
 type FusedResult struct {
 	ChunkID      string   // Chunk identifier
 	RRFScore     float64  // Combined RRF score (normalized 0-1)
+	RawScore     float64  // Combined score before normalization (comparable across queries)
 	BM25Score    float64  // Original BM25 score (preserved)
 	BM25Rank     int      // Position in BM25 list (1-indexed, 0 if absent)
 	VecScore     float64  // Original vector similarity score (preserved)
@@ -24,6 +27,52 @@ type FusedResult struct {
 	MatchedTerms []string // BM25 matched terms (for highlighting)
 }
 
+// FusionStrategy combines BM25 and vector result lists into a single ranking.
+//
+// Implementations must return results sorted best-first with scores normalized
+// to the 0-1 range, and must preserve per-source scores and ranks. RawScore
+// carries the score each result had before normalization.
+//
+// Tradeoffs between the built-in strategies:
+//   - RRFFusion (default): rank-based. Robust to score scale differences between
+//     BM25 and cosine similarity, but ignores how much better one hit is than the next.
+//   - WeightedScoreFusion: magnitude-aware. Normalizes each list's scores and takes
+//     a weighted sum, so a dominant hit keeps its lead; more sensitive to outliers
+//     and needs weight tuning per corpus.
+type FusionStrategy interface {
+	Fuse(bm25 []*store.BM25Result, vec []*store.VectorResult, weights Weights) []*FusedResult
+}
+
+// FusionStrategyName identifies a built-in fusion strategy in configuration.
+type FusionStrategyName string
+
+const (
+	// FusionStrategyRRF selects Reciprocal Rank Fusion (default).
+	FusionStrategyRRF FusionStrategyName = "rrf"
+
+	// FusionStrategyWeighted selects weighted score fusion.
+	FusionStrategyWeighted FusionStrategyName = "weighted"
+)
+
+// NewFusionStrategy returns the built-in strategy for name.
+// Empty name selects RRF so existing configurations are unchanged.
+func NewFusionStrategy(name FusionStrategyName, rrfConstant int, normalization ScoreNormalization) (FusionStrategy, error) {
+	switch FusionStrategyName(strings.ToLower(strings.TrimSpace(string(name)))) {
+	case "", FusionStrategyRRF:
+		return NewRRFFusionWithK(rrfConstant), nil
+	case FusionStrategyWeighted:
+		return NewWeightedScoreFusion(normalization)
+	default:
+		return nil, fmt.Errorf("unknown fusion strategy %q (valid options: rrf, weighted)", name)
+	}
+}
+
+// Ensure built-in strategies implement FusionStrategy.
+var (
+	_ FusionStrategy = (*RRFFusion)(nil)
+	_ FusionStrategy = (*WeightedScoreFusion)(nil)
+)
+
 // RRFFusion combines BM25 and vector search results using
 // Reciprocal Rank Fusion algorithm.
 //
@@ -33,6 +82,13 @@ type FusedResult struct {
 //   - k = smoothing constant (default: 60)
 //   - rank_i = position in ranked list i (1-indexed)
 //   - weight_i = weight for search source i
+//
+// Normalization: RRFScore = RRF_score(d) / max_d' RRF_score(d'), so the top
+// result of every query scores 1.0 and scores are only comparable within one
+// query. RawScore keeps RRF_score(d), which depends only on ranks, weights
+// and k, and is bounded by (weight_bm25 + weight_semantic) / (k + 1). Clients
+// applying an absolute threshold across queries should use RawScore with a
+// fixed k (ExplainData.RRFConstant reports the k in use).
 type RRFFusion struct {
 	K int // RRF smoothing constant (default: 60)
 }
@@ -109,7 +165,10 @@ func (f *RRFFusion) Fuse(
 	// Convert to sorted slice
 	results := f.toSortedSlice(scores)
 
-	// Normalize scores to 0-1 range
+	// Keep the raw score, then normalize scores to 0-1 range
+	for _, r := range results {
+		r.RawScore = r.RRFScore
+	}
 	f.normalize(results)
 
 	return results
@@ -150,13 +209,19 @@ func (f *RRFFusion) toSortedSlice(m map[string]*FusedResult) []*FusedResult {
 
 // compare implements deterministic comparison for sorting.
 // Returns true if a should rank before b.
+func (f *RRFFusion) compare(a, b *FusedResult) bool {
+	return compareFusedResults(a, b)
+}
+
+// compareFusedResults is the shared deterministic ordering for fused results.
+// Returns true if a should rank before b.
 //
 // Priority:
-//  1. Higher RRF score
+//  1. Higher fused score
 //  2. In both lists (true before false)
 //  3. Higher BM25 score (exact match indicator)
 //  4. Lexicographically smaller ChunkID (deterministic)
-func (f *RRFFusion) compare(a, b *FusedResult) bool {
+func compareFusedResults(a, b *FusedResult) bool {
 	// Primary: Higher RRF score ranks first
 	if a.RRFScore != b.RRFScore {
 		return a.RRFScore > b.RRFScore
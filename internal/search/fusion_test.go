@@ -285,6 +285,38 @@ func TestRRFFusion_ScoreNormalization(t *testing.T) {
 	assert.InDelta(t, 0.60, resultMap["C"].VecScore, 0.001)
 }
 
+func TestRRFFusion_RawScoreComparableAcrossQueries(t *testing.T) {
+	// Given: two queries whose top hits rank very differently
+	weights := DefaultWeights()
+	fusion := NewRRFFusion()
+	strong := fusion.Fuse(
+		createBM25Results([]string{"A", "B"}, []float64{10.0, 5.0}),
+		createVecResults([]string{"A", "B"}, []float32{0.95, 0.80}),
+		weights,
+	)
+	weak := fusion.Fuse(
+		createBM25Results([]string{"X"}, []float64{1.0}),
+		createVecResults([]string{"Y"}, []float32{0.40}),
+		weights,
+	)
+
+	// Then: both top hits normalize to 1.0
+	require.NotEmpty(t, strong)
+	require.NotEmpty(t, weak)
+	assert.Equal(t, 1.0, strong[0].RRFScore)
+	assert.Equal(t, 1.0, weak[0].RRFScore)
+
+	// And: raw scores keep Σ weight/(k+rank) and separate the two queries
+	k := float64(DefaultRRFConstant)
+	assert.InDelta(t, (weights.BM25+weights.Semantic)/(k+1), strong[0].RawScore, 1e-12)
+	assert.Greater(t, strong[0].RawScore, weak[0].RawScore)
+
+	// And: normalized score is raw divided by the query's best raw score
+	for _, r := range strong {
+		assert.InDelta(t, r.RawScore/strong[0].RawScore, r.RRFScore, 1e-12)
+	}
+}
+
 // --- TS08: Weight Sensitivity ---
 // Tests: AC01 (weighted fusion)
 
@@ -669,3 +701,81 @@ func BenchmarkRRFFusion_1000x1000(b *testing.B) {
 		fusion.Fuse(bm25, vec, weights)
 	}
 }
+
+// --- Pluggable fusion strategies ---
+
+func TestNewFusionStrategy_SelectsBuiltins(t *testing.T) {
+	// Given/When: empty and explicit strategy names
+	def, err := NewFusionStrategy("", 30, "")
+	require.NoError(t, err)
+	rrf, err := NewFusionStrategy(FusionStrategyRRF, 30, "")
+	require.NoError(t, err)
+	weighted, err := NewFusionStrategy(FusionStrategyWeighted, 30, ScoreNormalizationZScore)
+	require.NoError(t, err)
+
+	// Then: RRF is the default and weighted carries its normalization
+	require.IsType(t, &RRFFusion{}, def)
+	assert.Equal(t, 30, def.(*RRFFusion).K)
+	require.IsType(t, &RRFFusion{}, rrf)
+	require.IsType(t, &WeightedScoreFusion{}, weighted)
+	assert.Equal(t, ScoreNormalizationZScore, weighted.(*WeightedScoreFusion).Normalization)
+}
+
+func TestNewFusionStrategy_UnknownName_ReturnsError(t *testing.T) {
+	_, err := NewFusionStrategy("borda", 60, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "borda")
+
+	_, err = NewFusionStrategy(FusionStrategyWeighted, 60, "log")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log")
+}
+
+func TestWeightedScoreFusion_PreservesScoreGap(t *testing.T) {
+	// Given: BM25 has a dominant hit A far ahead of B; vector ranks B just above A
+	bm25 := createBM25Results([]string{"A", "B", "C"}, []float64{20.0, 1.0, 0.5})
+	vec := createVecResults([]string{"B", "A", "C"}, []float32{0.81, 0.80, 0.10})
+	weights := Weights{BM25: 0.5, Semantic: 0.5}
+
+	// When: fusing with weighted min-max normalization
+	f, err := NewWeightedScoreFusion("")
+	require.NoError(t, err)
+	results := f.Fuse(bm25, vec, weights)
+
+	// Then: A keeps its lead because the BM25 gap outweighs the tiny vector gap
+	require.Len(t, results, 3)
+	assert.Equal(t, "A", results[0].ChunkID)
+	assert.InDelta(t, 1.0, results[0].RRFScore, 1e-9)
+	assert.Equal(t, "B", results[1].ChunkID)
+	assert.Less(t, results[1].RRFScore, 0.6)
+	assert.True(t, results[0].InBothLists)
+	assert.Equal(t, 20.0, results[0].BM25Score)
+	assert.Equal(t, 2, results[0].VecRank)
+}
+
+func TestWeightedScoreFusion_ZScoreAndSingleList(t *testing.T) {
+	// Given: only BM25 results
+	bm25 := createBM25Results([]string{"A", "B", "C"}, []float64{3.0, 2.0, 1.0})
+
+	// When: fusing with z-score normalization
+	f, err := NewWeightedScoreFusion(ScoreNormalizationZScore)
+	require.NoError(t, err)
+	results := f.Fuse(bm25, nil, DefaultWeights())
+
+	// Then: order follows BM25, scores are in 0-1, lowest scorer lands at 0
+	require.Len(t, results, 3)
+	assert.Equal(t, []string{"A", "B", "C"}, []string{results[0].ChunkID, results[1].ChunkID, results[2].ChunkID})
+	assert.InDelta(t, 1.0, results[0].RRFScore, 1e-9)
+	assert.InDelta(t, 0.5, results[1].RRFScore, 1e-9)
+	assert.InDelta(t, 0.0, results[2].RRFScore, 1e-9)
+}
+
+func TestWeightedScoreFusion_EmptyInputs_ReturnsEmptySlice(t *testing.T) {
+	f, err := NewWeightedScoreFusion(ScoreNormalizationMinMax)
+	require.NoError(t, err)
+
+	results := f.Fuse(nil, nil, DefaultWeights())
+
+	assert.NotNil(t, results)
+	assert.Empty(t, results)
+}
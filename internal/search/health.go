@@ -0,0 +1,179 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// HealthLevel is the outcome of a health check.
+type HealthLevel string
+
+const (
+	// HealthOK means every component works.
+	HealthOK HealthLevel = "ok"
+	// HealthDegraded means search works with reduced quality, e.g. BM25-only
+	// because the embedder is down or no longer matches the index.
+	HealthDegraded HealthLevel = "degraded"
+	// HealthUnhealthy means search cannot serve queries.
+	HealthUnhealthy HealthLevel = "unhealthy"
+)
+
+// Health check component names, as reported in ComponentHealth.Name.
+const (
+	HealthComponentMetadata   = "metadata"
+	HealthComponentBM25       = "bm25"
+	HealthComponentEmbedder   = "embedder"
+	HealthComponentDimensions = "dimensions"
+	HealthComponentVectors    = "vectors"
+)
+
+// ErrUnhealthy is returned by HealthCheck when search cannot serve queries.
+var ErrUnhealthy = errors.New("search engine unhealthy")
+
+// healthProbeText is embedded to check the embedder responds.
+const healthProbeText = "health check"
+
+// ComponentHealth is the health of one part of the engine.
+type ComponentHealth struct {
+	Name   string
+	Status HealthLevel
+	Detail string // Why the component is not ok; empty when it is
+}
+
+// HealthStatus is the result of Engine.HealthCheck. Status is the worst
+// status of its components.
+type HealthStatus struct {
+	Status     HealthLevel
+	Components []ComponentHealth
+}
+
+// HealthCheck verifies that search works end to end, for readiness probes:
+// the metadata store is reachable, the BM25 index answers, the embedder
+// embeds a short probe text, its dimensions match the index, and the vector
+// store holds vectors when metadata says chunks were embedded. It reads only
+// and embeds one short text, so it is cheap enough to call often.
+//
+// The returned status is always non-nil. A failing metadata store or BM25
+// index makes it HealthUnhealthy and the error wraps ErrUnhealthy; embedder
+// and vector problems only make it HealthDegraded, since search then falls
+// back to BM25, and the error is nil.
+func (e *Engine) HealthCheck(ctx context.Context) (*HealthStatus, error) {
+	// Store reads are checked under the read lock; the embedder probe is a
+	// network call, so it runs after unlocking to not stall Index and Delete.
+	e.mu.RLock()
+	embedder := e.embedder
+	metadataErr := e.checkMetadataHealth(ctx)
+	bm25Err := e.checkBM25Health()
+	var dimensionErr, vectorErr error
+	if metadataErr == nil {
+		dimensionErr = e.checkDimensionHealth(ctx)
+		vectorErr = e.checkVectorHealth(ctx)
+	}
+	e.mu.RUnlock()
+
+	embedderErr := checkEmbedderHealth(ctx, embedder)
+
+	status := &HealthStatus{Status: HealthOK}
+	metadataOK := status.add(HealthComponentMetadata, metadataErr, HealthUnhealthy)
+	status.add(HealthComponentBM25, bm25Err, HealthUnhealthy)
+	status.add(HealthComponentEmbedder, embedderErr, HealthDegraded)
+	if metadataOK {
+		status.add(HealthComponentDimensions, dimensionErr, HealthDegraded)
+		status.add(HealthComponentVectors, vectorErr, HealthDegraded)
+	}
+
+	if status.Status == HealthUnhealthy {
+		for _, c := range status.Components {
+			if c.Status == HealthUnhealthy {
+				return status, fmt.Errorf("%w: %s: %s", ErrUnhealthy, c.Name, c.Detail)
+			}
+		}
+	}
+	return status, nil
+}
+
+// add records a component as ok when err is nil and at level otherwise, and
+// reports whether it was ok.
+func (s *HealthStatus) add(name string, err error, level HealthLevel) bool {
+	c := ComponentHealth{Name: name, Status: HealthOK}
+	if err != nil {
+		c.Status = level
+		c.Detail = err.Error()
+		s.downgrade(level)
+	}
+	s.Components = append(s.Components, c)
+	return err == nil
+}
+
+// downgrade lowers the overall status to level if it is worse.
+func (s *HealthStatus) downgrade(level HealthLevel) {
+	if level == HealthUnhealthy || (level == HealthDegraded && s.Status == HealthOK) {
+		s.Status = level
+	}
+}
+
+func (e *Engine) checkMetadataHealth(ctx context.Context) error {
+	if pinger, ok := e.metadata.(store.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	if _, err := e.metadata.GetState(ctx, store.StateKeyIndexDimension); err != nil {
+		return fmt.Errorf("failed to read state: %w", err)
+	}
+	return nil
+}
+
+func (e *Engine) checkBM25Health() error {
+	if e.bm25.Stats() == nil {
+		return errors.New("no index statistics")
+	}
+	return nil
+}
+
+func checkEmbedderHealth(ctx context.Context, embedder embed.Embedder) error {
+	if !embedder.Available(ctx) {
+		return fmt.Errorf("embedder %s unavailable", embedder.ModelName())
+	}
+	vec, err := embedder.Embed(ctx, healthProbeText)
+	if err != nil {
+		return fmt.Errorf("failed to embed probe: %w", err)
+	}
+	if len(vec) != embedder.Dimensions() {
+		return fmt.Errorf("probe embedding has %d dimensions, embedder reports %d", len(vec), embedder.Dimensions())
+	}
+	return nil
+}
+
+func (e *Engine) checkDimensionHealth(ctx context.Context) error {
+	stored, err := e.metadata.GetState(ctx, store.StateKeyIndexDimension)
+	if err != nil {
+		return fmt.Errorf("failed to read index dimension: %w", err)
+	}
+	if stored == "" {
+		return nil // Legacy index without a recorded dimension
+	}
+	indexDim, err := strconv.Atoi(stored)
+	if err != nil {
+		return fmt.Errorf("invalid stored index dimension %q", stored)
+	}
+	if indexDim != e.embedder.Dimensions() {
+		return fmt.Errorf("%w: index has %d dimensions, embedder has %d",
+			ErrDimensionMismatch, indexDim, e.embedder.Dimensions())
+	}
+	return nil
+}
+
+func (e *Engine) checkVectorHealth(ctx context.Context) error {
+	embedded, _, err := e.metadata.GetEmbeddingStats(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get embedding stats: %w", err)
+	}
+	if embedded > 0 && e.vector.Count() == 0 {
+		return fmt.Errorf("vector store is empty but %d chunks have embeddings", embedded)
+	}
+	return nil
+}
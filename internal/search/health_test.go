@@ -0,0 +1,149 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// newHealthTestEngine returns an engine over an in-memory metadata store
+// holding one embedded chunk of a 768-dimension index.
+func newHealthTestEngine(t *testing.T, vectors int, embedder *MockEmbedder, metadata store.MetadataStore) *Engine {
+	t.Helper()
+	ctx := context.Background()
+	if metadata == nil {
+		mem := store.NewMemoryStore()
+		require.NoError(t, mem.SaveProject(ctx, &store.Project{ID: "p"}))
+		require.NoError(t, mem.SaveFiles(ctx, []*store.File{{ID: "f", ProjectID: "p", Path: "a.go"}}))
+		require.NoError(t, mem.SaveChunks(ctx, []*store.Chunk{{ID: "c", FileID: "f", FilePath: "a.go"}}))
+		require.NoError(t, mem.SaveChunkEmbeddings(ctx, []string{"c"}, [][]float32{make([]float32, 768)}, "mock-embedder"))
+		require.NoError(t, mem.SetState(ctx, store.StateKeyIndexDimension, "768"))
+		metadata = mem
+	}
+	vector := &MockVectorStore{CountFn: func() int { return vectors }}
+	return New(&MockBM25Index{}, vector, embedder, metadata, DefaultConfig())
+}
+
+func componentStatus(status *HealthStatus, name string) HealthLevel {
+	for _, c := range status.Components {
+		if c.Name == name {
+			return c.Status
+		}
+	}
+	return ""
+}
+
+func TestEngine_HealthCheck_OK(t *testing.T) {
+	// Given: a fully working engine
+	engine := newHealthTestEngine(t, 1, &MockEmbedder{}, nil)
+
+	// When: checking health
+	status, err := engine.HealthCheck(context.Background())
+
+	// Then: every component is ok
+	require.NoError(t, err)
+	assert.Equal(t, HealthOK, status.Status)
+	assert.Len(t, status.Components, 5)
+	for _, c := range status.Components {
+		assert.Equal(t, HealthOK, c.Status, c.Name)
+		assert.Empty(t, c.Detail, c.Name)
+	}
+}
+
+func TestEngine_HealthCheck_Degraded(t *testing.T) {
+	tests := []struct {
+		name      string
+		vectors   int
+		embedder  *MockEmbedder
+		component string
+	}{
+		{
+			name:    "embedder fails the probe",
+			vectors: 1,
+			embedder: &MockEmbedder{EmbedFn: func(context.Context, string) ([]float32, error) {
+				return nil, errors.New("connection refused")
+			}},
+			component: HealthComponentEmbedder,
+		},
+		{
+			name:    "embedder dimensions differ from the index",
+			vectors: 1,
+			embedder: &MockEmbedder{
+				DimensionsFn: func() int { return 384 },
+				EmbedFn: func(context.Context, string) ([]float32, error) {
+					return make([]float32, 384), nil
+				},
+			},
+			component: HealthComponentDimensions,
+		},
+		{
+			name:      "vector store empty although chunks were embedded",
+			vectors:   0,
+			embedder:  &MockEmbedder{},
+			component: HealthComponentVectors,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: an engine that can still serve BM25 results
+			engine := newHealthTestEngine(t, tt.vectors, tt.embedder, nil)
+
+			// When: checking health
+			status, err := engine.HealthCheck(context.Background())
+
+			// Then: it is degraded, not failed, and names the component
+			require.NoError(t, err)
+			assert.Equal(t, HealthDegraded, status.Status)
+			assert.Equal(t, HealthDegraded, componentStatus(status, tt.component))
+		})
+	}
+}
+
+func TestEngine_HealthCheck_MetadataDown(t *testing.T) {
+	// Given: a metadata store that cannot be read
+	metadata := NewMockMetadataStore()
+	metadata.GetStateFn = func(context.Context, string) (string, error) {
+		return "", errors.New("database is locked")
+	}
+	engine := newHealthTestEngine(t, 1, &MockEmbedder{}, metadata)
+
+	// When: checking health
+	status, err := engine.HealthCheck(context.Background())
+
+	// Then: the engine is unhealthy and the error says why
+	require.ErrorIs(t, err, ErrUnhealthy)
+	assert.Contains(t, err.Error(), "database is locked")
+	require.NotNil(t, status)
+	assert.Equal(t, HealthUnhealthy, status.Status)
+	assert.Equal(t, HealthUnhealthy, componentStatus(status, HealthComponentMetadata))
+}
+
+func TestEngine_HealthCheck_ProbesEmbedderWithoutLock(t *testing.T) {
+	// Given: an embedder whose probe waits for a write to the engine
+	var engine *Engine
+	embedder := &MockEmbedder{EmbedFn: func(ctx context.Context, _ string) ([]float32, error) {
+		done := make(chan error, 1)
+		go func() { done <- engine.Delete(ctx, []string{"c"}) }()
+		select {
+		case err := <-done:
+			return make([]float32, 768), err
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("write blocked during probe")
+		}
+	}}
+	engine = newHealthTestEngine(t, 1, embedder, nil)
+
+	// When: checking health
+	status, err := engine.HealthCheck(context.Background())
+
+	// Then: the write completed while the embedder was probed
+	require.NoError(t, err)
+	assert.Equal(t, HealthOK, componentStatus(status, HealthComponentEmbedder))
+}
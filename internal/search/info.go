@@ -0,0 +1,106 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// IndexInfo describes the index behind an engine: what it was built with,
+// how much it holds, and whether the engine's embedder can query it.
+type IndexInfo struct {
+	// Embedder recorded when the index was built. Empty or zero for legacy
+	// indexes that predate dimension tracking.
+	IndexModel      string
+	IndexDimensions int
+
+	// Embedder currently configured on the engine.
+	CurrentModel      string
+	CurrentDimensions int
+
+	// DimensionsMatch reports whether the current embedder can query the
+	// index. It is true when the index has no recorded dimension.
+	DimensionsMatch bool
+
+	// ModelMatches reports whether the current embedder is the one the index
+	// was built with. Same-dimension models still produce incomparable
+	// vectors, so a false value calls for a reindex.
+	ModelMatches bool
+
+	// Counts across the metadata, BM25 and vector stores. Disagreement
+	// between Chunks, BM25Documents and Vectors points at an interrupted
+	// index run or pending compaction.
+	Files         int
+	Chunks        int
+	BM25Documents int
+	Vectors       int
+
+	// Embedding coverage from metadata (see MetadataStore.GetEmbeddingStats).
+	ChunksWithEmbedding    int
+	ChunksWithoutEmbedding int
+
+	// LastIndexedAt is the most recent project index time, zero if unknown.
+	LastIndexedAt time.Time
+}
+
+// Info assembles an IndexInfo from the engine's stores. File counts and the
+// last index time need a metadata store that implements store.ProjectLister;
+// without one they are left zero.
+func (e *Engine) Info(ctx context.Context) (*IndexInfo, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	info := &IndexInfo{
+		CurrentModel:      e.embedder.ModelName(),
+		CurrentDimensions: e.embedder.Dimensions(),
+		Vectors:           e.vector.Count(),
+	}
+
+	model, err := e.metadata.GetState(ctx, store.StateKeyIndexModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index model: %w", err)
+	}
+	info.IndexModel = model
+
+	dim, err := e.metadata.GetState(ctx, store.StateKeyIndexDimension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index dimension: %w", err)
+	}
+	if dim != "" {
+		if info.IndexDimensions, err = strconv.Atoi(dim); err != nil {
+			return nil, fmt.Errorf("invalid stored index dimension %q: %w", dim, err)
+		}
+	}
+	info.DimensionsMatch = info.IndexDimensions == 0 || info.IndexDimensions == info.CurrentDimensions
+	info.ModelMatches = info.IndexModel == "" || info.IndexModel == info.CurrentModel
+
+	with, without, err := e.metadata.GetEmbeddingStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedding stats: %w", err)
+	}
+	info.ChunksWithEmbedding = with
+	info.ChunksWithoutEmbedding = without
+	info.Chunks = with + without
+
+	if stats := e.bm25.Stats(); stats != nil {
+		info.BM25Documents = stats.DocumentCount
+	}
+
+	if lister, ok := e.metadata.(store.ProjectLister); ok {
+		projects, err := lister.ListProjects(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		for _, p := range projects {
+			info.Files += p.FileCount
+			if p.IndexedAt.After(info.LastIndexedAt) {
+				info.LastIndexedAt = p.IndexedAt
+			}
+		}
+	}
+
+	return info, nil
+}
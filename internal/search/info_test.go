@@ -0,0 +1,86 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEngine_Info(t *testing.T) {
+	// Given: an engine with an indexed project whose stats are up to date
+	engine := newExportTestEngine(t)
+	ctx := context.Background()
+	metadata := engine.metadata.(*store.SQLiteStore)
+	require.NoError(t, metadata.RefreshProjectStats(ctx, "proj"))
+	project, err := metadata.GetProject(ctx, "proj")
+	require.NoError(t, err)
+
+	// When: requesting index info
+	info, err := engine.Info(ctx)
+
+	// Then: it reports the index and current embedder
+	require.NoError(t, err)
+	model := engine.embedder.ModelName()
+	assert.Equal(t, &IndexInfo{
+		IndexModel:          model,
+		IndexDimensions:     768,
+		CurrentModel:        model,
+		CurrentDimensions:   768,
+		DimensionsMatch:     true,
+		ModelMatches:        true,
+		Files:               1,
+		Chunks:              2,
+		BM25Documents:       2,
+		Vectors:             2,
+		ChunksWithEmbedding: 2,
+		LastIndexedAt:       project.IndexedAt,
+	}, info)
+}
+
+func TestEngine_Info_EmbedderMismatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          map[string]string
+		wantDimensions bool
+		wantModel      bool
+	}{
+		{
+			name:           "legacy index without embedder state",
+			state:          map[string]string{},
+			wantDimensions: true,
+			wantModel:      true,
+		},
+		{
+			name:           "same dimensions, different model",
+			state:          map[string]string{store.StateKeyIndexDimension: "768", store.StateKeyIndexModel: "other-model"},
+			wantDimensions: true,
+			wantModel:      false,
+		},
+		{
+			name:           "different dimensions",
+			state:          map[string]string{store.StateKeyIndexDimension: "384", store.StateKeyIndexModel: "other-model"},
+			wantDimensions: false,
+			wantModel:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: an index built with a recorded embedder
+			engine, _, _, _, metadata := setupTestEngine(t)
+			metadata.state = tt.state
+
+			// When: requesting index info
+			info, err := engine.Info(context.Background())
+
+			// Then: the match flags reflect the recorded embedder
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDimensions, info.DimensionsMatch)
+			assert.Equal(t, tt.wantModel, info.ModelMatches)
+		})
+	}
+}
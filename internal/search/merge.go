@@ -0,0 +1,165 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// mergeContiguous implements SearchOptions.MergeContiguous: results from the
+// same file whose line ranges overlap or touch are merged into one result,
+// placed where the best-ranked of them was. Results without a chunk or line
+// range are left alone.
+func (e *Engine) mergeContiguous(results []*SearchResult, opts SearchOptions) []*SearchResult {
+	if !opts.MergeContiguous || len(results) < 2 {
+		return results
+	}
+
+	groups := make(map[string][]*SearchResult)
+	for _, r := range results {
+		if r.Chunk == nil || r.Chunk.StartLine <= 0 || r.Chunk.EndLine < r.Chunk.StartLine {
+			continue
+		}
+		key := r.Chunk.FileID
+		if key == "" {
+			key = r.Chunk.FilePath
+		}
+		groups[key] = append(groups[key], r)
+	}
+
+	replacements := make(map[*SearchResult]*SearchResult)
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		sort.SliceStable(members, func(i, j int) bool {
+			return members[i].Chunk.StartLine < members[j].Chunk.StartLine
+		})
+
+		start, end := 0, members[0].Chunk.EndLine
+		for i := 1; i <= len(members); i++ {
+			if i < len(members) && members[i].Chunk.StartLine <= end+1 {
+				end = max(end, members[i].Chunk.EndLine)
+				continue
+			}
+			if run := members[start:i]; len(run) > 1 {
+				merged := e.mergeRun(run)
+				for _, m := range run {
+					replacements[m] = merged
+				}
+			}
+			if i < len(members) {
+				start, end = i, members[i].Chunk.EndLine
+			}
+		}
+	}
+	if len(replacements) == 0 {
+		return results
+	}
+
+	out := make([]*SearchResult, 0, len(results))
+	emitted := make(map[*SearchResult]bool)
+	for _, r := range results {
+		merged, ok := replacements[r]
+		if !ok {
+			out = append(out, r)
+			continue
+		}
+		if !emitted[merged] {
+			emitted[merged] = true
+			out = append(out, merged)
+		}
+	}
+	return out
+}
+
+// mergeRun merges results sorted by start line into one spanning their
+// combined range. The highest-scoring member supplies the chunk ID, scores
+// and metadata; ranks take the best of the members.
+//
+// Member contents are joined in line order, dropping lines already covered
+// by an earlier member, which relies on chunk content mapping one line per
+// source line. Highlights are recomputed by matching the members' combined
+// terms against the merged content, so their offsets refer to it.
+func (e *Engine) mergeRun(run []*SearchResult) *SearchResult {
+	best := run[0]
+	for _, r := range run[1:] {
+		if r.Score > best.Score {
+			best = r
+		}
+	}
+
+	var lines []string
+	var symbols []*store.Symbol
+	var terms, subQueries []string
+	seenTerms := make(map[string]bool)
+	seenSubQueries := make(map[string]bool)
+	last := run[0]
+	end := 0
+	merged := *best
+	for _, r := range run {
+		body := r.Chunk.RawContent
+		if body == "" {
+			body = r.Chunk.Content
+		}
+		bodyLines := strings.Split(body, "\n")
+		if covered := end - r.Chunk.StartLine + 1; covered > 0 {
+			bodyLines = bodyLines[min(covered, len(bodyLines)):]
+		}
+		lines = append(lines, bodyLines...)
+		if r.Chunk.EndLine > end {
+			end = r.Chunk.EndLine
+			last = r
+		}
+
+		symbols = append(symbols, r.Chunk.Symbols...)
+		for _, term := range r.MatchedTerms {
+			if !seenTerms[term] {
+				seenTerms[term] = true
+				terms = append(terms, term)
+			}
+		}
+		for _, sq := range r.MatchedSubQueries {
+			if !seenSubQueries[sq] {
+				seenSubQueries[sq] = true
+				subQueries = append(subQueries, sq)
+			}
+		}
+
+		merged.RawScore = max(merged.RawScore, r.RawScore)
+		merged.BM25Score = max(merged.BM25Score, r.BM25Score)
+		merged.VecScore = max(merged.VecScore, r.VecScore)
+		merged.BM25Rank = bestRank(merged.BM25Rank, r.BM25Rank)
+		merged.VecRank = bestRank(merged.VecRank, r.VecRank)
+		merged.InBothLists = merged.InBothLists || r.InBothLists
+	}
+
+	chunk := *best.Chunk
+	chunk.StartLine = run[0].Chunk.StartLine
+	chunk.EndLine = end
+	chunk.Symbols = symbols
+	chunk.RawContent = strings.Join(lines, "\n")
+	chunk.Content = chunk.RawContent
+	if best.Chunk.Context != "" && best.Chunk.Content != best.Chunk.RawContent {
+		chunk.Content = chunk.Context + "\n\n" + chunk.RawContent
+	}
+
+	merged.Chunk = &chunk
+	merged.MatchedTerms = terms
+	merged.MatchedSubQueries = subQueries
+	merged.Highlights = e.calculateHighlights(chunk.Content, terms)
+	merged.AdjacentContext = AdjacentContext{
+		Before: run[0].AdjacentContext.Before,
+		After:  last.AdjacentContext.After,
+	}
+	return &merged
+}
+
+// bestRank returns the better of two 1-indexed ranks, where 0 means absent.
+func bestRank(a, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func mergeTestResult(id, fileID string, start, end int, content string, score float64, terms ...string) *SearchResult {
+	return &SearchResult{
+		Chunk: &store.Chunk{
+			ID:         id,
+			FileID:     fileID,
+			FilePath:   fileID + ".go",
+			Content:    content,
+			RawContent: content,
+			StartLine:  start,
+			EndLine:    end,
+		},
+		Score:        score,
+		MatchedTerms: terms,
+	}
+}
+
+func TestEngine_MergeContiguous_MergesAdjacentAndOverlapping(t *testing.T) {
+	// Given: three chunks of one file (adjacent, then overlapping) plus another file
+	e := &Engine{}
+	results := []*SearchResult{
+		mergeTestResult("b", "f1", 4, 6, "line4\nline5 token\nline6", 0.9, "token"),
+		mergeTestResult("other", "f2", 1, 3, "x\ny\nz", 0.8),
+		mergeTestResult("a", "f1", 1, 3, "line1 auth\nline2\nline3", 0.7, "auth"),
+		mergeTestResult("c", "f1", 6, 8, "line6\nline7\nline8", 0.5),
+	}
+
+	// When: merging contiguous results
+	merged := e.mergeContiguous(results, SearchOptions{MergeContiguous: true})
+
+	// Then: the f1 chunks become one result at the best member's position
+	require.Len(t, merged, 2)
+	m := merged[0]
+	assert.Equal(t, "b", m.Chunk.ID)
+	assert.Equal(t, 0.9, m.Score)
+	assert.Equal(t, 1, m.Chunk.StartLine)
+	assert.Equal(t, 8, m.Chunk.EndLine)
+	assert.Equal(t, "line1 auth\nline2\nline3\nline4\nline5 token\nline6\nline7\nline8", m.Chunk.Content)
+	assert.Equal(t, "other", merged[1].Chunk.ID)
+
+	// And: highlights are recomputed against the merged content
+	assert.ElementsMatch(t, []string{"token", "auth"}, m.MatchedTerms)
+	require.Len(t, m.Highlights, 2)
+	for _, h := range m.Highlights {
+		assert.Contains(t, []string{"auth", "token"}, m.Chunk.Content[h.Start:h.End])
+	}
+
+	// And: the original results are not modified
+	assert.Equal(t, 4, results[0].Chunk.StartLine)
+	assert.Equal(t, "line4\nline5 token\nline6", results[0].Chunk.Content)
+}
+
+func TestEngine_MergeContiguous_KeepsGapsAndDisabled(t *testing.T) {
+	// Given: two chunks of one file separated by a gap
+	e := &Engine{}
+	results := []*SearchResult{
+		mergeTestResult("a", "f1", 1, 3, "a\nb\nc", 0.9),
+		mergeTestResult("b", "f1", 5, 6, "e\nf", 0.8),
+		mergeTestResult("c", "f1", 7, 7, "g", 0.7),
+	}
+
+	// When: merging contiguous results
+	merged := e.mergeContiguous(results, SearchOptions{MergeContiguous: true})
+
+	// Then: only the touching pair merges
+	require.Len(t, merged, 2)
+	assert.Equal(t, "a", merged[0].Chunk.ID)
+	assert.Equal(t, 3, merged[0].Chunk.EndLine)
+	assert.Equal(t, 5, merged[1].Chunk.StartLine)
+	assert.Equal(t, 7, merged[1].Chunk.EndLine)
+	assert.Equal(t, "e\nf\ng", merged[1].Chunk.Content)
+
+	// And: without the option results are returned unchanged
+	assert.Equal(t, results, e.mergeContiguous(results, SearchOptions{}))
+}
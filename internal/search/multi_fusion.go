@@ -23,6 +23,10 @@ type MultiFusedResult struct {
 	// SubQueryHits is the number of sub-queries this document appeared in.
 	// Higher values indicate consensus across multiple query formulations.
 	SubQueryHits int
+
+	// MatchedSubQueries lists the sub-queries whose results contained this
+	// document, in decomposition order. Consensus hits list more than one.
+	MatchedSubQueries []string
 }
 
 // MultiRRFFusion combines results from multiple sub-queries using
@@ -103,6 +107,9 @@ func (f *MultiRRFFusion) FuseMultiQuery(subResults []SubQueryResult) []*MultiFus
 
 			// Track sub-query hits
 			mr.SubQueryHits++
+			if n := len(mr.MatchedSubQueries); n == 0 || mr.MatchedSubQueries[n-1] != sr.SubQuery.Query {
+				mr.MatchedSubQueries = append(mr.MatchedSubQueries, sr.SubQuery.Query)
+			}
 
 			// Merge metadata from result (take highest scores)
 			if result.BM25Score > mr.BM25Score {
@@ -136,7 +143,10 @@ func (f *MultiRRFFusion) FuseMultiQuery(subResults []SubQueryResult) []*MultiFus
 	// Convert to sorted slice
 	results := f.toSortedSlice(scores)
 
-	// Normalize scores to 0-1 range
+	// Keep the raw score, then normalize scores to 0-1 range
+	for _, r := range results {
+		r.RawScore = r.RRFScore
+	}
 	f.normalize(results)
 
 	return results
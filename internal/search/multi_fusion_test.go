@@ -89,6 +89,37 @@ func TestMultiRRFFusion(t *testing.T) {
 		}
 	})
 
+	t.Run("matched sub-queries are tracked per document", func(t *testing.T) {
+		subResults := []SubQueryResult{
+			{
+				SubQuery: SubQuery{Query: "func Search", Weight: 1.0},
+				Results: []*FusedResult{
+					{ChunkID: "chunk1", RRFScore: 0.5},
+					{ChunkID: "chunk2", RRFScore: 0.6},
+				},
+			},
+			{
+				SubQuery: SubQuery{Query: "Search method", Weight: 1.0},
+				Results: []*FusedResult{
+					{ChunkID: "chunk1", RRFScore: 0.5},
+				},
+			},
+		}
+
+		results := f.FuseMultiQuery(subResults)
+
+		matched := make(map[string][]string)
+		for _, r := range results {
+			matched[r.ChunkID] = r.MatchedSubQueries
+		}
+		if got := matched["chunk1"]; len(got) != 2 || got[0] != "func Search" || got[1] != "Search method" {
+			t.Errorf("chunk1: expected both sub-queries in order, got %v", got)
+		}
+		if got := matched["chunk2"]; len(got) != 1 || got[0] != "func Search" {
+			t.Errorf("chunk2: expected [func Search], got %v", got)
+		}
+	})
+
 	t.Run("weights affect scoring", func(t *testing.T) {
 		// Two sub-queries with different weights
 		subResults := []SubQueryResult{
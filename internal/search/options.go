@@ -2,6 +2,8 @@ package search
 
 import (
 	"fmt"
+	"path"
+	"slices"
 	"sort"
 	"strings"
 
@@ -43,6 +45,16 @@ const (
 	// DeclarationSymbolTypeMismatchPenalty demotes methods/functions with the
 	// requested name when the user asked for a type declaration.
 	DeclarationSymbolTypeMismatchPenalty = 0.2
+
+	// FileNameMatchBoost lifts results from a file the query names, e.g.
+	// "coordinator.go" or "index/coordinator.go", when
+	// SearchOptions.BoostPathMatch is set.
+	FileNameMatchBoost = 2.0
+
+	// PathTokenMatchBoost is the largest boost for results whose path
+	// contains the query's terms, when SearchOptions.BoostPathMatch is set.
+	// It is scaled by the fraction of query terms found in the path.
+	PathTokenMatchBoost = 1.5
 )
 
 // FilterFunc checks if a search result matches filter criteria.
@@ -51,7 +63,7 @@ type FilterFunc func(result *SearchResult) bool
 // ApplyFilters filters results based on search options.
 // Filters use AND logic - results must match all specified criteria.
 func ApplyFilters(results []*SearchResult, opts SearchOptions) []*SearchResult {
-	if opts.Filter == "all" && opts.Language == "" && opts.SymbolType == "" && len(opts.Scopes) == 0 && opts.Profile == "" && opts.Mode == "" {
+	if opts.Filter == "all" && opts.Language == "" && opts.SymbolType == "" && len(opts.Scopes) == 0 && len(opts.ExcludeSymbolPatterns) == 0 && opts.Profile == "" && opts.Mode == "" {
 		filtered, mismatches := ApplyProfileEligibility(results, opts)
 		recordProfileMismatches(opts, mismatches)
 		return filtered
@@ -105,6 +117,11 @@ func buildFilters(opts SearchOptions) []FilterFunc {
 		filters = append(filters, scopeFilter(opts.Scopes))
 	}
 
+	// Symbol denylist
+	if len(opts.ExcludeSymbolPatterns) > 0 {
+		filters = append(filters, excludeSymbolFilter(opts.ExcludeSymbolPatterns))
+	}
+
 	if opts.Mode != "" {
 		filters = append(filters, modeFilter(opts.Mode))
 	}
@@ -169,6 +186,25 @@ func symbolTypeFilter(symbolType string) FilterFunc {
 	}
 }
 
+// excludeSymbolFilter creates a filter that rejects results whose primary
+// symbol name matches any of the glob patterns. Malformed patterns never
+// match (SearchOptions.Validate reports them).
+func excludeSymbolFilter(patterns []string) FilterFunc {
+	return func(r *SearchResult) bool {
+		if r.Chunk == nil || len(r.Chunk.Symbols) == 0 || r.Chunk.Symbols[0] == nil {
+			return true
+		}
+
+		name := r.Chunk.Symbols[0].Name
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, name); matched {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 // ValidateOptions checks if search options are valid.
 func ValidateOptions(opts SearchOptions) error {
 	if _, err := ParseProfile(string(opts.Profile)); err != nil {
@@ -436,9 +472,15 @@ func hasExactSymbolOfType(chunk *store.Chunk, name string, symbolTypes []store.S
 	return false
 }
 
-// IsTestFile checks if a file path is a test file.
-// Supports Go (_test.go), JavaScript/TypeScript (.test.js, .spec.ts, etc.),
-// and Python (test_*.py, *_test.py).
+// IsTestFile checks if a file path is a test file. Detection uses the path
+// only, never file contents, and matches:
+//   - Go: *_test.go
+//   - JavaScript/TypeScript and others: names containing ".test." or ".spec."
+//     (foo.test.ts, foo.spec.js)
+//   - Python: test_*.py and *_test.py
+//   - anything under a test/, tests/, or __tests__/ directory at any depth
+//
+// SearchOptions.IncludeTests decides what happens to matching results.
 func IsTestFile(filePath string) bool {
 	// Go test files
 	if strings.HasSuffix(filePath, "_test.go") {
@@ -515,6 +557,85 @@ func ApplyPathBoost(results []*SearchResult) []*SearchResult {
 	return results
 }
 
+// ApplyPathMatchBoost lifts results whose file path matches the query, for
+// "I'm searching for a file" queries that content ranking alone misses
+// because other files mention the name more often. A result from a file
+// the query names by file name or path suffix gets FileNameMatchBoost;
+// otherwise query terms found among the path's tokens (split like code
+// identifiers, so coordinator_test.go yields "coordinator" and "test") give
+// up to PathTokenMatchBoost. The applied multiplier is recorded in
+// SearchResult.PathMatchBoost. It layers on ApplyPathBoost.
+func ApplyPathMatchBoost(results []*SearchResult, query string) []*SearchResult {
+	if len(results) == 0 {
+		return results
+	}
+
+	names := queryFileNames(query)
+	terms := store.TokenizeCode(query)
+	slices.Sort(terms)
+	terms = slices.Compact(terms)
+	if len(names) == 0 && len(terms) == 0 {
+		return results
+	}
+
+	for _, r := range results {
+		if r == nil || r.Chunk == nil {
+			continue
+		}
+		if boost := pathMatchBoost(r.Chunk.FilePath, names, terms); boost > 1 {
+			r.Score *= boost
+			r.PathMatchBoost = boost
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	return results
+}
+
+// pathMatchBoost returns the score multiplier for filePath given the file
+// names and terms of the query.
+func pathMatchBoost(filePath string, names, terms []string) float64 {
+	lowerPath := strings.ToLower(filePath)
+	for _, name := range names {
+		if lowerPath == name || strings.HasSuffix(lowerPath, "/"+name) {
+			return FileNameMatchBoost
+		}
+	}
+	if len(terms) == 0 {
+		return 1
+	}
+
+	pathTokens := make(map[string]struct{})
+	for _, token := range store.TokenizeCode(filePath) {
+		pathTokens[token] = struct{}{}
+	}
+	matched := 0
+	for _, term := range terms {
+		if _, ok := pathTokens[term]; ok {
+			matched++
+		}
+	}
+	return 1 + (PathTokenMatchBoost-1)*float64(matched)/float64(len(terms))
+}
+
+// queryFileNames returns the lowercased words of query that look like file
+// names or paths: they contain a dot or slash between other characters.
+func queryFileNames(query string) []string {
+	var names []string
+	for _, field := range strings.Fields(query) {
+		field = strings.ToLower(strings.Trim(field, "\"'`()[],;:?!"))
+		field = strings.TrimSuffix(strings.TrimPrefix(field, "./"), ".")
+		if strings.Trim(field, "./") == "" || !strings.ContainsAny(field, "./") {
+			continue
+		}
+		names = append(names, strings.Trim(field, "/"))
+	}
+	return names
+}
+
 // ApplyAuthorityBoost sorts results using source authority and freshness
 // metadata as a secondary ranking signal. It leaves public scores unchanged so
 // RRF/reranker score semantics remain stable for callers.
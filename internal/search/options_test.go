@@ -347,6 +347,49 @@ func TestApplyFilters_InvalidScope_ReturnsEmpty(t *testing.T) {
 	assert.Empty(t, filtered)
 }
 
+func TestApplyFilters_ExcludeSymbolPatterns(t *testing.T) {
+	// Given: results led by boilerplate and real symbols, and one without symbols
+	sym := func(name string) []*store.Symbol {
+		return []*store.Symbol{{Name: name, Type: store.SymbolTypeMethod}}
+	}
+	results := []*SearchResult{
+		{Chunk: &store.Chunk{FilePath: "user.go", Symbols: sym("String")}},
+		{Chunk: &store.Chunk{FilePath: "user_accessors.go", Symbols: sym("GetName")}},
+		{Chunk: &store.Chunk{FilePath: "auth.go", Symbols: sym("Authenticate")}},
+		{Chunk: &store.Chunk{FilePath: "README.md"}},
+		{Chunk: &store.Chunk{FilePath: "target.go", Symbols: []*store.Symbol{
+			{Name: "Target", Type: store.SymbolTypeFunction},
+			{Name: "String", Type: store.SymbolTypeMethod},
+		}}},
+	}
+
+	// When: excluding String and Get* with Filter "all"
+	filtered := ApplyFilters(results, SearchOptions{
+		Filter:                "all",
+		ExcludeSymbolPatterns: []string{"String", "Get*"},
+	})
+
+	// Then: only results whose primary symbol matches are dropped
+	var paths []string
+	for _, r := range filtered {
+		paths = append(paths, r.Chunk.FilePath)
+	}
+	assert.Equal(t, []string{"auth.go", "README.md", "target.go"}, paths)
+}
+
+func TestApplyFilters_ExcludeSymbolPatterns_Empty(t *testing.T) {
+	// Given: a result whose symbol would match a pattern
+	results := []*SearchResult{
+		{Chunk: &store.Chunk{FilePath: "user.go", Symbols: []*store.Symbol{{Name: "String"}}}},
+	}
+
+	// When: no exclusion patterns are set
+	filtered := ApplyFilters(results, SearchOptions{Filter: "all", ExcludeSymbolPatterns: []string{}})
+
+	// Then: nothing is removed
+	assert.Len(t, filtered, 1)
+}
+
 // =============================================================================
 // Benchmarks
 // =============================================================================
@@ -641,6 +684,55 @@ func TestApplyTestFilePenalty_ReorderByScore(t *testing.T) {
 	assert.Equal(t, 0.5, penalized[1].Score)
 }
 
+func TestApplyPathMatchBoost_RanksNamedFileFirst(t *testing.T) {
+	// Given: a file mentioning "coordinator" more often outranks the file itself
+	results := []*SearchResult{
+		{Chunk: &store.Chunk{FilePath: "internal/index/runner.go"}, Score: 0.9},
+		{Chunk: &store.Chunk{FilePath: "docs/coordinator.md"}, Score: 0.8},
+		{Chunk: &store.Chunk{FilePath: "internal/index/coordinator.go"}, Score: 0.7},
+	}
+
+	// When: boosting path matches for a query naming the file
+	boosted := ApplyPathMatchBoost(results, "coordinator.go")
+
+	// Then: the named file ranks first, files sharing path tokens get a
+	// smaller boost, and each boost is recorded
+	assert.Equal(t, "internal/index/coordinator.go", boosted[0].Chunk.FilePath)
+	assert.InDelta(t, 1.4, boosted[0].Score, 1e-9)
+	assert.Equal(t, FileNameMatchBoost, boosted[0].PathMatchBoost)
+	assert.Equal(t, "internal/index/runner.go", boosted[1].Chunk.FilePath)
+	assert.Equal(t, 1.25, boosted[1].PathMatchBoost) // "go" matches the extension
+	assert.Equal(t, 1.25, boosted[2].PathMatchBoost) // "coordinator" matches the name
+}
+
+func TestApplyPathMatchBoost_PathTokens(t *testing.T) {
+	// Given: results whose paths share none, some, or all query terms
+	results := []*SearchResult{
+		{Chunk: &store.Chunk{FilePath: "internal/search/engine.go"}, Score: 1.0},
+		{Chunk: &store.Chunk{FilePath: "internal/watcher/hybrid.go"}, Score: 0.9},
+		{Chunk: &store.Chunk{FilePath: "internal/watcher/debounce_test.go"}, Score: 0.8},
+		{Chunk: nil, Score: 0.7},
+	}
+
+	// When: boosting path matches for a natural language query
+	boosted := ApplyPathMatchBoost(results, "watcher debounce")
+
+	// Then: the boost grows with the fraction of terms in the path
+	assert.Equal(t, "internal/watcher/debounce_test.go", boosted[0].Chunk.FilePath)
+	assert.Equal(t, PathTokenMatchBoost, boosted[0].PathMatchBoost)
+	assert.Equal(t, "internal/watcher/hybrid.go", boosted[1].Chunk.FilePath)
+	assert.Equal(t, 1.25, boosted[1].PathMatchBoost)
+	assert.Equal(t, "internal/search/engine.go", boosted[2].Chunk.FilePath)
+	assert.Zero(t, boosted[2].PathMatchBoost)
+}
+
+func TestQueryFileNames(t *testing.T) {
+	assert.Equal(t,
+		[]string{"coordinator.go", "internal/index", "runner.go"},
+		queryFileNames("where is `coordinator.go` in ./internal/index/ or runner.go? ... end."))
+	assert.Empty(t, queryFileNames("how does indexing work"))
+}
+
 func TestApplyExactMatchBoost_RanksExactSymbolAboveReferences(t *testing.T) {
 	results := []*SearchResult{
 		{
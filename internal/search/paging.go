@@ -0,0 +1,66 @@
+package search
+
+import (
+	"context"
+)
+
+// SearchPage describes the page of results returned by a paged search.
+type SearchPage struct {
+	// Offset and Limit are the effective window after defaults and clamping.
+	Offset int
+	Limit  int
+
+	// HasMore reports whether another page can be fetched.
+	HasMore bool
+
+	// TotalEstimate estimates the number of results the query has. It is
+	// exact below EngineConfig.MaxLimit; at the limit it also counts the
+	// candidates that were cut, which may exceed what paging can reach.
+	TotalEstimate int
+}
+
+// searchPage runs a paged search (see Engine.Search).
+func (e *Engine) searchPage(ctx context.Context, query string, opts SearchOptions) ([]*SearchResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = e.config.DefaultLimit
+	}
+	limit = min(limit, e.config.MaxLimit)
+	offset := max(opts.Offset, 0)
+
+	page := opts.Page
+	sortBy := opts.SortBy
+	var diagnostics SearchDiagnostics
+	if opts.Diagnostics == nil {
+		opts.Diagnostics = &diagnostics
+	}
+
+	ranking := opts
+	ranking.Offset = 0
+	ranking.Page = nil
+	ranking.Limit = e.config.MaxLimit
+	ranking.SortBy = SortByScore
+	ranked, err := e.Search(ctx, query, ranking)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*SearchResult
+	if offset < len(ranked) {
+		results = ranked[offset:min(offset+limit, len(ranked))]
+	}
+	results = SortResults(results, sortBy)
+
+	if page != nil {
+		*page = SearchPage{
+			Offset:        offset,
+			Limit:         limit,
+			HasMore:       len(ranked) > offset+limit,
+			TotalEstimate: len(ranked),
+		}
+		if len(ranked) >= e.config.MaxLimit {
+			page.TotalEstimate = max(len(ranked), opts.Diagnostics.CandidateCount)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,75 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEngine_Search_Paging(t *testing.T) {
+	// Given: a query matching seven chunks
+	engine, bm25, _, _, metadata := setupTestEngine(t)
+	var bm25Results []*store.BM25Result
+	for i := 0; i < 7; i++ {
+		id := fmt.Sprintf("page%d", i)
+		metadata.chunks[id] = &store.Chunk{
+			ID:       id,
+			FileID:   id,
+			FilePath: fmt.Sprintf("pkg/file%d.go", i),
+			Content:  fmt.Sprintf("func Handler%d() {}", i),
+		}
+		bm25Results = append(bm25Results, &store.BM25Result{DocID: id, Score: float64(10 - i)})
+	}
+	bm25.SearchFn = func(_ context.Context, _ string, limit int) ([]*store.BM25Result, error) {
+		return bm25Results[:min(limit, len(bm25Results))], nil
+	}
+	all, err := engine.Search(context.Background(), "handler", SearchOptions{BM25Only: true, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 7)
+
+	// When: paging through three at a time
+	var paged []string
+	var pages []SearchPage
+	for offset := 0; offset < 9; offset += 3 {
+		var page SearchPage
+		results, err := engine.Search(context.Background(), "handler", SearchOptions{
+			BM25Only: true, Limit: 3, Offset: offset, Page: &page,
+		})
+		require.NoError(t, err)
+		paged = append(paged, resultChunkIDs(results)...)
+		pages = append(pages, page)
+	}
+
+	// Then: the pages split the full ranking without overlap or gaps
+	assert.Equal(t, resultChunkIDs(all), paged)
+	assert.Equal(t, SearchPage{Offset: 0, Limit: 3, HasMore: true, TotalEstimate: 7}, pages[0])
+	assert.True(t, pages[1].HasMore)
+	assert.Equal(t, SearchPage{Offset: 6, Limit: 3, TotalEstimate: 7}, pages[2])
+}
+
+func TestEngine_Search_OffsetPastResults(t *testing.T) {
+	// Given: a query with a few results
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	bm25.SearchFn = func(_ context.Context, _ string, _ int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{{DocID: "chunk1", Score: 1}}, nil
+	}
+
+	// When: asking for a page beyond them
+	var page SearchPage
+	results, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true, Offset: 20, Page: &page})
+
+	// Then: the page is empty and says so
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, SearchPage{Offset: 20, Limit: 10, TotalEstimate: 1}, page)
+}
+
+func TestSearchOptions_Validate_NegativeOffset(t *testing.T) {
+	err := SearchOptions{Offset: -1}.Validate()
+	assert.ErrorIs(t, err, ErrInvalidSearchOptions)
+}
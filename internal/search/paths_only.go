@@ -0,0 +1,196 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// pathsOnlyCandidateMultiplier widens the candidate pool of a PathsOnly
+// search relative to Limit, since several candidates usually share a file.
+// Candidates are cheap here: their text is never fetched.
+const pathsOnlyCandidateMultiplier = 5
+
+// searchPaths runs a SearchOptions.PathsOnly search: retrieval and fusion as
+// usual, then chunk headers instead of full chunks, grouped by file.
+func (e *Engine) searchPaths(ctx context.Context, query string, boolQuery *store.BooleanQuery, opts SearchOptions, start time.Time) ([]*SearchResult, error) {
+	if pathsOnlyNeedsChunks(opts) {
+		return e.searchPathsFromChunks(ctx, query, opts)
+	}
+
+	candidateLimit := min(max(e.candidateLimit(query, opts), opts.Limit*pathsOnlyCandidateMultiplier), e.maxCandidates())
+	weights := opts.Weights
+	var bm25Results []*store.BM25Result
+	var vecResults []*store.VectorResult
+	var searchErr error
+	dimMismatch := false
+	if !opts.BM25Only {
+		dimMismatch = e.validateQueryDimensions(ctx, opts) != nil
+	}
+	if opts.BM25Only || dimMismatch {
+		bm25Results, searchErr = e.searchBM25(ctx, query, boolQuery, opts.PrefixMatch, candidateLimit)
+		if searchErr != nil {
+			return nil, fmt.Errorf("BM25 search failed: %w", searchErr)
+		}
+		if opts.BM25Only {
+			weights = &Weights{BM25: 1.0, Semantic: 0.0}
+		}
+	} else {
+		bm25Results, vecResults, searchErr = e.parallelSearch(ctx, query, boolQuery, candidateLimit, opts)
+		if searchErr != nil && bm25Results == nil && vecResults == nil {
+			return nil, searchErr
+		}
+	}
+
+	fused := e.fuseResults(bm25Results, vecResults, weights)
+	candidates, err := e.chunkHeaderResults(ctx, fused)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only boosts that look at the path apply; the rest need chunk text
+	candidates = ApplyTestFileMode(candidates, opts.IncludeTests)
+	candidates = ApplyPathBoost(candidates)
+	if opts.BoostPathMatch {
+		candidates = ApplyPathMatchBoost(candidates, query)
+	}
+
+	results := groupResultsByFile(ApplyFilters(candidates, opts))
+	if len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	results = SortResults(results, opts.SortBy)
+	e.attachExplainData(results, query, opts, len(bm25Results), len(vecResults), dimMismatch, nil)
+	ClassifyConfidence(results, e.config.Confidence)
+	recordSearchDiagnostics(opts, SearchDiagnostics{
+		BM25ResultCount:     len(bm25Results),
+		VectorResultCount:   len(vecResults),
+		CandidateCount:      len(candidates),
+		ResultCount:         len(results),
+		DimensionMismatch:   dimMismatch,
+		SemanticUnavailable: searchErr != nil && vecResults == nil,
+	})
+
+	e.recordMetrics(query, e.classifyQueryType(ctx, query, opts), len(results), time.Since(start))
+	e.logQuery(ctx, query, *weights, len(results), time.Since(start))
+	return results, nil
+}
+
+// pathsOnlyNeedsChunks reports whether opts filter on chunk symbols or
+// source metadata, which chunk headers do not carry.
+func pathsOnlyNeedsChunks(opts SearchOptions) bool {
+	return opts.SymbolType != "" || len(opts.ExcludeSymbolPatterns) > 0 || opts.Mode != "" || opts.Profile != ""
+}
+
+// searchPathsFromChunks runs the regular search over the widest window and
+// groups its results by file.
+func (e *Engine) searchPathsFromChunks(ctx context.Context, query string, opts SearchOptions) ([]*SearchResult, error) {
+	limit, sortBy := opts.Limit, opts.SortBy
+	opts.PathsOnly = false
+	opts.Limit = e.config.MaxLimit
+	opts.SortBy = SortByScore
+
+	// Called from within search, which already holds a search slot
+	chunks, err := e.searchHoldingSlot(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range chunks {
+		r.Chunk = chunkHeader(r.Chunk)
+		r.Highlights = nil
+		r.AdjacentContext = AdjacentContext{}
+		r.LineCount, r.ByteCount = 0, 0
+	}
+	results := groupResultsByFile(chunks)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return SortResults(results, sortBy), nil
+}
+
+// chunkHeaderResults turns fused candidates into results carrying chunk
+// headers, in fused order. Metadata stores without store.ChunkHeaderGetter
+// fall back to GetChunks.
+func (e *Engine) chunkHeaderResults(ctx context.Context, fused []*fusedResult) ([]*SearchResult, error) {
+	if len(fused) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(fused))
+	fusedByID := make(map[string]*fusedResult, len(fused))
+	for i, f := range fused {
+		ids[i] = f.chunkID
+		fusedByID[f.chunkID] = f
+	}
+
+	var chunks []*store.Chunk
+	var err error
+	if getter, ok := e.metadata.(store.ChunkHeaderGetter); ok {
+		chunks, err = getter.GetChunkHeaders(ctx, ids)
+	} else {
+		chunks, err = e.metadata.GetChunks(ctx, ids)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunk headers: %w", err)
+	}
+
+	results := make([]*SearchResult, 0, len(chunks))
+	for _, chunk := range chunks {
+		f, ok := fusedByID[chunk.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, &SearchResult{
+			Chunk:          chunkHeader(chunk),
+			Score:          f.rrfScore,
+			RawScore:       f.rawScore,
+			BM25Score:      f.bm25Score,
+			VecScore:       f.vecScore,
+			BM25Rank:       f.bm25Rank,
+			VecRank:        f.vecRank,
+			InBothLists:    f.inBothLists,
+			MatchedTerms:   f.matchedTerms,
+			SourceMetadata: SourceMetadataFromChunkWithRules(chunk, e.config.MetadataRules),
+		})
+	}
+	return results, nil
+}
+
+// chunkHeader returns a copy of c reduced to the fields of a
+// store.ChunkHeaderGetter header.
+func chunkHeader(c *store.Chunk) *store.Chunk {
+	if c == nil {
+		return nil
+	}
+	return &store.Chunk{
+		ID:          c.ID,
+		FileID:      c.FileID,
+		FilePath:    c.FilePath,
+		ContentType: c.ContentType,
+		Language:    c.Language,
+		StartLine:   c.StartLine,
+		EndLine:     c.EndLine,
+	}
+}
+
+// groupResultsByFile keeps the first result of each file, which is its best
+// when results are ranked, and sets MatchCount to the file's result count.
+func groupResultsByFile(results []*SearchResult) []*SearchResult {
+	grouped := make([]*SearchResult, 0, len(results))
+	byPath := make(map[string]*SearchResult, len(results))
+	for _, r := range results {
+		if r == nil || r.Chunk == nil {
+			continue
+		}
+		if best, ok := byPath[r.Chunk.FilePath]; ok {
+			best.MatchCount++
+			continue
+		}
+		r.MatchCount = 1
+		byPath[r.Chunk.FilePath] = r
+		grouped = append(grouped, r)
+	}
+	return grouped
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEngine_Search_PathsOnly(t *testing.T) {
+	// Given: three matching chunks in auth.go and one in session.go
+	engine, bm25, _, _, metadata := setupTestEngine(t)
+	chunks := []*store.Chunk{
+		{ID: "auth1", FileID: "f-auth", FilePath: "internal/auth.go", Content: "func Login() {}", StartLine: 1, EndLine: 1},
+		{ID: "session1", FileID: "f-session", FilePath: "internal/session.go", Content: "func Login session", StartLine: 1, EndLine: 1},
+		{ID: "auth2", FileID: "f-auth", FilePath: "internal/auth.go", Content: "func Logout() {}", StartLine: 3, EndLine: 3},
+		{ID: "auth3", FileID: "f-auth", FilePath: "internal/auth.go", Content: "func LoginAgain() {}", StartLine: 5, EndLine: 5},
+	}
+	var bm25Results []*store.BM25Result
+	for i, c := range chunks {
+		metadata.chunks[c.ID] = c
+		bm25Results = append(bm25Results, &store.BM25Result{DocID: c.ID, Score: float64(10 - i)})
+	}
+	bm25.SearchFn = func(_ context.Context, _ string, _ int) ([]*store.BM25Result, error) {
+		return bm25Results, nil
+	}
+
+	// When: searching for paths only
+	results, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true, Limit: 10, PathsOnly: true})
+
+	// Then: each file appears once, ranked by its best chunk, with its match count
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "internal/auth.go", results[0].Chunk.FilePath)
+	assert.Equal(t, "auth1", results[0].Chunk.ID)
+	assert.Equal(t, 3, results[0].MatchCount)
+	assert.Equal(t, "internal/session.go", results[1].Chunk.FilePath)
+	assert.Equal(t, 1, results[1].MatchCount)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	// And: no chunk content is returned
+	for _, r := range results {
+		assert.Empty(t, r.Chunk.Content)
+		assert.Empty(t, r.Highlights)
+	}
+}
+
+func TestEngine_Search_PathsOnlyLimitCountsFiles(t *testing.T) {
+	// Given: two files with two matching chunks each
+	engine, bm25, _, _, metadata := setupTestEngine(t)
+	var bm25Results []*store.BM25Result
+	for i, id := range []string{"a1", "a2", "b1", "b2"} {
+		path := "pkg/" + id[:1] + ".go"
+		metadata.chunks[id] = &store.Chunk{ID: id, FileID: path, FilePath: path, Content: "func Handler() {}"}
+		bm25Results = append(bm25Results, &store.BM25Result{DocID: id, Score: float64(10 - i)})
+	}
+	bm25.SearchFn = func(_ context.Context, _ string, _ int) ([]*store.BM25Result, error) {
+		return bm25Results, nil
+	}
+
+	// When: limiting the paths-only search to one result
+	results, err := engine.Search(context.Background(), "handler", SearchOptions{BM25Only: true, Limit: 1, PathsOnly: true})
+
+	// Then: the limit applies to files, and all chunks of the top file still count
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "pkg/a.go", results[0].Chunk.FilePath)
+	assert.Equal(t, 2, results[0].MatchCount)
+}
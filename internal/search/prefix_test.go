@@ -0,0 +1,94 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// prefixCall records one SearchPrefix call.
+type prefixCall struct {
+	query       string
+	allPrefixes bool
+}
+
+// mockPrefixBM25Index is a MockBM25Index that also expands prefix queries.
+type mockPrefixBM25Index struct {
+	MockBM25Index
+	results []*store.BM25Result
+	calls   []prefixCall
+}
+
+var _ store.PrefixSearcher = (*mockPrefixBM25Index)(nil)
+
+func (m *mockPrefixBM25Index) SearchPrefix(_ context.Context, query string, allPrefixes bool, _ int) ([]*store.BM25Result, error) {
+	m.calls = append(m.calls, prefixCall{query: query, allPrefixes: allPrefixes})
+	return m.results, nil
+}
+
+func TestEngine_Search_PrefixMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		opts       SearchOptions
+		wantCalls  []prefixCall
+		wantSearch int32
+	}{
+		{
+			name:      "trailing marker",
+			query:     "authent* user",
+			opts:      SearchOptions{BM25Only: true},
+			wantCalls: []prefixCall{{query: "authent* user"}},
+		},
+		{
+			name:      "PrefixMatch option",
+			query:     "auth user",
+			opts:      SearchOptions{BM25Only: true, PrefixMatch: true},
+			wantCalls: []prefixCall{{query: "auth user", allPrefixes: true}},
+		},
+		{
+			name:       "plain query",
+			query:      "auth user",
+			opts:       SearchOptions{BM25Only: true},
+			wantSearch: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: an engine whose BM25 index supports prefix queries
+			_, _, vector, embedder, metadata := setupTestEngine(t)
+			bm25 := &mockPrefixBM25Index{results: []*store.BM25Result{{DocID: "chunk1", Score: 2}}}
+			engine := New(bm25, vector, embedder, metadata, DefaultConfig())
+
+			// When: searching
+			_, err := engine.Search(context.Background(), tt.query, tt.opts)
+
+			// Then: only prefix queries go through SearchPrefix
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCalls, bm25.calls)
+			assert.Equal(t, tt.wantSearch, bm25.searchCalled.Load())
+		})
+	}
+}
+
+func TestEngine_Search_PrefixMatch_UnsupportedIndex(t *testing.T) {
+	// Given: a BM25 index without prefix support
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	var got string
+	bm25.SearchFn = func(_ context.Context, query string, _ int) ([]*store.BM25Result, error) {
+		got = query
+		return []*store.BM25Result{{DocID: "chunk1", Score: 1}}, nil
+	}
+
+	// When: searching with PrefixMatch
+	results, err := engine.Search(context.Background(), "auth", SearchOptions{BM25Only: true, PrefixMatch: true})
+
+	// Then: the search falls back to a plain BM25 query
+	require.NoError(t, err)
+	assert.Equal(t, "auth", got)
+	assert.Len(t, results, 1)
+}
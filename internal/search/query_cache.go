@@ -0,0 +1,244 @@
+package search
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// queryCache is an LRU cache of search results.
+//
+// Entries are tagged with the index generation they were computed at. Index,
+// Delete and the other index mutations bump the generation, so a hit from an
+// older generation is treated as a miss and evicted; nothing has to walk the
+// cache on writes.
+type queryCache struct {
+	entries    *lru.Cache[string, *queryCacheEntry]
+	ttl        time.Duration
+	generation atomic.Uint64
+}
+
+// queryCacheEntry holds one cached search and the side outputs it wrote to
+// the caller's SearchOptions pointers, so a hit reproduces them too.
+type queryCacheEntry struct {
+	generation     uint64
+	createdAt      time.Time
+	results        []*SearchResult
+	mismatches     []ProfileMismatch
+	classification QueryClassification
+	rerankerStatus RerankerStatus
+	diagnostics    SearchDiagnostics
+}
+
+// newQueryCache returns a cache holding up to size searches, or nil when
+// size is not positive.
+func newQueryCache(size int, ttl time.Duration) *queryCache {
+	if size <= 0 {
+		return nil
+	}
+	entries, err := lru.New[string, *queryCacheEntry](size)
+	if err != nil {
+		return nil
+	}
+	return &queryCache{entries: entries, ttl: ttl}
+}
+
+// get returns the live entry for key.
+func (c *queryCache) get(key string) (*queryCacheEntry, bool) {
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if entry.generation != c.generation.Load() || (c.ttl > 0 && time.Since(entry.createdAt) > c.ttl) {
+		c.entries.Remove(key)
+		return nil, false
+	}
+	return entry, true
+}
+
+// invalidate drops every cached search by moving to a new generation.
+func (c *queryCache) invalidate() {
+	if c != nil {
+		c.generation.Add(1)
+	}
+}
+
+// InvalidateQueryCache drops all cached search results. The engine does this
+// itself on Index, Delete, DeleteProject and RecomputeBM25Stats; call it
+// after writing to the engine's stores by other means. No-op when the query
+// cache is disabled.
+func (e *Engine) InvalidateQueryCache() {
+	e.queryCache.invalidate()
+}
+
+// Search executes a hybrid search combining BM25 and semantic search.
+// It runs both searches in parallel and fuses results using Reciprocal Rank Fusion (RRF).
+//
+// FEAT-QI3: If multi-query search is enabled and the query benefits from
+// decomposition, this method delegates to MultiQuerySearcher which runs
+// multiple sub-queries in parallel and fuses results with consensus boosting.
+//
+// When EngineConfig.QueryCacheSize is set, repeated searches with the same
+// query and options are answered from the query cache until the index
+// changes or the entry is older than EngineConfig.QueryCacheTTL. Cache hits
+// skip query telemetry and logging. Searches with opts.Embedder set are
+// never cached.
+//
+// Paging (opts.Offset, opts.Page) runs the search for the first
+// EngineConfig.MaxLimit results, whatever the page, and returns the requested
+// slice of that ranking. Every page of a query is thus cut from the same
+// search, which the query cache (when enabled) answers after the first page.
+// For an unchanged index the ranking is deterministic, so pages neither
+// overlap nor skip results. If the index changes between requests, later
+// pages come from the new ranking and results may repeat or be missed across
+// pages. Extras computed only for the top of the ranking (adjacent context,
+// blame) are absent from deeper pages, and SortBy orders each page on its own.
+func (e *Engine) Search(ctx context.Context, query string, opts SearchOptions) ([]*SearchResult, error) {
+	if opts.Offset != 0 || opts.Page != nil {
+		return e.searchPage(ctx, query, opts)
+	}
+	if e.queryCache == nil || opts.Embedder != nil {
+		return e.search(ctx, query, opts)
+	}
+	key, ok := queryCacheKey(query, opts)
+	if !ok {
+		return e.search(ctx, query, opts)
+	}
+
+	if entry, hit := e.queryCache.get(key); hit {
+		slog.Debug("query_cache_hit", slog.String("query", query))
+		entry.writeOutputs(opts)
+		return cloneResults(entry.results), nil
+	}
+
+	// Capture the side outputs in the entry, then hand them to the caller
+	generation := e.queryCache.generation.Load()
+	entry := &queryCacheEntry{generation: generation}
+	callerOpts := opts
+	opts.ProfileMismatches = &entry.mismatches
+	opts.QueryClassification = &entry.classification
+	opts.RerankerStatus = &entry.rerankerStatus
+	opts.Diagnostics = &entry.diagnostics
+
+	results, err := e.search(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	entry.createdAt = time.Now()
+	entry.results = cloneResults(results)
+	e.queryCache.entries.Add(key, entry)
+	entry.writeOutputs(callerOpts)
+	return results, nil
+}
+
+// writeOutputs copies the entry's side outputs into the pointers set on opts.
+func (entry *queryCacheEntry) writeOutputs(opts SearchOptions) {
+	if opts.ProfileMismatches != nil {
+		*opts.ProfileMismatches = append(*opts.ProfileMismatches, entry.mismatches...)
+	}
+	if opts.QueryClassification != nil {
+		*opts.QueryClassification = entry.classification
+	}
+	if opts.RerankerStatus != nil {
+		*opts.RerankerStatus = entry.rerankerStatus
+	}
+	if opts.Diagnostics != nil {
+		*opts.Diagnostics = entry.diagnostics
+	}
+}
+
+// cloneResults copies each result so callers can modify what they get back
+// without changing the cached entry. Chunks are shared and must be treated
+// as read-only.
+func cloneResults(results []*SearchResult) []*SearchResult {
+	if results == nil {
+		return nil
+	}
+	out := make([]*SearchResult, len(results))
+	for i, r := range results {
+		if r == nil {
+			continue
+		}
+		c := *r
+		out[i] = &c
+	}
+	return out
+}
+
+// queryCacheKey hashes the whitespace-normalized query with every option
+// that affects results. Output pointers and the embedder override are not
+// part of the key.
+func queryCacheKey(query string, opts SearchOptions) (string, bool) {
+	key := struct {
+		Query                 string
+		Limit                 int
+		Filter                string
+		Language              string
+		SymbolType            string
+		Weights               *Weights
+		Scopes                []string
+		ExcludeSymbolPatterns []string
+		Profile               Profile
+		ProfileRules          ProfileRules
+		Mode                  SearchMode
+		RerankThreshold       float64
+		BM25Only              bool
+		BooleanQuery          bool
+		PrefixMatch           bool
+		AdjacentChunks        int
+		AdjacentTokenBudget   int
+		MergeContiguous       bool
+		PathsOnly             bool
+		Explain               bool
+		IncludeBlame          bool
+		BlameLimit            int
+		IncludeSalientTerms   bool
+		SortBy                SortBy
+		IncludeTests          TestFileMode
+		BoostPathMatch        bool
+		VectorEf              int
+		CandidateMultiplier   int
+	}{
+		Query:                 strings.Join(strings.Fields(query), " "),
+		Limit:                 opts.Limit,
+		Filter:                opts.Filter,
+		Language:              opts.Language,
+		SymbolType:            opts.SymbolType,
+		Weights:               opts.Weights,
+		Scopes:                opts.Scopes,
+		ExcludeSymbolPatterns: opts.ExcludeSymbolPatterns,
+		Profile:               opts.Profile,
+		ProfileRules:          opts.ProfileRules,
+		Mode:                  opts.Mode,
+		RerankThreshold:       opts.RerankThreshold,
+		BM25Only:              opts.BM25Only,
+		BooleanQuery:          opts.BooleanQuery,
+		PrefixMatch:           opts.PrefixMatch,
+		AdjacentChunks:        opts.AdjacentChunks,
+		AdjacentTokenBudget:   opts.AdjacentTokenBudget,
+		MergeContiguous:       opts.MergeContiguous,
+		PathsOnly:             opts.PathsOnly,
+		Explain:               opts.Explain,
+		IncludeBlame:          opts.IncludeBlame,
+		BlameLimit:            opts.BlameLimit,
+		IncludeSalientTerms:   opts.IncludeSalientTerms,
+		SortBy:                opts.SortBy,
+		IncludeTests:          opts.IncludeTests,
+		BoostPathMatch:        opts.BoostPathMatch,
+		VectorEf:              opts.VectorEf,
+		CandidateMultiplier:   opts.CandidateMultiplier,
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), true
+}
@@ -0,0 +1,193 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// setupCachedTestEngine is setupTestEngine with the query cache enabled.
+func setupCachedTestEngine(t *testing.T, ttl time.Duration) (*Engine, *MockBM25Index, *MockMetadataStore) {
+	t.Helper()
+
+	bm25 := &MockBM25Index{
+		SearchFn: func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+			return []*store.BM25Result{
+				{DocID: "chunk1", Score: 0.9, MatchedTerms: []string{"login"}},
+				{DocID: "chunk2", Score: 0.7, MatchedTerms: []string{"logout"}},
+			}, nil
+		},
+	}
+	metadata := NewMockMetadataStore()
+	for _, c := range createTestChunks() {
+		metadata.chunks[c.ID] = c
+	}
+
+	config := DefaultConfig()
+	config.QueryCacheSize = 8
+	config.QueryCacheTTL = ttl
+	engine := New(bm25, &MockVectorStore{}, &MockEmbedder{}, metadata, config)
+	return engine, bm25, metadata
+}
+
+func TestEngine_QueryCache_RepeatedQueryIsServedFromCache(t *testing.T) {
+	// Given: an engine with the query cache enabled
+	engine, bm25, _ := setupCachedTestEngine(t, 0)
+	ctx := context.Background()
+
+	// When: the same query is searched twice, differing only in whitespace
+	first, err := engine.Search(ctx, "login handler", SearchOptions{Limit: 5})
+	require.NoError(t, err)
+	calls := bm25.searchCalled.Load()
+	second, err := engine.Search(ctx, "  login   handler ", SearchOptions{Limit: 5})
+	require.NoError(t, err)
+
+	// Then: the pipeline ran once and both calls return the same ranking
+	assert.Equal(t, calls, bm25.searchCalled.Load())
+	require.Len(t, second, len(first))
+	for i := range first {
+		assert.Equal(t, first[i].Chunk.ID, second[i].Chunk.ID)
+		assert.Equal(t, first[i].Score, second[i].Score)
+	}
+}
+
+func TestEngine_QueryCache_DifferentOptionsMiss(t *testing.T) {
+	// Given: a cached search
+	engine, bm25, _ := setupCachedTestEngine(t, 0)
+	ctx := context.Background()
+	_, err := engine.Search(ctx, "login", SearchOptions{Limit: 5})
+	require.NoError(t, err)
+	calls := bm25.searchCalled.Load()
+
+	// When: the same query is searched with a different limit
+	_, err = engine.Search(ctx, "login", SearchOptions{Limit: 1})
+	require.NoError(t, err)
+
+	// Then: the pipeline ran again
+	assert.Greater(t, bm25.searchCalled.Load(), calls)
+}
+
+func TestQueryCacheKey_RerankThreshold(t *testing.T) {
+	// Given: the same query with two rerank thresholds
+	low, ok := queryCacheKey("login", SearchOptions{Limit: 5, RerankThreshold: 0.1})
+	require.True(t, ok)
+	high, ok := queryCacheKey("login", SearchOptions{Limit: 5, RerankThreshold: 0.5})
+	require.True(t, ok)
+
+	// Then: they are cached separately, since reranking differs
+	assert.NotEqual(t, low, high)
+}
+
+func TestEngine_QueryCache_IndexInvalidates(t *testing.T) {
+	// Given: a cached search
+	engine, bm25, _ := setupCachedTestEngine(t, 0)
+	ctx := context.Background()
+	_, err := engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+	calls := bm25.searchCalled.Load()
+
+	// When: the index changes and the query is repeated
+	require.NoError(t, engine.Index(ctx, createTestChunks()[:1]))
+	_, err = engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+
+	// Then: the repeat was not served from the stale cache
+	assert.Greater(t, bm25.searchCalled.Load(), calls)
+}
+
+func TestEngine_QueryCache_DeleteInvalidates(t *testing.T) {
+	// Given: a cached search
+	engine, bm25, _ := setupCachedTestEngine(t, 0)
+	ctx := context.Background()
+	_, err := engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+	calls := bm25.searchCalled.Load()
+
+	// When: a chunk is deleted and the query is repeated
+	require.NoError(t, engine.Delete(ctx, []string{"chunk2"}))
+	_, err = engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+
+	// Then: the pipeline ran again
+	assert.Greater(t, bm25.searchCalled.Load(), calls)
+}
+
+func TestEngine_QueryCache_TTLExpires(t *testing.T) {
+	// Given: a cache whose entries expire almost immediately
+	engine, bm25, _ := setupCachedTestEngine(t, time.Millisecond)
+	ctx := context.Background()
+	_, err := engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+	calls := bm25.searchCalled.Load()
+
+	// When: the query is repeated after the TTL
+	time.Sleep(5 * time.Millisecond)
+	_, err = engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+
+	// Then: the expired entry was not used
+	assert.Greater(t, bm25.searchCalled.Load(), calls)
+}
+
+func TestEngine_QueryCache_HitReproducesSideOutputs(t *testing.T) {
+	// Given: a cached search
+	engine, _, _ := setupCachedTestEngine(t, 0)
+	ctx := context.Background()
+	_, err := engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+
+	// When: the query is repeated asking for diagnostics and classification
+	var diag SearchDiagnostics
+	var class QueryClassification
+	results, err := engine.Search(ctx, "login", SearchOptions{Diagnostics: &diag, QueryClassification: &class})
+	require.NoError(t, err)
+
+	// Then: the outputs match an uncached search
+	var wantDiag SearchDiagnostics
+	var wantClass QueryClassification
+	want, err := engine.search(ctx, "login", SearchOptions{Diagnostics: &wantDiag, QueryClassification: &wantClass})
+	require.NoError(t, err)
+	assert.Len(t, results, len(want))
+	assert.Equal(t, wantDiag.NoResultsReason, diag.NoResultsReason)
+	assert.Equal(t, wantClass, class)
+}
+
+func TestEngine_QueryCache_HitsAreIsolatedFromCallerChanges(t *testing.T) {
+	// Given: a cached search whose results the caller modifies
+	engine, _, _ := setupCachedTestEngine(t, 0)
+	ctx := context.Background()
+	first, err := engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, first)
+	score := first[0].Score
+	first[0].Score = -1
+
+	// When: the query is repeated
+	second, err := engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+
+	// Then: the cached copy is unchanged
+	assert.Equal(t, score, second[0].Score)
+}
+
+func TestEngine_QueryCache_DisabledByDefault(t *testing.T) {
+	// Given: an engine with the default config
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	ctx := context.Background()
+
+	// When: the same query is searched twice
+	_, err := engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+	calls := bm25.searchCalled.Load()
+	_, err = engine.Search(ctx, "login", SearchOptions{})
+	require.NoError(t, err)
+
+	// Then: both ran the pipeline
+	assert.Nil(t, engine.queryCache)
+	assert.Equal(t, 2*calls, bm25.searchCalled.Load())
+}
@@ -0,0 +1,352 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// reembedBatchSize is the number of chunks re-embedded per batch. The engine
+// lock is only held while a batch is written, so searches and indexing
+// interleave with the re-embedding.
+const reembedBatchSize = 64
+
+// reembedJob is a running background re-embedding (see
+// EngineConfig.AutoReindexOnDimensionChange and
+// EngineConfig.ReembedOnModelChange).
+type reembedJob struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// reembedIfDimensionChanged starts a background re-embedding when the
+// embedder's dimension no longer matches the indexed one and
+// EngineConfig.AutoReindexOnDimensionChange is set. It reports whether a
+// re-embedding is running, in which case the indexed dimension must not be
+// updated until it finishes. Must be called with e.mu held for writing.
+func (e *Engine) reembedIfDimensionChanged(ctx context.Context) (bool, error) {
+	if !e.config.AutoReindexOnDimensionChange {
+		return false, nil
+	}
+	if e.reembed != nil {
+		return true, nil
+	}
+	if err := e.validateDimensions(ctx); !errors.Is(err, ErrDimensionMismatch) {
+		return false, nil
+	}
+
+	resetter, ok := e.vector.(store.VectorResetter)
+	if !ok {
+		return false, fmt.Errorf("vector store cannot change dimension, run 'amanmcp reindex --force'")
+	}
+	dims := e.embedder.Dimensions()
+	if err := resetter.Reset(dims); err != nil {
+		return false, fmt.Errorf("failed to reset vector store: %w", err)
+	}
+
+	// The reset vector store has to be refilled from the first chunk, so an
+	// interrupted re-embedding to the same dimension starts over too; the
+	// chunks it already converted reuse their stored embeddings
+	if err := e.metadata.SetState(ctx, store.StateKeyReembedDimension, strconv.Itoa(dims)); err != nil {
+		return false, fmt.Errorf("failed to save re-embed checkpoint: %w", err)
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job := &reembedJob{cancel: cancel, done: make(chan struct{})}
+	e.reembed = job
+	go e.runReembed(jobCtx, job, dims, 0, false)
+	return true, nil
+}
+
+// ReembedIfModelChanged starts the background re-embedding of
+// EngineConfig.ReembedOnModelChange when the embedder's model differs from
+// the one the index was built with, e.g. after upgrading to a better model
+// of the same dimension. Index checks this too; call it at startup so the
+// conversion begins, or resumes, without waiting for a file change. It
+// reports whether a re-embedding is running. The engine lock is only taken
+// to claim the job, so searches and indexing are not held up by the check.
+func (e *Engine) ReembedIfModelChanged(ctx context.Context) (bool, error) {
+	if !e.config.ReembedOnModelChange {
+		return false, nil
+	}
+	e.mu.RLock()
+	running := e.reembed != nil
+	e.mu.RUnlock()
+	if running {
+		return true, nil
+	}
+
+	resumeFrom, changed, err := e.prepareModelReembed(ctx)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.reembed == nil {
+		e.startModelReembed(resumeFrom)
+	}
+	return true, nil
+}
+
+// reembedIfModelChanged starts a background re-embedding of the chunks whose
+// stored embedding comes from another model when the embedder's model
+// differs from the indexed one at the same dimension and
+// EngineConfig.ReembedOnModelChange is set. Dimension changes are left to
+// reembedIfDimensionChanged. It reports whether a re-embedding is running,
+// in which case the indexed model must not be updated until it finishes.
+// Must be called with e.mu held for writing.
+func (e *Engine) reembedIfModelChanged(ctx context.Context) (bool, error) {
+	if !e.config.ReembedOnModelChange {
+		return false, nil
+	}
+	if e.reembed != nil {
+		return true, nil
+	}
+	resumeFrom, changed, err := e.prepareModelReembed(ctx)
+	if err != nil || !changed {
+		return false, err
+	}
+	e.startModelReembed(resumeFrom)
+	return true, nil
+}
+
+// prepareModelReembed reports whether the embedder's model differs from the
+// indexed one at the same dimension and, if so, returns the checkpoint to
+// resume from, saving a fresh one when the conversion targets a new model.
+// It does not need e.mu.
+func (e *Engine) prepareModelReembed(ctx context.Context) (int, bool, error) {
+	indexModel, err := e.metadata.GetState(ctx, store.StateKeyIndexModel)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read index model: %w", err)
+	}
+	model := e.embedder.ModelName()
+	if indexModel == "" || indexModel == model || e.validateDimensions(ctx) != nil {
+		return 0, false, nil
+	}
+
+	resumeFrom := 0
+	target, _ := e.metadata.GetState(ctx, store.StateKeyReembedModel)
+	if target == model {
+		progress, _ := e.metadata.GetState(ctx, store.StateKeyReembedProgress)
+		resumeFrom, _ = strconv.Atoi(progress)
+	} else {
+		if err := e.metadata.SetState(ctx, store.StateKeyReembedModel, model); err != nil {
+			return 0, false, fmt.Errorf("failed to save re-embed checkpoint: %w", err)
+		}
+		if err := e.metadata.SetState(ctx, store.StateKeyReembedProgress, "0"); err != nil {
+			return 0, false, fmt.Errorf("failed to save re-embed checkpoint: %w", err)
+		}
+	}
+	slog.Info("embedder_model_changed",
+		slog.String("index_model", indexModel),
+		slog.String("model", model))
+	return resumeFrom, true, nil
+}
+
+// startModelReembed starts re-embedding the chunks of other models from
+// resumeFrom. Must be called with e.mu held for writing and no job running.
+func (e *Engine) startModelReembed(resumeFrom int) {
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job := &reembedJob{cancel: cancel, done: make(chan struct{})}
+	e.reembed = job
+	go e.runReembed(jobCtx, job, e.embedder.Dimensions(), resumeFrom, true)
+}
+
+// runReembed re-embeds every stored chunk with the current embedder and adds
+// it to the vector store. Chunks whose stored embedding already comes from
+// the current embedder are not embedded again. With keepCurrent, the vector
+// store was not reset: their vectors are left as they are, progress is
+// checkpointed after each batch and the run starts at resumeFrom, the
+// checkpoint of an interrupted run.
+func (e *Engine) runReembed(ctx context.Context, job *reembedJob, dims, resumeFrom int, keepCurrent bool) {
+	defer close(job.done)
+	completed := false
+	defer func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if e.reembed == job {
+			e.reembed = nil
+		}
+		if completed {
+			e.finishReembedLocked(ctx)
+		}
+	}()
+
+	start := time.Now()
+	ids, err := e.listChunkIDs(ctx)
+	if err != nil {
+		slog.Warn("reembed_failed", slog.String("error", err.Error()))
+		return
+	}
+	slog.Info("reembed_started",
+		slog.Int("dimensions", dims),
+		slog.String("model", e.embedder.ModelName()),
+		slog.Int("chunks", len(ids)),
+		slog.Int("resume_from", resumeFrom))
+
+	first := 0
+	if keepCurrent {
+		first = min(max(resumeFrom, 0), len(ids))
+	}
+	for lo := first; lo < len(ids); lo += reembedBatchSize {
+		if ctx.Err() != nil {
+			slog.Info("reembed_interrupted", slog.Int("processed", lo), slog.Int("total", len(ids)))
+			return
+		}
+		hi := min(lo+reembedBatchSize, len(ids))
+		if err := e.reembedBatch(ctx, ids[lo:hi], dims, keepCurrent); err != nil {
+			slog.Warn("reembed_failed",
+				slog.String("error", err.Error()),
+				slog.Int("processed", lo),
+				slog.Int("total", len(ids)))
+			return
+		}
+		if keepCurrent {
+			if err := e.metadata.SetState(ctx, store.StateKeyReembedProgress, strconv.Itoa(hi)); err != nil {
+				slog.Warn("failed to save re-embed checkpoint", slog.String("error", err.Error()))
+			}
+		}
+	}
+
+	completed = true
+	slog.Info("reembed_complete",
+		slog.Int("dimensions", dims),
+		slog.Int("chunks", len(ids)),
+		slog.Duration("duration", time.Since(start)))
+}
+
+// reembedBatch embeds the chunks with the given IDs, reusing stored
+// embeddings of the current dimension, and writes them to the vector store.
+// With keepCurrent, chunks whose stored embedding comes from the current
+// embedder are skipped instead. Chunks deleted meanwhile are skipped.
+func (e *Engine) reembedBatch(ctx context.Context, ids []string, dims int, keepCurrent bool) error {
+	chunks, err := e.metadata.GetChunks(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load chunks: %w", err)
+	}
+	reuse, err := e.StoredEmbeddings(ctx, ids)
+	if err != nil {
+		reuse = nil
+	}
+
+	embeddings := make(map[string][]float32, len(chunks))
+	models := make(map[string]string, len(chunks))
+	var pending []*store.Chunk
+	for _, c := range chunks {
+		if emb, ok := reuse[c.ID]; ok && len(emb) == dims {
+			if !keepCurrent {
+				embeddings[c.ID] = emb
+				models[c.ID] = e.reusedModel(c)
+			}
+			continue
+		}
+		pending = append(pending, c)
+	}
+	if len(pending) > 0 {
+		generated, generatedModels, err := e.embedChunkTexts(ctx, pending)
+		if err != nil {
+			return fmt.Errorf("generate embeddings: %w", err)
+		}
+		for i, c := range pending {
+			embeddings[c.ID] = generated[i]
+			models[c.ID] = generatedModels[i]
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Chunks may have been deleted while the batch was being embedded
+	present, err := e.metadata.GetChunks(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load chunks: %w", err)
+	}
+	if keepCurrent {
+		// or re-indexed with the current embedder
+		current, err := e.StoredEmbeddings(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to load stored embeddings: %w", err)
+		}
+		for id := range current {
+			delete(embeddings, id)
+		}
+	}
+	addIDs := make([]string, 0, len(present))
+	vectors := make([][]float32, 0, len(present))
+	addModels := make([]string, 0, len(present))
+	for _, c := range present {
+		if emb, ok := embeddings[c.ID]; ok {
+			addIDs = append(addIDs, c.ID)
+			vectors = append(vectors, emb)
+			addModels = append(addModels, models[c.ID])
+		}
+	}
+
+	if err := e.vector.Add(ctx, addIDs, vectors); err != nil {
+		return fmt.Errorf("add vectors: %w", err)
+	}
+	if err := e.saveChunkEmbeddings(ctx, addIDs, vectors, addModels); err != nil {
+		return fmt.Errorf("save embeddings: %w", err)
+	}
+	return nil
+}
+
+// finishReembedLocked records the new index dimension and clears the
+// re-embed checkpoint. Must be called with e.mu held for writing.
+func (e *Engine) finishReembedLocked(ctx context.Context) {
+	defer e.queryCache.invalidate()
+
+	if err := e.storeIndexEmbeddingInfo(ctx); err != nil {
+		slog.Warn("failed to store index embedding info", slog.String("error", err.Error()))
+		return
+	}
+	for _, key := range []string{store.StateKeyReembedDimension, store.StateKeyReembedModel, store.StateKeyReembedProgress} {
+		if err := e.metadata.SetState(ctx, key, ""); err != nil {
+			slog.Warn("failed to clear re-embed checkpoint", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// listChunkIDs returns every stored chunk ID in a stable order. Metadata
+// stores without store.ChunkIDLister fall back to the IDs of stored
+// embeddings.
+func (e *Engine) listChunkIDs(ctx context.Context) ([]string, error) {
+	if lister, ok := e.metadata.(store.ChunkIDLister); ok {
+		ids, err := lister.ListChunkIDs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chunks: %w", err)
+		}
+		return ids, nil
+	}
+
+	all, err := e.metadata.GetAllEmbeddings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embeddings: %w", err)
+	}
+	ids := make([]string, 0, len(all))
+	for id := range all {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// stopReembed cancels a running re-embedding and waits for it to stop. Its
+// checkpoint is kept, so the next Index resumes it.
+func (e *Engine) stopReembed() {
+	e.mu.Lock()
+	job := e.reembed
+	e.mu.Unlock()
+
+	if job != nil {
+		job.cancel()
+		<-job.done
+	}
+}
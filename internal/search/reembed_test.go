@@ -0,0 +1,256 @@
+package search
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// dimEmbedder returns a MockEmbedder producing non-zero vectors of dims.
+func dimEmbedder(dims int) *MockEmbedder {
+	return &MockEmbedder{
+		EmbedFn: func(_ context.Context, text string) ([]float32, error) {
+			vec := make([]float32, dims)
+			vec[len(text)%dims] = 1
+			return vec, nil
+		},
+		DimensionsFn: func() int { return dims },
+	}
+}
+
+// waitReembed blocks until a running re-embedding has finished.
+func waitReembed(e *Engine) {
+	e.mu.RLock()
+	job := e.reembed
+	e.mu.RUnlock()
+	if job != nil {
+		<-job.done
+	}
+}
+
+// newDimensionChangeStores returns stores holding three chunks indexed
+// with a 4-dimensional embedder.
+func newDimensionChangeStores(t *testing.T) (store.BM25Index, store.VectorStore, store.MetadataStore) {
+	t.Helper()
+	vector, err := store.NewFlatStore(store.DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+
+	old := newSQLiteTestEngine(t, vector, dimEmbedder(4), []*store.File{
+		{ID: "file-a", ProjectID: "proj", Path: "a.go", Language: "go"},
+	}, []*store.Chunk{
+		{ID: "a-1", FileID: "file-a", FilePath: "a.go", Content: "func Alpha() {}"},
+		{ID: "a-2", FileID: "file-a", FilePath: "a.go", Content: "func Beta() {}"},
+		{ID: "a-3", FileID: "file-a", FilePath: "a.go", Content: "func Gamma() {}"},
+	})
+	return old.bm25, old.vector, old.metadata
+}
+
+func TestEngine_Index_AutoReindexOnDimensionChange(t *testing.T) {
+	// Given: an index built with 4 dimensions and an 8-dimensional embedder
+	ctx := context.Background()
+	bm25, vector, metadata := newDimensionChangeStores(t)
+	cfg := DefaultConfig()
+	cfg.AutoReindexOnDimensionChange = true
+	engine := New(bm25, vector, dimEmbedder(8), metadata, cfg)
+	t.Cleanup(func() { _ = engine.Close() })
+
+	// When: new chunks are indexed
+	err := engine.Index(ctx, []*store.Chunk{
+		{ID: "a-4", FileID: "file-a", FilePath: "a.go", Content: "func Delta() {}"},
+	})
+	require.NoError(t, err)
+
+	// Then: BM25 search keeps working while the index is converted
+	results, err := engine.Search(ctx, "Delta", SearchOptions{Limit: 5})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "a-4", results[0].Chunk.ID)
+
+	// And: every chunk is re-embedded at the new dimension
+	waitReembed(engine)
+	assert.Equal(t, 4, vector.Count())
+	dim, err := metadata.GetState(ctx, store.StateKeyIndexDimension)
+	require.NoError(t, err)
+	assert.Equal(t, "8", dim)
+	assert.NoError(t, engine.validateDimensions(ctx))
+
+	// And: the checkpoint is cleared
+	target, err := metadata.GetState(ctx, store.StateKeyReembedDimension)
+	require.NoError(t, err)
+	assert.Empty(t, target)
+}
+
+func TestEngine_Index_DimensionChangeWithoutAutoReindex(t *testing.T) {
+	// Given: an index built with 4 dimensions and an 8-dimensional embedder
+	ctx := context.Background()
+	bm25, vector, metadata := newDimensionChangeStores(t)
+	engine := New(bm25, vector, dimEmbedder(8), metadata, DefaultConfig())
+	t.Cleanup(func() { _ = engine.Close() })
+
+	// When: new chunks are indexed without auto-reindex
+	err := engine.Index(ctx, []*store.Chunk{
+		{ID: "a-4", FileID: "file-a", FilePath: "a.go", Content: "func Delta() {}"},
+	})
+
+	// Then: the vectors are rejected and the stored dimension is unchanged
+	require.Error(t, err)
+	dim, err := metadata.GetState(ctx, store.StateKeyIndexDimension)
+	require.NoError(t, err)
+	assert.Equal(t, "4", dim)
+}
+
+// namedEmbedder overrides the model name of a MockEmbedder.
+type namedEmbedder struct {
+	*MockEmbedder
+	name string
+}
+
+func (e *namedEmbedder) ModelName() string { return e.name }
+
+func TestEngine_ReembedIfModelChanged(t *testing.T) {
+	// Given: an index built by a 4-dimensional model and an upgraded model
+	// of the same dimension
+	ctx := context.Background()
+	bm25, vector, metadata := newDimensionChangeStores(t)
+	cfg := DefaultConfig()
+	cfg.ReembedOnModelChange = true
+	upgraded := &namedEmbedder{MockEmbedder: dimEmbedder(4), name: "mock-embedder-v2"}
+	engine := New(bm25, vector, upgraded, metadata, cfg)
+	t.Cleanup(func() { _ = engine.Close() })
+
+	// When: checking for a model change at startup
+	running, err := engine.ReembedIfModelChanged(ctx)
+	require.NoError(t, err)
+	assert.True(t, running)
+	waitReembed(engine)
+
+	// Then: every chunk is re-embedded in place by the new model
+	counter, ok := metadata.(store.EmbeddingModelCounter)
+	require.True(t, ok)
+	counts, err := counter.GetEmbeddingModelCounts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"mock-embedder-v2": 3}, counts)
+	assert.Equal(t, 3, vector.Count())
+
+	// And: the index records the new model and the checkpoint is cleared
+	model, err := metadata.GetState(ctx, store.StateKeyIndexModel)
+	require.NoError(t, err)
+	assert.Equal(t, "mock-embedder-v2", model)
+	target, err := metadata.GetState(ctx, store.StateKeyReembedModel)
+	require.NoError(t, err)
+	assert.Empty(t, target)
+
+	// And: nothing is left to convert
+	running, err = engine.ReembedIfModelChanged(ctx)
+	require.NoError(t, err)
+	assert.False(t, running)
+}
+
+// lockProbeMetadata records whether the engine lock was held while the
+// re-embed checkpoint was read.
+type lockProbeMetadata struct {
+	store.MetadataStore
+	engine *Engine
+	locked atomic.Bool
+}
+
+func (m *lockProbeMetadata) GetState(ctx context.Context, key string) (string, error) {
+	if key == store.StateKeyReembedModel {
+		if m.engine.mu.TryRLock() {
+			m.engine.mu.RUnlock()
+		} else {
+			m.locked.Store(true)
+		}
+	}
+	return m.MetadataStore.GetState(ctx, key)
+}
+
+func TestEngine_ReembedIfModelChanged_ChecksWithoutLock(t *testing.T) {
+	// Given: a model change and a metadata store that probes the engine lock
+	ctx := context.Background()
+	bm25, vector, metadata := newDimensionChangeStores(t)
+	cfg := DefaultConfig()
+	cfg.ReembedOnModelChange = true
+	upgraded := &namedEmbedder{MockEmbedder: dimEmbedder(4), name: "mock-embedder-v2"}
+	probe := &lockProbeMetadata{MetadataStore: metadata}
+	engine := New(bm25, vector, upgraded, probe, cfg)
+	probe.engine = engine
+	t.Cleanup(func() { _ = engine.Close() })
+
+	// When: checking for a model change at startup
+	running, err := engine.ReembedIfModelChanged(ctx)
+	require.NoError(t, err)
+	waitReembed(engine)
+
+	// Then: the re-embedding starts without the checkpoint being read under the lock
+	assert.True(t, running)
+	assert.False(t, probe.locked.Load())
+}
+
+func TestEngine_ReembedIfModelChanged_SkipsConvertedChunks(t *testing.T) {
+	// Given: an interrupted conversion that already re-embedded one chunk
+	ctx := context.Background()
+	bm25, vector, metadata := newDimensionChangeStores(t)
+	upgradedModel := dimEmbedder(4)
+	upgraded := &namedEmbedder{MockEmbedder: upgradedModel, name: "mock-embedder-v2"}
+	require.NoError(t, metadata.SaveChunkEmbeddings(ctx, []string{"a-1"}, [][]float32{{1, 0, 0, 0}}, upgraded.name))
+	require.NoError(t, metadata.SetState(ctx, store.StateKeyReembedModel, upgraded.name))
+	cfg := DefaultConfig()
+	cfg.ReembedOnModelChange = true
+	engine := New(bm25, vector, upgraded, metadata, cfg)
+	t.Cleanup(func() { _ = engine.Close() })
+
+	// When: the conversion resumes
+	_, err := engine.ReembedIfModelChanged(ctx)
+	require.NoError(t, err)
+	waitReembed(engine)
+
+	// Then: only the remaining chunks are embedded
+	assert.Equal(t, int32(2), upgradedModel.embedCalled.Load())
+}
+
+func TestEngine_ReembedIfModelChanged_ResumesFromCheckpoint(t *testing.T) {
+	// Given: an interrupted conversion whose checkpoint covers two chunks
+	ctx := context.Background()
+	bm25, vector, metadata := newDimensionChangeStores(t)
+	upgradedModel := dimEmbedder(4)
+	upgraded := &namedEmbedder{MockEmbedder: upgradedModel, name: "mock-embedder-v2"}
+	require.NoError(t, metadata.SetState(ctx, store.StateKeyReembedModel, upgraded.name))
+	require.NoError(t, metadata.SetState(ctx, store.StateKeyReembedProgress, "2"))
+	cfg := DefaultConfig()
+	cfg.ReembedOnModelChange = true
+	engine := New(bm25, vector, upgraded, metadata, cfg)
+	t.Cleanup(func() { _ = engine.Close() })
+
+	// When: the conversion resumes
+	_, err := engine.ReembedIfModelChanged(ctx)
+	require.NoError(t, err)
+	waitReembed(engine)
+
+	// Then: only the chunk after the checkpoint is embedded
+	assert.Equal(t, int32(1), upgradedModel.embedCalled.Load())
+}
+
+func TestEngine_ReembedIfModelChanged_Disabled(t *testing.T) {
+	// Given: a model change without ReembedOnModelChange
+	ctx := context.Background()
+	bm25, vector, metadata := newDimensionChangeStores(t)
+	upgraded := &namedEmbedder{MockEmbedder: dimEmbedder(4), name: "mock-embedder-v2"}
+	engine := New(bm25, vector, upgraded, metadata, DefaultConfig())
+	t.Cleanup(func() { _ = engine.Close() })
+
+	// When: checking for a model change
+	running, err := engine.ReembedIfModelChanged(ctx)
+
+	// Then: nothing is re-embedded
+	require.NoError(t, err)
+	assert.False(t, running)
+	model, err := metadata.GetState(ctx, store.StateKeyIndexModel)
+	require.NoError(t, err)
+	assert.Equal(t, "mock-embedder", model)
+}
@@ -241,3 +241,98 @@ func TestEngine_Search_RerankerPolicyControlsCallsAndStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestEngine_Search_RerankThresholdSkipsClearWinner(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         RerankerPolicy
+		vecResults     []*store.VectorResult
+		wantCalls      int
+		wantState      string
+		wantSkipReason string
+	}{
+		{
+			name:           "clear winner skips reranking",
+			policy:         RerankerPolicyAuto,
+			vecResults:     []*store.VectorResult{{ID: "chunk1", Score: 0.85}},
+			wantCalls:      0,
+			wantState:      RerankerStateSkipped,
+			wantSkipReason: RerankerSkipClearWinner,
+		},
+		{
+			name:       "ambiguous top results are reranked",
+			policy:     RerankerPolicyAuto,
+			vecResults: []*store.VectorResult{{ID: "chunk1", Score: 0.85}, {ID: "chunk2", Score: 0.75}},
+			wantCalls:  1,
+			wantState:  RerankerStateApplied,
+		},
+		{
+			name:       "always ignores the threshold",
+			policy:     RerankerPolicyAlways,
+			vecResults: []*store.VectorResult{{ID: "chunk1", Score: 0.85}},
+			wantCalls:  1,
+			wantState:  RerankerStateApplied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: chunk1 leads BM25 and vector search may or may not agree
+			engine, bm25, vector, embedder, metadata := setupTestEngine(t)
+			engine.config.RerankerPolicy = tt.policy
+			require.NoError(t, metadata.SaveChunks(context.Background(), []*store.Chunk{
+				{ID: "chunk1", Content: "func FindProjectRoot() {}", FilePath: "config.go", ContentType: store.ContentTypeCode},
+				{ID: "chunk2", Content: "func SearchConfig() {}", FilePath: "search.go", ContentType: store.ContentTypeCode},
+			}))
+			bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+				return []*store.BM25Result{{DocID: "chunk1", Score: 0.9}, {DocID: "chunk2", Score: 0.8}}, nil
+			}
+			embedder.EmbedFn = func(ctx context.Context, text string) ([]float32, error) {
+				return make([]float32, 768), nil
+			}
+			vector.SearchFn = func(ctx context.Context, query []float32, k int) ([]*store.VectorResult, error) {
+				return tt.vecResults, nil
+			}
+			mockReranker := &MockReranker{
+				RerankFn: func(ctx context.Context, query string, documents []string, topK int) ([]RerankResult, error) {
+					return []RerankResult{
+						{Index: 1, Score: 1.0, Document: documents[1]},
+						{Index: 0, Score: 0.5, Document: documents[0]},
+					}, nil
+				},
+			}
+			engine.reranker = mockReranker
+
+			// When: searching with a rerank threshold and explain on
+			results, err := engine.Search(context.Background(), "how is the project root found", SearchOptions{
+				Limit:               10,
+				RerankThreshold:     0.02,
+				Explain:             true,
+				QueryClassification: &QueryClassification{Type: QueryType("natural_language_intent")},
+			})
+
+			// Then: the reranker runs only without a clear winner, and explain says so
+			require.NoError(t, err)
+			require.NotEmpty(t, results)
+			assert.Equal(t, tt.wantCalls, mockReranker.called)
+			require.NotNil(t, results[0].Explain)
+			assert.Equal(t, tt.wantState, results[0].Explain.Reranker.State)
+			assert.Equal(t, tt.wantSkipReason, results[0].Explain.Reranker.SkipReason)
+		})
+	}
+}
+
+func TestIsClearWinner(t *testing.T) {
+	fused := func(scores ...float64) []*fusedResult {
+		out := make([]*fusedResult, len(scores))
+		for i, s := range scores {
+			out[i] = &fusedResult{rrfScore: s}
+		}
+		return out
+	}
+
+	assert.True(t, isClearWinner(fused(1, 0.5), 0.5))
+	assert.False(t, isClearWinner(fused(1, 0.6), 0.5))
+	assert.False(t, isClearWinner(fused(1, 0.1), 0), "zero threshold disables the check")
+	assert.False(t, isClearWinner(fused(1), 0.5), "a single result is not a contest")
+}
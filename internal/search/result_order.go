@@ -0,0 +1,97 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortBy selects the order of the final search results.
+type SortBy string
+
+const (
+	// SortByScore keeps results in ranking order, best first (the default).
+	SortByScore SortBy = "score"
+
+	// SortByPath orders results by file path, then by start line within a
+	// file, e.g. to list all matches in a package.
+	SortByPath SortBy = "path"
+
+	// SortByRecency orders results by the last update of their chunk,
+	// newest first.
+	SortByRecency SortBy = "recency"
+
+	// SortByLine groups results by file, files in the order of their best
+	// result, and orders each file's results by start line.
+	SortByLine SortBy = "line"
+)
+
+// ParseSortBy parses a result order name. Empty selects SortByScore.
+func ParseSortBy(value string) (SortBy, error) {
+	sortBy := SortBy(strings.ToLower(strings.TrimSpace(value)))
+	switch sortBy {
+	case "":
+		return SortByScore, nil
+	case SortByScore, SortByPath, SortByRecency, SortByLine:
+		return sortBy, nil
+	default:
+		return "", fmt.Errorf("unknown sort order %q; use one of: score, path, recency, line", value)
+	}
+}
+
+// SortResults reorders results in place according to sortBy and returns
+// them. It runs on the final results, after filtering and the limit, so it
+// changes the order but never which results are returned. Ties fall back to
+// score, then to the incoming order. Results without a chunk sort last.
+// SortByScore and unknown orders leave results unchanged.
+func SortResults(results []*SearchResult, sortBy SortBy) []*SearchResult {
+	var less func(a, b *SearchResult) bool
+	switch sortBy {
+	case SortByPath:
+		less = func(a, b *SearchResult) bool {
+			if a.Chunk.FilePath != b.Chunk.FilePath {
+				return a.Chunk.FilePath < b.Chunk.FilePath
+			}
+			return a.Chunk.StartLine < b.Chunk.StartLine
+		}
+	case SortByRecency:
+		less = func(a, b *SearchResult) bool {
+			return a.Chunk.UpdatedAt.After(b.Chunk.UpdatedAt)
+		}
+	case SortByLine:
+		// Rank files by their first (best-scoring) result
+		fileRank := make(map[string]int)
+		for _, r := range results {
+			if r == nil || r.Chunk == nil {
+				continue
+			}
+			if _, ok := fileRank[r.Chunk.FilePath]; !ok {
+				fileRank[r.Chunk.FilePath] = len(fileRank)
+			}
+		}
+		less = func(a, b *SearchResult) bool {
+			if a.Chunk.FilePath != b.Chunk.FilePath {
+				return fileRank[a.Chunk.FilePath] < fileRank[b.Chunk.FilePath]
+			}
+			return a.Chunk.StartLine < b.Chunk.StartLine
+		}
+	default:
+		return results
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		aOK, bOK := a != nil && a.Chunk != nil, b != nil && b.Chunk != nil
+		if !aOK || !bOK {
+			return aOK && !bOK
+		}
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return a.Score > b.Score
+	})
+	return results
+}
@@ -0,0 +1,114 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func orderedResult(id, path string, line int, score float64, updated time.Time) *SearchResult {
+	return &SearchResult{
+		Chunk: &store.Chunk{ID: id, FilePath: path, StartLine: line, UpdatedAt: updated},
+		Score: score,
+	}
+}
+
+func resultIDs(results []*SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		if r == nil || r.Chunk == nil {
+			ids[i] = ""
+			continue
+		}
+		ids[i] = r.Chunk.ID
+	}
+	return ids
+}
+
+func TestSortResults(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	// Given: score-ordered results spread over two files
+	ranked := func() []*SearchResult {
+		return []*SearchResult{
+			orderedResult("b40", "pkg/b.go", 40, 0.9, day(1)),
+			orderedResult("a30", "pkg/a.go", 30, 0.8, day(3)),
+			orderedResult("b10", "pkg/b.go", 10, 0.7, day(2)),
+			orderedResult("a10", "pkg/a.go", 10, 0.6, day(3)),
+			{Score: 0.5},
+		}
+	}
+
+	tests := []struct {
+		sortBy SortBy
+		want   []string
+	}{
+		{SortByScore, []string{"b40", "a30", "b10", "a10", ""}},
+		{"", []string{"b40", "a30", "b10", "a10", ""}},
+		{SortByPath, []string{"a10", "a30", "b10", "b40", ""}},
+		{SortByRecency, []string{"a30", "a10", "b10", "b40", ""}},
+		{SortByLine, []string{"b10", "b40", "a10", "a30", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.sortBy), func(t *testing.T) {
+			// When: sorting the results
+			got := SortResults(ranked(), tt.sortBy)
+
+			// Then: they come back in the requested order, chunkless results last
+			assert.Equal(t, tt.want, resultIDs(got))
+		})
+	}
+}
+
+func TestSortResults_TiesFallBackToScore(t *testing.T) {
+	// Given: two results for the same file and line with ascending scores
+	results := []*SearchResult{
+		orderedResult("low", "a.go", 5, 0.2, time.Time{}),
+		orderedResult("high", "a.go", 5, 0.9, time.Time{}),
+	}
+
+	// When: sorting by path
+	SortResults(results, SortByPath)
+
+	// Then: the higher score wins the tie
+	assert.Equal(t, []string{"high", "low"}, resultIDs(results))
+}
+
+func TestParseSortBy(t *testing.T) {
+	for input, want := range map[string]SortBy{
+		"": SortByScore, "score": SortByScore, " Path ": SortByPath,
+		"recency": SortByRecency, "line": SortByLine,
+	} {
+		got, err := ParseSortBy(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseSortBy("size")
+	assert.ErrorContains(t, err, "unknown sort order")
+	assert.Error(t, SearchOptions{SortBy: "size"}.Validate())
+}
+
+func TestEngine_Search_SortByPath(t *testing.T) {
+	// Given: BM25 results where the best match is in the later file
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{
+			{DocID: "chunk2", Score: 0.9},
+			{DocID: "chunk1", Score: 0.7},
+		}, nil
+	}
+
+	// When: searching sorted by path
+	results, err := engine.Search(context.Background(), "auth", SearchOptions{BM25Only: true, SortBy: SortByPath})
+
+	// Then: results come back in path order
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "auth/login.go", results[0].Chunk.FilePath)
+	assert.Equal(t, "auth/logout.go", results[1].Chunk.FilePath)
+}
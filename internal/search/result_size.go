@@ -0,0 +1,31 @@
+package search
+
+import "strings"
+
+// setResultSizes fills LineCount and ByteCount on each result from its chunk.
+func setResultSizes(results []*SearchResult) {
+	for _, r := range results {
+		setResultSize(r)
+	}
+}
+
+// setResultSize fills LineCount and ByteCount on r from its chunk.
+func setResultSize(r *SearchResult) {
+	if r == nil || r.Chunk == nil {
+		return
+	}
+	r.ByteCount = len(r.Chunk.Content)
+	r.LineCount = chunkLineCount(r.Chunk.StartLine, r.Chunk.EndLine, r.Chunk.Content)
+}
+
+// chunkLineCount returns the number of lines in the range startLine..endLine,
+// or in content when the range is unknown.
+func chunkLineCount(startLine, endLine int, content string) int {
+	if startLine > 0 && endLine >= startLine {
+		return endLine - startLine + 1
+	}
+	if content == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimSuffix(content, "\n"), "\n") + 1
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestChunkLineCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		startLine int
+		endLine   int
+		content   string
+		want      int
+	}{
+		{name: "line range", startLine: 10, endLine: 14, content: "x", want: 5},
+		{name: "single line", startLine: 3, endLine: 3, content: "x", want: 1},
+		{name: "no range counts content", content: "a\nb\nc\n", want: 3},
+		{name: "no trailing newline", content: "a\nb", want: 2},
+		{name: "empty", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, chunkLineCount(tt.startLine, tt.endLine, tt.content))
+		})
+	}
+}
+
+func TestEngine_Search_ResultSizes(t *testing.T) {
+	// Given: a chunk spanning lines 5-7
+	engine, bm25, _, _, metadata := setupTestEngine(t)
+	metadata.chunks["sized"] = &store.Chunk{
+		ID:        "sized",
+		FileID:    "sized",
+		FilePath:  "pkg/sized.go",
+		Content:   "func Sized() {\n\treturn\n}",
+		StartLine: 5,
+		EndLine:   7,
+	}
+	bm25.SearchFn = func(_ context.Context, _ string, _ int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{{DocID: "sized", Score: 1}}, nil
+	}
+
+	// When: searching
+	results, err := engine.Search(context.Background(), "sized", SearchOptions{BM25Only: true})
+
+	// Then: the result carries the chunk's size
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 3, results[0].LineCount)
+	assert.Equal(t, len("func Sized() {\n\treturn\n}"), results[0].ByteCount)
+}
+
+func TestAddSubQueryPathCandidates_SetsResultSizes(t *testing.T) {
+	// Given: an indexed file matched only by a sub-query path hint
+	metadata := NewMockMetadataStore()
+	metadata.chunks["fusion"] = &store.Chunk{
+		ID:          "fusion",
+		FileID:      "fusion-file",
+		FilePath:    "internal/search/fusion.go",
+		Content:     "func (f *RRFFusion) Fuse() {\n}",
+		ContentType: store.ContentTypeCode,
+		Language:    "go",
+		StartLine:   10,
+		EndLine:     11,
+	}
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, &MockEmbedder{}, metadata, DefaultConfig())
+
+	// When: adding path candidates
+	results, err := engine.addSubQueryPathCandidates(context.Background(), nil, []SubQuery{
+		{Query: "internal/search/fusion.go", Weight: 6.0, Hint: "code"},
+	}, SearchOptions{Limit: 10, Filter: "code", Profile: ProfileCode})
+
+	// Then: the candidate is sized when it is produced
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 2, results[0].LineCount)
+	assert.Equal(t, len("func (f *RRFFusion) Fuse() {\n}"), results[0].ByteCount)
+}
@@ -0,0 +1,107 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sort"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// MaxSalientTerms is the number of terms reported in SearchResult.SalientTerms.
+const MaxSalientTerms = 5
+
+// attachSalientTerms populates SalientTerms on each result when
+// opts.IncludeSalientTerms is set. It needs a BM25 index that exposes term
+// statistics (store.TermStatsProvider); results keep empty SalientTerms
+// otherwise, or when the statistics cannot be read.
+func (e *Engine) attachSalientTerms(ctx context.Context, results []*SearchResult, opts SearchOptions) {
+	if !opts.IncludeSalientTerms || len(results) == 0 {
+		return
+	}
+	provider, ok := e.bm25.(store.TermStatsProvider)
+	if !ok {
+		return
+	}
+	stats := e.bm25.Stats()
+	if stats == nil || stats.DocumentCount == 0 {
+		return
+	}
+
+	// Term frequencies per result, and one document frequency lookup for
+	// every distinct term across the result set
+	termFreqs := make([]map[string]int, len(results))
+	var terms []string
+	seen := make(map[string]struct{})
+	for i, r := range results {
+		if r == nil || r.Chunk == nil {
+			continue
+		}
+		tf := make(map[string]int)
+		for _, term := range provider.AnalyzeTerms(r.Chunk.Content) {
+			if isStopWord(term) {
+				continue
+			}
+			tf[term]++
+			if _, ok := seen[term]; !ok {
+				seen[term] = struct{}{}
+				terms = append(terms, term)
+			}
+		}
+		termFreqs[i] = tf
+	}
+	if len(terms) == 0 {
+		return
+	}
+
+	docFreqs, err := provider.DocumentFrequencies(ctx, terms)
+	if err != nil {
+		slog.Debug("salient_terms_unavailable", slog.String("error", err.Error()))
+		return
+	}
+
+	for i, r := range results {
+		if termFreqs[i] != nil {
+			r.SalientTerms = salientTerms(termFreqs[i], docFreqs, stats.DocumentCount)
+		}
+	}
+}
+
+// salientTerms ranks a chunk's terms by TF-IDF against a corpus of docCount
+// documents and returns the top MaxSalientTerms, ties broken alphabetically.
+// IDF uses the BM25 formula so the ranking agrees with how the index weighs
+// the same terms. Terms the index has no statistics for are skipped.
+func salientTerms(termFreqs map[string]int, docFreqs map[string]int, docCount int) []string {
+	type scoredTerm struct {
+		term  string
+		score float64
+	}
+	scored := make([]scoredTerm, 0, len(termFreqs))
+	for term, tf := range termFreqs {
+		df := docFreqs[term]
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(1 + (float64(docCount)-float64(df)+0.5)/(float64(df)+0.5))
+		scored = append(scored, scoredTerm{term: term, score: float64(tf) * idf})
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].term < scored[j].term
+	})
+
+	if len(scored) > MaxSalientTerms {
+		scored = scored[:MaxSalientTerms]
+	}
+	if len(scored) == 0 {
+		return nil
+	}
+	out := make([]string, len(scored))
+	for i, s := range scored {
+		out[i] = s.term
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// termStatsBM25Index adds canned term statistics to MockBM25Index.
+type termStatsBM25Index struct {
+	*MockBM25Index
+	docFreqs map[string]int
+	err      error
+}
+
+var _ store.TermStatsProvider = (*termStatsBM25Index)(nil)
+
+func (m *termStatsBM25Index) AnalyzeTerms(text string) []string {
+	return strings.Fields(strings.ToLower(text))
+}
+
+func (m *termStatsBM25Index) DocumentFrequencies(ctx context.Context, terms []string) (map[string]int, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	out := make(map[string]int)
+	for _, term := range terms {
+		if df, ok := m.docFreqs[term]; ok {
+			out[term] = df
+		}
+	}
+	return out, nil
+}
+
+func TestSalientTerms_RanksByTFIDF(t *testing.T) {
+	// Given: term frequencies in a chunk and document frequencies in a 100-doc corpus
+	termFreqs := map[string]int{
+		"retry": 3, "backoff": 1, "jitter": 1, "context": 2,
+		"user": 4, "handler": 1, "unknown": 5,
+	}
+	docFreqs := map[string]int{
+		"retry": 2, "backoff": 2, "jitter": 2, "context": 30,
+		"user": 90, "handler": 60,
+	}
+
+	// When: ranking the terms
+	terms := salientTerms(termFreqs, docFreqs, 100)
+
+	// Then: rare terms outrank common ones, ties sort alphabetically,
+	// unindexed terms are skipped and the list is bounded
+	assert.Equal(t, []string{"retry", "backoff", "jitter", "context", "handler"}, terms)
+}
+
+func TestEngine_Search_IncludeSalientTerms(t *testing.T) {
+	// Given: an engine whose BM25 index exposes term statistics
+	engine, bm25, _, _, metadata := setupTestEngine(t)
+	metadata.chunks["chunk1"].Content = "retry backoff retry the user"
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{{DocID: "chunk1", Score: 0.9}}, nil
+	}
+	bm25.StatsFn = func() *store.IndexStats { return &store.IndexStats{DocumentCount: 50} }
+	engine.bm25 = &termStatsBM25Index{
+		MockBM25Index: bm25,
+		docFreqs:      map[string]int{"retry": 1, "backoff": 3, "user": 40, "the": 50},
+	}
+
+	// When: searching with and without IncludeSalientTerms
+	with, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true, IncludeSalientTerms: true})
+	require.NoError(t, err)
+	without, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true})
+	require.NoError(t, err)
+
+	// Then: only the opted-in search reports salient terms, without stop words
+	require.Len(t, with, 1)
+	assert.Equal(t, []string{"retry", "backoff", "user"}, with[0].SalientTerms)
+	require.Len(t, without, 1)
+	assert.Empty(t, without[0].SalientTerms)
+}
+
+func TestEngine_Search_IncludeSalientTerms_Degrades(t *testing.T) {
+	tests := []struct {
+		name string
+		bm25 func(*MockBM25Index) store.BM25Index
+	}{
+		{
+			name: "index without term statistics",
+			bm25: func(m *MockBM25Index) store.BM25Index { return m },
+		},
+		{
+			name: "statistics lookup fails",
+			bm25: func(m *MockBM25Index) store.BM25Index {
+				return &termStatsBM25Index{MockBM25Index: m, err: errors.New("closed")}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: an engine that cannot provide term statistics
+			engine, bm25, _, _, _ := setupTestEngine(t)
+			bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+				return []*store.BM25Result{{DocID: "chunk1", Score: 0.9}}, nil
+			}
+			bm25.StatsFn = func() *store.IndexStats { return &store.IndexStats{DocumentCount: 10} }
+			engine.bm25 = tt.bm25(bm25)
+
+			// When: searching with IncludeSalientTerms
+			results, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true, IncludeSalientTerms: true})
+
+			// Then: the search succeeds without salient terms
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Empty(t, results[0].SalientTerms)
+		})
+	}
+}
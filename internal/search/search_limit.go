@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// searchGate bounds how many searches run at once and counts the searches
+// running and waiting. With no limit it only counts. A nil gate does nothing.
+type searchGate struct {
+	slots    chan struct{} // nil = unlimited
+	inFlight atomic.Int64
+	queued   atomic.Int64
+}
+
+// newSearchGate returns a gate admitting up to limit concurrent searches,
+// or any number when limit is not positive.
+func newSearchGate(limit int) *searchGate {
+	g := &searchGate{}
+	if limit > 0 {
+		g.slots = make(chan struct{}, limit)
+	}
+	return g
+}
+
+// acquire waits for a free slot or until ctx is done. On success the caller
+// must call release once the search finishes.
+func (g *searchGate) acquire(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	if g.slots != nil {
+		select {
+		case g.slots <- struct{}{}:
+		default:
+			g.queued.Add(1)
+			select {
+			case g.slots <- struct{}{}:
+				g.queued.Add(-1)
+			case <-ctx.Done():
+				g.queued.Add(-1)
+				return ctx.Err()
+			}
+		}
+	}
+	g.inFlight.Add(1)
+	return nil
+}
+
+// release frees the slot taken by acquire.
+func (g *searchGate) release() {
+	if g == nil {
+		return
+	}
+	g.inFlight.Add(-1)
+	if g.slots != nil {
+		<-g.slots
+	}
+}
+
+// SearchConcurrency reports the engine's search load.
+type SearchConcurrency struct {
+	// Limit is EngineConfig.MaxConcurrentSearches (0 = unlimited).
+	Limit int
+
+	// InFlight is the number of searches currently running.
+	InFlight int
+
+	// Queued is the number of searches waiting for a free slot.
+	Queued int
+}
+
+// SearchConcurrency returns the current number of running and queued
+// searches. Cache hits are answered without a slot and are not counted.
+func (e *Engine) SearchConcurrency() SearchConcurrency {
+	if e.searches == nil {
+		return SearchConcurrency{}
+	}
+	return SearchConcurrency{
+		Limit:    cap(e.searches.slots),
+		InFlight: int(e.searches.inFlight.Load()),
+		Queued:   int(e.searches.queued.Load()),
+	}
+}
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestEngine_Search_MaxConcurrentSearches(t *testing.T) {
+	// Given: an engine admitting one search at a time, with a BM25 index that
+	// blocks until released
+	_, bm25, vector, embedder, metadata := setupTestEngine(t)
+	cfg := DefaultConfig()
+	cfg.MaxConcurrentSearches = 1
+	engine := New(bm25, vector, embedder, metadata, cfg)
+
+	started := make(chan struct{}, 2)
+	unblock := make(chan struct{})
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		started <- struct{}{}
+		<-unblock
+		return []*store.BM25Result{{DocID: "chunk1", Score: 1}}, nil
+	}
+	search := func(ctx context.Context) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := engine.Search(ctx, "login", SearchOptions{BM25Only: true})
+			done <- err
+		}()
+		return done
+	}
+
+	// When: a second search arrives while the first is running
+	first := search(context.Background())
+	<-started
+	second := search(context.Background())
+
+	// Then: it waits in the queue
+	require.Eventually(t, func() bool {
+		return engine.SearchConcurrency() == SearchConcurrency{Limit: 1, InFlight: 1, Queued: 1}
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 1, engine.Stats().Concurrency.Queued)
+
+	// And: a queued search whose context ends gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	third := search(ctx)
+	require.Eventually(t, func() bool { return engine.SearchConcurrency().Queued == 2 }, time.Second, time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-third, context.Canceled)
+
+	// And: the queued search runs once the first finishes
+	close(unblock)
+	require.NoError(t, <-first)
+	require.NoError(t, <-second)
+	assert.Len(t, started, 1, "the queued search should run once a slot frees")
+	assert.Equal(t, SearchConcurrency{Limit: 1}, engine.SearchConcurrency())
+}
+
+func TestEngine_SearchConcurrency_UnlimitedByDefault(t *testing.T) {
+	// Given: an engine with the default configuration
+	engine, bm25, _, _, _ := setupTestEngine(t)
+	var during SearchConcurrency
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		during = engine.SearchConcurrency()
+		return nil, nil
+	}
+
+	// When: searching
+	_, err := engine.Search(context.Background(), "login", SearchOptions{BM25Only: true})
+
+	// Then: searches are counted but not limited
+	require.NoError(t, err)
+	assert.Equal(t, SearchConcurrency{InFlight: 1}, during)
+	assert.Equal(t, SearchConcurrency{}, engine.SearchConcurrency())
+}
+
+func TestEngine_Search_PathsOnlyWithChunkFiltersTakesOneSlot(t *testing.T) {
+	// Given: an engine admitting one search at a time
+	_, bm25, vector, embedder, metadata := setupTestEngine(t)
+	cfg := DefaultConfig()
+	cfg.MaxConcurrentSearches = 1
+	engine := New(bm25, vector, embedder, metadata, cfg)
+	metadata.chunks["auth1"] = &store.Chunk{ID: "auth1", FileID: "f-auth", FilePath: "internal/auth.go", Content: "func Login() {}", StartLine: 1, EndLine: 1}
+	bm25.SearchFn = func(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+		return []*store.BM25Result{{DocID: "auth1", Score: 1}}, nil
+	}
+
+	// When: a paths-only search excludes symbols, which runs the
+	// regular search underneath
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results, err := engine.Search(ctx, "login", SearchOptions{BM25Only: true, PathsOnly: true, ExcludeSymbolPatterns: []string{"Test*"}})
+
+	// Then: it completes within its single slot
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "internal/auth.go", results[0].Chunk.FilePath)
+	assert.Equal(t, SearchConcurrency{Limit: 1}, engine.SearchConcurrency())
+}
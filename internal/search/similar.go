@@ -0,0 +1,194 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ErrFileNotIndexed is returned by SimilarFiles when the source file has no
+// indexed chunks.
+var ErrFileNotIndexed = errors.New("file not indexed")
+
+// ErrVectorIndexRequired is returned by SimilarFiles when there are no
+// vectors to compare: the index is BM25-only, or the source file's chunks
+// have no stored embedding from the current embedder.
+var ErrVectorIndexRequired = errors.New("similar files require a vector index")
+
+// similarFilesCandidatesPerResult is how many chunk hits are fetched from the
+// vector store per requested file. Files contribute several chunks each, so
+// the chunk pool has to be wider than k to yield k distinct files.
+const similarFilesCandidatesPerResult = 10
+
+// SimilarFile is one result of Engine.SimilarFiles.
+type SimilarFile struct {
+	FilePath string
+
+	// Score is the cosine similarity (0-1, see store.VectorResult.Score)
+	// between the source file's pooled vector and this file's closest chunk.
+	Score float64
+
+	// MatchedChunks is how many of this file's chunks were among the nearest
+	// neighbours of the source file.
+	MatchedChunks int
+}
+
+// SimilarFiles returns up to k files whose content is semantically closest
+// to the file at path, for "related code" navigation. Only files of
+// projectID are returned, and the source file is never included.
+//
+// The file-level vector is the mean of the file's stored chunk embeddings,
+// each normalized to unit length first so that every chunk counts equally.
+// It is searched against the chunk vectors, and each other file is scored by
+// its best-matching chunk. Only embeddings persisted at index time are used;
+// nothing is re-embedded.
+//
+// This needs a vector index: it returns ErrVectorIndexRequired for
+// BM25-only indexes and for files without embeddings from the current
+// embedder, ErrDimensionMismatch if the embedder no longer matches the
+// index, and ErrFileNotIndexed if path has no indexed chunks.
+func (e *Engine) SimilarFiles(ctx context.Context, projectID, path string, k int) ([]*SimilarFile, error) {
+	if k <= 0 {
+		k = e.config.DefaultLimit
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	file, err := e.metadata.GetFileByPath(ctx, projectID, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %s: %w", path, err)
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotIndexed, path)
+	}
+	chunks, err := e.metadata.GetChunksByFile(ctx, file.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunks for %s: %w", path, err)
+	}
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotIndexed, path)
+	}
+
+	if e.vector.Count() == 0 {
+		return nil, ErrVectorIndexRequired
+	}
+	if err := e.validateDimensions(ctx); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(chunks))
+	for i, c := range chunks {
+		ids[i] = c.ID
+	}
+	embeddings, err := e.StoredEmbeddings(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embeddings for %s: %w", path, err)
+	}
+	vectors := make([][]float32, 0, len(ids))
+	for _, id := range ids {
+		if v, ok := embeddings[id]; ok {
+			vectors = append(vectors, v)
+		}
+	}
+	pooled := meanUnitVector(vectors, e.embedder.Dimensions())
+	if pooled == nil {
+		return nil, fmt.Errorf("%w: no stored embeddings for %s", ErrVectorIndexRequired, path)
+	}
+
+	// The file's own chunks are likely the nearest hits, so fetch past them
+	hits, err := e.vector.Search(ctx, pooled, k*similarFilesCandidatesPerResult+len(chunks))
+	if err != nil {
+		return nil, fmt.Errorf("vector search: %w", err)
+	}
+
+	hitIDs := make([]string, len(hits))
+	for i, h := range hits {
+		hitIDs[i] = h.ID
+	}
+	hitChunks, err := e.metadata.GetChunks(ctx, hitIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunks: %w", err)
+	}
+	// A shared store holds other projects' chunks too
+	projectFiles, err := e.metadata.GetFilesForReconciliation(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get files of project %s: %w", projectID, err)
+	}
+	pathByID := make(map[string]string, len(hitChunks))
+	for _, c := range hitChunks {
+		f, ok := projectFiles[c.FilePath]
+		if !ok || f.ID != c.FileID {
+			continue
+		}
+		if c.FileID != file.ID && c.FilePath != file.Path {
+			pathByID[c.ID] = c.FilePath
+		}
+	}
+
+	// Group chunk hits by file; hits arrive best first, so the first hit of
+	// each file carries its score
+	byPath := make(map[string]*SimilarFile)
+	var results []*SimilarFile
+	for _, h := range hits {
+		p, ok := pathByID[h.ID]
+		if !ok {
+			continue
+		}
+		if sf, seen := byPath[p]; seen {
+			sf.MatchedChunks++
+			continue
+		}
+		sf := &SimilarFile{FilePath: p, Score: float64(h.Score), MatchedChunks: 1}
+		byPath[p] = sf
+		results = append(results, sf)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].FilePath < results[j].FilePath
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+
+	return results, nil
+}
+
+// meanUnitVector returns the mean of the given vectors after normalizing each
+// to unit length. Vectors of the wrong dimension or zero length are skipped;
+// nil is returned if none remain.
+func meanUnitVector(vectors [][]float32, dims int) []float32 {
+	sum := make([]float64, dims)
+	n := 0
+	for _, v := range vectors {
+		if len(v) != dims {
+			continue
+		}
+		var norm float64
+		for _, x := range v {
+			norm += float64(x) * float64(x)
+		}
+		if norm == 0 {
+			continue
+		}
+		norm = math.Sqrt(norm)
+		for i, x := range v {
+			sum[i] += float64(x) / norm
+		}
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+
+	mean := make([]float32, dims)
+	for i, s := range sum {
+		mean[i] = float32(s / float64(n))
+	}
+	return mean
+}
@@ -0,0 +1,149 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/embed"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// newSimilarFilesTestEngine indexes three files: two about authentication
+// and one unrelated.
+func newSimilarFilesTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	embedder := embed.NewStaticEmbedder768()
+	vector, err := store.NewFlatStore(store.DefaultVectorStoreConfig(embedder.Dimensions()))
+	require.NoError(t, err)
+
+	files := []*store.File{
+		{ID: "file-auth", ProjectID: "proj", Path: "auth.go", Language: "go"},
+		{ID: "file-login", ProjectID: "proj", Path: "login.go", Language: "go"},
+		{ID: "file-matrix", ProjectID: "proj", Path: "matrix.go", Language: "go"},
+	}
+	return newSQLiteTestEngine(t, vector, embedder, files, []*store.Chunk{
+		{ID: "auth-1", FileID: "file-auth", FilePath: "auth.go", Content: "func AuthenticateUser(user string, password string) error { return checkPassword(user, password) }"},
+		{ID: "auth-2", FileID: "file-auth", FilePath: "auth.go", Content: "func ValidateSessionToken(token string) (user string, err error)"},
+		{ID: "login-1", FileID: "file-login", FilePath: "login.go", Content: "func LoginUser(user string, password string) error { return AuthenticateUser(user, password) }"},
+		{ID: "login-2", FileID: "file-login", FilePath: "login.go", Content: "func NewSessionToken(user string) (token string, err error)"},
+		{ID: "matrix-1", FileID: "file-matrix", FilePath: "matrix.go", Content: "func MultiplyMatrix(a [][]float64, b [][]float64) [][]float64 { return transpose(dot(a, b)) }"},
+	})
+}
+
+func TestEngine_SimilarFiles_RanksRelatedFilesFirst(t *testing.T) {
+	// Given: an index with two authentication files and a matrix file
+	engine := newSimilarFilesTestEngine(t)
+
+	// When: asking for files similar to auth.go
+	results, err := engine.SimilarFiles(context.Background(), "proj", "auth.go", 5)
+
+	// Then: login.go ranks above matrix.go and auth.go itself is excluded
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "login.go", results[0].FilePath)
+	assert.Equal(t, "matrix.go", results[1].FilePath)
+	assert.Greater(t, results[0].Score, results[1].Score)
+	assert.Equal(t, 2, results[0].MatchedChunks)
+	for _, r := range results {
+		assert.NotEqual(t, "auth.go", r.FilePath)
+	}
+}
+
+func TestEngine_SimilarFiles_OnlyReturnsFilesOfProject(t *testing.T) {
+	// Given: a shared store that also holds a closely related file of another project
+	engine := newSimilarFilesTestEngine(t)
+	ctx := context.Background()
+	require.NoError(t, engine.metadata.SaveProject(ctx, &store.Project{ID: "other", Name: "other", RootPath: t.TempDir()}))
+	require.NoError(t, engine.metadata.SaveFiles(ctx, []*store.File{
+		{ID: "other-auth", ProjectID: "other", Path: "signin.go", Language: "go"},
+	}))
+	require.NoError(t, engine.Index(ctx, []*store.Chunk{
+		{ID: "other-auth-1", FileID: "other-auth", FilePath: "signin.go", Content: "func AuthenticateUser(user string, password string) error { return checkPassword(user, password) }"},
+	}))
+
+	// When: asking for files similar to auth.go in the first project
+	results, err := engine.SimilarFiles(ctx, "proj", "auth.go", 5)
+
+	// Then: the other project's file is not returned
+	require.NoError(t, err)
+	paths := make([]string, len(results))
+	for i, r := range results {
+		paths[i] = r.FilePath
+	}
+	assert.Equal(t, []string{"login.go", "matrix.go"}, paths)
+}
+
+func TestEngine_SimilarFiles_RespectsK(t *testing.T) {
+	// Given: an index with three files
+	engine := newSimilarFilesTestEngine(t)
+
+	// When: asking for one similar file
+	results, err := engine.SimilarFiles(context.Background(), "proj", "auth.go", 1)
+
+	// Then: only the best match is returned
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "login.go", results[0].FilePath)
+}
+
+func TestEngine_SimilarFiles_UnknownFile(t *testing.T) {
+	// Given: an indexed engine
+	engine := newSimilarFilesTestEngine(t)
+
+	// When: asking about a file that is not indexed
+	_, err := engine.SimilarFiles(context.Background(), "proj", "missing.go", 5)
+
+	// Then: ErrFileNotIndexed is returned
+	assert.ErrorIs(t, err, ErrFileNotIndexed)
+}
+
+func TestEngine_SimilarFiles_RequiresVectorIndex(t *testing.T) {
+	// Given: a BM25-only index where chunks were saved without vectors
+	ctx := context.Background()
+	metadata := &similarFilesMetadata{
+		MockMetadataStore: NewMockMetadataStore(),
+		file:              &store.File{ID: "file-auth", ProjectID: "proj", Path: "auth.go"},
+	}
+	require.NoError(t, metadata.SaveChunks(ctx, []*store.Chunk{
+		{ID: "auth-1", FileID: "file-auth", FilePath: "auth.go", Content: "func Login()"},
+	}))
+	engine := New(&MockBM25Index{}, &MockVectorStore{}, &MockEmbedder{}, metadata, DefaultConfig())
+
+	// When: asking for similar files
+	_, err := engine.SimilarFiles(ctx, "proj", "auth.go", 5)
+
+	// Then: ErrVectorIndexRequired is returned
+	assert.ErrorIs(t, err, ErrVectorIndexRequired)
+}
+
+func TestMeanUnitVector(t *testing.T) {
+	// Given: vectors of different lengths pointing along x and y
+	vectors := [][]float32{{10, 0}, {0, 1}, {0, 0}, {1, 2, 3}}
+
+	// When: pooling them
+	mean := meanUnitVector(vectors, 2)
+
+	// Then: each valid vector counts equally, zero and wrong-dimension ones are skipped
+	require.Len(t, mean, 2)
+	assert.InDelta(t, 0.5, mean[0], 1e-6)
+	assert.InDelta(t, 0.5, mean[1], 1e-6)
+
+	assert.Nil(t, meanUnitVector([][]float32{{0, 0}}, 2))
+	assert.Nil(t, meanUnitVector(nil, 2))
+}
+
+// similarFilesMetadata is a MockMetadataStore that knows one file.
+type similarFilesMetadata struct {
+	*MockMetadataStore
+	file *store.File
+}
+
+func (m *similarFilesMetadata) GetFileByPath(_ context.Context, projectID, path string) (*store.File, error) {
+	if m.file != nil && m.file.ProjectID == projectID && m.file.Path == path {
+		return m.file, nil
+	}
+	return nil, nil
+}
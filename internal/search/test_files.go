@@ -0,0 +1,62 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TestFileMode selects how search results from test files are treated.
+// Test files are recognized by path alone; see IsTestFile.
+type TestFileMode string
+
+const (
+	// TestFilesDemote keeps test files but scales their scores by
+	// TestFilePenalty so implementations rank first (the default).
+	TestFilesDemote TestFileMode = "demote"
+
+	// TestFilesExclude drops results from test files.
+	TestFilesExclude TestFileMode = "exclude"
+
+	// TestFilesOnly keeps only results from test files, e.g. to find how
+	// a function is exercised. Their scores are not penalized.
+	TestFilesOnly TestFileMode = "only"
+)
+
+// ParseTestFileMode parses a test file mode name. Empty selects
+// TestFilesDemote.
+func ParseTestFileMode(value string) (TestFileMode, error) {
+	mode := TestFileMode(strings.ToLower(strings.TrimSpace(value)))
+	switch mode {
+	case "":
+		return TestFilesDemote, nil
+	case TestFilesDemote, TestFilesExclude, TestFilesOnly:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown include_tests mode %q; use one of: demote, exclude, only", value)
+	}
+}
+
+// ApplyTestFileMode demotes, excludes, or keeps only test file results
+// according to mode. Results without a chunk are kept unless mode is
+// TestFilesOnly. Empty and unknown modes demote, like ApplyTestFilePenalty.
+func ApplyTestFileMode(results []*SearchResult, mode TestFileMode) []*SearchResult {
+	switch mode {
+	case TestFilesExclude, TestFilesOnly:
+		wantTests := mode == TestFilesOnly
+		kept := results[:0]
+		for _, r := range results {
+			if r.Chunk == nil {
+				if !wantTests {
+					kept = append(kept, r)
+				}
+				continue
+			}
+			if IsTestFile(r.Chunk.FilePath) == wantTests {
+				kept = append(kept, r)
+			}
+		}
+		return kept
+	default:
+		return ApplyTestFilePenalty(results)
+	}
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestParseTestFileMode(t *testing.T) {
+	for input, want := range map[string]TestFileMode{
+		"": TestFilesDemote, "demote": TestFilesDemote, " Exclude ": TestFilesExclude, "only": TestFilesOnly,
+	} {
+		got, err := ParseTestFileMode(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseTestFileMode("include")
+	assert.ErrorContains(t, err, "unknown include_tests mode")
+	assert.Error(t, SearchOptions{IncludeTests: "include"}.Validate())
+}
+
+func TestApplyTestFileMode(t *testing.T) {
+	newResults := func() []*SearchResult {
+		return []*SearchResult{
+			{Chunk: &store.Chunk{ID: "test", FilePath: "internal/search/engine_test.go"}, Score: 1.0},
+			{Chunk: &store.Chunk{ID: "impl", FilePath: "internal/search/engine.go"}, Score: 0.8},
+			{Chunk: &store.Chunk{ID: "fixture", FilePath: "tests/fixture.go"}, Score: 0.6},
+			{Chunk: nil, Score: 0.4},
+		}
+	}
+	ids := func(results []*SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			if r.Chunk == nil {
+				out = append(out, "<nil>")
+				continue
+			}
+			out = append(out, r.Chunk.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		mode TestFileMode
+		want []string
+	}{
+		{mode: "", want: []string{"impl", "test", "<nil>", "fixture"}},
+		{mode: TestFilesDemote, want: []string{"impl", "test", "<nil>", "fixture"}},
+		{mode: TestFilesExclude, want: []string{"impl", "<nil>"}},
+		{mode: TestFilesOnly, want: []string{"test", "fixture"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			// Given: results mixing test files, an implementation, and no chunk
+
+			// When: applying the test file mode
+			got := ApplyTestFileMode(newResults(), tt.mode)
+
+			// Then: test files are demoted, dropped, or the only ones kept
+			assert.Equal(t, tt.want, ids(got))
+		})
+	}
+}
+
+func TestApplyTestFileMode_OnlyKeepsScores(t *testing.T) {
+	// Given: a test file result
+	results := []*SearchResult{{Chunk: &store.Chunk{FilePath: "foo.spec.ts"}, Score: 0.9}}
+
+	// When: searching only test files
+	got := ApplyTestFileMode(results, TestFilesOnly)
+
+	// Then: its score is not penalized
+	require.Len(t, got, 1)
+	assert.Equal(t, 0.9, got[0].Score)
+}
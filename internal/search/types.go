@@ -6,6 +6,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Aman-CERP/amanmcp/internal/embed"
 	"github.com/Aman-CERP/amanmcp/internal/store"
 )
 
@@ -32,6 +33,16 @@ type SearchOptions struct {
 	// Limit is the maximum number of results to return (default: 10, max: 100).
 	Limit int
 
+	// Offset skips this many ranked results, returning results
+	// Offset..Offset+Limit. Pages are cut from the first EngineConfig.MaxLimit
+	// ranked results; see Engine.Search for consistency across pages.
+	// 0 = first page (default).
+	Offset int
+
+	// Page collects the position of the returned page and an estimate of the
+	// total result count. Callers that do not need it can leave this nil.
+	Page *SearchPage
+
 	// Filter restricts results by content type: "all", "code", "docs".
 	Filter string
 
@@ -49,6 +60,13 @@ type SearchOptions struct {
 	// Empty slice means no scope filtering.
 	Scopes []string
 
+	// ExcludeSymbolPatterns drops results whose primary symbol (the chunk's
+	// first symbol) matches any of these glob patterns, e.g. "String" or
+	// "Get*", to hide boilerplate such as generated accessors. Patterns use
+	// path.Match syntax and are case-sensitive. Results without symbols are
+	// kept. Empty slice means no symbol exclusion.
+	ExcludeSymbolPatterns []string
+
 	// Profile selects a retrieval profile before results are returned.
 	// Empty keeps existing broad active-search behavior while excluding
 	// review-corpus, archive, and raw-evidence material by default.
@@ -73,20 +91,129 @@ type SearchOptions struct {
 	// Callers that do not need diagnostics can leave this nil.
 	RerankerStatus *RerankerStatus
 
+	// RerankThreshold skips reranking when the top fused result is a clear
+	// winner: its score leads the runner-up's by at least this fraction of
+	// its own score (0-1). 0 uses EngineConfig.RerankThreshold.
+	RerankThreshold float64
+
+	// Diagnostics collects per-stage result counts and, when the search
+	// returns nothing, the stage that zeroed it out. Populated regardless of
+	// Explain. Callers that do not need diagnostics can leave this nil.
+	Diagnostics *SearchDiagnostics
+
 	// BM25Only forces keyword-only search, skipping semantic/vector search entirely.
 	// FEAT-DIM1: Useful when embedder is unavailable or for exact keyword matching.
 	BM25Only bool
 
+	// BooleanQuery parses the query as a boolean expression of terms joined
+	// by AND, OR and NOT, with parentheses for grouping (see
+	// store.BooleanQuery for precedence). The expression selects the BM25
+	// candidates, which are then scored and fused as usual; semantic search
+	// and the later ranking stages see only the non-negated terms. Combine
+	// with BM25Only for strict grep-like filtering, since semantic results
+	// are not filtered by the expression.
+	BooleanQuery bool
+
+	// PrefixMatch matches every query word as a prefix of indexed terms, as
+	// if each ended with "*" ("auth" finds authenticate and authorization).
+	// Without it only words written with a trailing "*" are prefixes. Each
+	// prefix expands to at most store.MaxPrefixExpansions terms, the most
+	// frequent first. Ignored with BooleanQuery.
+	PrefixMatch bool
+
 	// AdjacentChunks specifies how many chunks before/after to retrieve for context.
 	// FEAT-QI5: Adjacent chunk retrieval for context continuity.
 	// 0 = disabled (default), 1 = fetch 1 before + 1 after, 2 = fetch 2 each.
 	AdjacentChunks int
 
+	// AdjacentTokenBudget caps the tokens of all adjacent chunks across the
+	// enriched results, counted with embed.TokenizerFor the engine's
+	// embedder. Closer chunks are kept first; enrichment stops at the first
+	// chunk that does not fit. Setting only the budget enables adjacent
+	// retrieval; with AdjacentChunks also set, the stricter limit wins.
+	// 0 = no budget (default).
+	AdjacentTokenBudget int
+
+	// MergeContiguous merges results from the same file whose line ranges
+	// overlap or are adjacent into one result spanning their union, ranked
+	// where the best of them was and keeping its score. Content is joined in
+	// line order and highlights are recomputed for the merged content from
+	// the combined matched terms. Unlike a per-file cap, every matched line
+	// stays covered. Merging happens before Limit is applied.
+	MergeContiguous bool
+
+	// PathsOnly returns one result per file instead of per chunk, for a
+	// quick overview of which files match. Each result's Chunk is a
+	// store.ChunkHeaderGetter header of the file's best chunk (no content),
+	// Score is that chunk's score and MatchCount the number of the file's
+	// chunks among the candidates. Chunk text is never fetched, so reranking,
+	// multi-query decomposition, highlights and content-based boosts are
+	// skipped. SymbolType, ExcludeSymbolPatterns, Mode and Profile need the
+	// full chunks; with any of them set the regular search runs and its
+	// results are grouped by file.
+	PathsOnly bool
+
 	// Explain enables detailed search explanation mode.
 	// FEAT-UNIX3: When true, returns ExplainData with search decision details.
 	Explain bool
+
+	// IncludeBlame populates SearchResult.Blame with the most recent commit
+	// touching each result's line range. Requires an engine configured
+	// WithBlameProvider; results keep an empty Blame otherwise.
+	IncludeBlame bool
+
+	// BlameLimit bounds how many top results are blamed
+	// (0 = DefaultBlameLimit).
+	BlameLimit int
+
+	// IncludeSalientTerms populates SearchResult.SalientTerms with the most
+	// distinctive terms of each result's chunk. Requires a BM25 index that
+	// exposes term statistics (the SQLite backend does).
+	IncludeSalientTerms bool
+
+	// SortBy orders the final results: by score (default), by path and
+	// line, by recency, or by line within files grouped by relevance. It is
+	// applied after filtering and the limit.
+	SortBy SortBy
+
+	// BoostPathMatch lifts results whose file path matches the query, e.g.
+	// the file coordinator.go for the query "coordinator.go" (see
+	// ApplyPathMatchBoost). Off by default.
+	BoostPathMatch bool
+
+	// IncludeTests controls results from test files (see IsTestFile):
+	// demote them below implementations (default), exclude them, or return
+	// only them.
+	IncludeTests TestFileMode
+
+	// VectorEf overrides the HNSW search width for this query. Higher values
+	// raise recall at the cost of latency (roughly linear in ef); use them for
+	// thorough or batch/eval searches and leave interactive searches at 0.
+	// 0 = the vector store's configured EfSearch. Clamped to
+	// [store.MinEfSearch, store.MaxEfSearch]. Ignored by stores that do not
+	// implement store.EfSearcher.
+	VectorEf int
+
+	// CandidateMultiplier sets how many candidates each searcher (BM25 and
+	// vector) returns relative to Limit, before fusion, reranking and
+	// filtering cut the list down to Limit. A wider pool gives the reranker
+	// more to work with at the cost of latency and memory.
+	// 0 = DefaultCandidateMultiplier. Clamped to MaxCandidateMultiplier.
+	CandidateMultiplier int
+
+	// Embedder overrides the engine's embedder for the query vector only,
+	// e.g. to A/B test query embedders without reindexing. It must produce
+	// vectors of the indexed dimension; otherwise the search falls back to
+	// BM25-only and explains why. nil = engine default.
+	Embedder embed.Embedder
 }
 
+// Bounds for SearchOptions.CandidateMultiplier.
+const (
+	DefaultCandidateMultiplier = 2
+	MaxCandidateMultiplier     = 20
+)
+
 type SearchMode string
 
 const (
@@ -116,9 +243,22 @@ type SearchResult struct {
 	// Chunk contains the full chunk data from MetadataStore.
 	Chunk *store.Chunk
 
+	// LineCount is the number of lines the chunk spans and ByteCount the
+	// length of its content, for clients deciding whether to inline a
+	// result or collapse it.
+	LineCount int
+	ByteCount int
+
 	// Score is the combined normalized score (0-1).
 	Score float64
 
+	// RawScore is the fusion score before per-query normalization and
+	// post-fusion boosts. Unlike Score it is comparable across queries that
+	// use the same fusion settings (see RRFFusion). Reranking replaces Score
+	// but leaves RawScore as the fusion score. Results added outside fusion,
+	// such as exact symbol or path matches, have a zero RawScore.
+	RawScore float64
+
 	// BM25Score is the individual BM25 score (normalized).
 	BM25Score float64
 
@@ -153,6 +293,49 @@ type SearchResult struct {
 
 	// SourceMetadata contains F39 source authority/profile/freshness metadata.
 	SourceMetadata SourceMetadata
+
+	// Blame is the last commit touching the result's lines when
+	// opts.IncludeBlame=true. Empty outside a git repository.
+	Blame Blame
+
+	// MatchedSubQueries lists the decomposed sub-queries whose results
+	// contained this chunk when multi-query search ran (FEAT-QI3). Empty for
+	// single-query searches and for candidates added outside fusion.
+	MatchedSubQueries []string
+
+	// Confidence labels the result High, Medium or Low from where its score
+	// falls among the returned results (see ClassifyConfidence).
+	Confidence Confidence
+
+	// MatchCount is the number of ranked chunks from the result's file when
+	// opts.PathsOnly=true. Zero for per-chunk results.
+	MatchCount int
+
+	// PathMatchBoost is the score multiplier opts.BoostPathMatch applied
+	// because the result's path matched the query. Zero when none was.
+	PathMatchBoost float64
+
+	// SalientTerms lists up to MaxSalientTerms of the chunk's terms with the
+	// highest TF-IDF against the BM25 corpus, most distinctive first, when
+	// opts.IncludeSalientTerms=true. Unlike matched terms, they do not
+	// depend on the query.
+	SalientTerms []string
+
+	// NearDuplicates is the number of chunks skipped at index time as
+	// near-duplicates of this one (search.near_duplicate_distance). Zero
+	// when detection is off or the metadata store does not record them.
+	NearDuplicates int
+}
+
+// Section returns the heading breadcrumb of the document section a markdown
+// result comes from, outermost heading first, e.g. "Installation › macOS ›
+// Homebrew". Code blocks of a markdown section report the section too.
+// Empty for other results and for documents without headings.
+func (r *SearchResult) Section() string {
+	if r == nil || r.Chunk == nil || r.Chunk.Language != "markdown" && r.Chunk.Metadata["type"] != "code_block" {
+		return ""
+	}
+	return r.Chunk.Context
 }
 
 // AdjacentContext contains surrounding chunks for context continuity.
@@ -184,6 +367,9 @@ type EngineStats struct {
 
 	// VectorCount is the number of vectors in the store.
 	VectorCount int
+
+	// Concurrency is the current search load.
+	Concurrency SearchConcurrency
 }
 
 const (
@@ -218,6 +404,7 @@ const (
 	RerankerSkipPolicyAutoNegative     = "policy_auto_negative"
 	RerankerSkipPolicyAutoUnknownClass = "policy_auto_unknown_class"
 	RerankerSkipPolicyAutoSemantic     = "policy_auto_semantic"
+	RerankerSkipClearWinner            = "clear_winner"
 )
 
 // RerankerPolicy controls whether the optional cross-encoder reranker runs.
@@ -239,6 +426,64 @@ type RerankerStatus struct {
 	LatencyMS      int64
 }
 
+// NoResultsReason identifies the search stage that produced zero results.
+type NoResultsReason string
+
+const (
+	// NoResultsEmptyQuery means the query was empty after trimming.
+	NoResultsEmptyQuery NoResultsReason = "empty_query"
+
+	// NoResultsDimensionMismatch means semantic search was disabled because the
+	// embedder no longer matches the index, and BM25 alone found nothing.
+	NoResultsDimensionMismatch NoResultsReason = "dimension_mismatch"
+
+	// NoResultsSemanticUnavailable means vector search failed (e.g. embedder
+	// offline) and BM25 alone found nothing.
+	NoResultsSemanticUnavailable NoResultsReason = "semantic_unavailable"
+
+	// NoResultsFiltered means candidates were found but Filter, Language,
+	// SymbolType, Scopes, ExcludeSymbolPatterns, Profile or Mode removed all
+	// of them.
+	NoResultsFiltered NoResultsReason = "filtered"
+
+	// NoResultsNoMatches means neither retriever matched the query.
+	NoResultsNoMatches NoResultsReason = "no_matches"
+)
+
+// SearchDiagnostics records how many results survived each search stage.
+type SearchDiagnostics struct {
+	// NoResultsReason is empty when results were returned.
+	NoResultsReason NoResultsReason
+
+	BM25ResultCount   int
+	VectorResultCount int
+
+	// CandidateCount is the number of enriched candidates before filtering.
+	CandidateCount int
+
+	// ResultCount is the number of results returned.
+	ResultCount int
+
+	DimensionMismatch   bool
+	SemanticUnavailable bool
+}
+
+// reason derives NoResultsReason from the stage counts.
+func (d SearchDiagnostics) reason() NoResultsReason {
+	switch {
+	case d.ResultCount > 0:
+		return ""
+	case d.CandidateCount > 0:
+		return NoResultsFiltered
+	case d.DimensionMismatch:
+		return NoResultsDimensionMismatch
+	case d.SemanticUnavailable:
+		return NoResultsSemanticUnavailable
+	default:
+		return NoResultsNoMatches
+	}
+}
+
 // EngineConfig configures the search engine.
 type EngineConfig struct {
 	// DefaultLimit is the default number of results (default: 10).
@@ -247,12 +492,36 @@ type EngineConfig struct {
 	// MaxLimit is the maximum allowed results (default: 100).
 	MaxLimit int
 
+	// MaxCandidates caps how many candidates each retriever (BM25 and
+	// vector) fetches for a query, whatever its Limit and
+	// SearchOptions.CandidateMultiplier, bounding the memory and reranking
+	// work a single query can cause (default: DefaultMaxCandidates).
+	MaxCandidates int
+
+	// StrictLimits makes Search fail with ErrLimitExceeded when a query's
+	// Limit exceeds MaxLimit or Limit times its candidate multiplier exceeds
+	// MaxCandidates, instead of clamping them.
+	StrictLimits bool
+
+	// StrictOptions makes Search reject invalid SearchOptions with the
+	// SearchOptions.Validate error instead of coercing them to defaults.
+	// Off by default for compatibility.
+	StrictOptions bool
+
 	// DefaultWeights are the default BM25/semantic weights.
 	DefaultWeights Weights
 
 	// RRFConstant is the RRF fusion constant k (default: 60).
 	RRFConstant int
 
+	// FusionStrategy selects how BM25 and vector results are combined
+	// ("rrf" or "weighted"). Empty selects RRF.
+	FusionStrategy FusionStrategyName
+
+	// FusionNormalization selects per-list score normalization for the
+	// weighted strategy ("minmax" or "zscore"). Ignored by RRF.
+	FusionNormalization ScoreNormalization
+
 	// SearchTimeout is the maximum search duration (default: 5s).
 	SearchTimeout time.Duration
 
@@ -264,19 +533,106 @@ type EngineConfig struct {
 
 	// RerankerPolicy controls when the optional reranker runs.
 	RerankerPolicy RerankerPolicy
+
+	// RerankThreshold is the default SearchOptions.RerankThreshold. Under
+	// RerankerPolicyAlways the reranker runs regardless. 0 = never skip for
+	// a clear winner (default).
+	RerankThreshold float64
+
+	// EmbedRetry controls retries of transient embedder failures during
+	// indexing and query embedding. The zero value disables retries.
+	EmbedRetry EmbedRetryPolicy
+
+	// MaxChunkBytes caps the chunk text sent to the embedder by Index and
+	// re-embedding; longer texts are truncated, preferably at a line
+	// boundary (see embed.TruncateInput). Stored content, BM25 and search
+	// output keep the full text. 0 uses the embedder's advertised limit
+	// (embed.InputLimiter), or no limit when it has none.
+	MaxChunkBytes int
+
+	// EmbedRateLimit throttles embedder calls for API-based backends.
+	// The zero value disables rate limiting.
+	EmbedRateLimit EmbedRateLimit
+
+	// MaxHighlights caps the highlight ranges returned per result across all
+	// matched terms (default: DefaultMaxHighlights). Zero or negative selects
+	// the default.
+	MaxHighlights int
+
+	// Confidence sets the thresholds for SearchResult.Confidence. The zero
+	// value selects DefaultConfidenceThresholds.
+	Confidence ConfidenceThresholds
+
+	// QueryCacheSize is the number of searches kept in the query result
+	// cache. Zero disables caching.
+	QueryCacheSize int
+
+	// QueryCacheTTL expires cached searches after this long, even if the
+	// index has not changed. Zero keeps them until the index changes or
+	// they are evicted.
+	QueryCacheTTL time.Duration
+
+	// MaxConcurrentSearches bounds how many searches run at once, protecting
+	// the embedder and vector store from request bursts. Further searches
+	// wait for a free slot until their context is done. Zero or negative
+	// means unlimited.
+	MaxConcurrentSearches int
+
+	// QueryInstruction is prepended to queries before they are embedded.
+	// Instruction-tuned embedders expect a task prefix on queries but not on
+	// documents; use QueryInstructionForModel to pick the one for the
+	// embedder's model. Empty embeds queries as is. Queries embedded by a
+	// per-query SearchOptions.Embedder get that embedder's instruction
+	// instead.
+	QueryInstruction string
+
+	// AutoReindexOnDimensionChange re-embeds the whole index in the
+	// background when Index finds that the embedder's dimension differs from
+	// the indexed one, instead of failing to add vectors. Searches stay
+	// BM25-only until it finishes. Off by default.
+	AutoReindexOnDimensionChange bool
+
+	// ReembedOnModelChange re-embeds, in the background, the chunks whose
+	// stored embedding comes from another model when the embedder's model
+	// differs from the indexed one at the same dimension (see
+	// ReembedIfModelChanged). Vectors are replaced in place, so search
+	// keeps working, but until it finishes query vectors from the new model
+	// are also scored against the old model's vectors, which ranks those
+	// chunks poorly; BM25 still finds them. Off by default: the index keeps
+	// the old model's vectors.
+	ReembedOnModelChange bool
+
+	// PipelineIndexing makes Index embed large inputs in batches, embedding
+	// the next batch while the previous one is written to the indexes and
+	// metadata store, instead of embedding everything before writing. An
+	// embed or write error stops indexing, but batches written before it
+	// stay indexed. Off by default.
+	PipelineIndexing bool
 }
 
+// DefaultMaxCandidates is the default EngineConfig.MaxCandidates: the
+// largest pool the default MaxLimit and MaxCandidateMultiplier can ask for.
+const DefaultMaxCandidates = 2000
+
+// DefaultMaxHighlights is the default cap on highlight ranges per result.
+const DefaultMaxHighlights = 50
+
 // DefaultConfig returns sensible default configuration.
 func DefaultConfig() EngineConfig {
 	return EngineConfig{
 		DefaultLimit:   10,
 		MaxLimit:       100,
+		MaxCandidates:  DefaultMaxCandidates,
 		DefaultWeights: DefaultWeights(),
 		RRFConstant:    60,
+		FusionStrategy: FusionStrategyRRF,
 		SearchTimeout:  5 * time.Second,
 		MetadataRules:  DefaultMetadataRules(),
 		ProfileRules:   DefaultProfileRules(),
 		RerankerPolicy: RerankerPolicyAuto,
+		EmbedRetry:     DefaultEmbedRetryPolicy(),
+		MaxHighlights:  DefaultMaxHighlights,
+		Confidence:     DefaultConfidenceThresholds(),
 	}
 }
 
@@ -346,4 +702,20 @@ type ExplainData struct {
 
 	// SubQueries contains the decomposed sub-queries (if MultiQueryDecomposed is true).
 	SubQueries []string
+
+	// SubQueryResultCounts maps each sub-query to how many of the returned
+	// results it matched (see SearchResult.MatchedSubQueries). A sub-query
+	// with a zero count contributed nothing to the final results.
+	SubQueryResultCounts map[string]int
+
+	// QueryEmbedder is the model of SearchOptions.Embedder when the query
+	// embedder was overridden.
+	QueryEmbedder string
+
+	// Note explains a degraded search, e.g. why vector search was skipped.
+	Note string
+
+	// Reranker records whether the reranker ran and, if not, why (e.g.
+	// RerankerSkipClearWinner). Empty for multi-query searches.
+	Reranker RerankerStatus
 }
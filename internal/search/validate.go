@@ -0,0 +1,108 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+)
+
+// ErrInvalidSearchOptions is returned by SearchOptions.Validate, and by
+// Engine.Search when EngineConfig.StrictOptions is set.
+var ErrInvalidSearchOptions = errors.New("invalid search options")
+
+// ErrLimitExceeded is returned by Engine.Search when EngineConfig.StrictLimits
+// is set and a query asks for more results or candidates than the engine
+// allows.
+var ErrLimitExceeded = errors.New("search limit exceeded")
+
+// Validate reports options that the engine would otherwise silently coerce
+// or ignore: negative counts, an unknown Filter, Profile or Mode, malformed
+// ExcludeSymbolPatterns, and weights outside [0, 1] or summing to zero. Zero
+// values are valid and select the engine defaults. Limits above
+// EngineConfig.MaxLimit are not reported since they depend on the engine;
+// Search clamps them.
+//
+// All problems are reported in one error wrapping ErrInvalidSearchOptions.
+func (o SearchOptions) Validate() error {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if o.Limit < 0 {
+		add("limit must not be negative, got %d", o.Limit)
+	}
+	if o.Offset < 0 {
+		add("offset must not be negative, got %d", o.Offset)
+	}
+
+	switch o.Filter {
+	case "", "all", "code", "docs":
+	default:
+		add("unknown filter %q; use one of: all, code, docs", o.Filter)
+	}
+
+	if o.Weights != nil {
+		if !validWeight(o.Weights.BM25) {
+			add("bm25 weight must be within [0, 1], got %v", o.Weights.BM25)
+		}
+		if !validWeight(o.Weights.Semantic) {
+			add("semantic weight must be within [0, 1], got %v", o.Weights.Semantic)
+		}
+		if o.Weights.BM25 == 0 && o.Weights.Semantic == 0 {
+			add("weights must not both be zero")
+		}
+	}
+
+	if _, err := ParseProfile(string(o.Profile)); err != nil {
+		add("%v", err)
+	}
+
+	switch o.Mode {
+	case "", SearchModeDecisions, SearchModeDecisionHistory:
+	default:
+		add("unknown mode %q; use one of: %s, %s", o.Mode, SearchModeDecisions, SearchModeDecisionHistory)
+	}
+
+	if _, err := ParseSortBy(string(o.SortBy)); err != nil {
+		add("%v", err)
+	}
+	if _, err := ParseTestFileMode(string(o.IncludeTests)); err != nil {
+		add("%v", err)
+	}
+
+	if o.AdjacentChunks < 0 {
+		add("adjacent chunks must not be negative, got %d", o.AdjacentChunks)
+	}
+	if o.AdjacentTokenBudget < 0 {
+		add("adjacent token budget must not be negative, got %d", o.AdjacentTokenBudget)
+	}
+	if o.BlameLimit < 0 {
+		add("blame limit must not be negative, got %d", o.BlameLimit)
+	}
+	if o.VectorEf < 0 {
+		add("vector ef must not be negative, got %d", o.VectorEf)
+	}
+	if o.RerankThreshold < 0 || o.RerankThreshold > 1 {
+		add("rerank threshold must be within [0, 1], got %v", o.RerankThreshold)
+	}
+	if o.CandidateMultiplier < 0 {
+		add("candidate multiplier must not be negative, got %d", o.CandidateMultiplier)
+	}
+	for _, pattern := range o.ExcludeSymbolPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			add("invalid exclude symbol pattern %q: %v", pattern, err)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidSearchOptions, strings.Join(problems, "; "))
+}
+
+func validWeight(w float64) bool {
+	return !math.IsNaN(w) && w >= 0 && w <= 1
+}
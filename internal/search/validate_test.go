@@ -0,0 +1,131 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+func TestSearchOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    SearchOptions
+		wantErr string
+	}{
+		{name: "zero value", opts: SearchOptions{}},
+		{name: "typical options", opts: SearchOptions{
+			Limit: 20, Filter: "code", Weights: &Weights{BM25: 0.65, Semantic: 0.35},
+			Profile: ProfileCode, Mode: SearchModeDecisions, AdjacentChunks: 1,
+		}},
+		{name: "single source weight", opts: SearchOptions{Weights: &Weights{BM25: 1}}},
+		{name: "negative limit", opts: SearchOptions{Limit: -1}, wantErr: "limit must not be negative"},
+		{name: "unknown filter", opts: SearchOptions{Filter: "tests"}, wantErr: `unknown filter "tests"`},
+		{name: "filter is case sensitive", opts: SearchOptions{Filter: "Code"}, wantErr: `unknown filter "Code"`},
+		{name: "negative weight", opts: SearchOptions{Weights: &Weights{BM25: -0.5, Semantic: 1}}, wantErr: "bm25 weight must be within [0, 1]"},
+		{name: "weight above one", opts: SearchOptions{Weights: &Weights{BM25: 0.5, Semantic: 2}}, wantErr: "semantic weight must be within [0, 1]"},
+		{name: "NaN weight", opts: SearchOptions{Weights: &Weights{BM25: math.NaN(), Semantic: 1}}, wantErr: "bm25 weight"},
+		{name: "zero weights", opts: SearchOptions{Weights: &Weights{}}, wantErr: "weights must not both be zero"},
+		{name: "unknown profile", opts: SearchOptions{Profile: "docs"}, wantErr: `unknown search profile "docs"`},
+		{name: "unknown mode", opts: SearchOptions{Mode: "latest"}, wantErr: `unknown mode "latest"`},
+		{name: "negative adjacent chunks", opts: SearchOptions{AdjacentChunks: -1}, wantErr: "adjacent chunks"},
+		{name: "negative vector ef", opts: SearchOptions{VectorEf: -5}, wantErr: "vector ef"},
+		{name: "negative candidate multiplier", opts: SearchOptions{CandidateMultiplier: -1}, wantErr: "candidate multiplier"},
+		{name: "exclude symbol patterns", opts: SearchOptions{ExcludeSymbolPatterns: []string{"String", "Get*", "set[A-Z]*"}}},
+		{name: "malformed exclude symbol pattern", opts: SearchOptions{ExcludeSymbolPatterns: []string{"Get*", "[a-"}}, wantErr: `invalid exclude symbol pattern "[a-"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidSearchOptions)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSearchOptions_Validate_ReportsAllProblems(t *testing.T) {
+	// Given: options with several problems
+	opts := SearchOptions{Limit: -3, Filter: "tests", BlameLimit: -1}
+
+	// When: validating
+	err := opts.Validate()
+
+	// Then: every problem is listed in one error
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "limit must not be negative, got -3")
+	assert.Contains(t, err.Error(), `unknown filter "tests"`)
+	assert.Contains(t, err.Error(), "blame limit must not be negative")
+}
+
+func TestEngine_Search_StrictOptions(t *testing.T) {
+	// Given: engines with lenient (default) and strict option handling
+	_, bm25, vector, embedder, metadata := setupTestEngine(t)
+	lenient := New(bm25, vector, embedder, metadata, DefaultConfig())
+	cfg := DefaultConfig()
+	cfg.StrictOptions = true
+	strict := New(bm25, vector, embedder, metadata, cfg)
+	opts := SearchOptions{Limit: -1, BM25Only: true}
+
+	// When: searching strictly with a negative limit
+	_, err := strict.Search(context.Background(), "query", opts)
+
+	// Then: the validation error is returned before any search runs
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidSearchOptions)
+	assert.Zero(t, bm25.searchCalled.Load())
+
+	// When: searching leniently with the same options
+	_, err = lenient.Search(context.Background(), "query", opts)
+
+	// Then: the limit is coerced to the default and the search runs
+	assert.NoError(t, err)
+	assert.Positive(t, bm25.searchCalled.Load())
+}
+
+func TestEngine_Search_MaxCandidates(t *testing.T) {
+	// Given: an engine capping candidates at 150
+	_, bm25, vector, embedder, metadata := setupTestEngine(t)
+	var gotLimit atomic.Int32
+	bm25.SearchFn = func(_ context.Context, _ string, limit int) ([]*store.BM25Result, error) {
+		gotLimit.Store(int32(limit))
+		return nil, nil
+	}
+	cfg := DefaultConfig()
+	cfg.MaxCandidates = 150
+	engine := New(bm25, vector, embedder, metadata, cfg)
+	opts := SearchOptions{Limit: 100, CandidateMultiplier: 20, BM25Only: true}
+
+	// When: a query asks for 100 results with a 20x candidate pool
+	_, err := engine.Search(context.Background(), "query", opts)
+
+	// Then: BM25 fetches no more than the cap
+	require.NoError(t, err)
+	assert.Equal(t, int32(150), gotLimit.Load())
+
+	// When: the same query runs with strict limits
+	cfg.StrictLimits = true
+	strict := New(bm25, vector, embedder, metadata, cfg)
+	bm25.searchCalled.Store(0)
+	_, err = strict.Search(context.Background(), "query", opts)
+
+	// Then: it is rejected before any search runs
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Contains(t, err.Error(), "needs 2000 candidates, above the maximum of 150")
+	assert.Zero(t, bm25.searchCalled.Load())
+
+	// And: a limit above MaxLimit is rejected too
+	_, err = strict.Search(context.Background(), "query", SearchOptions{Limit: 500, BM25Only: true})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
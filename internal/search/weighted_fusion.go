@@ -0,0 +1,120 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// ScoreNormalization selects how WeightedScoreFusion rescales each result list
+// before combining them.
+type ScoreNormalization string
+
+const (
+	// ScoreNormalizationMinMax maps each list to [0, 1] via (s - min) / (max - min).
+	ScoreNormalizationMinMax ScoreNormalization = "minmax"
+
+	// ScoreNormalizationZScore standardizes each list via (s - mean) / stddev,
+	// then shifts so the lowest score in the list is 0.
+	ScoreNormalizationZScore ScoreNormalization = "zscore"
+)
+
+// WeightedScoreFusion combines BM25 and vector results by normalizing each
+// list's raw scores and taking a weighted sum:
+//
+//	score(d) = weight_bm25 * norm_bm25(d) + weight_semantic * norm_vec(d)
+//
+// Documents missing from a list contribute 0 for that source. Unlike RRF, the
+// magnitude of score gaps is preserved, so a clearly dominant match keeps its
+// lead. Final scores are scaled so the best result is 1.0; RawScore keeps
+// score(d) before that scaling.
+type WeightedScoreFusion struct {
+	Normalization ScoreNormalization
+}
+
+// NewWeightedScoreFusion creates a weighted score fusion with the given
+// normalization. Empty normalization defaults to min-max.
+func NewWeightedScoreFusion(normalization ScoreNormalization) (*WeightedScoreFusion, error) {
+	switch ScoreNormalization(strings.ToLower(strings.TrimSpace(string(normalization)))) {
+	case "", ScoreNormalizationMinMax:
+		return &WeightedScoreFusion{Normalization: ScoreNormalizationMinMax}, nil
+	case ScoreNormalizationZScore:
+		return &WeightedScoreFusion{Normalization: ScoreNormalizationZScore}, nil
+	default:
+		return nil, fmt.Errorf("unknown score normalization %q (valid options: minmax, zscore)", normalization)
+	}
+}
+
+// Fuse combines BM25 and vector results using weighted normalized scores.
+// Results are sorted with the same tie-breaking as RRFFusion.
+func (f *WeightedScoreFusion) Fuse(
+	bm25 []*store.BM25Result,
+	vec []*store.VectorResult,
+	weights Weights,
+) []*FusedResult {
+	// Return empty slice, not nil, for consistent API behavior (DEBT-012)
+	if len(bm25) == 0 && len(vec) == 0 {
+		return []*FusedResult{}
+	}
+
+	bm25Raw := make([]float64, len(bm25))
+	for i, r := range bm25 {
+		bm25Raw[i] = r.Score
+	}
+	vecRaw := make([]float64, len(vec))
+	for i, r := range vec {
+		vecRaw[i] = float64(r.Score)
+	}
+	zscore := f.Normalization == ScoreNormalizationZScore
+	bm25Norm := store.NormalizeScores(bm25Raw, zscore)
+	vecNorm := store.NormalizeScores(vecRaw, zscore)
+
+	scores := make(map[string]*FusedResult, len(bm25)+len(vec))
+	get := func(id string) *FusedResult {
+		if r, ok := scores[id]; ok {
+			return r
+		}
+		r := &FusedResult{ChunkID: id}
+		scores[id] = r
+		return r
+	}
+
+	for rank, r := range bm25 {
+		result := get(r.DocID)
+		result.BM25Score = r.Score
+		result.BM25Rank = rank + 1
+		result.MatchedTerms = r.MatchedTerms
+		result.RRFScore += weights.BM25 * bm25Norm[rank]
+	}
+	for rank, r := range vec {
+		result := get(r.ID)
+		result.VecScore = float64(r.Score)
+		result.VecRank = rank + 1
+		result.RRFScore += weights.Semantic * vecNorm[rank]
+		if result.BM25Rank > 0 {
+			result.InBothLists = true
+		}
+	}
+
+	results := make([]*FusedResult, 0, len(scores))
+	for _, r := range scores {
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return compareFusedResults(results[i], results[j])
+	})
+
+	// Scale so the best result is 1.0 (matches RRFFusion output range)
+	for _, r := range results {
+		r.RawScore = r.RRFScore
+	}
+	if maxScore := results[0].RRFScore; maxScore > 0 {
+		for _, r := range results {
+			r.RRFScore /= maxScore
+		}
+	}
+
+	return results
+}
@@ -139,6 +139,7 @@ func CopyIndexFiles(srcDir, dstDir string) error {
 		"metadata.db",
 		"vectors.hnsw",
 		"vectors.hnsw.meta",
+		"vectors.hnsw.wal",
 	}
 
 	// Copy individual files
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidBooleanQuery is returned by ParseBooleanQuery for malformed queries.
+var ErrInvalidBooleanQuery = errors.New("invalid boolean query")
+
+// BooleanQuery is a parsed boolean BM25 query such as
+// "error AND handler NOT test" or "(auth OR login) AND NOT mock".
+//
+// Operators are the uppercase words AND, OR and NOT; lowercase "and", "or"
+// and "not" are ordinary terms. Precedence from tightest to loosest is
+// NOT, AND, OR, and parentheses group. Adjacent terms without an operator
+// are ANDed, so "handler NOT test" means "handler AND NOT test".
+//
+// NOT subtracts from the other operands of its AND group, so every group
+// needs at least one operand that is not negated: "NOT test" and
+// "a OR NOT b" are rejected.
+//
+// Each term is tokenized like indexed content, so a term that splits into
+// several tokens (errorHandler, error_handler) requires all of them.
+type BooleanQuery struct {
+	root boolNode
+}
+
+// boolNode is one node of a parsed boolean query.
+type boolNode interface {
+	String() string
+}
+
+type boolTerm struct {
+	text string
+}
+
+type boolAnd struct {
+	operands []boolNode
+}
+
+type boolOr struct {
+	operands []boolNode
+}
+
+type boolNot struct {
+	operand boolNode
+}
+
+func (n *boolTerm) String() string { return n.text }
+
+func (n *boolAnd) String() string { return joinBoolNodes(n.operands, " AND ") }
+
+func (n *boolOr) String() string { return joinBoolNodes(n.operands, " OR ") }
+
+func (n *boolNot) String() string {
+	if _, ok := n.operand.(*boolTerm); ok {
+		return "NOT " + n.operand.String()
+	}
+	return "NOT (" + n.operand.String() + ")"
+}
+
+func joinBoolNodes(nodes []boolNode, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, n := range nodes {
+		parts[i] = n.String()
+		if _, ok := n.(*boolTerm); !ok {
+			if _, ok := n.(*boolNot); !ok {
+				parts[i] = "(" + parts[i] + ")"
+			}
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// String returns the query with every operator and grouping made explicit.
+func (q *BooleanQuery) String() string {
+	return q.root.String()
+}
+
+// Terms returns the terms the query searches for, in query order, leaving out
+// negated ones. They suit stages that do not understand operators, such as
+// semantic search.
+func (q *BooleanQuery) Terms() []string {
+	var terms []string
+	var walk func(boolNode)
+	walk = func(n boolNode) {
+		switch n := n.(type) {
+		case *boolTerm:
+			terms = append(terms, n.text)
+		case *boolAnd:
+			for _, o := range n.operands {
+				walk(o)
+			}
+		case *boolOr:
+			for _, o := range n.operands {
+				walk(o)
+			}
+		}
+	}
+	walk(q.root)
+	return terms
+}
+
+// ParseBooleanQuery parses a query using AND, OR, NOT and parentheses.
+// Errors wrap ErrInvalidBooleanQuery.
+func ParseBooleanQuery(query string) (*BooleanQuery, error) {
+	p := &boolParser{tokens: lexBooleanQuery(query)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty query", ErrInvalidBooleanQuery)
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidBooleanQuery, p.tokens[p.pos])
+	}
+	return &BooleanQuery{root: root}, nil
+}
+
+// lexBooleanQuery splits a query on whitespace, with parentheses as tokens
+// of their own.
+func lexBooleanQuery(query string) []string {
+	query = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(query)
+	return strings.Fields(query)
+}
+
+// boolParser is a recursive descent parser over lexed tokens.
+type boolParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *boolParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *boolParser) parseOr() (boolNode, error) {
+	var operands []boolNode
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		if p.peek() != "OR" {
+			break
+		}
+		p.pos++
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &boolOr{operands: operands}, nil
+}
+
+// parseAnd parses: unary (["AND"] unary)*
+func (p *boolParser) parseAnd() (boolNode, error) {
+	var operands []boolNode
+	positive := false
+	for {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if _, negated := operand.(*boolNot); !negated {
+			positive = true
+		}
+		operands = append(operands, operand)
+
+		next := p.peek()
+		if next == "AND" {
+			p.pos++
+			continue
+		}
+		if next == "" || next == "OR" || next == ")" {
+			break
+		}
+	}
+	if !positive {
+		return nil, fmt.Errorf("%w: NOT needs a term to subtract from in %q",
+			ErrInvalidBooleanQuery, joinBoolNodes(operands, " AND "))
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &boolAnd{operands: operands}, nil
+}
+
+// parseUnary parses: "NOT" primary | primary
+func (p *boolParser) parseUnary() (boolNode, error) {
+	if p.peek() != "NOT" {
+		return p.parsePrimary()
+	}
+	p.pos++
+	if p.peek() == "NOT" {
+		return nil, fmt.Errorf("%w: NOT cannot be repeated", ErrInvalidBooleanQuery)
+	}
+	operand, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return &boolNot{operand: operand}, nil
+}
+
+// parsePrimary parses: "(" or ")" | term
+func (p *boolParser) parsePrimary() (boolNode, error) {
+	tok := p.peek()
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("%w: query ends after an operator", ErrInvalidBooleanQuery)
+	case "AND", "OR", "NOT", ")":
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidBooleanQuery, tok)
+	case "(":
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidBooleanQuery)
+		}
+		p.pos++
+		return node, nil
+	}
+	p.pos++
+	return &boolTerm{text: tok}, nil
+}
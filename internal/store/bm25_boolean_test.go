@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBooleanQuery(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantTree  string
+		wantTerms []string
+	}{
+		{query: "error", wantTree: "error", wantTerms: []string{"error"}},
+		{query: "error handler", wantTree: "error AND handler", wantTerms: []string{"error", "handler"}},
+		{query: "error AND handler NOT test", wantTree: "error AND handler AND NOT test", wantTerms: []string{"error", "handler"}},
+		{query: "a OR b AND c", wantTree: "a OR (b AND c)", wantTerms: []string{"a", "b", "c"}},
+		{query: "(a OR b) AND c", wantTree: "(a OR b) AND c", wantTerms: []string{"a", "b", "c"}},
+		{query: "a NOT (b OR c)", wantTree: "a AND NOT (b OR c)", wantTerms: []string{"a"}},
+		{query: "(auth)OR(login)", wantTree: "auth OR login", wantTerms: []string{"auth", "login"}},
+		{query: "error and handler", wantTree: "error AND and AND handler", wantTerms: []string{"error", "and", "handler"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			// When: parsing the query
+			q, err := ParseBooleanQuery(tt.query)
+
+			// Then: precedence and implicit AND are applied
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTree, q.String())
+			assert.Equal(t, tt.wantTerms, q.Terms())
+		})
+	}
+}
+
+func TestParseBooleanQuery_Invalid(t *testing.T) {
+	queries := []string{
+		"",
+		"NOT test",
+		"a OR NOT b",
+		"(NOT b) AND a",
+		"a AND",
+		"OR a",
+		"a NOT NOT b",
+		"(a OR b",
+		"a OR b)",
+		"()",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			// When: parsing a malformed query
+			_, err := ParseBooleanQuery(query)
+
+			// Then: it is rejected
+			assert.ErrorIs(t, err, ErrInvalidBooleanQuery)
+		})
+	}
+}
+
+func TestSQLiteBM25Index_SearchBoolean(t *testing.T) {
+	// Given: an index of handler, test and unrelated documents
+	idx, err := NewSQLiteBM25Index("", DefaultBM25Config())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	require.NoError(t, idx.Index(context.Background(), []*Document{
+		{ID: "handler", Content: "func handleError(w http.ResponseWriter) { errorHandler.Write(w) }"},
+		{ID: "handler_test", Content: "func TestErrorHandler(t *testing.T) { errorHandler.Write(w) }"},
+		{ID: "logger", Content: "func logError(msg string) { logger.Error(msg) }"},
+		{ID: "session", Content: "func newSession(user string) *Session { return login(user) }"},
+	}))
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "error AND handler", want: []string{"handler", "handler_test"}},
+		{query: "error AND handler NOT test", want: []string{"handler"}},
+		{query: "errorHandler NOT test", want: []string{"handler"}},
+		{query: "handler OR logger", want: []string{"handler", "handler_test", "logger"}},
+		{query: "(handler OR session) NOT test", want: []string{"handler", "session"}},
+		{query: "error NOT (handler OR logger)", want: nil},
+		{query: "session NOT func", want: []string{"session"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseBooleanQuery(tt.query)
+			require.NoError(t, err)
+
+			// When: evaluating the boolean query
+			results, err := idx.SearchBoolean(context.Background(), q, 10)
+
+			// Then: exactly the matching documents are returned, with BM25 scores
+			require.NoError(t, err)
+			var ids []string
+			for _, r := range results {
+				ids = append(ids, r.DocID)
+				assert.Greater(t, r.Score, 0.0)
+			}
+			assert.ElementsMatch(t, tt.want, ids)
+		})
+	}
+}
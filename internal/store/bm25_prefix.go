@@ -0,0 +1,61 @@
+package store
+
+import "strings"
+
+// PrefixMarker marks a query word as a prefix, as in "authent*".
+const PrefixMarker = "*"
+
+// MaxPrefixExpansions bounds how many indexed terms a single prefix expands
+// to. The terms found in the most documents are kept.
+const MaxPrefixExpansions = 50
+
+// HasPrefixMarker reports whether any word of query ends with PrefixMarker.
+func HasPrefixMarker(query string) bool {
+	for _, word := range strings.Fields(query) {
+		if strings.HasSuffix(word, PrefixMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixWord is one whitespace-separated word of a prefix query, split into
+// code tokens. When prefix is set the last token is matched as a prefix, since
+// that is the part still being typed ("getUserBy*" matches get, user and
+// by-prefixed terms such as byid).
+type prefixWord struct {
+	tokens []string
+	prefix bool
+}
+
+// splitPrefixQuery splits query into words, marking those ending with
+// PrefixMarker (or every word, with allPrefixes) as prefixes.
+func splitPrefixQuery(query string, allPrefixes bool) []prefixWord {
+	var words []prefixWord
+	for _, field := range strings.Fields(query) {
+		trimmed := strings.TrimRight(field, PrefixMarker)
+		tokens := splitCodeTerms(trimmed)
+		if len(tokens) == 0 {
+			continue
+		}
+		words = append(words, prefixWord{
+			tokens: tokens,
+			prefix: allPrefixes || trimmed != field,
+		})
+	}
+	return words
+}
+
+// prefixUpperBound returns the smallest string greater than every string
+// starting with prefix, for range scans over a sorted term list. ok is false
+// when no such bound exists (prefix is all 0xff bytes).
+func prefixUpperBound(prefix string) (bound string, ok bool) {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return string(b[:i+1]), true
+		}
+	}
+	return "", false
+}
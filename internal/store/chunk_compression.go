@@ -0,0 +1,110 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// minCompressSize is the smallest combined chunk text size worth compressing.
+// Below this the gzip header/trailer (~18 bytes) eats most of the savings.
+const minCompressSize = 256
+
+var (
+	gzipWriterPool = sync.Pool{
+		New: func() any { return gzip.NewWriter(nil) },
+	}
+	gzipReaderPool sync.Pool
+)
+
+// compressChunkText gzips a chunk text column. Empty text is stored as-is so
+// NULL/empty semantics are unchanged.
+func compressChunkText(text string) (any, error) {
+	if text == "" {
+		return text, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(zw)
+	zw.Reset(&buf)
+
+	if _, err := io.WriteString(zw, text); err != nil {
+		return nil, fmt.Errorf("gzip write: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("gzip close: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressChunkText reverses compressChunkText.
+func decompressChunkText(data string) (string, error) {
+	if data == "" {
+		return data, nil
+	}
+
+	src := bytes.NewReader([]byte(data))
+	var zr *gzip.Reader
+	if pooled, ok := gzipReaderPool.Get().(*gzip.Reader); ok {
+		zr = pooled
+		if err := zr.Reset(src); err != nil {
+			return "", fmt.Errorf("gzip header: %w", err)
+		}
+	} else {
+		var err error
+		if zr, err = gzip.NewReader(src); err != nil {
+			return "", fmt.Errorf("gzip header: %w", err)
+		}
+	}
+	defer gzipReaderPool.Put(zr)
+
+	var out bytes.Buffer
+	out.Grow(len(data) * 3)
+	if _, err := io.Copy(&out, zr); err != nil {
+		return "", fmt.Errorf("gzip read: %w", err)
+	}
+	return out.String(), nil
+}
+
+// chunkTextArgs returns the content, raw_content and context column values for
+// a chunk plus its compressed flag. Rows are compressed only when enabled and
+// the text is large enough to benefit.
+func (s *SQLiteStore) chunkTextArgs(chunk *Chunk) (content, rawContent, chunkContext any, compressed bool, err error) {
+	if !s.compressContent || len(chunk.Content)+len(chunk.RawContent)+len(chunk.Context) < minCompressSize {
+		return chunk.Content, chunk.RawContent, chunk.Context, false, nil
+	}
+
+	if content, err = compressChunkText(chunk.Content); err != nil {
+		return nil, nil, nil, false, err
+	}
+	if rawContent, err = compressChunkText(chunk.RawContent); err != nil {
+		return nil, nil, nil, false, err
+	}
+	if chunkContext, err = compressChunkText(chunk.Context); err != nil {
+		return nil, nil, nil, false, err
+	}
+	return content, rawContent, chunkContext, true, nil
+}
+
+// decompressChunk restores the text columns of a chunk read from a compressed
+// row. Legacy and uncompressed rows (compressed = 0) are left untouched.
+func decompressChunk(c *Chunk, compressed bool) error {
+	if !compressed {
+		return nil
+	}
+
+	var err error
+	if c.Content, err = decompressChunkText(c.Content); err != nil {
+		return fmt.Errorf("failed to decompress content of chunk %s: %w", c.ID, err)
+	}
+	if c.RawContent, err = decompressChunkText(c.RawContent); err != nil {
+		return fmt.Errorf("failed to decompress raw content of chunk %s: %w", c.ID, err)
+	}
+	if c.Context, err = decompressChunkText(c.Context); err != nil {
+		return fmt.Errorf("failed to decompress context of chunk %s: %w", c.ID, err)
+	}
+	return nil
+}
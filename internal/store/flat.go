@@ -0,0 +1,377 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FlatStore implements VectorStore with exact brute-force search.
+//
+// Every query is compared against every stored vector, so results are exact
+// (100% recall) but search time grows linearly with the number of vectors.
+// There is no graph: memory is just the vectors themselves, Delete frees a
+// vector immediately (no lazy-deleted orphans to compact), and Save/Load are a
+// single sequential write or read of the vectors.
+//
+// Quantization, M, EfConstruction and EfSearch in VectorStoreConfig do not
+// apply; vectors are kept as f32.
+type FlatStore struct {
+	mu     sync.RWMutex
+	config VectorStoreConfig
+
+	ids     []string
+	vectors [][]float32    // Normalized when Metric is "cos"
+	index   map[string]int // ID -> position in ids/vectors
+
+	closed bool
+}
+
+// flatSnapshot is the on-disk form of a FlatStore.
+type flatSnapshot struct {
+	Config  VectorStoreConfig
+	IDs     []string
+	Vectors [][]float32
+}
+
+// NewFlatStore creates a new brute-force vector store.
+func NewFlatStore(cfg VectorStoreConfig) (*FlatStore, error) {
+	if cfg.Metric == "" {
+		cfg.Metric = "cos"
+	}
+
+	return &FlatStore{
+		config: cfg,
+		index:  make(map[string]int),
+	}, nil
+}
+
+// Add inserts vectors with their IDs. If an ID exists, it is replaced.
+func (s *FlatStore) Add(ctx context.Context, ids []string, vectors [][]float32) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if len(ids) != len(vectors) {
+		return fmt.Errorf("ids and vectors length mismatch: %d vs %d", len(ids), len(vectors))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+
+	for _, v := range vectors {
+		if len(v) != s.config.Dimensions {
+			return ErrDimensionMismatch{
+				Expected: s.config.Dimensions,
+				Got:      len(v),
+			}
+		}
+	}
+
+	for i, id := range ids {
+		vec := make([]float32, len(vectors[i]))
+		copy(vec, vectors[i])
+		if s.config.Metric == "cos" {
+			normalizeVectorInPlace(vec)
+		}
+
+		if pos, exists := s.index[id]; exists {
+			s.vectors[pos] = vec
+			continue
+		}
+
+		s.index[id] = len(s.ids)
+		s.ids = append(s.ids, id)
+		s.vectors = append(s.vectors, vec)
+	}
+
+	return nil
+}
+
+// Search finds the k nearest neighbors to query by comparing it against every
+// stored vector.
+func (s *FlatStore) Search(ctx context.Context, query []float32, k int) ([]*VectorResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("store is closed")
+	}
+
+	if len(query) != s.config.Dimensions {
+		return nil, ErrDimensionMismatch{
+			Expected: s.config.Dimensions,
+			Got:      len(query),
+		}
+	}
+
+	if len(s.ids) == 0 || k <= 0 {
+		return []*VectorResult{}, nil
+	}
+
+	normalizedQuery := make([]float32, len(query))
+	copy(normalizedQuery, query)
+	if s.config.Metric == "cos" {
+		normalizeVectorInPlace(normalizedQuery)
+	}
+
+	results := make([]*VectorResult, 0, len(s.ids))
+	for i, vec := range s.vectors {
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		distance := s.distance(normalizedQuery, vec)
+		results = append(results, &VectorResult{
+			ID:       s.ids[i],
+			Distance: distance,
+			Score:    distanceToScore(distance, s.config.Metric),
+		})
+	}
+
+	// Ties are broken by ID so results are deterministic
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		return results[i].ID < results[j].ID
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+
+	return results, nil
+}
+
+// distance returns the configured distance between a and b. For "cos" both
+// vectors must already be normalized.
+func (s *FlatStore) distance(a, b []float32) float32 {
+	if s.config.Metric == "l2" {
+		var sum float64
+		for i := range a {
+			diff := float64(a[i]) - float64(b[i])
+			sum += diff * diff
+		}
+		return float32(math.Sqrt(sum))
+	}
+
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return float32(1 - dot)
+}
+
+// Delete removes vectors by ID. Unlike HNSWStore, the vectors are freed
+// immediately.
+func (s *FlatStore) Delete(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+
+	for _, id := range ids {
+		pos, exists := s.index[id]
+		if !exists {
+			continue
+		}
+
+		// Move the last vector into the freed slot
+		last := len(s.ids) - 1
+		if pos != last {
+			s.ids[pos] = s.ids[last]
+			s.vectors[pos] = s.vectors[last]
+			s.index[s.ids[pos]] = pos
+		}
+		s.ids[last] = ""
+		s.vectors[last] = nil
+		s.ids = s.ids[:last]
+		s.vectors = s.vectors[:last]
+		delete(s.index, id)
+	}
+
+	return nil
+}
+
+// AllIDs returns all vector IDs in the store.
+func (s *FlatStore) AllIDs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil
+	}
+
+	ids := make([]string, len(s.ids))
+	copy(ids, s.ids)
+	return ids
+}
+
+// Contains checks if ID exists.
+func (s *FlatStore) Contains(id string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return false
+	}
+
+	_, exists := s.index[id]
+	return exists
+}
+
+// Count returns number of vectors.
+func (s *FlatStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return 0
+	}
+
+	return len(s.ids)
+}
+
+// Save persists the vectors to disk.
+// Uses atomic save (temp file + rename).
+func (s *FlatStore) Save(path string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create index file: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	snapshot := flatSnapshot{
+		Config:  s.config,
+		IDs:     s.ids,
+		Vectors: s.vectors,
+	}
+	if err := gob.NewEncoder(w).Encode(snapshot); err != nil {
+		if closeErr := file.Close(); closeErr != nil {
+			slog.Warn("failed to close temp file during cleanup", slog.String("error", closeErr.Error()))
+		}
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to encode vectors: %w", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close index file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename index file: %w", err)
+	}
+
+	return nil
+}
+
+// Load replaces the store's contents with vectors saved at path.
+func (s *FlatStore) Load(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			slog.Warn("failed to close index file", slog.String("error", err.Error()))
+		}
+	}()
+
+	var snapshot flatSnapshot
+	if err := gob.NewDecoder(bufio.NewReader(file)).Decode(&snapshot); err != nil {
+		return fmt.Errorf("failed to decode flat index: %w", err)
+	}
+	if len(snapshot.IDs) != len(snapshot.Vectors) {
+		return fmt.Errorf("corrupt flat index: %d ids but %d vectors", len(snapshot.IDs), len(snapshot.Vectors))
+	}
+
+	s.config = snapshot.Config
+	s.ids = snapshot.IDs
+	s.vectors = snapshot.Vectors
+	s.index = make(map[string]int, len(s.ids))
+	for i, id := range s.ids {
+		s.index[id] = i
+	}
+
+	return nil
+}
+
+// Reset drops all vectors and switches the store to dimensions.
+func (s *FlatStore) Reset(dimensions int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+
+	s.config.Dimensions = dimensions
+	s.ids = nil
+	s.vectors = nil
+	s.index = make(map[string]int)
+
+	return nil
+}
+
+// Close releases resources.
+func (s *FlatStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+	s.ids = nil
+	s.vectors = nil
+	s.index = nil
+
+	return nil
+}
+
+// Verify interface implementation
+var _ VectorStore = (*FlatStore)(nil)
+var _ VectorResetter = (*FlatStore)(nil)
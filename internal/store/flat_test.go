@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatStore_AddAndSearch(t *testing.T) {
+	// Given: a flat store with vectors a=[1,0,0,0], b=[0,1,0,0], c=[0.9,0.1,0,0]
+	store, err := NewFlatStore(DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	err = store.Add(context.Background(), []string{"a", "b", "c"}, [][]float32{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0.9, 0.1, 0, 0},
+	})
+	require.NoError(t, err)
+
+	// When: searching for [1,0,0,0] with k=2
+	results, err := store.Search(context.Background(), []float32{1, 0, 0, 0}, 2)
+	require.NoError(t, err)
+
+	// Then: results are ["a", "c"] with "a" an exact match
+	require.Len(t, results, 2)
+	assert.Equal(t, "a", results[0].ID)
+	assert.Equal(t, "c", results[1].ID)
+	assert.InDelta(t, 1.0, results[0].Score, 1e-6)
+	assert.InDelta(t, 0.0, results[0].Distance, 1e-6)
+}
+
+func TestFlatStore_L2Metric(t *testing.T) {
+	// Given: a flat store using euclidean distance
+	cfg := DefaultVectorStoreConfig(2)
+	cfg.Metric = "l2"
+	store, err := NewFlatStore(cfg)
+	require.NoError(t, err)
+
+	err = store.Add(context.Background(), []string{"near", "far"}, [][]float32{{3, 4}, {30, 40}})
+	require.NoError(t, err)
+
+	// When: searching from the origin
+	results, err := store.Search(context.Background(), []float32{0, 0}, 2)
+	require.NoError(t, err)
+
+	// Then: vectors are not normalized and distances are euclidean
+	require.Len(t, results, 2)
+	assert.Equal(t, "near", results[0].ID)
+	assert.InDelta(t, 5.0, results[0].Distance, 1e-6)
+	assert.InDelta(t, 50.0, results[1].Distance, 1e-5)
+}
+
+func TestFlatStore_AddReplacesExistingID(t *testing.T) {
+	// Given: a store with "a" pointing along x
+	store, err := NewFlatStore(DefaultVectorStoreConfig(2))
+	require.NoError(t, err)
+	require.NoError(t, store.Add(context.Background(), []string{"a", "b"}, [][]float32{{1, 0}, {0.7, 0.7}}))
+
+	// When: "a" is re-added pointing along y
+	require.NoError(t, store.Add(context.Background(), []string{"a"}, [][]float32{{0, 1}}))
+
+	// Then: the count is unchanged and "a" matches the new vector
+	assert.Equal(t, 2, store.Count())
+	results, err := store.Search(context.Background(), []float32{0, 1}, 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "a", results[0].ID)
+}
+
+func TestFlatStore_DeleteFreesVectors(t *testing.T) {
+	// Given: a store with "a", "b" and "c"
+	store, err := NewFlatStore(DefaultVectorStoreConfig(2))
+	require.NoError(t, err)
+	require.NoError(t, store.Add(context.Background(), []string{"a", "b", "c"}, [][]float32{{1, 0}, {0, 1}, {1, 1}}))
+
+	// When: deleting "a" and an unknown ID
+	require.NoError(t, store.Delete(context.Background(), []string{"a", "missing"}))
+
+	// Then: "a" is gone from both lookups and search, and the rest remain
+	assert.False(t, store.Contains("a"))
+	assert.Equal(t, 2, store.Count())
+	assert.ElementsMatch(t, []string{"b", "c"}, store.AllIDs())
+
+	results, err := store.Search(context.Background(), []float32{1, 0}, 3)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		assert.NotEqual(t, "a", r.ID)
+	}
+
+	// And: the moved vector is still found under its own ID
+	results, err = store.Search(context.Background(), []float32{1, 1}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "c", results[0].ID)
+}
+
+func TestFlatStore_DimensionMismatch(t *testing.T) {
+	// Given: a 4-dimension store
+	store, err := NewFlatStore(DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+
+	// When/Then: adding or searching with 3 dimensions fails
+	err = store.Add(context.Background(), []string{"a"}, [][]float32{{1, 0, 0}})
+	assert.ErrorAs(t, err, &ErrDimensionMismatch{})
+
+	_, err = store.Search(context.Background(), []float32{1, 0, 0}, 1)
+	assert.ErrorAs(t, err, &ErrDimensionMismatch{})
+}
+
+func TestFlatStore_SaveAndLoad(t *testing.T) {
+	// Given: a saved store with two vectors, one deleted before saving
+	path := filepath.Join(t.TempDir(), "vectors.flat")
+	store, err := NewFlatStore(DefaultVectorStoreConfig(2))
+	require.NoError(t, err)
+	require.NoError(t, store.Add(context.Background(), []string{"a", "b", "c"}, [][]float32{{1, 0}, {0, 1}, {1, 1}}))
+	require.NoError(t, store.Delete(context.Background(), []string{"b"}))
+	require.NoError(t, store.Save(path))
+	require.NoError(t, store.Close())
+
+	// When: loading into a new store
+	loaded, err := NewFlatStore(VectorStoreConfig{})
+	require.NoError(t, err)
+	defer func() { _ = loaded.Close() }()
+	require.NoError(t, loaded.Load(path))
+
+	// Then: contents and config are restored
+	assert.Equal(t, 2, loaded.Count())
+	assert.False(t, loaded.Contains("b"))
+	results, err := loaded.Search(context.Background(), []float32{1, 0}, 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "a", results[0].ID)
+}
+
+func TestFlatStore_MatchesHNSWTopResult(t *testing.T) {
+	// Given: HNSW and flat stores holding the same random vectors, few enough
+	// that HNSW's approximate search reliably finds the nearest one
+	const dims, n = 16, 60
+	rng := rand.New(rand.NewSource(42))
+	ids := make([]string, n)
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		ids[i] = fmt.Sprintf("v%d", i)
+		vectors[i] = make([]float32, dims)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()*2 - 1
+		}
+	}
+
+	flat, err := NewFlatStore(DefaultVectorStoreConfig(dims))
+	require.NoError(t, err)
+	hnsw, err := NewHNSWStore(DefaultVectorStoreConfig(dims))
+	require.NoError(t, err)
+	require.NoError(t, flat.Add(context.Background(), ids, vectors))
+	require.NoError(t, hnsw.Add(context.Background(), ids, vectors))
+
+	// When: querying with a stored vector
+	flatResults, err := flat.Search(context.Background(), vectors[7], 1)
+	require.NoError(t, err)
+	hnswResults, err := hnsw.Search(context.Background(), vectors[7], 1)
+	require.NoError(t, err)
+
+	// Then: both find it, with comparable scores
+	require.Len(t, flatResults, 1)
+	require.Len(t, hnswResults, 1)
+	assert.Equal(t, ids[7], flatResults[0].ID)
+	assert.Equal(t, flatResults[0].ID, hnswResults[0].ID)
+	assert.InDelta(t, hnswResults[0].Score, flatResults[0].Score, 1e-4)
+}
+
+func TestNewVectorStoreWithBackend(t *testing.T) {
+	cfg := DefaultVectorStoreConfig(4)
+
+	// When/Then: each backend name selects its store
+	s, err := NewVectorStoreWithBackend(cfg, "")
+	require.NoError(t, err)
+	assert.IsType(t, &HNSWStore{}, s)
+
+	s, err = NewVectorStoreWithBackend(cfg, "hnsw")
+	require.NoError(t, err)
+	assert.IsType(t, &HNSWStore{}, s)
+
+	s, err = NewVectorStoreWithBackend(cfg, "flat")
+	require.NoError(t, err)
+	assert.IsType(t, &FlatStore{}, s)
+
+	_, err = NewVectorStoreWithBackend(cfg, "faiss")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown vector backend")
+}
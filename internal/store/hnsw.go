@@ -9,6 +9,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/coder/hnsw"
@@ -26,6 +27,7 @@ type HNSWStore struct {
 	keyMap  map[uint64]string // internal key -> string ID
 	nextKey uint64            // next available key
 
+	wal    *hnswLog // nil unless EnableLog was called
 	closed bool
 }
 
@@ -104,7 +106,18 @@ func (s *HNSWStore) Add(ctx context.Context, ids []string, vectors [][]float32)
 		}
 	}
 
-	// Add vectors
+	if err := s.logMutation(hnswLogAdd, ids, vectors); err != nil {
+		return err
+	}
+	s.addLocked(ids, vectors)
+	s.maybeCheckpoint()
+
+	return nil
+}
+
+// addLocked inserts already validated vectors. Must be called with s.mu held
+// for writing.
+func (s *HNSWStore) addLocked(ids []string, vectors [][]float32) {
 	for i, id := range ids {
 		// If ID exists, use lazy deletion (just update mappings, don't remove from graph)
 		// This avoids a bug in coder/hnsw where deleting the last node breaks the graph
@@ -131,12 +144,17 @@ func (s *HNSWStore) Add(ctx context.Context, ids []string, vectors [][]float32)
 		s.idMap[id] = key
 		s.keyMap[key] = id
 	}
-
-	return nil
 }
 
 // Search finds k nearest neighbors to query vector.
 func (s *HNSWStore) Search(ctx context.Context, query []float32, k int) ([]*VectorResult, error) {
+	return s.SearchWithEf(ctx, query, k, 0)
+}
+
+// SearchWithEf finds k nearest neighbors using a per-query search width.
+// ef <= 0 uses the configured EfSearch; other values are clamped to
+// [MinEfSearch, MaxEfSearch].
+func (s *HNSWStore) SearchWithEf(ctx context.Context, query []float32, k, ef int) ([]*VectorResult, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -163,8 +181,17 @@ func (s *HNSWStore) Search(ctx context.Context, query []float32, k int) ([]*Vect
 		normalizeVectorInPlace(normalizedQuery)
 	}
 
-	// Search
-	nodes := s.graph.Search(normalizedQuery, k)
+	// Search. A per-query ef runs on a shallow copy of the graph so concurrent
+	// readers never observe a modified EfSearch.
+	graph := s.graph
+	if ef > 0 {
+		if ef = ClampEfSearch(ef); ef != graph.EfSearch {
+			g := *s.graph
+			g.EfSearch = ef
+			graph = &g
+		}
+	}
+	nodes := graph.Search(normalizedQuery, k)
 
 	// Convert results
 	results := make([]*VectorResult, 0, len(nodes))
@@ -199,6 +226,17 @@ func (s *HNSWStore) Delete(ctx context.Context, ids []string) error {
 		return fmt.Errorf("store is closed")
 	}
 
+	if err := s.logMutation(hnswLogDelete, ids, nil); err != nil {
+		return err
+	}
+	s.deleteLocked(ids)
+	s.maybeCheckpoint()
+
+	return nil
+}
+
+// deleteLocked lazily removes ids. Must be called with s.mu held for writing.
+func (s *HNSWStore) deleteLocked(ids []string) {
 	for _, id := range ids {
 		if key, exists := s.idMap[id]; exists {
 			// Use lazy deletion - just remove from mappings
@@ -208,10 +246,100 @@ func (s *HNSWStore) Delete(ctx context.Context, ids []string) error {
 			delete(s.idMap, id)
 		}
 	}
+}
+
+// Purge removes the vectors for ids and rebuilds the graph from the remaining
+// vectors, dropping these and any previously lazy-deleted nodes. Unlike
+// Delete, nothing is left behind for compaction.
+func (s *HNSWStore) Purge(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+
+	purged := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		purged[id] = struct{}{}
+	}
+
+	// Build the new graph and mappings aside, so that a cancelled ctx
+	// leaves the store as it was
+	keys := make([]uint64, 0, len(s.keyMap))
+	for key, id := range s.keyMap {
+		if _, ok := purged[id]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	graph := hnsw.NewGraph[uint64]()
+	graph.Distance = s.graph.Distance
+	graph.M = s.graph.M
+	graph.EfSearch = s.graph.EfSearch
+	graph.Ml = s.graph.Ml
+	idMap := make(map[string]uint64, len(keys))
+	keyMap := make(map[uint64]string, len(keys))
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		vec, ok := s.graph.Lookup(key)
+		if !ok {
+			// Mapping without a node: drop it rather than keep a dangling ID
+			continue
+		}
+		// Stored vectors are already normalized
+		graph.Add(hnsw.MakeNode(key, vec))
+		idMap[s.keyMap[key]] = key
+		keyMap[key] = s.keyMap[key]
+	}
+
+	// The rebuild itself is not logged: replaying the deletes restores the
+	// same set of IDs, and the next snapshot drops the orphans.
+	if err := s.logMutation(hnswLogDelete, ids, nil); err != nil {
+		return err
+	}
+	s.graph = graph
+	s.idMap = idMap
+	s.keyMap = keyMap
+	s.maybeCheckpoint()
 
 	return nil
 }
 
+// Reset drops all vectors and switches the store to dimensions. With the
+// write-ahead log enabled, the empty store is written as the new snapshot
+// so that a restart does not replay vectors of the old dimension.
+func (s *HNSWStore) Reset(dimensions int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+
+	graph := hnsw.NewGraph[uint64]()
+	graph.Distance = s.graph.Distance
+	graph.M = s.graph.M
+	graph.EfSearch = s.graph.EfSearch
+	graph.Ml = s.graph.Ml
+
+	s.graph = graph
+	s.config.Dimensions = dimensions
+	s.idMap = make(map[string]uint64)
+	s.keyMap = make(map[uint64]string)
+	s.nextKey = 0
+
+	if s.wal != nil {
+		if err := s.saveLocked(s.wal.snapshotPath); err != nil {
+			return fmt.Errorf("failed to snapshot reset store: %w", err)
+		}
+	}
+	return nil
+}
+
 // AllIDs returns all vector IDs in the store.
 // Used for consistency checking between stores.
 func (s *HNSWStore) AllIDs() []string {
@@ -283,7 +411,8 @@ func (s *HNSWStore) Stats() HNSWStats {
 }
 
 // Save persists the index to disk.
-// Uses atomic save (temp file + rename).
+// Uses atomic save (temp file + rename). The write-ahead log belonging to
+// path, if any, is truncated since the snapshot now covers it.
 func (s *HNSWStore) Save(path string) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -292,6 +421,11 @@ func (s *HNSWStore) Save(path string) error {
 		return fmt.Errorf("store is closed")
 	}
 
+	return s.saveLocked(path)
+}
+
+// saveLocked writes a snapshot to path. Must be called with s.mu held.
+func (s *HNSWStore) saveLocked(path string) error {
 	// Create directory if needed
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -328,7 +462,7 @@ func (s *HNSWStore) Save(path string) error {
 		return fmt.Errorf("failed to save metadata: %w", err)
 	}
 
-	return nil
+	return s.resetLogFor(path)
 }
 
 // saveMetadata saves ID mappings to a gob file.
@@ -390,7 +524,8 @@ func (s *HNSWStore) Load(path string) error {
 		return fmt.Errorf("failed to import graph: %w", err)
 	}
 
-	return nil
+	// Apply changes made since the snapshot
+	return s.replayLog(path)
 }
 
 // loadMetadata loads ID mappings from a gob file.
@@ -435,6 +570,12 @@ func (s *HNSWStore) Close() error {
 	}
 
 	s.closed = true
+	if s.wal != nil {
+		if err := s.wal.file.Close(); err != nil {
+			slog.Warn("failed to close vector log", slog.String("error", err.Error()))
+		}
+		s.wal = nil
+	}
 	// coder/hnsw Graph doesn't need explicit cleanup
 	s.graph = nil
 
@@ -471,6 +612,9 @@ func ReadHNSWStoreDimensions(vectorPath string) (int, error) {
 
 // Verify interface implementation
 var _ VectorStore = (*HNSWStore)(nil)
+var _ EfSearcher = (*HNSWStore)(nil)
+var _ VectorPurger = (*HNSWStore)(nil)
+var _ VectorResetter = (*HNSWStore)(nil)
 
 // normalizeVectorInPlace normalizes a vector to unit length in place.
 func normalizeVectorInPlace(v []float32) {
@@ -0,0 +1,298 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultHNSWLogCheckpointBytes is the write-ahead log size at which an
+// HNSWStore with EnableLog checkpoints itself.
+const DefaultHNSWLogCheckpointBytes = 64 << 20
+
+// HNSWLogPath returns the write-ahead log path for an HNSW snapshot at path.
+func HNSWLogPath(path string) string {
+	return path + ".wal"
+}
+
+// Write-ahead log operations.
+const (
+	hnswLogAdd    byte = 1
+	hnswLogDelete byte = 2
+)
+
+// hnswLog is an append-only log of vector adds and deletes since the last
+// snapshot. Each record is framed as
+//
+//	uint32 payload length | uint32 CRC-32 of payload | payload
+//
+// with payload = op | uvarint id length | id, followed for adds by
+// uvarint dimensions | float32 values, all little endian. A torn or corrupt
+// tail, as left by a crash mid-append, ends replay.
+type hnswLog struct {
+	mu              sync.Mutex // Save truncates under the store's read lock
+	file            *os.File
+	size            int64
+	snapshotPath    string
+	checkpointBytes int64
+}
+
+// EnableLog starts logging every Add, Delete and Purge to HNSWLogPath(path)
+// before it is applied, so incremental changes survive a crash without
+// rewriting the whole graph. path is the snapshot the log belongs to: Load
+// replays the log on top of it, and Save or Checkpoint to it truncate the
+// log. Call EnableLog after Load with the same path. When no snapshot exists
+// yet, one is written first so the log always has a base.
+//
+// Once the log grows past DefaultHNSWLogCheckpointBytes the store
+// checkpoints itself during the mutation that crossed the limit.
+func (s *HNSWStore) EnableLog(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+	if s.wal != nil {
+		if err := s.wal.file.Close(); err != nil {
+			slog.Warn("failed to close vector log", slog.String("error", err.Error()))
+		}
+		s.wal = nil
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := s.saveLocked(path); err != nil {
+			return fmt.Errorf("failed to write base snapshot: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(HNSWLogPath(path), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open vector log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat vector log: %w", err)
+	}
+
+	s.wal = &hnswLog{
+		file:            file,
+		size:            info.Size(),
+		snapshotPath:    filepath.Clean(path),
+		checkpointBytes: DefaultHNSWLogCheckpointBytes,
+	}
+	return nil
+}
+
+// Checkpoint writes a full snapshot to the path given to EnableLog and
+// truncates the log.
+func (s *HNSWStore) Checkpoint() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+	if s.wal == nil {
+		return fmt.Errorf("vector log is not enabled")
+	}
+	return s.saveLocked(s.wal.snapshotPath)
+}
+
+// logMutation appends op for ids (and vectors, for adds) to the log, if
+// enabled. Must be called with s.mu held for writing, before applying the
+// mutation.
+func (s *HNSWStore) logMutation(op byte, ids []string, vectors [][]float32) error {
+	if s.wal == nil || len(ids) == 0 {
+		return nil
+	}
+	var buf []byte
+	for i, id := range ids {
+		var vec []float32
+		if op == hnswLogAdd {
+			vec = vectors[i]
+		}
+		buf = appendHNSWLogRecord(buf, op, id, vec)
+	}
+	return s.wal.append(buf)
+}
+
+// maybeCheckpoint checkpoints once the log has outgrown its limit. Must be
+// called with s.mu held for writing, after applying a mutation. Failures
+// are logged: the mutation itself is already durable in the log.
+func (s *HNSWStore) maybeCheckpoint() {
+	if s.wal == nil || s.wal.size < s.wal.checkpointBytes {
+		return
+	}
+	if err := s.saveLocked(s.wal.snapshotPath); err != nil {
+		slog.Warn("vector_log_checkpoint_failed", slog.String("error", err.Error()))
+	}
+}
+
+// resetLogFor drops the log belonging to the snapshot just written at path:
+// the live log is truncated, a log left by an earlier process is removed.
+func (s *HNSWStore) resetLogFor(path string) error {
+	if s.wal != nil && s.wal.snapshotPath == filepath.Clean(path) {
+		return s.wal.truncate()
+	}
+	if err := os.Remove(HNSWLogPath(path)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale vector log: %w", err)
+	}
+	return nil
+}
+
+// append writes and syncs buf. On failure the log is cut back to its
+// previous size so later records are not stranded behind a torn one.
+func (l *hnswLog) append(buf []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n, err := l.file.Write(buf)
+	if err == nil {
+		err = l.file.Sync()
+	}
+	if err != nil {
+		if n > 0 {
+			if truncErr := l.file.Truncate(l.size); truncErr != nil {
+				slog.Warn("failed to roll back vector log", slog.String("error", truncErr.Error()))
+			}
+		}
+		return fmt.Errorf("failed to append to vector log: %w", err)
+	}
+	l.size += int64(n)
+	return nil
+}
+
+// truncate empties the log once a snapshot covers it.
+func (l *hnswLog) truncate() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate vector log: %w", err)
+	}
+	l.size = 0
+	return nil
+}
+
+// replayLog applies the log next to the snapshot at path. Must be called
+// with s.mu held for writing. A torn tail is cut off so that appends made
+// after this load are not stranded behind it.
+func (s *HNSWStore) replayLog(path string) error {
+	logPath := HNSWLogPath(path)
+	data, err := os.ReadFile(logPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read vector log: %w", err)
+	}
+
+	offset, records := 0, 0
+	for offset < len(data) {
+		op, id, vec, n, err := decodeHNSWLogRecord(data[offset:])
+		if err != nil {
+			break
+		}
+		switch op {
+		case hnswLogAdd:
+			if len(vec) != s.config.Dimensions {
+				return fmt.Errorf("failed to replay vector log: %w", ErrDimensionMismatch{
+					Expected: s.config.Dimensions,
+					Got:      len(vec),
+				})
+			}
+			s.addLocked([]string{id}, [][]float32{vec})
+		case hnswLogDelete:
+			s.deleteLocked([]string{id})
+		}
+		offset += n
+		records++
+	}
+
+	if offset < len(data) {
+		slog.Warn("vector_log_tail_dropped",
+			slog.String("path", logPath),
+			slog.Int("valid_bytes", offset),
+			slog.Int("dropped_bytes", len(data)-offset))
+		if err := os.Truncate(logPath, int64(offset)); err != nil {
+			return fmt.Errorf("failed to truncate vector log: %w", err)
+		}
+	}
+	if records > 0 {
+		slog.Debug("vector_log_replayed", slog.Int("records", records))
+	}
+	return nil
+}
+
+// appendHNSWLogRecord appends one framed record to buf.
+func appendHNSWLogRecord(buf []byte, op byte, id string, vec []float32) []byte {
+	payload := []byte{op}
+	payload = binary.AppendUvarint(payload, uint64(len(id)))
+	payload = append(payload, id...)
+	if op == hnswLogAdd {
+		payload = binary.AppendUvarint(payload, uint64(len(vec)))
+		for _, v := range vec {
+			payload = binary.LittleEndian.AppendUint32(payload, math.Float32bits(v))
+		}
+	}
+
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(payload))
+	return append(buf, payload...)
+}
+
+var errHNSWLogCorrupt = errors.New("corrupt vector log record")
+
+// decodeHNSWLogRecord decodes the record at the start of data and returns
+// its total framed length.
+func decodeHNSWLogRecord(data []byte) (op byte, id string, vec []float32, n int, err error) {
+	if len(data) < 8 {
+		return 0, "", nil, 0, errHNSWLogCorrupt
+	}
+	size := binary.LittleEndian.Uint32(data)
+	sum := binary.LittleEndian.Uint32(data[4:])
+	if uint64(size) > uint64(len(data)-8) {
+		return 0, "", nil, 0, errHNSWLogCorrupt
+	}
+	payload := data[8 : 8+int(size)]
+	if crc32.ChecksumIEEE(payload) != sum || len(payload) == 0 {
+		return 0, "", nil, 0, errHNSWLogCorrupt
+	}
+
+	op = payload[0]
+	rest := payload[1:]
+	idLen, k := binary.Uvarint(rest)
+	if k <= 0 || idLen > uint64(len(rest)-k) {
+		return 0, "", nil, 0, errHNSWLogCorrupt
+	}
+	id = string(rest[k : k+int(idLen)])
+	rest = rest[k+int(idLen):]
+
+	switch op {
+	case hnswLogAdd:
+		dims, k := binary.Uvarint(rest)
+		if k <= 0 || dims*4 != uint64(len(rest)-k) {
+			return 0, "", nil, 0, errHNSWLogCorrupt
+		}
+		rest = rest[k:]
+		vec = make([]float32, dims)
+		for i := range vec {
+			vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(rest[i*4:]))
+		}
+	case hnswLogDelete:
+		if len(rest) != 0 {
+			return 0, "", nil, 0, errHNSWLogCorrupt
+		}
+	default:
+		return 0, "", nil, 0, errHNSWLogCorrupt
+	}
+	return op, id, vec, 8 + int(size), nil
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openLoggedHNSWStore loads the store at path, if any, and enables its log.
+func openLoggedHNSWStore(t *testing.T, path string) *HNSWStore {
+	t.Helper()
+	s, err := NewHNSWStore(DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+	if _, err := os.Stat(path); err == nil {
+		require.NoError(t, s.Load(path))
+	}
+	require.NoError(t, s.EnableLog(path))
+	return s
+}
+
+func TestHNSWStore_EnableLog_ReplaysChangesAfterRestart(t *testing.T) {
+	// Given: a logged store with a snapshot of "a" and "b"
+	path := filepath.Join(t.TempDir(), "vectors.hnsw")
+	s1 := openLoggedHNSWStore(t, path)
+	ctx := context.Background()
+	require.NoError(t, s1.Add(ctx, []string{"a", "b"}, [][]float32{{1, 0, 0, 0}, {0, 1, 0, 0}}))
+	require.NoError(t, s1.Checkpoint())
+
+	// When: vectors change without another snapshot and the process stops
+	require.NoError(t, s1.Add(ctx, []string{"c", "a"}, [][]float32{{0, 0, 1, 0}, {0, 0, 0, 1}}))
+	require.NoError(t, s1.Delete(ctx, []string{"b"}))
+	require.NoError(t, s1.Close())
+
+	// Then: reloading the snapshot replays the log
+	s2 := openLoggedHNSWStore(t, path)
+	defer func() { _ = s2.Close() }()
+	assert.ElementsMatch(t, []string{"a", "c"}, s2.AllIDs())
+	results, err := s2.Search(ctx, []float32{0, 0, 0, 1}, 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "a", results[0].ID, "replayed update should replace the snapshot vector")
+}
+
+func TestHNSWStore_Checkpoint_TruncatesLog(t *testing.T) {
+	// Given: a logged store with unsnapshotted changes
+	path := filepath.Join(t.TempDir(), "vectors.hnsw")
+	s := openLoggedHNSWStore(t, path)
+	defer func() { _ = s.Close() }()
+	require.NoError(t, s.Add(context.Background(), []string{"a"}, [][]float32{{1, 0, 0, 0}}))
+	info, err := os.Stat(HNSWLogPath(path))
+	require.NoError(t, err)
+	require.Positive(t, info.Size())
+
+	// When: checkpointing
+	require.NoError(t, s.Checkpoint())
+
+	// Then: the snapshot holds the change and the log is empty
+	info, err = os.Stat(HNSWLogPath(path))
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+	dims, err := ReadHNSWStoreDimensions(path)
+	require.NoError(t, err)
+	assert.Equal(t, 4, dims)
+}
+
+func TestHNSWStore_Checkpoint_RequiresLog(t *testing.T) {
+	// Given: a store without a log
+	s, err := NewHNSWStore(DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+
+	// When/Then: Checkpoint fails
+	assert.Error(t, s.Checkpoint())
+}
+
+func TestHNSWStore_Load_IgnoresTornLogTail(t *testing.T) {
+	// Given: a log whose last record was cut short by a crash
+	path := filepath.Join(t.TempDir(), "vectors.hnsw")
+	s1 := openLoggedHNSWStore(t, path)
+	ctx := context.Background()
+	require.NoError(t, s1.Add(ctx, []string{"a"}, [][]float32{{1, 0, 0, 0}}))
+	require.NoError(t, s1.Add(ctx, []string{"b"}, [][]float32{{0, 1, 0, 0}}))
+	require.NoError(t, s1.Close())
+	info, err := os.Stat(HNSWLogPath(path))
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(HNSWLogPath(path), info.Size()-3))
+
+	// When: loading
+	s2 := openLoggedHNSWStore(t, path)
+	defer func() { _ = s2.Close() }()
+
+	// Then: the complete records are applied and the torn one is dropped
+	assert.Equal(t, []string{"a"}, s2.AllIDs())
+
+	// And: later appends are replayed after the cut
+	require.NoError(t, s2.Add(ctx, []string{"c"}, [][]float32{{0, 0, 1, 0}}))
+	require.NoError(t, s2.Close())
+	s3 := openLoggedHNSWStore(t, path)
+	defer func() { _ = s3.Close() }()
+	assert.ElementsMatch(t, []string{"a", "c"}, s3.AllIDs())
+}
+
+func TestHNSWStore_Save_RemovesStaleLog(t *testing.T) {
+	// Given: a log left behind for a snapshot
+	path := filepath.Join(t.TempDir(), "vectors.hnsw")
+	s1 := openLoggedHNSWStore(t, path)
+	require.NoError(t, s1.Add(context.Background(), []string{"a"}, [][]float32{{1, 0, 0, 0}}))
+	require.NoError(t, s1.Close())
+
+	// When: another store without a log saves over the snapshot
+	s2, err := NewHNSWStore(DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+	defer func() { _ = s2.Close() }()
+	require.NoError(t, s2.Add(context.Background(), []string{"b"}, [][]float32{{0, 1, 0, 0}}))
+	require.NoError(t, s2.Save(path))
+
+	// Then: the old log is gone, so it is not replayed onto the new snapshot
+	_, err = os.Stat(HNSWLogPath(path))
+	assert.True(t, os.IsNotExist(err))
+	s3 := openLoggedHNSWStore(t, path)
+	defer func() { _ = s3.Close() }()
+	assert.Equal(t, []string{"b"}, s3.AllIDs())
+}
+
+func TestDecodeHNSWLogRecord_RoundTrip(t *testing.T) {
+	// Given: an add and a delete record
+	buf := appendHNSWLogRecord(nil, hnswLogAdd, "chunk-1", []float32{0.5, -1, 2})
+	buf = appendHNSWLogRecord(buf, hnswLogDelete, "chunk-2", nil)
+
+	// When: decoding them in turn
+	op, id, vec, n, err := decodeHNSWLogRecord(buf)
+	require.NoError(t, err)
+	op2, id2, vec2, n2, err := decodeHNSWLogRecord(buf[n:])
+	require.NoError(t, err)
+
+	// Then: both decode to what was written
+	assert.Equal(t, hnswLogAdd, op)
+	assert.Equal(t, "chunk-1", id)
+	assert.Equal(t, []float32{0.5, -1, 2}, vec)
+	assert.Equal(t, hnswLogDelete, op2)
+	assert.Equal(t, "chunk-2", id2)
+	assert.Nil(t, vec2)
+	assert.Equal(t, len(buf), n+n2)
+
+	// And: a flipped byte is detected
+	buf[len(buf)-1] ^= 0xff
+	_, _, _, _, err = decodeHNSWLogRecord(buf[n:])
+	assert.ErrorIs(t, err, errHNSWLogCorrupt)
+}
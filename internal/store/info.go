@@ -3,6 +3,8 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -43,6 +45,24 @@ func GetIndexInfo(ctx context.Context, metadata MetadataStore, dataDir string, e
 		info.IndexBackend = InferBackendFromModel(model)
 	}
 
+	// Per-model embedding counts reveal partially migrated indexes
+	if counter, ok := metadata.(EmbeddingModelCounter); ok {
+		counts, err := counter.GetEmbeddingModelCounts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count embeddings per model: %w", err)
+		}
+		info.EmbeddingModels = counts
+	}
+
+	// Symbol counts are recorded by the indexer at the end of a full run
+	if raw, err := metadata.GetState(ctx, StateKeyIndexSymbolCounts); err == nil && raw != "" {
+		var counts SymbolCounts
+		if err := json.Unmarshal([]byte(raw), &counts); err != nil {
+			return nil, fmt.Errorf("failed to decode symbol counts: %w", err)
+		}
+		info.SymbolCounts = counts
+	}
+
 	// Get file sizes - check both BM25 backends
 	bm25SQLitePath := filepath.Join(dataDir, "bm25.db")
 	bm25BlevePath := filepath.Join(dataDir, "bm25.bleve")
@@ -79,6 +99,43 @@ func GetIndexInfo(ctx context.Context, metadata MetadataStore, dataDir string, e
 	return info, nil
 }
 
+// SymbolCounts holds extracted symbol counts per language and symbol type,
+// e.g. counts["python"][SymbolTypeFunction]. Every language with code chunks
+// has an entry, even if no symbols were extracted from it, so a language
+// whose extraction silently broke shows up with zero symbols instead of
+// being absent.
+type SymbolCounts map[string]map[SymbolType]int
+
+// Add counts the symbols carried by the given code chunks.
+func (c SymbolCounts) Add(chunks []*Chunk) {
+	for _, ch := range chunks {
+		if ch == nil || ch.ContentType != ContentTypeCode || ch.Language == "" {
+			continue
+		}
+		byType, ok := c[ch.Language]
+		if !ok {
+			byType = make(map[SymbolType]int)
+			c[ch.Language] = byType
+		}
+		for _, sym := range ch.Symbols {
+			if sym != nil {
+				byType[sym.Type]++
+			}
+		}
+	}
+}
+
+// Total returns the number of symbols across all languages.
+func (c SymbolCounts) Total() int {
+	total := 0
+	for _, byType := range c {
+		for _, n := range byType {
+			total += n
+		}
+	}
+	return total
+}
+
 // EmbedderInfoInput provides current embedder details for GetIndexInfo.
 type EmbedderInfoInput struct {
 	Model      string
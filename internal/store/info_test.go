@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -261,3 +262,64 @@ func TestGetDirSize_NonexistentPath(t *testing.T) {
 	size := getDirSize("/nonexistent/path/that/does/not/exist")
 	assert.Equal(t, int64(0), size)
 }
+
+// =============================================================================
+// SymbolCounts Tests
+// =============================================================================
+
+func TestSymbolCounts_Add(t *testing.T) {
+	// Given: code chunks in two languages, one without symbols, and a markdown chunk
+	chunks := []*Chunk{
+		{ContentType: ContentTypeCode, Language: "go", Symbols: []*Symbol{
+			{Name: "Run", Type: SymbolTypeFunction},
+			{Name: "Runner", Type: SymbolTypeType},
+		}},
+		{ContentType: ContentTypeCode, Language: "go", Symbols: []*Symbol{
+			{Name: "Close", Type: SymbolTypeMethod},
+			{Name: "New", Type: SymbolTypeFunction},
+		}},
+		{ContentType: ContentTypeCode, Language: "python"},
+		{ContentType: ContentTypeMarkdown, Language: "markdown", Symbols: []*Symbol{{Name: "Intro"}}},
+		nil,
+	}
+
+	// When: counting symbols
+	counts := make(SymbolCounts)
+	counts.Add(chunks)
+
+	// Then: symbols are counted per language and type, and python is present with none
+	assert.Equal(t, SymbolCounts{
+		"go":     {SymbolTypeFunction: 2, SymbolTypeType: 1, SymbolTypeMethod: 1},
+		"python": {},
+	}, counts)
+	assert.Equal(t, 4, counts.Total())
+}
+
+func TestGetIndexInfo_SymbolCounts(t *testing.T) {
+	store, tmpDir := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: symbol counts recorded by an index run
+	require.NoError(t, store.SetState(ctx, StateKeyIndexSymbolCounts, `{"go":{"function":3,"method":2},"python":{}}`))
+
+	// When: reading index info
+	info, err := GetIndexInfo(ctx, store, tmpDir, nil)
+
+	// Then: the counts are decoded
+	require.NoError(t, err)
+	assert.Equal(t, SymbolCounts{
+		"go":     {SymbolTypeFunction: 3, SymbolTypeMethod: 2},
+		"python": {},
+	}, info.SymbolCounts)
+}
+
+func TestGetIndexInfo_NoSymbolCounts(t *testing.T) {
+	store, tmpDir := newTestStore(t)
+
+	// When: reading info for an index built before symbol counts were recorded
+	info, err := GetIndexInfo(context.Background(), store, tmpDir, nil)
+
+	// Then: SymbolCounts is nil
+	require.NoError(t, err)
+	assert.Nil(t, info.SymbolCounts)
+}
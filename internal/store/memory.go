@@ -0,0 +1,770 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore implements MetadataStore in memory, for tests and ephemeral
+// indexes that do not need to outlive the process.
+//
+// It follows the semantics of SQLiteStore: lookups of missing projects, files
+// and chunks return nil without an error, deleting a file also deletes its
+// chunks, and deleting a chunk also drops its embedding. Stored values are
+// copied on the way in and out, so callers may reuse or modify them freely.
+// Nothing is persisted; Close discards all data.
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	projects   map[string]*Project
+	files      map[string]*File           // file ID -> file
+	chunks     map[string]*Chunk          // chunk ID -> chunk
+	embeddings map[string]memoryEmbedding // chunk ID -> embedding
+	state      map[string]string
+}
+
+// memoryEmbedding is a chunk embedding and the model that produced it.
+type memoryEmbedding struct {
+	vector []float32
+	model  string
+}
+
+// NewMemoryStore creates an empty in-memory metadata store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		projects:   make(map[string]*Project),
+		files:      make(map[string]*File),
+		chunks:     make(map[string]*Chunk),
+		embeddings: make(map[string]memoryEmbedding),
+		state:      make(map[string]string),
+	}
+}
+
+// Ping always succeeds; there is no backend to reach.
+func (s *MemoryStore) Ping(_ context.Context) error {
+	return nil
+}
+
+// SaveProject saves or updates a project.
+func (s *MemoryStore) SaveProject(_ context.Context, project *Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := *project
+	s.projects[p.ID] = &p
+	return nil
+}
+
+// GetProject retrieves a project by ID.
+func (s *MemoryStore) GetProject(_ context.Context, id string) (*Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.projects[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *p
+	return &cp, nil
+}
+
+// ListProjects returns all projects in the store, ordered by ID.
+func (s *MemoryStore) ListProjects(_ context.Context) ([]*Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	projects := make([]*Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		cp := *p
+		projects = append(projects, &cp)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ID < projects[j].ID })
+	return projects, nil
+}
+
+// DeleteProject deletes a project together with its files and their chunks.
+// Deleting a project that does not exist is not an error.
+func (s *MemoryStore) DeleteProject(_ context.Context, projectID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.projects, projectID)
+	s.deleteFilesLocked(func(f *File) bool { return f.ProjectID == projectID })
+	return nil
+}
+
+// UpdateProjectStats updates the file and chunk counts for a project.
+func (s *MemoryStore) UpdateProjectStats(_ context.Context, id string, fileCount, chunkCount int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.updateProjectStatsLocked(id, fileCount, chunkCount)
+	return nil
+}
+
+// RefreshProjectStats recalculates the file and chunk counts of a project
+// from its stored files and chunks and updates indexed_at.
+func (s *MemoryStore) RefreshProjectStats(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fileIDs := make(map[string]struct{})
+	for _, f := range s.files {
+		if f.ProjectID == id {
+			fileIDs[f.ID] = struct{}{}
+		}
+	}
+	chunkCount := 0
+	for _, c := range s.chunks {
+		if _, ok := fileIDs[c.FileID]; ok {
+			chunkCount++
+		}
+	}
+
+	s.updateProjectStatsLocked(id, len(fileIDs), chunkCount)
+	return nil
+}
+
+func (s *MemoryStore) updateProjectStatsLocked(id string, fileCount, chunkCount int) {
+	if p, ok := s.projects[id]; ok {
+		p.FileCount = fileCount
+		p.ChunkCount = chunkCount
+		p.IndexedAt = time.Now()
+	}
+}
+
+// SaveFiles saves or updates files. A file saved under a new ID replaces the
+// file previously stored at the same project path.
+func (s *MemoryStore) SaveFiles(_ context.Context, files []*File) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range files {
+		s.deleteFilesLocked(func(old *File) bool {
+			return old.ProjectID == f.ProjectID && old.Path == f.Path && old.ID != f.ID
+		})
+		cp := *f
+		s.files[cp.ID] = &cp
+	}
+	return nil
+}
+
+// GetFileByPath retrieves a file by its path within a project.
+func (s *MemoryStore) GetFileByPath(_ context.Context, projectID, path string) (*File, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, f := range s.files {
+		if f.ProjectID == projectID && f.Path == path {
+			cp := *f
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetChangedFiles returns files modified since the given timestamp, oldest
+// first.
+func (s *MemoryStore) GetChangedFiles(_ context.Context, projectID string, since time.Time) ([]*File, error) {
+	files := s.projectFiles(projectID, func(f *File) bool { return f.ModTime.After(since) })
+	sort.SliceStable(files, func(i, j int) bool { return files[i].ModTime.Before(files[j].ModTime) })
+	return files, nil
+}
+
+// ListFiles returns files for a project ordered by path, with the same
+// cursor-based pagination as SQLiteStore.ListFiles.
+func (s *MemoryStore) ListFiles(_ context.Context, projectID string, cursor string, limit int) ([]*File, string, error) {
+	offset := 0
+	if cursor != "" {
+		decoded, err := base64.StdEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid cursor: %w", err)
+		}
+		if _, err := fmt.Sscanf(string(decoded), "offset:%d", &offset); err != nil {
+			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
+		}
+		if offset < 0 {
+			return nil, "", fmt.Errorf("cursor offset must be non-negative: %d", offset)
+		}
+	}
+
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	files := s.projectFiles(projectID, nil)
+	if offset >= len(files) {
+		return nil, "", nil
+	}
+	files = files[offset:]
+
+	var nextCursor string
+	if len(files) > limit {
+		files = files[:limit]
+		nextCursor = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("offset:%d", offset+limit)))
+	}
+	return files, nextCursor, nil
+}
+
+// FindFilesByContentHash returns the files of a project whose content hash
+// equals hash, ordered by path.
+func (s *MemoryStore) FindFilesByContentHash(_ context.Context, projectID, hash string) ([]*File, error) {
+	return s.projectFiles(projectID, func(f *File) bool { return f.ContentHash == hash }), nil
+}
+
+// GetFilePathsByProject returns the paths of all files in a project, sorted.
+func (s *MemoryStore) GetFilePathsByProject(_ context.Context, projectID string) ([]string, error) {
+	return filePaths(s.projectFiles(projectID, nil)), nil
+}
+
+// ListFilePathsUnder returns the paths of the files in a project at or under
+// dirPrefix, sorted. An empty prefix matches every file.
+func (s *MemoryStore) ListFilePathsUnder(_ context.Context, projectID, dirPrefix string) ([]string, error) {
+	dirPrefix = strings.TrimSuffix(dirPrefix, "/")
+	if dirPrefix == "" {
+		return filePaths(s.projectFiles(projectID, nil)), nil
+	}
+	return filePaths(s.projectFiles(projectID, func(f *File) bool {
+		return f.Path == dirPrefix || strings.HasPrefix(f.Path, dirPrefix+"/")
+	})), nil
+}
+
+// GetFilesForReconciliation returns all files for a project keyed by path.
+func (s *MemoryStore) GetFilesForReconciliation(_ context.Context, projectID string) (map[string]*File, error) {
+	files := s.projectFiles(projectID, nil)
+	result := make(map[string]*File, len(files))
+	for _, f := range files {
+		result[f.Path] = f
+	}
+	return result, nil
+}
+
+// DeleteFile deletes a single file by ID together with its chunks.
+func (s *MemoryStore) DeleteFile(_ context.Context, fileID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteFilesLocked(func(f *File) bool { return f.ID == fileID })
+	return nil
+}
+
+// DeleteFilesByProject deletes all files of a project together with their
+// chunks.
+func (s *MemoryStore) DeleteFilesByProject(_ context.Context, projectID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteFilesLocked(func(f *File) bool { return f.ProjectID == projectID })
+	return nil
+}
+
+// projectFiles returns copies of the files of a project that satisfy keep
+// (all of them when keep is nil), ordered by path.
+func (s *MemoryStore) projectFiles(projectID string, keep func(*File) bool) []*File {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var files []*File
+	for _, f := range s.files {
+		if f.ProjectID != projectID || (keep != nil && !keep(f)) {
+			continue
+		}
+		cp := *f
+		files = append(files, &cp)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// deleteFilesLocked deletes the files matching match and their chunks.
+func (s *MemoryStore) deleteFilesLocked(match func(*File) bool) {
+	for id, f := range s.files {
+		if !match(f) {
+			continue
+		}
+		delete(s.files, id)
+		s.deleteChunksLocked(func(c *Chunk) bool { return c.FileID == id })
+	}
+}
+
+func filePaths(files []*File) []string {
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+// SaveChunks saves or updates chunks, replacing their symbols. The
+// embedding of an updated chunk is kept.
+func (s *MemoryStore) SaveChunks(_ context.Context, chunks []*Chunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range chunks {
+		s.chunks[c.ID] = copyChunk(c)
+	}
+	return nil
+}
+
+// GetChunk retrieves a chunk by ID.
+func (s *MemoryStore) GetChunk(_ context.Context, id string) (*Chunk, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.chunks[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyChunk(c), nil
+}
+
+// GetChunks retrieves chunks by ID in the order of ids. Unknown IDs are
+// skipped.
+func (s *MemoryStore) GetChunks(_ context.Context, ids []string) ([]*Chunk, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*Chunk, 0, len(ids))
+	for _, id := range ids {
+		if c, ok := s.chunks[id]; ok {
+			result = append(result, copyChunk(c))
+		}
+	}
+	return result, nil
+}
+
+// GetChunkHeaders retrieves chunks by ID without their text, in the order of
+// ids. Unknown IDs are skipped.
+func (s *MemoryStore) GetChunkHeaders(_ context.Context, ids []string) ([]*Chunk, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*Chunk, 0, len(ids))
+	for _, id := range ids {
+		c, ok := s.chunks[id]
+		if !ok {
+			continue
+		}
+		result = append(result, &Chunk{
+			ID:          c.ID,
+			FileID:      c.FileID,
+			FilePath:    c.FilePath,
+			ContentType: c.ContentType,
+			Language:    c.Language,
+			StartLine:   c.StartLine,
+			EndLine:     c.EndLine,
+		})
+	}
+	return result, nil
+}
+
+// GetChunksByFile retrieves all chunks of a file in source order.
+func (s *MemoryStore) GetChunksByFile(_ context.Context, fileID string) ([]*Chunk, error) {
+	return s.sortedChunks(func(c *Chunk) bool { return c.FileID == fileID }, 0), nil
+}
+
+// GetChunksByPath retrieves up to limit chunks of an indexed file path in
+// source order. A limit of zero or less returns all of them.
+func (s *MemoryStore) GetChunksByPath(_ context.Context, filePath string, limit int) ([]*Chunk, error) {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return nil, nil
+	}
+	return s.sortedChunks(func(c *Chunk) bool { return c.FilePath == filePath }, limit), nil
+}
+
+// GetChunksBySymbol returns chunks that own an exact symbol name, types and
+// classes before functions and methods, then by symbol position.
+func (s *MemoryStore) GetChunksBySymbol(_ context.Context, name string, limit int) ([]*Chunk, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type match struct {
+		chunk     *Chunk
+		rank      int
+		startLine int
+	}
+	var matches []match
+	for _, c := range s.chunks {
+		m := match{chunk: c, rank: -1}
+		for _, sym := range c.Symbols {
+			if sym.Name != name {
+				continue
+			}
+			rank := symbolTypeRank(sym.Type)
+			if m.rank < 0 || rank < m.rank {
+				m.rank = rank
+			}
+			if m.rank == rank && (m.startLine == 0 || sym.StartLine < m.startLine) {
+				m.startLine = sym.StartLine
+			}
+		}
+		if m.rank >= 0 {
+			matches = append(matches, m)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		if matches[i].startLine != matches[j].startLine {
+			return matches[i].startLine < matches[j].startLine
+		}
+		return matches[i].chunk.ID < matches[j].chunk.ID
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	result := make([]*Chunk, len(matches))
+	for i, m := range matches {
+		result[i] = copyChunk(m.chunk)
+	}
+	return result, nil
+}
+
+// symbolTypeRank orders symbol kinds for GetChunksBySymbol.
+func symbolTypeRank(t SymbolType) int {
+	switch t {
+	case SymbolTypeType:
+		return 0
+	case SymbolTypeClass:
+		return 1
+	case SymbolTypeInterface:
+		return 2
+	case SymbolTypeFunction:
+		return 3
+	case SymbolTypeMethod:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// FindChunksByContentHash returns the chunks of a project whose
+// ChunkContentHash equals hash, ordered by file path and start line.
+func (s *MemoryStore) FindChunksByContentHash(_ context.Context, projectID, hash string) ([]*Chunk, error) {
+	s.mu.RLock()
+	fileIDs := make(map[string]struct{})
+	for _, f := range s.files {
+		if f.ProjectID == projectID {
+			fileIDs[f.ID] = struct{}{}
+		}
+	}
+	s.mu.RUnlock()
+
+	return s.sortedChunks(func(c *Chunk) bool {
+		_, ok := fileIDs[c.FileID]
+		return ok && ChunkContentHash(c.Content) == hash
+	}, 0), nil
+}
+
+// ListChunkIDs returns all chunk IDs in ascending order.
+func (s *MemoryStore) ListChunkIDs(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.chunks))
+	for id := range s.chunks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// DeleteChunks deletes chunks by their IDs. Unknown IDs are ignored.
+func (s *MemoryStore) DeleteChunks(_ context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.chunks, id)
+		delete(s.embeddings, id)
+	}
+	return nil
+}
+
+// DeleteChunksByFile deletes all chunks of a file.
+func (s *MemoryStore) DeleteChunksByFile(_ context.Context, fileID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteChunksLocked(func(c *Chunk) bool { return c.FileID == fileID })
+	return nil
+}
+
+// sortedChunks returns copies of up to limit chunks satisfying keep, ordered
+// by file path and start line. A limit of zero or less means no limit.
+func (s *MemoryStore) sortedChunks(keep func(*Chunk) bool, limit int) []*Chunk {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var chunks []*Chunk
+	for _, c := range s.chunks {
+		if keep(c) {
+			chunks = append(chunks, c)
+		}
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		if chunks[i].FilePath != chunks[j].FilePath {
+			return chunks[i].FilePath < chunks[j].FilePath
+		}
+		return chunks[i].StartLine < chunks[j].StartLine
+	})
+	if limit > 0 && len(chunks) > limit {
+		chunks = chunks[:limit]
+	}
+	for i, c := range chunks {
+		chunks[i] = copyChunk(c)
+	}
+	return chunks
+}
+
+func (s *MemoryStore) deleteChunksLocked(match func(*Chunk) bool) {
+	for id, c := range s.chunks {
+		if match(c) {
+			delete(s.chunks, id)
+			delete(s.embeddings, id)
+		}
+	}
+}
+
+// copyChunk returns a copy of c that shares no symbols or metadata with it.
+func copyChunk(c *Chunk) *Chunk {
+	cp := *c
+	if c.Symbols != nil {
+		cp.Symbols = make([]*Symbol, len(c.Symbols))
+		for i, sym := range c.Symbols {
+			symCopy := *sym
+			cp.Symbols[i] = &symCopy
+		}
+	}
+	if c.Metadata != nil {
+		cp.Metadata = make(map[string]string, len(c.Metadata))
+		for k, v := range c.Metadata {
+			cp.Metadata[k] = v
+		}
+	}
+	return &cp
+}
+
+// SearchSymbols returns up to limit symbols whose name contains name,
+// case-insensitively.
+func (s *MemoryStore) SearchSymbols(_ context.Context, name string, limit int) ([]*Symbol, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	name = strings.ToLower(name)
+
+	var symbols []*Symbol
+	for _, c := range s.sortedChunks(func(*Chunk) bool { return true }, 0) {
+		for _, sym := range c.Symbols {
+			if !strings.Contains(strings.ToLower(sym.Name), name) {
+				continue
+			}
+			symbols = append(symbols, sym)
+			if len(symbols) == limit {
+				return symbols, nil
+			}
+		}
+	}
+	return symbols, nil
+}
+
+// GetSymbolsByFile returns the symbols of a file's chunks ordered by start
+// line, each symbol once. See FileSymbolLister.
+func (s *MemoryStore) GetSymbolsByFile(_ context.Context, fileID string) ([]*Symbol, error) {
+	var symbols []*Symbol
+	for _, c := range s.sortedChunks(func(c *Chunk) bool { return c.FileID == fileID }, 0) {
+		symbols = append(symbols, c.Symbols...)
+	}
+	return mergeFileSymbols(symbols), nil
+}
+
+// GetState retrieves a value from the state by key.
+// Returns empty string if key doesn't exist (not an error).
+func (s *MemoryStore) GetState(_ context.Context, key string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.state[key], nil
+}
+
+// SetState saves a key-value pair to the state.
+func (s *MemoryStore) SetState(_ context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state[key] = value
+	return nil
+}
+
+// SaveIndexCheckpoint saves the current indexing progress for resume
+// capability, under the same state keys as SQLiteStore.
+func (s *MemoryStore) SaveIndexCheckpoint(_ context.Context, stage string, total, embeddedCount int, embedderModel string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state[StateKeyCheckpointStage] = stage
+	s.state[StateKeyCheckpointTotal] = strconv.Itoa(total)
+	s.state[StateKeyCheckpointEmbedded] = strconv.Itoa(embeddedCount)
+	s.state[StateKeyCheckpointTimestamp] = time.Now().Format(time.RFC3339)
+	s.state[StateKeyCheckpointEmbedderModel] = embedderModel
+	return nil
+}
+
+// LoadIndexCheckpoint retrieves the current checkpoint state.
+// Returns nil if no checkpoint exists or indexing was completed.
+func (s *MemoryStore) LoadIndexCheckpoint(_ context.Context) (*IndexCheckpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stage := s.state[StateKeyCheckpointStage]
+	if stage == "" || stage == "complete" {
+		return nil, nil
+	}
+
+	total, _ := strconv.Atoi(s.state[StateKeyCheckpointTotal])
+	embedded, _ := strconv.Atoi(s.state[StateKeyCheckpointEmbedded])
+	timestamp, _ := time.Parse(time.RFC3339, s.state[StateKeyCheckpointTimestamp])
+
+	return &IndexCheckpoint{
+		Stage:         stage,
+		Total:         total,
+		EmbeddedCount: embedded,
+		Timestamp:     timestamp,
+		EmbedderModel: s.state[StateKeyCheckpointEmbedderModel],
+	}, nil
+}
+
+// ClearIndexCheckpoint removes all checkpoint data.
+func (s *MemoryStore) ClearIndexCheckpoint(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.state, StateKeyCheckpointStage)
+	delete(s.state, StateKeyCheckpointTotal)
+	delete(s.state, StateKeyCheckpointEmbedded)
+	delete(s.state, StateKeyCheckpointTimestamp)
+	delete(s.state, StateKeyCheckpointEmbedderModel)
+	return nil
+}
+
+// SaveChunkEmbeddings saves embeddings for stored chunks. Embeddings for
+// unknown chunk IDs are ignored, as with SQLiteStore.
+func (s *MemoryStore) SaveChunkEmbeddings(_ context.Context, chunkIDs []string, embeddings [][]float32, model string) error {
+	if len(chunkIDs) != len(embeddings) {
+		return fmt.Errorf("chunk IDs and embeddings length mismatch: %d vs %d", len(chunkIDs), len(embeddings))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, id := range chunkIDs {
+		if _, ok := s.chunks[id]; !ok {
+			continue
+		}
+		s.embeddings[id] = memoryEmbedding{
+			vector: append([]float32(nil), embeddings[i]...),
+			model:  model,
+		}
+	}
+	return nil
+}
+
+// GetAllEmbeddings returns every stored embedding keyed by chunk ID.
+func (s *MemoryStore) GetAllEmbeddings(_ context.Context) (map[string][]float32, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string][]float32, len(s.embeddings))
+	for id, emb := range s.embeddings {
+		if len(emb.vector) > 0 {
+			result[id] = append([]float32(nil), emb.vector...)
+		}
+	}
+	return result, nil
+}
+
+// GetChunkEmbeddings returns the stored embeddings of the given chunks that
+// were produced by model. Chunks without a matching embedding are omitted.
+func (s *MemoryStore) GetChunkEmbeddings(_ context.Context, ids []string, model string) (map[string][]float32, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string][]float32, len(ids))
+	for _, id := range ids {
+		if emb, ok := s.embeddings[id]; ok && emb.model == model && len(emb.vector) > 0 {
+			result[id] = append([]float32(nil), emb.vector...)
+		}
+	}
+	return result, nil
+}
+
+// GetEmbeddingStats returns the count of chunks with and without embeddings.
+func (s *MemoryStore) GetEmbeddingStats(_ context.Context) (withEmbedding, withoutEmbedding int, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	withEmbedding = len(s.embeddings)
+	return withEmbedding, len(s.chunks) - withEmbedding, nil
+}
+
+// GetEmbeddingModelCounts returns the number of embedded chunks per model.
+func (s *MemoryStore) GetEmbeddingModelCounts(_ context.Context) (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, emb := range s.embeddings {
+		counts[emb.model]++
+	}
+	return counts, nil
+}
+
+// Close discards all data. The store can still be used afterwards, empty.
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.projects = make(map[string]*Project)
+	s.files = make(map[string]*File)
+	s.chunks = make(map[string]*Chunk)
+	s.embeddings = make(map[string]memoryEmbedding)
+	s.state = make(map[string]string)
+	return nil
+}
+
+// Verify MemoryStore implements MetadataStore interface.
+var _ MetadataStore = (*MemoryStore)(nil)
+var _ ProjectDeleter = (*MemoryStore)(nil)
+var _ ProjectLister = (*MemoryStore)(nil)
+var _ ChunkEmbeddingGetter = (*MemoryStore)(nil)
+var _ EmbeddingModelCounter = (*MemoryStore)(nil)
+var _ ChunkIDLister = (*MemoryStore)(nil)
+var _ ContentHashFinder = (*MemoryStore)(nil)
+var _ ChunkHeaderGetter = (*MemoryStore)(nil)
+var _ FileSymbolLister = (*MemoryStore)(nil)
+var _ Pinger = (*MemoryStore)(nil)
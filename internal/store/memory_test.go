@@ -0,0 +1,223 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metadataStores returns a fresh instance of every MetadataStore
+// implementation, so behavior shared by all backends is tested once.
+func metadataStores(t *testing.T) map[string]MetadataStore {
+	t.Helper()
+	sqlite, _ := newTestStore(t)
+	return map[string]MetadataStore{
+		"sqlite": sqlite,
+		"memory": NewMemoryStore(),
+	}
+}
+
+func TestMetadataStores_FilesAndChunks(t *testing.T) {
+	for name, s := range metadataStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Given: a project with two files and three chunks
+			require.NoError(t, s.SaveProject(ctx, &Project{ID: "p", Name: "p", RootPath: "/p"}))
+			require.NoError(t, s.SaveFiles(ctx, []*File{
+				{ID: "f1", ProjectID: "p", Path: "src/a.go", ContentHash: "h1", ModTime: time.Unix(100, 0)},
+				{ID: "f2", ProjectID: "p", Path: "docs/b.md", ContentHash: "h2", ModTime: time.Unix(200, 0)},
+			}))
+			require.NoError(t, s.SaveChunks(ctx, []*Chunk{
+				{ID: "c2", FileID: "f1", FilePath: "src/a.go", Content: "func B() {}", StartLine: 10, EndLine: 12,
+					Symbols: []*Symbol{{Name: "B", Type: SymbolTypeFunction, StartLine: 10, EndLine: 12}}},
+				{ID: "c1", FileID: "f1", FilePath: "src/a.go", Content: "type A struct{}", StartLine: 1, EndLine: 3,
+					Symbols: []*Symbol{{Name: "A", Type: SymbolTypeType, StartLine: 1, EndLine: 3}}},
+				{ID: "c3", FileID: "f2", FilePath: "docs/b.md", Content: "# B", StartLine: 1, EndLine: 1},
+			}))
+
+			// When/Then: lookups agree across backends
+			missing, err := s.GetChunk(ctx, "nope")
+			require.NoError(t, err)
+			assert.Nil(t, missing)
+
+			byPath, err := s.GetChunksByPath(ctx, "src/a.go", 0)
+			require.NoError(t, err)
+			require.Len(t, byPath, 2)
+			assert.Equal(t, "c1", byPath[0].ID)
+			assert.Equal(t, "c2", byPath[1].ID)
+
+			got, err := s.GetChunks(ctx, []string{"c3", "nope", "c2"})
+			require.NoError(t, err)
+			require.Len(t, got, 2)
+			assert.Equal(t, "c3", got[0].ID)
+			assert.Equal(t, "B", got[1].Symbols[0].Name)
+
+			bySymbol, err := s.GetChunksBySymbol(ctx, "A", 5)
+			require.NoError(t, err)
+			require.Len(t, bySymbol, 1)
+			assert.Equal(t, "c1", bySymbol[0].ID)
+
+			paths, err := s.ListFilePathsUnder(ctx, "p", "src/")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"src/a.go"}, paths)
+
+			changed, err := s.GetChangedFiles(ctx, "p", time.Unix(150, 0))
+			require.NoError(t, err)
+			require.Len(t, changed, 1)
+			assert.Equal(t, "docs/b.md", changed[0].Path)
+
+			page, cursor, err := s.ListFiles(ctx, "p", "", 1)
+			require.NoError(t, err)
+			require.Len(t, page, 1)
+			assert.Equal(t, "docs/b.md", page[0].Path)
+			page, cursor, err = s.ListFiles(ctx, "p", cursor, 1)
+			require.NoError(t, err)
+			require.Len(t, page, 1)
+			assert.Equal(t, "src/a.go", page[0].Path)
+			assert.Empty(t, cursor)
+
+			// When: a file is deleted
+			require.NoError(t, s.DeleteFile(ctx, "f1"))
+
+			// Then: its chunks go with it and project stats are recounted
+			gone, err := s.GetChunksByFile(ctx, "f1")
+			require.NoError(t, err)
+			assert.Empty(t, gone)
+
+			require.NoError(t, s.RefreshProjectStats(ctx, "p"))
+			project, err := s.GetProject(ctx, "p")
+			require.NoError(t, err)
+			assert.Equal(t, 1, project.FileCount)
+			assert.Equal(t, 1, project.ChunkCount)
+		})
+	}
+}
+
+func TestMetadataStores_EmbeddingsAndCheckpoint(t *testing.T) {
+	for name, s := range metadataStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Given: two chunks, one of them embedded
+			require.NoError(t, s.SaveProject(ctx, &Project{ID: "p", Name: "p", RootPath: "/p"}))
+			require.NoError(t, s.SaveFiles(ctx, []*File{{ID: "f1", ProjectID: "p", Path: "a.go"}}))
+			require.NoError(t, s.SaveChunks(ctx, []*Chunk{
+				{ID: "c1", FileID: "f1", FilePath: "a.go", Content: "a", StartLine: 1, EndLine: 1},
+				{ID: "c2", FileID: "f1", FilePath: "a.go", Content: "b", StartLine: 2, EndLine: 2},
+			}))
+			require.NoError(t, s.SaveChunkEmbeddings(ctx, []string{"c1"}, [][]float32{{1, 2}}, "m"))
+
+			// Then: stats and lookups reflect the embedding
+			with, without, err := s.GetEmbeddingStats(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, with)
+			assert.Equal(t, 1, without)
+
+			all, err := s.GetAllEmbeddings(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]float32{"c1": {1, 2}}, all)
+
+			byModel, err := s.(ChunkEmbeddingGetter).GetChunkEmbeddings(ctx, []string{"c1", "c2"}, "other")
+			require.NoError(t, err)
+			assert.Empty(t, byModel)
+
+			// When: a checkpoint is saved, loaded and cleared
+			require.NoError(t, s.SaveIndexCheckpoint(ctx, "embedding", 2, 1, "m"))
+			cp, err := s.LoadIndexCheckpoint(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, cp)
+			assert.Equal(t, "embedding", cp.Stage)
+			assert.Equal(t, 2, cp.Total)
+			assert.Equal(t, 1, cp.EmbeddedCount)
+			assert.Equal(t, "m", cp.EmbedderModel)
+
+			require.NoError(t, s.ClearIndexCheckpoint(ctx))
+			cp, err = s.LoadIndexCheckpoint(ctx)
+			require.NoError(t, err)
+			assert.Nil(t, cp)
+
+			// Then: unknown state keys read as empty
+			value, err := s.GetState(ctx, "missing")
+			require.NoError(t, err)
+			assert.Empty(t, value)
+		})
+	}
+}
+
+func TestMetadataStores_GetSymbolsByFile(t *testing.T) {
+	for name, s := range metadataStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Given: a type split across two chunks that both record it,
+			// a method in a later chunk, and a symbol of another file
+			require.NoError(t, s.SaveProject(ctx, &Project{ID: "p", Name: "p", RootPath: "/p"}))
+			require.NoError(t, s.SaveFiles(ctx, []*File{
+				{ID: "f1", ProjectID: "p", Path: "server.go"},
+				{ID: "f2", ProjectID: "p", Path: "client.go"},
+			}))
+			require.NoError(t, s.SaveChunks(ctx, []*Chunk{
+				{ID: "c3", FileID: "f1", FilePath: "server.go", Content: "func (s *Server) Run() {}", StartLine: 90, EndLine: 95,
+					Symbols: []*Symbol{{Name: "Run", Type: SymbolTypeMethod, StartLine: 90, EndLine: 95, Signature: "func (s *Server) Run()"}}},
+				{ID: "c1", FileID: "f1", FilePath: "server.go", Content: "type Server struct {", StartLine: 1, EndLine: 40,
+					Symbols: []*Symbol{
+						{Name: "Server", Type: SymbolTypeType, StartLine: 10, EndLine: 40, DocComment: "Server serves requests."},
+						{Name: "New", Type: SymbolTypeFunction, StartLine: 2, EndLine: 8},
+					}},
+				{ID: "c2", FileID: "f1", FilePath: "server.go", Content: "\tfields...\n}", StartLine: 41, EndLine: 80,
+					Symbols: []*Symbol{{Name: "Server", Type: SymbolTypeType, StartLine: 10, EndLine: 80}}},
+				{ID: "c4", FileID: "f2", FilePath: "client.go", Content: "type Client struct{}", StartLine: 1, EndLine: 1,
+					Symbols: []*Symbol{{Name: "Client", Type: SymbolTypeType, StartLine: 1, EndLine: 1}}},
+			}))
+			lister, ok := s.(FileSymbolLister)
+			require.True(t, ok)
+
+			// When: listing the symbols of the first file
+			symbols, err := lister.GetSymbolsByFile(ctx, "f1")
+
+			// Then: each symbol appears once, in line order, the split type
+			// spanning both chunks
+			require.NoError(t, err)
+			require.Len(t, symbols, 3)
+			assert.Equal(t, "New", symbols[0].Name)
+			assert.Equal(t, "Server", symbols[1].Name)
+			assert.Equal(t, SymbolTypeType, symbols[1].Type)
+			assert.Equal(t, 10, symbols[1].StartLine)
+			assert.Equal(t, 80, symbols[1].EndLine)
+			assert.Equal(t, "Server serves requests.", symbols[1].DocComment)
+			assert.Equal(t, "Run", symbols[2].Name)
+			assert.Equal(t, "func (s *Server) Run()", symbols[2].Signature)
+
+			// And: a file without chunks has no symbols
+			symbols, err = lister.GetSymbolsByFile(ctx, "nope")
+			require.NoError(t, err)
+			assert.Empty(t, symbols)
+		})
+	}
+}
+
+func TestMemoryStore_ReturnsCopies(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	// Given: a saved chunk with a symbol
+	chunk := &Chunk{ID: "c1", FileID: "f1", FilePath: "a.go", Content: "x",
+		Symbols: []*Symbol{{Name: "X"}}}
+	require.NoError(t, s.SaveChunks(ctx, []*Chunk{chunk}))
+
+	// When: the caller modifies both its own and a returned chunk
+	chunk.Content = "changed"
+	got, err := s.GetChunk(ctx, "c1")
+	require.NoError(t, err)
+	got.Symbols[0].Name = "Y"
+
+	// Then: the stored chunk is unaffected
+	again, err := s.GetChunk(ctx, "c1")
+	require.NoError(t, err)
+	assert.Equal(t, "x", again.Content)
+	assert.Equal(t, "X", again.Symbols[0].Name)
+}
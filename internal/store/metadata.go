@@ -1,16 +1,22 @@
 package store
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,7 +26,8 @@ import (
 
 // SQLiteStore implements MetadataStore using SQLite.
 type SQLiteStore struct {
-	db *sql.DB
+	db              *sql.DB
+	compressContent bool
 }
 
 // StoreConfig configures the SQLite metadata store.
@@ -28,12 +35,51 @@ type StoreConfig struct {
 	// CacheSizeMB is the SQLite cache size in megabytes.
 	// Default is 64MB. Set to 0 to use default.
 	CacheSizeMB int
+
+	// CompressContent gzips chunk content, raw_content and context on write.
+	// Reads are transparent either way: each row records whether it is
+	// compressed, so stores can mix legacy and compressed rows.
+	CompressContent bool
+
+	// BusyTimeoutMS is how long a connection waits for a lock held by another
+	// connection or process before failing with "database is locked".
+	// Raise it when concurrent indexing and search contend for the database.
+	// Default is 5000 (5 seconds). Set to 0 to use default.
+	BusyTimeoutMS int
+
+	// SynchronousMode is the SQLite synchronous setting: OFF, NORMAL, FULL
+	// or EXTRA. NORMAL is safe with WAL (a power loss can roll back the last
+	// commits but not corrupt the database); FULL also makes every commit
+	// durable at the cost of an fsync per transaction. The journal mode is
+	// always WAL. Default is NORMAL. Set to "" to use default.
+	SynchronousMode string
 }
 
+// Defaults applied by NewSQLiteStoreWithConfig for zero StoreConfig fields.
+const (
+	DefaultBusyTimeoutMS   = 5000
+	DefaultSynchronousMode = "NORMAL"
+)
+
 // DefaultStoreConfig returns sensible defaults for the metadata store.
 func DefaultStoreConfig() StoreConfig {
 	return StoreConfig{
-		CacheSizeMB: 64, // 64MB default cache
+		CacheSizeMB:     64, // 64MB default cache
+		BusyTimeoutMS:   DefaultBusyTimeoutMS,
+		SynchronousMode: DefaultSynchronousMode,
+	}
+}
+
+// synchronousMode returns the validated, upper-cased synchronous mode.
+func (c StoreConfig) synchronousMode() (string, error) {
+	mode := strings.ToUpper(strings.TrimSpace(c.SynchronousMode))
+	switch mode {
+	case "":
+		return DefaultSynchronousMode, nil
+	case "OFF", "NORMAL", "FULL", "EXTRA":
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid synchronous mode %q (valid options: OFF, NORMAL, FULL, EXTRA)", c.SynchronousMode)
 	}
 }
 
@@ -48,6 +94,11 @@ func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
 // It creates the database file and directory if they don't exist,
 // and initializes the schema automatically.
 func NewSQLiteStoreWithConfig(dbPath string, cfg StoreConfig) (*SQLiteStore, error) {
+	synchronous, err := cfg.synchronousMode()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -56,7 +107,7 @@ func NewSQLiteStoreWithConfig(dbPath string, cfg StoreConfig) (*SQLiteStore, err
 
 	// Open database with WAL mode and other pragmas
 	// Note: _busy_timeout in DSN may be ignored by mattn/go-sqlite3, so we set it via PRAGMA below
-	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_synchronous=NORMAL&_foreign_keys=ON")
+	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_synchronous="+synchronous+"&_foreign_keys=ON")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -76,11 +127,17 @@ func NewSQLiteStoreWithConfig(dbPath string, cfg StoreConfig) (*SQLiteStore, err
 	// -N means N kilobytes
 	cacheSizeKB := cacheSizeMB * 1024
 
+	busyTimeoutMS := cfg.BusyTimeoutMS
+	if busyTimeoutMS <= 0 {
+		busyTimeoutMS = DefaultBusyTimeoutMS
+	}
+
 	// Set additional pragmas
 	// CRITICAL: busy_timeout MUST be set via PRAGMA, not DSN (DSN syntax may be ignored)
 	pragmas := []string{
-		"PRAGMA busy_timeout = 5000",                      // 5 second timeout for lock contention
-		fmt.Sprintf("PRAGMA cache_size=-%d", cacheSizeKB), // Negative = KB
+		fmt.Sprintf("PRAGMA busy_timeout = %d", busyTimeoutMS), // Wait out lock contention
+		fmt.Sprintf("PRAGMA cache_size=-%d", cacheSizeKB),      // Negative = KB
+		"PRAGMA synchronous = " + synchronous,
 	}
 	for _, pragma := range pragmas {
 		if _, err := db.Exec(pragma); err != nil {
@@ -101,7 +158,7 @@ func NewSQLiteStoreWithConfig(dbPath string, cfg StoreConfig) (*SQLiteStore, err
 			slog.String("action", "recommend running 'amanmcp index --force' to rebuild"))
 	}
 
-	store := &SQLiteStore{db: db}
+	store := &SQLiteStore{db: db, compressContent: cfg.CompressContent}
 
 	// Initialize schema
 	if err := store.initSchema(); err != nil {
@@ -187,6 +244,8 @@ func (s *SQLiteStore) initSchema() error {
 
 	CREATE INDEX IF NOT EXISTS idx_symbols_chunk ON symbols(chunk_id);
 	CREATE INDEX IF NOT EXISTS idx_symbols_name ON symbols(name);
+	-- Case-insensitive prefix lookups for symbol suggestions
+	CREATE INDEX IF NOT EXISTS idx_symbols_name_nocase ON symbols(name COLLATE NOCASE);
 
 	-- Key-value store for misc state
 	CREATE TABLE IF NOT EXISTS state (
@@ -211,6 +270,10 @@ func (s *SQLiteStore) initSchema() error {
 	return nil
 }
 
+// MetadataSchemaVersion is the metadata schema version produced by
+// runMigrations. Bump it with every new migration.
+const MetadataSchemaVersion = 8
+
 // runMigrations applies schema migrations based on current version.
 func (s *SQLiteStore) runMigrations() error {
 	// Get current schema version
@@ -285,6 +348,170 @@ func (s *SQLiteStore) runMigrations() error {
 		slog.Info("migration 3 complete: telemetry tables added")
 	}
 
+	// Migration 4: Per-row compression flag for chunk text columns
+	if version < 4 {
+		slog.Info("applying migration 4: add chunk compression flag")
+		stmts := []string{
+			"ALTER TABLE chunks ADD COLUMN compressed INTEGER NOT NULL DEFAULT 0",
+			"INSERT INTO schema_version (version) VALUES (4)",
+		}
+		for _, stmt := range stmts {
+			if _, err := s.db.Exec(stmt); err != nil {
+				// Ignore "duplicate column name" errors (column already exists)
+				if !strings.Contains(err.Error(), "duplicate column name") {
+					return fmt.Errorf("migration 4 failed: %w", err)
+				}
+			}
+		}
+		slog.Info("migration 4 complete: chunk compression flag added")
+	}
+
+	// Migration 5: Per-project language statistics
+	if version < 5 {
+		slog.Info("applying migration 5: add project language statistics")
+		stmts := []string{
+			`CREATE TABLE IF NOT EXISTS project_languages (
+				project_id TEXT NOT NULL,
+				language TEXT NOT NULL,
+				file_count INTEGER NOT NULL DEFAULT 0,
+				chunk_count INTEGER NOT NULL DEFAULT 0,
+				PRIMARY KEY (project_id, language),
+				FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+			)`,
+			"INSERT INTO schema_version (version) VALUES (5)",
+		}
+		for _, stmt := range stmts {
+			if _, err := s.db.Exec(stmt); err != nil {
+				return fmt.Errorf("migration 5 failed: %w", err)
+			}
+		}
+		slog.Info("migration 5 complete: project language statistics added")
+	}
+
+	// Migration 6: Enclosing scope breadcrumb per chunk
+	if version < 6 {
+		slog.Info("applying migration 6: add chunk enclosing symbol")
+		stmts := []string{
+			"ALTER TABLE chunks ADD COLUMN enclosing_symbol TEXT",
+			"INSERT INTO schema_version (version) VALUES (6)",
+		}
+		for _, stmt := range stmts {
+			if _, err := s.db.Exec(stmt); err != nil {
+				// Ignore "duplicate column name" errors (column already exists)
+				if !strings.Contains(err.Error(), "duplicate column name") {
+					return fmt.Errorf("migration 6 failed: %w", err)
+				}
+			}
+		}
+		slog.Info("migration 6 complete: chunk enclosing symbol added")
+	}
+
+	// Migration 7: Content hash lookups for duplicate detection
+	if version < 7 {
+		slog.Info("applying migration 7: add chunk content hash")
+		stmts := []string{
+			"ALTER TABLE chunks ADD COLUMN content_hash TEXT",
+			"CREATE INDEX IF NOT EXISTS idx_chunks_content_hash ON chunks(content_hash)",
+			"CREATE INDEX IF NOT EXISTS idx_files_content_hash ON files(project_id, content_hash)",
+		}
+		for _, stmt := range stmts {
+			if _, err := s.db.Exec(stmt); err != nil {
+				// Ignore "duplicate column name" errors (column already exists)
+				if !strings.Contains(err.Error(), "duplicate column name") {
+					return fmt.Errorf("migration 7 failed: %w", err)
+				}
+			}
+		}
+		if err := s.backfillChunkContentHashes(); err != nil {
+			return fmt.Errorf("migration 7 failed: %w", err)
+		}
+		if _, err := s.db.Exec("INSERT INTO schema_version (version) VALUES (7)"); err != nil {
+			return fmt.Errorf("migration 7 failed: %w", err)
+		}
+		slog.Info("migration 7 complete: chunk content hash added")
+	}
+
+	// Migration 8: Chunk fingerprints for near-duplicate detection
+	if version < 8 {
+		slog.Info("applying migration 8: add chunk fingerprints")
+		stmts := []string{
+			`CREATE TABLE IF NOT EXISTS chunk_fingerprints (
+				chunk_id TEXT PRIMARY KEY,
+				project_id TEXT NOT NULL,
+				file_path TEXT NOT NULL,
+				fingerprint INTEGER NOT NULL,
+				canonical_id TEXT,
+				FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+			)`,
+			"CREATE INDEX IF NOT EXISTS idx_chunk_fingerprints_file ON chunk_fingerprints(project_id, file_path)",
+			"CREATE INDEX IF NOT EXISTS idx_chunk_fingerprints_canonical ON chunk_fingerprints(canonical_id)",
+			"INSERT INTO schema_version (version) VALUES (8)",
+		}
+		for _, stmt := range stmts {
+			if _, err := s.db.Exec(stmt); err != nil {
+				return fmt.Errorf("migration 8 failed: %w", err)
+			}
+		}
+		slog.Info("migration 8 complete: chunk fingerprints added")
+	}
+
+	return nil
+}
+
+// backfillChunkContentHashes sets content_hash on chunks saved before
+// migration 7.
+func (s *SQLiteStore) backfillChunkContentHashes() error {
+	rows, err := s.db.Query(`SELECT id, content, compressed FROM chunks WHERE content_hash IS NULL`)
+	if err != nil {
+		return fmt.Errorf("query chunks: %w", err)
+	}
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var id, content string
+		var compressed bool
+		if err := rows.Scan(&id, &content, &compressed); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("scan row: %w", err)
+		}
+		if compressed {
+			if content, err = decompressChunkText(content); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to decompress content of chunk %s: %w", id, err)
+			}
+		}
+		hashes[id] = ChunkContentHash(content)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return fmt.Errorf("iterate rows: %w", err)
+	}
+	_ = rows.Close()
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	for id, hash := range hashes {
+		if _, err := tx.Exec(`UPDATE chunks SET content_hash = ? WHERE id = ?`, hash, id); err != nil {
+			return fmt.Errorf("failed to update chunk %s: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Snapshot writes a consistent copy of the database to path using VACUUM INTO.
+// The copy is compacted and safe to take while the store is in use.
+func (s *SQLiteStore) Snapshot(ctx context.Context, path string) error {
+	if _, err := s.db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to snapshot metadata: %w", err)
+	}
 	return nil
 }
 
@@ -302,6 +529,14 @@ func (s *SQLiteStore) DB() *sql.DB {
 	return s.db
 }
 
+// Ping verifies the database connection is alive.
+func (s *SQLiteStore) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
 // SaveProject saves or updates a project.
 func (s *SQLiteStore) SaveProject(ctx context.Context, project *Project) error {
 	query := `
@@ -358,6 +593,46 @@ func (s *SQLiteStore) GetProject(ctx context.Context, id string) (*Project, erro
 	return &p, nil
 }
 
+// ListProjects returns all projects in the store, ordered by ID.
+func (s *SQLiteStore) ListProjects(ctx context.Context) ([]*Project, error) {
+	query := `
+		SELECT id, name, root_path, project_type, indexed_at, chunk_count, file_count, schema_version
+		FROM projects ORDER BY id
+	`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var projects []*Project
+	for rows.Next() {
+		var p Project
+		var indexedAt sql.NullTime
+		var projectType, schemaVersion sql.NullString
+
+		if err := rows.Scan(&p.ID, &p.Name, &p.RootPath, &projectType, &indexedAt, &p.ChunkCount, &p.FileCount, &schemaVersion); err != nil {
+			return nil, fmt.Errorf("failed to scan project row: %w", err)
+		}
+
+		if indexedAt.Valid {
+			p.IndexedAt = indexedAt.Time
+		}
+		if projectType.Valid {
+			p.ProjectType = projectType.String
+		}
+		if schemaVersion.Valid {
+			p.Version = schemaVersion.String
+		}
+		projects = append(projects, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate projects: %w", err)
+	}
+
+	return projects, nil
+}
+
 // UpdateProjectStats updates the file and chunk counts for a project.
 func (s *SQLiteStore) UpdateProjectStats(ctx context.Context, id string, fileCount, chunkCount int) error {
 	query := `UPDATE projects SET file_count = ?, chunk_count = ?, indexed_at = ? WHERE id = ?`
@@ -385,10 +660,74 @@ func (s *SQLiteStore) RefreshProjectStats(ctx context.Context, id string) error
 		return fmt.Errorf("failed to count chunks: %w", err)
 	}
 
+	if err := s.refreshProjectLanguages(ctx, id); err != nil {
+		return err
+	}
+
 	// Update project stats with fresh counts
 	return s.UpdateProjectStats(ctx, id, fileCount, chunkCount)
 }
 
+// refreshProjectLanguages replaces the per-language file/chunk counts for a project.
+// Files without a detected language are grouped under "unknown".
+func (s *SQLiteStore) refreshProjectLanguages(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM project_languages WHERE project_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to clear project languages: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO project_languages (project_id, language, file_count, chunk_count)
+		SELECT f.project_id, COALESCE(NULLIF(f.language, ''), 'unknown'), COUNT(DISTINCT f.id), COUNT(c.id)
+		FROM files f
+		LEFT JOIN chunks c ON c.file_id = f.id
+		WHERE f.project_id = ?
+		GROUP BY f.project_id, COALESCE(NULLIF(f.language, ''), 'unknown')
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to compute project languages: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit project languages: %w", err)
+	}
+	return nil
+}
+
+// GetProjectLanguages returns per-language file and chunk counts for a project,
+// as computed by the last RefreshProjectStats. Results are ordered by file count
+// (descending), then chunk count, then language name.
+func (s *SQLiteStore) GetProjectLanguages(ctx context.Context, projectID string) ([]ProjectLanguage, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT language, file_count, chunk_count
+		FROM project_languages
+		WHERE project_id = ?
+		ORDER BY file_count DESC, chunk_count DESC, language
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query project languages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var langs []ProjectLanguage
+	for rows.Next() {
+		var l ProjectLanguage
+		if err := rows.Scan(&l.Language, &l.FileCount, &l.ChunkCount); err != nil {
+			return nil, fmt.Errorf("failed to scan project language: %w", err)
+		}
+		langs = append(langs, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate project languages: %w", err)
+	}
+	return langs, nil
+}
+
 // SaveFiles saves or updates multiple files in a single transaction.
 func (s *SQLiteStore) SaveFiles(ctx context.Context, files []*File) error {
 	if len(files) == 0 {
@@ -520,12 +859,302 @@ func (s *SQLiteStore) GetChangedFiles(ctx context.Context, projectID string, sin
 
 		files = append(files, &f)
 	}
-
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating files: %w", err)
+	}
+
+	return files, nil
+}
+
+// FindFilesByContentHash returns the files of a project whose content hash
+// equals hash, ordered by path.
+func (s *SQLiteStore) FindFilesByContentHash(ctx context.Context, projectID, hash string) ([]*File, error) {
+	query := `
+		SELECT id, project_id, path, size, mod_time, content_hash, language, content_type, indexed_at
+		FROM files WHERE project_id = ? AND content_hash = ?
+		ORDER BY path ASC
+	`
+	rows, err := s.db.QueryContext(ctx, query, projectID, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query files by content hash: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var files []*File
+	for rows.Next() {
+		var f File
+		var modTime, indexedAt sql.NullTime
+		var language, contentType sql.NullString
+
+		err := rows.Scan(&f.ID, &f.ProjectID, &f.Path, &f.Size, &modTime, &f.ContentHash, &language, &contentType, &indexedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan file row: %w", err)
+		}
+
+		if modTime.Valid {
+			f.ModTime = modTime.Time
+		}
+		if indexedAt.Valid {
+			f.IndexedAt = indexedAt.Time
+		}
+		if language.Valid {
+			f.Language = language.String
+		}
+		if contentType.Valid {
+			f.ContentType = contentType.String
+		}
+
+		files = append(files, &f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating files: %w", err)
+	}
+
+	return files, nil
+}
+
+// ChunkContentHash returns the hex SHA-256 of a chunk's content, as matched
+// by ContentHashFinder.FindChunksByContentHash.
+func ChunkContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// FindChunksByContentHash returns the chunks of a project whose
+// ChunkContentHash equals hash, ordered by file path and start line.
+func (s *SQLiteStore) FindChunksByContentHash(ctx context.Context, projectID, hash string) ([]*Chunk, error) {
+	query := `
+		SELECT c.id
+		FROM chunks c JOIN files f ON f.id = c.file_id
+		WHERE f.project_id = ? AND c.content_hash = ?
+		ORDER BY c.file_path ASC, c.start_line ASC
+	`
+	rows, err := s.db.QueryContext(ctx, query, projectID, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chunks by content hash: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan chunk id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate chunk ids: %w", err)
+	}
+
+	return s.GetChunks(ctx, ids)
+}
+
+// SaveChunkFingerprints upserts chunk fingerprints by chunk ID.
+func (s *SQLiteStore) SaveChunkFingerprints(ctx context.Context, projectID string, fingerprints []ChunkFingerprint) error {
+	if len(fingerprints) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO chunk_fingerprints (chunk_id, project_id, file_path, fingerprint, canonical_id)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(chunk_id) DO UPDATE SET
+			project_id = excluded.project_id,
+			file_path = excluded.file_path,
+			fingerprint = excluded.fingerprint,
+			canonical_id = excluded.canonical_id
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, fp := range fingerprints {
+		var canonicalID sql.NullString
+		if fp.CanonicalID != "" {
+			canonicalID = sql.NullString{String: fp.CanonicalID, Valid: true}
+		}
+		// SQLite integers are signed; the bits round-trip unchanged
+		if _, err := stmt.ExecContext(ctx, fp.ChunkID, projectID, fp.FilePath, int64(fp.Fingerprint), canonicalID); err != nil {
+			return fmt.Errorf("failed to save fingerprint of chunk %s: %w", fp.ChunkID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetChunkFingerprints returns all chunk fingerprints of a project, ordered
+// by chunk ID.
+func (s *SQLiteStore) GetChunkFingerprints(ctx context.Context, projectID string) ([]ChunkFingerprint, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT chunk_id, file_path, fingerprint, canonical_id
+		FROM chunk_fingerprints WHERE project_id = ?
+		ORDER BY chunk_id ASC
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chunk fingerprints: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var fingerprints []ChunkFingerprint
+	for rows.Next() {
+		var fp ChunkFingerprint
+		var value int64
+		var canonicalID sql.NullString
+		if err := rows.Scan(&fp.ChunkID, &fp.FilePath, &value, &canonicalID); err != nil {
+			return nil, fmt.Errorf("failed to scan chunk fingerprint: %w", err)
+		}
+		fp.Fingerprint = uint64(value)
+		fp.CanonicalID = canonicalID.String
+		fingerprints = append(fingerprints, fp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating chunk fingerprints: %w", err)
+	}
+	return fingerprints, nil
+}
+
+// DeleteChunkFingerprintsByFile deletes the chunk fingerprints of a file,
+// except canonical ones whose chunk ID is in keep, together with the
+// fingerprints of other files' near-duplicates of the deleted canonical
+// chunks. It returns the deleted canonical chunk IDs and the sorted paths of
+// the files whose near-duplicates were released.
+func (s *SQLiteStore) DeleteChunkFingerprintsByFile(ctx context.Context, projectID, filePath string, keep []string) ([]string, []string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT chunk_id FROM chunk_fingerprints
+		WHERE project_id = ? AND file_path = ? AND canonical_id IS NULL
+		ORDER BY chunk_id ASC
+	`, projectID, filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query canonical fingerprints: %w", err)
+	}
+	kept := make(map[string]bool, len(keep))
+	for _, id := range keep {
+		kept[id] = true
+	}
+	var canonicalIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, nil, fmt.Errorf("failed to scan chunk id: %w", err)
+		}
+		if !kept[id] {
+			canonicalIDs = append(canonicalIDs, id)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating canonical fingerprints: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM chunk_fingerprints
+		WHERE project_id = ? AND file_path = ? AND canonical_id IS NOT NULL
+	`, projectID, filePath); err != nil {
+		return nil, nil, fmt.Errorf("failed to delete near-duplicate fingerprints: %w", err)
+	}
+
+	released := make(map[string]bool)
+	for _, id := range canonicalIDs {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT DISTINCT file_path FROM chunk_fingerprints
+			WHERE project_id = ? AND canonical_id = ?
+		`, projectID, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to query released near-duplicates: %w", err)
+		}
+		for rows.Next() {
+			var path string
+			if err := rows.Scan(&path); err != nil {
+				_ = rows.Close()
+				return nil, nil, fmt.Errorf("failed to scan file path: %w", err)
+			}
+			released[path] = true
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, nil, fmt.Errorf("error iterating released near-duplicates: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM chunk_fingerprints
+			WHERE chunk_id = ? OR (project_id = ? AND canonical_id = ?)
+		`, id, projectID, id); err != nil {
+			return nil, nil, fmt.Errorf("failed to delete fingerprint of chunk %s: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return canonicalIDs, slices.Sorted(maps.Keys(released)), nil
+}
+
+// ClearChunkFingerprints deletes all chunk fingerprints of a project.
+func (s *SQLiteStore) ClearChunkFingerprints(ctx context.Context, projectID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM chunk_fingerprints WHERE project_id = ?`, projectID); err != nil {
+		return fmt.Errorf("failed to clear chunk fingerprints: %w", err)
+	}
+	return nil
+}
+
+// CountNearDuplicates returns the number of chunks skipped as near-duplicates
+// of each of canonicalIDs. IDs without near-duplicates are absent.
+func (s *SQLiteStore) CountNearDuplicates(ctx context.Context, canonicalIDs []string) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(canonicalIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders := make([]string, len(canonicalIDs))
+	args := make([]any, len(canonicalIDs))
+	for i, id := range canonicalIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT canonical_id, COUNT(*) FROM chunk_fingerprints
+		WHERE canonical_id IN (`+strings.Join(placeholders, ",")+`)
+		GROUP BY canonical_id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count near-duplicates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id string
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan near-duplicate count: %w", err)
+		}
+		counts[id] = count
+	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating files: %w", err)
+		return nil, fmt.Errorf("error iterating near-duplicate counts: %w", err)
 	}
-
-	return files, nil
+	return counts, nil
 }
 
 // ListFiles returns files for a project with cursor-based pagination.
@@ -626,6 +1255,88 @@ func (s *SQLiteStore) DeleteFilesByProject(ctx context.Context, projectID string
 	return nil
 }
 
+// DeleteProject deletes a project record. Due to ON DELETE CASCADE, this also
+// deletes its files, chunks, symbols, language stats and chunk fingerprints.
+// Deleting a project that does not exist is not an error.
+func (s *SQLiteStore) DeleteProject(ctx context.Context, projectID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM projects WHERE id = ?`, projectID); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	return nil
+}
+
+// Errors returned by RenameProject.
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrProjectExists   = errors.New("project already exists")
+)
+
+// RenameProject changes a project's ID from oldID to newID in one
+// transaction, moving its files (and with them their chunks and symbols),
+// language stats and chunk fingerprints to the new ID. Chunk IDs are unchanged, so the BM25 and
+// vector indexes, which are keyed by chunk ID, stay valid and nothing has to
+// be reindexed or re-embedded. File IDs keep their old values; indexers
+// resolve files by project and path, not by deriving their ID.
+//
+// It returns ErrProjectNotFound when oldID does not exist and
+// ErrProjectExists when newID is already taken.
+func (s *SQLiteStore) RenameProject(ctx context.Context, oldID, newID string) error {
+	if newID == "" {
+		return fmt.Errorf("new project ID must not be empty")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM projects WHERE id = ?`, oldID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to look up project: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, oldID)
+	}
+	if newID == oldID {
+		return nil
+	}
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM projects WHERE id = ?`, newID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to look up project: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s", ErrProjectExists, newID)
+	}
+
+	// Foreign keys do not cascade updates, so copy the project row, repoint
+	// the referencing rows, then drop the old row (which no longer owns any)
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO projects (id, name, root_path, project_type, indexed_at, chunk_count, file_count, schema_version)
+		SELECT ?, name, root_path, project_type, indexed_at, chunk_count, file_count, schema_version
+		FROM projects WHERE id = ?
+	`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to copy project: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE files SET project_id = ? WHERE project_id = ?`, newID, oldID); err != nil {
+		return fmt.Errorf("failed to move files: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE project_languages SET project_id = ? WHERE project_id = ?`, newID, oldID); err != nil {
+		return fmt.Errorf("failed to move project languages: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE chunk_fingerprints SET project_id = ? WHERE project_id = ?`, newID, oldID); err != nil {
+		return fmt.Errorf("failed to move chunk fingerprints: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM projects WHERE id = ?`, oldID); err != nil {
+		return fmt.Errorf("failed to delete old project: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit project rename: %w", err)
+	}
+	return nil
+}
+
 // GetFilePathsByProject returns all file paths for a project.
 // This is used for gitignore synchronization to determine which indexed files
 // should be removed when gitignore patterns change.
@@ -657,20 +1368,20 @@ func (s *SQLiteStore) GetFilePathsByProject(ctx context.Context, projectID strin
 // Used for differential gitignore reconciliation (BUG-028).
 // Only files with paths starting with dirPrefix/ are returned.
 func (s *SQLiteStore) ListFilePathsUnder(ctx context.Context, projectID, dirPrefix string) ([]string, error) {
-	// Normalize prefix: ensure no trailing slash, then add for LIKE match
+	// Normalize prefix: ensure no trailing slash
 	dirPrefix = strings.TrimSuffix(dirPrefix, "/")
 	if dirPrefix == "" {
 		// Empty prefix means all files - use GetFilePathsByProject instead
 		return s.GetFilePathsByProject(ctx, projectID)
 	}
 
-	// Use LIKE with escaped prefix + /% to match files under directory
-	// Note: SQLite LIKE is case-insensitive by default; paths should be case-sensitive
-	// We use || to concatenate in SQLite since Go's fmt.Sprintf might cause issues
-	query := `SELECT path FROM files WHERE project_id = ? AND (path LIKE ? OR path = ?) ORDER BY path`
-	likePattern := dirPrefix + "/%"
+	// Range scan [prefix/, prefix0) instead of LIKE, which is
+	// case-insensitive and treats _ and % in the prefix as wildcards; the
+	// results feed deletions. '0' is the byte after '/', so exactly the
+	// paths under the directory sort within the range.
+	query := `SELECT path FROM files WHERE project_id = ? AND (path = ? OR (path >= ? AND path < ?)) ORDER BY path`
 
-	rows, err := s.db.QueryContext(ctx, query, projectID, likePattern, dirPrefix)
+	rows, err := s.db.QueryContext(ctx, query, projectID, dirPrefix, dirPrefix+"/", dirPrefix+"0")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query files under %s: %w", dirPrefix, err)
 	}
@@ -769,18 +1480,21 @@ func (s *SQLiteStore) SaveChunks(ctx context.Context, chunks []*Chunk) error {
 
 	// Prepare chunk insert statement
 	chunkStmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO chunks (id, file_id, file_path, content, raw_content, context, content_type, language, start_line, end_line, metadata, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO chunks (id, file_id, file_path, content, content_hash, raw_content, context, compressed, content_type, language, start_line, end_line, enclosing_symbol, metadata, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			file_id = excluded.file_id,
 			file_path = excluded.file_path,
 			content = excluded.content,
+			content_hash = excluded.content_hash,
 			raw_content = excluded.raw_content,
 			context = excluded.context,
+			compressed = excluded.compressed,
 			content_type = excluded.content_type,
 			language = excluded.language,
 			start_line = excluded.start_line,
 			end_line = excluded.end_line,
+			enclosing_symbol = excluded.enclosing_symbol,
 			metadata = excluded.metadata,
 			updated_at = excluded.updated_at
 	`)
@@ -813,10 +1527,15 @@ func (s *SQLiteStore) SaveChunks(ctx context.Context, chunks []*Chunk) error {
 			metadataJSON, _ = json.Marshal(chunk.Metadata)
 		}
 
-		_, err := chunkStmt.ExecContext(ctx,
-			chunk.ID, chunk.FileID, chunk.FilePath, chunk.Content, chunk.RawContent, chunk.Context,
+		content, rawContent, chunkContext, compressed, err := s.chunkTextArgs(chunk)
+		if err != nil {
+			return fmt.Errorf("failed to compress chunk %s: %w", chunk.ID, err)
+		}
+
+		_, err = chunkStmt.ExecContext(ctx,
+			chunk.ID, chunk.FileID, chunk.FilePath, content, ChunkContentHash(chunk.Content), rawContent, chunkContext, compressed,
 			string(chunk.ContentType), chunk.Language, chunk.StartLine, chunk.EndLine,
-			string(metadataJSON), chunk.CreatedAt, chunk.UpdatedAt)
+			chunk.EnclosingSymbol, string(metadataJSON), chunk.CreatedAt, chunk.UpdatedAt)
 		if err != nil {
 			return fmt.Errorf("failed to save chunk %s: %w", chunk.ID, err)
 		}
@@ -845,16 +1564,17 @@ func (s *SQLiteStore) SaveChunks(ctx context.Context, chunks []*Chunk) error {
 // GetChunk retrieves a chunk by ID.
 func (s *SQLiteStore) GetChunk(ctx context.Context, id string) (*Chunk, error) {
 	query := `
-		SELECT id, file_id, file_path, content, raw_content, context, content_type, language, start_line, end_line, metadata, created_at, updated_at
+		SELECT id, file_id, file_path, content, raw_content, context, compressed, content_type, language, start_line, end_line, enclosing_symbol, metadata, created_at, updated_at
 		FROM chunks WHERE id = ?
 	`
 	row := s.db.QueryRowContext(ctx, query, id)
 
 	var c Chunk
-	var rawContent, chunkContext, contentType, language, metadataJSON sql.NullString
+	var rawContent, chunkContext, contentType, language, enclosingSymbol, metadataJSON sql.NullString
 	var createdAt, updatedAt sql.NullTime
+	var compressed bool
 
-	err := row.Scan(&c.ID, &c.FileID, &c.FilePath, &c.Content, &rawContent, &chunkContext, &contentType, &language, &c.StartLine, &c.EndLine, &metadataJSON, &createdAt, &updatedAt)
+	err := row.Scan(&c.ID, &c.FileID, &c.FilePath, &c.Content, &rawContent, &chunkContext, &compressed, &contentType, &language, &c.StartLine, &c.EndLine, &enclosingSymbol, &metadataJSON, &createdAt, &updatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -874,6 +1594,9 @@ func (s *SQLiteStore) GetChunk(ctx context.Context, id string) (*Chunk, error) {
 	if language.Valid {
 		c.Language = language.String
 	}
+	if enclosingSymbol.Valid {
+		c.EnclosingSymbol = enclosingSymbol.String
+	}
 	if createdAt.Valid {
 		c.CreatedAt = createdAt.Time
 	}
@@ -883,6 +1606,9 @@ func (s *SQLiteStore) GetChunk(ctx context.Context, id string) (*Chunk, error) {
 	if metadataJSON.Valid && metadataJSON.String != "" {
 		_ = json.Unmarshal([]byte(metadataJSON.String), &c.Metadata)
 	}
+	if err := decompressChunk(&c, compressed); err != nil {
+		return nil, err
+	}
 
 	// Load symbols
 	symbols, err := s.getSymbolsForChunk(ctx, id)
@@ -931,6 +1657,59 @@ func (s *SQLiteStore) getSymbolsForChunk(ctx context.Context, chunkID string) ([
 	return symbols, rows.Err()
 }
 
+// GetChunkHeaders retrieves the location and type of multiple chunks without
+// their text, symbols or metadata. Returns chunks in the same order as the
+// input IDs. Missing chunks are excluded.
+func (s *SQLiteStore) GetChunkHeaders(ctx context.Context, ids []string) ([]*Chunk, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := `
+		SELECT id, file_id, file_path, content_type, language, start_line, end_line
+		FROM chunks WHERE id IN (` + strings.Join(placeholders, ",") + `)
+	`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chunk headers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	byID := make(map[string]*Chunk, len(ids))
+	for rows.Next() {
+		var c Chunk
+		var contentType, language sql.NullString
+		if err := rows.Scan(&c.ID, &c.FileID, &c.FilePath, &contentType, &language, &c.StartLine, &c.EndLine); err != nil {
+			return nil, fmt.Errorf("failed to scan chunk header: %w", err)
+		}
+		if contentType.Valid {
+			c.ContentType = ContentType(contentType.String)
+		}
+		if language.Valid {
+			c.Language = language.String
+		}
+		byID[c.ID] = &c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating chunk headers: %w", err)
+	}
+
+	chunks := make([]*Chunk, 0, len(byID))
+	for _, id := range ids {
+		if c, ok := byID[id]; ok {
+			chunks = append(chunks, c)
+		}
+	}
+	return chunks, nil
+}
+
 // GetChunks retrieves multiple chunks by ID in a single query (batch operation).
 // This is more efficient than multiple GetChunk calls when fetching many chunks.
 // Returns chunks in the same order as the input IDs. Missing chunks are excluded.
@@ -948,7 +1727,7 @@ func (s *SQLiteStore) GetChunks(ctx context.Context, ids []string) ([]*Chunk, er
 	}
 
 	query := `
-		SELECT id, file_id, file_path, content, raw_content, context, content_type, language, start_line, end_line, metadata, created_at, updated_at
+		SELECT id, file_id, file_path, content, raw_content, context, compressed, content_type, language, start_line, end_line, enclosing_symbol, metadata, created_at, updated_at
 		FROM chunks WHERE id IN (` + strings.Join(placeholders, ",") + `)
 	`
 
@@ -964,10 +1743,11 @@ func (s *SQLiteStore) GetChunks(ctx context.Context, ids []string) ([]*Chunk, er
 
 	for rows.Next() {
 		var c Chunk
-		var rawContent, chunkContext, contentType, language, metadataJSON sql.NullString
+		var rawContent, chunkContext, contentType, language, enclosingSymbol, metadataJSON sql.NullString
 		var createdAt, updatedAt sql.NullTime
+		var compressed bool
 
-		err := rows.Scan(&c.ID, &c.FileID, &c.FilePath, &c.Content, &rawContent, &chunkContext, &contentType, &language, &c.StartLine, &c.EndLine, &metadataJSON, &createdAt, &updatedAt)
+		err := rows.Scan(&c.ID, &c.FileID, &c.FilePath, &c.Content, &rawContent, &chunkContext, &compressed, &contentType, &language, &c.StartLine, &c.EndLine, &enclosingSymbol, &metadataJSON, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan chunk: %w", err)
 		}
@@ -984,6 +1764,9 @@ func (s *SQLiteStore) GetChunks(ctx context.Context, ids []string) ([]*Chunk, er
 		if language.Valid {
 			c.Language = language.String
 		}
+		if enclosingSymbol.Valid {
+			c.EnclosingSymbol = enclosingSymbol.String
+		}
 		if createdAt.Valid {
 			c.CreatedAt = createdAt.Time
 		}
@@ -993,6 +1776,9 @@ func (s *SQLiteStore) GetChunks(ctx context.Context, ids []string) ([]*Chunk, er
 		if metadataJSON.Valid && metadataJSON.String != "" {
 			_ = json.Unmarshal([]byte(metadataJSON.String), &c.Metadata)
 		}
+		if err := decompressChunk(&c, compressed); err != nil {
+			return nil, err
+		}
 
 		chunkMap[c.ID] = &c
 		chunkIDs = append(chunkIDs, c.ID)
@@ -1164,7 +1950,7 @@ func (s *SQLiteStore) getSymbolsForChunks(ctx context.Context, chunkIDs []string
 // GetChunksByFile retrieves all chunks for a file.
 func (s *SQLiteStore) GetChunksByFile(ctx context.Context, fileID string) ([]*Chunk, error) {
 	query := `
-		SELECT id, file_id, file_path, content, raw_content, context, content_type, language, start_line, end_line, metadata, created_at, updated_at
+		SELECT id, file_id, file_path, content, raw_content, context, compressed, content_type, language, start_line, end_line, enclosing_symbol, metadata, created_at, updated_at
 		FROM chunks WHERE file_id = ?
 		ORDER BY start_line ASC
 	`
@@ -1177,10 +1963,11 @@ func (s *SQLiteStore) GetChunksByFile(ctx context.Context, fileID string) ([]*Ch
 	var chunks []*Chunk
 	for rows.Next() {
 		var c Chunk
-		var rawContent, chunkContext, contentType, language, metadataJSON sql.NullString
+		var rawContent, chunkContext, contentType, language, enclosingSymbol, metadataJSON sql.NullString
 		var createdAt, updatedAt sql.NullTime
+		var compressed bool
 
-		err := rows.Scan(&c.ID, &c.FileID, &c.FilePath, &c.Content, &rawContent, &chunkContext, &contentType, &language, &c.StartLine, &c.EndLine, &metadataJSON, &createdAt, &updatedAt)
+		err := rows.Scan(&c.ID, &c.FileID, &c.FilePath, &c.Content, &rawContent, &chunkContext, &compressed, &contentType, &language, &c.StartLine, &c.EndLine, &enclosingSymbol, &metadataJSON, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan chunk: %w", err)
 		}
@@ -1197,6 +1984,9 @@ func (s *SQLiteStore) GetChunksByFile(ctx context.Context, fileID string) ([]*Ch
 		if language.Valid {
 			c.Language = language.String
 		}
+		if enclosingSymbol.Valid {
+			c.EnclosingSymbol = enclosingSymbol.String
+		}
 		if createdAt.Valid {
 			c.CreatedAt = createdAt.Time
 		}
@@ -1206,6 +1996,9 @@ func (s *SQLiteStore) GetChunksByFile(ctx context.Context, fileID string) ([]*Ch
 		if metadataJSON.Valid && metadataJSON.String != "" {
 			_ = json.Unmarshal([]byte(metadataJSON.String), &c.Metadata)
 		}
+		if err := decompressChunk(&c, compressed); err != nil {
+			return nil, err
+		}
 
 		chunks = append(chunks, &c)
 	}
@@ -1230,7 +2023,7 @@ func (s *SQLiteStore) GetChunksByPath(ctx context.Context, filePath string, limi
 	}
 
 	query := `
-		SELECT id, file_id, file_path, content, raw_content, context, content_type, language, start_line, end_line, metadata, created_at, updated_at
+		SELECT id, file_id, file_path, content, raw_content, context, compressed, content_type, language, start_line, end_line, enclosing_symbol, metadata, created_at, updated_at
 		FROM chunks WHERE file_path = ?
 		ORDER BY start_line ASC
 	`
@@ -1249,10 +2042,11 @@ func (s *SQLiteStore) GetChunksByPath(ctx context.Context, filePath string, limi
 	var chunks []*Chunk
 	for rows.Next() {
 		var c Chunk
-		var rawContent, chunkContext, contentType, language, metadataJSON sql.NullString
+		var rawContent, chunkContext, contentType, language, enclosingSymbol, metadataJSON sql.NullString
 		var createdAt, updatedAt sql.NullTime
+		var compressed bool
 
-		err := rows.Scan(&c.ID, &c.FileID, &c.FilePath, &c.Content, &rawContent, &chunkContext, &contentType, &language, &c.StartLine, &c.EndLine, &metadataJSON, &createdAt, &updatedAt)
+		err := rows.Scan(&c.ID, &c.FileID, &c.FilePath, &c.Content, &rawContent, &chunkContext, &compressed, &contentType, &language, &c.StartLine, &c.EndLine, &enclosingSymbol, &metadataJSON, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan chunk: %w", err)
 		}
@@ -1269,6 +2063,9 @@ func (s *SQLiteStore) GetChunksByPath(ctx context.Context, filePath string, limi
 		if language.Valid {
 			c.Language = language.String
 		}
+		if enclosingSymbol.Valid {
+			c.EnclosingSymbol = enclosingSymbol.String
+		}
 		if createdAt.Valid {
 			c.CreatedAt = createdAt.Time
 		}
@@ -1278,6 +2075,9 @@ func (s *SQLiteStore) GetChunksByPath(ctx context.Context, filePath string, limi
 		if metadataJSON.Valid && metadataJSON.String != "" {
 			_ = json.Unmarshal([]byte(metadataJSON.String), &c.Metadata)
 		}
+		if err := decompressChunk(&c, compressed); err != nil {
+			return nil, err
+		}
 
 		chunks = append(chunks, &c)
 	}
@@ -1352,10 +2152,10 @@ func (s *SQLiteStore) SearchSymbols(ctx context.Context, name string, limit int)
 
 	query := `
 		SELECT name, type, start_line, end_line, signature, doc_comment
-		FROM symbols WHERE name LIKE ?
+		FROM symbols WHERE name LIKE ? ESCAPE '\'
 		LIMIT ?
 	`
-	rows, err := s.db.QueryContext(ctx, query, "%"+name+"%", limit)
+	rows, err := s.db.QueryContext(ctx, query, likeContainsPattern(name), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search symbols: %w", err)
 	}
@@ -1386,6 +2186,212 @@ func (s *SQLiteStore) SearchSymbols(ctx context.Context, name string, limit int)
 	return symbols, rows.Err()
 }
 
+// GetSymbolsByFile returns the symbols of a file's chunks ordered by start
+// line, each symbol once. See FileSymbolLister.
+func (s *SQLiteStore) GetSymbolsByFile(ctx context.Context, fileID string) ([]*Symbol, error) {
+	query := `
+		SELECT s.name, s.type, s.start_line, s.end_line, s.signature, s.doc_comment
+		FROM symbols s JOIN chunks c ON c.id = s.chunk_id
+		WHERE c.file_id = ?
+		ORDER BY s.start_line ASC, s.id ASC
+	`
+	rows, err := s.db.QueryContext(ctx, query, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query file symbols: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var symbols []*Symbol
+	for rows.Next() {
+		var sym Symbol
+		var symType string
+		var signature, docComment sql.NullString
+
+		if err := rows.Scan(&sym.Name, &symType, &sym.StartLine, &sym.EndLine, &signature, &docComment); err != nil {
+			return nil, fmt.Errorf("failed to scan symbol: %w", err)
+		}
+		sym.Type = SymbolType(symType)
+		sym.Signature = signature.String
+		sym.DocComment = docComment.String
+
+		symbols = append(symbols, &sym)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return mergeFileSymbols(symbols), nil
+}
+
+// mergeFileSymbols orders symbols by start line and merges those with the
+// same name, type and start line, which chunks sharing a symbol each record.
+// The merged symbol spans the widest end line and keeps the first non-empty
+// signature and doc comment. The input symbols are not modified.
+func mergeFileSymbols(symbols []*Symbol) []*Symbol {
+	type symbolKey struct {
+		name      string
+		symType   SymbolType
+		startLine int
+	}
+
+	merged := make([]*Symbol, 0, len(symbols))
+	byKey := make(map[symbolKey]*Symbol, len(symbols))
+	for _, sym := range symbols {
+		key := symbolKey{name: sym.Name, symType: sym.Type, startLine: sym.StartLine}
+		existing, ok := byKey[key]
+		if !ok {
+			cp := *sym
+			byKey[key] = &cp
+			merged = append(merged, &cp)
+			continue
+		}
+		existing.EndLine = max(existing.EndLine, sym.EndLine)
+		if existing.Signature == "" {
+			existing.Signature = sym.Signature
+		}
+		if existing.DocComment == "" {
+			existing.DocComment = sym.DocComment
+		}
+	}
+
+	slices.SortStableFunc(merged, func(a, b *Symbol) int {
+		if a.StartLine != b.StartLine {
+			return cmp.Compare(a.StartLine, b.StartLine)
+		}
+		return cmp.Compare(b.EndLine, a.EndLine)
+	})
+	return merged
+}
+
+// likeContainsPattern returns a LIKE pattern, for use with ESCAPE '\', that
+// matches values containing term literally: its %, _ and \ are escaped.
+func likeContainsPattern(term string) string {
+	return "%" + likeEscaper.Replace(term) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Weights of a query term matching a symbol field in SearchSymbolsAllFields.
+const (
+	symbolNameMatchWeight      = 4
+	symbolSignatureMatchWeight = 2
+	symbolDocMatchWeight       = 1
+)
+
+// SearchSymbolsAllFields searches symbols by name, signature and doc comment.
+// See SymbolFieldSearcher.
+func (s *SQLiteStore) SearchSymbolsAllFields(ctx context.Context, query string, limit int) ([]*Symbol, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return []*Symbol{}, nil
+	}
+
+	// LIKE is case-insensitive for ASCII; each term adds the weights of the
+	// fields it occurs in
+	scoreParts := make([]string, 0, len(terms))
+	args := make([]any, 0, len(terms)*3+1)
+	for _, term := range terms {
+		scoreParts = append(scoreParts, fmt.Sprintf(
+			`(name LIKE ? ESCAPE '\') * %d + (COALESCE(signature, '') LIKE ? ESCAPE '\') * %d + (COALESCE(doc_comment, '') LIKE ? ESCAPE '\') * %d`,
+			symbolNameMatchWeight, symbolSignatureMatchWeight, symbolDocMatchWeight))
+		pattern := likeContainsPattern(term)
+		args = append(args, pattern, pattern, pattern)
+	}
+	args = append(args, limit)
+
+	sqlQuery := `
+		SELECT name, type, start_line, end_line, signature, doc_comment FROM (
+			SELECT name, type, start_line, end_line, signature, doc_comment,
+				` + strings.Join(scoreParts, " + ") + ` AS score
+			FROM symbols
+		)
+		WHERE score > 0
+		ORDER BY score DESC, length(name) ASC, name ASC
+		LIMIT ?
+	`
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search symbols: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	symbols := make([]*Symbol, 0, limit)
+	for rows.Next() {
+		var sym Symbol
+		var symType string
+		var signature, docComment sql.NullString
+
+		if err := rows.Scan(&sym.Name, &symType, &sym.StartLine, &sym.EndLine, &signature, &docComment); err != nil {
+			return nil, fmt.Errorf("failed to scan symbol: %w", err)
+		}
+		sym.Type = SymbolType(symType)
+		sym.Signature = signature.String
+		sym.DocComment = docComment.String
+		symbols = append(symbols, &sym)
+	}
+
+	return symbols, rows.Err()
+}
+
+// SuggestSymbols returns distinct symbol names starting with prefix, most
+// frequent first, for query autocompletion. Unlike SearchSymbols the match is
+// prefix-anchored, so it is served by the idx_symbols_name range scan.
+// Matching is case-sensitive; see SuggestSymbolsIgnoreCase.
+func (s *SQLiteStore) SuggestSymbols(ctx context.Context, prefix string, limit int) ([]string, error) {
+	return s.suggestSymbols(ctx, prefix, limit, false)
+}
+
+// SuggestSymbolsIgnoreCase is SuggestSymbols with ASCII case-insensitive
+// prefix matching, served by idx_symbols_name_nocase.
+func (s *SQLiteStore) SuggestSymbolsIgnoreCase(ctx context.Context, prefix string, limit int) ([]string, error) {
+	return s.suggestSymbols(ctx, prefix, limit, true)
+}
+
+func (s *SQLiteStore) suggestSymbols(ctx context.Context, prefix string, limit int, ignoreCase bool) ([]string, error) {
+	if prefix == "" {
+		return []string{}, nil
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// Range scan [prefix, prefix+0xFF) instead of LIKE so the index is used
+	// regardless of case_sensitive_like. 0xFF never occurs in UTF-8, so every
+	// name starting with prefix sorts below the upper bound.
+	upper := prefix + "\xff"
+	collate := ""
+	if ignoreCase {
+		collate = " COLLATE NOCASE"
+	}
+
+	query := `
+		SELECT name FROM symbols
+		WHERE name` + collate + ` >= ? AND name` + collate + ` < ?
+		GROUP BY name
+		ORDER BY COUNT(*) DESC, name ASC
+		LIMIT ?
+	`
+	rows, err := s.db.QueryContext(ctx, query, prefix, upper, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest symbols: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	names := make([]string, 0, limit)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan symbol name: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
 // GetState retrieves a value from the state table by key.
 // Returns empty string if key doesn't exist (not an error).
 func (s *SQLiteStore) GetState(ctx context.Context, key string) (string, error) {
@@ -1591,6 +2597,38 @@ func (s *SQLiteStore) SaveChunkEmbeddings(ctx context.Context, chunkIDs []string
 	return nil
 }
 
+// SaveChunkEmbeddingsByModel persists embeddings produced by several models,
+// models[i] being the one that produced embeddings[i], in one
+// SaveChunkEmbeddings call per model.
+func SaveChunkEmbeddingsByModel(ctx context.Context, s MetadataStore, chunkIDs []string, embeddings [][]float32, models []string) error {
+	if len(models) != len(chunkIDs) {
+		return fmt.Errorf("chunk IDs and models length mismatch: %d vs %d", len(chunkIDs), len(models))
+	}
+
+	var order []string
+	byModel := make(map[string][]int)
+	for i, model := range models {
+		if _, ok := byModel[model]; !ok {
+			order = append(order, model)
+		}
+		byModel[model] = append(byModel[model], i)
+	}
+
+	for _, model := range order {
+		indexes := byModel[model]
+		modelIDs := make([]string, len(indexes))
+		modelEmbeddings := make([][]float32, len(indexes))
+		for j, i := range indexes {
+			modelIDs[j] = chunkIDs[i]
+			modelEmbeddings[j] = embeddings[i]
+		}
+		if err := s.SaveChunkEmbeddings(ctx, modelIDs, modelEmbeddings, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetAllEmbeddings retrieves all chunk IDs and their embeddings for compaction.
 // Returns a map of chunk ID to embedding vector.
 // Chunks without embeddings (NULL) are skipped.
@@ -1625,6 +2663,81 @@ func (s *SQLiteStore) GetAllEmbeddings(ctx context.Context) (map[string][]float3
 	return result, nil
 }
 
+// chunkEmbeddingsQueryBatch is how many chunk IDs GetChunkEmbeddings binds
+// per query, staying well below SQLite's limit on bound variables.
+const chunkEmbeddingsQueryBatch = 500
+
+// GetChunkEmbeddings retrieves the stored embeddings of the given chunks that
+// were produced by model. Chunks without a matching embedding are omitted.
+func (s *SQLiteStore) GetChunkEmbeddings(ctx context.Context, ids []string, model string) (map[string][]float32, error) {
+	result := make(map[string][]float32, len(ids))
+	for batch := range slices.Chunk(ids, chunkEmbeddingsQueryBatch) {
+		if err := s.getChunkEmbeddingsBatch(ctx, batch, model, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// getChunkEmbeddingsBatch adds the embeddings of one batch of
+// GetChunkEmbeddings to result.
+func (s *SQLiteStore) getChunkEmbeddingsBatch(ctx context.Context, ids []string, model string, result map[string][]float32) error {
+	placeholders := make([]string, len(ids))
+	args := make([]any, 0, len(ids)+1)
+	args = append(args, model)
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := `
+		SELECT id, embedding FROM chunks
+		WHERE embedding IS NOT NULL AND embedding_model = ? AND id IN (` + strings.Join(placeholders, ",") + `)
+	`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query chunk embeddings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id string
+		var embBytes []byte
+		if err := rows.Scan(&id, &embBytes); err != nil {
+			return fmt.Errorf("scan row: %w", err)
+		}
+		if embedding := bytesToEmbedding(embBytes); embedding != nil {
+			result[id] = embedding
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate rows: %w", err)
+	}
+	return nil
+}
+
+// ListChunkIDs returns the IDs of all stored chunks in ascending order.
+func (s *SQLiteStore) ListChunkIDs(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id FROM chunks ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("query chunk ids: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+	return ids, nil
+}
+
 // GetEmbeddingStats returns the count of chunks with and without embeddings.
 func (s *SQLiteStore) GetEmbeddingStats(ctx context.Context) (withEmbedding, withoutEmbedding int, err error) {
 	query := `
@@ -1640,5 +2753,44 @@ func (s *SQLiteStore) GetEmbeddingStats(ctx context.Context) (withEmbedding, wit
 	return withEmbedding, withoutEmbedding, nil
 }
 
+// GetEmbeddingModelCounts returns the number of embedded chunks per model.
+func (s *SQLiteStore) GetEmbeddingModelCounts(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT COALESCE(embedding_model, ''), COUNT(*) FROM chunks
+		WHERE embedding IS NOT NULL
+		GROUP BY COALESCE(embedding_model, '')
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query embedding models: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var model string
+		var count int
+		if err := rows.Scan(&model, &count); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		counts[model] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+	return counts, nil
+}
+
 // Verify SQLiteStore implements MetadataStore interface.
 var _ MetadataStore = (*SQLiteStore)(nil)
+var _ Snapshotter = (*SQLiteStore)(nil)
+var _ ProjectDeleter = (*SQLiteStore)(nil)
+var _ ProjectLister = (*SQLiteStore)(nil)
+var _ ChunkEmbeddingGetter = (*SQLiteStore)(nil)
+var _ EmbeddingModelCounter = (*SQLiteStore)(nil)
+var _ ChunkIDLister = (*SQLiteStore)(nil)
+var _ ContentHashFinder = (*SQLiteStore)(nil)
+var _ NearDuplicateStore = (*SQLiteStore)(nil)
+var _ ChunkHeaderGetter = (*SQLiteStore)(nil)
+var _ SymbolFieldSearcher = (*SQLiteStore)(nil)
+var _ FileSymbolLister = (*SQLiteStore)(nil)
+var _ Pinger = (*SQLiteStore)(nil)
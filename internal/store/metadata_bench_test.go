@@ -152,6 +152,38 @@ func BenchmarkSQLiteStore_GetChunks_Batch(b *testing.B) {
 	}
 }
 
+// BenchmarkSQLiteStore_GetChunks_Compressed measures the read-path overhead of
+// StoreConfig.CompressContent on the batch path used by search enrichment.
+// Compare with BenchmarkSQLiteStore_GetChunks_Batch.
+func BenchmarkSQLiteStore_GetChunks_Compressed(b *testing.B) {
+	for _, compress := range []bool{false, true} {
+		b.Run(fmt.Sprintf("compress_%t", compress), func(b *testing.B) {
+			cfg := DefaultStoreConfig()
+			cfg.CompressContent = compress
+			store, cleanup := setupBenchmarkMetadataStoreWithConfig(b, 1000, cfg)
+			defer cleanup()
+
+			ctx := context.Background()
+			ids := make([]string, 50)
+			for i := range ids {
+				ids[i] = fmt.Sprintf("chunk-%d", i)
+			}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_, err := store.GetChunks(ctx, ids)
+				if err != nil {
+					b.Fatalf("GetChunks failed: %v", err)
+				}
+			}
+
+			b.ReportMetric(float64(len(ids)*b.N)/b.Elapsed().Seconds(), "chunks/sec")
+		})
+	}
+}
+
 // BenchmarkSQLiteStore_SearchSymbols benchmarks symbol search.
 func BenchmarkSQLiteStore_SearchSymbols(b *testing.B) {
 	store, cleanup := setupBenchmarkMetadataStore(b, 1000)
@@ -221,6 +253,12 @@ func BenchmarkSQLiteStore_Concurrent(b *testing.B) {
 // setupBenchmarkMetadataStore creates a SQLite store with pre-populated data.
 func setupBenchmarkMetadataStore(b *testing.B, numChunks int) (*SQLiteStore, func()) {
 	b.Helper()
+	return setupBenchmarkMetadataStoreWithConfig(b, numChunks, DefaultStoreConfig())
+}
+
+// setupBenchmarkMetadataStoreWithConfig is setupBenchmarkMetadataStore with a custom StoreConfig.
+func setupBenchmarkMetadataStoreWithConfig(b *testing.B, numChunks int, cfg StoreConfig) (*SQLiteStore, func()) {
+	b.Helper()
 
 	// Create temporary directory
 	tmpDir, err := os.MkdirTemp("", "bench-metadata-*")
@@ -229,7 +267,7 @@ func setupBenchmarkMetadataStore(b *testing.B, numChunks int) (*SQLiteStore, fun
 	}
 
 	dbPath := filepath.Join(tmpDir, "metadata.db")
-	store, err := NewSQLiteStore(dbPath)
+	store, err := NewSQLiteStoreWithConfig(dbPath, cfg)
 	if err != nil {
 		_ = os.RemoveAll(tmpDir)
 		b.Fatalf("failed to create store: %v", err)
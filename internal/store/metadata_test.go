@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -124,6 +125,56 @@ func TestSQLiteStore_RefreshProjectStats(t *testing.T) {
 	assert.False(t, updated.IndexedAt.IsZero(), "indexed_at should be set")
 }
 
+func TestSQLiteStore_GetProjectLanguages(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: a project with files in several languages
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj-lang", Name: "lang-test", RootPath: "/path/to/lang"}))
+	require.NoError(t, store.SaveFiles(ctx, []*File{
+		{ID: "file-1", ProjectID: "proj-lang", Path: "a.go", Language: "go"},
+		{ID: "file-2", ProjectID: "proj-lang", Path: "b.go", Language: "go"},
+		{ID: "file-3", ProjectID: "proj-lang", Path: "README.md", Language: "markdown"},
+		{ID: "file-4", ProjectID: "proj-lang", Path: "LICENSE"},
+	}))
+	require.NoError(t, store.SaveChunks(ctx, []*Chunk{
+		{ID: "chunk-1", FileID: "file-1", Content: "content 1"},
+		{ID: "chunk-2", FileID: "file-1", Content: "content 2"},
+		{ID: "chunk-3", FileID: "file-2", Content: "content 3"},
+		{ID: "chunk-4", FileID: "file-3", Content: "content 4"},
+		{ID: "chunk-5", FileID: "file-3", Content: "content 5"},
+		{ID: "chunk-6", FileID: "file-3", Content: "content 6"},
+	}))
+
+	// When: refreshing project stats
+	require.NoError(t, store.RefreshProjectStats(ctx, "proj-lang"))
+
+	// Then: per-language counts are available, most files first
+	langs, err := store.GetProjectLanguages(ctx, "proj-lang")
+	require.NoError(t, err)
+	assert.Equal(t, []ProjectLanguage{
+		{Language: "go", FileCount: 2, ChunkCount: 3},
+		{Language: "markdown", FileCount: 1, ChunkCount: 3},
+		{Language: "unknown", FileCount: 1, ChunkCount: 0},
+	}, langs)
+
+	// When: a language disappears and stats are refreshed again
+	require.NoError(t, store.DeleteFile(ctx, "file-3"))
+	require.NoError(t, store.RefreshProjectStats(ctx, "proj-lang"))
+
+	// Then: stale languages are removed
+	langs, err = store.GetProjectLanguages(ctx, "proj-lang")
+	require.NoError(t, err)
+	require.Len(t, langs, 2)
+	assert.Equal(t, "go", langs[0].Language)
+	assert.Equal(t, "unknown", langs[1].Language)
+
+	// Then: unknown projects have no language stats
+	langs, err = store.GetProjectLanguages(ctx, "proj-missing")
+	require.NoError(t, err)
+	assert.Empty(t, langs)
+}
+
 // TS02: File Tracking
 func TestSQLiteStore_FileTracking(t *testing.T) {
 	store, _ := newTestStore(t)
@@ -414,6 +465,341 @@ func TestSQLiteStore_SymbolSearch(t *testing.T) {
 	assert.Contains(t, names, "HandleLogout")
 }
 
+func TestSQLiteStore_SearchSymbolsAllFields(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: symbols whose doc comments and signatures describe them
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj-fld", Name: "fields", RootPath: "/fields"}))
+	require.NoError(t, store.SaveFiles(ctx, []*File{{
+		ID: "file-fld", ProjectID: "proj-fld", Path: "config.go", ModTime: time.Now(), IndexedAt: time.Now(),
+	}}))
+	symbols := []*Symbol{
+		{Name: "Load", Type: SymbolTypeFunction, Signature: "func Load(path string) (*Config, error)", DocComment: "Load reads the YAML configuration file."},
+		{Name: "ParseConfig", Type: SymbolTypeFunction, Signature: "func ParseConfig(data []byte) (*Config, error)"},
+		{Name: "Merge", Type: SymbolTypeFunction, Signature: "func (c *Config) Merge(other *Config)", DocComment: "Merge overlays other onto c."},
+		{Name: "Watch", Type: SymbolTypeFunction, Signature: "func Watch()", DocComment: "Watch polls for changes."},
+	}
+	chunks := make([]*Chunk, len(symbols))
+	for i, sym := range symbols {
+		sym.StartLine, sym.EndLine = i*10+1, i*10+5
+		chunks[i] = &Chunk{
+			ID:          fmt.Sprintf("chunk-fld-%d", i),
+			FileID:      "file-fld",
+			FilePath:    "config.go",
+			Content:     sym.Signature,
+			ContentType: ContentTypeCode,
+			Language:    "go",
+			StartLine:   sym.StartLine,
+			EndLine:     sym.EndLine,
+			Symbols:     []*Symbol{sym},
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+	}
+	require.NoError(t, store.SaveChunks(ctx, chunks))
+
+	// When: searching by a description
+	results, err := store.SearchSymbolsAllFields(ctx, "yaml file", 10)
+
+	// Then: the symbol is found by its doc comment
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Load", results[0].Name)
+	assert.Equal(t, "Load reads the YAML configuration file.", results[0].DocComment)
+
+	// When: a term matches a name, signatures and a doc comment
+	results, err = store.SearchSymbolsAllFields(ctx, "config", 10)
+
+	// Then: the name match ranks first, then signature matches, and
+	// unrelated symbols are left out
+	require.NoError(t, err)
+	names := make([]string, len(results))
+	for i, s := range results {
+		names[i] = s.Name
+	}
+	assert.Equal(t, []string{"ParseConfig", "Load", "Merge"}, names)
+
+	// And: an empty query finds nothing
+	results, err = store.SearchSymbolsAllFields(ctx, "  ", 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestSQLiteStore_SearchSymbols_MatchesWildcardsLiterally(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: symbols that only match "get_user" if _ is a wildcard
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj-esc", Name: "escape", RootPath: "/escape"}))
+	require.NoError(t, store.SaveFiles(ctx, []*File{{
+		ID: "file-esc", ProjectID: "proj-esc", Path: "user.go", ModTime: time.Now(), IndexedAt: time.Now(),
+	}}))
+	symbols := []*Symbol{
+		{Name: "get_user", Type: SymbolTypeFunction, Signature: "func get_user()"},
+		{Name: "getXuser", Type: SymbolTypeFunction, Signature: "func getXuser()"},
+	}
+	chunks := make([]*Chunk, len(symbols))
+	for i, sym := range symbols {
+		sym.StartLine, sym.EndLine = i*10+1, i*10+5
+		chunks[i] = &Chunk{
+			ID:          fmt.Sprintf("chunk-esc-%d", i),
+			FileID:      "file-esc",
+			FilePath:    "user.go",
+			Content:     sym.Signature,
+			ContentType: ContentTypeCode,
+			Language:    "go",
+			StartLine:   sym.StartLine,
+			EndLine:     sym.EndLine,
+			Symbols:     []*Symbol{sym},
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+	}
+	require.NoError(t, store.SaveChunks(ctx, chunks))
+
+	// When: searching by name and across all fields
+	byName, err := store.SearchSymbols(ctx, "get_user", 10)
+	require.NoError(t, err)
+	allFields, err := store.SearchSymbolsAllFields(ctx, "get_user", 10)
+	require.NoError(t, err)
+
+	// Then: only the literal match is found
+	require.Len(t, byName, 1)
+	assert.Equal(t, "get_user", byName[0].Name)
+	require.Len(t, allFields, 1)
+	assert.Equal(t, "get_user", allFields[0].Name)
+
+	// And: % matches nothing unless it occurs literally
+	allFields, err = store.SearchSymbolsAllFields(ctx, "%", 10)
+	require.NoError(t, err)
+	assert.Empty(t, allFields)
+}
+
+func TestSQLiteStore_SuggestSymbols(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: symbols with shared prefixes and repeated names
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj-sug", Name: "suggest", RootPath: "/suggest"}))
+	require.NoError(t, store.SaveFiles(ctx, []*File{{
+		ID: "file-sug", ProjectID: "proj-sug", Path: "search.go", ModTime: time.Now(), IndexedAt: time.Now(),
+	}}))
+	names := []string{"SearchEngine", "SearchOptions", "SearchEngine", "searchHelper", "Searcher", "Index"}
+	chunks := make([]*Chunk, len(names))
+	for i, name := range names {
+		chunks[i] = &Chunk{
+			ID:          fmt.Sprintf("chunk-sug-%d", i),
+			FileID:      "file-sug",
+			FilePath:    "search.go",
+			Content:     name,
+			ContentType: ContentTypeCode,
+			Language:    "go",
+			StartLine:   i + 1,
+			EndLine:     i + 1,
+			Symbols:     []*Symbol{{Name: name, Type: SymbolTypeFunction, StartLine: i + 1, EndLine: i + 1}},
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+	}
+	require.NoError(t, store.SaveChunks(ctx, chunks))
+
+	// When: suggesting case-sensitively
+	got, err := store.SuggestSymbols(ctx, "Search", 10)
+
+	// Then: distinct prefix matches, most frequent first, then by name
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SearchEngine", "SearchOptions", "Searcher"}, got)
+
+	// When: suggesting case-insensitively with a limit
+	got, err = store.SuggestSymbolsIgnoreCase(ctx, "search", 2)
+
+	// Then: differently-cased names match too and the limit applies
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SearchEngine", "SearchOptions"}, got)
+
+	got, err = store.SuggestSymbolsIgnoreCase(ctx, "SEARCHH", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"searchHelper"}, got)
+
+	// And: an empty prefix suggests nothing
+	got, err = store.SuggestSymbols(ctx, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestSQLiteStore_CompressContent_RoundTripAndLegacyRows(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "metadata.db")
+	longText := strings.Repeat("func handler(w http.ResponseWriter, r *http.Request) {}\n", 20)
+
+	newChunk := func(id string) *Chunk {
+		return &Chunk{
+			ID:          id,
+			FileID:      "file-cmp",
+			FilePath:    "handler.go",
+			Content:     longText,
+			RawContent:  longText + "// raw",
+			Context:     "package handler",
+			ContentType: ContentTypeCode,
+			Language:    "go",
+			StartLine:   1,
+			EndLine:     20,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+	}
+
+	// Given: a legacy row written without compression
+	legacy, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, legacy.SaveProject(ctx, &Project{ID: "proj-cmp", Name: "cmp", RootPath: "/cmp"}))
+	require.NoError(t, legacy.SaveFiles(ctx, []*File{{ID: "file-cmp", ProjectID: "proj-cmp", Path: "handler.go", ModTime: time.Now(), IndexedAt: time.Now()}}))
+	require.NoError(t, legacy.SaveChunks(ctx, []*Chunk{newChunk("legacy")}))
+	require.NoError(t, legacy.Close())
+
+	// When: the store is reopened with compression and writes a new row
+	cfg := DefaultStoreConfig()
+	cfg.CompressContent = true
+	store, err := NewSQLiteStoreWithConfig(dbPath, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	require.NoError(t, store.SaveChunks(ctx, []*Chunk{newChunk("compressed")}))
+
+	// Then: the new row is stored compressed and smaller than the legacy row
+	var legacySize, compressedSize int
+	var compressedFlag bool
+	require.NoError(t, store.db.QueryRowContext(ctx, `SELECT length(content) FROM chunks WHERE id = 'legacy'`).Scan(&legacySize))
+	require.NoError(t, store.db.QueryRowContext(ctx, `SELECT length(content), compressed FROM chunks WHERE id = 'compressed'`).Scan(&compressedSize, &compressedFlag))
+	assert.True(t, compressedFlag)
+	assert.Less(t, compressedSize, legacySize/2)
+
+	// And: both rows read back identically through every read path
+	for _, id := range []string{"legacy", "compressed"} {
+		c, err := store.GetChunk(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, longText, c.Content, id)
+		assert.Equal(t, longText+"// raw", c.RawContent, id)
+		assert.Equal(t, "package handler", c.Context, id)
+	}
+	batch, err := store.GetChunks(ctx, []string{"legacy", "compressed"})
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+	byFile, err := store.GetChunksByFile(ctx, "file-cmp")
+	require.NoError(t, err)
+	byPath, err := store.GetChunksByPath(ctx, "handler.go", 0)
+	require.NoError(t, err)
+	for _, c := range append(append(batch, byFile...), byPath...) {
+		assert.Equal(t, longText, c.Content, c.ID)
+	}
+}
+
+func TestSQLiteStore_GetChunkHeaders(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: two stored chunks with content and symbols
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj", Name: "proj", RootPath: "/proj"}))
+	require.NoError(t, store.SaveFiles(ctx, []*File{{ID: "file", ProjectID: "proj", Path: "auth.go"}}))
+	require.NoError(t, store.SaveChunks(ctx, []*Chunk{
+		{ID: "c1", FileID: "file", FilePath: "auth.go", Content: "func Login() {}", ContentType: ContentTypeCode, Language: "go", StartLine: 1, EndLine: 3,
+			Symbols: []*Symbol{{Name: "Login", Type: SymbolTypeFunction}}},
+		{ID: "c2", FileID: "file", FilePath: "auth.go", Content: "func Logout() {}", ContentType: ContentTypeCode, Language: "go", StartLine: 5, EndLine: 7},
+	}))
+
+	// When: fetching their headers, with one unknown ID
+	headers, err := store.GetChunkHeaders(ctx, []string{"c2", "missing", "c1"})
+
+	// Then: headers come back in input order without text or symbols
+	require.NoError(t, err)
+	require.Len(t, headers, 2)
+	assert.Equal(t, &Chunk{ID: "c2", FileID: "file", FilePath: "auth.go", ContentType: ContentTypeCode, Language: "go", StartLine: 5, EndLine: 7}, headers[0])
+	assert.Equal(t, "c1", headers[1].ID)
+	assert.Empty(t, headers[1].Content)
+	assert.Empty(t, headers[1].Symbols)
+}
+
+func TestSQLiteStore_FindByContentHash(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: two projects, one holding two identical files and chunks
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj-dup", Name: "dup", RootPath: "/dup"}))
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj-other", Name: "other", RootPath: "/other"}))
+	require.NoError(t, store.SaveFiles(ctx, []*File{
+		{ID: "file-b", ProjectID: "proj-dup", Path: "b/util.go", ContentHash: "hash-util"},
+		{ID: "file-a", ProjectID: "proj-dup", Path: "a/util.go", ContentHash: "hash-util"},
+		{ID: "file-main", ProjectID: "proj-dup", Path: "main.go", ContentHash: "hash-main"},
+		{ID: "file-x", ProjectID: "proj-other", Path: "util.go", ContentHash: "hash-util"},
+	}))
+	const body = "func Clamp(v, lo, hi int) int { return min(max(v, lo), hi) }"
+	require.NoError(t, store.SaveChunks(ctx, []*Chunk{
+		{ID: "chunk-b", FileID: "file-b", FilePath: "b/util.go", Content: body, StartLine: 1, EndLine: 1},
+		{ID: "chunk-a", FileID: "file-a", FilePath: "a/util.go", Content: body, StartLine: 3, EndLine: 3},
+		{ID: "chunk-main", FileID: "file-main", FilePath: "main.go", Content: "func main() {}", StartLine: 1, EndLine: 1},
+		{ID: "chunk-x", FileID: "file-x", FilePath: "util.go", Content: body, StartLine: 1, EndLine: 1},
+	}))
+
+	// When: looking up the shared hashes in the first project
+	files, err := store.FindFilesByContentHash(ctx, "proj-dup", "hash-util")
+	require.NoError(t, err)
+	chunks, err := store.FindChunksByContentHash(ctx, "proj-dup", ChunkContentHash(body))
+	require.NoError(t, err)
+
+	// Then: only that project's duplicates are returned, ordered by path
+	require.Len(t, files, 2)
+	assert.Equal(t, "a/util.go", files[0].Path)
+	assert.Equal(t, "b/util.go", files[1].Path)
+	assert.Equal(t, "hash-util", files[0].ContentHash)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, "chunk-a", chunks[0].ID)
+	assert.Equal(t, "chunk-b", chunks[1].ID)
+
+	// And: unknown hashes match nothing
+	files, err = store.FindFilesByContentHash(ctx, "proj-dup", "missing")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+	chunks, err = store.FindChunksByContentHash(ctx, "proj-dup", "missing")
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+}
+
+func TestSQLiteStore_Migration7_BackfillsChunkContentHash(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "metadata.db")
+	longText := strings.Repeat("func handler(w http.ResponseWriter, r *http.Request) {}\n", 20)
+
+	// Given: compressed and plain chunks stored before migration 7
+	cfg := DefaultStoreConfig()
+	cfg.CompressContent = true
+	old, err := NewSQLiteStoreWithConfig(dbPath, cfg)
+	require.NoError(t, err)
+	require.NoError(t, old.SaveProject(ctx, &Project{ID: "proj", Name: "proj", RootPath: "/proj"}))
+	require.NoError(t, old.SaveFiles(ctx, []*File{{ID: "file", ProjectID: "proj", Path: "handler.go"}}))
+	require.NoError(t, old.SaveChunks(ctx, []*Chunk{
+		{ID: "long", FileID: "file", FilePath: "handler.go", Content: longText, StartLine: 1, EndLine: 20},
+		{ID: "short", FileID: "file", FilePath: "handler.go", Content: "func f() {}", StartLine: 21, EndLine: 21},
+	}))
+	_, err = old.db.ExecContext(ctx, `UPDATE chunks SET content_hash = NULL`)
+	require.NoError(t, err)
+	_, err = old.db.ExecContext(ctx, `DELETE FROM schema_version WHERE version >= 7`)
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	// When: the store is reopened and migrates
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	// Then: both chunks are found by the hash of their content
+	for id, content := range map[string]string{"long": longText, "short": "func f() {}"} {
+		chunks, err := store.FindChunksByContentHash(ctx, "proj", ChunkContentHash(content))
+		require.NoError(t, err)
+		require.Len(t, chunks, 1, id)
+		assert.Equal(t, id, chunks[0].ID)
+	}
+}
+
 func TestSQLiteStore_GetChunksBySymbol_ExactName(t *testing.T) {
 	store, _ := newTestStore(t)
 	ctx := context.Background()
@@ -513,6 +899,186 @@ func TestSQLiteStore_CascadingDelete(t *testing.T) {
 	assert.Empty(t, chunks1)
 }
 
+func TestSQLiteStore_ListProjects(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: an empty store
+	projects, err := store.ListProjects(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+
+	// When: I save two projects
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj-b", Name: "b", RootPath: "/b", FileCount: 3}))
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj-a", Name: "a", RootPath: "/a", FileCount: 1}))
+
+	// Then: both are listed in ID order
+	projects, err = store.ListProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, "proj-a", projects[0].ID)
+	assert.Equal(t, "/a", projects[0].RootPath)
+	assert.Equal(t, 3, projects[1].FileCount)
+}
+
+func TestSQLiteStore_DeleteProject(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: two projects with files and chunks
+	for _, id := range []string{"proj-gone", "proj-kept"} {
+		require.NoError(t, store.SaveProject(ctx, &Project{ID: id, Name: id, RootPath: "/" + id}))
+		require.NoError(t, store.SaveFiles(ctx, []*File{
+			{ID: id + "-file", ProjectID: id, Path: "a.go", ModTime: time.Now(), IndexedAt: time.Now()},
+		}))
+		require.NoError(t, store.SaveChunks(ctx, []*Chunk{
+			{ID: id + "-chunk", FileID: id + "-file", FilePath: "a.go", Content: "a", ContentType: ContentTypeCode, StartLine: 1, EndLine: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		}))
+	}
+
+	// When: I delete one project
+	require.NoError(t, store.DeleteProject(ctx, "proj-gone"))
+
+	// Then: its project row, files and chunks are gone
+	project, err := store.GetProject(ctx, "proj-gone")
+	require.NoError(t, err)
+	assert.Nil(t, project)
+	file, err := store.GetFileByPath(ctx, "proj-gone", "a.go")
+	require.NoError(t, err)
+	assert.Nil(t, file)
+	chunks, err := store.GetChunksByFile(ctx, "proj-gone-file")
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	// And: the other project is untouched
+	chunks, err = store.GetChunksByFile(ctx, "proj-kept-file")
+	require.NoError(t, err)
+	assert.Len(t, chunks, 1)
+
+	// And: deleting a missing project is not an error
+	assert.NoError(t, store.DeleteProject(ctx, "proj-gone"))
+}
+
+func TestSQLiteStore_RenameProject(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: two projects with files, chunks and language stats
+	for _, id := range []string{"proj-old", "proj-other"} {
+		require.NoError(t, store.SaveProject(ctx, &Project{ID: id, Name: id, RootPath: "/" + id}))
+		require.NoError(t, store.SaveFiles(ctx, []*File{
+			{ID: id + "-file", ProjectID: id, Path: "a.go", Language: "go", ModTime: time.Now(), IndexedAt: time.Now()},
+		}))
+		require.NoError(t, store.SaveChunks(ctx, []*Chunk{
+			{ID: id + "-chunk", FileID: id + "-file", FilePath: "a.go", Content: "a", ContentType: ContentTypeCode, StartLine: 1, EndLine: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		}))
+		require.NoError(t, store.RefreshProjectStats(ctx, id))
+	}
+
+	// When: I rename one project
+	require.NoError(t, store.RenameProject(ctx, "proj-old", "proj-new"))
+
+	// Then: the project row moved, keeping its fields
+	project, err := store.GetProject(ctx, "proj-old")
+	require.NoError(t, err)
+	assert.Nil(t, project)
+	project, err = store.GetProject(ctx, "proj-new")
+	require.NoError(t, err)
+	require.NotNil(t, project)
+	assert.Equal(t, "/proj-old", project.RootPath)
+	assert.Equal(t, 1, project.ChunkCount)
+
+	// And: files, chunks and language stats followed it, with IDs unchanged
+	file, err := store.GetFileByPath(ctx, "proj-new", "a.go")
+	require.NoError(t, err)
+	require.NotNil(t, file)
+	assert.Equal(t, "proj-old-file", file.ID)
+	chunk, err := store.GetChunk(ctx, "proj-old-chunk")
+	require.NoError(t, err)
+	assert.NotNil(t, chunk)
+	langs, err := store.GetProjectLanguages(ctx, "proj-new")
+	require.NoError(t, err)
+	assert.Len(t, langs, 1)
+	paths, err := store.GetFilePathsByProject(ctx, "proj-old")
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+func TestSQLiteStore_RenameProject_Validation(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: two existing projects, one with a file
+	for _, id := range []string{"proj-a", "proj-b"} {
+		require.NoError(t, store.SaveProject(ctx, &Project{ID: id, Name: id, RootPath: "/" + id}))
+	}
+	require.NoError(t, store.SaveFiles(ctx, []*File{
+		{ID: "a-file", ProjectID: "proj-a", Path: "a.go", ModTime: time.Now(), IndexedAt: time.Now()},
+	}))
+
+	// When / Then: renaming onto an existing ID fails and changes nothing
+	err := store.RenameProject(ctx, "proj-a", "proj-b")
+	require.ErrorIs(t, err, ErrProjectExists)
+	paths, err := store.GetFilePathsByProject(ctx, "proj-a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go"}, paths)
+
+	// And: renaming a missing project or to an empty ID fails
+	assert.ErrorIs(t, store.RenameProject(ctx, "proj-missing", "proj-c"), ErrProjectNotFound)
+	assert.Error(t, store.RenameProject(ctx, "proj-a", ""))
+}
+
+func TestSQLiteStore_ChunkFingerprints(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+	require.NoError(t, store.SaveProject(ctx, &Project{ID: "proj", Name: "proj", RootPath: "/proj"}))
+
+	// Given: canonical chunks in a.go, one kept across a re-index, and
+	// near-duplicates of them in b.go, c.go and a.go itself
+	require.NoError(t, store.SaveChunkFingerprints(ctx, "proj", []ChunkFingerprint{
+		{ChunkID: "a1", FilePath: "a.go", Fingerprint: 1 << 63},
+		{ChunkID: "a2", FilePath: "a.go", Fingerprint: 2},
+		{ChunkID: "a3", FilePath: "a.go", Fingerprint: 3, CanonicalID: "a1"},
+		{ChunkID: "b1", FilePath: "b.go", Fingerprint: 4, CanonicalID: "a1"},
+		{ChunkID: "c1", FilePath: "c.go", Fingerprint: 5, CanonicalID: "a2"},
+	}))
+
+	// Then: fingerprints round-trip, including the sign bit
+	fingerprints, err := store.GetChunkFingerprints(ctx, "proj")
+	require.NoError(t, err)
+	require.Len(t, fingerprints, 5)
+	assert.Equal(t, ChunkFingerprint{ChunkID: "a1", FilePath: "a.go", Fingerprint: 1 << 63}, fingerprints[0])
+	assert.Equal(t, "a1", fingerprints[3].CanonicalID)
+
+	// And: near-duplicates are counted per canonical chunk
+	counts, err := store.CountNearDuplicates(ctx, []string{"a1", "a2", "b1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a1": 2, "a2": 1}, counts)
+
+	// When: a.go is re-indexed keeping a2
+	canonicalIDs, released, err := store.DeleteChunkFingerprintsByFile(ctx, "proj", "a.go", []string{"a2"})
+	require.NoError(t, err)
+
+	// Then: a1 is gone and the file of its other near-duplicate is released
+	assert.Equal(t, []string{"a1"}, canonicalIDs)
+	assert.Equal(t, []string{"b.go"}, released)
+	fingerprints, err = store.GetChunkFingerprints(ctx, "proj")
+	require.NoError(t, err)
+	ids := make([]string, len(fingerprints))
+	for i, fp := range fingerprints {
+		ids[i] = fp.ChunkID
+	}
+	assert.Equal(t, []string{"a2", "c1"}, ids)
+
+	// When: the project's fingerprints are cleared
+	require.NoError(t, store.ClearChunkFingerprints(ctx, "proj"))
+
+	// Then: none are left
+	fingerprints, err = store.GetChunkFingerprints(ctx, "proj")
+	require.NoError(t, err)
+	assert.Empty(t, fingerprints)
+}
+
 // TS06: Schema Auto-Creation
 func TestSQLiteStore_SchemaAutoCreation(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -635,9 +1201,10 @@ func TestSQLiteStore_ChunkOperations(t *testing.T) {
 		Symbols: []*Symbol{
 			{Name: "main", Type: SymbolTypeFunction, StartLine: 5, EndLine: 7, Signature: "func main()"},
 		},
-		Metadata:  map[string]string{"key": "value"},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Metadata:        map[string]string{"key": "value"},
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		EnclosingSymbol: "main",
 	}
 	require.NoError(t, store.SaveChunks(ctx, []*Chunk{chunk}))
 
@@ -652,12 +1219,14 @@ func TestSQLiteStore_ChunkOperations(t *testing.T) {
 	assert.Equal(t, chunk.Language, retrieved.Language)
 	assert.Equal(t, chunk.StartLine, retrieved.StartLine)
 	assert.Equal(t, chunk.EndLine, retrieved.EndLine)
+	assert.Equal(t, "main", retrieved.EnclosingSymbol)
 
 	// GetChunksByFile
 	chunks, err := store.GetChunksByFile(ctx, "file-chunk")
 	require.NoError(t, err)
 	assert.Len(t, chunks, 1)
 	assert.Equal(t, "chunk-test-1", chunks[0].ID)
+	assert.Equal(t, "main", chunks[0].EnclosingSymbol)
 
 	// DeleteChunksByFile
 	err = store.DeleteChunksByFile(ctx, "file-chunk")
@@ -1061,6 +1630,49 @@ func TestSQLiteStore_DefaultStoreConfig(t *testing.T) {
 
 	// Then: default cache size is 64MB
 	assert.Equal(t, 64, cfg.CacheSizeMB)
+	assert.Equal(t, 5000, cfg.BusyTimeoutMS)
+	assert.Equal(t, "NORMAL", cfg.SynchronousMode)
+}
+
+func TestSQLiteStore_BusyTimeoutAndSynchronousMode(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             StoreConfig
+		wantBusyTimeout int
+		wantSynchronous int // 0=OFF, 1=NORMAL, 2=FULL, 3=EXTRA
+	}{
+		{name: "zero config keeps defaults", cfg: StoreConfig{}, wantBusyTimeout: 5000, wantSynchronous: 1},
+		{name: "custom values", cfg: StoreConfig{BusyTimeoutMS: 15000, SynchronousMode: "FULL"}, wantBusyTimeout: 15000, wantSynchronous: 2},
+		{name: "mode is case-insensitive", cfg: StoreConfig{SynchronousMode: "extra"}, wantBusyTimeout: 5000, wantSynchronous: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: a store opened with the config
+			store, err := NewSQLiteStoreWithConfig(filepath.Join(t.TempDir(), "metadata.db"), tt.cfg)
+			require.NoError(t, err)
+			defer func() { _ = store.Close() }()
+
+			// Then: the pragmas are applied and WAL is kept
+			var busyTimeout, synchronous int
+			var journalMode string
+			require.NoError(t, store.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout))
+			require.NoError(t, store.db.QueryRow("PRAGMA synchronous").Scan(&synchronous))
+			require.NoError(t, store.db.QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+			assert.Equal(t, tt.wantBusyTimeout, busyTimeout)
+			assert.Equal(t, tt.wantSynchronous, synchronous)
+			assert.Equal(t, "wal", journalMode)
+		})
+	}
+}
+
+func TestSQLiteStore_InvalidSynchronousMode(t *testing.T) {
+	// When: opening with an unknown synchronous mode
+	_, err := NewSQLiteStoreWithConfig(filepath.Join(t.TempDir(), "metadata.db"), StoreConfig{SynchronousMode: "FAST"})
+
+	// Then: the open fails before touching the database
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid synchronous mode")
 }
 
 func TestSQLiteStore_ZeroCacheSize_UsesDefault(t *testing.T) {
@@ -1184,6 +1796,86 @@ func TestGetAllEmbeddings_SkipsNullEmbeddings(t *testing.T) {
 	assert.NotContains(t, allEmbs, "no-emb")
 }
 
+func TestGetChunkEmbeddings_FiltersByIDAndModel(t *testing.T) {
+	store, tmpDir := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: chunks embedded by two different models, and one without embedding
+	project := &Project{ID: "get-emb-proj", Name: "get-emb-test", RootPath: tmpDir}
+	require.NoError(t, store.SaveProject(ctx, project))
+
+	file := &File{ID: "get-emb-file", ProjectID: "get-emb-proj", Path: "test.go"}
+	require.NoError(t, store.SaveFiles(ctx, []*File{file}))
+
+	chunks := []*Chunk{
+		{ID: "g-chunk-1", FileID: "get-emb-file", FilePath: "test.go", Content: "func a()", StartLine: 1, EndLine: 5},
+		{ID: "g-chunk-2", FileID: "get-emb-file", FilePath: "test.go", Content: "func b()", StartLine: 6, EndLine: 10},
+		{ID: "g-chunk-3", FileID: "get-emb-file", FilePath: "test.go", Content: "func c()", StartLine: 11, EndLine: 15},
+		{ID: "g-chunk-4", FileID: "get-emb-file", FilePath: "test.go", Content: "func d()", StartLine: 16, EndLine: 20},
+	}
+	require.NoError(t, store.SaveChunks(ctx, chunks))
+	require.NoError(t, store.SaveChunkEmbeddings(ctx, []string{"g-chunk-1", "g-chunk-2"}, [][]float32{{0.1, 0.2}, {0.3, 0.4}}, "model-a"))
+	require.NoError(t, store.SaveChunkEmbeddings(ctx, []string{"g-chunk-3"}, [][]float32{{0.5, 0.6}}, "model-b"))
+
+	// When: requesting embeddings for model-a
+	embs, err := store.GetChunkEmbeddings(ctx, []string{"g-chunk-1", "g-chunk-3", "g-chunk-4", "missing"}, "model-a")
+
+	// Then: only requested chunks embedded by model-a are returned
+	require.NoError(t, err)
+	require.Len(t, embs, 1)
+	assert.InDeltaSlice(t, []float32{0.1, 0.2}, embs["g-chunk-1"], 0.0001)
+
+	// And: an empty ID list returns nothing
+	embs, err = store.GetChunkEmbeddings(ctx, nil, "model-a")
+	require.NoError(t, err)
+	assert.Empty(t, embs)
+
+	// And: more IDs than SQLite binds in one query are looked up in batches
+	many := make([]string, 0, 40000)
+	for i := range 40000 {
+		many = append(many, fmt.Sprintf("missing-%d", i))
+	}
+	many = append(many, "g-chunk-2")
+	embs, err = store.GetChunkEmbeddings(ctx, many, "model-a")
+	require.NoError(t, err)
+	require.Len(t, embs, 1)
+	assert.InDeltaSlice(t, []float32{0.3, 0.4}, embs["g-chunk-2"], 0.0001)
+}
+
+func TestGetEmbeddingModelCounts(t *testing.T) {
+	store, tmpDir := newTestStore(t)
+	ctx := context.Background()
+
+	// Given: a partially migrated index with chunks from two models and one unembedded chunk
+	project := &Project{ID: "model-count-proj", Name: "model-count-test", RootPath: tmpDir}
+	require.NoError(t, store.SaveProject(ctx, project))
+
+	file := &File{ID: "model-count-file", ProjectID: "model-count-proj", Path: "test.go"}
+	require.NoError(t, store.SaveFiles(ctx, []*File{file}))
+
+	chunks := []*Chunk{
+		{ID: "m-chunk-1", FileID: "model-count-file", FilePath: "test.go", Content: "func a()", StartLine: 1, EndLine: 5},
+		{ID: "m-chunk-2", FileID: "model-count-file", FilePath: "test.go", Content: "func b()", StartLine: 6, EndLine: 10},
+		{ID: "m-chunk-3", FileID: "model-count-file", FilePath: "test.go", Content: "func c()", StartLine: 11, EndLine: 15},
+		{ID: "m-chunk-4", FileID: "model-count-file", FilePath: "test.go", Content: "func d()", StartLine: 16, EndLine: 20},
+	}
+	require.NoError(t, store.SaveChunks(ctx, chunks))
+	require.NoError(t, store.SaveChunkEmbeddings(ctx, []string{"m-chunk-1", "m-chunk-2"}, [][]float32{{0.1, 0.2}, {0.3, 0.4}}, "old-model"))
+	require.NoError(t, store.SaveChunkEmbeddings(ctx, []string{"m-chunk-3"}, [][]float32{{0.5, 0.6}}, "new-model"))
+
+	// When: counting embeddings per model
+	counts, err := store.GetEmbeddingModelCounts(ctx)
+
+	// Then: each model's embedded chunks are counted, unembedded chunks are not
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"old-model": 2, "new-model": 1}, counts)
+
+	// And: GetIndexInfo reports the same counts
+	info, err := GetIndexInfo(ctx, store, tmpDir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, counts, info.EmbeddingModels)
+}
+
 func TestGetEmbeddingStats(t *testing.T) {
 	store, tmpDir := newTestStore(t)
 	ctx := context.Background()
@@ -1289,6 +1981,19 @@ func TestSQLiteStore_ListFilePathsUnder(t *testing.T) {
 		require.NoError(t, err)
 		assert.Len(t, paths, 2)
 	})
+
+	t.Run("prefix matches exactly, not as a pattern", func(t *testing.T) {
+		require.NoError(t, store.SaveFiles(ctx, []*File{
+			{ID: "f6", ProjectID: "proj-paths", Path: "my_dir/a.go"},
+			{ID: "f7", ProjectID: "proj-paths", Path: "myXdir/b.go"},
+			{ID: "f8", ProjectID: "proj-paths", Path: "My_Dir/c.go"},
+			{ID: "f9", ProjectID: "proj-paths", Path: "my_dir.go"},
+			{ID: "f10", ProjectID: "proj-paths", Path: "my_dir-old/d.go"},
+		}))
+		paths, err := store.ListFilePathsUnder(ctx, "proj-paths", "my_dir")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"my_dir/a.go"}, paths)
+	})
 }
 
 func TestSQLiteStore_GetFilesForReconciliation(t *testing.T) {
@@ -0,0 +1,59 @@
+package store
+
+import "math"
+
+// NormalizeScores rescales one result list's raw scores to non-negative
+// values where higher is better, for weighted score fusion. By default it
+// maps them to [0, 1] via (s - min) / (max - min); with zscore it
+// standardizes them via (s - mean) / stddev, shifted so the lowest score is
+// 0. A list whose scores are all equal normalizes to 1.0 for every entry, so
+// a single-result list still contributes its full weight.
+func NormalizeScores(raw []float64, zscore bool) []float64 {
+	out := make([]float64, len(raw))
+	if len(raw) == 0 {
+		return out
+	}
+
+	if zscore {
+		var mean float64
+		for _, s := range raw {
+			mean += s
+		}
+		mean /= float64(len(raw))
+		var variance float64
+		for _, s := range raw {
+			variance += (s - mean) * (s - mean)
+		}
+		stddev := math.Sqrt(variance / float64(len(raw)))
+		if stddev == 0 {
+			for i := range out {
+				out[i] = 1
+			}
+			return out
+		}
+		minZ := math.Inf(1)
+		for i, s := range raw {
+			out[i] = (s - mean) / stddev
+			minZ = math.Min(minZ, out[i])
+		}
+		for i := range out {
+			out[i] -= minZ
+		}
+		return out
+	}
+
+	minScore, maxScore := raw[0], raw[0]
+	for _, s := range raw[1:] {
+		minScore = math.Min(minScore, s)
+		maxScore = math.Max(maxScore, s)
+	}
+	spread := maxScore - minScore
+	for i, s := range raw {
+		if spread == 0 {
+			out[i] = 1
+			continue
+		}
+		out[i] = (s - minScore) / spread
+	}
+	return out
+}
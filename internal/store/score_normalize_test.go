@@ -0,0 +1,32 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeScores(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    []float64
+		zscore bool
+		want   []float64
+	}{
+		{name: "empty", raw: nil, want: []float64{}},
+		{name: "minmax", raw: []float64{10, 5, 0}, want: []float64{1, 0.5, 0}},
+		{name: "minmax without spread", raw: []float64{3, 3}, want: []float64{1, 1}},
+		{name: "zscore", raw: []float64{3, 1}, zscore: true, want: []float64{2, 0}},
+		{name: "zscore without spread", raw: []float64{0.4}, zscore: true, want: []float64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: normalizing the raw scores
+			got := NormalizeScores(tt.raw, tt.zscore)
+
+			// Then: they are rescaled to non-negative values
+			assert.InDeltaSlice(t, tt.want, got, 1e-9)
+		})
+	}
+}
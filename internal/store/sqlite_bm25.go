@@ -22,10 +22,26 @@ type SQLiteBM25Index struct {
 	config    BM25Config
 	closed    bool
 	stopWords map[string]struct{}
+
+	// Short-term filtering from config.MinTermLength and config.KeepShortTerms
+	minTermLength  int
+	keepShortTerms map[string]struct{}
+
+	// Running corpus statistics, maintained incrementally by Index and Delete
+	// from the per-document lengths in doc_lengths. RecomputeStats resyncs them.
+	docCount    int
+	totalTokens int
 }
 
 // Verify interface implementation at compile time
-var _ BM25Index = (*SQLiteBM25Index)(nil)
+var (
+	_ BM25Index           = (*SQLiteBM25Index)(nil)
+	_ BM25StatsRecomputer = (*SQLiteBM25Index)(nil)
+	_ Snapshotter         = (*SQLiteBM25Index)(nil)
+	_ BooleanSearcher     = (*SQLiteBM25Index)(nil)
+	_ TermStatsProvider   = (*SQLiteBM25Index)(nil)
+	_ PrefixSearcher      = (*SQLiteBM25Index)(nil)
+)
 
 // validateSQLiteIntegrity checks if a SQLite FTS5 index is valid before opening.
 // Returns nil if valid, error describing corruption if not.
@@ -148,11 +164,18 @@ func NewSQLiteBM25Index(path string, config BM25Config) (*SQLiteBM25Index, error
 		}
 	}
 
+	minTermLength := config.MinTermLength
+	if minTermLength <= 0 {
+		minTermLength = DefaultMinTermLength
+	}
+
 	idx := &SQLiteBM25Index{
-		db:        db,
-		path:      path,
-		config:    config,
-		stopWords: BuildStopWordMap(config.StopWords),
+		db:             db,
+		path:           path,
+		config:         config,
+		stopWords:      BuildStopWordMap(config.StopWords),
+		minTermLength:  minTermLength,
+		keepShortTerms: BuildStopWordMap(config.KeepShortTerms),
 	}
 
 	// Initialize FTS5 schema
@@ -161,9 +184,25 @@ func NewSQLiteBM25Index(path string, config BM25Config) (*SQLiteBM25Index, error
 		return nil, fmt.Errorf("failed to initialize schema: %w", err)
 	}
 
+	if err := idx.loadStats(context.Background()); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to load corpus statistics: %w", err)
+	}
+
 	return idx, nil
 }
 
+// analyze tokenizes text for indexing and querying, dropping short terms and
+// stop words. Both sides must use it so that queries match indexed terms.
+func (s *SQLiteBM25Index) analyze(text string) []string {
+	return s.filterTokens(splitCodeTerms(text))
+}
+
+// filterTokens drops short terms and stop words from split tokens.
+func (s *SQLiteBM25Index) filterTokens(tokens []string) []string {
+	return FilterStopWords(FilterShortTerms(tokens, s.minTermLength, s.keepShortTerms), s.stopWords)
+}
+
 // initSchema creates the FTS5 virtual table and supporting tables.
 func (s *SQLiteBM25Index) initSchema() error {
 	schema := `
@@ -181,12 +220,22 @@ func (s *SQLiteBM25Index) initSchema() error {
 		tokenize='unicode61'
 	);
 
+	-- Per-term document counts over fts_content, read by DocumentFrequencies
+	CREATE VIRTUAL TABLE IF NOT EXISTS fts_vocab USING fts5vocab(fts_content, 'row');
+
 	-- Auxiliary table for tracking document IDs (AllIDs method)
 	-- FTS5 doesn't expose rowid reliably for external content tables
 	CREATE TABLE IF NOT EXISTS doc_ids (
 		doc_id TEXT PRIMARY KEY
 	);
 
+	-- Indexed token count per document, used to maintain corpus statistics
+	-- (document count, total tokens, average length) without scanning FTS5
+	CREATE TABLE IF NOT EXISTS doc_lengths (
+		doc_id TEXT PRIMARY KEY,
+		token_count INTEGER NOT NULL
+	);
+
 	INSERT OR IGNORE INTO schema_version (version) VALUES (1);
 	`
 
@@ -238,13 +287,38 @@ func (s *SQLiteBM25Index) Index(ctx context.Context, docs []*Document) error {
 	}
 	defer idStmt.Close()
 
+	lengthLookupStmt, err := tx.PrepareContext(ctx,
+		`SELECT token_count FROM doc_lengths WHERE doc_id = ?`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare length lookup statement: %w", err)
+	}
+	defer lengthLookupStmt.Close()
+
+	lengthStmt, err := tx.PrepareContext(ctx,
+		`INSERT OR REPLACE INTO doc_lengths(doc_id, token_count) VALUES (?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare length statement: %w", err)
+	}
+	defer lengthStmt.Close()
+
+	// Deltas are applied to the running statistics only after commit.
+	var docDelta, tokenDelta int
 	for _, doc := range docs {
 		// Pre-process content with code-aware tokenization
-		// This handles camelCase, snake_case, and stop word filtering
-		tokens := TokenizeCode(doc.Content)
-		tokens = FilterStopWords(tokens, s.stopWords)
+		// This handles camelCase, snake_case, short term and stop word filtering
+		tokens := s.analyze(doc.Content)
 		processedContent := strings.Join(tokens, " ")
 
+		var previousLength int
+		switch err := lengthLookupStmt.QueryRowContext(ctx, doc.ID).Scan(&previousLength); {
+		case err == sql.ErrNoRows:
+			docDelta++
+		case err != nil:
+			return fmt.Errorf("failed to look up document length %s: %w", doc.ID, err)
+		default:
+			tokenDelta -= previousLength
+		}
+
 		// Delete existing entry first (FTS5 doesn't support REPLACE)
 		if _, err := deleteStmt.ExecContext(ctx, doc.ID); err != nil {
 			return fmt.Errorf("failed to delete existing document %s: %w", doc.ID, err)
@@ -257,9 +331,18 @@ func (s *SQLiteBM25Index) Index(ctx context.Context, docs []*Document) error {
 		if _, err := idStmt.ExecContext(ctx, doc.ID); err != nil {
 			return fmt.Errorf("failed to track document ID %s: %w", doc.ID, err)
 		}
+		if _, err := lengthStmt.ExecContext(ctx, doc.ID, len(tokens)); err != nil {
+			return fmt.Errorf("failed to track document length %s: %w", doc.ID, err)
+		}
+		tokenDelta += len(tokens)
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.docCount += docDelta
+	s.totalTokens += tokenDelta
+	return nil
 }
 
 // Search returns documents matching query, scored by BM25.
@@ -278,8 +361,7 @@ func (s *SQLiteBM25Index) Search(ctx context.Context, queryStr string, limit int
 	}
 
 	// Pre-process query with same tokenization as indexing
-	tokens := TokenizeCode(queryStr)
-	tokens = FilterStopWords(tokens, s.stopWords)
+	tokens := s.analyze(queryStr)
 	if len(tokens) == 0 {
 		return []*BM25Result{}, nil
 	}
@@ -366,6 +448,169 @@ func matchedTermsForIndexedContent(queryTerms []string, indexedContent string) [
 	return matched
 }
 
+// SearchPrefix returns documents matching query with prefix words expanded,
+// scored by BM25. A prefix expands to the MaxPrefixExpansions indexed terms
+// with the highest document frequency that start with it, read from the
+// sorted FTS5 vocabulary, and matches documents containing any of them.
+// Prefixes shorter than the minimum term length are dropped, but stop words
+// are not, so "dat*" still finds "database". Like Search, words are ANDed
+// first and ORed when that finds nothing.
+func (s *SQLiteBM25Index) SearchPrefix(ctx context.Context, queryStr string, allPrefixes bool, limit int) ([]*BM25Result, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("index is closed")
+	}
+
+	var groups, queryTerms []string
+	for _, word := range splitPrefixQuery(queryStr, allPrefixes) {
+		tokens := word.tokens
+		var prefix string
+		if word.prefix {
+			prefix = tokens[len(tokens)-1]
+			tokens = tokens[:len(tokens)-1]
+		}
+		for _, token := range s.filterTokens(tokens) {
+			groups = append(groups, quoteFTS5Term(token))
+			queryTerms = append(queryTerms, token)
+		}
+		if prefix == "" || len(prefix) < s.minTermLength {
+			continue
+		}
+
+		expansions, err := s.expandPrefix(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if len(expansions) == 0 {
+			// Keeps the AND query honest: nothing can match this word
+			groups = append(groups, quoteFTS5Term(prefix))
+			continue
+		}
+		quoted := make([]string, len(expansions))
+		for i, term := range expansions {
+			quoted[i] = quoteFTS5Term(term)
+		}
+		groups = append(groups, "("+strings.Join(quoted, " OR ")+")")
+		queryTerms = append(queryTerms, expansions...)
+	}
+	if len(groups) == 0 {
+		return []*BM25Result{}, nil
+	}
+
+	results, err := s.searchProcessedQuery(ctx, strings.Join(groups, " AND "), queryTerms, limit)
+	if err != nil || len(results) > 0 || len(groups) == 1 {
+		return results, err
+	}
+	return s.searchProcessedQuery(ctx, strings.Join(groups, " OR "), queryTerms, limit)
+}
+
+// expandPrefix returns the indexed terms starting with prefix, most
+// document-frequent first, bounded by MaxPrefixExpansions. The vocabulary
+// is sorted by term, so the range scan only visits matching terms.
+func (s *SQLiteBM25Index) expandPrefix(ctx context.Context, prefix string) ([]string, error) {
+	query := `SELECT term FROM fts_vocab WHERE term >= ?`
+	args := []any{prefix}
+	if upper, ok := prefixUpperBound(prefix); ok {
+		query += ` AND term < ?`
+		args = append(args, upper)
+	}
+	query += ` ORDER BY doc DESC, term LIMIT ?`
+	args = append(args, MaxPrefixExpansions)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand prefix %q: %w", prefix, err)
+	}
+	defer rows.Close()
+
+	var terms []string
+	for rows.Next() {
+		var term string
+		if err := rows.Scan(&term); err != nil {
+			return nil, fmt.Errorf("failed to scan prefix expansion: %w", err)
+		}
+		terms = append(terms, term)
+	}
+	return terms, rows.Err()
+}
+
+// SearchBoolean returns documents matching a boolean query, scored by BM25.
+// The query is compiled to an FTS5 expression, so matching documents are
+// ranked like plain searches. Terms that analyze to nothing (stop words,
+// short terms) drop out of the expression; a query left empty matches nothing.
+func (s *SQLiteBM25Index) SearchBoolean(ctx context.Context, query *BooleanQuery, limit int) ([]*BM25Result, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("index is closed")
+	}
+
+	match := s.compileBoolean(query.root)
+	if match == "" {
+		return []*BM25Result{}, nil
+	}
+
+	var queryTerms []string
+	for _, term := range query.Terms() {
+		queryTerms = append(queryTerms, s.analyze(term)...)
+	}
+	return s.searchProcessedQuery(ctx, match, queryTerms, limit)
+}
+
+// compileBoolean renders a boolean query node as an FTS5 MATCH expression,
+// or "" if none of its terms survive analysis. FTS5 NOT is binary, so the
+// negated operands of an AND group are subtracted from its other operands.
+func (s *SQLiteBM25Index) compileBoolean(node boolNode) string {
+	switch n := node.(type) {
+	case *boolTerm:
+		tokens := s.analyze(n.text)
+		if len(tokens) == 0 {
+			return ""
+		}
+		terms := make([]string, len(tokens))
+		for i, token := range tokens {
+			terms[i] = quoteFTS5Term(token)
+		}
+		return "(" + strings.Join(terms, " AND ") + ")"
+	case *boolOr:
+		var operands []string
+		for _, o := range n.operands {
+			if expr := s.compileBoolean(o); expr != "" {
+				operands = append(operands, expr)
+			}
+		}
+		if len(operands) == 0 {
+			return ""
+		}
+		return "(" + strings.Join(operands, " OR ") + ")"
+	case *boolAnd:
+		var include, exclude []string
+		for _, o := range n.operands {
+			if not, ok := o.(*boolNot); ok {
+				if expr := s.compileBoolean(not.operand); expr != "" {
+					exclude = append(exclude, expr)
+				}
+				continue
+			}
+			if expr := s.compileBoolean(o); expr != "" {
+				include = append(include, expr)
+			}
+		}
+		if len(include) == 0 {
+			return ""
+		}
+		expr := "(" + strings.Join(include, " AND ") + ")"
+		for _, e := range exclude {
+			expr = "(" + expr + " NOT " + e + ")"
+		}
+		return expr
+	}
+	return ""
+}
+
 func buildFTS5ORQuery(tokens []string) string {
 	terms := make([]string, 0, len(tokens))
 	for _, token := range tokens {
@@ -421,7 +666,23 @@ func (s *SQLiteBM25Index) Delete(ctx context.Context, docIDs []string) error {
 		return fmt.Errorf("failed to delete from doc_ids: %w", err)
 	}
 
-	return tx.Commit()
+	// Capture removed lengths before deleting so running stats stay exact
+	var removedDocs, removedTokens int
+	lengthsQuery := fmt.Sprintf("SELECT COUNT(*), COALESCE(SUM(token_count), 0) FROM doc_lengths WHERE doc_id IN (%s)", inClause)
+	if err := tx.QueryRowContext(ctx, lengthsQuery, args...).Scan(&removedDocs, &removedTokens); err != nil {
+		return fmt.Errorf("failed to read document lengths: %w", err)
+	}
+	deleteLengths := fmt.Sprintf("DELETE FROM doc_lengths WHERE doc_id IN (%s)", inClause)
+	if _, err := tx.ExecContext(ctx, deleteLengths, args...); err != nil {
+		return fmt.Errorf("failed to delete from doc_lengths: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.docCount -= removedDocs
+	s.totalTokens -= removedTokens
+	return nil
 }
 
 // AllIDs returns all document IDs in the index.
@@ -453,6 +714,57 @@ func (s *SQLiteBM25Index) AllIDs() ([]string, error) {
 	return ids, rows.Err()
 }
 
+// AnalyzeTerms tokenizes text as Index does before storing it.
+func (s *SQLiteBM25Index) AnalyzeTerms(text string) []string {
+	return s.analyze(text)
+}
+
+// documentFrequencyBatch bounds the number of terms bound into one
+// DocumentFrequencies query, well below SQLite's host parameter limit.
+const documentFrequencyBatch = 500
+
+// DocumentFrequencies returns how many documents contain each term, read from
+// the FTS5 vocabulary table.
+func (s *SQLiteBM25Index) DocumentFrequencies(ctx context.Context, terms []string) (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("index is closed")
+	}
+
+	freqs := make(map[string]int, len(terms))
+	for start := 0; start < len(terms); start += documentFrequencyBatch {
+		batch := terms[start:min(start+documentFrequencyBatch, len(terms))]
+		args := make([]any, len(batch))
+		for i, term := range batch {
+			args[i] = term
+		}
+		query := `SELECT term, doc FROM fts_vocab WHERE term IN (?` +
+			strings.Repeat(",?", len(batch)-1) + `)`
+
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query document frequencies: %w", err)
+		}
+		for rows.Next() {
+			var term string
+			var docs int
+			if err := rows.Scan(&term, &docs); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to scan document frequency: %w", err)
+			}
+			freqs[term] = docs
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read document frequencies: %w", err)
+		}
+	}
+	return freqs, nil
+}
+
 // Stats returns index statistics.
 func (s *SQLiteBM25Index) Stats() *IndexStats {
 	s.mu.RLock()
@@ -468,11 +780,111 @@ func (s *SQLiteBM25Index) Stats() *IndexStats {
 		return &IndexStats{}
 	}
 
-	return &IndexStats{
-		DocumentCount: count,
-		// Note: TermCount and AvgDocLength not readily available in FTS5
-		// Would require querying internal tables
+	// Note: TermCount is not computed here; counting fts_vocab rows scans
+	// the whole vocabulary. Token totals come from the running statistics.
+	return s.statsLocked(count)
+}
+
+// statsLocked builds IndexStats from the running token totals.
+// Caller must hold s.mu.
+func (s *SQLiteBM25Index) statsLocked(documentCount int) *IndexStats {
+	stats := &IndexStats{
+		DocumentCount: documentCount,
+		TotalTokens:   s.totalTokens,
+	}
+	if s.docCount > 0 {
+		stats.AvgDocLength = float64(s.totalTokens) / float64(s.docCount)
+	}
+	return stats
+}
+
+// loadStats seeds the running statistics from the persisted per-document lengths.
+func (s *SQLiteBM25Index) loadStats(ctx context.Context) error {
+	var docCount, totalTokens int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(SUM(token_count), 0) FROM doc_lengths`).Scan(&docCount, &totalTokens)
+	if err != nil {
+		return err
 	}
+	s.docCount = docCount
+	s.totalTokens = totalTokens
+	return nil
+}
+
+// RecomputeStats rebuilds per-document lengths from the indexed FTS5 content
+// and resets the running statistics. Index and Delete keep the statistics exact
+// within this process; this corrects drift from writers in other processes and
+// backfills indexes created before document lengths were tracked.
+func (s *SQLiteBM25Index) RecomputeStats(ctx context.Context) (*IndexStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("index is closed")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `SELECT doc_id, content FROM fts_content`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexed content: %w", err)
+	}
+	lengths := make(map[string]int)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		var docID, content string
+		if err := rows.Scan(&docID, &content); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan indexed content: %w", err)
+		}
+		// Content is stored pre-tokenized and space-joined.
+		lengths[docID] = len(strings.Fields(content))
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("failed to iterate indexed content: %w", err)
+	}
+	_ = rows.Close()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM doc_lengths`); err != nil {
+		return nil, fmt.Errorf("failed to clear document lengths: %w", err)
+	}
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO doc_lengths(doc_id, token_count) VALUES (?, ?)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare length statement: %w", err)
+	}
+	defer stmt.Close()
+
+	var totalTokens int
+	for docID, length := range lengths {
+		if _, err := stmt.ExecContext(ctx, docID, length); err != nil {
+			return nil, fmt.Errorf("failed to store document length %s: %w", docID, err)
+		}
+		totalTokens += length
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit recomputed statistics: %w", err)
+	}
+
+	drift := s.totalTokens - totalTokens
+	s.docCount = len(lengths)
+	s.totalTokens = totalTokens
+	if drift != 0 {
+		slog.Debug("bm25_stats_recomputed",
+			slog.Int("documents", s.docCount),
+			slog.Int("total_tokens", totalTokens),
+			slog.Int("token_drift", drift))
+	}
+
+	return s.statsLocked(len(lengths)), nil
 }
 
 // Save persists the index to disk.
@@ -490,6 +902,20 @@ func (s *SQLiteBM25Index) Save(path string) error {
 	return err
 }
 
+// Snapshot writes a consistent copy of the index to path using VACUUM INTO.
+func (s *SQLiteBM25Index) Snapshot(ctx context.Context, path string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return fmt.Errorf("index is closed")
+	}
+	if _, err := s.db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to snapshot BM25 index: %w", err)
+	}
+	return nil
+}
+
 // Load opens an existing index from disk.
 func (s *SQLiteBM25Index) Load(path string) error {
 	s.mu.Lock()
@@ -511,6 +937,12 @@ func (s *SQLiteBM25Index) Load(path string) error {
 	s.path = path
 	s.closed = false
 
+	// Best effort: indexes written before doc_lengths existed start from zero
+	// until RecomputeStats backfills them.
+	if err := s.loadStats(context.Background()); err != nil {
+		s.docCount, s.totalTokens = 0, 0
+	}
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -42,6 +43,62 @@ func TestSQLiteBM25Index_IndexAndSearch_Basic(t *testing.T) {
 	assert.Greater(t, results[0].Score, 0.0)
 }
 
+func TestSQLiteBM25Index_MinTermLength(t *testing.T) {
+	tests := []struct {
+		name      string
+		minLength int
+		keep      []string
+		query     string
+		wantHits  int
+	}{
+		{name: "default drops single characters", query: "i", wantHits: 0},
+		{name: "default keeps two characters", query: "id", wantHits: 1},
+		{name: "one keeps single characters", minLength: 1, query: "i", wantHits: 1},
+		{name: "longer minimum drops short terms", minLength: 3, query: "id", wantHits: 0},
+		{name: "exception survives longer minimum", minLength: 3, keep: []string{"ID"}, query: "id", wantHits: 1},
+		{name: "long terms unaffected", minLength: 3, query: "lookup", wantHits: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: an index with a term length configuration
+			config := DefaultBM25Config()
+			config.MinTermLength = tt.minLength
+			config.KeepShortTerms = tt.keep
+			idx, err := NewSQLiteBM25Index("", config)
+			require.NoError(t, err)
+			defer func() { _ = idx.Close() }()
+
+			require.NoError(t, idx.Index(context.Background(), []*Document{
+				{ID: "1", Content: "for i := range items { lookupByID(id) }"},
+			}))
+
+			// When: searching for the term
+			results, err := idx.Search(context.Background(), tt.query, 10)
+
+			// Then: it matches only if the term was kept
+			require.NoError(t, err)
+			assert.Len(t, results, tt.wantHits)
+		})
+	}
+}
+
+func TestBM25ConfigWithTermLength(t *testing.T) {
+	// Given/When: a config built without term length settings
+	defaults := BM25ConfigWithTermLength(0, nil)
+
+	// Then: it matches the defaults
+	assert.Equal(t, DefaultBM25Config(), defaults)
+
+	// Given/When: a config built with term length settings
+	config := BM25ConfigWithTermLength(3, []string{"id", "io"})
+
+	// Then: they override only the term length fields
+	assert.Equal(t, 3, config.MinTermLength)
+	assert.Equal(t, []string{"id", "io"}, config.KeepShortTerms)
+	assert.Equal(t, DefaultCodeStopWords, config.StopWords)
+}
+
 // TS02: CamelCase Tokenization
 func TestSQLiteBM25Index_Search_FindsCamelCase(t *testing.T) {
 	// Given: index with camelCase content
@@ -293,6 +350,77 @@ func TestSQLiteBM25Index_Stats_Accuracy(t *testing.T) {
 	assert.Equal(t, 2, stats.DocumentCount)
 }
 
+func TestSQLiteBM25Index_Stats_AvgDocLengthTracksAddDelete(t *testing.T) {
+	idx, err := NewSQLiteBM25Index("", DefaultBM25Config())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+	ctx := context.Background()
+
+	require.NoError(t, idx.Index(ctx, []*Document{
+		{ID: "1", Content: "hello world"},       // 2 tokens
+		{ID: "2", Content: "hello there world"}, // 3 tokens
+	}))
+	stats := idx.Stats()
+	assert.Equal(t, 5, stats.TotalTokens)
+	assert.InDelta(t, 2.5, stats.AvgDocLength, 1e-9)
+
+	// Replacing a document swaps its length rather than adding to it
+	require.NoError(t, idx.Index(ctx, []*Document{{ID: "1", Content: "alpha beta gamma delta"}}))
+	stats = idx.Stats()
+	assert.Equal(t, 2, stats.DocumentCount)
+	assert.Equal(t, 7, stats.TotalTokens)
+	assert.InDelta(t, 3.5, stats.AvgDocLength, 1e-9)
+
+	require.NoError(t, idx.Delete(ctx, []string{"2", "missing"}))
+	stats = idx.Stats()
+	assert.Equal(t, 1, stats.DocumentCount)
+	assert.Equal(t, 4, stats.TotalTokens)
+	assert.InDelta(t, 4.0, stats.AvgDocLength, 1e-9)
+}
+
+func TestSQLiteBM25Index_RecomputeStats_CorrectsDrift(t *testing.T) {
+	idx, err := NewSQLiteBM25Index("", DefaultBM25Config())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+	ctx := context.Background()
+
+	require.NoError(t, idx.Index(ctx, []*Document{
+		{ID: "1", Content: "hello world"},
+		{ID: "2", Content: "hello there world"},
+	}))
+
+	// Simulate drift: a legacy index without per-document lengths
+	_, err = idx.db.Exec(`DELETE FROM doc_lengths`)
+	require.NoError(t, err)
+	idx.totalTokens = 42
+
+	stats, err := idx.RecomputeStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.DocumentCount)
+	assert.Equal(t, 5, stats.TotalTokens)
+	assert.InDelta(t, 2.5, stats.AvgDocLength, 1e-9)
+	assert.Equal(t, stats.TotalTokens, idx.Stats().TotalTokens)
+}
+
+func TestSQLiteBM25Index_Stats_PersistAcrossReopen(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "bm25.db")
+	idx, err := NewSQLiteBM25Index(indexPath, DefaultBM25Config())
+	require.NoError(t, err)
+	require.NoError(t, idx.Index(context.Background(), []*Document{
+		{ID: "1", Content: "hello world"},
+		{ID: "2", Content: "hello there world"},
+	}))
+	require.NoError(t, idx.Close())
+
+	reopened, err := NewSQLiteBM25Index(indexPath, DefaultBM25Config())
+	require.NoError(t, err)
+	defer func() { _ = reopened.Close() }()
+
+	stats := reopened.Stats()
+	assert.Equal(t, 5, stats.TotalTokens)
+	assert.InDelta(t, 2.5, stats.AvgDocLength, 1e-9)
+}
+
 // TS10: AllIDs returns all document IDs
 func TestSQLiteBM25Index_AllIDs(t *testing.T) {
 	// Given: index with documents
@@ -978,6 +1106,111 @@ func BenchmarkSQLiteBM25Index_Index_10K(b *testing.B) {
 	}
 }
 
+func TestSQLiteBM25Index_DocumentFrequencies(t *testing.T) {
+	// Given: an index where "user" appears in every document and "retry" in one
+	idx, err := NewSQLiteBM25Index("", DefaultBM25Config())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	ctx := context.Background()
+	require.NoError(t, idx.Index(ctx, []*Document{
+		{ID: "1", Content: "func getUser() { retryUser() }"},
+		{ID: "2", Content: "func createUser()"},
+		{ID: "3", Content: "func deleteUser()"},
+	}))
+
+	// When: analyzing text and looking up its terms
+	terms := idx.AnalyzeTerms("retryUser")
+	freqs, err := idx.DocumentFrequencies(ctx, append(terms, "missing"))
+	require.NoError(t, err)
+
+	// Then: terms are analyzed like indexed content and counted per document
+	assert.Equal(t, []string{"retry", "user"}, terms)
+	assert.Equal(t, map[string]int{"retry": 1, "user": 3}, freqs)
+
+	// And: deleting a document updates the counts
+	require.NoError(t, idx.Delete(ctx, []string{"1"}))
+	freqs, err = idx.DocumentFrequencies(ctx, terms)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"user": 2}, freqs)
+}
+
+func TestSQLiteBM25Index_SearchPrefix(t *testing.T) {
+	idx, err := NewSQLiteBM25Index("", DefaultBM25Config())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	ctx := context.Background()
+	require.NoError(t, idx.Index(ctx, []*Document{
+		{ID: "authn", Content: "func authenticateUser(token string)"},
+		{ID: "authz", Content: "type AuthenticationError struct"},
+		{ID: "author", Content: "var authorName string"},
+		{ID: "db", Content: "func openDatabase(dsn string)"},
+	}))
+
+	ids := func(results []*BM25Result) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.DocID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		allPrefixes bool
+		want        []string
+	}{
+		{name: "trailing marker expands prefix", query: "authent*", want: []string{"authn", "authz"}},
+		{name: "exact word without marker", query: "authent", want: nil},
+		{name: "all prefixes option", query: "authent", allPrefixes: true, want: []string{"authn", "authz"}},
+		{name: "exact and prefix words are ANDed", query: "user authent*", want: []string{"authn"}},
+		{name: "stop word prefix still expands", query: "data*", want: []string{"db"}},
+		{name: "last camelCase token is the prefix", query: "authenticateUs*", want: []string{"authn"}},
+		{name: "unknown prefix matches nothing", query: "zzz*", want: nil},
+		{name: "prefix below minimum length is dropped", query: "a*", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: searching with prefix matching
+			results, err := idx.SearchPrefix(ctx, tt.query, tt.allPrefixes, 10)
+
+			// Then: documents with terms sharing the prefix match
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, ids(results))
+		})
+	}
+}
+
+func TestSQLiteBM25Index_SearchPrefix_BoundsExpansion(t *testing.T) {
+	// Given: more distinct terms sharing a prefix than MaxPrefixExpansions
+	idx, err := NewSQLiteBM25Index("", DefaultBM25Config())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	ctx := context.Background()
+	var docs []*Document
+	for i := 0; i < MaxPrefixExpansions+10; i++ {
+		docs = append(docs, &Document{ID: fmt.Sprintf("doc%d", i), Content: fmt.Sprintf("handler%c%c", 'a'+i/26, 'a'+i%26)})
+	}
+	// The common term appears in three documents and must survive the bound
+	docs = append(docs,
+		&Document{ID: "common1", Content: "handlerzz"},
+		&Document{ID: "common2", Content: "handlerzz"},
+		&Document{ID: "common3", Content: "handlerzz"},
+	)
+	require.NoError(t, idx.Index(ctx, docs))
+
+	// When: expanding the shared prefix
+	terms, err := idx.expandPrefix(ctx, "handler")
+
+	// Then: the expansion is bounded and keeps the most frequent term first
+	require.NoError(t, err)
+	assert.Len(t, terms, MaxPrefixExpansions)
+	assert.Equal(t, "handlerzz", terms[0])
+}
+
 func BenchmarkSQLiteBM25Index_Search(b *testing.B) {
 	idx, _ := NewSQLiteBM25Index("", DefaultBM25Config())
 	docs := generateTestDocs(10000, 100)
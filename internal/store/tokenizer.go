@@ -13,6 +13,11 @@ var tokenRegex = regexp.MustCompile(`[a-zA-Z0-9_]+`)
 // It handles camelCase, PascalCase, snake_case, and filters short tokens.
 // All tokens are lowercased.
 func TokenizeCode(text string) []string {
+	return FilterShortTerms(splitCodeTerms(text), 2, nil)
+}
+
+// splitCodeTerms splits text like TokenizeCode but keeps tokens of any length.
+func splitCodeTerms(text string) []string {
 	var tokens []string
 
 	// Split on whitespace and punctuation first
@@ -20,19 +25,29 @@ func TokenizeCode(text string) []string {
 
 	for _, word := range words {
 		// Split camelCase and snake_case
-		subTokens := SplitCodeToken(word)
-		for _, t := range subTokens {
-			lower := strings.ToLower(t)
-			// Filter tokens < 2 chars
-			if len(lower) >= 2 {
-				tokens = append(tokens, lower)
-			}
+		for _, t := range SplitCodeToken(word) {
+			tokens = append(tokens, strings.ToLower(t))
 		}
 	}
 
 	return tokens
 }
 
+// FilterShortTerms removes tokens shorter than minLen characters, except
+// those in keep. Tokens are expected to be lowercased.
+func FilterShortTerms(tokens []string, minLen int, keep map[string]struct{}) []string {
+	result := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if len(token) < minLen {
+			if _, ok := keep[token]; !ok {
+				continue
+			}
+		}
+		result = append(result, token)
+	}
+	return result
+}
+
 // SplitCodeToken splits camelCase and snake_case identifiers.
 func SplitCodeToken(token string) []string {
 	var result []string
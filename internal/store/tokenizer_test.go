@@ -277,6 +277,18 @@ func TestFilterStopWords(t *testing.T) {
 	assert.Equal(t, []string{"getUserById", "user", "name"}, result)
 }
 
+func TestFilterShortTerms(t *testing.T) {
+	// Given: tokens of varying length, with "io" as an exception
+	tokens := []string{"i", "io", "ctx", "fmt", "reader"}
+	keep := map[string]struct{}{"io": {}}
+
+	// When: filtering terms shorter than 4 characters
+	result := FilterShortTerms(tokens, 4, keep)
+
+	// Then: short terms are removed except the kept ones
+	assert.Equal(t, []string{"io", "reader"}, result)
+}
+
 // Benchmark tokenization
 func BenchmarkTokenizeCode(b *testing.B) {
 	input := "func getUserById(ctx context.Context, id string) (*User, error)"
@@ -24,6 +24,19 @@ const (
 	StateKeyIndexDimension = "index_embedding_dimension"
 	// StateKeyIndexModel stores the embedding model name used for the index
 	StateKeyIndexModel = "index_embedding_model"
+	// StateKeyIndexSymbolCounts stores the JSON-encoded SymbolCounts of the
+	// last full index run
+	StateKeyIndexSymbolCounts = "index_symbol_counts"
+	// StateKeyReembedDimension stores the target dimension of an unfinished
+	// background re-embedding after an embedder dimension change
+	StateKeyReembedDimension = "reembed_dimension"
+	// StateKeyReembedModel stores the target model of an unfinished
+	// background re-embedding after an embedder model change at the same
+	// dimension
+	StateKeyReembedModel = "reembed_model"
+	// StateKeyReembedProgress stores how many chunks that re-embedding has
+	// processed, in ListChunkIDs order, so an interrupted one resumes there
+	StateKeyReembedProgress = "reembed_progress"
 )
 
 // Checkpoint state keys for resumable indexing
@@ -86,7 +99,7 @@ type Chunk struct {
 	FilePath    string            // Relative to project root
 	Content     string            // Full content with context
 	RawContent  string            // Just the symbol, no context (code only)
-	Context     string            // Imports, package decl (code only)
+	Context     string            // Imports, package decl (code); heading breadcrumb (markdown)
 	ContentType ContentType       // code, markdown, text
 	Language    string            // go, typescript, python, etc.
 	StartLine   int               // 1-indexed
@@ -95,6 +108,10 @@ type Chunk struct {
 	Metadata    map[string]string // Custom metadata
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	// EnclosingSymbol is the scope breadcrumb set by the chunker, e.g.
+	// "AuthService › Login" (see chunk.Chunk.EnclosingSymbol).
+	EnclosingSymbol string
 }
 
 // File represents a tracked file in the index.
@@ -122,7 +139,17 @@ type Project struct {
 	Version     string // Index schema version
 }
 
-// MetadataStore persists chunk metadata in SQLite.
+// ProjectLanguage holds file and chunk counts for one language in a project.
+type ProjectLanguage struct {
+	Language   string // Detected language ("unknown" if none)
+	FileCount  int
+	ChunkCount int
+}
+
+// MetadataStore persists project, file and chunk metadata. SQLiteStore is the
+// persistent implementation; MemoryStore keeps everything in memory. Search and
+// indexing depend only on this interface and on the optional interfaces below,
+// which they detect with type assertions, so other backends can be swapped in.
 type MetadataStore interface {
 	// Project operations
 	SaveProject(ctx context.Context, project *Project) error
@@ -193,11 +220,13 @@ type IndexInfo struct {
 	IndexDimensions int    // Embedding dimensions
 
 	// Statistics
-	ChunkCount      int   // Number of chunks in index
-	DocumentCount   int   // Number of documents (files) indexed
-	IndexSizeBytes  int64 // Total index size (BM25 + vector)
-	BM25SizeBytes   int64 // BM25 index file size
-	VectorSizeBytes int64 // Vector store file size
+	ChunkCount      int            // Number of chunks in index
+	EmbeddingModels map[string]int // Embedded chunks per model (nil if the store does not track it)
+	DocumentCount   int            // Number of documents (files) indexed
+	IndexSizeBytes  int64          // Total index size (BM25 + vector)
+	BM25SizeBytes   int64          // BM25 index file size
+	VectorSizeBytes int64          // Vector store file size
+	SymbolCounts    SymbolCounts   // Symbols extracted by the last full index run (nil if not recorded)
 
 	// Timestamps
 	CreatedAt time.Time // When index was first created
@@ -230,7 +259,144 @@ type BM25Result struct {
 type IndexStats struct {
 	DocumentCount int
 	TermCount     int
-	AvgDocLength  float64
+	TotalTokens   int     // Sum of indexed token counts across all documents
+	AvgDocLength  float64 // TotalTokens / DocumentCount (0 when empty)
+}
+
+// BM25StatsRecomputer is implemented by BM25 indexes that can rebuild their
+// corpus statistics from the stored postings. Indexes maintain the running
+// totals incrementally on every add/delete; RecomputeStats is the periodic
+// resync that corrects drift (e.g. writes from another process, legacy indexes
+// created before per-document lengths were tracked).
+type BM25StatsRecomputer interface {
+	RecomputeStats(ctx context.Context) (*IndexStats, error)
+}
+
+// Snapshotter is implemented by stores that can write a consistent
+// point-in-time copy of themselves to a single new file, e.g. for index export.
+// path must not exist.
+type Snapshotter interface {
+	Snapshot(ctx context.Context, path string) error
+}
+
+// ProjectDeleter is implemented by metadata stores that can remove a project
+// record together with all of its files, chunks and symbols.
+type ProjectDeleter interface {
+	DeleteProject(ctx context.Context, projectID string) error
+}
+
+// ProjectLister is implemented by metadata stores that can enumerate their
+// projects.
+type ProjectLister interface {
+	ListProjects(ctx context.Context) ([]*Project, error)
+}
+
+// ChunkEmbeddingGetter is implemented by metadata stores that can look up the
+// stored embeddings of specific chunks, unlike GetAllEmbeddings which loads
+// them all.
+type ChunkEmbeddingGetter interface {
+	GetChunkEmbeddings(ctx context.Context, ids []string, model string) (map[string][]float32, error)
+}
+
+// EmbeddingModelCounter is implemented by metadata stores that record which
+// model produced each chunk embedding. Indexes migrated between models can
+// hold several; counts for models other than the current one are chunks
+// that still need re-embedding.
+type EmbeddingModelCounter interface {
+	// GetEmbeddingModelCounts returns the number of embedded chunks per
+	// model. Embeddings saved without a model name are counted under "".
+	GetEmbeddingModelCounts(ctx context.Context) (map[string]int, error)
+}
+
+// ChunkHeaderGetter is implemented by metadata stores that can fetch chunks
+// without their text, for callers that only need where a chunk is. The
+// returned chunks have ID, FileID, FilePath, ContentType, Language and line
+// range set; Content, symbols and metadata are left empty.
+type ChunkHeaderGetter interface {
+	GetChunkHeaders(ctx context.Context, ids []string) ([]*Chunk, error)
+}
+
+// ContentHashFinder is implemented by metadata stores that can look up files
+// and chunks by content hash, e.g. to find exact duplicates. File hashes are
+// the File.ContentHash recorded at index time; chunk hashes are
+// ChunkContentHash of Chunk.Content.
+type ContentHashFinder interface {
+	FindFilesByContentHash(ctx context.Context, projectID, hash string) ([]*File, error)
+	FindChunksByContentHash(ctx context.Context, projectID, hash string) ([]*Chunk, error)
+}
+
+// ChunkFingerprint is the SimHash fingerprint of an indexed chunk. Chunks
+// skipped as near-duplicates are not stored as chunks; their fingerprint
+// records the canonical chunk they duplicate in CanonicalID, which is empty
+// for canonical chunks.
+type ChunkFingerprint struct {
+	ChunkID     string
+	FilePath    string
+	Fingerprint uint64
+	CanonicalID string
+}
+
+// NearDuplicateStore is implemented by metadata stores that persist chunk
+// fingerprints for near-duplicate detection, so which chunks were skipped as
+// near-duplicates of which canonical chunk survives restarts.
+type NearDuplicateStore interface {
+	// SaveChunkFingerprints upserts fingerprints by chunk ID.
+	SaveChunkFingerprints(ctx context.Context, projectID string, fingerprints []ChunkFingerprint) error
+
+	// GetChunkFingerprints returns all fingerprints of a project, ordered by
+	// chunk ID.
+	GetChunkFingerprints(ctx context.Context, projectID string) ([]ChunkFingerprint, error)
+
+	// DeleteChunkFingerprintsByFile deletes the fingerprints of a file,
+	// except canonical ones whose chunk ID is in keep. Fingerprints of other
+	// files' near-duplicates of the deleted canonical chunks are deleted
+	// too, since those chunks are no longer represented in the index. It
+	// returns the deleted canonical chunk IDs and the sorted paths of the
+	// files whose near-duplicates were released this way.
+	DeleteChunkFingerprintsByFile(ctx context.Context, projectID, filePath string, keep []string) (canonicalIDs, releasedPaths []string, err error)
+
+	// ClearChunkFingerprints deletes all fingerprints of a project.
+	ClearChunkFingerprints(ctx context.Context, projectID string) error
+
+	// CountNearDuplicates returns, for each of canonicalIDs that has any,
+	// the number of chunks skipped as its near-duplicates.
+	CountNearDuplicates(ctx context.Context, canonicalIDs []string) (map[string]int, error)
+}
+
+// SymbolFieldSearcher is implemented by metadata stores that can search
+// symbols by signature and doc comment as well as by name, to find a symbol
+// from a description of what it does. MetadataStore.SearchSymbols remains
+// the name-only fast path.
+type SymbolFieldSearcher interface {
+	// SearchSymbolsAllFields matches each whitespace-separated term of query
+	// against symbol names, signatures and doc comments (partial,
+	// case-insensitive) and returns the symbols matching any term, best
+	// first: a term in the name weighs most, then in the signature, then in
+	// the doc comment.
+	SearchSymbolsAllFields(ctx context.Context, query string, limit int) ([]*Symbol, error)
+}
+
+// FileSymbolLister is implemented by metadata stores that can list the
+// symbols of a file, e.g. for an outline of its structure.
+type FileSymbolLister interface {
+	// GetSymbolsByFile returns the symbols of all chunks of a file ordered
+	// by start line. A symbol recorded by several chunks, such as a type
+	// split across chunk boundaries, is returned once, spanning the widest
+	// line range recorded for it.
+	GetSymbolsByFile(ctx context.Context, fileID string) ([]*Symbol, error)
+}
+
+// Pinger is implemented by metadata stores that can cheaply verify their
+// backend is reachable, e.g. for readiness probes.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// ChunkIDLister is implemented by metadata stores that can list the IDs of
+// every stored chunk without loading the chunks themselves.
+type ChunkIDLister interface {
+	// ListChunkIDs returns all chunk IDs in ascending order.
+	ListChunkIDs(ctx context.Context) ([]string, error)
 }
 
 // BM25Index provides keyword search using BM25 algorithm.
@@ -256,6 +422,34 @@ type BM25Index interface {
 	Close() error
 }
 
+// BooleanSearcher is implemented by BM25 indexes that can evaluate boolean
+// queries (see ParseBooleanQuery). Matching documents are scored by BM25 as
+// in Search; the query only decides which documents match.
+type BooleanSearcher interface {
+	SearchBoolean(ctx context.Context, query *BooleanQuery, limit int) ([]*BM25Result, error)
+}
+
+// PrefixSearcher is implemented by BM25 indexes that can match query words
+// as prefixes of indexed terms, e.g. for as-you-type search.
+type PrefixSearcher interface {
+	// SearchPrefix is like Search, except that words ending with
+	// PrefixMarker (every word, with allPrefixes) match all indexed terms
+	// they are a prefix of, up to MaxPrefixExpansions per word.
+	SearchPrefix(ctx context.Context, query string, allPrefixes bool, limit int) ([]*BM25Result, error)
+}
+
+// TermStatsProvider is implemented by BM25 indexes that expose per-term
+// corpus statistics, e.g. to find the most distinctive terms of a document.
+type TermStatsProvider interface {
+	// AnalyzeTerms tokenizes text the way the index tokenizes documents,
+	// dropping short terms and stop words. Repeated terms are kept.
+	AnalyzeTerms(text string) []string
+
+	// DocumentFrequencies returns the number of indexed documents containing
+	// each of terms. Terms that occur in no document are omitted.
+	DocumentFrequencies(ctx context.Context, terms []string) (map[string]int, error)
+}
+
 // BM25Config configures the BM25 index.
 type BM25Config struct {
 	// K1 is the term frequency saturation parameter (default: 1.2)
@@ -267,8 +461,20 @@ type BM25Config struct {
 	// StopWords is a list of words to filter out during tokenization
 	StopWords []string
 
-	// MinTokenLength is minimum token length to index (default: 2)
-	MinTokenLength int
+	// MinTermLength drops terms shorter than this many characters at index
+	// and query time, such as loop variables i and j (default: 2).
+	// Zero uses the default. Changing it requires a reindex: terms dropped
+	// from an existing index cannot be found, and terms it still contains
+	// are no longer queried.
+	MinTermLength int
+
+	// KeepShortTerms lists terms that are kept even when shorter than
+	// MinTermLength, so short identifiers such as id, os and io stay
+	// searchable.
+	//
+	// MinTermLength and KeepShortTerms apply to the SQLite backend; the legacy
+	// Bleve backend keeps its fixed analyzer.
+	KeepShortTerms []string
 }
 
 // DefaultBM25Config returns default BM25 configuration.
@@ -277,10 +483,34 @@ func DefaultBM25Config() BM25Config {
 		K1:             1.2,
 		B:              0.75,
 		StopWords:      DefaultCodeStopWords,
-		MinTokenLength: 2,
+		MinTermLength:  DefaultMinTermLength,
+		KeepShortTerms: DefaultKeepShortTerms,
 	}
 }
 
+// BM25ConfigWithTermLength returns DefaultBM25Config with the given minimum
+// term length and short-term exceptions. Zero or empty values keep the
+// defaults.
+func BM25ConfigWithTermLength(minTermLength int, keepShortTerms []string) BM25Config {
+	config := DefaultBM25Config()
+	if minTermLength > 0 {
+		config.MinTermLength = minTermLength
+	}
+	if len(keepShortTerms) > 0 {
+		config.KeepShortTerms = keepShortTerms
+	}
+	return config
+}
+
+// DefaultMinTermLength is the default BM25Config.MinTermLength.
+const DefaultMinTermLength = 2
+
+// DefaultKeepShortTerms contains short identifiers common enough in code to
+// stay searchable under a higher MinTermLength.
+var DefaultKeepShortTerms = []string{
+	"id", "io", "os", "db", "fs", "ui", "ip", "go", "js", "ts", "vm",
+}
+
 // DefaultCodeStopWords contains programming keywords to filter out.
 var DefaultCodeStopWords = []string{
 	"var", "let", "const", "func", "function", "def", "class",
@@ -354,6 +584,56 @@ type VectorStore interface {
 	Close() error
 }
 
+// Bounds for per-query HNSW search width. Below MinEfSearch recall collapses;
+// above MaxEfSearch latency grows with little recall gain.
+const (
+	MinEfSearch = 10
+	MaxEfSearch = 1000
+)
+
+// EfSearcher is implemented by vector stores that accept a per-query HNSW
+// search width (ef). Higher ef explores more of the graph: better recall,
+// higher latency. ef <= 0 uses the store's configured EfSearch; other values
+// are clamped to [MinEfSearch, MaxEfSearch].
+type EfSearcher interface {
+	SearchWithEf(ctx context.Context, query []float32, k, ef int) ([]*VectorResult, error)
+}
+
+// VectorLogger is implemented by vector stores that can log incremental
+// changes next to their snapshot, so a long-running process persists them
+// without rewriting the whole snapshot.
+type VectorLogger interface {
+	// EnableLog logs changes for the snapshot at path.
+	EnableLog(path string) error
+	// Checkpoint folds the log into the snapshot.
+	Checkpoint() error
+}
+
+// VectorPurger is implemented by vector stores whose Delete only tombstones
+// entries. Purge removes the vectors for ids and reclaims their space, for
+// data that is gone for good.
+type VectorPurger interface {
+	Purge(ctx context.Context, ids []string) error
+}
+
+// VectorResetter is implemented by vector stores that can drop every vector
+// and start over at a new dimension, as needed when the embedding model
+// changes. Stores with a write-ahead log snapshot the empty store.
+type VectorResetter interface {
+	Reset(dimensions int) error
+}
+
+// ClampEfSearch bounds ef to [MinEfSearch, MaxEfSearch].
+func ClampEfSearch(ef int) int {
+	if ef < MinEfSearch {
+		return MinEfSearch
+	}
+	if ef > MaxEfSearch {
+		return MaxEfSearch
+	}
+	return ef
+}
+
 // ErrDimensionMismatch indicates vector dimension mismatch.
 type ErrDimensionMismatch struct {
 	Expected int
@@ -0,0 +1,39 @@
+package store
+
+import "fmt"
+
+// VectorBackend represents the vector store backend type.
+type VectorBackend string
+
+const (
+	// VectorBackendHNSW uses an in-memory HNSW graph (default).
+	// Approximate search: fast at any index size, recall tuned by EfSearch.
+	VectorBackendHNSW VectorBackend = "hnsw"
+
+	// VectorBackendFlat uses exact brute-force search.
+	// No graph memory and no orphans to compact, but search time grows
+	// linearly with the number of vectors.
+	VectorBackendFlat VectorBackend = "flat"
+)
+
+// NewVectorStoreWithBackend creates a VectorStore using the specified backend.
+//
+// backend options:
+//   - "hnsw" (default): HNSW graph. Approximate (recall ~95-99% depending on
+//     EfSearch) with millisecond searches on large indexes, at the cost of
+//     graph memory and lazy-deleted nodes that need compaction.
+//   - "flat": brute-force scan. Exact (100% recall) and compact, with
+//     cheap Save/Load, but every query reads every vector, so latency grows
+//     linearly; best suited to small indexes or memory-constrained hosts.
+func NewVectorStoreWithBackend(cfg VectorStoreConfig, backend string) (VectorStore, error) {
+	switch backend {
+	case string(VectorBackendHNSW), "":
+		return NewHNSWStore(cfg)
+
+	case string(VectorBackendFlat):
+		return NewFlatStore(cfg)
+
+	default:
+		return nil, fmt.Errorf("unknown vector backend: %s (valid options: hnsw, flat)", backend)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
@@ -75,6 +76,93 @@ func TestHNSWStore_Delete(t *testing.T) {
 	assert.True(t, store.Contains("b"))
 }
 
+func TestHNSWStore_Purge(t *testing.T) {
+	// Given: a store with vectors "a", "b" and "c", where "a" was lazily deleted
+	store, err := NewHNSWStore(DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+	require.NoError(t, store.Add(ctx, []string{"a", "b", "c"}, [][]float32{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+	}))
+	require.NoError(t, store.Delete(ctx, []string{"a"}))
+	require.Equal(t, 1, store.Stats().Orphans)
+
+	// When: I purge "b"
+	require.NoError(t, store.Purge(ctx, []string{"b"}))
+
+	// Then: only "c" remains and no orphaned nodes are left in the graph
+	assert.Equal(t, HNSWStats{ValidIDs: 1, GraphNodes: 1, Orphans: 0}, store.Stats())
+	assert.False(t, store.Contains("b"))
+
+	// And: the remaining vector is still searchable
+	results, err := store.Search(ctx, []float32{0, 0, 1, 0}, 3)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "c", results[0].ID)
+}
+
+func TestHNSWStore_Purge_CancelledLeavesStoreUnchanged(t *testing.T) {
+	// Given: a store with vectors "a", "b" and "c"
+	store, err := NewHNSWStore(DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	require.NoError(t, store.Add(context.Background(), []string{"a", "b", "c"}, [][]float32{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+	}))
+	before := store.Stats()
+
+	// When: I purge "b" with a cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = store.Purge(ctx, []string{"b"})
+
+	// Then: the purge fails and the store is untouched
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, before, store.Stats())
+	assert.True(t, store.Contains("b"))
+	results, err := store.Search(context.Background(), []float32{0, 1, 0, 0}, 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "b", results[0].ID)
+}
+
+func TestHNSWStore_Reset(t *testing.T) {
+	// Given: a logged store with 4-dimensional vectors
+	path := filepath.Join(t.TempDir(), "vectors.hnsw")
+	store, err := NewHNSWStore(DefaultVectorStoreConfig(4))
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+	require.NoError(t, store.EnableLog(path))
+
+	ctx := context.Background()
+	require.NoError(t, store.Add(ctx, []string{"a"}, [][]float32{{1, 0, 0, 0}}))
+
+	// When: I reset it to 2 dimensions
+	require.NoError(t, store.Reset(2))
+
+	// Then: it is empty and only accepts the new dimension
+	assert.Equal(t, 0, store.Count())
+	assert.Error(t, store.Add(ctx, []string{"b"}, [][]float32{{1, 0, 0, 0}}))
+	require.NoError(t, store.Add(ctx, []string{"b"}, [][]float32{{1, 0}}))
+
+	// And: the snapshot and log reload to the reset store
+	reloaded, err := NewHNSWStore(DefaultVectorStoreConfig(2))
+	require.NoError(t, err)
+	defer func() { _ = reloaded.Close() }()
+	require.NoError(t, reloaded.Load(path))
+	assert.Equal(t, []string{"b"}, reloaded.AllIDs())
+	dims, err := ReadHNSWStoreDimensions(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, dims)
+}
+
 // TS03: Update Vector (add with same ID replaces)
 func TestHNSWStore_Update(t *testing.T) {
 	// Given: a store with vector "a" = [1,0,0,0]
@@ -1145,3 +1233,47 @@ func TestHNSWStore_Count_ClosedStore(t *testing.T) {
 	// Then: returns 0 (closed store)
 	assert.Equal(t, 0, count)
 }
+
+func TestHNSWStore_SearchWithEf(t *testing.T) {
+	// Given: a store with 500 random vectors and a low configured ef
+	cfg := DefaultVectorStoreConfig(16)
+	cfg.EfSearch = MinEfSearch
+	store, err := NewHNSWStore(cfg)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	rng := rand.New(rand.NewSource(42))
+	const n = 500
+	ids := make([]string, n)
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		ids[i] = fmt.Sprintf("v%d", i)
+		vectors[i] = make([]float32, cfg.Dimensions)
+		for d := range vectors[i] {
+			vectors[i][d] = rng.Float32()*2 - 1
+		}
+	}
+	require.NoError(t, store.Add(context.Background(), ids, vectors))
+
+	// When: searching for an indexed vector with a high per-query ef
+	results, err := store.SearchWithEf(context.Background(), vectors[7], 10, MaxEfSearch)
+
+	// Then: the exact match is found and the configured ef is untouched
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "v7", results[0].ID)
+	assert.Equal(t, MinEfSearch, store.graph.EfSearch)
+
+	// And: ef <= 0 behaves like Search
+	viaSearch, err := store.Search(context.Background(), vectors[7], 10)
+	require.NoError(t, err)
+	viaDefault, err := store.SearchWithEf(context.Background(), vectors[7], 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, viaSearch, viaDefault)
+}
+
+func TestClampEfSearch(t *testing.T) {
+	assert.Equal(t, MinEfSearch, ClampEfSearch(1))
+	assert.Equal(t, 64, ClampEfSearch(64))
+	assert.Equal(t, MaxEfSearch, ClampEfSearch(1_000_000))
+}
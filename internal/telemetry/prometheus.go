@@ -0,0 +1,87 @@
+package telemetry
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// PrometheusContentType is the content type of the Prometheus text format.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// latencyBucketBounds maps each latency bucket to its upper bound in seconds,
+// in increasing order. BucketP1000 has no bound and becomes +Inf.
+var latencyBucketBounds = []struct {
+	bucket LatencyBucket
+	le     string
+}{
+	{BucketP10, "0.01"},
+	{BucketP50, "0.05"},
+	{BucketP100, "0.1"},
+	{BucketP500, "0.5"},
+}
+
+// PrometheusHandler returns an HTTP handler that exposes m in the Prometheus
+// text format. It reads the counters QueryMetrics already keeps, so nothing
+// is counted twice:
+//
+//   - amanmcp_queries_total: queries served
+//   - amanmcp_queries_by_type_total{type}: queries per classified query type
+//   - amanmcp_zero_result_queries_total: queries that returned no results
+//   - amanmcp_zero_result_ratio: zero-result share of all queries
+//   - amanmcp_query_duration_seconds: latency histogram over the LatencyBucket bounds
+//
+// Counters start at zero when m is created and reset on server restart.
+func PrometheusHandler(m *QueryMetrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", PrometheusContentType)
+		WritePrometheus(w, m.Snapshot())
+	})
+}
+
+// WritePrometheus writes a snapshot in the Prometheus text format.
+func WritePrometheus(w io.Writer, s *QueryMetricsSnapshot) {
+	writeMetricHeader(w, "amanmcp_queries_total", "counter", "Search queries served.")
+	fmt.Fprintf(w, "amanmcp_queries_total %d\n", s.TotalQueries)
+
+	writeMetricHeader(w, "amanmcp_queries_by_type_total", "counter", "Search queries by classified query type.")
+	counts := map[QueryType]int64{QueryTypeLexical: 0, QueryTypeSemantic: 0, QueryTypeMixed: 0}
+	for t, n := range s.QueryTypeCounts {
+		counts[t] = n
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		fmt.Fprintf(w, "amanmcp_queries_by_type_total{type=%s} %d\n", strconv.Quote(t), counts[QueryType(t)])
+	}
+
+	writeMetricHeader(w, "amanmcp_zero_result_queries_total", "counter", "Search queries that returned no results.")
+	fmt.Fprintf(w, "amanmcp_zero_result_queries_total %d\n", s.ZeroResultCount)
+
+	writeMetricHeader(w, "amanmcp_zero_result_ratio", "gauge", "Share of search queries that returned no results.")
+	fmt.Fprintf(w, "amanmcp_zero_result_ratio %s\n", formatFloat(s.ZeroResultPercentage()/100))
+
+	writeMetricHeader(w, "amanmcp_query_duration_seconds", "histogram", "Search query latency.")
+	var cumulative int64
+	for _, b := range latencyBucketBounds {
+		cumulative += s.LatencyDistribution[b.bucket]
+		fmt.Fprintf(w, "amanmcp_query_duration_seconds_bucket{le=%q} %d\n", b.le, cumulative)
+	}
+	cumulative += s.LatencyDistribution[BucketP1000]
+	fmt.Fprintf(w, "amanmcp_query_duration_seconds_bucket{le=\"+Inf\"} %d\n", cumulative)
+	fmt.Fprintf(w, "amanmcp_query_duration_seconds_sum %s\n", formatFloat(s.TotalLatency.Seconds()))
+	fmt.Fprintf(w, "amanmcp_query_duration_seconds_count %d\n", cumulative)
+}
+
+func writeMetricHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,64 @@
+package telemetry
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusHandler_ExposesRecordedQueries(t *testing.T) {
+	// Given: metrics with queries across types and latency buckets
+	m := NewQueryMetrics(nil)
+	defer func() { _ = m.Close() }()
+	m.Record(QueryEvent{Query: "auth", QueryType: QueryTypeLexical, ResultCount: 3, Latency: 5 * time.Millisecond})
+	m.Record(QueryEvent{Query: "how does auth work", QueryType: QueryTypeSemantic, ResultCount: 0, Latency: 75 * time.Millisecond})
+	m.Record(QueryEvent{Query: "login flow", QueryType: QueryTypeSemantic, ResultCount: 2, Latency: 2 * time.Second})
+	m.Record(QueryEvent{Query: "Session", QueryType: QueryTypeLexical, ResultCount: 0, Latency: 20 * time.Millisecond})
+
+	// When: scraping the handler
+	rec := httptest.NewRecorder()
+	PrometheusHandler(m).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	// Then: the counters are exposed in the text format
+	require.Equal(t, 200, rec.Code)
+	assert.Equal(t, PrometheusContentType, rec.Header().Get("Content-Type"))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	out := string(body)
+
+	assert.Contains(t, out, "# TYPE amanmcp_queries_total counter\namanmcp_queries_total 4\n")
+	assert.Contains(t, out, `amanmcp_queries_by_type_total{type="lexical"} 2`)
+	assert.Contains(t, out, `amanmcp_queries_by_type_total{type="mixed"} 0`)
+	assert.Contains(t, out, `amanmcp_queries_by_type_total{type="semantic"} 2`)
+	assert.Contains(t, out, "amanmcp_zero_result_queries_total 2\n")
+	assert.Contains(t, out, "amanmcp_zero_result_ratio 0.5\n")
+	assert.Contains(t, out, "# TYPE amanmcp_query_duration_seconds histogram\n")
+	assert.Contains(t, out, `amanmcp_query_duration_seconds_bucket{le="0.01"} 1`)
+	assert.Contains(t, out, `amanmcp_query_duration_seconds_bucket{le="0.05"} 2`)
+	assert.Contains(t, out, `amanmcp_query_duration_seconds_bucket{le="0.1"} 3`)
+	assert.Contains(t, out, `amanmcp_query_duration_seconds_bucket{le="0.5"} 3`)
+	assert.Contains(t, out, `amanmcp_query_duration_seconds_bucket{le="+Inf"} 4`)
+	assert.Contains(t, out, "amanmcp_query_duration_seconds_sum 2.1\n")
+	assert.Contains(t, out, "amanmcp_query_duration_seconds_count 4\n")
+}
+
+func TestWritePrometheus_Empty(t *testing.T) {
+	// Given: metrics with no queries
+	m := NewQueryMetrics(nil)
+	defer func() { _ = m.Close() }()
+
+	// When: writing the snapshot
+	rec := httptest.NewRecorder()
+	WritePrometheus(rec, m.Snapshot())
+
+	// Then: every series is present at zero
+	out := rec.Body.String()
+	assert.Contains(t, out, "amanmcp_queries_total 0\n")
+	assert.Contains(t, out, "amanmcp_zero_result_ratio 0\n")
+	assert.Contains(t, out, `amanmcp_query_duration_seconds_bucket{le="+Inf"} 0`)
+	assert.Contains(t, out, "amanmcp_query_duration_seconds_sum 0\n")
+}
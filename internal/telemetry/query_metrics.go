@@ -5,6 +5,7 @@ package telemetry
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -197,6 +198,7 @@ type QueryMetricsSnapshot struct {
 	LatencyDistribution map[LatencyBucket]int64 `json:"latency_distribution"`
 	TotalQueries        int64                   `json:"total_queries"`
 	ZeroResultCount     int64                   `json:"zero_result_count"`
+	TotalLatency        time.Duration           `json:"total_latency_ns"`
 	Since               time.Time               `json:"since"`
 
 	// Repetition metrics (SPIKE-004)
@@ -303,6 +305,10 @@ type QueryMetricsConfig struct {
 	RecentQueriesCapacity   int     // Max queries to track for repetition (default: 500)
 	RecentEmbeddingsCapacity int    // Max embeddings to sample for similarity (default: 10)
 	SimilarityThreshold     float64 // Cosine similarity threshold (default: 0.95)
+
+	// Query history (see RecentQueries)
+	HistoryCapacity int                       // Max query events kept (default: 100)
+	RedactQuery     func(query string) string // Rewrites query text before it is kept (nil = verbatim)
 }
 
 // DefaultQueryMetricsConfig returns sensible defaults.
@@ -314,6 +320,7 @@ func DefaultQueryMetricsConfig() QueryMetricsConfig {
 		RecentQueriesCapacity:    500,
 		RecentEmbeddingsCapacity: 10,
 		SimilarityThreshold:      0.95,
+		HistoryCapacity:          100,
 	}
 }
 
@@ -333,6 +340,7 @@ type QueryMetrics struct {
 	latencies       map[LatencyBucket]int64
 	totalQueries    int64
 	zeroResultCount int64
+	totalLatency    time.Duration
 	startTime       time.Time
 
 	// Repetition tracking (SPIKE-004)
@@ -341,6 +349,9 @@ type QueryMetrics struct {
 	recentEmbeddings  *CircularBuffer[[]float32]   // Circular buffer of recent embeddings
 	similarQueryCount int64                        // Count of semantically similar queries
 
+	// Raw recent events, unlike the aggregates above
+	history *CircularBuffer[QueryEvent]
+
 	// Persistence
 	store       QueryMetricsStore
 	config      QueryMetricsConfig
@@ -372,6 +383,9 @@ func NewQueryMetricsWithConfig(store QueryMetricsStore, cfg QueryMetricsConfig)
 	if cfg.SimilarityThreshold <= 0 {
 		cfg.SimilarityThreshold = 0.95
 	}
+	if cfg.HistoryCapacity <= 0 {
+		cfg.HistoryCapacity = 100
+	}
 
 	topTerms, _ := lru.New[string, int64](cfg.TopTermsCapacity)
 	recentQueries, _ := lru.New[string, struct{}](cfg.RecentQueriesCapacity)
@@ -384,6 +398,7 @@ func NewQueryMetricsWithConfig(store QueryMetricsStore, cfg QueryMetricsConfig)
 		startTime:        time.Now(),
 		recentQueries:    recentQueries,
 		recentEmbeddings: NewCircularBuffer[[]float32](cfg.RecentEmbeddingsCapacity),
+		history:          NewCircularBuffer[QueryEvent](cfg.HistoryCapacity),
 		store:            store,
 		config:           cfg,
 		stopCh:           make(chan struct{}),
@@ -440,6 +455,7 @@ func (m *QueryMetrics) Record(event QueryEvent) {
 	// Track latency
 	bucket := LatencyToBucket(event.Latency)
 	m.latencies[bucket]++
+	m.totalLatency += event.Latency
 
 	// Track exact query repetition (SPIKE-004)
 	queryHash := hashQuery(event.Query)
@@ -447,6 +463,25 @@ func (m *QueryMetrics) Record(event QueryEvent) {
 		m.exactRepeatCount++
 	}
 	m.recentQueries.Add(queryHash, struct{}{})
+
+	// Keep the raw event, redacted
+	if m.config.RedactQuery != nil {
+		event.Query = m.config.RedactQuery(event.Query)
+	}
+	m.history.Add(event)
+}
+
+// RecentQueries returns up to n of the most recently recorded query events,
+// newest first; n <= 0 returns all that are kept (see
+// QueryMetricsConfig.HistoryCapacity). Query text is redacted if
+// QueryMetricsConfig.RedactQuery is set.
+func (m *QueryMetrics) RecentQueries(n int) []QueryEvent {
+	events := m.history.Items()
+	slices.Reverse(events)
+	if n > 0 && n < len(events) {
+		events = events[:n]
+	}
+	return events
 }
 
 // hashQuery creates a normalized hash of the query for repetition detection.
@@ -572,6 +607,7 @@ func (m *QueryMetrics) Snapshot() *QueryMetricsSnapshot {
 		LatencyDistribution: latencies,
 		TotalQueries:        m.totalQueries,
 		ZeroResultCount:     m.zeroResultCount,
+		TotalLatency:        m.totalLatency,
 		Since:               m.startTime,
 		// Repetition metrics (SPIKE-004)
 		ExactRepeatCount:  m.exactRepeatCount,
@@ -1,6 +1,7 @@
 package telemetry
 
 import (
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -577,3 +578,43 @@ func TestRepetitionSummary_WithData(t *testing.T) {
 	assert.Contains(t, summary, "similar=")
 	assert.Contains(t, summary, "unique=")
 }
+
+func TestQueryMetrics_RecentQueries_NewestFirst(t *testing.T) {
+	// Given: more queries than the history keeps
+	m := NewQueryMetricsWithConfig(nil, QueryMetricsConfig{HistoryCapacity: 3})
+	defer m.Close()
+	for i := 0; i < 5; i++ {
+		m.Record(QueryEvent{
+			Query:       "query" + string(rune('A'+i)),
+			QueryType:   QueryTypeLexical,
+			ResultCount: i,
+		})
+	}
+
+	// When: reading the history
+	all := m.RecentQueries(0)
+	latest := m.RecentQueries(2)
+
+	// Then: the newest events come first, bounded by capacity and n
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"queryE", "queryD", "queryC"}, []string{all[0].Query, all[1].Query, all[2].Query})
+	assert.Equal(t, 4, all[0].ResultCount)
+	require.Len(t, latest, 2)
+	assert.Equal(t, "queryE", latest[0].Query)
+}
+
+func TestQueryMetrics_RecentQueries_Redacted(t *testing.T) {
+	// Given: a collector that redacts query text
+	m := NewQueryMetricsWithConfig(nil, QueryMetricsConfig{
+		RedactQuery: func(query string) string { return "redacted:" + strings.ToUpper(query) },
+	})
+	defer m.Close()
+
+	// When: recording a query
+	m.Record(QueryEvent{Query: "secret project", ResultCount: 0})
+
+	// Then: the history keeps only the redacted text, aggregates are unchanged
+	require.Len(t, m.RecentQueries(0), 1)
+	assert.Equal(t, "redacted:SECRET PROJECT", m.RecentQueries(0)[0].Query)
+	assert.Equal(t, int64(1), m.Snapshot().TotalQueries)
+}
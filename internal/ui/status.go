@@ -15,6 +15,9 @@ type StatusInfo struct {
 	TotalChunks int       `json:"total_chunks"`
 	LastIndexed time.Time `json:"last_indexed"`
 
+	// Language breakdown, ordered by file count (descending)
+	Languages []LanguageStat `json:"languages,omitempty"`
+
 	// Storage sizes (in bytes)
 	MetadataSize int64 `json:"metadata_size"`
 	BM25Size     int64 `json:"bm25_size"`
@@ -28,6 +31,13 @@ type StatusInfo struct {
 	WatcherStatus  string `json:"watcher_status"` // "running", "stopped", "n/a"
 }
 
+// LanguageStat holds file and chunk counts for one language.
+type LanguageStat struct {
+	Language string `json:"language"`
+	Files    int    `json:"files"`
+	Chunks   int    `json:"chunks"`
+}
+
 // StatusRenderer displays index status.
 type StatusRenderer struct {
 	out     io.Writer
@@ -57,6 +67,19 @@ func (r *StatusRenderer) Render(info StatusInfo) error {
 	}
 	_, _ = fmt.Fprintln(r.out)
 
+	// Language breakdown
+	if len(info.Languages) > 0 {
+		_, _ = fmt.Fprintln(r.out, "  Languages:")
+		for _, lang := range info.Languages {
+			pct := 0.0
+			if info.TotalFiles > 0 {
+				pct = float64(lang.Files) * 100 / float64(info.TotalFiles)
+			}
+			_, _ = fmt.Fprintf(r.out, "    %-12s %5.1f%%  %d files, %d chunks\n", lang.Language, pct, lang.Files, lang.Chunks)
+		}
+		_, _ = fmt.Fprintln(r.out)
+	}
+
 	// Storage sizes
 	_, _ = fmt.Fprintln(r.out, "  Storage:")
 	_, _ = fmt.Fprintf(r.out, "    Metadata:   %s\n", FormatBytes(info.MetadataSize))
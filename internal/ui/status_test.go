@@ -87,6 +87,31 @@ func TestStatusRenderer_Render_Basic(t *testing.T) {
 	assert.Contains(t, output, "ready")
 }
 
+func TestStatusRenderer_Render_Languages(t *testing.T) {
+	// Given: status renderer
+	buf := &bytes.Buffer{}
+	r := NewStatusRenderer(buf, true)
+
+	// When: rendering status info with a language breakdown
+	info := StatusInfo{
+		ProjectName: "polyglot",
+		TotalFiles:  4,
+		TotalChunks: 10,
+		Languages: []LanguageStat{
+			{Language: "go", Files: 3, Chunks: 8},
+			{Language: "markdown", Files: 1, Chunks: 2},
+		},
+	}
+	require.NoError(t, r.Render(info))
+
+	// Then: each language is listed with its share of files
+	output := buf.String()
+	assert.Contains(t, output, "Languages:")
+	assert.Contains(t, output, "75.0%")
+	assert.Contains(t, output, "3 files, 8 chunks")
+	assert.Contains(t, output, "markdown")
+}
+
 func TestStatusRenderer_RenderJSON(t *testing.T) {
 	// Given: status renderer
 	buf := &bytes.Buffer{}
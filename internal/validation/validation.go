@@ -470,7 +470,8 @@ func NewValidator(ctx context.Context, projectRoot string) (*Validator, error) {
 	}
 
 	// Use factory for BM25 backend (SQLite supports concurrent access)
-	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, store.DefaultBM25Config(), backend)
+	bm25Config := store.BM25ConfigWithTermLength(cfg.Search.BM25MinTermLength, cfg.Search.BM25KeepShortTerms)
+	bm25, err := store.NewBM25IndexWithBackend(bm25BasePath, bm25Config, backend)
 	if err != nil {
 		metadata.Close()
 		return nil, fmt.Errorf("failed to open BM25 index: %w", err)
@@ -519,7 +520,7 @@ func newValidatorWithStores(ctx context.Context, projectRoot string, cfg *config
 	vectorPath := filepath.Join(dataDir, "vectors.hnsw")
 	dimensions := embedder.Dimensions()
 	vectorConfig := store.DefaultVectorStoreConfig(dimensions)
-	vector, err := store.NewHNSWStore(vectorConfig)
+	vector, err := store.NewVectorStoreWithBackend(vectorConfig, cfg.Search.VectorBackend)
 	if err != nil {
 		embedder.Close()
 		bm25.Close()
@@ -534,6 +535,7 @@ func newValidatorWithStores(ctx context.Context, projectRoot string, cfg *config
 
 	// Create search engine
 	engineConfig := search.DefaultConfig()
+	engineConfig.QueryInstruction = search.QueryInstructionForModel(embedder.ModelName())
 	engineConfig.MetadataRules = cfg.SearchMetadataRules()
 	engineConfig.ProfileRules = cfg.SearchProfileRules()
 	if cfg.Search.BM25Weight > 0 || cfg.Search.SemanticWeight > 0 {
@@ -139,8 +139,28 @@ func (d *Debouncer) flush() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
+	d.flushLocked()
+}
+
+// Flush immediately emits all pending events instead of waiting for the
+// debounce window, and cancels the scheduled flush. Returns the number of
+// events emitted. Safe to call concurrently with Add: an event either makes
+// this batch or starts a new window, so nothing is emitted twice.
+func (d *Debouncer) Flush() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	return d.flushLocked()
+}
+
+// flushLocked emits all pending events. Caller must hold d.mu.
+func (d *Debouncer) flushLocked() int {
 	if d.stopped || len(d.pending) == 0 {
-		return
+		return 0
 	}
 
 	events := make([]FileEvent, 0, len(d.pending))
@@ -152,10 +172,12 @@ func (d *Debouncer) flush() {
 	// Non-blocking send
 	select {
 	case d.output <- events:
+		return len(events)
 	default:
 		slog.Warn("debouncer output full, dropping batch",
 			slog.Int("batch_size", len(events)),
 		)
+		return 0
 	}
 }
 
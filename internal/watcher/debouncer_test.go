@@ -209,3 +209,53 @@ func TestDebouncer_CreateThenModify_CreateOnly(t *testing.T) {
 		t.Fatal("timeout waiting for debounced event")
 	}
 }
+
+func TestDebouncer_Flush_EmitsPendingImmediately(t *testing.T) {
+	// Given: a debouncer with a window far longer than the test
+	d := NewDebouncer(time.Hour)
+	defer d.Stop()
+	d.Add(FileEvent{Path: "a.go", Operation: OpCreate, Timestamp: time.Now()})
+	d.Add(FileEvent{Path: "a.go", Operation: OpModify, Timestamp: time.Now()})
+	d.Add(FileEvent{Path: "b.go", Operation: OpModify, Timestamp: time.Now()})
+
+	// When: flushing manually
+	n := d.Flush()
+
+	// Then: the coalesced batch is emitted without waiting for the window
+	assert.Equal(t, 2, n)
+	select {
+	case events := <-d.Output():
+		require.Len(t, events, 2)
+	default:
+		t.Fatal("expected flushed batch to be ready immediately")
+	}
+
+	// And: a second flush has nothing to emit
+	assert.Equal(t, 0, d.Flush())
+}
+
+func TestDebouncer_Flush_NoDoubleEmitWithTimer(t *testing.T) {
+	// Given: a pending event whose window is about to expire
+	d := NewDebouncer(20 * time.Millisecond)
+	defer d.Stop()
+	d.Add(FileEvent{Path: "a.go", Operation: OpModify, Timestamp: time.Now()})
+
+	// When: flushing manually just before the timer
+	require.Equal(t, 1, d.Flush())
+	<-d.Output()
+
+	// Then: the scheduled flush does not emit the event again
+	select {
+	case events := <-d.Output():
+		t.Fatalf("unexpected second batch: %v", events)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestDebouncer_Flush_AfterStop_NoOp(t *testing.T) {
+	d := NewDebouncer(time.Hour)
+	d.Add(FileEvent{Path: "a.go", Operation: OpModify, Timestamp: time.Now()})
+	d.Stop()
+
+	assert.Equal(t, 0, d.Flush())
+}
@@ -33,6 +33,76 @@ type HybridWatcher struct {
 	mu             sync.RWMutex
 	stopped        bool
 	droppedBatches atomic.Uint64
+
+	// overflow holds events while the events channel is full (see emitEvents)
+	overflow        overflowBuffer
+	overflowMu      sync.Mutex
+	coalescedEvents atomic.Uint64
+	droppedEvents   atomic.Uint64
+
+	// pause state (see Pause): suppressed counts discarded file events and
+	// held keeps .gitignore and config events for delivery on Resume
+	pauseMu    sync.Mutex
+	paused     bool
+	suppressed int
+	held       []FileEvent
+}
+
+// overflowRetryInterval is how often a non-empty overflow buffer retries
+// delivery to the events channel.
+const overflowRetryInterval = 50 * time.Millisecond
+
+// overflowBuffer collapses pending events per path, keeping the order in
+// which paths first overflowed.
+type overflowBuffer struct {
+	index  map[string]int
+	events []FileEvent
+}
+
+// add stores event, replacing a pending event for the same path so the
+// latest operation wins. It reports whether an event was replaced, and
+// returns false for added when a new path does not fit under limit.
+func (b *overflowBuffer) add(event FileEvent, limit int) (added, coalesced bool) {
+	if i, ok := b.index[event.Path]; ok {
+		b.events[i] = event
+		return true, true
+	}
+	if len(b.events) >= limit {
+		return false, false
+	}
+	if b.index == nil {
+		b.index = make(map[string]int)
+	}
+	b.index[event.Path] = len(b.events)
+	b.events = append(b.events, event)
+	return true, false
+}
+
+// take removes and returns all pending events.
+func (b *overflowBuffer) take() []FileEvent {
+	events := b.events
+	b.index = nil
+	b.events = nil
+	return events
+}
+
+// BackpressureStats describes how far event delivery lags behind the
+// consumer of Events.
+type BackpressureStats struct {
+	// QueuedBatches is the number of batches waiting in the events channel.
+	QueuedBatches int
+
+	// OverflowEvents is the number of events waiting in the overflow buffer
+	// because the events channel was full.
+	OverflowEvents int
+
+	// CoalescedEvents counts overflowed events that replaced a pending event
+	// for the same path.
+	CoalescedEvents uint64
+
+	// DroppedEvents counts events discarded because the overflow buffer
+	// held Options.MaxOverflowEvents paths.
+	DroppedEvents uint64
 }
 
 // Ensure HybridWatcher implements Watcher interface.
@@ -104,7 +174,7 @@ func (h *HybridWatcher) Start(ctx context.Context, path string) error {
 // startFsnotify starts the fsnotify-based watcher.
 func (h *HybridWatcher) startFsnotify(ctx context.Context) error {
 	// Recursively add all directories to watch
-	if err := h.addRecursive(h.rootPath); err != nil {
+	if err := h.addRecursive(ctx, h.rootPath); err != nil {
 		return fmt.Errorf("add directories to watcher: %w", err)
 	}
 
@@ -260,8 +330,10 @@ func (h *HybridWatcher) handleFsnotifyEvent(event fsnotify.Event) {
 	})
 }
 
-// forwardDebouncedEvents forwards debounced events to the output channel.
+// forwardDebouncedEvents forwards debounced events to the output channel,
+// retrying delivery of overflowed events until the consumer catches up.
 func (h *HybridWatcher) forwardDebouncedEvents(ctx context.Context) {
+	var retry <-chan time.Time
 	for {
 		select {
 		case <-ctx.Done():
@@ -272,16 +344,27 @@ func (h *HybridWatcher) forwardDebouncedEvents(ctx context.Context) {
 			if !ok {
 				return
 			}
-			if len(events) == 0 {
-				continue
+			if events = h.holdWhilePaused(events); len(events) > 0 {
+				h.emitEvents(events)
 			}
-			h.emitEvents(events)
+		case <-retry:
+			h.drainOverflow()
+		}
+
+		retry = nil
+		if h.Backpressure().OverflowEvents > 0 {
+			retry = time.After(overflowRetryInterval)
 		}
 	}
 }
 
-// addRecursive adds all directories under root to the fsnotify watcher.
-func (h *HybridWatcher) addRecursive(root string) error {
+// addRecursive adds all directories under root to the fsnotify watcher,
+// listed by Options.ScanDirs when set.
+func (h *HybridWatcher) addRecursive(ctx context.Context, root string) error {
+	if h.opts.ScanDirs != nil {
+		return h.addScannedDirs(ctx, root)
+	}
+
 	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil // Skip files we can't access
@@ -307,6 +390,28 @@ func (h *HybridWatcher) addRecursive(root string) error {
 	})
 }
 
+// addScannedDirs adds the directories listed by Options.ScanDirs to the
+// fsnotify watcher, still skipping those the watcher itself ignores.
+func (h *HybridWatcher) addScannedDirs(ctx context.Context, root string) error {
+	dirs, err := h.opts.ScanDirs(ctx, root)
+	if err != nil {
+		return fmt.Errorf("scan directories: %w", err)
+	}
+
+	var addErr error
+	for path := range dirs {
+		if addErr != nil {
+			continue // Drain so the scan can finish
+		}
+		relPath, _ := filepath.Rel(h.rootPath, path)
+		if relPath != "." && h.shouldIgnoreDir(relPath) {
+			continue
+		}
+		addErr = h.fsWatcher.Add(path)
+	}
+	return addErr
+}
+
 // shouldIgnoreDir checks if a directory should be ignored.
 func (h *HybridWatcher) shouldIgnoreDir(relPath string) bool {
 	// Always ignore .git directory
@@ -392,32 +497,110 @@ func (h *HybridWatcher) loadGitignore() {
 	})
 }
 
-// emitEvents sends events to the output channel.
+// emitEvents sends events to the output channel without blocking.
+//
+// When the channel is full, for example while a branch switch produces
+// thousands of events faster than they are indexed, events go to a bounded
+// overflow buffer instead. It collapses events per path, latest operation
+// wins, and is delivered as a single batch once the channel has room. Later
+// batches queue behind it so per-path order is kept. Only events for new
+// paths arriving while the buffer is at Options.MaxOverflowEvents are
+// dropped.
 func (h *HybridWatcher) emitEvents(events []FileEvent) {
+	// Hold the read lock so Stop cannot close the channel mid-send
 	h.mu.RLock()
-	stopped := h.stopped
-	h.mu.RUnlock()
+	defer h.mu.RUnlock()
 
-	if stopped {
+	if h.stopped {
+		return
+	}
+
+	h.overflowMu.Lock()
+	defer h.overflowMu.Unlock()
+
+	if len(h.overflow.events) == 0 {
+		select {
+		case h.events <- events:
+			return
+		default:
+			slog.Warn("event buffer full, coalescing events until indexing catches up",
+				slog.Int("queued_batches", len(h.events)))
+		}
+	}
+
+	dropped := 0
+	for _, event := range events {
+		added, coalesced := h.overflow.add(event, h.opts.MaxOverflowEvents)
+		switch {
+		case !added:
+			dropped++
+		case coalesced:
+			h.coalescedEvents.Add(1)
+		}
+	}
+	if dropped > 0 {
+		total := h.droppedEvents.Add(uint64(dropped))
+		count := h.droppedBatches.Add(1)
+		slog.Warn("event overflow buffer full, dropping events",
+			slog.Int("dropped", dropped),
+			slog.Uint64("total_dropped_events", total),
+			slog.Uint64("total_dropped_batches", count))
+	}
+
+	h.sendOverflowLocked()
+}
+
+// drainOverflow retries delivery of overflowed events.
+func (h *HybridWatcher) drainOverflow() {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.stopped {
 		return
 	}
 
+	h.overflowMu.Lock()
+	defer h.overflowMu.Unlock()
+	h.sendOverflowLocked()
+}
+
+// sendOverflowLocked delivers the overflow buffer as one batch if the channel
+// has room. Caller must hold h.mu (read) and h.overflowMu.
+func (h *HybridWatcher) sendOverflowLocked() {
+	if len(h.overflow.events) == 0 {
+		return
+	}
 	select {
-	case h.events <- events:
+	case h.events <- h.overflow.events:
+		slog.Info("delivered coalesced events",
+			slog.Int("events", len(h.overflow.events)),
+			slog.Uint64("total_coalesced", h.coalescedEvents.Load()))
+		h.overflow.take()
 	default:
-		count := h.droppedBatches.Add(1)
-		slog.Warn("event buffer full, dropping batch",
-			slog.Int("batch_size", len(events)),
-			slog.Uint64("total_dropped_batches", count),
-		)
 	}
 }
 
-// DroppedBatches returns the number of event batches dropped due to buffer overflow.
+// DroppedBatches returns the number of event batches that lost events because
+// the overflow buffer was full.
 func (h *HybridWatcher) DroppedBatches() uint64 {
 	return h.droppedBatches.Load()
 }
 
+// Backpressure returns the current event queue depth and the coalesced and
+// dropped event counters. Safe to call concurrently.
+func (h *HybridWatcher) Backpressure() BackpressureStats {
+	h.overflowMu.Lock()
+	overflowed := len(h.overflow.events)
+	h.overflowMu.Unlock()
+
+	return BackpressureStats{
+		QueuedBatches:   len(h.events),
+		OverflowEvents:  overflowed,
+		CoalescedEvents: h.coalescedEvents.Load(),
+		DroppedEvents:   h.droppedEvents.Load(),
+	}
+}
+
 // emitError sends an error to the error channel.
 func (h *HybridWatcher) emitError(err error) {
 	h.mu.RLock()
@@ -462,6 +645,105 @@ func (h *HybridWatcher) Stop() error {
 	return nil
 }
 
+// Flush emits any pending debounced events immediately rather than waiting
+// out the debounce window. Used by tests and "reindex now" to force a sync.
+// Returns the number of events flushed; the batch arrives on Events() once the
+// forwarder picks it up. Safe to call concurrently with event arrival.
+func (h *HybridWatcher) Flush() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.stopped {
+		return 0
+	}
+	return h.debouncer.Flush()
+}
+
+// Pause stops delivering events on Events() until Resume is called, for
+// bulk operations such as a scripted refactor that rewrites many files.
+//
+// The underlying fsnotify or polling watcher keeps running and its events are
+// still debounced, so its buffers cannot overflow; the resulting batches are
+// discarded instead of queued. .gitignore and config change events are held
+// and delivered on Resume, since they change what should be indexed.
+// Pausing a paused or stopped watcher is a no-op.
+func (h *HybridWatcher) Pause() {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.stopped {
+		return
+	}
+
+	h.pauseMu.Lock()
+	defer h.pauseMu.Unlock()
+	if !h.paused {
+		h.paused = true
+		slog.Info("watcher paused")
+	}
+}
+
+// Resume restarts event delivery after Pause and returns the number of file
+// events suppressed while paused.
+//
+// Held .gitignore and config events are delivered first. When resync is true
+// and events were suppressed, they are followed by a single OpResync event so
+// the consumer can reconcile once instead of replaying each change. Resuming
+// a watcher that is not paused is a no-op.
+func (h *HybridWatcher) Resume(resync bool) int {
+	h.pauseMu.Lock()
+	if !h.paused {
+		h.pauseMu.Unlock()
+		return 0
+	}
+	h.paused = false
+	suppressed := h.suppressed
+	events := h.held
+	h.suppressed = 0
+	h.held = nil
+	h.pauseMu.Unlock()
+
+	if resync && suppressed > 0 {
+		events = append(events, FileEvent{Operation: OpResync, Timestamp: time.Now()})
+	}
+	slog.Info("watcher resumed",
+		slog.Int("suppressed_events", suppressed),
+		slog.Bool("resync", resync && suppressed > 0))
+
+	if len(events) > 0 {
+		h.emitEvents(events)
+	}
+	return suppressed
+}
+
+// IsPaused reports whether event delivery is paused.
+func (h *HybridWatcher) IsPaused() bool {
+	h.pauseMu.Lock()
+	defer h.pauseMu.Unlock()
+	return h.paused
+}
+
+// holdWhilePaused returns events unchanged when the watcher is not paused.
+// Otherwise it counts file events as suppressed, holds .gitignore and config
+// events for Resume, and returns nil.
+func (h *HybridWatcher) holdWhilePaused(events []FileEvent) []FileEvent {
+	h.pauseMu.Lock()
+	defer h.pauseMu.Unlock()
+
+	if !h.paused {
+		return events
+	}
+	for _, event := range events {
+		switch event.Operation {
+		case OpGitignoreChange, OpConfigChange:
+			h.held = append(h.held, event)
+		default:
+			h.suppressed++
+		}
+	}
+	return nil
+}
+
 // Events returns the channel of batched file events.
 func (h *HybridWatcher) Events() <-chan []FileEvent {
 	return h.events
@@ -336,6 +336,67 @@ loop:
 	require.NoError(t, w.Stop())
 }
 
+func TestHybridWatcher_WatchesOnlyScannedDirs(t *testing.T) {
+	// Given: a source directory and a directory excluded from indexing
+	tempDir := t.TempDir()
+	srcDir := filepath.Join(tempDir, "src")
+	excludedDir := filepath.Join(tempDir, "node_modules")
+	require.NoError(t, os.MkdirAll(srcDir, 0o755))
+	require.NoError(t, os.MkdirAll(excludedDir, 0o755))
+
+	// And: a directory lister that leaves out the excluded directory
+	opts := Options{
+		DebounceWindow:  50 * time.Millisecond,
+		EventBufferSize: 100,
+		ScanDirs: func(_ context.Context, root string) (<-chan string, error) {
+			dirs := make(chan string, 2)
+			dirs <- root
+			dirs <- filepath.Join(root, "src")
+			close(dirs)
+			return dirs, nil
+		},
+	}.WithDefaults()
+
+	w, err := NewHybridWatcher(opts)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = w.Start(ctx, tempDir)
+	}()
+
+	// Wait for watcher to initialize
+	time.Sleep(100 * time.Millisecond)
+
+	// When: files are created in both directories
+	require.NoError(t, os.WriteFile(filepath.Join(excludedDir, "dep.js"), []byte("x"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "main.go"), []byte("package main"), 0o644))
+
+	// Then: only the scanned directory's file is reported
+	var gotSrcFile bool
+	timeout := time.After(1 * time.Second)
+loop:
+	for {
+		select {
+		case events := <-w.Events():
+			for _, e := range events {
+				if filepath.Base(e.Path) == "main.go" {
+					gotSrcFile = true
+				}
+				assert.NotContains(t, e.Path, "node_modules",
+					"should not receive events for unscanned directories")
+			}
+		case <-timeout:
+			break loop
+		}
+	}
+
+	assert.True(t, gotSrcFile, "should have received event for src/main.go")
+	require.NoError(t, w.Stop())
+}
+
 func TestHybridWatcher_DetectsNewSubdirectory(t *testing.T) {
 	// Given: a temp directory and hybrid watcher
 	tempDir := t.TempDir()
@@ -414,23 +475,193 @@ func TestHybridWatcher_DroppedBatches_InitiallyZero(t *testing.T) {
 }
 
 func TestHybridWatcher_DroppedBatches_IncrementsOnOverflow(t *testing.T) {
-	// Given: a hybrid watcher with a tiny buffer
+	// Given: a hybrid watcher with a tiny buffer and overflow buffer
 	opts := Options{
-		EventBufferSize: 1, // Very small buffer to trigger overflow
+		EventBufferSize:   1, // Very small buffer to trigger overflow
+		MaxOverflowEvents: 1,
 	}.WithDefaults()
 
 	w, err := NewHybridWatcher(opts)
 	require.NoError(t, err)
 	defer func() { _ = w.Stop() }()
 
-	// When: we emit more batches than the buffer can hold
+	// When: we emit more batches than the buffer and overflow can hold
 	// Fill the buffer first
 	w.emitEvents([]FileEvent{{Path: "/test1.go", Operation: OpCreate}})
 
-	// Now emit more - these should be dropped
+	// The next path overflows, the ones after it are dropped
 	w.emitEvents([]FileEvent{{Path: "/test2.go", Operation: OpCreate}})
 	w.emitEvents([]FileEvent{{Path: "/test3.go", Operation: OpCreate}})
+	w.emitEvents([]FileEvent{{Path: "/test4.go", Operation: OpCreate}})
 
 	// Then: dropped batches count reflects the drops
 	assert.Equal(t, uint64(2), w.DroppedBatches())
+	assert.Equal(t, uint64(2), w.Backpressure().DroppedEvents)
+}
+
+func TestHybridWatcher_Overflow_CoalescesPerPathAndDelivers(t *testing.T) {
+	// Given: a hybrid watcher whose event buffer is already full
+	w, err := NewHybridWatcher(Options{EventBufferSize: 1}.WithDefaults())
+	require.NoError(t, err)
+	defer func() { _ = w.Stop() }()
+	w.emitEvents([]FileEvent{{Path: "first.go", Operation: OpModify}})
+
+	// When: a burst of events arrives, touching some paths repeatedly
+	w.emitEvents([]FileEvent{{Path: "a.go", Operation: OpCreate}, {Path: "b.go", Operation: OpModify}})
+	w.emitEvents([]FileEvent{{Path: "a.go", Operation: OpModify}, {Path: "c.go", Operation: OpCreate}})
+	w.emitEvents([]FileEvent{{Path: "b.go", Operation: OpDelete}})
+
+	// Then: nothing is dropped; pending events are collapsed per path
+	stats := w.Backpressure()
+	assert.Equal(t, 1, stats.QueuedBatches)
+	assert.Equal(t, 3, stats.OverflowEvents)
+	assert.Equal(t, uint64(2), stats.CoalescedEvents)
+	assert.Zero(t, stats.DroppedEvents)
+	assert.Zero(t, w.DroppedBatches())
+
+	// When: the consumer catches up
+	first := <-w.Events()
+	w.drainOverflow()
+
+	// Then: the overflow arrives as one batch in first-seen order, latest op per path
+	assert.Equal(t, "first.go", first[0].Path)
+	select {
+	case batch := <-w.Events():
+		require.Len(t, batch, 3)
+		assert.Equal(t, FileEvent{Path: "a.go", Operation: OpModify}, batch[0])
+		assert.Equal(t, FileEvent{Path: "b.go", Operation: OpDelete}, batch[1])
+		assert.Equal(t, FileEvent{Path: "c.go", Operation: OpCreate}, batch[2])
+	default:
+		t.Fatal("overflowed events were not delivered")
+	}
+	assert.Zero(t, w.Backpressure().OverflowEvents)
+}
+
+func TestHybridWatcher_Overflow_LaterBatchesQueueBehindOverflow(t *testing.T) {
+	// Given: a full event buffer with one overflowed event
+	w, err := NewHybridWatcher(Options{EventBufferSize: 1}.WithDefaults())
+	require.NoError(t, err)
+	defer func() { _ = w.Stop() }()
+	w.emitEvents([]FileEvent{{Path: "first.go", Operation: OpModify}})
+	w.emitEvents([]FileEvent{{Path: "x.go", Operation: OpCreate}})
+
+	// When: the buffer drains and another batch arrives before the retry
+	<-w.Events()
+	w.emitEvents([]FileEvent{{Path: "x.go", Operation: OpDelete}})
+
+	// Then: the new event joins the overflow instead of overtaking it
+	batch := <-w.Events()
+	assert.Equal(t, []FileEvent{{Path: "x.go", Operation: OpDelete}}, batch)
+	assert.Zero(t, w.Backpressure().OverflowEvents)
+}
+
+func TestHybridWatcher_Flush_EmitsWithoutWaitingForWindow(t *testing.T) {
+	// Given: a watcher with a debounce window far longer than the test
+	tempDir := t.TempDir()
+	w, err := NewHybridWatcher(Options{
+		DebounceWindow:  time.Hour,
+		EventBufferSize: 100,
+	}.WithDefaults())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = w.Start(ctx, tempDir) }()
+	time.Sleep(200 * time.Millisecond) // Wait for watcher to be ready
+
+	// When: a file is created and the watcher is flushed once it has seen it
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "flush.go"), []byte("package main"), 0o644))
+	require.Eventually(t, func() bool { return w.Flush() > 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// Then: the event arrives right away
+	select {
+	case events := <-w.Events():
+		require.NotEmpty(t, events)
+		assert.Equal(t, "flush.go", events[0].Path)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for flushed events")
+	}
+
+	// And: flushing a stopped watcher is a no-op
+	require.NoError(t, w.Stop())
+	assert.Equal(t, 0, w.Flush())
+}
+
+func TestHybridWatcher_Pause_SuppressesEventsAndResumeSignalsResync(t *testing.T) {
+	// Given: a started watcher that is paused
+	tempDir := t.TempDir()
+	w, err := NewHybridWatcher(Options{
+		DebounceWindow:  10 * time.Millisecond,
+		EventBufferSize: 100,
+	}.WithDefaults())
+	require.NoError(t, err)
+	defer func() { _ = w.Stop() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = w.Start(ctx, tempDir) }()
+	time.Sleep(200 * time.Millisecond) // Wait for watcher to be ready
+
+	w.Pause()
+	require.True(t, w.IsPaused())
+
+	// When: many files are written while paused
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte("package main"), 0o644))
+	}
+	time.Sleep(300 * time.Millisecond) // Let the debouncer emit its batches
+
+	// Then: no events are delivered
+	select {
+	case events := <-w.Events():
+		t.Fatalf("unexpected events while paused: %v", events)
+	default:
+	}
+
+	// When: resuming with resync
+	suppressed := w.Resume(true)
+
+	// Then: a single resync event replaces the suppressed events
+	assert.False(t, w.IsPaused())
+	assert.Positive(t, suppressed)
+	select {
+	case events := <-w.Events():
+		require.Len(t, events, 1)
+		assert.Equal(t, OpResync, events[0].Operation)
+		assert.Empty(t, events[0].Path)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for resync event")
+	}
+}
+
+func TestHybridWatcher_Pause_HoldsGitignoreAndConfigEvents(t *testing.T) {
+	// Given: a paused watcher
+	w, err := NewHybridWatcher(DefaultOptions())
+	require.NoError(t, err)
+	defer func() { _ = w.Stop() }()
+	w.Pause()
+
+	// When: a batch with file, .gitignore and config events arrives, then
+	// the watcher resumes without resync
+	held := w.holdWhilePaused([]FileEvent{
+		{Path: "a.go", Operation: OpModify},
+		{Path: ".gitignore", Operation: OpGitignoreChange},
+		{Path: ".amanmcp.yaml", Operation: OpConfigChange},
+	})
+	suppressed := w.Resume(false)
+
+	// Then: only the .gitignore and config events are delivered
+	assert.Nil(t, held)
+	assert.Equal(t, 1, suppressed)
+	select {
+	case events := <-w.Events():
+		require.Len(t, events, 2)
+		assert.Equal(t, OpGitignoreChange, events[0].Operation)
+		assert.Equal(t, OpConfigChange, events[1].Operation)
+	default:
+		t.Fatal("held events were not delivered")
+	}
+
+	// And: resuming again is a no-op
+	assert.Zero(t, w.Resume(true))
 }
@@ -24,6 +24,10 @@ const (
 	// OpConfigChange indicates the .amanmcp.yaml config file was modified.
 	// This triggers reload of exclude patterns and reconciliation.
 	OpConfigChange
+	// OpResync indicates file events were suppressed while the watcher was
+	// paused. Path is empty; consumers should reconcile the whole index
+	// instead of expecting per-file events.
+	OpResync
 )
 
 // String returns a human-readable representation of the operation.
@@ -41,6 +45,8 @@ func (op Operation) String() string {
 		return "GITIGNORE_CHANGE"
 	case OpConfigChange:
 		return "CONFIG_CHANGE"
+	case OpResync:
+		return "RESYNC"
 	default:
 		return "UNKNOWN"
 	}
@@ -100,18 +106,31 @@ type Options struct {
 	// Default: 1000
 	EventBufferSize int
 
+	// MaxOverflowEvents bounds the overflow buffer that holds events while
+	// the event channel is full. Pending events are collapsed per path, so
+	// it bounds distinct paths; events for new paths beyond it are dropped.
+	// Default: 50000
+	MaxOverflowEvents int
+
 	// IgnorePatterns are additional patterns to ignore beyond .gitignore.
 	// Patterns use gitignore syntax.
 	IgnorePatterns []string
+
+	// ScanDirs, when set, lists the directories under root to watch instead
+	// of walking the whole tree, so that directories excluded from indexing
+	// are not watched either (see scanner.Scanner.ScanDirs).
+	// Default: nil (watch every directory not ignored)
+	ScanDirs func(ctx context.Context, root string) (<-chan string, error)
 }
 
 // DefaultOptions returns the default watcher options.
 func DefaultOptions() Options {
 	return Options{
-		DebounceWindow:  200 * time.Millisecond,
-		PollInterval:    5 * time.Second,
-		EventBufferSize: 1000,
-		IgnorePatterns:  nil,
+		DebounceWindow:    200 * time.Millisecond,
+		PollInterval:      5 * time.Second,
+		EventBufferSize:   1000,
+		MaxOverflowEvents: 50000,
+		IgnorePatterns:    nil,
 	}
 }
 
@@ -133,5 +152,8 @@ func (o Options) WithDefaults() Options {
 	if o.EventBufferSize == 0 {
 		o.EventBufferSize = defaults.EventBufferSize
 	}
+	if o.MaxOverflowEvents == 0 {
+		o.MaxOverflowEvents = defaults.MaxOverflowEvents
+	}
 	return o
 }
@@ -0,0 +1,214 @@
+package indexer
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/Aman-CERP/amanmcp/internal/index"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// DefaultNearDuplicateDistance is the default maximum SimHash Hamming distance
+// at which two chunks are considered near-duplicates.
+const DefaultNearDuplicateDistance = 4
+
+// DefaultNearDuplicateMinTokens is the default minimum chunk size (in tokens)
+// for dedup. Smaller chunks (closing braces, one-line getters) collide too
+// easily to be deduplicated safely.
+const DefaultNearDuplicateMinTokens = index.DefaultNearDuplicateMinTokens
+
+// NearDuplicateConfig configures NearDuplicateFilter.
+type NearDuplicateConfig struct {
+	// MaxDistance is the maximum Hamming distance between 64-bit SimHash
+	// fingerprints for two chunks to count as near-duplicates.
+	// Default: 4. Values above 16 are clamped to 16.
+	MaxDistance int
+
+	// MinTokens skips dedup for chunks with fewer whitespace-separated tokens.
+	// Default: 20.
+	MinTokens int
+}
+
+// DefaultNearDuplicateConfig returns the default near-duplicate configuration.
+func DefaultNearDuplicateConfig() NearDuplicateConfig {
+	return NearDuplicateConfig{
+		MaxDistance: DefaultNearDuplicateDistance,
+		MinTokens:   DefaultNearDuplicateMinTokens,
+	}
+}
+
+// NearDuplicateFilter drops chunks whose content is a near-duplicate of an
+// already-indexed chunk, using a 64-bit SimHash over token shingles.
+//
+// Vendored or copy-pasted files otherwise produce several near-identical
+// chunks that crowd out other results. Skipped chunks are recorded against
+// their canonical (first-seen) chunk so callers can report "N near-duplicates".
+//
+// Canonical fingerprints are looked up in an index.SimHashIndex.
+//
+// NearDuplicateFilter is safe for concurrent use.
+type NearDuplicateFilter struct {
+	minTokens int
+
+	mu         sync.RWMutex
+	index      *index.SimHashIndex                // canonical chunk fingerprints
+	canonical  map[string]string                  // duplicate chunk ID -> canonical chunk ID
+	duplicates map[string]map[string]*store.Chunk // canonical chunk ID -> duplicates by ID
+}
+
+// NewNearDuplicateFilter creates a filter with the given configuration.
+// Zero values fall back to defaults.
+func NewNearDuplicateFilter(cfg NearDuplicateConfig) *NearDuplicateFilter {
+	if cfg.MaxDistance <= 0 {
+		cfg.MaxDistance = DefaultNearDuplicateDistance
+	}
+	if cfg.MinTokens <= 0 {
+		cfg.MinTokens = DefaultNearDuplicateMinTokens
+	}
+
+	return &NearDuplicateFilter{
+		minTokens:  cfg.MinTokens,
+		index:      index.NewSimHashIndex(cfg.MaxDistance),
+		canonical:  make(map[string]string),
+		duplicates: make(map[string]map[string]*store.Chunk),
+	}
+}
+
+// Filter returns the chunks that are not near-duplicates of an already-seen
+// chunk (including earlier chunks in the same batch). Kept chunks become
+// canonical candidates for later batches. Re-indexing a known chunk ID
+// replaces its previous fingerprint; duplicates recorded against it are
+// filtered again after the batch and returned too unless they still match.
+func (f *NearDuplicateFilter) Filter(chunks []*store.Chunk) []*store.Chunk {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	queued := make(map[string]struct{}, len(chunks))
+	for _, c := range chunks {
+		queued[c.ID] = struct{}{}
+	}
+	// Released duplicates are appended; the full slice expression keeps
+	// that from writing into the caller's array
+	chunks = chunks[:len(chunks):len(chunks)]
+
+	kept := make([]*store.Chunk, 0, len(chunks))
+	for i := 0; i < len(chunks); i++ {
+		c := chunks[i]
+		for _, released := range f.forgetLocked(c.ID) {
+			if _, ok := queued[released.ID]; !ok {
+				queued[released.ID] = struct{}{}
+				chunks = append(chunks, released)
+			}
+		}
+
+		fp, ok := index.SimHash(c.Content, f.minTokens)
+		if !ok {
+			kept = append(kept, c)
+			continue
+		}
+
+		if canonicalID, ok := f.index.Nearest(fp); ok {
+			f.canonical[c.ID] = canonicalID
+			if f.duplicates[canonicalID] == nil {
+				f.duplicates[canonicalID] = make(map[string]*store.Chunk)
+			}
+			f.duplicates[canonicalID][c.ID] = c
+			continue
+		}
+
+		f.index.Add(c.ID, fp)
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+// Forget removes chunk IDs from the filter, e.g. after they are deleted from
+// the index. Duplicates recorded against a forgotten canonical chunk are
+// released too and returned, sorted by ID and excluding ids, so the caller
+// can index them in its place (see HybridIndexer.Delete).
+func (f *NearDuplicateFilter) Forget(ids []string) []*store.Chunk {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var released []*store.Chunk
+	for _, id := range ids {
+		released = append(released, f.forgetLocked(id)...)
+	}
+
+	forgotten := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		forgotten[id] = struct{}{}
+	}
+	return slices.DeleteFunc(released, func(c *store.Chunk) bool {
+		_, ok := forgotten[c.ID]
+		return ok
+	})
+}
+
+// Reset clears all recorded fingerprints and duplicates.
+func (f *NearDuplicateFilter) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.index.Reset()
+	f.canonical = make(map[string]string)
+	f.duplicates = make(map[string]map[string]*store.Chunk)
+}
+
+// CanonicalID returns the chunk that id was deduplicated against.
+// Returns false if id was not skipped as a near-duplicate.
+func (f *NearDuplicateFilter) CanonicalID(id string) (string, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	canonicalID, ok := f.canonical[id]
+	return canonicalID, ok
+}
+
+// NearDuplicates returns the sorted IDs of chunks skipped as near-duplicates
+// of canonicalID.
+func (f *NearDuplicateFilter) NearDuplicates(canonicalID string) []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	dups := make([]string, 0, len(f.duplicates[canonicalID]))
+	for id := range f.duplicates[canonicalID] {
+		dups = append(dups, id)
+	}
+	sort.Strings(dups)
+	return dups
+}
+
+// DuplicateCount returns how many chunks were skipped as near-duplicates of canonicalID.
+func (f *NearDuplicateFilter) DuplicateCount(canonicalID string) int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return len(f.duplicates[canonicalID])
+}
+
+// forgetLocked removes id from the filter and returns the duplicates that
+// were recorded against it, sorted by ID.
+func (f *NearDuplicateFilter) forgetLocked(id string) []*store.Chunk {
+	if canonicalID, ok := f.canonical[id]; ok {
+		delete(f.canonical, id)
+		delete(f.duplicates[canonicalID], id)
+		if len(f.duplicates[canonicalID]) == 0 {
+			delete(f.duplicates, canonicalID)
+		}
+		return nil
+	}
+
+	if !f.index.Remove(id) {
+		return nil
+	}
+	released := make([]*store.Chunk, 0, len(f.duplicates[id]))
+	for _, dup := range slices.Sorted(maps.Keys(f.duplicates[id])) {
+		delete(f.canonical, dup)
+		released = append(released, f.duplicates[id][dup])
+	}
+	delete(f.duplicates, id)
+	return released
+}
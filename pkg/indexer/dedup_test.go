@@ -0,0 +1,196 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// vendoredFunc returns a realistic ~90-token function; variant renames the
+// final call so copies differ slightly.
+func vendoredFunc(variant string) string {
+	return `func (s *Server) ServeRequest(ctx context.Context, req *Request) (*Response, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if err := s.validator.Validate(ctx, req); err != nil {
+		return nil, fmt.Errorf("validate request: %w", err)
+	}
+	user, err := s.users.Lookup(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup user %s: %w", req.UserID, err)
+	}
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("user.id", user.ID))
+	resp, err := s.backend.Dispatch(ctx, user, req.Payload)
+	if err != nil {
+		s.metrics.Failures.Inc()
+		return nil, fmt.Errorf("dispatch: %w", err)
+	}
+	s.metrics.Successes.Inc()
+	return s.handle` + variant + `(ctx, resp)
+}`
+}
+
+func TestNearDuplicateFilter_SkipsNearDuplicates(t *testing.T) {
+	// Given: a canonical chunk, a near-identical copy, and an unrelated chunk
+	f := NewNearDuplicateFilter(DefaultNearDuplicateConfig())
+	chunks := []*store.Chunk{
+		{ID: "a", Content: vendoredFunc("Request")},
+		{ID: "vendor/a", Content: vendoredFunc("Requests")},
+		{ID: "b", Content: strings.Repeat("SELECT id, name FROM users WHERE active = 1 ORDER BY name; ", 4)},
+	}
+
+	// When: filtering
+	kept := f.Filter(chunks)
+
+	// Then: the copy is skipped and recorded against the canonical chunk
+	if len(kept) != 2 || kept[0].ID != "a" || kept[1].ID != "b" {
+		t.Fatalf("expected [a b] kept, got %v", chunkIDs(kept))
+	}
+	if canonical, ok := f.CanonicalID("vendor/a"); !ok || canonical != "a" {
+		t.Errorf("expected vendor/a -> a, got %q (%v)", canonical, ok)
+	}
+	if got := f.DuplicateCount("a"); got != 1 {
+		t.Errorf("expected 1 near-duplicate of a, got %d", got)
+	}
+}
+
+func TestNearDuplicateFilter_SmallChunksAlwaysKept(t *testing.T) {
+	// Given: identical chunks below MinTokens
+	f := NewNearDuplicateFilter(DefaultNearDuplicateConfig())
+	chunks := []*store.Chunk{{ID: "x", Content: "}"}, {ID: "y", Content: "}"}}
+
+	// Then: both are kept
+	if kept := f.Filter(chunks); len(kept) != 2 {
+		t.Fatalf("expected both small chunks kept, got %v", chunkIDs(kept))
+	}
+}
+
+func TestNearDuplicateFilter_ForgetCanonical_ReleasesDuplicates(t *testing.T) {
+	// Given: a canonical chunk with one duplicate
+	f := NewNearDuplicateFilter(DefaultNearDuplicateConfig())
+	f.Filter([]*store.Chunk{{ID: "a", Content: vendoredFunc("X")}, {ID: "b", Content: vendoredFunc("X")}})
+
+	// When: the canonical chunk is deleted
+	released := f.Forget([]string{"a"})
+
+	// Then: the duplicate is released and returned for indexing
+	if got := chunkIDs(released); len(got) != 1 || got[0] != "b" {
+		t.Errorf("expected [b] released, got %v", got)
+	}
+	if _, ok := f.CanonicalID("b"); ok {
+		t.Error("expected b to be released")
+	}
+	if kept := f.Filter([]*store.Chunk{{ID: "b", Content: vendoredFunc("X")}}); len(kept) != 1 {
+		t.Errorf("expected b kept after canonical removed, got %v", chunkIDs(kept))
+	}
+}
+
+func TestHybridIndexer_WithNearDuplicateFilter_SkipsDuplicatesInBothIndexers(t *testing.T) {
+	// Given: a hybrid indexer with near-duplicate detection
+	var bm25Got, vectorGot []string
+	bm25 := &MockIndexer{IndexFn: func(_ context.Context, chunks []*store.Chunk) error {
+		bm25Got = append(bm25Got, chunkIDs(chunks)...)
+		return nil
+	}}
+	vector := &MockIndexer{IndexFn: func(_ context.Context, chunks []*store.Chunk) error {
+		vectorGot = append(vectorGot, chunkIDs(chunks)...)
+		return nil
+	}}
+	h, err := NewHybridIndexer(
+		WithBM25(bm25),
+		WithVector(vector),
+		WithNearDuplicateFilter(NewNearDuplicateFilter(DefaultNearDuplicateConfig())),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// When: indexing five copies of the same boilerplate across two batches
+	ctx := context.Background()
+	for batch := 0; batch < 2; batch++ {
+		var chunks []*store.Chunk
+		for i := 0; i < 3; i++ {
+			chunks = append(chunks, &store.Chunk{ID: fmt.Sprintf("copy-%d-%d", batch, i), Content: vendoredFunc("Boilerplate")})
+		}
+		if err := h.Index(ctx, chunks); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Then: only the first copy reaches either index and the rest are recorded
+	if len(bm25Got) != 1 || len(vectorGot) != 1 || bm25Got[0] != "copy-0-0" {
+		t.Fatalf("expected only copy-0-0 indexed, got bm25=%v vector=%v", bm25Got, vectorGot)
+	}
+	if dups := h.NearDuplicates("copy-0-0"); len(dups) != 5 {
+		t.Errorf("expected 5 near-duplicates, got %v", dups)
+	}
+}
+
+func TestNearDuplicateFilter_ChangedCanonical_ReturnsReleasedDuplicates(t *testing.T) {
+	// Given: a canonical chunk with one duplicate
+	f := NewNearDuplicateFilter(DefaultNearDuplicateConfig())
+	f.Filter([]*store.Chunk{{ID: "a", Content: vendoredFunc("X")}, {ID: "b", Content: vendoredFunc("X")}})
+
+	// When: the canonical chunk is re-indexed with different content
+	changed := strings.Repeat("SELECT id, name FROM users WHERE active = 1 ORDER BY name; ", 4)
+	kept := f.Filter([]*store.Chunk{{ID: "a", Content: changed}})
+
+	// Then: the released duplicate is returned to be indexed as well
+	if got := chunkIDs(kept); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("expected [a b] kept, got %v", got)
+	}
+	if got := f.DuplicateCount("a"); got != 0 {
+		t.Errorf("expected no near-duplicates of a, got %d", got)
+	}
+}
+
+func TestHybridIndexer_Delete_IndexesReleasedDuplicates(t *testing.T) {
+	// Given: a hybrid indexer that skipped two copies of a canonical chunk
+	var indexed []string
+	bm25 := &MockIndexer{IndexFn: func(_ context.Context, chunks []*store.Chunk) error {
+		indexed = append(indexed, chunkIDs(chunks)...)
+		return nil
+	}}
+	h, err := NewHybridIndexer(
+		WithBM25(bm25),
+		WithNearDuplicateFilter(NewNearDuplicateFilter(DefaultNearDuplicateConfig())),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	if err := h.Index(ctx, []*store.Chunk{
+		{ID: "a", Content: vendoredFunc("X")},
+		{ID: "b", Content: vendoredFunc("X")},
+		{ID: "c", Content: vendoredFunc("X")},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// When: the canonical chunk is deleted
+	indexed = nil
+	if err := h.Delete(ctx, []string{"a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Then: one released copy is indexed in its place and the other is recorded against it
+	if len(indexed) != 1 || indexed[0] != "b" {
+		t.Fatalf("expected b indexed, got %v", indexed)
+	}
+	if dups := h.NearDuplicates("b"); len(dups) != 1 || dups[0] != "c" {
+		t.Errorf("expected c recorded against b, got %v", dups)
+	}
+}
+
+func chunkIDs(chunks []*store.Chunk) []string {
+	ids := make([]string, len(chunks))
+	for i, c := range chunks {
+		ids[i] = c.ID
+	}
+	return ids
+}
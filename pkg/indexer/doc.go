@@ -21,7 +21,7 @@
 //	    ┌────┴────┐
 //	    │         │
 //	┌───▼───┐ ┌───▼───┐
-//	│ BM25  │ │Vector │   (future: FEAT-BB3)
+//	│ BM25  │ │Vector │   (composed by HybridIndexer)
 //	└───────┘ └───────┘
 //
 // # Usage
@@ -38,6 +38,50 @@
 //	// Index chunks
 //	err = indexer.Index(ctx, chunks)
 //
+// # Hybrid Indexing
+//
+// HybridIndexer fans Index, Delete, Clear and Close out to a BM25 and a
+// Vector indexer and itself implements Indexer:
+//
+//	h, err := indexer.NewHybridIndexer(
+//	    indexer.WithBM25Indexer(bm25),
+//	    indexer.WithVectorIndexer(vector),
+//	)
+//
+// Deletes are best-effort across both indexers. A batch that BM25 accepted
+// but Vector rejected is deleted from BM25 again before the error is
+// returned.
+//
+// # Near-Duplicate Detection
+//
+// HybridIndexer can skip vendored or copy-pasted chunks before they are
+// embedded. It is off by default:
+//
+//	h, err := indexer.NewHybridIndexer(
+//	    indexer.WithBM25Indexer(bm25),
+//	    indexer.WithVectorIndexer(vector),
+//	    indexer.WithNearDuplicateFilter(
+//	        indexer.NewNearDuplicateFilter(indexer.DefaultNearDuplicateConfig())),
+//	)
+//
+// # Streaming from the Scanner
+//
+// IndexFromScan feeds scanner results straight into any Indexer, chunking
+// files on a worker pool and indexing fixed-size batches while the scan is
+// still running. Backpressure from a slow indexer propagates back to the
+// scanner, so memory stays bounded on large cold indexes:
+//
+//	results, err := s.Scan(ctx, scanOpts)
+//	if err != nil {
+//	    return err
+//	}
+//	stats, err := indexer.IndexFromScan(ctx, h, results,
+//	    indexer.WithChunkFunc(indexer.ChunkWith(map[scanner.ContentType]chunk.Chunker{
+//	        scanner.ContentTypeCode:     codeChunker,
+//	        scanner.ContentTypeMarkdown: markdownChunker,
+//	    })),
+//	)
+//
 // # Thread Safety
 //
 // All Indexer implementations are safe for concurrent use.
@@ -46,6 +90,6 @@
 // # Related
 //
 //   - FEAT-BB2: BM25Indexer module extraction
-//   - FEAT-BB3: VectorIndexer module extraction (future)
-//   - FEAT-BB4: HybridIndexer composition (future)
+//   - FEAT-BB3: VectorIndexer module extraction
+//   - FEAT-BB4: HybridIndexer composition
 package indexer
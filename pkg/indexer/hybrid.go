@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"github.com/Aman-CERP/amanmcp/internal/store"
@@ -21,8 +22,9 @@ var ErrNoIndexers = errors.New("at least one indexer is required")
 // HybridIndexer is safe for concurrent use. All methods may be called
 // from multiple goroutines simultaneously.
 type HybridIndexer struct {
-	bm25   Indexer // May be nil for vector-only mode
-	vector Indexer // May be nil for BM25-only mode
+	bm25   Indexer              // May be nil for vector-only mode
+	vector Indexer              // May be nil for BM25-only mode
+	dedup  *NearDuplicateFilter // Optional near-duplicate filter (off by default)
 	mu     sync.RWMutex
 	closed bool
 }
@@ -30,36 +32,58 @@ type HybridIndexer struct {
 // HybridOption configures a HybridIndexer.
 type HybridOption func(*HybridIndexer)
 
-// WithBM25 sets the BM25 indexer component.
+// WithBM25Indexer sets the BM25 indexer component.
 //
 // Pass nil to operate in vector-only mode.
-func WithBM25(idx Indexer) HybridOption {
+func WithBM25Indexer(idx Indexer) HybridOption {
 	return func(h *HybridIndexer) {
 		h.bm25 = idx
 	}
 }
 
-// WithVector sets the Vector indexer component.
+// WithVectorIndexer sets the Vector indexer component.
 //
 // Pass nil to operate in BM25-only mode.
-func WithVector(idx Indexer) HybridOption {
+func WithVectorIndexer(idx Indexer) HybridOption {
 	return func(h *HybridIndexer) {
 		h.vector = idx
 	}
 }
 
+// WithBM25 is shorthand for [WithBM25Indexer].
+func WithBM25(idx Indexer) HybridOption {
+	return WithBM25Indexer(idx)
+}
+
+// WithVector is shorthand for [WithVectorIndexer].
+func WithVector(idx Indexer) HybridOption {
+	return WithVectorIndexer(idx)
+}
+
+// WithNearDuplicateFilter enables near-duplicate detection before indexing.
+//
+// Chunks within the filter's SimHash distance of an already-indexed chunk are
+// skipped by both indexers (so they are never embedded), and recorded against
+// the canonical chunk; see [HybridIndexer.NearDuplicates].
+// Off by default, since some users want exact copies indexed.
+func WithNearDuplicateFilter(f *NearDuplicateFilter) HybridOption {
+	return func(h *HybridIndexer) {
+		h.dedup = f
+	}
+}
+
 // NewHybridIndexer creates a hybrid indexer from components.
 //
 // At least one indexer must be provided. Example configurations:
 //
 //	// Full hybrid mode
-//	h, err := NewHybridIndexer(WithBM25(bm25), WithVector(vector))
+//	h, err := NewHybridIndexer(WithBM25Indexer(bm25), WithVectorIndexer(vector))
 //
 //	// BM25-only mode (e.g., when embedder unavailable)
-//	h, err := NewHybridIndexer(WithBM25(bm25))
+//	h, err := NewHybridIndexer(WithBM25Indexer(bm25))
 //
 //	// Vector-only mode (rare)
-//	h, err := NewHybridIndexer(WithVector(vector))
+//	h, err := NewHybridIndexer(WithVectorIndexer(vector))
 //
 // Returns ErrNoIndexers if both indexers are nil.
 func NewHybridIndexer(opts ...HybridOption) (*HybridIndexer, error) {
@@ -78,9 +102,12 @@ func NewHybridIndexer(opts ...HybridOption) (*HybridIndexer, error) {
 
 // Index sends chunks to both indexers sequentially.
 //
-// BM25 is indexed first, then Vector. If either fails, the operation
-// fails fast and returns immediately. This ensures atomic behavior:
-// either both succeed or neither does (for the current batch).
+// BM25 is indexed first, then Vector. If BM25 fails, Vector is not
+// attempted. If Vector fails after BM25 succeeded, the batch is deleted
+// from BM25 again so a retry starts from a clean slate; that rollback is
+// best-effort like Delete, and a failed rollback only leaves orphans that
+// are filtered during search and cleaned up during compaction. Either way
+// the Vector error is returned.
 //
 // Empty or nil slices are no-ops that return nil.
 //
@@ -93,6 +120,19 @@ func (h *HybridIndexer) Index(ctx context.Context, chunks []*store.Chunk) error
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	return h.indexLocked(ctx, chunks)
+}
+
+// indexLocked implements Index. Caller must hold h.mu.
+func (h *HybridIndexer) indexLocked(ctx context.Context, chunks []*store.Chunk) error {
+	// Skip near-duplicates before they reach either index (and the embedder)
+	if h.dedup != nil {
+		chunks = h.dedup.Filter(chunks)
+		if len(chunks) == 0 {
+			return nil
+		}
+	}
+
 	// Index BM25 first (if available)
 	if h.bm25 != nil {
 		if err := h.bm25.Index(ctx, chunks); err != nil {
@@ -103,6 +143,8 @@ func (h *HybridIndexer) Index(ctx context.Context, chunks []*store.Chunk) error
 	// Then Vector (if available)
 	if h.vector != nil {
 		if err := h.vector.Index(ctx, chunks); err != nil {
+			// Roll back even if ctx was what cancelled the Vector step
+			h.rollbackLocked(context.WithoutCancel(ctx), chunks)
 			return fmt.Errorf("hybrid vector index: %w", err)
 		}
 	}
@@ -110,12 +152,39 @@ func (h *HybridIndexer) Index(ctx context.Context, chunks []*store.Chunk) error
 	return nil
 }
 
+// rollbackLocked undoes a partially indexed batch after the Vector indexer
+// failed: the chunks are deleted from BM25 (best-effort) and forgotten by
+// the near-duplicate filter so that retrying the batch indexes them again.
+// Caller must hold h.mu.
+func (h *HybridIndexer) rollbackLocked(ctx context.Context, chunks []*store.Chunk) {
+	ids := make([]string, len(chunks))
+	for i, c := range chunks {
+		ids[i] = c.ID
+	}
+
+	if h.bm25 != nil {
+		if err := h.bm25.Delete(ctx, ids); err != nil {
+			slog.Warn("BM25 rollback failed, orphans will remain until compaction",
+				slog.String("error", err.Error()),
+				slog.Int("count", len(ids)))
+		}
+	}
+
+	if h.dedup != nil {
+		h.dedup.Forget(ids)
+	}
+}
+
 // Delete removes chunks from both indexers.
 //
 // This uses a best-effort pattern: both indexers are attempted even
 // if one fails. Orphaned entries in one index are harmless (filtered
 // during search) and cleaned up during compaction.
 //
+// With a near-duplicate filter, chunks that were skipped as duplicates of
+// a deleted chunk are indexed in its place, so their content stays
+// searchable.
+//
 // Empty or nil slices are no-ops that return nil.
 //
 // This method is thread-safe.
@@ -143,6 +212,14 @@ func (h *HybridIndexer) Delete(ctx context.Context, ids []string) error {
 		}
 	}
 
+	if h.dedup != nil {
+		if released := h.dedup.Forget(ids); len(released) > 0 {
+			if err := h.indexLocked(ctx, released); err != nil {
+				errs = append(errs, fmt.Errorf("hybrid reindex released duplicates: %w", err))
+			}
+		}
+	}
+
 	if len(errs) > 0 {
 		return errors.Join(errs...)
 	}
@@ -150,6 +227,15 @@ func (h *HybridIndexer) Delete(ctx context.Context, ids []string) error {
 	return nil
 }
 
+// NearDuplicates returns the IDs of chunks skipped as near-duplicates of
+// canonicalID. Returns nil when near-duplicate detection is disabled.
+func (h *HybridIndexer) NearDuplicates(canonicalID string) []string {
+	if h.dedup == nil {
+		return nil
+	}
+	return h.dedup.NearDuplicates(canonicalID)
+}
+
 // Clear removes all content from both indexers.
 //
 // Uses fail-fast pattern: if BM25 clear fails, Vector clear is not attempted.
@@ -173,6 +259,10 @@ func (h *HybridIndexer) Clear(ctx context.Context) error {
 		}
 	}
 
+	if h.dedup != nil {
+		h.dedup.Reset()
+	}
+
 	return nil
 }
 
@@ -3,6 +3,7 @@ package indexer
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -300,6 +301,146 @@ func TestHybridIndexer_Index_ContextCancelled(t *testing.T) {
 	}
 }
 
+func TestHybridIndexer_Index_VectorError_RollsBackBM25(t *testing.T) {
+	// Given: BM25 that succeeds and Vector that fails
+	var deleted []string
+	bm25 := &MockIndexer{
+		DeleteFn: func(ctx context.Context, ids []string) error {
+			deleted = append(deleted, ids...)
+			return nil
+		},
+	}
+	vector := &MockIndexer{
+		IndexFn: func(ctx context.Context, chunks []*store.Chunk) error {
+			return errors.New("vector failed")
+		},
+	}
+	h, _ := NewHybridIndexer(WithBM25Indexer(bm25), WithVectorIndexer(vector))
+
+	chunks := []*store.Chunk{{ID: "1", Content: "a"}, {ID: "2", Content: "b"}}
+
+	// When: Indexing
+	err := h.Index(context.Background(), chunks)
+
+	// Then: Error returned and the batch removed from BM25 again
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if bm25.deleteCalled.Load() != 1 {
+		t.Fatalf("expected one bm25 Delete for rollback, got %d", bm25.deleteCalled.Load())
+	}
+	if len(deleted) != 2 || deleted[0] != "1" || deleted[1] != "2" {
+		t.Errorf("expected rollback of [1 2], got %v", deleted)
+	}
+	if vector.deleteCalled.Load() != 0 {
+		t.Error("expected vector Delete NOT called on rollback")
+	}
+}
+
+func TestHybridIndexer_Index_RollbackFails_ReturnsVectorError(t *testing.T) {
+	// Given: Vector that fails and a BM25 rollback that also fails
+	vectorErr := errors.New("vector failed")
+	bm25Err := errors.New("bm25 delete failed")
+	bm25 := &MockIndexer{
+		DeleteFn: func(ctx context.Context, ids []string) error {
+			return bm25Err
+		},
+	}
+	vector := &MockIndexer{
+		IndexFn: func(ctx context.Context, chunks []*store.Chunk) error {
+			return vectorErr
+		},
+	}
+	h, _ := NewHybridIndexer(WithBM25Indexer(bm25), WithVectorIndexer(vector))
+
+	// When: Indexing
+	err := h.Index(context.Background(), []*store.Chunk{{ID: "1", Content: "a"}})
+
+	// Then: The vector error is reported; the rollback failure is not
+	if !errors.Is(err, vectorErr) {
+		t.Errorf("expected vector error, got %v", err)
+	}
+	if errors.Is(err, bm25Err) {
+		t.Errorf("expected rollback error to be logged, not returned: %v", err)
+	}
+}
+
+func TestHybridIndexer_Index_VectorCancelled_StillRollsBack(t *testing.T) {
+	// Given: Vector that fails because the context is cancelled mid-batch
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var rollbackCtxErr error
+	bm25 := &MockIndexer{
+		DeleteFn: func(ctx context.Context, ids []string) error {
+			rollbackCtxErr = ctx.Err()
+			return ctx.Err()
+		},
+	}
+	vector := &MockIndexer{
+		IndexFn: func(ctx context.Context, chunks []*store.Chunk) error {
+			cancel()
+			return ctx.Err()
+		},
+	}
+	h, _ := NewHybridIndexer(WithBM25Indexer(bm25), WithVectorIndexer(vector))
+
+	// When: Indexing
+	err := h.Index(ctx, []*store.Chunk{{ID: "1", Content: "a"}})
+
+	// Then: Cancellation reported, but the rollback ran with a live context
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if bm25.deleteCalled.Load() != 1 {
+		t.Fatal("expected bm25 rollback")
+	}
+	if rollbackCtxErr != nil {
+		t.Errorf("expected rollback context not cancelled, got %v", rollbackCtxErr)
+	}
+}
+
+func TestHybridIndexer_Index_VectorError_FailedChunksNotCanonical(t *testing.T) {
+	// Given: Near-duplicate filtering and a Vector that fails once
+	var vectorCalls int
+	var indexed []string
+	vector := &MockIndexer{
+		IndexFn: func(ctx context.Context, chunks []*store.Chunk) error {
+			vectorCalls++
+			if vectorCalls == 1 {
+				return errors.New("vector failed")
+			}
+			for _, c := range chunks {
+				indexed = append(indexed, c.ID)
+			}
+			return nil
+		},
+	}
+	h, _ := NewHybridIndexer(
+		WithBM25Indexer(&MockIndexer{}),
+		WithVectorIndexer(vector),
+		WithNearDuplicateFilter(NewNearDuplicateFilter(DefaultNearDuplicateConfig())),
+	)
+
+	content := strings.Repeat("token ", DefaultNearDuplicateMinTokens) + "end"
+
+	// When: A chunk fails to index, then a copy of it is indexed under another ID
+	if err := h.Index(context.Background(), []*store.Chunk{{ID: "1", Content: content}}); err == nil {
+		t.Fatal("expected first batch to fail")
+	}
+	if err := h.Index(context.Background(), []*store.Chunk{{ID: "2", Content: content}}); err != nil {
+		t.Fatalf("expected second batch to succeed, got %v", err)
+	}
+
+	// Then: The copy is indexed, not skipped as a duplicate of the failed chunk
+	if len(indexed) != 1 || indexed[0] != "2" {
+		t.Errorf("expected chunk 2 indexed, got %v", indexed)
+	}
+	if dups := h.NearDuplicates("1"); len(dups) != 0 {
+		t.Errorf("expected no duplicates recorded against failed chunk, got %v", dups)
+	}
+}
+
 // =============================================================================
 // Delete Tests
 // =============================================================================
@@ -0,0 +1,315 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/Aman-CERP/amanmcp/internal/chunk"
+	"github.com/Aman-CERP/amanmcp/internal/index"
+	"github.com/Aman-CERP/amanmcp/internal/scanner"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// DefaultPipelineBatchSize is the number of chunks IndexFromScan passes to
+// each Indexer.Index call, and so the embedding batch size when the indexer
+// embeds.
+const DefaultPipelineBatchSize = 32
+
+// ErrNoChunkFunc is returned by IndexFromScan when no ChunkFunc is configured.
+var ErrNoChunkFunc = errors.New("chunk func is required")
+
+// ChunkFunc splits one scanned file into chunks.
+//
+// It is called concurrently from several workers. Returning no chunks and
+// no error skips the file.
+type ChunkFunc func(ctx context.Context, file *scanner.FileInfo) ([]*store.Chunk, error)
+
+// PipelineOption configures IndexFromScan.
+type PipelineOption func(*pipeline)
+
+// WithChunkFunc sets how scanned files are chunked. Required.
+func WithChunkFunc(fn ChunkFunc) PipelineOption {
+	return func(p *pipeline) {
+		p.chunk = fn
+	}
+}
+
+// WithChunkWorkers sets how many files are read and chunked concurrently.
+// Values below 1 are ignored. Defaults to runtime.NumCPU().
+func WithChunkWorkers(n int) PipelineOption {
+	return func(p *pipeline) {
+		if n > 0 {
+			p.workers = n
+		}
+	}
+}
+
+// WithPipelineBatchSize sets how many chunks are indexed per Index call.
+// Values below 1 are ignored. Defaults to DefaultPipelineBatchSize.
+func WithPipelineBatchSize(n int) PipelineOption {
+	return func(p *pipeline) {
+		if n > 0 {
+			p.batchSize = n
+		}
+	}
+}
+
+// PipelineStats summarizes an IndexFromScan run.
+type PipelineStats struct {
+	// Files is the number of files that produced at least one chunk.
+	Files int
+
+	// Chunks is the number of chunks indexed.
+	Chunks int
+
+	// Batches is the number of Index calls made.
+	Batches int
+
+	// ScanErrors is the number of scan results that carried an error.
+	ScanErrors int
+
+	// ChunkErrors is the number of files the ChunkFunc failed on.
+	ChunkErrors int
+
+	// Duration is the wall-clock time of the run.
+	Duration time.Duration
+}
+
+// pipeline holds IndexFromScan's configuration.
+type pipeline struct {
+	chunk     ChunkFunc
+	workers   int
+	batchSize int
+}
+
+// chunkedFile is one worker's output for a file.
+type chunkedFile struct {
+	chunks []*store.Chunk
+	err    error
+}
+
+// IndexFromScan indexes files as the scanner finds them, instead of waiting
+// for the scan to finish.
+//
+// Scan results are chunked by a pool of workers (see WithChunkWorkers) and
+// the chunks are passed to idx in batches (see WithPipelineBatchSize) by a
+// single writer, so indexing and embedding overlap with scanning. The
+// channels between the stages are small: when idx falls behind, the workers
+// stop reading results, which in turn blocks the scanner. Memory therefore
+// stays bounded by the in-flight files and one batch, whatever the project
+// size.
+//
+// Scan and chunk errors are logged, counted in the returned stats and
+// skipped, as in a regular index run. The first Index error stops the
+// pipeline and is returned; batches indexed before it are kept. On error or
+// cancellation the remaining results are drained without being chunked so
+// the scanner is never left blocked; cancel the scan's context to stop it
+// early.
+//
+// IndexFromScan only writes to idx. It does not record files or chunks in a
+// metadata store. It is the streaming entry point for programs built on this
+// package; amanmcp's own index command runs index.Runner, which also records
+// metadata.
+func IndexFromScan(ctx context.Context, idx Indexer, results <-chan scanner.ScanResult, opts ...PipelineOption) (PipelineStats, error) {
+	start := time.Now()
+	p := &pipeline{
+		workers:   runtime.NumCPU(),
+		batchSize: DefaultPipelineBatchSize,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.chunk == nil {
+		return PipelineStats{}, ErrNoChunkFunc
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Workers read and chunk files; the buffer lets them run a little ahead
+	// of the writer before backpressure kicks in
+	chunked := make(chan chunkedFile, p.workers)
+	var scanErrors int
+	var scanMu sync.Mutex
+	var wg sync.WaitGroup
+	for range p.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for result := range results {
+				if ctx.Err() != nil {
+					continue // Drain so the scanner can finish
+				}
+				if result.Error != nil || result.File == nil {
+					logScanError(result)
+					scanMu.Lock()
+					scanErrors++
+					scanMu.Unlock()
+					continue
+				}
+
+				chunks, err := p.chunk(ctx, result.File)
+				if err != nil {
+					err = fmt.Errorf("failed to chunk %s: %w", result.File.Path, err)
+				}
+				select {
+				case chunked <- chunkedFile{chunks: chunks, err: err}:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(chunked)
+	}()
+
+	// The writer batches chunks across files and indexes each full batch
+	var stats PipelineStats
+	var indexErr error
+	batch := make([]*store.Chunk, 0, p.batchSize)
+	flush := func() {
+		if len(batch) == 0 || indexErr != nil {
+			return
+		}
+		if err := idx.Index(ctx, batch); err != nil {
+			indexErr = fmt.Errorf("failed to index batch of %d chunks: %w", len(batch), err)
+			cancel()
+			return
+		}
+		stats.Batches++
+		stats.Chunks += len(batch)
+		batch = make([]*store.Chunk, 0, p.batchSize)
+	}
+
+	for file := range chunked {
+		if indexErr != nil || ctx.Err() != nil {
+			continue // Drain until the workers exit
+		}
+		if file.err != nil {
+			slog.Warn("pipeline_chunk_failed", slog.String("error", file.err.Error()))
+			stats.ChunkErrors++
+			continue
+		}
+		if len(file.chunks) == 0 {
+			continue
+		}
+		stats.Files++
+		for _, c := range file.chunks {
+			batch = append(batch, c)
+			if len(batch) == p.batchSize {
+				flush()
+			}
+		}
+	}
+
+	// Only the writer cancels ctx itself, and only after setting indexErr
+	if indexErr == nil {
+		if err := ctx.Err(); err != nil {
+			indexErr = fmt.Errorf("indexing interrupted after %d chunks: %w", stats.Chunks, err)
+		} else {
+			flush()
+		}
+	}
+
+	stats.ScanErrors = scanErrors
+	stats.Duration = time.Since(start)
+
+	if indexErr != nil {
+		return stats, indexErr
+	}
+
+	slog.Info("pipeline_index_complete",
+		slog.Int("files", stats.Files),
+		slog.Int("chunks", stats.Chunks),
+		slog.Int("batches", stats.Batches),
+		slog.Int("scan_errors", stats.ScanErrors),
+		slog.Int("chunk_errors", stats.ChunkErrors),
+		slog.Duration("duration", stats.Duration))
+
+	return stats, nil
+}
+
+// logScanError logs a failed scan result.
+func logScanError(result scanner.ScanResult) {
+	path := ""
+	if result.File != nil {
+		path = result.File.Path
+	}
+	msg := "missing file info"
+	if result.Error != nil {
+		msg = result.Error.Error()
+	}
+	slog.Warn("pipeline_scan_failed", slog.String("file", path), slog.String("error", msg))
+}
+
+// ChunkOption configures ChunkWith.
+type ChunkOption func(*index.ContentGuard)
+
+// WithContentGuard replaces the checks ChunkWith applies to file content
+// before chunking. Defaults to index.DefaultContentGuard().
+func WithContentGuard(guard index.ContentGuard) ChunkOption {
+	return func(g *index.ContentGuard) {
+		*g = guard
+	}
+}
+
+// ChunkWith returns a ChunkFunc that reads each file from disk and splits it
+// with the chunker registered for its content type. Files whose content type
+// has no chunker are skipped.
+//
+// Content passes the same guard as a regular index run: oversized and binary
+// files are skipped, and secrets are redacted or the file blocked per the
+// secrets policy. Chunks carry the file ID a regular index run would store.
+func ChunkWith(chunkers map[scanner.ContentType]chunk.Chunker, opts ...ChunkOption) ChunkFunc {
+	guard := index.DefaultContentGuard()
+	for _, opt := range opts {
+		opt(&guard)
+	}
+
+	return func(ctx context.Context, file *scanner.FileInfo) ([]*store.Chunk, error) {
+		chunker, ok := chunkers[file.ContentType]
+		if !ok || chunker == nil {
+			return nil, nil
+		}
+
+		content, secretResult, ok, err := guard.Read(file)
+		logSecretWarnings(file.Path, len(secretResult.Warnings))
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		chunks, err := chunker.Chunk(ctx, &chunk.FileInput{
+			Path:     file.Path,
+			Content:  content,
+			Language: file.Language,
+		})
+		if err != nil {
+			return nil, err
+		}
+		chunks, warnings := guard.Annotate(file, chunks, secretResult)
+		logSecretWarnings(file.Path, len(warnings))
+
+		fileID := index.FileID(file.Path)
+		now := time.Now()
+		out := make([]*store.Chunk, 0, len(chunks))
+		for _, c := range chunks {
+			if c != nil {
+				out = append(out, index.ConvertChunk(c, fileID, now))
+			}
+		}
+		return out, nil
+	}
+}
+
+// logSecretWarnings logs the number of secrets found in a file.
+func logSecretWarnings(path string, count int) {
+	if count > 0 {
+		slog.Warn("pipeline_secrets_found", slog.String("file", path), slog.Int("warnings", count))
+	}
+}
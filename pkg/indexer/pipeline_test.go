@@ -0,0 +1,282 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Aman-CERP/amanmcp/internal/chunk"
+	"github.com/Aman-CERP/amanmcp/internal/index"
+	"github.com/Aman-CERP/amanmcp/internal/scanner"
+	"github.com/Aman-CERP/amanmcp/internal/store"
+)
+
+// twoChunksPerFile is a ChunkFunc producing two chunks for every file.
+func twoChunksPerFile(_ context.Context, file *scanner.FileInfo) ([]*store.Chunk, error) {
+	return []*store.Chunk{
+		{ID: file.Path + "#1", FilePath: file.Path, Content: "a"},
+		{ID: file.Path + "#2", FilePath: file.Path, Content: "b"},
+	}, nil
+}
+
+// scanResults returns a closed channel holding n successful results.
+func scanResults(n int) <-chan scanner.ScanResult {
+	ch := make(chan scanner.ScanResult, n)
+	for i := range n {
+		ch <- scanner.ScanResult{File: &scanner.FileInfo{Path: fmt.Sprintf("f%d.go", i)}}
+	}
+	close(ch)
+	return ch
+}
+
+// recordingIndexer collects indexed batches.
+func recordingIndexer() (*MockIndexer, func() [][]*store.Chunk) {
+	var mu sync.Mutex
+	var batches [][]*store.Chunk
+	idx := &MockIndexer{
+		IndexFn: func(_ context.Context, chunks []*store.Chunk) error {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, chunks)
+			return nil
+		},
+	}
+	return idx, func() [][]*store.Chunk {
+		mu.Lock()
+		defer mu.Unlock()
+		return batches
+	}
+}
+
+func TestIndexFromScan_IndexesAllChunksInBatches(t *testing.T) {
+	// Given: 10 files of 2 chunks each and a batch size of 8
+	idx, batches := recordingIndexer()
+
+	// When: indexing from the scan
+	stats, err := IndexFromScan(context.Background(), idx, scanResults(10),
+		WithChunkFunc(twoChunksPerFile),
+		WithChunkWorkers(3),
+		WithPipelineBatchSize(8),
+	)
+
+	// Then: every chunk is indexed exactly once in batches of at most 8
+	require.NoError(t, err)
+	assert.Equal(t, 10, stats.Files)
+	assert.Equal(t, 20, stats.Chunks)
+	assert.Equal(t, 3, stats.Batches)
+
+	seen := make(map[string]bool)
+	for _, b := range batches() {
+		assert.LessOrEqual(t, len(b), 8)
+		for _, c := range b {
+			assert.False(t, seen[c.ID], "chunk %s indexed twice", c.ID)
+			seen[c.ID] = true
+		}
+	}
+	assert.Len(t, seen, 20)
+}
+
+func TestIndexFromScan_StartsBeforeScanFinishes(t *testing.T) {
+	// Given: a scan that only sends its last file after the first batch is indexed
+	firstBatch := make(chan struct{})
+	var once sync.Once
+	idx := &MockIndexer{
+		IndexFn: func(context.Context, []*store.Chunk) error {
+			once.Do(func() { close(firstBatch) })
+			return nil
+		},
+	}
+	results := make(chan scanner.ScanResult)
+	go func() {
+		defer close(results)
+		for i := range 4 {
+			results <- scanner.ScanResult{File: &scanner.FileInfo{Path: fmt.Sprintf("f%d.go", i)}}
+		}
+		select {
+		case <-firstBatch:
+		case <-time.After(5 * time.Second):
+			return // Pipeline waited for the scan; the assertion below fails
+		}
+		results <- scanner.ScanResult{File: &scanner.FileInfo{Path: "last.go"}}
+	}()
+
+	// When: indexing with a batch size the first files fill
+	stats, err := IndexFromScan(context.Background(), idx, results,
+		WithChunkFunc(twoChunksPerFile),
+		WithChunkWorkers(2),
+		WithPipelineBatchSize(4),
+	)
+
+	// Then: the last file was scanned after indexing began, and is indexed too
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.Files)
+	assert.Equal(t, 10, stats.Chunks)
+}
+
+func TestIndexFromScan_SkipsScanAndChunkErrors(t *testing.T) {
+	// Given: a scan error, a file that fails to chunk, an empty file and a good file
+	results := make(chan scanner.ScanResult, 4)
+	results <- scanner.ScanResult{File: &scanner.FileInfo{Path: "denied.go"}, Error: errors.New("permission denied")}
+	results <- scanner.ScanResult{File: &scanner.FileInfo{Path: "broken.go"}}
+	results <- scanner.ScanResult{File: &scanner.FileInfo{Path: "empty.go"}}
+	results <- scanner.ScanResult{File: &scanner.FileInfo{Path: "good.go"}}
+	close(results)
+
+	chunkFn := func(ctx context.Context, file *scanner.FileInfo) ([]*store.Chunk, error) {
+		switch file.Path {
+		case "broken.go":
+			return nil, errors.New("parse error")
+		case "empty.go":
+			return nil, nil
+		}
+		return twoChunksPerFile(ctx, file)
+	}
+	idx, _ := recordingIndexer()
+
+	// When: indexing from the scan
+	stats, err := IndexFromScan(context.Background(), idx, results, WithChunkFunc(chunkFn))
+
+	// Then: errors are counted and the good file is still indexed
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.ScanErrors)
+	assert.Equal(t, 1, stats.ChunkErrors)
+	assert.Equal(t, 1, stats.Files)
+	assert.Equal(t, 2, stats.Chunks)
+}
+
+func TestIndexFromScan_IndexErrorStopsAndDrains(t *testing.T) {
+	// Given: an indexer that fails on the second batch and an unbuffered scan of 50 files
+	var calls int
+	idx := &MockIndexer{
+		IndexFn: func(context.Context, []*store.Chunk) error {
+			calls++
+			if calls == 2 {
+				return errors.New("embedder offline")
+			}
+			return nil
+		},
+	}
+	results := make(chan scanner.ScanResult)
+	scanDone := make(chan struct{})
+	go func() {
+		defer close(scanDone)
+		defer close(results)
+		for i := range 50 {
+			results <- scanner.ScanResult{File: &scanner.FileInfo{Path: fmt.Sprintf("f%d.go", i)}}
+		}
+	}()
+
+	// When: indexing from the scan
+	stats, err := IndexFromScan(context.Background(), idx, results,
+		WithChunkFunc(twoChunksPerFile),
+		WithPipelineBatchSize(2),
+	)
+
+	// Then: the index error is returned, no more batches are indexed,
+	// and the scanner was not left blocked
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "embedder offline")
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, stats.Batches)
+	select {
+	case <-scanDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scanner blocked after index error")
+	}
+}
+
+func TestIndexFromScan_Cancelled(t *testing.T) {
+	// Given: a cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	idx, batches := recordingIndexer()
+
+	// When: indexing from the scan
+	_, err := IndexFromScan(ctx, idx, scanResults(5), WithChunkFunc(twoChunksPerFile))
+
+	// Then: nothing is indexed and the cancellation is reported
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, batches())
+}
+
+func TestIndexFromScan_RequiresChunkFunc(t *testing.T) {
+	// When: no ChunkFunc is configured
+	_, err := IndexFromScan(context.Background(), &MockIndexer{}, scanResults(1))
+
+	// Then: ErrNoChunkFunc is returned
+	assert.ErrorIs(t, err, ErrNoChunkFunc)
+}
+
+func TestChunkWith_UsesChunkerForContentType(t *testing.T) {
+	// Given: a markdown file and a chunker registered only for markdown
+	dir := t.TempDir()
+	path := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Title\n\nBody text.\n"), 0o644))
+	chunkFn := ChunkWith(map[scanner.ContentType]chunk.Chunker{
+		scanner.ContentTypeMarkdown: chunk.NewMarkdownChunker(),
+	})
+
+	// When: chunking the markdown file and a code file
+	chunks, err := chunkFn(context.Background(), &scanner.FileInfo{
+		Path: "README.md", AbsPath: path, ContentType: scanner.ContentTypeMarkdown, Language: "markdown",
+	})
+	require.NoError(t, err)
+	skipped, err := chunkFn(context.Background(), &scanner.FileInfo{
+		Path: "main.go", AbsPath: filepath.Join(dir, "main.go"), ContentType: scanner.ContentTypeCode,
+	})
+
+	// Then: the markdown file is chunked into store chunks and the code file is skipped
+	require.NotEmpty(t, chunks)
+	assert.Equal(t, "README.md", chunks[0].FilePath)
+	assert.Contains(t, chunks[0].Content, "Body text.")
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+}
+
+func TestChunkWith_GuardsContentLikeIndexRun(t *testing.T) {
+	// Given: a file holding a private key, an oversized file and a plain file
+	dir := t.TempDir()
+	write := func(name, content string) *scanner.FileInfo {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return &scanner.FileInfo{
+			Path: name, AbsPath: path, ContentType: scanner.ContentTypeMarkdown,
+			Language: "markdown", Size: int64(len(content)),
+		}
+	}
+	begin := "-----BEGIN " + "PRIVATE KEY-----"
+	end := "-----END " + "PRIVATE KEY-----"
+	secret := write("key.md", "# Key\n\n"+begin+"\nMIIEvQIBADANBgkqhkiG9w0BAQEFAASC\n"+end+"\n")
+	large := write("large.md", "# Large\n\n"+strings.Repeat("text ", 100))
+	plain := write("plain.md", "# Plain\n\nBody text.\n")
+	guard := index.DefaultContentGuard()
+	guard.MaxFileSize = 64
+	chunkFn := ChunkWith(map[scanner.ContentType]chunk.Chunker{
+		scanner.ContentTypeMarkdown: chunk.NewMarkdownChunker(),
+	}, WithContentGuard(guard))
+
+	// When: chunking each file
+	ctx := context.Background()
+	secretChunks, err := chunkFn(ctx, secret)
+	require.NoError(t, err)
+	largeChunks, err := chunkFn(ctx, large)
+	require.NoError(t, err)
+	plainChunks, err := chunkFn(ctx, plain)
+	require.NoError(t, err)
+
+	// Then: the key file is blocked, the oversized file is skipped and the
+	// plain file's chunks carry its file ID
+	assert.Empty(t, secretChunks)
+	assert.Empty(t, largeChunks)
+	require.NotEmpty(t, plainChunks)
+	assert.Equal(t, index.FileID("plain.md"), plainChunks[0].FileID)
+}
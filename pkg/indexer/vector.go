@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"github.com/Aman-CERP/amanmcp/internal/embed"
@@ -20,12 +21,15 @@ var ErrNilVectorStore = errors.New("vector store is required")
 //
 // It generates embeddings via an [embed.Embedder] and stores them in a
 // [store.VectorStore]. This enables semantic similarity search over indexed content.
+// With a metadata store configured, embeddings are also persisted so that
+// compaction can rebuild the vector store without re-embedding.
 //
 // VectorIndexer is safe for concurrent use. All methods may be called
 // from multiple goroutines simultaneously.
 type VectorIndexer struct {
 	embedder embed.Embedder
 	store    store.VectorStore
+	metadata store.MetadataStore // Optional; persists embeddings when set
 	mu       sync.RWMutex
 	closed   bool
 }
@@ -54,6 +58,16 @@ func WithVectorStore(s store.VectorStore) VectorOption {
 	}
 }
 
+// WithMetadataStore sets the metadata store that indexed embeddings are
+// persisted to via SaveChunkEmbeddings.
+//
+// This is optional. Without it, embeddings live only in the vector store.
+func WithMetadataStore(m store.MetadataStore) VectorOption {
+	return func(v *VectorIndexer) {
+		v.metadata = m
+	}
+}
+
 // NewVectorIndexer creates a new vector indexer with the given options.
 //
 // At minimum, WithEmbedder and WithVectorStore must be provided:
@@ -61,6 +75,7 @@ func WithVectorStore(s store.VectorStore) VectorOption {
 //	indexer, err := NewVectorIndexer(
 //	    WithEmbedder(embedder),
 //	    WithVectorStore(vectorStore),
+//	    WithMetadataStore(metadata), // optional
 //	)
 //
 // Returns ErrNilEmbedder if no embedder is provided.
@@ -88,6 +103,10 @@ func NewVectorIndexer(opts ...VectorOption) (*VectorIndexer, error) {
 //  1. Extract text content from chunks
 //  2. Generate embeddings via embedder.EmbedBatch()
 //  3. Store embeddings via vectorStore.Add()
+//  4. Persist embeddings via metadata.SaveChunkEmbeddings(), if configured
+//
+// Persisting is best-effort: a failure is logged and does not fail the
+// call, since embeddings can be regenerated.
 //
 // Empty or nil slices are no-ops that return nil.
 //
@@ -110,6 +129,9 @@ func (v *VectorIndexer) Index(ctx context.Context, chunks []*store.Chunk) error
 	if err != nil {
 		return fmt.Errorf("vector embed: %w", err)
 	}
+	if len(embeddings) != len(texts) {
+		return fmt.Errorf("vector embed: got %d embeddings for %d texts", len(embeddings), len(texts))
+	}
 
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -119,6 +141,14 @@ func (v *VectorIndexer) Index(ctx context.Context, chunks []*store.Chunk) error
 		return fmt.Errorf("vector store add: %w", err)
 	}
 
+	if v.metadata != nil {
+		if err := v.metadata.SaveChunkEmbeddings(ctx, ids, embeddings, v.embedder.ModelName()); err != nil {
+			slog.Warn("failed to persist embeddings, compaction will require re-embedding",
+				slog.String("error", err.Error()),
+				slog.Int("count", len(ids)))
+		}
+	}
+
 	return nil
 }
 
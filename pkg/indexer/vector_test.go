@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Aman-CERP/amanmcp/internal/embed"
 	"github.com/Aman-CERP/amanmcp/internal/store"
 )
 
@@ -170,8 +171,27 @@ func (m *MockVectorStore) Close() error {
 	return nil
 }
 
+// MockMetadataStore records SaveChunkEmbeddings calls. Other
+// store.MetadataStore methods are not used by VectorIndexer and panic.
+type MockMetadataStore struct {
+	store.MetadataStore
+
+	SaveChunkEmbeddingsFn func(ctx context.Context, ids []string, embeddings [][]float32, model string) error
+
+	saveEmbeddingsCalled atomic.Int32
+}
+
+func (m *MockMetadataStore) SaveChunkEmbeddings(ctx context.Context, ids []string, embeddings [][]float32, model string) error {
+	m.saveEmbeddingsCalled.Add(1)
+	if m.SaveChunkEmbeddingsFn != nil {
+		return m.SaveChunkEmbeddingsFn(ctx, ids, embeddings, model)
+	}
+	return nil
+}
+
 // Ensure mocks implement interfaces
 var _ store.VectorStore = (*MockVectorStore)(nil)
+var _ store.MetadataStore = (*MockMetadataStore)(nil)
 
 // =============================================================================
 // Constructor Tests
@@ -400,6 +420,117 @@ func TestVectorIndexer_Index_ContextCancelled(t *testing.T) {
 	assert.ErrorIs(t, err, context.Canceled)
 }
 
+func TestVectorIndexer_Index_EmbeddingCountMismatch_ReturnsError(t *testing.T) {
+	// Given: an embedder that returns fewer embeddings than texts
+	mockEmbedder := &MockEmbedder{
+		EmbedBatchFn: func(ctx context.Context, texts []string) ([][]float32, error) {
+			return [][]float32{make([]float32, 768)}, nil
+		},
+	}
+	mockStore := &MockVectorStore{}
+	indexer, err := NewVectorIndexer(WithEmbedder(mockEmbedder), WithVectorStore(mockStore))
+	require.NoError(t, err)
+
+	// When: indexing two chunks
+	err = indexer.Index(context.Background(), []*store.Chunk{{ID: "a"}, {ID: "b"}})
+
+	// Then: an error is returned and nothing is stored
+	require.Error(t, err)
+	assert.Equal(t, int32(0), mockStore.addCalled.Load())
+}
+
+func TestVectorIndexer_Index_PersistsEmbeddings(t *testing.T) {
+	// Given: an indexer with a metadata store and the static embedder
+	embedder := embed.NewStaticEmbedder768()
+	defer func() { _ = embedder.Close() }()
+
+	var storedVectors [][]float32
+	mockStore := &MockVectorStore{
+		AddFn: func(ctx context.Context, ids []string, vectors [][]float32) error {
+			storedVectors = vectors
+			return nil
+		},
+	}
+	var savedIDs []string
+	var savedEmbeddings [][]float32
+	var savedModel string
+	metadata := &MockMetadataStore{
+		SaveChunkEmbeddingsFn: func(ctx context.Context, ids []string, embeddings [][]float32, model string) error {
+			savedIDs, savedEmbeddings, savedModel = ids, embeddings, model
+			return nil
+		},
+	}
+
+	indexer, err := NewVectorIndexer(
+		WithEmbedder(embedder),
+		WithVectorStore(mockStore),
+		WithMetadataStore(metadata),
+	)
+	require.NoError(t, err)
+
+	// When: indexing chunks
+	chunks := []*store.Chunk{
+		{ID: "chunk1", Content: "func main() {}"},
+		{ID: "chunk2", Content: "type User struct {}"},
+	}
+	err = indexer.Index(context.Background(), chunks)
+
+	// Then: the stored vectors are persisted under the embedder's model
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), metadata.saveEmbeddingsCalled.Load())
+	assert.Equal(t, []string{"chunk1", "chunk2"}, savedIDs)
+	assert.Equal(t, storedVectors, savedEmbeddings)
+	assert.Len(t, savedEmbeddings[0], embedder.Dimensions())
+	assert.Equal(t, embedder.ModelName(), savedModel)
+}
+
+func TestVectorIndexer_Index_PersistFailure_NotFatal(t *testing.T) {
+	// Given: a metadata store that fails to persist embeddings
+	mockStore := &MockVectorStore{}
+	metadata := &MockMetadataStore{
+		SaveChunkEmbeddingsFn: func(ctx context.Context, ids []string, embeddings [][]float32, model string) error {
+			return errors.New("disk full")
+		},
+	}
+	indexer, err := NewVectorIndexer(
+		WithEmbedder(&MockEmbedder{}),
+		WithVectorStore(mockStore),
+		WithMetadataStore(metadata),
+	)
+	require.NoError(t, err)
+
+	// When: indexing
+	err = indexer.Index(context.Background(), []*store.Chunk{{ID: "a", Content: "x"}})
+
+	// Then: vectors are stored and the persist failure is not returned
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), mockStore.addCalled.Load())
+	assert.Equal(t, int32(1), metadata.saveEmbeddingsCalled.Load())
+}
+
+func TestVectorIndexer_Index_StoreError_SkipsPersist(t *testing.T) {
+	// Given: a vector store that fails to add
+	mockStore := &MockVectorStore{
+		AddFn: func(ctx context.Context, ids []string, vectors [][]float32) error {
+			return errors.New("store failed")
+		},
+	}
+	metadata := &MockMetadataStore{}
+	indexer, err := NewVectorIndexer(
+		WithEmbedder(&MockEmbedder{}),
+		WithVectorStore(mockStore),
+		WithMetadataStore(metadata),
+	)
+	require.NoError(t, err)
+
+	// When: indexing
+	err = indexer.Index(context.Background(), []*store.Chunk{{ID: "a", Content: "x"}})
+
+	// Then: the error is returned and no embeddings are persisted
+	require.Error(t, err)
+	assert.Equal(t, int32(0), metadata.saveEmbeddingsCalled.Load())
+}
+
 // =============================================================================
 // Delete Tests
 // =============================================================================
@@ -13,8 +13,9 @@ import (
 // It wraps a store.BM25Index to provide the Searcher interface.
 // Thread-safe for concurrent use.
 type BM25Searcher struct {
-	store store.BM25Index
-	mu    sync.RWMutex
+	store   store.BM25Index
+	boolean bool
+	mu      sync.RWMutex
 }
 
 // BM25Option configures BM25Searcher.
@@ -27,9 +28,22 @@ func WithBM25Store(s store.BM25Index) BM25Option {
 	}
 }
 
+// WithBooleanQueries makes Search parse queries as boolean expressions:
+// terms joined by AND, OR and NOT, grouped with parentheses, for example
+// "error AND handler NOT test". Matching documents are still ranked by BM25
+// score, so results fuse like plain ones. See store.BooleanQuery for syntax
+// and precedence. The store must implement store.BooleanSearcher.
+func WithBooleanQueries() BM25Option {
+	return func(searcher *BM25Searcher) {
+		searcher.boolean = true
+	}
+}
+
 // NewBM25Searcher creates a new BM25 searcher.
 //
-// Requires WithBM25Store option. Returns ErrNilBM25Store if store is nil.
+// Requires WithBM25Store option. Returns ErrNilBM25Store if store is nil,
+// and ErrBooleanUnsupported if WithBooleanQueries is set and the store
+// cannot evaluate boolean queries.
 func NewBM25Searcher(opts ...BM25Option) (*BM25Searcher, error) {
 	s := &BM25Searcher{}
 
@@ -40,19 +54,24 @@ func NewBM25Searcher(opts ...BM25Option) (*BM25Searcher, error) {
 	if s.store == nil {
 		return nil, ErrNilBM25Store
 	}
+	if _, ok := s.store.(store.BooleanSearcher); s.boolean && !ok {
+		return nil, ErrBooleanUnsupported
+	}
 
 	return s, nil
 }
 
 // Search executes a BM25 search and returns ranked results.
 //
-// The query is passed directly to the BM25 index.
+// The query is passed directly to the BM25 index, or parsed first when
+// boolean queries are enabled; a malformed boolean query returns an error
+// wrapping store.ErrInvalidBooleanQuery.
 // Returns an empty slice if no results match.
 func (s *BM25Searcher) Search(ctx context.Context, query string, limit int) ([]Result, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	bm25Results, err := s.store.Search(ctx, query, limit)
+	bm25Results, err := s.search(ctx, query, limit)
 	if err != nil {
 		return nil, fmt.Errorf("BM25 search failed: %w", err)
 	}
@@ -69,3 +88,14 @@ func (s *BM25Searcher) Search(ctx context.Context, query string, limit int) ([]R
 
 	return results, nil
 }
+
+func (s *BM25Searcher) search(ctx context.Context, query string, limit int) ([]*store.BM25Result, error) {
+	if !s.boolean {
+		return s.store.Search(ctx, query, limit)
+	}
+	q, err := store.ParseBooleanQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.(store.BooleanSearcher).SearchBoolean(ctx, q, limit)
+}
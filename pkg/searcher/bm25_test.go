@@ -202,6 +202,75 @@ func TestBM25Searcher_Search_ZeroLimit(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Boolean Query Tests
+// =============================================================================
+
+func TestBM25Searcher_BooleanQueries_SQLiteStore(t *testing.T) {
+	// Given: A SQLite BM25 index and a searcher with boolean queries enabled
+	idx, err := store.NewSQLiteBM25Index("", store.DefaultBM25Config())
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+	defer func() { _ = idx.Close() }()
+	err = idx.Index(context.Background(), []*store.Document{
+		{ID: "handler", Content: "errorHandler handles request errors"},
+		{ID: "handler_test", Content: "errorHandler test covers request errors"},
+	})
+	if err != nil {
+		t.Fatalf("failed to index: %v", err)
+	}
+	s, err := NewBM25Searcher(WithBM25Store(idx), WithBooleanQueries())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// When: Searching with NOT
+	results, err := s.Search(context.Background(), "error AND handler NOT test", 10)
+
+	// Then: The negated document is excluded
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "handler" {
+		t.Errorf("expected only handler, got %+v", results)
+	}
+}
+
+func TestBM25Searcher_BooleanQueries_InvalidQuery(t *testing.T) {
+	// Given: A searcher with boolean queries enabled
+	idx, err := store.NewSQLiteBM25Index("", store.DefaultBM25Config())
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+	defer func() { _ = idx.Close() }()
+	s, _ := NewBM25Searcher(WithBM25Store(idx), WithBooleanQueries())
+
+	// When: Searching with unbalanced parentheses
+	_, err = s.Search(context.Background(), "(error OR handler", 10)
+
+	// Then: The parse error is returned
+	if !errors.Is(err, store.ErrInvalidBooleanQuery) {
+		t.Errorf("expected ErrInvalidBooleanQuery, got %v", err)
+	}
+}
+
+func TestNewBM25Searcher_BooleanQueries_UnsupportedStore(t *testing.T) {
+	// Given: A store without boolean support
+	mockStore := &MockBM25Store{}
+
+	// When: Enabling boolean queries
+	s, err := NewBM25Searcher(WithBM25Store(mockStore), WithBooleanQueries())
+
+	// Then: ErrBooleanUnsupported
+	if !errors.Is(err, ErrBooleanUnsupported) {
+		t.Errorf("expected ErrBooleanUnsupported, got %v", err)
+	}
+	if s != nil {
+		t.Error("expected nil searcher")
+	}
+}
+
 // =============================================================================
 // Concurrency Tests
 // =============================================================================
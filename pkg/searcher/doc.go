@@ -57,6 +57,20 @@
 //	    // No vector searcher = BM25-only mode
 //	)
 //
+// # Boolean Queries
+//
+// For grep-like control over the lexical candidates, enable boolean queries
+// on a SQLite BM25 index:
+//
+//	bm25, _ := searcher.NewBM25Searcher(
+//	    searcher.WithBM25Store(bm25Index),
+//	    searcher.WithBooleanQueries(),
+//	)
+//	results, err := bm25.Search(ctx, "error AND handler NOT test", 10)
+//
+// NOT binds tightest, then AND, then OR. The matching documents keep their
+// BM25 scores and fuse like any other BM25 results.
+//
 // # Thread Safety
 //
 // All Searcher implementations are safe for concurrent use.
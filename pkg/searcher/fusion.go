@@ -7,9 +7,12 @@ import (
 	"sync"
 
 	"golang.org/x/sync/errgroup"
+
+	"github.com/Aman-CERP/amanmcp/internal/store"
 )
 
-// FusionSearcher combines multiple searchers using Reciprocal Rank Fusion (RRF).
+// FusionSearcher combines multiple searchers using a FusionStrategy
+// (Reciprocal Rank Fusion by default).
 //
 // Supports three modes:
 //   - Hybrid: Both BM25 and Vector searchers (full fusion)
@@ -141,13 +144,39 @@ func (f *FusionSearcher) hybridSearch(ctx context.Context, query string, limit i
 		return truncateResults(bm25Results, limit), nil
 	}
 
-	// Fuse results using RRF
+	// Fuse results using the configured strategy
 	fused := f.fuseResults(bm25Results, vectorResults)
 
 	return truncateResults(fused, limit), nil
 }
 
-// fusedScore tracks score accumulation during RRF fusion.
+// fuseResults combines result lists using the configured fusion strategy.
+// A nil FusionConfig.Strategy selects RRF.
+func (f *FusionSearcher) fuseResults(bm25Results, vectorResults []Result) []Result {
+	strategy := f.config.Strategy
+	if strategy == nil {
+		strategy = RRFFusion{}
+	}
+	return strategy.Fuse(bm25Results, vectorResults, f.config)
+}
+
+// FusionStrategy combines ranked BM25 and vector result lists into one ranking.
+//
+// RRFFusion (default) is rank-based and robust to incompatible score scales.
+// WeightedScoreFusion preserves score magnitudes, so a dominant hit keeps its
+// lead, at the cost of sensitivity to outliers and per-corpus weight tuning.
+type FusionStrategy interface {
+	// Fuse returns the combined results sorted by descending score.
+	Fuse(bm25Results, vectorResults []Result, config FusionConfig) []Result
+}
+
+// RRFFusion implements Reciprocal Rank Fusion.
+//
+// RRF formula: score(d) = Σ weight_i / (k + rank_i)
+// Where k is the smoothing constant and rank is 1-indexed.
+type RRFFusion struct{}
+
+// fusedScore tracks score accumulation during fusion.
 type fusedScore struct {
 	ID           string
 	Score        float64
@@ -155,16 +184,13 @@ type fusedScore struct {
 	InBoth       bool
 }
 
-// fuseResults applies Reciprocal Rank Fusion to combine result lists.
-//
-// RRF formula: score(d) = Σ weight_i / (k + rank_i)
-// Where k is the smoothing constant and rank is 1-indexed.
-func (f *FusionSearcher) fuseResults(bm25Results, vectorResults []Result) []Result {
+// Fuse applies Reciprocal Rank Fusion to combine result lists.
+func (RRFFusion) Fuse(bm25Results, vectorResults []Result, config FusionConfig) []Result {
 	scores := make(map[string]*fusedScore)
 
 	// Process BM25 results
 	for rank, r := range bm25Results {
-		rrfScore := f.config.BM25Weight / float64(f.config.RRFConstant+rank+1)
+		rrfScore := config.BM25Weight / float64(config.RRFConstant+rank+1)
 		scores[r.ID] = &fusedScore{
 			ID:           r.ID,
 			Score:        rrfScore,
@@ -174,7 +200,7 @@ func (f *FusionSearcher) fuseResults(bm25Results, vectorResults []Result) []Resu
 
 	// Process Vector results
 	for rank, r := range vectorResults {
-		rrfScore := f.config.SemanticWeight / float64(f.config.RRFConstant+rank+1)
+		rrfScore := config.SemanticWeight / float64(config.RRFConstant+rank+1)
 		if existing, ok := scores[r.ID]; ok {
 			existing.Score += rrfScore
 			existing.InBoth = true
@@ -186,7 +212,75 @@ func (f *FusionSearcher) fuseResults(bm25Results, vectorResults []Result) []Resu
 		}
 	}
 
-	// Convert to slice and sort by score
+	return sortFusedScores(scores)
+}
+
+// ScoreNormalization selects how WeightedScoreFusion rescales each list.
+type ScoreNormalization string
+
+const (
+	// NormalizeMinMax maps each list to [0, 1] via (s - min) / (max - min).
+	NormalizeMinMax ScoreNormalization = "minmax"
+
+	// NormalizeZScore standardizes each list via (s - mean) / stddev, shifted
+	// so the lowest score in the list is 0.
+	NormalizeZScore ScoreNormalization = "zscore"
+)
+
+// WeightedScoreFusion combines result lists by normalizing each list's scores
+// and taking a weighted sum:
+//
+//	score(d) = BM25Weight * norm_bm25(d) + SemanticWeight * norm_vec(d)
+//
+// Documents missing from a list contribute 0 for that source.
+// Empty Normalization defaults to min-max.
+type WeightedScoreFusion struct {
+	Normalization ScoreNormalization
+}
+
+// Fuse applies weighted score fusion to combine result lists.
+func (w WeightedScoreFusion) Fuse(bm25Results, vectorResults []Result, config FusionConfig) []Result {
+	scores := make(map[string]*fusedScore)
+
+	bm25Norm := w.normalize(bm25Results)
+	for i, r := range bm25Results {
+		scores[r.ID] = &fusedScore{
+			ID:           r.ID,
+			Score:        config.BM25Weight * bm25Norm[i],
+			MatchedTerms: r.MatchedTerms,
+		}
+	}
+
+	vecNorm := w.normalize(vectorResults)
+	for i, r := range vectorResults {
+		score := config.SemanticWeight * vecNorm[i]
+		if existing, ok := scores[r.ID]; ok {
+			existing.Score += score
+			existing.InBoth = true
+		} else {
+			scores[r.ID] = &fusedScore{
+				ID:    r.ID,
+				Score: score,
+			}
+		}
+	}
+
+	return sortFusedScores(scores)
+}
+
+// normalize rescales a list's scores to non-negative values (see
+// store.NormalizeScores).
+func (w WeightedScoreFusion) normalize(results []Result) []float64 {
+	raw := make([]float64, len(results))
+	for i, r := range results {
+		raw[i] = r.Score
+	}
+	return store.NormalizeScores(raw, w.Normalization == NormalizeZScore)
+}
+
+// sortFusedScores converts accumulated scores to results sorted by descending
+// score, breaking ties by ID for deterministic ordering.
+func sortFusedScores(scores map[string]*fusedScore) []Result {
 	results := make([]Result, 0, len(scores))
 	for _, s := range scores {
 		results = append(results, Result{
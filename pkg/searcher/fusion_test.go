@@ -3,6 +3,7 @@ package searcher
 import (
 	"context"
 	"errors"
+	"math"
 	"sync/atomic"
 	"testing"
 )
@@ -597,3 +598,82 @@ func TestFusionSearcher_ImplementsSearcher(t *testing.T) {
 }
 
 var _ Searcher = (*FusionSearcher)(nil)
+
+// =============================================================================
+// Fusion Strategy Tests
+// =============================================================================
+
+func TestFusionSearcher_Search_WeightedScoreStrategy(t *testing.T) {
+	// Given: BM25 has a dominant hit A; vector slightly prefers B
+	bm25 := &MockSearcher{
+		SearchFn: func(ctx context.Context, query string, limit int) ([]Result, error) {
+			return []Result{
+				{ID: "A", Score: 20.0, MatchedTerms: []string{"auth"}},
+				{ID: "B", Score: 1.0},
+				{ID: "C", Score: 0.5},
+			}, nil
+		},
+	}
+	vector := &MockSearcher{
+		SearchFn: func(ctx context.Context, query string, limit int) ([]Result, error) {
+			return []Result{
+				{ID: "B", Score: 0.81},
+				{ID: "A", Score: 0.80},
+				{ID: "C", Score: 0.10},
+			}, nil
+		},
+	}
+	config := FusionConfig{
+		BM25Weight:     0.5,
+		SemanticWeight: 0.5,
+		Strategy:       WeightedScoreFusion{Normalization: NormalizeMinMax},
+	}
+	s, err := NewFusionSearcher(
+		WithBM25Searcher(bm25),
+		WithVectorSearcher(vector),
+		WithFusionConfig(config),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// When: Searching
+	results, err := s.Search(context.Background(), "test", 10)
+
+	// Then: A leads with the weighted sum of normalized scores
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].ID != "A" {
+		t.Errorf("expected A first, got %s", results[0].ID)
+	}
+	// A = 0.5*1.0 + 0.5*(0.70/0.71)
+	if math.Abs(results[0].Score-(0.5+0.5*0.70/0.71)) > 1e-6 {
+		t.Errorf("unexpected score for A: %f", results[0].Score)
+	}
+	if len(results[0].MatchedTerms) != 1 {
+		t.Errorf("expected matched terms preserved, got %v", results[0].MatchedTerms)
+	}
+	if results[2].ID != "C" || results[2].Score != 0 {
+		t.Errorf("expected C last with score 0, got %s=%f", results[2].ID, results[2].Score)
+	}
+}
+
+func TestWeightedScoreFusion_ZScore_UniformScores(t *testing.T) {
+	// Given: a list where every score is equal
+	results := []Result{{ID: "A", Score: 0.5}, {ID: "B", Score: 0.5}}
+
+	// When: fusing with z-score normalization
+	fused := WeightedScoreFusion{Normalization: NormalizeZScore}.Fuse(results, nil, DefaultFusionConfig())
+
+	// Then: both keep the full BM25 weight and order falls back to ID
+	if len(fused) != 2 || fused[0].ID != "A" || fused[1].ID != "B" {
+		t.Fatalf("unexpected results: %+v", fused)
+	}
+	if fused[0].Score != DefaultFusionConfig().BM25Weight {
+		t.Errorf("expected score %f, got %f", DefaultFusionConfig().BM25Weight, fused[0].Score)
+	}
+}
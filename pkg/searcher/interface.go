@@ -8,6 +8,10 @@ import (
 // ErrNilBM25Store is returned when attempting to create a BM25Searcher without a store.
 var ErrNilBM25Store = errors.New("BM25 store is required")
 
+// ErrBooleanUnsupported is returned when boolean queries are enabled on a
+// BM25Searcher whose store does not implement store.BooleanSearcher.
+var ErrBooleanUnsupported = errors.New("BM25 store does not support boolean queries")
+
 // ErrNilEmbedder is returned when attempting to create a VectorSearcher without an embedder.
 var ErrNilEmbedder = errors.New("embedder is required")
 
@@ -47,7 +51,7 @@ type Result struct {
 	MatchedTerms []string
 }
 
-// FusionConfig configures the RRF fusion algorithm.
+// FusionConfig configures result fusion.
 type FusionConfig struct {
 	// BM25Weight is the weight for BM25 results in fusion.
 	// Default: 0.35
@@ -60,6 +64,10 @@ type FusionConfig struct {
 	// RRFConstant is the smoothing constant for RRF.
 	// Default: 60
 	RRFConstant int
+
+	// Strategy combines the BM25 and vector result lists.
+	// Default: nil (RRFFusion).
+	Strategy FusionStrategy
 }
 
 // DefaultFusionConfig returns the default fusion configuration.